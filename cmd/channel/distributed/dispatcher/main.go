@@ -17,18 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/controller"
@@ -65,7 +74,7 @@ func main() {
 	defer flush(logger)
 
 	// UnComment To Enable Sarama Logging For Local Debug
-	// sarama.EnableSaramaLogging()
+	// kafkasarama.EnableSaramaLogging()
 
 	// Load Environment Variables
 	environment, err := env.GetEnvironment(logger)
@@ -73,14 +82,172 @@ func main() {
 		logger.Fatal("Failed To Load Environment Variables - Terminating!", zap.Error(err))
 	}
 
-	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap
-	saramaConfig, _, err := sarama.LoadSettings(ctx)
+	// Start The Liveness And Readiness Servers (Not Ready Until The Dispatcher Has Started)
+	healthServer := dispatcherhealth.NewDispatcherHealthServer(strconv.Itoa(environment.HealthPort))
+	healthServer.Start(logger)
+
+	// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap (Optionally Waiting For It To Exist)
+	saramaConfig, ekConfig := loadSettings(ctx, environment, healthServer)
+
+	// Validate The Policy For Handling Messages Which Cannot Be Deserialized Into CloudEvents
+	deserializationFailurePolicy, err := dispatch.ParseDeserializationFailurePolicy(ekConfig.Dispatcher.DeserializationFailurePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Tombstone Records (Null Values) On Compacted Topics
+	tombstonePolicy, err := dispatch.ParseTombstonePolicy(ekConfig.Dispatcher.TombstonePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Record Batches Which Cannot Be Decompressed (Corrupt Data)
+	decompressionFailurePolicy, err := dispatch.ParseDecompressionFailurePolicy(ekConfig.Dispatcher.DecompressionFailurePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Multiple Subscribers With The Same UID
+	duplicateSubscriberPolicy, err := dispatch.ParseDuplicateSubscriberPolicy(ekConfig.Dispatcher.DuplicateSubscriberPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Subscribers Without A SubscriberURI
+	missingSubscriberPolicy, err := dispatch.ParseMissingSubscriberPolicy(ekConfig.Dispatcher.MissingSubscriberPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Deriving Each Subscriber's ConsumerGroup ID
+	groupIdPolicy, err := dispatch.ParseGroupIdPolicy(ekConfig.Dispatcher.GroupIdPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+	groupIdSanitizationPolicy, err := dispatch.ParseGroupIdSanitizationPolicy(ekConfig.Dispatcher.GroupIdSanitizationPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Messages Exceeding The Maximum Message Size
+	oversizedMessagePolicy, err := dispatch.ParseOversizedMessagePolicy(ekConfig.Dispatcher.OversizedMessagePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling The Kafka Brokers Being Unreachable At Startup
+	brokerUnavailablePolicy, err := dispatcherhealth.ParseBrokerUnavailablePolicy(ekConfig.Dispatcher.BrokerUnavailablePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Predicate Identifying Successful Subscriber Responses Which Describe A Failure
+	successPredicate, err := dispatch.NewSuccessPredicate(ekConfig.Dispatcher.SuccessPredicate)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Allow / Deny List Of CloudEvent Extensions Dispatched To Subscribers
+	extensionFilter, err := dispatch.NewExtensionFilter(ekConfig.Dispatcher.Extensions)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Determine How Long Closing Each Subscriber's ConsumerGroup Waits For Its Consume Loop To Exit (Defaulted If Unspecified)
+	shutdownTimeout := time.Duration(ekConfig.Dispatcher.ShutdownTimeoutMillis) * time.Millisecond
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = constants.DefaultShutdownTimeoutMillis * time.Millisecond
+	}
+
+	// Determine How Long The Dispatcher May Drain After A Termination Signal Before Exiting Within Its Grace Period
+	drainTimeout, err := dispatch.ParseDrainTimeout(environment.TerminationGracePeriodSeconds, ekConfig.Dispatcher.DrainMarginMillis)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Determine The Intervals Before Retrying A Failed Consume() (Defaulted If Unspecified)
+	coordinatorRetryInterval := time.Duration(ekConfig.Dispatcher.CoordinatorRetryIntervalMillis) * time.Millisecond
+	if coordinatorRetryInterval <= 0 {
+		coordinatorRetryInterval = constants.DefaultCoordinatorRetryIntervalMillis * time.Millisecond
+	}
+	consumeRetryInterval := time.Duration(ekConfig.Dispatcher.ConsumeRetryIntervalMillis) * time.Millisecond
+	if consumeRetryInterval <= 0 {
+		consumeRetryInterval = constants.DefaultConsumeRetryIntervalMillis * time.Millisecond
+	}
+
+	// Validate The Overload Pause Threshold & Determine The Cooldown (Defaulted If Unspecified)
+	overloadPauseCooldown, err := dispatch.ParseOverloadPause(ekConfig.Dispatcher.OverloadPauseThreshold, ekConfig.Dispatcher.OverloadPauseCooldownMillis)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Retry Budget Of Subscribers Favoring Progress Over Ordering (Defaulted If Unspecified)
+	retryBudget, err := dispatch.ParseRetryBudget(ekConfig.Dispatcher.RetryBudgetMillis)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Replies Which Cannot Be Delivered & Determine The Reply Retry Interval (Defaulted If Unspecified)
+	replyFailurePolicy, err := dispatch.ParseReplyFailurePolicy(ekConfig.Dispatcher.ReplyFailurePolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+	replyRetryInterval, err := dispatch.ParseReplyRetry(ekConfig.Dispatcher.ReplyRetryMax, ekConfig.Dispatcher.ReplyRetryIntervalMillis)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Handling Subscriber Redirects & The Maximum Number Followed (Defaulted If Unspecified)
+	redirectPolicy, err := dispatch.ParseRedirectPolicy(ekConfig.Dispatcher.RedirectPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+	redirectMax, err := dispatch.ParseRedirectMax(ekConfig.Dispatcher.RedirectMax)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Sampling Of Repeated Subscriber Delivery Error Logs
+	errorLogSampling, err := dispatch.NewErrorLogSampling(ekConfig.Dispatcher.ErrorLogSampling)
+	if err != nil {
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Sampling Of Repeated ConsumerGroup Error Logs
+	groupErrorLogSampling, err := dispatch.NewErrorLogSampling(ekConfig.Dispatcher.GroupErrorLogSampling)
 	if err != nil {
-		logger.Fatal("Failed To Load Sarama Settings", zap.Error(err))
+		logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
 	}
 
 	// Update The Sarama Config - Username/Password Overrides (EnvVars From Secret Take Precedence Over ConfigMap)
-	sarama.UpdateSaramaConfig(saramaConfig, constants.Component, environment.KafkaUsername, environment.KafkaPassword)
+	kafkasarama.UpdateSaramaConfig(saramaConfig, constants.Component, environment.KafkaUsername, environment.KafkaPassword)
+
+	// Update The Sarama Config - SASL Mechanism Override (EnvVar From Secret Takes Precedence Over ConfigMap)
+	err = kafkasarama.UpdateSaramaSaslMechanism(saramaConfig, environment.KafkaSaslMechanism)
+	if err != nil {
+		logger.Fatal("Invalid Kafka SASL Mechanism - Terminating", zap.Error(err))
+	}
+
+	// Update The Sarama Config - Client Rack For Rack-Aware Fetching (Possibly From The Node's Topology Label)
+	err = kafkasarama.UpdateSaramaRackId(ctx, saramaConfig, ekConfig.Kafka.ClientRack, environment.NodeName)
+	if err != nil {
+		logger.Fatal("Failed To Determine The Kafka Client Rack - Terminating", zap.Error(err))
+	}
+	if len(saramaConfig.RackID) > 0 {
+		logger.Info("Kafka Client Rack Configured", zap.String("RackID", saramaConfig.RackID), zap.String("Version", saramaConfig.Version.String()))
+	}
+
+	// Create The (Optional) Cache Of The ConsumerGroups' Committed Offsets Shared By Features Fetching Them Repeatedly
+	offsetCache := dispatcherhealth.NewConsumerGroupOffsetCache(time.Duration(ekConfig.Dispatcher.OffsetCacheTTLMillis) * time.Millisecond)
+
+	// Validate The (Optional) Target Lag Of The Scaling Signal Exposed For Autoscalers (After The Sarama Config Is Complete)
+	var lagScaler *dispatcherhealth.LagScaler
+	if ekConfig.Dispatcher.Scaling.TargetLag != 0 {
+		lagScaler, err = dispatcherhealth.NewLagScaler(logger, strings.Split(environment.KafkaBrokers, ","), saramaConfig, environment.KafkaTopic, ekConfig.Dispatcher.Scaling.TargetLag, func() []string { return dispatcher.ConsumerGroupIds() }, offsetCache)
+		if err != nil {
+			logger.Fatal("Invalid Dispatcher Configuration - Terminating", zap.Error(err))
+		}
+	}
 
 	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
 	err = commonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName)
@@ -94,10 +261,6 @@ func main() {
 		logger.Fatal("Failed To Initialize Observability - Terminating", zap.Error(err))
 	}
 
-	// Start The Liveness And Readiness Servers
-	healthServer := dispatcherhealth.NewDispatcherHealthServer(strconv.Itoa(environment.HealthPort))
-	healthServer.Start(logger)
-
 	statsReporter := metrics.NewStatsReporter(logger)
 
 	// Create The Dispatcher With Specified Configuration
@@ -111,9 +274,76 @@ func main() {
 		ChannelKey:    environment.ChannelKey,
 		StatsReporter: statsReporter,
 		SaramaConfig:  saramaConfig,
+
+		SubscriptionSnapshotPath:     environment.SubscriptionSnapshotPath,
+		SubscriptionSnapshotMaxAge:   time.Duration(ekConfig.Dispatcher.SubscriptionSnapshot.MaxAgeMillis) * time.Millisecond,
+		DeserializationFailurePolicy: deserializationFailurePolicy,
+		TombstonePolicy:              tombstonePolicy,
+		DecompressionFailurePolicy:   decompressionFailurePolicy,
+		DuplicateSubscriberPolicy:    duplicateSubscriberPolicy,
+		MissingSubscriberPolicy:      missingSubscriberPolicy,
+		GroupIdPolicy:                groupIdPolicy,
+		GroupIdKey:                   ekConfig.Dispatcher.GroupIdKey,
+		GroupIdSanitizationPolicy:    groupIdSanitizationPolicy,
+		MaxMessageBytes:              ekConfig.Dispatcher.MaxMessageBytes,
+		OversizedMessagePolicy:       oversizedMessagePolicy,
+		StripProvenanceHeaders:       ekConfig.Dispatcher.StripProvenanceHeaders,
+		CommitBatchSize:              ekConfig.Dispatcher.CommitBatchSize,
+		CommitBatchInterval:          time.Duration(ekConfig.Dispatcher.CommitBatchIntervalMillis) * time.Millisecond,
+		CommitOnRebalance:            ekConfig.Dispatcher.CommitOnRebalance,
+		SchemaRegistryFraming:        len(ekConfig.Kafka.SchemaRegistry.Url) > 0,
+		SessionLivenessTimeout:       time.Duration(ekConfig.Dispatcher.SessionLivenessTimeoutMillis) * time.Millisecond,
+		ShutdownTimeout:              shutdownTimeout,
+		MaxDeliveryConcurrency:       ekConfig.Dispatcher.MaxDeliveryConcurrency,
+		ConsumerGroupEventInterval:   time.Duration(ekConfig.Dispatcher.ConsumerGroupEventIntervalMillis) * time.Millisecond,
+		ConsumerLagInterval:          time.Duration(ekConfig.Dispatcher.ConsumerLagIntervalMillis) * time.Millisecond,
+		OnRebalance:                  offsetCache.Invalidate,
+		SuccessPredicate:             successPredicate,
+		OverloadPauseThreshold:       ekConfig.Dispatcher.OverloadPauseThreshold,
+		OverloadPauseCooldown:        overloadPauseCooldown,
+		RetryBudget:                  retryBudget,
+		ErrorLogSampling:             errorLogSampling,
+		GroupErrorLogSampling:        groupErrorLogSampling,
+		CoordinatorRetryInterval:     coordinatorRetryInterval,
+		ConsumeRetryInterval:         consumeRetryInterval,
+		ReplyFailurePolicy:           replyFailurePolicy,
+		ReplyRetryMax:                ekConfig.Dispatcher.ReplyRetryMax,
+		ReplyRetryInterval:           replyRetryInterval,
+		RedirectPolicy:               redirectPolicy,
+		RedirectMax:                  redirectMax,
+		ExtensionFilter:              extensionFilter,
+		Shard:                        environment.Shard,
+	}
+
+	// Verify The Kafka Brokers Can Be Reached (Either Terminating Or Reporting Not Ready Until They Can, Depending On Policy)
+	healthServer.SetAlive(true)
+	brokerRetryInterval := time.Duration(ekConfig.Dispatcher.BrokerRetryIntervalMillis) * time.Millisecond
+	if brokerRetryInterval <= 0 {
+		brokerRetryInterval = constants.DefaultBrokerRetryIntervalMillis * time.Millisecond
+	}
+	err = dispatcherhealth.CheckBrokers(ctx, logger, dispatcherConfig.Brokers, saramaConfig, brokerUnavailablePolicy, brokerRetryInterval, healthServer)
+	if err != nil {
+		logger.Fatal("Kafka Brokers Unavailable - Terminating", zap.Error(err))
+	}
+
+	// Optionally Wait For The Kafka Topic To Exist Before Joining Any ConsumerGroups (Avoids UnknownTopicOrPartition Noise)
+	if ekConfig.Dispatcher.TopicWaitTimeoutMillis > 0 {
+		waitForTopic(ctx, dispatcherConfig, ekConfig.Dispatcher, healthServer)
 	}
+
 	dispatcher = dispatch.NewDispatcher(dispatcherConfig)
 
+	// Expose The Scaling Signal Now That The Dispatcher's ConsumerGroups Can Be Queried
+	if lagScaler != nil {
+		healthServer.HandleFunc(constants.ScalingPath, lagScaler.HandleScaling)
+	}
+
+	// Optimistically Resume Any Previously Snapshot Subscriptions (Reconciled Once The KafkaChannel Informer Syncs)
+	failedSubscriptions := dispatcher.RestoreSubscriptionSnapshot()
+	if len(failedSubscriptions) > 0 {
+		logger.Warn("Failed To Restore Some Snapshot Subscriptions", zap.Int("Count", len(failedSubscriptions)))
+	}
+
 	// Watch The Settings ConfigMap For Changes
 	err = commonconfig.InitializeConfigWatcher(ctx, logger.Sugar(), configMapObserver)
 	if err != nil {
@@ -135,6 +365,18 @@ func main() {
 	// Create KafkaChannel Informer
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
 
+	// Create Subscriber TLS Secret Informer (Limited To Labelled Secrets In The KafkaChannel's Namespace)
+	channelNamespace, _, err := cache.SplitMetaNamespaceKey(environment.ChannelKey)
+	if err != nil {
+		logger.Fatal("Invalid ChannelKey", zap.String("ChannelKey", environment.ChannelKey), zap.Error(err))
+	}
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, kncontroller.DefaultResyncPeriod,
+		kubeinformers.WithNamespace(channelNamespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = constants.SubscriberTLSSecretLabel
+		}))
+	secretInformer := kubeInformerFactory.Core().V1().Secrets()
+
 	// Construct Array Of Controllers, In Our Case Just The One
 	controllers := [...]*kncontroller.Impl{
 		controller.NewController(
@@ -142,6 +384,7 @@ func main() {
 			environment.ChannelKey,
 			dispatcher,
 			kafkaChannelInformer,
+			secretInformer,
 			kubeClient,
 			kafkaClientSet,
 			ctx.Done(),
@@ -150,30 +393,103 @@ func main() {
 
 	// Start The Informers
 	logger.Info("Starting informers.")
-	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer()); err != nil {
+	if err := kncontroller.StartInformers(ctx.Done(), kafkaChannelInformer.Informer(), secretInformer.Informer()); err != nil {
 		logger.Error("Failed to start informers", zap.Error(err))
 		return
 	}
 
+	// Optionally Start Periodically Verifying The Kafka Credentials (Clears Readiness When Rejected By The Brokers)
+	var authChecker *dispatcherhealth.AuthChecker
+	if ekConfig.Dispatcher.AuthCheckIntervalMillis > 0 {
+		authCheckInterval := time.Duration(ekConfig.Dispatcher.AuthCheckIntervalMillis) * time.Millisecond
+		authChecker = dispatcherhealth.NewAuthChecker(logger, dispatcherConfig.Brokers, saramaConfig, authCheckInterval, healthServer, statsReporter)
+		authChecker.Start()
+	}
+
 	// Set The Liveness And Readiness Flags
 	logger.Info("Registering dispatcher as alive and ready")
 	healthServer.SetAlive(true)
 	healthServer.SetDispatcherReady(true)
 
+	// Begin Draining The Dispatcher As Soon As A Termination Signal Is Received (While The Controllers Stop)
+	drainSignals := make(chan os.Signal, 1)
+	signal.Notify(drainSignals, os.Interrupt, syscall.SIGTERM)
+	drainer := dispatch.NewDrainer(logger, func() dispatch.Dispatcher { return dispatcher }, drainTimeout, func() {
+
+		// Stop Any Authentication Checks & Reset The Liveness and Readiness Flags In Preparation For Shutdown
+		if authChecker != nil {
+			authChecker.Stop()
+		}
+		healthServer.Shutdown()
+	})
+	drainer.Start(drainSignals)
+
 	// Start The Controllers (Blocking WaitGroup.Wait Call)
 	logger.Info("Starting controllers.")
 	kncontroller.StartAll(ctx, controllers[:]...)
 
-	// Reset The Liveness and Readiness Flags In Preparation For Shutdown
-	healthServer.Shutdown()
-
-	// Shutdown The Dispatcher (Close ConsumerGroups)
-	dispatcher.Shutdown()
+	// Wait For The Dispatcher To Drain (Close ConsumerGroups), Draining Now If Not Already Triggered By A Signal
+	drainer.Drain()
 
 	// Stop The Liveness And Readiness Servers
 	healthServer.Stop(logger)
 }
 
+// Load The Sarama & Eventing-Kafka Configuration From The ConfigMap - If A Wait Timeout Is Configured Then A Missing
+// ConfigMap Is Waited For (Reporting Live But Not Ready) Rather Than Terminating Immediately, So That The Dispatcher
+// Never Runs Without Its Configuration
+func loadSettings(ctx context.Context, environment *env.Environment, healthServer *dispatcherhealth.Server) (*sarama.Config, *commonconfig.EventingKafkaConfig) {
+
+	// Load The Settings Immediately If No Wait Timeout Is Configured
+	if environment.ConfigMapWaitTimeoutMillis <= 0 {
+		saramaConfig, ekConfig, err := kafkasarama.LoadSettings(ctx)
+		if err != nil {
+			logger.Fatal("Failed To Load Sarama Settings", zap.Error(err))
+		}
+		return saramaConfig, ekConfig
+	}
+
+	// Otherwise Wait For The ConfigMap Until The Timeout (Remaining Live So As Not To Be Restarted While Waiting)
+	timeout := time.Duration(environment.ConfigMapWaitTimeoutMillis) * time.Millisecond
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	healthServer.SetAlive(true)
+	saramaConfig, ekConfig, err := kafkasarama.WaitForSettings(timeoutCtx, logger, constants.ConfigMapWaitIntervalMillis*time.Millisecond)
+	if err != nil {
+		logger.Fatal("Failed To Load Sarama Settings Within The ConfigMap Wait Timeout - Terminating", zap.Duration("Timeout", timeout), zap.Error(err))
+	}
+	return saramaConfig, ekConfig
+}
+
+// Wait (Up To The Configured Timeout) For The Kafka Topic To Exist - If It Does Not Then Startup Continues With The
+// Dispatcher Reporting Not Ready Until The Topic Is Eventually Found By A Background Go Routine
+func waitForTopic(ctx context.Context, dispatcherConfig dispatch.DispatcherConfig, ekDispatcherConfig commonconfig.EKDispatcherConfig, healthServer *dispatcherhealth.Server) {
+
+	// Determine The Timeout & Poll Interval
+	timeout := time.Duration(ekDispatcherConfig.TopicWaitTimeoutMillis) * time.Millisecond
+	interval := time.Duration(ekDispatcherConfig.TopicWaitIntervalMillis) * time.Millisecond
+	if interval <= 0 {
+		interval = constants.DefaultTopicWaitIntervalMillis * time.Millisecond
+	}
+
+	// Wait For The Topic Until The Timeout
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := dispatcherhealth.WaitForTopic(timeoutCtx, logger, dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig, dispatcherConfig.Topic, interval)
+	if err == nil {
+		return
+	}
+
+	// Report Not Ready With The Reason & Keep Waiting In The Background
+	logger.Error("Timed Out Waiting For Kafka Topic - Dispatcher Will Report Not Ready Until It Exists", zap.Duration("Timeout", timeout), zap.Error(err))
+	healthServer.SetTopicAvailable(false)
+	go func() {
+		if dispatcherhealth.WaitForTopic(ctx, logger, dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig, dispatcherConfig.Topic, interval) == nil {
+			healthServer.SetTopicAvailable(true)
+		}
+	}()
+}
+
 func flush(logger *zap.Logger) {
 	_ = logger.Sync()
 	eventingmetrics.FlushExporter()
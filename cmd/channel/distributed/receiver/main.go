@@ -22,6 +22,7 @@ import (
 	nethttp "net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
@@ -34,19 +35,25 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/channel"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/eventage"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/heartbeat"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/pathrouting"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/ratelimit"
 	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/logging"
 	eventingmetrics "knative.dev/pkg/metrics"
 )
 
 // Variables
 var (
-	logger        *zap.Logger
-	serverURL     = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
-	kubeconfig    = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
-	kafkaProducer *producer.Producer
+	logger           *zap.Logger
+	serverURL        = flag.String("server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	kubeconfig       = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	kafkaProducer    *producer.Producer
+	rateLimitHandler *ratelimit.Handler
 )
 
 // The Main Function (Go Command)
@@ -72,7 +79,7 @@ func main() {
 	}
 
 	// Load The Sarama (& Eventing-Kafka) Configuration From The ConfigMap
-	saramaConfig, _, err := sarama.LoadSettings(ctx)
+	saramaConfig, ekConfig, err := sarama.LoadSettings(ctx)
 	if err != nil {
 		logger.Fatal("Failed To Load Sarama Settings", zap.Error(err))
 	}
@@ -80,6 +87,12 @@ func main() {
 	// Update The Sarama Config - Username/Password Overrides (EnvVars From Secret Take Precedence Over ConfigMap)
 	sarama.UpdateSaramaConfig(saramaConfig, constants.Component, environment.KafkaUsername, environment.KafkaPassword)
 
+	// Update The Sarama Config - SASL Mechanism Override (EnvVar From Secret Takes Precedence Over ConfigMap)
+	err = sarama.UpdateSaramaSaslMechanism(saramaConfig, environment.KafkaSaslMechanism)
+	if err != nil {
+		logger.Fatal("Invalid Kafka SASL Mechanism - Terminating", zap.Error(err))
+	}
+
 	// Initialize Tracing (Watches config-tracing ConfigMap, Assumes Context Came From LoggingContext With Embedded K8S Client Key)
 	err = commonconfig.InitializeTracing(logger.Sugar(), ctx, environment.ServiceName)
 	if err != nil {
@@ -112,8 +125,78 @@ func main() {
 		logger.Fatal("Failed To Initialize ConfigMap Watcher", zap.Error(err))
 	}
 
+	// Validate The Policy For Using Received Events' "partitionkey" Extension As The Kafka Record Key
+	partitionKeyPolicy, err := producer.ParsePartitionKeyPolicy(ekConfig.Receiver.PartitionKeyPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Policy For Producing Received Events Without Data
+	emptyDataPolicy, err := producer.ParseEmptyDataPolicy(ekConfig.Receiver.EmptyDataPolicy)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Create The Schema Registry Configuration For Framing Produced Message Values (If A Registry Is Configured)
+	schemaRegistryConfig, err := producer.NewSchemaRegistryConfig(ekConfig.Kafka.SchemaRegistry, environment.SchemaRegistryUsername, environment.SchemaRegistryPassword)
+	if err != nil {
+		logger.Fatal("Invalid Schema Registry Configuration - Terminating", zap.Error(err))
+	} else if schemaRegistryConfig.Client != nil {
+		logger.Info("Framing Produced Messages In Schema Registry Wire Format", zap.String("Url", ekConfig.Kafka.SchemaRegistry.Url), zap.String("SchemaType", schemaRegistryConfig.SchemaType))
+	}
+
+	// Validate The Handling Of Events Produced To A KafkaChannel Whose Kafka Topic Does Not Exist
+	unknownTopicConfig, err := producer.NewUnknownTopicConfig(ekConfig.Receiver)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Local Disk Spool Of Events Which Could Not Be Produced
+	spoolConfig, err := producer.NewSpoolConfig(ekConfig.Receiver.Spool)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The Ordering Of Kafka Messages Produced For Concurrently Received Events
+	produceOrdering, err := producer.ParseProduceOrdering(ekConfig.Receiver.ProduceOrdering, spoolConfig)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) External Audit Sink Of Produced Record Metadata
+	auditConfig, err := producer.NewAuditConfig(ekConfig.Receiver.Audit)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Reporting Of Produce Latency Against An SLO
+	latencyConfig, err := producer.NewLatencyConfig(ekConfig.Receiver.ProduceLatency)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
+	// Validate The (Optional) Per-Channel Rate Limits (Which May Also Be Changed Later Via The ConfigMap)
+	rateLimits, err := ratelimit.NewLimits(ekConfig.Receiver.RateLimit)
+	if err != nil {
+		logger.Fatal("Invalid Receiver Configuration - Terminating", zap.Error(err))
+	}
+
 	// Initialize The Kafka Producer In Order To Start Processing Status Events
-	kafkaProducer, err = producer.NewProducer(logger, saramaConfig, strings.Split(environment.KafkaBrokers, ","), statsReporter, healthServer)
+	provenanceConfig := producer.ProvenanceConfig{Enabled: ekConfig.Receiver.ProvenanceHeaders, PodName: environment.PodName}
+	kafkaProducer, err = producer.NewProducer(logger, saramaConfig, producer.ProducerOptions{
+		Brokers:              strings.Split(environment.KafkaBrokers, ","),
+		ProvenanceConfig:     provenanceConfig,
+		PartitionKeyPolicy:   partitionKeyPolicy,
+		EmptyDataPolicy:      emptyDataPolicy,
+		ProduceOrdering:      produceOrdering,
+		SchemaRegistryConfig: schemaRegistryConfig,
+		UnknownTopicConfig:   unknownTopicConfig,
+		SpoolConfig:          spoolConfig,
+		AuditConfig:          auditConfig,
+		LatencyConfig:        latencyConfig,
+		StatsReporter:        statsReporter,
+		HealthServer:         healthServer,
+	})
 	if err != nil {
 		logger.Fatal("Failed To Initialize Kafka Producer", zap.Error(err))
 	}
@@ -125,11 +208,54 @@ func main() {
 		logger.Fatal("Failed To Create MessageReceiver", zap.Error(err))
 	}
 
+	// Optionally Start Periodically Producing Heartbeat Events To The Configured KafkaChannel
+	if ekConfig.Receiver.Heartbeat.IntervalMillis > 0 {
+		heartbeatInterval := time.Duration(ekConfig.Receiver.Heartbeat.IntervalMillis) * time.Millisecond
+		heartbeatEventType := ekConfig.Receiver.Heartbeat.EventType
+		if len(heartbeatEventType) <= 0 {
+			heartbeatEventType = constants.DefaultHeartbeatEventType
+		}
+		heartbeatSource := constants.Component + "/" + environment.PodName
+		kafkaHeartbeat, err := heartbeat.NewHeartbeat(logger, ekConfig.Receiver.Heartbeat.Target, heartbeatInterval, heartbeatEventType, heartbeatSource, produceHeartbeat, statsReporter)
+		if err != nil {
+			logger.Fatal("Invalid Receiver Heartbeat Configuration - Terminating", zap.Error(err))
+		}
+		go kafkaHeartbeat.Start(ctx)
+	}
+
 	// Set The Liveness Flag - Readiness Is Set By Individual Components
 	healthServer.SetAlive(true)
 
+	// Describe Events Produced To A Non-Existent Kafka Topic In The Response (Per The UnknownTopicPolicy)
+	var handler nethttp.Handler = producer.NewUnknownTopicHandler(logger, messageReceiver)
+
+	// Include The Produce Latency Of Successfully Produced Events In The Response (If Enabled)
+	if latencyConfig.Header {
+		handler = producer.NewLatencyHandler(handler)
+	}
+
+	// Reject Stale Events First If A Maximum Event Age Is Configured
+	if ekConfig.Receiver.MaxEventAgeMillis > 0 {
+		maxEventAge := time.Duration(ekConfig.Receiver.MaxEventAgeMillis) * time.Millisecond
+		logger.Info("Rejecting Events Older Than Maximum Event Age", zap.Duration("MaxEventAge", maxEventAge))
+		handler = eventage.NewHandler(logger, maxEventAge, statsReporter, handler)
+	}
+
+	// Reject Events Exceeding The Per-Channel Rate Limits Before Any Further Processing (Always Installed So That Limits Can Be Enabled Without A Restart)
+	if rateLimits.Enabled() {
+		logger.Info("Limiting The Rate Of Events Per KafkaChannel", zap.Float64("RequestsPerSecond", rateLimits.RequestsPerSecond), zap.Int64("BytesPerSecond", rateLimits.BytesPerSecond))
+	}
+	rateLimitHandler = ratelimit.NewHandler(logger, rateLimits, statsReporter, handler)
+	handler = rateLimitHandler
+
+	// Route Requests Identifying The KafkaChannel By Path (Behind A Path-Based Router) First If A Path Prefix Is Configured
+	if len(ekConfig.Receiver.PathPrefix) > 0 {
+		logger.Info("Routing Requests By KafkaChannel Path", zap.String("PathPrefix", pathrouting.NormalizePathPrefix(ekConfig.Receiver.PathPrefix)))
+		handler = pathrouting.NewHandler(logger, ekConfig.Receiver.PathPrefix, handler)
+	}
+
 	// Start The Message Receiver (Blocking)
-	err = messageReceiver.Start(ctx)
+	err = kncloudevents.NewHTTPMessageReceiver(constants.HttpPort).StartListen(ctx, handler)
 	if err != nil {
 		logger.Error("Failed To Start MessageReceiver", zap.Error(err))
 	}
@@ -164,7 +290,10 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 		return err
 	}
 
-	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic)
+	// Produce The CloudEvent Binding Message (Send To The Appropriate Kafka Topic With The KafkaChannel's Compression, Delivery Guarantee & Idempotence)
+	ctx = producer.WithCompressionCodec(ctx, channel.CompressionCodec(channelReference))
+	ctx = producer.WithDeliveryGuarantee(ctx, channel.DeliveryGuarantee(channelReference))
+	ctx = producer.WithIdempotence(ctx, channel.Idempotence(channelReference))
 	err = kafkaProducer.ProduceKafkaMessage(ctx, channelReference, message, transformers...)
 	if err != nil {
 		logger.Error("Failed To Produce Kafka Message", zap.Error(err))
@@ -175,12 +304,36 @@ func handleMessage(ctx context.Context, channelReference eventingchannel.Channel
 	return nil
 }
 
+// Heartbeat Produce Function - Validates The KafkaChannel & Produces Via The Current Producer (Which May Be Replaced)
+func produceHeartbeat(ctx context.Context, channelReference eventingchannel.ChannelReference, message binding.Message) error {
+	err := channel.ValidateKafkaChannel(channelReference)
+	if err != nil {
+		return err
+	}
+	ctx = producer.WithCompressionCodec(ctx, channel.CompressionCodec(channelReference))
+	ctx = producer.WithDeliveryGuarantee(ctx, channel.DeliveryGuarantee(channelReference))
+	ctx = producer.WithIdempotence(ctx, channel.Idempotence(channelReference))
+	return kafkaProducer.ProduceKafkaMessage(ctx, channelReference, message)
+}
+
 // configMapObserver is the callback function that handles changes to our ConfigMap
 func configMapObserver(configMap *v1.ConfigMap) {
 	if configMap == nil {
 		logger.Warn("Nil ConfigMap passed to configMapObserver; ignoring")
 		return
 	}
+
+	// Apply Any Change To The Per-Channel Rate Limits (Invalid Limits Are Ignored, Retaining The Current Ones)
+	if rateLimitHandler != nil {
+		ekConfig, err := sarama.LoadEventingKafkaSettings(configMap)
+		if err != nil {
+			logger.Warn("Unable To Load Eventing-Kafka Settings; Ignoring Rate Limit Changes", zap.Error(err))
+		} else if rateLimits, err := ratelimit.NewLimits(ekConfig.Receiver.RateLimit); err != nil {
+			logger.Warn("Invalid Rate Limits; Ignoring Rate Limit Changes", zap.Error(err))
+		} else {
+			rateLimitHandler.SetLimits(rateLimits)
+		}
+	}
 	if kafkaProducer == nil {
 		// This typically happens during startup
 		logger.Debug("Producer is nil during call to configMapObserver; ignoring changes")
@@ -36,6 +36,7 @@ import (
 
 	messagingv1alpha1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1alpha1"
 	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
@@ -80,7 +81,7 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 		func(ctx context.Context) context.Context {
-			return ctx
+			return messagingv1beta1.WithTopicNameFunc(ctx, topicName)
 		},
 
 		// Whether to disallow unknown fields.
@@ -121,6 +122,11 @@ func NewConversionController(ctx context.Context, cmw configmap.Watcher) *contro
 	)
 }
 
+// topicName is the name of the Kafka topic backing a KafkaChannel, used to validate that it is legal.
+func topicName(namespace, name string) string {
+	return utils.TopicName(utils.KafkaChannelSeparator, namespace, name)
+}
+
 func main() {
 	// Set up a signal context with our webhook options
 	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
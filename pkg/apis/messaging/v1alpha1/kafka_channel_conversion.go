@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
@@ -29,6 +30,20 @@ import (
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 )
 
+const (
+	// V1beta1SpecAnnotationKey is the annotation preserving the v1beta1 KafkaChannel spec fields which have
+	// no v1alpha1 equivalent, so that they survive a round trip through v1alpha1 (e.g. when it is the
+	// storage version) without loss.
+	V1beta1SpecAnnotationKey = "eventing-kafka.knative.dev/v1beta1-spec"
+)
+
+// v1beta1Spec holds the v1beta1 KafkaChannel spec fields preserved in the V1beta1SpecAnnotationKey annotation.
+type v1beta1Spec struct {
+	MaxDeliveryConcurrency int32                        `json:"maxDeliveryConcurrency,omitempty"`
+	RetentionMillis        int64                        `json:"retentionMillis,omitempty"`
+	Delivery               *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+}
+
 // ConvertTo implements apis.Convertible.
 // Converts channel (from v1alpha1.KafkaChannel) into v1beta1.KafkaChannel
 func (source *KafkaChannel) ConvertTo(ctx context.Context, obj apis.Convertible) error {
@@ -85,14 +100,25 @@ func (source *KafkaChannel) ConvertTo(ctx context.Context, obj apis.Convertible)
 			}
 		}
 
+		// Restore Any v1beta1 Spec Fields Preserved By A Previous Conversion From v1beta1
+		preserved := v1beta1Spec{}
+		if value, ok := source.Annotations[V1beta1SpecAnnotationKey]; ok {
+			if err := json.Unmarshal([]byte(value), &preserved); err != nil {
+				return fmt.Errorf("invalid %s annotation: %w", V1beta1SpecAnnotationKey, err)
+			}
+		}
+
 		sink.ObjectMeta = source.ObjectMeta
+		sink.Annotations = withoutAnnotation(source.Annotations, V1beta1SpecAnnotationKey)
 		sink.Spec = v1beta1.KafkaChannelSpec{
-			NumPartitions:     source.Spec.NumPartitions,
-			ReplicationFactor: source.Spec.ReplicationFactor,
+			NumPartitions:          source.Spec.NumPartitions,
+			ReplicationFactor:      source.Spec.ReplicationFactor,
+			MaxDeliveryConcurrency: preserved.MaxDeliveryConcurrency,
+			RetentionMillis:        preserved.RetentionMillis,
 			ChannelableSpec: eventingduckv1.ChannelableSpec{
 				SubscribableSpec: subscribableSpec,
-				// no delivery in v1alpha1
-				Delivery: nil,
+				// no delivery in v1alpha1 (other than as preserved in the annotation)
+				Delivery: preserved.Delivery,
 			},
 		}
 		sink.Status = v1beta1.KafkaChannelStatus{
@@ -170,6 +196,26 @@ func (sink *KafkaChannel) ConvertFrom(ctx context.Context, obj apis.Convertible)
 		}
 
 		sink.ObjectMeta = source.ObjectMeta
+		sink.Annotations = withoutAnnotation(source.Annotations, V1beta1SpecAnnotationKey)
+
+		// Preserve Any v1beta1 Spec Fields Which Have No v1alpha1 Equivalent In An Annotation
+		if source.Spec.MaxDeliveryConcurrency != 0 || source.Spec.RetentionMillis != 0 || source.Spec.Delivery != nil {
+			value, err := json.Marshal(v1beta1Spec{
+				MaxDeliveryConcurrency: source.Spec.MaxDeliveryConcurrency,
+				RetentionMillis:        source.Spec.RetentionMillis,
+				Delivery:               source.Spec.Delivery,
+			})
+			if err != nil {
+				return err
+			}
+			annotations := make(map[string]string, len(sink.Annotations)+1)
+			for k, v := range sink.Annotations {
+				annotations[k] = v
+			}
+			annotations[V1beta1SpecAnnotationKey] = string(value)
+			sink.Annotations = annotations
+		}
+
 		sink.Spec = KafkaChannelSpec{
 			NumPartitions:     source.Spec.NumPartitions,
 			ReplicationFactor: source.Spec.ReplicationFactor,
@@ -190,3 +236,21 @@ func (sink *KafkaChannel) ConvertFrom(ctx context.Context, obj apis.Convertible)
 		return fmt.Errorf("Unknown conversion, got: %T", source)
 	}
 }
+
+// withoutAnnotation returns a copy of the specified annotations without the specified key (or the annotations
+// themselves if they do not contain it), so that the annotations of the source of a conversion are not modified.
+func withoutAnnotation(annotations map[string]string, key string) map[string]string {
+	if _, ok := annotations[key]; !ok {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != key {
+			result[k] = v
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
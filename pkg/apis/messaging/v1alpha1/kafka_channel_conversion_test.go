@@ -180,6 +180,7 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 	versions := []apis.Convertible{&KafkaChannel{}}
 
 	BackoffPolicyLinear := eventingduckv1.BackoffPolicyLinear
+	BackoffPolicyExponential := eventingduckv1.BackoffPolicyExponential
 
 	tests := []struct {
 		name string
@@ -212,8 +213,10 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 				Generation: 17,
 			},
 			Spec: v1beta1.KafkaChannelSpec{
-				NumPartitions:     117,
-				ReplicationFactor: 118,
+				NumPartitions:          117,
+				ReplicationFactor:      118,
+				MaxDeliveryConcurrency: 119,
+				RetentionMillis:        120,
 				ChannelableSpec: v1.ChannelableSpec{
 					SubscribableSpec: v1.SubscribableSpec{
 						Subscribers: []eventingduckv1.SubscriberSpec{
@@ -239,21 +242,21 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 							},
 						},
 					},
-					// this field doesn't exist in v1alpha1
-					//Delivery: &v1.DeliverySpec{
-					//	DeadLetterSink: &duckv1.Destination{
-					//		Ref: &duckv1.KReference{
-					//			Kind:       "delivery-dls-kind",
-					//			Namespace:  "delivery-dls-ns",
-					//			Name:       "delivery-dls-name",
-					//			APIVersion: "delivery-dls-apiversion",
-					//		},
-					//		URI: apis.HTTP("subscriber-uri"),
-					//	},
-					//	Retry:         pointer.Int32Ptr(11),
-					//	BackoffPolicy: &BackoffPolicyExponential,
-					//	BackoffDelay:  pointer.StringPtr("backoffdelay2"),
-					//},
+					// this field doesn't exist in v1alpha1 (it is preserved in an annotation)
+					Delivery: &v1.DeliverySpec{
+						DeadLetterSink: &duckv1.Destination{
+							Ref: &duckv1.KReference{
+								Kind:       "delivery-dls-kind",
+								Namespace:  "delivery-dls-ns",
+								Name:       "delivery-dls-name",
+								APIVersion: "delivery-dls-apiversion",
+							},
+							URI: apis.HTTP("subscriber-uri"),
+						},
+						Retry:         pointer.Int32Ptr(11),
+						BackoffPolicy: &BackoffPolicyExponential,
+						BackoffDelay:  pointer.StringPtr("backoffdelay2"),
+					},
 				},
 			},
 			Status: v1beta1.KafkaChannelStatus{
@@ -315,3 +318,96 @@ func TestKafkaChannelConversionRoundTripV1beta1(t *testing.T) {
 		}
 	}
 }
+
+// Test that the topic settings (and the v1beta1 spec fields without a v1alpha1 equivalent) survive a round trip
+// of a v1beta1 KafkaChannel through v1alpha1, without modifying the source of either conversion.
+func TestKafkaChannelConversionTopicSettings(t *testing.T) {
+	tests := []struct {
+		name                   string
+		numPartitions          int32
+		replicationFactor      int16
+		maxDeliveryConcurrency int32
+		retentionMillis        int64
+		annotations            map[string]string
+		wantAnnotation         bool
+	}{{
+		name: "defaulted topic settings",
+	}, {
+		name:              "topic settings",
+		numPartitions:     12,
+		replicationFactor: 3,
+		annotations:       map[string]string{"foo": "bar"},
+	}, {
+		name:                   "topic settings with v1beta1 only fields",
+		numPartitions:          12,
+		replicationFactor:      3,
+		maxDeliveryConcurrency: 7,
+		annotations:            map[string]string{"foo": "bar"},
+		wantAnnotation:         true,
+	}, {
+		name:              "topic settings with retention",
+		numPartitions:     12,
+		replicationFactor: 3,
+		retentionMillis:   3600000,
+		wantAnnotation:    true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &v1beta1.KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka-channel-name", Namespace: "kafka-channel-ns", Annotations: test.annotations},
+				Spec: v1beta1.KafkaChannelSpec{
+					NumPartitions:          test.numPartitions,
+					ReplicationFactor:      test.replicationFactor,
+					MaxDeliveryConcurrency: test.maxDeliveryConcurrency,
+					RetentionMillis:        test.retentionMillis,
+				},
+			}
+			original := in.DeepCopy()
+
+			// Convert down to v1alpha1 (e.g. the storage version)
+			stored := &KafkaChannel{}
+			if err := stored.ConvertFrom(context.Background(), in); err != nil {
+				t.Fatalf("ConvertFrom() = %v", err)
+			}
+			if stored.Spec.NumPartitions != test.numPartitions || stored.Spec.ReplicationFactor != test.replicationFactor {
+				t.Errorf("ConvertFrom() topic settings = %d/%d, want %d/%d", stored.Spec.NumPartitions, stored.Spec.ReplicationFactor, test.numPartitions, test.replicationFactor)
+			}
+			if _, ok := stored.Annotations[V1beta1SpecAnnotationKey]; ok != test.wantAnnotation {
+				t.Errorf("ConvertFrom() %s annotation present = %t, want %t", V1beta1SpecAnnotationKey, ok, test.wantAnnotation)
+			}
+			storedOriginal := stored.DeepCopy()
+
+			// Convert back up to v1beta1 (as read by the controller)
+			got := &v1beta1.KafkaChannel{}
+			if err := stored.ConvertTo(context.Background(), got); err != nil {
+				t.Fatalf("ConvertTo() = %v", err)
+			}
+			if diff := cmp.Diff(original.ObjectMeta, got.ObjectMeta); diff != "" {
+				t.Errorf("roundtrip metadata (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff(original.Spec, got.Spec); diff != "" {
+				t.Errorf("roundtrip spec (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff(original, in); diff != "" {
+				t.Errorf("ConvertFrom() modified its source (-want, +got) = %v", diff)
+			}
+			if diff := cmp.Diff(storedOriginal, stored); diff != "" {
+				t.Errorf("ConvertTo() modified its source (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestKafkaChannelConversionInvalidV1beta1SpecAnnotation(t *testing.T) {
+	in := &KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kafka-channel-name",
+			Namespace:   "kafka-channel-ns",
+			Annotations: map[string]string{V1beta1SpecAnnotationKey: "not json"},
+		},
+	}
+	if err := in.ConvertTo(context.Background(), &v1beta1.KafkaChannel{}); err == nil {
+		t.Error("ConvertTo() = nil, wanted error")
+	}
+}
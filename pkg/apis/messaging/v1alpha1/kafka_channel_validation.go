@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
 )
@@ -38,6 +39,11 @@ func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	// Validate the derived topic name on creation only (see v1beta1)
+	if c.Name != "" && !apis.IsInUpdate(ctx) {
+		errs = errs.Also(v1beta1.ValidateTopicName(ctx, c.Namespace, c.Name))
+	}
+
 	return errs
 }
 
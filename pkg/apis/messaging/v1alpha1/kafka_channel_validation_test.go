@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -144,6 +145,23 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"name too long for topic name": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      strings.Repeat("a", 240),
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(strings.Repeat("a", 240), "metadata.name")
+				fe.Details = "derived kafka topic name \"namespace." + strings.Repeat("a", 240) + "\" is 250 characters, exceeding the maximum of 249"
+				return fe
+			}(),
+		},
 	}
 
 	for n, test := range testCases {
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+const (
+	// DeliveryGuaranteeAnnotationKey is the annotation declaring the end-to-end delivery guarantee of a
+	// KafkaChannel's events, which configures the receiver, topic and dispatcher consistently to achieve it.
+	// KafkaChannels which are not annotated are delivered as configured by the lower-level Kafka settings.
+	DeliveryGuaranteeAnnotationKey = "eventing-kafka.knative.dev/delivery-guarantee"
+
+	// DeliveryGuaranteeAtMostOnce never redelivers an event, at the risk of losing it.
+	DeliveryGuaranteeAtMostOnce = "at-most-once"
+
+	// DeliveryGuaranteeAtLeastOnce never loses an acknowledged event, at the risk of delivering it more than once.
+	DeliveryGuaranteeAtLeastOnce = "at-least-once"
+
+	// DeliveryGuaranteeEffectivelyOnce never loses an acknowledged event, and additionally avoids the duplicates
+	// introduced by retried produce requests and by consuming aborted or uncommitted records.
+	DeliveryGuaranteeEffectivelyOnce = "effectively-once"
+)
+
+// DeliveryGuarantee returns the delivery guarantee annotated on the KafkaChannel (empty if not annotated).
+func (c *KafkaChannel) DeliveryGuarantee() string {
+	return c.Annotations[DeliveryGuaranteeAnnotationKey]
+}
+
+// IsValidDeliveryGuarantee returns true if the specified delivery guarantee is one of the supported values.
+func IsValidDeliveryGuarantee(deliveryGuarantee string) bool {
+	switch deliveryGuarantee {
+	case DeliveryGuaranteeAtMostOnce, DeliveryGuaranteeAtLeastOnce, DeliveryGuaranteeEffectivelyOnce:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKafkaChannelDeliveryGuarantee(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        string
+		wantValid   bool
+	}{
+		"not annotated": {
+			want:      "",
+			wantValid: false,
+		},
+		"at-most-once": {
+			annotations: map[string]string{DeliveryGuaranteeAnnotationKey: DeliveryGuaranteeAtMostOnce},
+			want:        DeliveryGuaranteeAtMostOnce,
+			wantValid:   true,
+		},
+		"at-least-once": {
+			annotations: map[string]string{DeliveryGuaranteeAnnotationKey: DeliveryGuaranteeAtLeastOnce},
+			want:        DeliveryGuaranteeAtLeastOnce,
+			wantValid:   true,
+		},
+		"effectively-once": {
+			annotations: map[string]string{DeliveryGuaranteeAnnotationKey: DeliveryGuaranteeEffectivelyOnce},
+			want:        DeliveryGuaranteeEffectivelyOnce,
+			wantValid:   true,
+		},
+		"unsupported": {
+			annotations: map[string]string{DeliveryGuaranteeAnnotationKey: "exactly-once"},
+			want:        "exactly-once",
+			wantValid:   false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			c := &KafkaChannel{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got := c.DeliveryGuarantee()
+			if got != tc.want {
+				t.Errorf("DeliveryGuarantee() = %q, want %q", got, tc.want)
+			}
+			if valid := IsValidDeliveryGuarantee(got); valid != tc.wantValid {
+				t.Errorf("IsValidDeliveryGuarantee(%q) = %v, want %v", got, valid, tc.wantValid)
+			}
+		})
+	}
+}
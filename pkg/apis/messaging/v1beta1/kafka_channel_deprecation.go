@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"sort"
+
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// DeprecatedSpecField describes a KafkaChannelSpec field which is no longer supported, and how it is
+// migrated to its replacement.
+type DeprecatedSpecField struct {
+	// Replacement is the path of the field replacing the deprecated field (empty if there is none).
+	Replacement string
+
+	// IsSet returns whether the deprecated field is set in the specified spec.
+	IsSet func(spec *KafkaChannelSpec) bool
+
+	// Migrate moves the deprecated field's value to its replacement and clears the deprecated field.
+	// It is nil if the field cannot be migrated automatically.
+	Migrate func(ctx context.Context, spec *KafkaChannelSpec) error
+}
+
+// DeprecatedSpecFields maps the path of each deprecated KafkaChannelSpec field to its handling.
+var DeprecatedSpecFields = map[string]DeprecatedSpecField{
+	"spec.subscribable": {
+		Replacement: "spec.subscribers",
+		IsSet:       func(spec *KafkaChannelSpec) bool { return spec.Subscribable != nil },
+		Migrate:     migrateSubscribable,
+	},
+}
+
+// DeprecatedFields returns the sorted paths of the deprecated fields which are set in the spec.
+func (cs *KafkaChannelSpec) DeprecatedFields() []string {
+	var fields []string
+	for field, deprecatedSpecField := range DeprecatedSpecFields {
+		if deprecatedSpecField.IsSet(cs) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MigrateDeprecatedFields migrates each deprecated field which is set in the spec to its replacement,
+// returning the sorted paths of the fields which were migrated.  Fields which cannot be migrated
+// automatically are left as-is.
+func (cs *KafkaChannelSpec) MigrateDeprecatedFields(ctx context.Context) ([]string, error) {
+	var migrated []string
+	for _, field := range cs.DeprecatedFields() {
+		deprecatedSpecField := DeprecatedSpecFields[field]
+		if deprecatedSpecField.Migrate == nil {
+			continue
+		}
+		if err := deprecatedSpecField.Migrate(ctx, cs); err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, field)
+	}
+	return migrated, nil
+}
+
+// migrateSubscribable appends the pre-v1 duck subscribers to the Subscribers (other than those whose
+// UID is already present, which are assumed to be more recent) and clears the Subscribable.
+func migrateSubscribable(ctx context.Context, spec *KafkaChannelSpec) error {
+	existing := make(map[string]bool, len(spec.Subscribers))
+	for _, subscriber := range spec.Subscribers {
+		existing[string(subscriber.UID)] = true
+	}
+	for _, source := range spec.Subscribable.Subscribers {
+		if existing[string(source.UID)] {
+			continue
+		}
+		subscriber := eventingduck.SubscriberSpec{
+			UID:           source.UID,
+			Generation:    source.Generation,
+			SubscriberURI: source.SubscriberURI,
+			ReplyURI:      source.ReplyURI,
+		}
+		if source.Delivery != nil {
+			subscriber.Delivery = &eventingduck.DeliverySpec{}
+			if err := source.Delivery.ConvertTo(ctx, subscriber.Delivery); err != nil {
+				return err
+			}
+		} else if source.DeadLetterSinkURI != nil {
+			subscriber.Delivery = &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: source.DeadLetterSinkURI}}
+		}
+		spec.Subscribers = append(spec.Subscribers, subscriber)
+	}
+	spec.Subscribable = nil
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestKafkaChannelSpecMigrateDeprecatedFields(t *testing.T) {
+	subscriberURI := apis.HTTP("subscriber.example.com")
+	replyURI := apis.HTTP("reply.example.com")
+	deadLetterSinkURI := apis.HTTP("dls.example.com")
+
+	testCases := map[string]struct {
+		initial        KafkaChannelSpec
+		expected       KafkaChannelSpec
+		wantDeprecated []string
+		wantMigrated   []string
+	}{
+		"no deprecated fields": {
+			initial: KafkaChannelSpec{
+				NumPartitions: testNumPartitions,
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{{UID: "uid-1", SubscriberURI: subscriberURI}},
+					},
+				},
+			},
+			expected: KafkaChannelSpec{
+				NumPartitions: testNumPartitions,
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{{UID: "uid-1", SubscriberURI: subscriberURI}},
+					},
+				},
+			},
+		},
+		"empty subscribable": {
+			initial:        KafkaChannelSpec{Subscribable: &eventingduckv1alpha1.Subscribable{}},
+			expected:       KafkaChannelSpec{},
+			wantDeprecated: []string{"spec.subscribable"},
+			wantMigrated:   []string{"spec.subscribable"},
+		},
+		"subscribable migrated to subscribers": {
+			initial: KafkaChannelSpec{
+				NumPartitions: testNumPartitions,
+				Subscribable: &eventingduckv1alpha1.Subscribable{
+					Subscribers: []eventingduckv1alpha1.SubscriberSpec{
+						{UID: "uid-1", Generation: 2, SubscriberURI: subscriberURI, ReplyURI: replyURI},
+						{UID: "uid-2", SubscriberURI: subscriberURI, DeadLetterSinkURI: deadLetterSinkURI},
+					},
+				},
+			},
+			expected: KafkaChannelSpec{
+				NumPartitions: testNumPartitions,
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{
+							{UID: "uid-1", Generation: 2, SubscriberURI: subscriberURI, ReplyURI: replyURI},
+							{UID: "uid-2", SubscriberURI: subscriberURI, Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: deadLetterSinkURI}}},
+						},
+					},
+				},
+			},
+			wantDeprecated: []string{"spec.subscribable"},
+			wantMigrated:   []string{"spec.subscribable"},
+		},
+		"existing subscribers take precedence": {
+			initial: KafkaChannelSpec{
+				Subscribable: &eventingduckv1alpha1.Subscribable{
+					Subscribers: []eventingduckv1alpha1.SubscriberSpec{
+						{UID: "uid-1", Generation: 1, SubscriberURI: replyURI},
+						{UID: "uid-2", SubscriberURI: subscriberURI},
+					},
+				},
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{{UID: "uid-1", Generation: 3, SubscriberURI: subscriberURI}},
+					},
+				},
+			},
+			expected: KafkaChannelSpec{
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{
+							{UID: "uid-1", Generation: 3, SubscriberURI: subscriberURI},
+							{UID: "uid-2", SubscriberURI: subscriberURI},
+						},
+					},
+				},
+			},
+			wantDeprecated: []string{"spec.subscribable"},
+			wantMigrated:   []string{"spec.subscribable"},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			spec := tc.initial.DeepCopy()
+			if diff := cmp.Diff(tc.wantDeprecated, spec.DeprecatedFields()); diff != "" {
+				t.Errorf("Unexpected deprecated fields (-want, +got): %s", diff)
+			}
+			migrated, err := spec.MigrateDeprecatedFields(context.Background())
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantMigrated, migrated); diff != "" {
+				t.Errorf("Unexpected migrated fields (-want, +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.expected, *spec); diff != "" {
+				t.Errorf("Unexpected spec (-want, +got): %s", diff)
+			}
+			if len(spec.DeprecatedFields()) > 0 {
+				t.Errorf("Deprecated fields remain after migration: %v", spec.DeprecatedFields())
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
@@ -64,6 +66,11 @@ const (
 	// KafkaChannelConditionConfigReady has status True when the Kafka configuration to use by the channel exists and is valid
 	// (ie. the connection has been established).
 	KafkaChannelConditionConfigReady apis.ConditionType = "ConfigurationReady"
+
+	// KafkaChannelConditionOrderingGuaranteed has status True when ordered delivery is requested of a KafkaChannel
+	// with a single partition, and False when its multiple partitions only allow ordering per-partition.  It is not
+	// one of the conditions which determine readiness, and is absent when ordered delivery is not requested.
+	KafkaChannelConditionOrderingGuaranteed apis.ConditionType = "OrderingGuaranteed"
 )
 
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
@@ -104,6 +111,12 @@ func (cs *KafkaChannelStatus) MarkDispatcherFailed(reason, messageFormat string,
 	kc.Manage(cs).MarkFalse(KafkaChannelConditionDispatcherReady, reason, messageFormat, messageA...)
 }
 
+// MarkDispatcherNotManaged sets the DispatcherReady condition to True with the specified reason, indicating that no
+// Dispatcher is required of the controller (e.g. because the channel's events are consumed by an external system).
+func (cs *KafkaChannelStatus) MarkDispatcherNotManaged(reason, messageFormat string, messageA ...interface{}) {
+	kc.Manage(cs).MarkTrueWithReason(KafkaChannelConditionDispatcherReady, reason, messageFormat, messageA...)
+}
+
 func (cs *KafkaChannelStatus) MarkDispatcherUnknown(reason, messageFormat string, messageA ...interface{}) {
 	kc.Manage(cs).MarkUnknown(KafkaChannelConditionDispatcherReady, reason, messageFormat, messageA...)
 }
@@ -159,6 +172,10 @@ func (cs *KafkaChannelStatus) MarkTopicFailed(reason, messageFormat string, mess
 	kc.Manage(cs).MarkFalse(KafkaChannelConditionTopicReady, reason, messageFormat, messageA...)
 }
 
+func (cs *KafkaChannelStatus) MarkTopicUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kc.Manage(cs).MarkUnknown(KafkaChannelConditionTopicReady, reason, messageFormat, messageA...)
+}
+
 func (cs *KafkaChannelStatus) MarkConfigTrue() {
 	kc.Manage(cs).MarkTrue(KafkaChannelConditionConfigReady)
 }
@@ -166,3 +183,32 @@ func (cs *KafkaChannelStatus) MarkConfigTrue() {
 func (cs *KafkaChannelStatus) MarkConfigFailed(reason, messageFormat string, messageA ...interface{}) {
 	kc.Manage(cs).MarkFalse(KafkaChannelConditionConfigReady, reason, messageFormat, messageA...)
 }
+
+func (cs *KafkaChannelStatus) MarkConfigUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kc.Manage(cs).MarkUnknown(KafkaChannelConditionConfigReady, reason, messageFormat, messageA...)
+}
+
+// MarkOrderingGuaranteed sets the (informational) OrderingGuaranteed condition to True.
+func (cs *KafkaChannelStatus) MarkOrderingGuaranteed() {
+	kc.Manage(cs).SetCondition(apis.Condition{
+		Type:     KafkaChannelConditionOrderingGuaranteed,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityInfo,
+	})
+}
+
+// MarkOrderingNotGuaranteed sets the OrderingGuaranteed condition to False as a warning, without affecting readiness.
+func (cs *KafkaChannelStatus) MarkOrderingNotGuaranteed(reason, messageFormat string, messageA ...interface{}) {
+	kc.Manage(cs).SetCondition(apis.Condition{
+		Type:     KafkaChannelConditionOrderingGuaranteed,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  fmt.Sprintf(messageFormat, messageA...),
+	})
+}
+
+// ClearOrderingCondition removes the OrderingGuaranteed condition (i.e. when ordered delivery is not requested).
+func (cs *KafkaChannelStatus) ClearOrderingCondition() {
+	_ = kc.Manage(cs).ClearCondition(KafkaChannelConditionOrderingGuaranteed)
+}
@@ -256,6 +256,7 @@ func TestChannelIsReady(t *testing.T) {
 		markTopicReady          bool
 		wantReady               bool
 		dispatcherStatus        *appsv1.DeploymentStatus
+		dispatcherNotManaged    bool
 	}{{
 		name:                    "all happy",
 		markServiceReady:        true,
@@ -326,6 +327,16 @@ func TestChannelIsReady(t *testing.T) {
 		setAddress:              true,
 		markTopicReady:          false,
 		wantReady:               false,
+	}, {
+		name:                    "dispatcher not managed",
+		markServiceReady:        true,
+		markConfigurationReady:  true,
+		markChannelServiceReady: true,
+		markEndpointsReady:      true,
+		dispatcherNotManaged:    true,
+		setAddress:              true,
+		markTopicReady:          true,
+		wantReady:               true,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -354,7 +365,9 @@ func TestChannelIsReady(t *testing.T) {
 			} else {
 				cs.MarkEndpointsFailed("NotReadyEndpoints", "testing")
 			}
-			if test.dispatcherStatus != nil {
+			if test.dispatcherNotManaged {
+				cs.MarkDispatcherNotManaged("DispatcherNotManaged", "testing")
+			} else if test.dispatcherStatus != nil {
 				cs.PropagateDispatcherStatus(test.dispatcherStatus)
 			} else {
 				cs.MarkDispatcherFailed("NotReadyDispatcher", "testing")
@@ -372,6 +385,16 @@ func TestChannelIsReady(t *testing.T) {
 	}
 }
 
+func TestKafkaChannelStatus_MarkDispatcherNotManaged(t *testing.T) {
+	cs := &KafkaChannelStatus{}
+	cs.InitializeConditions()
+	cs.MarkDispatcherNotManaged("DispatcherNotManaged", "no dispatcher for %s", "testing")
+	got := cs.GetCondition(KafkaChannelConditionDispatcherReady)
+	if got == nil || got.Status != corev1.ConditionTrue || got.Reason != "DispatcherNotManaged" || got.Message != "no dispatcher for testing" {
+		t.Errorf("unexpected DispatcherReady condition: %+v", got)
+	}
+}
+
 func TestKafkaChannelStatus_SetAddressable(t *testing.T) {
 	testCases := map[string]struct {
 		url  *apis.URL
@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+const (
+	// DeliveryOrderAnnotationKey is the annotation declaring the delivery order required by a KafkaChannel's
+	// subscribers, either DeliveryOrderOrdered or DeliveryOrderUnordered (the default when not annotated).
+	DeliveryOrderAnnotationKey = "eventing-kafka.knative.dev/delivery-order"
+
+	// DeliveryOrderOrdered requires events to be delivered in the order they were received by the channel.
+	DeliveryOrderOrdered = "ordered"
+
+	// DeliveryOrderUnordered makes no delivery ordering requirement.
+	DeliveryOrderUnordered = "unordered"
+)
+
+// IsOrderedDelivery returns true if the KafkaChannel is annotated as requiring ordered delivery.
+func (c *KafkaChannel) IsOrderedDelivery() bool {
+	return c.Annotations[DeliveryOrderAnnotationKey] == DeliveryOrderOrdered
+}
+
+// IsOrderingGuaranteed returns true if the delivery order required by the KafkaChannel can be guaranteed across
+// all of its events.  Kafka only orders events within a partition, so ordered delivery is only guaranteed for
+// channels with a single partition.
+func (c *KafkaChannel) IsOrderingGuaranteed() bool {
+	return !c.IsOrderedDelivery() || c.Spec.NumPartitions <= 1
+}
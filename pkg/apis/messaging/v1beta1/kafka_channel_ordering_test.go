@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestKafkaChannelIsOrderingGuaranteed(t *testing.T) {
+	testCases := map[string]struct {
+		annotations    map[string]string
+		numPartitions  int32
+		wantOrdered    bool
+		wantGuaranteed bool
+	}{
+		"not annotated": {
+			numPartitions:  3,
+			wantOrdered:    false,
+			wantGuaranteed: true,
+		},
+		"unordered multiple partitions": {
+			annotations:    map[string]string{DeliveryOrderAnnotationKey: DeliveryOrderUnordered},
+			numPartitions:  3,
+			wantOrdered:    false,
+			wantGuaranteed: true,
+		},
+		"ordered single partition": {
+			annotations:    map[string]string{DeliveryOrderAnnotationKey: DeliveryOrderOrdered},
+			numPartitions:  1,
+			wantOrdered:    true,
+			wantGuaranteed: true,
+		},
+		"ordered multiple partitions": {
+			annotations:    map[string]string{DeliveryOrderAnnotationKey: DeliveryOrderOrdered},
+			numPartitions:  3,
+			wantOrdered:    true,
+			wantGuaranteed: false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			c := &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       KafkaChannelSpec{NumPartitions: tc.numPartitions, ReplicationFactor: 1},
+			}
+			if got := c.IsOrderedDelivery(); got != tc.wantOrdered {
+				t.Errorf("IsOrderedDelivery() = %v, want %v", got, tc.wantOrdered)
+			}
+			if got := c.IsOrderingGuaranteed(); got != tc.wantGuaranteed {
+				t.Errorf("IsOrderingGuaranteed() = %v, want %v", got, tc.wantGuaranteed)
+			}
+		})
+	}
+}
+
+func TestKafkaChannelStatus_OrderingCondition(t *testing.T) {
+	cs := &KafkaChannelStatus{}
+	cs.InitializeConditions()
+
+	// the ordering condition is informational and must never affect readiness
+	cs.MarkOrderingNotGuaranteed("MultiplePartitions", "ordering is per-partition")
+	got := cs.GetCondition(KafkaChannelConditionOrderingGuaranteed)
+	want := &apis.Condition{
+		Type:     KafkaChannelConditionOrderingGuaranteed,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   "MultiplePartitions",
+		Message:  "ordering is per-partition",
+	}
+	if diff := cmp.Diff(want, got, ignoreAllButTypeAndStatus); diff != "" {
+		t.Errorf("unexpected condition (-want, +got) = %v", diff)
+	}
+	if got.Severity != apis.ConditionSeverityWarning {
+		t.Errorf("unexpected severity %q", got.Severity)
+	}
+	if ready := cs.GetCondition(KafkaChannelConditionReady); ready.Status != corev1.ConditionUnknown {
+		t.Errorf("unexpected Ready status %q", ready.Status)
+	}
+
+	cs.MarkOrderingGuaranteed()
+	if got := cs.GetCondition(KafkaChannelConditionOrderingGuaranteed); got == nil || got.Status != corev1.ConditionTrue {
+		t.Errorf("unexpected condition %v", got)
+	}
+
+	cs.ClearOrderingCondition()
+	if got := cs.GetCondition(KafkaChannelConditionOrderingGuaranteed); got != nil {
+		t.Errorf("unexpected condition %v", got)
+	}
+}
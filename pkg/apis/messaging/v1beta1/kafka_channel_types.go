@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -67,6 +68,23 @@ type KafkaChannelSpec struct {
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
+	// MaxDeliveryConcurrency is the maximum number of concurrent deliveries of events from the channel,
+	// across all of its subscribers. By default (zero), the dispatcher's configured default applies.
+	// +optional
+	MaxDeliveryConcurrency int32 `json:"maxDeliveryConcurrency,omitempty"`
+
+	// RetentionMillis is the retention time (retention.ms) of a Kafka topic in milliseconds. By default (zero),
+	// the controller's configured default applies.
+	// +optional
+	RetentionMillis int64 `json:"retentionMillis,omitempty"`
+
+	// Subscribable is the pre-v1 duck list of subscribers, which may remain on KafkaChannels
+	// created before v1beta1.  It is migrated to Subscribers by the controller.
+	//
+	// Deprecated: Use Subscribers instead.
+	// +optional
+	Subscribable *eventingduckv1alpha1.Subscribable `json:"subscribable,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
@@ -75,6 +93,11 @@ type KafkaChannelSpec struct {
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableStatus `json:",inline"`
+
+	// MaxDeliveryConcurrency is the effective maximum number of concurrent deliveries of events from the
+	// channel, as applied by its dispatcher. Zero indicates that deliveries are unbounded.
+	// +optional
+	MaxDeliveryConcurrency int32 `json:"maxDeliveryConcurrency,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -19,11 +19,48 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
 )
 
+const (
+	// maxTopicNameLength is the maximum length of a Kafka topic name.
+	maxTopicNameLength = 249
+
+	// ImmutableAnnotationKey is the annotation which, when set to "true" on a KafkaChannel, causes any
+	// change to its spec to be rejected until the annotation is removed.
+	ImmutableAnnotationKey = "eventing-kafka.knative.dev/immutable"
+)
+
+// legalTopicNameRegexp matches the characters allowed in a Kafka topic name.
+var legalTopicNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// TopicNameFunc returns the name of the Kafka topic backing the KafkaChannel with the given namespace and name.
+type TopicNameFunc func(namespace, name string) string
+
+type topicNameFuncKey struct{}
+
+// WithTopicNameFunc returns a copy of the context carrying the TopicNameFunc used to validate that the
+// topic name derived from a KafkaChannel's namespace and name is a legal Kafka topic name, so that each
+// channel implementation's topic naming (e.g. prefixes) is accounted for.
+func WithTopicNameFunc(ctx context.Context, topicNameFunc TopicNameFunc) context.Context {
+	return context.WithValue(ctx, topicNameFuncKey{}, topicNameFunc)
+}
+
+// topicNameFuncFrom returns the TopicNameFunc from the context, defaulting to the "namespace.name" which
+// is common to the topic names of all channel implementations.
+func topicNameFuncFrom(ctx context.Context) TopicNameFunc {
+	if topicNameFunc, ok := ctx.Value(topicNameFuncKey{}).(TopicNameFunc); ok && topicNameFunc != nil {
+		return topicNameFunc
+	}
+	return func(namespace, name string) string {
+		return namespace + "." + name
+	}
+}
+
 func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 	errs := c.Spec.Validate(ctx).ViaField("spec")
 
@@ -36,11 +73,78 @@ func (c *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 				errs = errs.Also(iv.ViaFieldKey("annotations", eventing.ScopeAnnotationKey).ViaField("metadata"))
 			}
 		}
+		if deliveryOrder, ok := c.Annotations[DeliveryOrderAnnotationKey]; ok {
+			if deliveryOrder != DeliveryOrderOrdered && deliveryOrder != DeliveryOrderUnordered {
+				iv := apis.ErrInvalidValue(deliveryOrder, "")
+				iv.Details = fmt.Sprintf("expected either '%s' or '%s'", DeliveryOrderOrdered, DeliveryOrderUnordered)
+				errs = errs.Also(iv.ViaFieldKey("annotations", DeliveryOrderAnnotationKey).ViaField("metadata"))
+			}
+		}
+		if deliveryGuarantee, ok := c.Annotations[DeliveryGuaranteeAnnotationKey]; ok {
+			if !IsValidDeliveryGuarantee(deliveryGuarantee) {
+				iv := apis.ErrInvalidValue(deliveryGuarantee, "")
+				iv.Details = fmt.Sprintf("expected one of '%s', '%s' or '%s'", DeliveryGuaranteeAtMostOnce, DeliveryGuaranteeAtLeastOnce, DeliveryGuaranteeEffectivelyOnce)
+				errs = errs.Also(iv.ViaFieldKey("annotations", DeliveryGuaranteeAnnotationKey).ViaField("metadata"))
+			}
+		}
+	}
+
+	// Validate the derived topic name on creation only (the name is immutable, and rejecting updates
+	// would prevent the finalizers of an existing channel from being removed)
+	if c.Name != "" && !apis.IsInUpdate(ctx) {
+		errs = errs.Also(ValidateTopicName(ctx, c.Namespace, c.Name))
+	}
+
+	// Reject spec changes to channels marked immutable (the annotation of the original is checked, so that
+	// removing the annotation, and any other metadata change, is always allowed)
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*KafkaChannel); ok && original != nil {
+			errs = errs.Also(c.checkImmutableSpec(original))
+		}
 	}
 
 	return errs
 }
 
+// checkImmutableSpec returns an error if the original KafkaChannel is annotated as immutable and the spec
+// has been changed.
+func (c *KafkaChannel) checkImmutableSpec(original *KafkaChannel) *apis.FieldError {
+	if original.Annotations[ImmutableAnnotationKey] != "true" {
+		return nil
+	}
+	if diff, err := kmp.ShortDiff(original.Spec, c.Spec); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff KafkaChannel",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("KafkaChannel is marked immutable by the %q annotation, which must be removed before changing the spec (-old +new)", ImmutableAnnotationKey),
+			Paths:   []string{"spec"},
+			Details: diff,
+		}
+	}
+	return nil
+}
+
+// ValidateTopicName verifies that the Kafka topic name derived from the namespace and name is legal, so
+// that the channel is rejected immediately instead of failing when the topic is created.
+func ValidateTopicName(ctx context.Context, namespace, name string) *apis.FieldError {
+	topicName := topicNameFuncFrom(ctx)(namespace, name)
+	if len(topicName) > maxTopicNameLength {
+		fe := apis.ErrInvalidValue(name, "name")
+		fe.Details = fmt.Sprintf("derived kafka topic name %q is %d characters, exceeding the maximum of %d", topicName, len(topicName), maxTopicNameLength)
+		return fe.ViaField("metadata")
+	}
+	if !legalTopicNameRegexp.MatchString(topicName) {
+		fe := apis.ErrInvalidValue(name, "name")
+		fe.Details = fmt.Sprintf("derived kafka topic name %q contains characters other than ASCII alphanumerics, '.', '_' and '-'", topicName)
+		return fe.ViaField("metadata")
+	}
+	return nil
+}
+
 func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 
@@ -54,6 +158,16 @@ func (cs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(fe)
 	}
 
+	if cs.MaxDeliveryConcurrency < 0 {
+		fe := apis.ErrInvalidValue(cs.MaxDeliveryConcurrency, "maxDeliveryConcurrency")
+		errs = errs.Also(fe)
+	}
+
+	if cs.RetentionMillis < 0 {
+		fe := apis.ErrInvalidValue(cs.RetentionMillis, "retentionMillis")
+		errs = errs.Also(fe)
+	}
+
 	for i, subscriber := range cs.SubscribableSpec.Subscribers {
 		if subscriber.ReplyURI == nil && subscriber.SubscriberURI == nil {
 			fe := apis.ErrMissingField("replyURI", "subscriberURI")
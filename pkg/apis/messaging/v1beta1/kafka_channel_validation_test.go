@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -72,6 +74,52 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"negative maxDeliveryConcurrency": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:          1,
+					ReplicationFactor:      1,
+					MaxDeliveryConcurrency: -1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(-1, "spec.maxDeliveryConcurrency")
+				return fe
+			}(),
+		},
+		"valid maxDeliveryConcurrency": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:          1,
+					ReplicationFactor:      1,
+					MaxDeliveryConcurrency: 10,
+				},
+			},
+			want: nil,
+		},
+		"negative retentionMillis": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionMillis:   -1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue(-1, "spec.retentionMillis")
+				return fe
+			}(),
+		},
+		"valid retentionMillis": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionMillis:   3600000,
+				},
+			},
+			want: nil,
+		},
 		"valid subscribers array": {
 			cr: &KafkaChannel{
 				Spec: KafkaChannelSpec{
@@ -149,6 +197,70 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"valid delivery order annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						DeliveryOrderAnnotationKey: DeliveryOrderOrdered,
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     3,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
+		"invalid delivery order annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						DeliveryOrderAnnotationKey: "sorted",
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("sorted", "metadata.annotations.[eventing-kafka.knative.dev/delivery-order]")
+				fe.Details = "expected either 'ordered' or 'unordered'"
+				return fe
+			}(),
+		},
+		"valid delivery guarantee annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						DeliveryGuaranteeAnnotationKey: DeliveryGuaranteeEffectivelyOnce,
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: nil,
+		},
+		"invalid delivery guarantee annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						DeliveryGuaranteeAnnotationKey: "exactly-once",
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("exactly-once", "metadata.annotations.[eventing-kafka.knative.dev/delivery-guarantee]")
+				fe.Details = "expected one of 'at-most-once', 'at-least-once' or 'effectively-once'"
+				return fe
+			}(),
+		},
 	}
 
 	for n, test := range testCases {
@@ -160,3 +272,192 @@ func TestKafkaChannelValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaChannelTopicNameValidation(t *testing.T) {
+
+	const namespace = "namespace"
+	prefixedTopicName := func(namespace, name string) string {
+		return "knative-messaging-kafka." + namespace + "." + name
+	}
+	templatedTopicName := func(namespace, name string) string {
+		return "channels/" + namespace + "." + name
+	}
+	maxName := strings.Repeat("a", maxTopicNameLength-len(namespace)-1)
+	maxPrefixedName := strings.Repeat("a", maxTopicNameLength-len("knative-messaging-kafka.")-len(namespace)-1)
+
+	tooLong := func(name, topicName string) *apis.FieldError {
+		fe := apis.ErrInvalidValue(name, "metadata.name")
+		fe.Details = fmt.Sprintf("derived kafka topic name %q is %d characters, exceeding the maximum of %d", topicName, len(topicName), maxTopicNameLength)
+		return fe
+	}
+	illegal := func(name, topicName string) *apis.FieldError {
+		fe := apis.ErrInvalidValue(name, "metadata.name")
+		fe.Details = fmt.Sprintf("derived kafka topic name %q contains characters other than ASCII alphanumerics, '.', '_' and '-'", topicName)
+		return fe
+	}
+
+	testCases := map[string]struct {
+		name          string
+		topicNameFunc TopicNameFunc
+		update        bool
+		want          *apis.FieldError
+	}{
+		"short name": {
+			name: "channel",
+		},
+		"name at max topic name length": {
+			name: maxName,
+		},
+		"name just over max topic name length": {
+			name: maxName + "a",
+			want: tooLong(maxName+"a", namespace+"."+maxName+"a"),
+		},
+		"prefixed name at max topic name length": {
+			name:          maxPrefixedName,
+			topicNameFunc: prefixedTopicName,
+		},
+		"prefixed name just over max topic name length": {
+			name:          maxPrefixedName + "a",
+			topicNameFunc: prefixedTopicName,
+			want:          tooLong(maxPrefixedName+"a", prefixedTopicName(namespace, maxPrefixedName+"a")),
+		},
+		"name valid without prefix but too long with prefix": {
+			name:          maxName,
+			topicNameFunc: prefixedTopicName,
+			want:          tooLong(maxName, prefixedTopicName(namespace, maxName)),
+		},
+		"name with illegal characters": {
+			name: "channel:name",
+			want: illegal("channel:name", namespace+".channel:name"),
+		},
+		"template with illegal characters": {
+			name:          "channel",
+			topicNameFunc: templatedTopicName,
+			want:          illegal("channel", templatedTopicName(namespace, "channel")),
+		},
+		"name too long on update": {
+			name:   maxName + "a",
+			update: true,
+		},
+	}
+
+	for n, test := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := context.Background()
+			if test.topicNameFunc != nil {
+				ctx = WithTopicNameFunc(ctx, test.topicNameFunc)
+			}
+			if test.update {
+				ctx = apis.WithinUpdate(ctx, &KafkaChannel{})
+			}
+			cr := &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: test.name},
+				Spec:       KafkaChannelSpec{NumPartitions: 1, ReplicationFactor: 1},
+			}
+			got := cr.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: validate (-want, +got) = %v", n, diff)
+			}
+		})
+	}
+}
+
+func TestKafkaChannelImmutableValidation(t *testing.T) {
+
+	immutable := map[string]string{ImmutableAnnotationKey: "true"}
+	spec := KafkaChannelSpec{NumPartitions: 1, ReplicationFactor: 1}
+	changedSpec := KafkaChannelSpec{NumPartitions: 2, ReplicationFactor: 1}
+
+	testCases := map[string]struct {
+		origAnnotations    map[string]string
+		origSpec           KafkaChannelSpec
+		updatedAnnotations map[string]string
+		updatedSpec        KafkaChannelSpec
+		wantErr            bool
+	}{
+		"spec changed without annotation": {
+			origSpec:    spec,
+			updatedSpec: changedSpec,
+		},
+		"spec changed with annotation": {
+			origAnnotations:    immutable,
+			origSpec:           spec,
+			updatedAnnotations: immutable,
+			updatedSpec:        changedSpec,
+			wantErr:            true,
+		},
+		"subscribers changed with annotation": {
+			origAnnotations:    immutable,
+			origSpec:           spec,
+			updatedAnnotations: immutable,
+			updatedSpec: KafkaChannelSpec{
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+				ChannelableSpec: eventingduck.ChannelableSpec{
+					SubscribableSpec: eventingduck.SubscribableSpec{
+						Subscribers: []eventingduck.SubscriberSpec{{
+							SubscriberURI: apis.HTTP("subscriberendpoint"),
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		"spec changed with annotation set to false": {
+			origAnnotations:    map[string]string{ImmutableAnnotationKey: "false"},
+			origSpec:           spec,
+			updatedAnnotations: map[string]string{ImmutableAnnotationKey: "false"},
+			updatedSpec:        changedSpec,
+		},
+		"metadata changed with annotation": {
+			origAnnotations:    immutable,
+			origSpec:           spec,
+			updatedAnnotations: map[string]string{ImmutableAnnotationKey: "true", "foo": "bar"},
+			updatedSpec:        spec,
+		},
+		"annotation removed": {
+			origAnnotations: immutable,
+			origSpec:        spec,
+			updatedSpec:     spec,
+		},
+		"spec changed after annotation removed": {
+			origSpec:    spec,
+			updatedSpec: changedSpec,
+		},
+		"annotation removed and spec changed together": {
+			origAnnotations: immutable,
+			origSpec:        spec,
+			updatedSpec:     changedSpec,
+			wantErr:         true,
+		},
+		"annotation added and spec changed together": {
+			origSpec:           spec,
+			updatedAnnotations: immutable,
+			updatedSpec:        changedSpec,
+		},
+	}
+
+	for n, test := range testCases {
+		t.Run(n, func(t *testing.T) {
+			original := &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "channel", Annotations: test.origAnnotations},
+				Spec:       test.origSpec,
+			}
+			updated := &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "channel", Annotations: test.updatedAnnotations},
+				Spec:       test.updatedSpec,
+			}
+			got := updated.Validate(apis.WithinUpdate(context.Background(), original))
+			if test.wantErr {
+				if got == nil {
+					t.Fatalf("%s: expected an error, got none", n)
+				}
+				if !strings.Contains(got.Error(), ImmutableAnnotationKey) {
+					t.Errorf("%s: expected the error to reference the %q annotation, got %v", n, ImmutableAnnotationKey, got)
+				}
+			} else if got != nil {
+				t.Errorf("%s: expected no error, got %v", n, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,34 +34,222 @@ import (
 // stored in the config-eventing-kafka configmap.  The sub-structs are explicitly declared so that they
 // can have their own JSON tags in the overall EventingKafkaConfig
 type EKKubernetesConfig struct {
-	CpuLimit      resource.Quantity `json:"cpuLimit,omitempty"`
-	CpuRequest    resource.Quantity `json:"cpuRequest,omitempty"`
-	MemoryLimit   resource.Quantity `json:"memoryLimit,omitempty"`
-	MemoryRequest resource.Quantity `json:"memoryRequest,omitempty"`
-	Replicas      int               `json:"replicas,omitempty"`
+	CpuLimit                  resource.Quantity                 `json:"cpuLimit,omitempty"`
+	CpuRequest                resource.Quantity                 `json:"cpuRequest,omitempty"`
+	MemoryLimit               resource.Quantity                 `json:"memoryLimit,omitempty"`
+	MemoryRequest             resource.Quantity                 `json:"memoryRequest,omitempty"`
+	Replicas                  int                               `json:"replicas,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	Affinity                  *corev1.Affinity                  `json:"affinity,omitempty"`
+	NamingPolicy              string                            `json:"namingPolicy,omitempty"`
 }
 
-// The Receiver config has nothing in it except the base Kubernetes fields (Cpu, Memory, Replicas)
+// The Receiver config has the base Kubernetes fields (Cpu, Memory, Replicas, Scheduling), the deployment mode, the
+// provenance toggle, the partition key & empty data policies, the optional Ingress exposing the Receiver outside the cluster, the
+// optional heartbeat events, the optional local disk spool of events which could not be produced and the optional
+// per-channel rate limits
 type EKReceiverConfig struct {
 	EKKubernetesConfig
+	Mode                            string                    `json:"mode,omitempty"`
+	ProvenanceHeaders               bool                      `json:"provenanceHeaders,omitempty"`
+	PartitionKeyPolicy              string                    `json:"partitionKeyPolicy,omitempty"`
+	EmptyDataPolicy                 string                    `json:"emptyDataPolicy,omitempty"`
+	MaxEventAgeMillis               int64                     `json:"maxEventAgeMillis,omitempty"`
+	UnknownTopicPolicy              string                    `json:"unknownTopicPolicy,omitempty"`
+	UnknownTopicRetryTimeoutMillis  int64                     `json:"unknownTopicRetryTimeoutMillis,omitempty"`
+	UnknownTopicRetryIntervalMillis int64                     `json:"unknownTopicRetryIntervalMillis,omitempty"`
+	ProduceOrdering                 string                    `json:"produceOrdering,omitempty"`
+	PathPrefix                      string                    `json:"pathPrefix,omitempty"`
+	SeparateMetricsService          bool                      `json:"separateMetricsService,omitempty"`
+	Ingress                         EKReceiverIngressConfig   `json:"ingress,omitempty"`
+	Heartbeat                       EKReceiverHeartbeatConfig `json:"heartbeat,omitempty"`
+	Spool                           EKReceiverSpoolConfig     `json:"spool,omitempty"`
+	Audit                           EKReceiverAuditConfig     `json:"audit,omitempty"`
+	ProduceLatency                  EKReceiverLatencyConfig   `json:"produceLatency,omitempty"`
+	RateLimit                       EKReceiverRateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// The Receiver Ingress config controls whether (and how) an Ingress is reconciled for each Receiver Service
+type EKReceiverIngressConfig struct {
+	Enabled       bool              `json:"enabled,omitempty"`
+	Host          string            `json:"host,omitempty"`
+	ClassName     string            `json:"className,omitempty"`
+	TLSSecretName string            `json:"tlsSecretName,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// The Receiver Spool config enables spooling events to a local (persistent) volume when producing them fails
+type EKReceiverSpoolConfig struct {
+	Directory            string `json:"directory,omitempty"`
+	MaxBytes             int64  `json:"maxBytes,omitempty"`
+	ReplayIntervalMillis int64  `json:"replayIntervalMillis,omitempty"`
+}
+
+// The Receiver ProduceLatency config reports the time taken to produce each event to clients and against an SLO
+type EKReceiverLatencyConfig struct {
+	Header    bool  `json:"header,omitempty"`
+	SloMillis int64 `json:"sloMillis,omitempty"`
+}
+
+// The Receiver RateLimit config limits the events (and bytes) produced per second to each KafkaChannel
+type EKReceiverRateLimitConfig struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	BytesPerSecond    int64   `json:"bytesPerSecond,omitempty"`
+}
+
+// The Receiver Audit config enables posting the broker metadata of every produced event to an external audit sink
+type EKReceiverAuditConfig struct {
+	Url           string `json:"url,omitempty"`
+	QueueSize     int    `json:"queueSize,omitempty"`
+	TimeoutMillis int64  `json:"timeoutMillis,omitempty"`
+}
+
+// The Receiver Heartbeat config controls the synthetic events periodically produced to a KafkaChannel for monitoring
+type EKReceiverHeartbeatConfig struct {
+	IntervalMillis int64  `json:"intervalMillis,omitempty"`
+	Target         string `json:"target,omitempty"`
+	EventType      string `json:"eventType,omitempty"`
 }
 
 // The Dispatcher config has the base Kubernetes fields and some retry settings
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+	DeserializationFailurePolicy     string                             `json:"deserializationFailurePolicy,omitempty"`
+	TombstonePolicy                  string                             `json:"tombstonePolicy,omitempty"`
+	DecompressionFailurePolicy       string                             `json:"decompressionFailurePolicy,omitempty"`
+	DuplicateSubscriberPolicy        string                             `json:"duplicateSubscriberPolicy,omitempty"`
+	MissingSubscriberPolicy          string                             `json:"missingSubscriberPolicy,omitempty"`
+	GroupIdPolicy                    string                             `json:"groupIdPolicy,omitempty"`
+	GroupIdKey                       string                             `json:"groupIdKey,omitempty"`
+	GroupIdSanitizationPolicy        string                             `json:"groupIdSanitizationPolicy,omitempty"`
+	MaxMessageBytes                  int64                              `json:"maxMessageBytes,omitempty"`
+	OversizedMessagePolicy           string                             `json:"oversizedMessagePolicy,omitempty"`
+	StripProvenanceHeaders           bool                               `json:"stripProvenanceHeaders,omitempty"`
+	CommitBatchSize                  int                                `json:"commitBatchSize,omitempty"`
+	CommitBatchIntervalMillis        int64                              `json:"commitBatchIntervalMillis,omitempty"`
+	CommitOnRebalance                bool                               `json:"commitOnRebalance,omitempty"`
+	AuthCheckIntervalMillis          int64                              `json:"authCheckIntervalMillis,omitempty"`
+	TopicWaitTimeoutMillis           int64                              `json:"topicWaitTimeoutMillis,omitempty"`
+	TopicWaitIntervalMillis          int64                              `json:"topicWaitIntervalMillis,omitempty"`
+	ConfigMapWaitTimeoutMillis       int64                              `json:"configMapWaitTimeoutMillis,omitempty"`
+	BrokerUnavailablePolicy          string                             `json:"brokerUnavailablePolicy,omitempty"`
+	BrokerRetryIntervalMillis        int64                              `json:"brokerRetryIntervalMillis,omitempty"`
+	SessionLivenessTimeoutMillis     int64                              `json:"sessionLivenessTimeoutMillis,omitempty"`
+	ShutdownTimeoutMillis            int64                              `json:"shutdownTimeoutMillis,omitempty"`
+	MaxDeliveryConcurrency           int32                              `json:"maxDeliveryConcurrency,omitempty"`
+	ConsumerGroupEventIntervalMillis int64                              `json:"consumerGroupEventIntervalMillis,omitempty"`
+	TerminationGracePeriodSeconds    int64                              `json:"terminationGracePeriodSeconds,omitempty"`
+	DrainMarginMillis                int64                              `json:"drainMarginMillis,omitempty"`
+	OverloadPauseThreshold           int                                `json:"overloadPauseThreshold,omitempty"`
+	OverloadPauseCooldownMillis      int64                              `json:"overloadPauseCooldownMillis,omitempty"`
+	RetryBudgetMillis                int64                              `json:"retryBudgetMillis,omitempty"`
+	SuccessPredicate                 EKDispatcherSuccessPredicateConfig `json:"successPredicate,omitempty"`
+	ErrorLogSampling                 EKDispatcherErrorLogSamplingConfig `json:"errorLogSampling,omitempty"`
+	GroupErrorLogSampling            EKDispatcherErrorLogSamplingConfig `json:"groupErrorLogSampling,omitempty"`
+	CoordinatorRetryIntervalMillis   int64                              `json:"coordinatorRetryIntervalMillis,omitempty"`
+	ConsumeRetryIntervalMillis       int64                              `json:"consumeRetryIntervalMillis,omitempty"`
+	ReplyFailurePolicy               string                             `json:"replyFailurePolicy,omitempty"`
+	ReplyRetryMax                    int                                `json:"replyRetryMax,omitempty"`
+	ReplyRetryIntervalMillis         int64                              `json:"replyRetryIntervalMillis,omitempty"`
+	RedirectPolicy                   string                             `json:"redirectPolicy,omitempty"`
+	RedirectMax                      int                                `json:"redirectMax,omitempty"`
+	Extensions                       EKDispatcherExtensionsConfig       `json:"extensions,omitempty"`
+	Scaling                          EKDispatcherScalingConfig          `json:"scaling,omitempty"`
+	OffsetCacheTTLMillis             int64                              `json:"offsetCacheTTLMillis,omitempty"`
+	ConsumerLagIntervalMillis        int64                              `json:"consumerLagIntervalMillis,omitempty"`
+	SubscriptionSnapshot             EKDispatcherSnapshotConfig         `json:"subscriptionSnapshot,omitempty"`
+}
+
+// The Dispatcher SubscriptionSnapshot config enables persisting the active subscriptions for warm restarts
+type EKDispatcherSnapshotConfig struct {
+	Enabled      bool  `json:"enabled,omitempty"`
+	MaxAgeMillis int64 `json:"maxAgeMillis,omitempty"`
 }
 
-// EKKafkaTopicConfig contains some defaults that are only used if not provided by the channel spec
+// The Dispatcher Scaling config enables an endpoint reporting the consumer lag relative to a target for autoscalers
+type EKDispatcherScalingConfig struct {
+	TargetLag int64 `json:"targetLag,omitempty"`
+}
+
+// The Dispatcher Extensions config restricts the CloudEvent extension attributes propagated to subscribers
+type EKDispatcherExtensionsConfig struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// The Dispatcher SuccessPredicate config identifies failed deliveries to subscribers which respond 2xx with an error
+type EKDispatcherSuccessPredicateConfig struct {
+	Header      string `json:"header,omitempty"`
+	HeaderValue string `json:"headerValue,omitempty"`
+	JsonField   string `json:"jsonField,omitempty"`
+	JsonValue   string `json:"jsonValue,omitempty"`
+}
+
+// The Dispatcher ErrorLogSampling (and GroupErrorLogSampling) config limits the logging of repeated identical
+// subscriber delivery (and ConsumerGroup) errors
+type EKDispatcherErrorLogSamplingConfig struct {
+	IntervalMillis int64 `json:"intervalMillis,omitempty"`
+	First          int   `json:"first,omitempty"`
+	Thereafter     int   `json:"thereafter,omitempty"`
+}
+
+// EKKafkaTopicConfig contains some defaults that are only used if not provided by the channel spec, the optional
+// bounds (e.g. mirroring a broker's topic creation policy) within which the default retention must fall, the optional
+// maximum (e.g. mirroring a cluster's partition limit) which the partitions may not exceed, as well as the optional
+// warm-up of newly created topics
 type EKKafkaTopicConfig struct {
-	DefaultNumPartitions     int32 `json:"defaultNumPartitions,omitempty"`
-	DefaultReplicationFactor int16 `json:"defaultReplicationFactor,omitempty"`
-	DefaultRetentionMillis   int64 `json:"defaultRetentionMillis,omitempty"`
+	DefaultNumPartitions     int32                    `json:"defaultNumPartitions,omitempty"`
+	DefaultReplicationFactor int16                    `json:"defaultReplicationFactor,omitempty"`
+	DefaultRetentionMillis   int64                    `json:"defaultRetentionMillis,omitempty"`
+	MinRetentionMillis       int64                    `json:"minRetentionMillis,omitempty"`
+	MaxRetentionMillis       int64                    `json:"maxRetentionMillis,omitempty"`
+	MaxNumPartitions         int32                    `json:"maxNumPartitions,omitempty"`
+	Warmup                   EKKafkaTopicWarmupConfig `json:"warmup,omitempty"`
+}
+
+// EKKafkaTopicWarmupConfig enables producing a marker record to every partition of newly created Kafka topics
+type EKKafkaTopicWarmupConfig struct {
+	Enabled     bool   `json:"enabled,omitempty"`
+	MarkerValue string `json:"markerValue,omitempty"`
+}
+
+// EKKafkaSchemaRegistryConfig enables the schema registry wire format for produced / consumed Kafka message values
+type EKKafkaSchemaRegistryConfig struct {
+	Url        string `json:"url,omitempty"`
+	SchemaType string `json:"schemaType,omitempty"`
+	Schema     string `json:"schema,omitempty"`
+}
+
+// EKKafkaClientRackConfig identifies the rack (e.g. availability zone) of the Kafka clients, either statically or
+// via a label of the Kubernetes Node on which they are running (which takes precedence when present)
+type EKKafkaClientRackConfig struct {
+	RackId    string `json:"rackId,omitempty"`
+	NodeLabel string `json:"nodeLabel,omitempty"`
 }
 
-// EKKafkaConfig contains items relevant to Kafka specifically
+// EKKafkaConfig contains items relevant to Kafka specifically (ReadOnly prevents the controller from mutating Topics,
+// MaxConcurrentTopicOperations bounds the Topic operations the controller performs concurrently, SecretWaitTimeoutMillis
+// lets new KafkaChannels wait for their Kafka Secret to be created, DeprecatedFieldPolicy determines how the controller
+// handles KafkaChannels with deprecated spec fields, DeliveryOrderPolicy determines how it handles KafkaChannels
+// requesting ordered delivery with multiple partitions, BrokersChangePolicy determines whether the Receiver &
+// Dispatcher Deployments are rolled out when the brokers of their Kafka Secret change, and DefaultSecretName selects
+// the Kafka Secret of KafkaChannels whose namespace does not select one when multiple Kafka Secrets exist,
+// AuthValidationPolicy determines how strictly the consistency of the Sarama SASL & TLS settings is validated, and
+// MetadataTimeoutMillis bounds the controller's fetching of Topic metadata before requeueing the KafkaChannel)
 type EKKafkaConfig struct {
-	Topic     EKKafkaTopicConfig `json:"topic,omitempty"`
-	AdminType string             `json:"adminType,omitempty"`
+	Topic                        EKKafkaTopicConfig          `json:"topic,omitempty"`
+	AdminType                    string                      `json:"adminType,omitempty"`
+	ReadOnly                     bool                        `json:"readOnly,omitempty"`
+	MaxConcurrentTopicOperations int32                       `json:"maxConcurrentTopicOperations,omitempty"`
+	SecretWaitTimeoutMillis      int64                       `json:"secretWaitTimeoutMillis,omitempty"`
+	DeprecatedFieldPolicy        string                      `json:"deprecatedFieldPolicy,omitempty"`
+	DeliveryOrderPolicy          string                      `json:"deliveryOrderPolicy,omitempty"`
+	BrokersChangePolicy          string                      `json:"brokersChangePolicy,omitempty"`
+	DefaultSecretName            string                      `json:"defaultSecretName,omitempty"`
+	AuthValidationPolicy         string                      `json:"authValidationPolicy,omitempty"`
+	MetadataTimeoutMillis        int64                       `json:"metadataTimeoutMillis,omitempty"`
+	SchemaRegistry               EKKafkaSchemaRegistryConfig `json:"schemaRegistry,omitempty"`
+	ClientRack                   EKKafkaClientRackConfig     `json:"clientRack,omitempty"`
 }
 
 // EventingKafkaConfig is the main struct that holds the Receiver, Dispatcher, and Kafka sub-items
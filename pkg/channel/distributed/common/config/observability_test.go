@@ -84,7 +84,7 @@ func TestInitializeObservability(t *testing.T) {
 }
 
 func assertGet(t *testing.T, url string, expected int) {
-	resp, err := commontesting.RetryGet(url, 100*time.Millisecond, 20)
+	resp, err := commontesting.RetryGet(context.TODO(), url, 100*time.Millisecond, 20, false, 0)
 	assert.Nil(t, err)
 	assert.Equal(t, expected, resp.StatusCode)
 	err = resp.Body.Close()
@@ -26,9 +26,14 @@ const (
 	HealthPortEnvVarKey     = "HEALTH_PORT"
 
 	// Kafka Authorization
-	KafkaBrokerEnvVarKey   = "KAFKA_BROKERS"
-	KafkaUsernameEnvVarKey = "KAFKA_USERNAME"
-	KafkaPasswordEnvVarKey = "KAFKA_PASSWORD"
+	KafkaBrokerEnvVarKey        = "KAFKA_BROKERS"
+	KafkaUsernameEnvVarKey      = "KAFKA_USERNAME"
+	KafkaPasswordEnvVarKey      = "KAFKA_PASSWORD"
+	KafkaSaslMechanismEnvVarKey = "KAFKA_SASL_MECHANISM"
+
+	// Schema Registry Authorization
+	SchemaRegistryUsernameEnvVarKey = "SCHEMA_REGISTRY_USERNAME"
+	SchemaRegistryPasswordEnvVarKey = "SCHEMA_REGISTRY_PASSWORD"
 
 	// Kafka Configuration
 	KafkaTopicEnvVarKey = "KAFKA_TOPIC"
@@ -36,7 +41,15 @@ const (
 	// Knative Logging Configuration
 	KnativeLoggingConfigMapNameEnvVarKey = "CONFIG_LOGGING_NAME" // Note - Matches value of configMapNameEnv constant in Knative.dev/pkg/logging !
 
+	// Receiver Configuration
+	PodNameEnvVarKey = "POD_NAME"
+
 	// Dispatcher Configuration
-	ChannelKeyEnvVarKey  = "CHANNEL_KEY"
-	ServiceNameEnvVarKey = "SERVICE_NAME"
+	ChannelKeyEnvVarKey               = "CHANNEL_KEY"
+	ServiceNameEnvVarKey              = "SERVICE_NAME"
+	SubscriptionSnapshotPathEnvVarKey = "SUBSCRIPTION_SNAPSHOT_PATH"
+	NodeNameEnvVarKey                 = "NODE_NAME"
+	TerminationGracePeriodEnvVarKey   = "TERMINATION_GRACE_PERIOD_SECONDS"
+	DispatcherShardEnvVarKey          = "DISPATCHER_SHARD"
+	ConfigMapWaitTimeoutEnvVarKey     = "CONFIGMAP_WAIT_TIMEOUT_MILLIS"
 )
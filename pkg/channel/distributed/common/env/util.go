@@ -19,6 +19,7 @@ package env
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 
 	"go.uber.org/zap"
@@ -158,3 +159,21 @@ func GetRequiredQuantityConfigValue(logger *zap.Logger, envVarKey string) (*reso
 	// Return The Parsed Quantity
 	return &quantity, nil
 }
+
+// Verify That None Of The Specified (Named) Ports Are The Same, Returning An Error Naming The First Conflicting Ports
+func VerifyDistinctPorts(ports map[string]int) error {
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic Error Messages
+	portNames := make(map[int]string, len(ports))
+	for _, name := range names {
+		port := ports[name]
+		if existingName, ok := portNames[port]; ok {
+			return fmt.Errorf("port conflict: %s and %s are both configured as port %d", existingName, name, port)
+		}
+		portNames[port] = name
+	}
+	return nil
+}
@@ -239,3 +239,36 @@ func TestGetRequiredQuantityConfigValue(t *testing.T) {
 	assertErr(t, fmt.Sprintf("invalid (non quantity) value '%v' for environment variable '%v'", TestQuantityInvalidValue, TestQuantityEnvKey), err)
 	assert.Nil(t, result)
 }
+
+// Test The VerifyDistinctPorts() Functionality
+func TestVerifyDistinctPorts(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		ports   map[string]int
+		wantErr string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Distinct", ports: map[string]int{"HttpPort": 8080, "MetricsPort": 8081, "HealthPort": 8082}},
+		{name: "Empty", ports: map[string]int{}},
+		{name: "HTTP & Metrics Conflict", ports: map[string]int{"HttpPort": 8080, "MetricsPort": 8080, "HealthPort": 8082}, wantErr: "port conflict: HttpPort and MetricsPort are both configured as port 8080"},
+		{name: "HTTP & Health Conflict", ports: map[string]int{"HttpPort": 8080, "MetricsPort": 8081, "HealthPort": 8080}, wantErr: "port conflict: HealthPort and HttpPort are both configured as port 8080"},
+		{name: "Metrics & Health Conflict", ports: map[string]int{"HttpPort": 8080, "MetricsPort": 8082, "HealthPort": 8082}, wantErr: "port conflict: HealthPort and MetricsPort are both configured as port 8082"},
+		{name: "All Conflict", ports: map[string]int{"HttpPort": 8080, "MetricsPort": 8080, "HealthPort": 8080}, wantErr: "port conflict: HealthPort and HttpPort are both configured as port 8080"},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := VerifyDistinctPorts(testCase.ports)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -138,12 +138,17 @@ func TestHealthServer(t *testing.T) {
 	logger := logtesting.TestLogger(t).Desugar()
 
 	health := getTestHealthServer()
+	health.HandleFunc("/custom", func(responseWriter http.ResponseWriter, _ *http.Request) {
+		responseWriter.WriteHeader(http.StatusAccepted)
+	})
 	health.Start(logger)
 
 	livenessUri, err := url.Parse(fmt.Sprintf("http://%s:%s%s", testHttpHost, health.HttpPort, livenessPath))
 	assert.Nil(t, err)
 	readinessUri, err := url.Parse(fmt.Sprintf("http://%s:%s%s", testHttpHost, health.HttpPort, readinessPath))
 	assert.Nil(t, err)
+	customUri, err := url.Parse(fmt.Sprintf("http://%s:%s%s", testHttpHost, health.HttpPort, "/custom"))
+	assert.Nil(t, err)
 	waitServerReady(readinessUri.String(), 3*time.Second)
 
 	// Test basic functionality - advanced logical tests are in TestHealthHandler
@@ -151,6 +156,7 @@ func TestHealthServer(t *testing.T) {
 	getEventToServer(t, readinessUri, http.StatusOK)
 	health.SetAlive(true)
 	getEventToServer(t, livenessUri, http.StatusOK)
+	getEventToServer(t, customUri, http.StatusAccepted)
 
 	health.Stop(logger)
 
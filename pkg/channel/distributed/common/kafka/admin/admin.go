@@ -29,6 +29,11 @@ import (
 type AdminClientInterface interface {
 	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
 	DeleteTopic(context.Context, string) *sarama.TopicError
+	DescribeTopic(context.Context, string) *sarama.TopicError                            // Verifies Existence Without Mutation (ErrUnknownTopicOrPartition If Not Found)
+	DescribeTopicConfig(context.Context, string) (map[string]string, *sarama.TopicError) // The Topic-Level Config Overrides (Not Broker Defaults)
+	AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError     // Replaces All Topic-Level Config Overrides
+	DescribeTopicPartitions(context.Context, string) (int32, *sarama.TopicError)         // The Number Of Partitions Of An Existing Topic
+	CreatePartitions(context.Context, string, int32) *sarama.TopicError                  // Increases The Total Number Of Partitions Of A Topic
 	Close() error
 	GetKafkaSecretName(topicName string) string
 }
@@ -66,6 +71,9 @@ const (
 //
 // * If no authorization is required (local dev instance) then specify username and password as the empty string ""
 //
+// For the normal Kafka use case multiple Secrets (e.g. one per Kafka cluster) are also supported, provided that the
+// context selects one of them by name (see WithKafkaSecretName()).
+//
 func CreateAdminClient(ctx context.Context, saramaConfig *sarama.Config, clientId string, adminClientType AdminClientType) (AdminClientInterface, error) {
 	switch adminClientType {
 	case Kafka:
@@ -81,6 +89,20 @@ func CreateAdminClient(ctx context.Context, saramaConfig *sarama.Config, clientI
 	}
 }
 
+// Context Key Of The (Optional) Name Of The Kafka Secret Selected For A Kafka AdminClient
+type kafkaSecretNameKey struct{}
+
+// Return A Context Selecting The Named Kafka Secret For Kafka AdminClients Created With It (None If Empty)
+func WithKafkaSecretName(ctx context.Context, kafkaSecretName string) context.Context {
+	return context.WithValue(ctx, kafkaSecretNameKey{}, kafkaSecretName)
+}
+
+// Get The Name Of The Kafka Secret Selected By The Specified Context (Empty If None)
+func KafkaSecretNameFromContext(ctx context.Context) string {
+	kafkaSecretName, _ := ctx.Value(kafkaSecretNameKey{}).(string)
+	return kafkaSecretName
+}
+
 // New Kafka AdminClient Wrapper To Facilitate Unit Testing
 var NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (AdminClientInterface, error) {
 	return NewKafkaAdminClient(ctx, saramaConfig, clientId, namespace)
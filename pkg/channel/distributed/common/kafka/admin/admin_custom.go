@@ -187,6 +187,60 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Describing Topics (Verifying Existence)
+func (c *CustomAdminClient) DescribeTopic(_ context.Context, topicName string) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName))
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		logger.Warn("Received Empty/Nil Topic Configuration")
+		return adminutil.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Create Topics URL For Sidecar Endpoint (TopicName In GET URL!)
+	url := c.sidecarTopicsUrl(topicName)
+
+	// Create The HTTP GET Request
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		logger.Error("Failed To Create New HTTP GET Request", zap.String("URL", url), zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for description of topic '%s'", topicName))
+	}
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		logger.Error("HTTP GET Request To Describe Topic Failed", zap.Error(err))
+		return adminutil.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to make http request for description of topic '%s'", topicName))
+	}
+
+	// Map The HTTP Response Into A Sarama TopicError & Return
+	return c.mapHttpResponse("describe", response)
+}
+
+// Topic Config Is Not Supported By The Custom Sidecar REST API (Retention Is Only Applied When Creating The Topic)
+func (c *CustomAdminClient) DescribeTopicConfig(_ context.Context, topicName string) (map[string]string, *sarama.TopicError) {
+	return nil, adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("describing the config of topic '%s' is not supported by the custom sidecar", topicName))
+}
+
+// Topic Config Is Not Supported By The Custom Sidecar REST API (Retention Is Only Applied When Creating The Topic)
+func (c *CustomAdminClient) AlterTopicConfig(_ context.Context, topicName string, _ map[string]*string) *sarama.TopicError {
+	return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("altering the config of topic '%s' is not supported by the custom sidecar", topicName))
+}
+
+// Partition Counts Are Not Supported By The Custom Sidecar REST API (Partitions Are Only Applied When Creating The Topic)
+func (c *CustomAdminClient) DescribeTopicPartitions(_ context.Context, topicName string) (int32, *sarama.TopicError) {
+	return 0, adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("describing the partitions of topic '%s' is not supported by the custom sidecar", topicName))
+}
+
+// Partition Counts Are Not Supported By The Custom Sidecar REST API (Partitions Are Only Applied When Creating The Topic)
+func (c *CustomAdminClient) CreatePartitions(_ context.Context, topicName string, _ int32) *sarama.TopicError {
+	return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("creating partitions of topic '%s' is not supported by the custom sidecar", topicName))
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
@@ -244,7 +298,7 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 		switch {
 		case statusCode >= 200 && statusCode <= 299:
 			return adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("custom sidecar topic '%s' operation succeeded with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
-		case statusCode == 404 && operation == "delete": // 404 Not Found Indicates Topic Does Not Exist In Delete Operation
+		case statusCode == 404 && (operation == "delete" || operation == "describe"): // 404 Not Found Indicates Topic Does Not Exist In Delete / Describe Operations
 			return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
 		case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
 			return adminutil.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
@@ -197,6 +197,63 @@ func TestCustomAdminClientDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The Custom AdminClient DescribeTopic() Functionality
+func TestCustomAdminClientDescribeTopic(t *testing.T) {
+
+	// Test Data
+	namespace := "TestNamespace"
+	topicName := "TestTopicName"
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		statusCode int
+		wantErr    sarama.KError
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Existing Topic", statusCode: http.StatusOK, wantErr: sarama.ErrNoError},
+		{name: "Nonexistent Topic", statusCode: http.StatusNotFound, wantErr: sarama.ErrUnknownTopicOrPartition},
+		{name: "Sidecar Error", statusCode: http.StatusInternalServerError, wantErr: sarama.ErrInvalidRequest},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create & Start The Test Sidecar HTTP Server & Defer Close
+			mockSidecarServer := NewMockSidecarServer(t, testCase.statusCode)
+			mockSidecarServer.Start()
+			defer mockSidecarServer.Close()
+
+			// Create Test Kafka Secret With Dummy (But Valid) Data
+			kafkaSecret := createKafkaSecret("Name", namespace, "Brokers", "Username", "Password")
+
+			// Create A Context With Test Logger & K8S Client
+			logger := logtesting.TestLogger(t)
+			ctx := logging.WithLogger(context.TODO(), logger)
+			ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret))
+
+			// Create A New Custom AdminClient
+			adminClient, err := NewCustomAdminClient(ctx, namespace)
+			assert.Nil(t, err)
+			assert.NotNil(t, adminClient)
+
+			// Perform The Test
+			resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.wantErr, resultTopicError.Err)
+			assert.Equal(t, 1, len(mockSidecarServer.requests))
+			for request, body := range mockSidecarServer.requests {
+				verifySidecarRequest(t, request, body, topicName, nil)
+			}
+		})
+	}
+}
+
 // Test The Custom AdminClient Close() Functionality
 func TestCustomAdminClientClose(t *testing.T) {
 
@@ -314,7 +371,7 @@ func verifySidecarRequest(t *testing.T, request *http.Request, body []byte, topi
 		assert.Equal(t, saramaTopicDetail.ConfigEntries, customTopicDetail.ConfigEntries)
 		assert.Equal(t, saramaTopicDetail.ReplicaAssignment, customTopicDetail.ReplicaAssignment)
 
-	case http.MethodDelete:
+	case http.MethodDelete, http.MethodGet:
 		assert.Equal(t, custom.TopicsPath+"/"+topicName, request.URL.Path)
 		assert.Equal(t, "", request.Header.Get(custom.TopicNameHeader))
 		assert.Empty(t, body)
@@ -186,6 +186,45 @@ func (c *EventHubAdminClient) DeleteTopic(ctx context.Context, topicName string)
 	return adminutil.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
 }
 
+// Verify A Single Topic (EventHub) Exists Via The Azure EventHub API (Refreshing The Cache If Not Already Known)
+func (c *EventHubAdminClient) DescribeTopic(ctx context.Context, topicName string) *sarama.TopicError {
+
+	// EventHubs Created Externally Are Only Known After Refreshing The Namespace Cache
+	if c.cache.GetNamespace(topicName) == nil {
+		err := c.cache.Update(ctx)
+		if err != nil {
+			c.logger.Error("Failed To Update EventHub Cache", zap.String("TopicName", topicName), zap.Error(err))
+			return adminutil.PromoteErrorToTopicError(err)
+		}
+	}
+
+	// Return Whether The EventHub Was Found In Any Namespace
+	if c.cache.GetNamespace(topicName) == nil {
+		return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("no azure namespace found for EventHub '%s'", topicName))
+	}
+	return adminutil.NewTopicError(sarama.ErrNoError, "successfully described topic")
+}
+
+// Topic Config Is Not Supported By The Azure EventHub API (Retention Is Only Applied When Creating The EventHub)
+func (c *EventHubAdminClient) DescribeTopicConfig(_ context.Context, topicName string) (map[string]string, *sarama.TopicError) {
+	return nil, adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("describing the config of EventHub '%s' is not supported", topicName))
+}
+
+// Topic Config Is Not Supported By The Azure EventHub API (Retention Is Only Applied When Creating The EventHub)
+func (c *EventHubAdminClient) AlterTopicConfig(_ context.Context, topicName string, _ map[string]*string) *sarama.TopicError {
+	return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("altering the config of EventHub '%s' is not supported", topicName))
+}
+
+// Partition Counts Are Not Supported By The Azure EventHub API (Partitions Are Only Applied When Creating The EventHub)
+func (c *EventHubAdminClient) DescribeTopicPartitions(_ context.Context, topicName string) (int32, *sarama.TopicError) {
+	return 0, adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("describing the partitions of EventHub '%s' is not supported", topicName))
+}
+
+// Partition Counts Are Not Supported By The Azure EventHub API (Partitions Are Only Applied When Creating The EventHub)
+func (c *EventHubAdminClient) CreatePartitions(_ context.Context, topicName string, _ int32) *sarama.TopicError {
+	return adminutil.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("creating partitions of EventHub '%s' is not supported", topicName))
+}
+
 // Get The K8S Secret With Kafka Credentials For The Specified Topic (EventHub)
 func (c *EventHubAdminClient) GetKafkaSecretName(topicName string) string {
 
@@ -18,6 +18,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
@@ -514,6 +515,90 @@ func TestEventHubAdminClientDeleteTopicError(t *testing.T) {
 	mockCache.AssertExpectations(t)
 }
 
+// Test The EventHub AdminClient DescribeTopic() Functionality
+func TestEventHubAdminClientDescribeTopic(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	namespace := &eventhubcache.Namespace{}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		cached       bool
+		refreshed    bool
+		updateErr    error
+		wantUpdate   bool
+		wantTopicErr sarama.KError
+		wantTopicMsg string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Cached EventHub",
+			cached:       true,
+			wantTopicErr: sarama.ErrNoError,
+			wantTopicMsg: "successfully described topic",
+		},
+		{
+			name:         "EventHub Found After Cache Update",
+			refreshed:    true,
+			wantUpdate:   true,
+			wantTopicErr: sarama.ErrNoError,
+			wantTopicMsg: "successfully described topic",
+		},
+		{
+			name:         "Nonexistent EventHub",
+			wantUpdate:   true,
+			wantTopicErr: sarama.ErrUnknownTopicOrPartition,
+			wantTopicMsg: "no azure namespace found for EventHub 'TestTopicName'",
+		},
+		{
+			name:         "Cache Update Error",
+			updateErr:    errors.New("test cache update error"),
+			wantUpdate:   true,
+			wantTopicErr: sarama.ErrUnknown,
+			wantTopicMsg: "test cache update error",
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock EventHub Cache Which Only Knows The EventHub Once Cached / Refreshed
+			mockCache := &MockCache{}
+			if testCase.cached {
+				mockCache.On("GetNamespace", topicName).Return(namespace)
+			} else {
+				mockCache.On("GetNamespace", topicName).Return(nil).Once()
+				if testCase.refreshed {
+					mockCache.On("GetNamespace", topicName).Return(namespace)
+				} else if testCase.updateErr == nil {
+					mockCache.On("GetNamespace", topicName).Return(nil)
+				}
+			}
+			if testCase.wantUpdate {
+				mockCache.On("Update", ctx).Return(testCase.updateErr)
+			}
+
+			// Create A New EventHub AdminClient With Mock Cache To Test
+			adminClient := &EventHubAdminClient{logger: logtesting.TestLogger(t).Desugar(), cache: mockCache}
+
+			// Perform The Test
+			resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.wantTopicErr, resultTopicError.Err)
+			assert.Equal(t, testCase.wantTopicMsg, *resultTopicError.ErrMsg)
+			mockCache.AssertExpectations(t)
+		})
+	}
+}
+
 // Test The EventHub AdminClient GetKafkaSecretName() Functionality
 func TestEventHubAdminClientGetKafkaSecretName(t *testing.T) {
 
@@ -65,9 +65,23 @@ func NewKafkaAdminClient(ctx context.Context, saramaConfig *sarama.Config, clien
 		return nil, err
 	}
 
-	// Currently Only Support One Kafka Secret - Invalid AdminClient For All Other Cases!
+	// Use The Kafka Secret Selected By The Context (If Any), Otherwise Only Support One Kafka Secret - Invalid AdminClient For All Other Cases!
 	var kafkaSecret corev1.Secret
-	if len(kafkaSecrets.Items) != 1 {
+	if selectedSecretName := KafkaSecretNameFromContext(ctx); len(selectedSecretName) > 0 {
+		found := false
+		for _, secret := range kafkaSecrets.Items {
+			if secret.Name == selectedSecretName {
+				kafkaSecret = secret
+				found = true
+				break
+			}
+		}
+		if !found {
+			logger.Warn("Selected Kafka Secret Not Found - Kafka AdminClient Will Not Be Functional!", zap.String("Secret", selectedSecretName))
+			return &KafkaAdminClient{logger: logger, namespace: namespace, clientId: clientId}, nil
+		}
+		logger.Info("Found Selected Kafka Secret", zap.String("Secret", kafkaSecret.Name))
+	} else if len(kafkaSecrets.Items) != 1 {
 		logger.Warn(fmt.Sprintf("Expected 1 Kafka Secret But Found %d - Kafka AdminClient Will Not Be Functional!", len(kafkaSecrets.Items)))
 		return &KafkaAdminClient{logger: logger, namespace: namespace, clientId: clientId}, nil
 	} else {
@@ -85,9 +99,11 @@ func NewKafkaAdminClient(ctx context.Context, saramaConfig *sarama.Config, clien
 	brokers := strings.Split(string(kafkaSecret.Data[constants.KafkaSecretKeyBrokers]), ",")
 	username := string(kafkaSecret.Data[constants.KafkaSecretKeyUsername])
 	password := string(kafkaSecret.Data[constants.KafkaSecretKeyPassword])
+	saslMechanism := string(kafkaSecret.Data[constants.KafkaSecretKeySaslMechanism])
 
-	// Update The Sarama ClusterAdmin Configuration With Our Values
+	// Update The Sarama ClusterAdmin Configuration With Our Values (The SASL Mechanism Was Validated Above)
 	kafkasarama.UpdateSaramaConfig(saramaConfig, clientId, username, password)
+	_ = kafkasarama.UpdateSaramaSaslMechanism(saramaConfig, saslMechanism)
 
 	// Create A New Sarama ClusterAdmin
 	clusterAdmin, err := NewClusterAdminWrapper(brokers, saramaConfig)
@@ -137,6 +153,89 @@ func (k KafkaAdminClient) DeleteTopic(_ context.Context, topicName string) *sara
 	}
 }
 
+// Sarama Pass-Through Function For Describing Topics (Verifies The Topic Exists Without Modifying It)
+func (k KafkaAdminClient) DescribeTopic(_ context.Context, topicName string) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return adminutil.NewUnknownTopicError("unable to describe topic due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	topicMetadata, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return adminutil.PromoteErrorToTopicError(err)
+	}
+	for _, metadata := range topicMetadata {
+		if metadata != nil && metadata.Name == topicName {
+			return adminutil.NewTopicError(metadata.Err, fmt.Sprintf("described topic '%s'", topicName))
+		}
+	}
+	return adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("no metadata returned for topic '%s'", topicName))
+}
+
+// Sarama Pass-Through Function For Describing The Topic-Level Config Overrides Of A Topic (Excluding Broker Defaults)
+func (k KafkaAdminClient) DescribeTopicConfig(_ context.Context, topicName string) (map[string]string, *sarama.TopicError) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Config Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, adminutil.NewUnknownTopicError("unable to describe topic config due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	configEntries, err := k.clusterAdmin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName})
+	if err != nil {
+		return nil, adminutil.PromoteErrorToTopicError(err)
+	}
+	topicConfig := make(map[string]string)
+	for _, configEntry := range configEntries {
+		// The Source Is Only Reported By Newer Brokers, Which Otherwise Only Indicate Whether The Value Is A Default
+		if configEntry.Source == sarama.SourceTopic || (configEntry.Source == sarama.SourceUnknown && !configEntry.Default) {
+			topicConfig[configEntry.Name] = configEntry.Value
+		}
+	}
+	return topicConfig, adminutil.NewTopicError(sarama.ErrNoError, fmt.Sprintf("described config of topic '%s'", topicName))
+}
+
+// Sarama Pass-Through Function For Altering (Replacing) The Topic-Level Config Overrides Of A Topic
+func (k KafkaAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Alter Topic Config Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return adminutil.NewUnknownTopicError("unable to alter topic config due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	err := k.clusterAdmin.AlterConfig(sarama.TopicResource, topicName, configEntries, false)
+	return adminutil.PromoteErrorToTopicError(err)
+}
+
+// Sarama Pass-Through Function For Describing The Number Of Partitions Of A Topic
+func (k KafkaAdminClient) DescribeTopicPartitions(_ context.Context, topicName string) (int32, *sarama.TopicError) {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Describe Topic Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return 0, adminutil.NewUnknownTopicError("unable to describe topic partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	topicMetadata, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return 0, adminutil.PromoteErrorToTopicError(err)
+	}
+	for _, metadata := range topicMetadata {
+		if metadata != nil && metadata.Name == topicName {
+			return int32(len(metadata.Partitions)), adminutil.NewTopicError(metadata.Err, fmt.Sprintf("described partitions of topic '%s'", topicName))
+		}
+	}
+	return 0, adminutil.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("no metadata returned for topic '%s'", topicName))
+}
+
+// Sarama Pass-Through Function For Increasing The Total Number Of Partitions Of A Topic
+func (k KafkaAdminClient) CreatePartitions(_ context.Context, topicName string, count int32) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Create Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return adminutil.NewUnknownTopicError("unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	err := k.clusterAdmin.CreatePartitions(topicName, count, nil, false)
+	switch err := err.(type) {
+	case *sarama.TopicPartitionError: // Preserve The Kafka Error Code (e.g. ErrInvalidPartitions) Of The Broker Response
+		return adminutil.NewTopicError(err.Err, err.Error())
+	case sarama.KError:
+		return adminutil.NewTopicError(err, fmt.Sprintf("failed to create partitions of topic '%s'", topicName))
+	default:
+		return adminutil.PromoteErrorToTopicError(err)
+	}
+}
+
 // Sarama Pass-Through Function For Closing ClusterAdmin
 func (k KafkaAdminClient) Close() error {
 	if k.clusterAdmin == nil {
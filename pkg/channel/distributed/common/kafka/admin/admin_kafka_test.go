@@ -18,6 +18,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	"github.com/Shopify/sarama"
@@ -66,6 +67,7 @@ Metadata:
 
 	// Create Test Kafka Secret And ConfigMap
 	kafkaSecret := createKafkaSecret(kafkaSecretName, namespace, kafkaSecretBrokers, kafkaSecretUsername, kafkaSecretPassword)
+	kafkaSecret.Data[constants.KafkaSecretKeySaslMechanism] = []byte("scram-sha-512")
 	kafkaConfig := createKafkaConfig(config.SettingsConfigMapName, system.Namespace(), saramaSettings)
 
 	// Create A Context With Test Logger & K8S Client
@@ -85,6 +87,8 @@ Metadata:
 		assert.Equal(t, constants.ConfigKafkaVersionDefault, config.Version)
 		assert.Equal(t, kafkaSecretUsername, config.Net.SASL.User)
 		assert.Equal(t, kafkaSecretPassword, config.Net.SASL.Password)
+		assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), config.Net.SASL.Mechanism)
+		assert.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
 		return mockClusterAdmin, nil
 	}
 	defer func() {
@@ -118,6 +122,69 @@ func TestNewKafkaAdminClientNoSecrets(t *testing.T) {
 	assert.NotNil(t, adminClient)
 }
 
+// Test The NewKafkaAdminClient() Constructor - Selected Kafka Secret Path
+func TestNewKafkaAdminClientSelectedSecret(t *testing.T) {
+
+	// Test Data
+	clientId := "TestClientId"
+	namespace := "TestNamespace"
+	kafkaSecretName1 := "TestKafkaSecretName1"
+	kafkaSecretName2 := "TestKafkaSecretName2"
+	kafkaSecretBrokers2 := "TestKafkaSecretBrokers2"
+
+	// Setup Environment
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Create Multiple Test Kafka Secrets
+	kafkaSecret1 := createKafkaSecret(kafkaSecretName1, namespace, "TestKafkaSecretBrokers1", "TestUsername1", "TestPassword1")
+	kafkaSecret2 := createKafkaSecret(kafkaSecretName2, namespace, kafkaSecretBrokers2, "TestUsername2", "TestPassword2")
+
+	// Create A Context With Test Logger, K8S Client & Selected Kafka Secret
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret1, kafkaSecret2))
+	ctx = WithKafkaSecretName(ctx, kafkaSecretName2)
+
+	// Mock The Sarama ClusterAdmin Creation For Testing
+	newClusterAdminWrapperPlaceholder := NewClusterAdminWrapper
+	NewClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+		assert.Equal(t, []string{kafkaSecretBrokers2}, brokers)
+		return &MockClusterAdmin{}, nil
+	}
+	defer func() {
+		NewClusterAdminWrapper = newClusterAdminWrapperPlaceholder
+	}()
+
+	// Perform The Test
+	adminClient, err := NewKafkaAdminClient(ctx, commontesting.GetDefaultSaramaConfig(t), clientId, namespace)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	assert.Equal(t, kafkaSecretName2, adminClient.GetKafkaSecretName("TestTopicName"))
+}
+
+// Test The NewKafkaAdminClient() Constructor - Selected Kafka Secret Not Found Path
+func TestNewKafkaAdminClientSelectedSecretNotFound(t *testing.T) {
+
+	// Test Data
+	clientId := "TestClientId"
+	namespace := "TestNamespace"
+
+	// Create A Context With Test Logger, K8S Client & Selected Kafka Secret
+	kafkaSecret := createKafkaSecret("TestKafkaSecretName", namespace, "TestKafkaSecretBrokers", "TestUsername", "TestPassword")
+	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
+	ctx = context.WithValue(ctx, injectionclient.Key{}, fake.NewSimpleClientset(kafkaSecret))
+	ctx = WithKafkaSecretName(ctx, "OtherKafkaSecretName")
+
+	// Perform The Test
+	adminClient, err := NewKafkaAdminClient(ctx, commontesting.GetDefaultSaramaConfig(t), clientId, namespace)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.NotNil(t, adminClient)
+	assert.Equal(t, "", adminClient.GetKafkaSecretName("TestTopicName"))
+}
+
 // Test The Kafka AdminClient CreateTopic() Functionality
 func TestKafkaAdminClientCreateTopic(t *testing.T) {
 
@@ -262,6 +329,270 @@ func TestKafkaAdminClientDeleteTopicInvalidAdminClient(t *testing.T) {
 	assert.Equal(t, errMsg, *resultTopicError.ErrMsg)
 }
 
+// Test The Kafka AdminClient DescribeTopic() Functionality
+func TestKafkaAdminClientDescribeTopic(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name     string
+		metadata []*sarama.TopicMetadata
+		err      error
+		wantErr  sarama.KError
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:     "Existing Topic",
+			metadata: []*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrNoError}},
+			wantErr:  sarama.ErrNoError,
+		},
+		{
+			name:     "Nonexistent Topic",
+			metadata: []*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrUnknownTopicOrPartition}},
+			wantErr:  sarama.ErrUnknownTopicOrPartition,
+		},
+		{
+			name:     "Missing Metadata",
+			metadata: []*sarama.TopicMetadata{},
+			wantErr:  sarama.ErrUnknownTopicOrPartition,
+		},
+		{
+			name:     "Describe Error",
+			metadata: []*sarama.TopicMetadata{},
+			err:      errors.New("test DescribeTopics() error"),
+			wantErr:  sarama.ErrUnknown,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Sarama ClusterAdmin To Test Against
+			mockClusterAdmin := &MockClusterAdmin{}
+			mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return(testCase.metadata, testCase.err)
+
+			// Create A New Kafka AdminClient To Test
+			adminClient := &KafkaAdminClient{
+				logger:       logtesting.TestLogger(t).Desugar(),
+				clusterAdmin: mockClusterAdmin,
+			}
+
+			// Perform The Test
+			resultTopicError := adminClient.DescribeTopic(ctx, topicName)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.wantErr, resultTopicError.Err)
+			mockClusterAdmin.AssertExpectations(t)
+		})
+	}
+}
+
+// Test The Kafka AdminClient DescribeTopic() Without AdminClient Functionality
+func TestKafkaAdminClientDescribeTopicInvalidAdminClient(t *testing.T) {
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+
+	// Perform The Test
+	resultTopicError := adminClient.DescribeTopic(context.TODO(), "TestTopicName")
+
+	// Verify The Results
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+	assert.Equal(t, "unable to describe topic due to invalid ClusterAdmin - check Kafka authorization secrets", *resultTopicError.ErrMsg)
+}
+
+// Test The Kafka AdminClient DescribeTopicConfig() Functionality
+func TestKafkaAdminClientDescribeTopicConfig(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	configResource := sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}
+
+	// Create A Mock Sarama ClusterAdmin Returning Topic Overrides, Defaults & (Older Broker) Non-Defaults
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeConfig", configResource).Return([]sarama.ConfigEntry{
+		{Name: constants.TopicDetailConfigRetentionMs, Value: "3600000", Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: sarama.SourceDefault, Default: true},
+		{Name: "min.insync.replicas", Value: "2", Source: sarama.SourceStaticBroker},
+		{Name: "unclean.leader.election.enable", Value: "false", Source: sarama.SourceUnknown},
+		{Name: "segment.ms", Value: "604800000", Source: sarama.SourceUnknown, Default: true},
+	}, nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Test
+	topicConfig, resultTopicError := adminClient.DescribeTopicConfig(ctx, topicName)
+
+	// Verify Only The Topic-Level Overrides Are Returned
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+	assert.Equal(t, map[string]string{constants.TopicDetailConfigRetentionMs: "3600000", "unclean.leader.election.enable": "false"}, topicConfig)
+	mockClusterAdmin.AssertExpectations(t)
+
+	// Verify Errors Are Promoted To TopicErrors
+	mockClusterAdmin = &MockClusterAdmin{}
+	mockClusterAdmin.On("DescribeConfig", configResource).Return([]sarama.ConfigEntry{}, errors.New("test DescribeConfig() error"))
+	adminClient.clusterAdmin = mockClusterAdmin
+	topicConfig, resultTopicError = adminClient.DescribeTopicConfig(ctx, topicName)
+	assert.Nil(t, topicConfig)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+
+	// Verify An Invalid AdminClient Fails
+	adminClient.clusterAdmin = nil
+	_, resultTopicError = adminClient.DescribeTopicConfig(ctx, topicName)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+}
+
+// Test The Kafka AdminClient AlterTopicConfig() Functionality
+func TestKafkaAdminClientAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	retentionMillis := "3600000"
+	configEntries := map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis}
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("AlterConfig", sarama.TopicResource, topicName, configEntries).Return(nil)
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Test & Verify The Results
+	assert.Nil(t, adminClient.AlterTopicConfig(ctx, topicName, configEntries))
+	mockClusterAdmin.AssertExpectations(t)
+
+	// Verify An Invalid AdminClient Fails
+	adminClient.clusterAdmin = nil
+	resultTopicError := adminClient.AlterTopicConfig(ctx, topicName, configEntries)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+}
+
+// Test The Kafka AdminClient DescribeTopicPartitions() Functionality
+func TestKafkaAdminClientDescribeTopicPartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		metadata       []*sarama.TopicMetadata
+		err            error
+		wantPartitions int32
+		wantErr        sarama.KError
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Existing Topic",
+			metadata:       []*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrNoError, Partitions: []*sarama.PartitionMetadata{{ID: 0}, {ID: 1}, {ID: 2}}}},
+			wantPartitions: 3,
+			wantErr:        sarama.ErrNoError,
+		},
+		{
+			name:     "Nonexistent Topic",
+			metadata: []*sarama.TopicMetadata{{Name: topicName, Err: sarama.ErrUnknownTopicOrPartition}},
+			wantErr:  sarama.ErrUnknownTopicOrPartition,
+		},
+		{
+			name:     "Missing Metadata",
+			metadata: []*sarama.TopicMetadata{},
+			wantErr:  sarama.ErrUnknownTopicOrPartition,
+		},
+		{
+			name:     "Describe Error",
+			metadata: []*sarama.TopicMetadata{},
+			err:      errors.New("test DescribeTopics() error"),
+			wantErr:  sarama.ErrUnknown,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Sarama ClusterAdmin To Test Against
+			mockClusterAdmin := &MockClusterAdmin{}
+			mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return(testCase.metadata, testCase.err)
+
+			// Create A New Kafka AdminClient To Test
+			adminClient := &KafkaAdminClient{
+				logger:       logtesting.TestLogger(t).Desugar(),
+				clusterAdmin: mockClusterAdmin,
+			}
+
+			// Perform The Test
+			partitions, resultTopicError := adminClient.DescribeTopicPartitions(ctx, topicName)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.wantErr, resultTopicError.Err)
+			assert.Equal(t, testCase.wantPartitions, partitions)
+			mockClusterAdmin.AssertExpectations(t)
+		})
+	}
+
+	// Verify An Invalid AdminClient Fails
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+	_, resultTopicError := adminClient.DescribeTopicPartitions(ctx, topicName)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+}
+
+// Test The Kafka AdminClient CreatePartitions() Functionality
+func TestKafkaAdminClientCreatePartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+
+	// Create A Mock Sarama ClusterAdmin To Test Against
+	mockClusterAdmin := &MockClusterAdmin{}
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(6)).Return(nil)
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(2)).Return(&sarama.TopicPartitionError{Err: sarama.ErrInvalidPartitions})
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(3)).Return(sarama.ErrInvalidTopic)
+	mockClusterAdmin.On("CreatePartitions", topicName, int32(4)).Return(errors.New("test CreatePartitions() error"))
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar(), clusterAdmin: mockClusterAdmin}
+
+	// Perform The Tests & Verify The Results
+	assert.Nil(t, adminClient.CreatePartitions(ctx, topicName, 6))
+	resultTopicError := adminClient.CreatePartitions(ctx, topicName, 2)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidPartitions, resultTopicError.Err)
+	resultTopicError = adminClient.CreatePartitions(ctx, topicName, 3)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrInvalidTopic, resultTopicError.Err)
+	resultTopicError = adminClient.CreatePartitions(ctx, topicName, 4)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+	mockClusterAdmin.AssertExpectations(t)
+
+	// Verify An Invalid AdminClient Fails
+	adminClient.clusterAdmin = nil
+	resultTopicError = adminClient.CreatePartitions(ctx, topicName, 6)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+}
+
 // Test The Kafka AdminClient Close() Functionality
 func TestKafkaAdminClientClose(t *testing.T) {
 
@@ -380,7 +711,8 @@ func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
 }
 
 func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
-	panic("implement me")
+	args := m.Called(topics)
+	return args.Get(0).([]*sarama.TopicMetadata), args.Error(1)
 }
 
 func (m *MockClusterAdmin) DeleteTopic(topic string) error {
@@ -389,7 +721,8 @@ func (m *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(topic, count)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -405,11 +738,13 @@ func (m *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int3
 }
 
 func (m *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
-	panic("implement me")
+	args := m.Called(resource)
+	return args.Get(0).([]sarama.ConfigEntry), args.Error(1)
 }
 
 func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(resourceType, name, entries)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
@@ -29,6 +29,14 @@ import (
 // Mock AdminClient Reference
 var mockAdminClient AdminClientInterface
 
+// Test The Kafka Secret Name Context Functionality
+func TestKafkaSecretNameContext(t *testing.T) {
+	ctx := context.TODO()
+	assert.Equal(t, "", KafkaSecretNameFromContext(ctx))
+	ctx = WithKafkaSecretName(ctx, "TestKafkaSecretName")
+	assert.Equal(t, "TestKafkaSecretName", KafkaSecretNameFromContext(ctx))
+}
+
 // Test The CreateAdminClient() Kafka Functionality
 func TestCreateAdminClientKafka(t *testing.T) {
 
@@ -153,6 +161,26 @@ func (c MockAdminClient) DeleteTopic(context.Context, string) *sarama.TopicError
 	return nil
 }
 
+func (c MockAdminClient) DescribeTopic(context.Context, string) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) DescribeTopicConfig(context.Context, string) (map[string]string, *sarama.TopicError) {
+	return nil, nil
+}
+
+func (c MockAdminClient) AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError {
+	return nil
+}
+
+func (c MockAdminClient) DescribeTopicPartitions(context.Context, string) (int32, *sarama.TopicError) {
+	return 0, nil
+}
+
+func (c MockAdminClient) CreatePartitions(context.Context, string, int32) *sarama.TopicError {
+	return nil
+}
+
 func (c MockAdminClient) Close() error {
 	return nil
 }
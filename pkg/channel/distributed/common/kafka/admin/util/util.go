@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 )
 
 // Utility Function For Getting All (Limit 100) The Kafka Secrets In A K8S Namespace
@@ -48,9 +49,11 @@ func ValidateKafkaSecret(logger *zap.Logger, secret *corev1.Secret) bool {
 		brokers := string(secret.Data[constants.KafkaSecretKeyBrokers])
 		username := string(secret.Data[constants.KafkaSecretKeyUsername])
 		password := string(secret.Data[constants.KafkaSecretKeyPassword])
+		saslMechanism := string(secret.Data[constants.KafkaSecretKeySaslMechanism])
+		_, saslMechanismErr := kafkasarama.ParseSaslMechanism(saslMechanism)
 
 		// Validate Kafka Secret Data (Allowing for Kafka not having Authentication enabled)
-		if len(brokers) > 0 && len(username) >= 0 && len(password) >= 0 {
+		if len(brokers) > 0 && len(username) >= 0 && len(password) >= 0 && saslMechanismErr == nil {
 
 			// Mark Kafka Secret As Valid
 			valid = true
@@ -66,7 +69,8 @@ func ValidateKafkaSecret(logger *zap.Logger, secret *corev1.Secret) bool {
 				zap.String("Name", secret.Name),
 				zap.String("Brokers", brokers),
 				zap.String("Username", username),
-				zap.String("Password", pwdString))
+				zap.String("Password", pwdString),
+				zap.String("SaslMechanism", saslMechanism))
 		}
 	}
 
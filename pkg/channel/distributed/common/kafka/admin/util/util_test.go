@@ -94,6 +94,26 @@ func TestValidateKafkaSecret(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Valid Kafka Secret (SCRAM Auth)",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyBrokers:       []byte(brokers),
+				constants.KafkaSecretKeyUsername:      []byte(username),
+				constants.KafkaSecretKeyPassword:      []byte(password),
+				constants.KafkaSecretKeySaslMechanism: []byte("SCRAM-SHA-512"),
+			},
+			want: true,
+		},
+		{
+			name: "Invalid Kafka Secret (Unknown SASL Mechanism)",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyBrokers:       []byte(brokers),
+				constants.KafkaSecretKeyUsername:      []byte(username),
+				constants.KafkaSecretKeyPassword:      []byte(password),
+				constants.KafkaSecretKeySaslMechanism: []byte("SCRAM-SHA-1"),
+			},
+			want: false,
+		},
 		{
 			name: "Invalid Kafka Secret (No Brokers)",
 			data: map[string][]byte{
@@ -34,6 +34,15 @@ const (
 	KafkaSecretKeyUsername  = "username"
 	KafkaSecretKeyPassword  = "password"
 
+	// Optional Kafka Secret Key For The SASL Mechanism (e.g. "SCRAM-SHA-512", Defaulting To The Sarama Settings)
+	KafkaSecretKeySaslMechanism = "saslmechanism"
+
+	// Policies For Validating The Consistency Of The Sarama SASL & TLS Settings
+	AuthValidationPolicyConsistent = "consistent" // Reject Settings Which Are Contradictory (e.g. A SCRAM Mechanism With SASL Disabled)
+	AuthValidationPolicySecure     = "secure"     // Also Reject SASL Without TLS (Credentials Sent In The Clear)
+	AuthValidationPolicyNone       = "none"       // Don't Validate The Settings (Sarama Only Logs Some Inconsistencies)
+	DefaultAuthValidationPolicy    = AuthValidationPolicyConsistent
+
 	// Kafka Admin/Consumer/Producer Config Values
 	ConfigNetSaslVersion = sarama.SASLHandshakeV1 // Latest version, seems to work with EventHubs as well.
 
@@ -49,6 +58,13 @@ const (
 	// EventHub Constraints
 	MaxEventHubNamespaces = 100
 
+	// Provenance Kafka Header Keys (Identify The Receiver Pod & KafkaChannel Which Produced A Message)
+	ProvenanceHeaderKeyProducerPod = "ek-producer-pod"
+	ProvenanceHeaderKeyChannel     = "ek-channel"
+
+	// Topic Warm-Up Kafka Header Key (Identifies The Marker Records Produced To Each Partition Of A New Topic)
+	WarmupMarkerHeaderKey = "ek-warmup-marker"
+
 	// KafkaChannel Constants
 	KafkaChannelServiceNameSuffix = "kn-channel" // Specific Value For Use With Knative e2e Tests!
 )
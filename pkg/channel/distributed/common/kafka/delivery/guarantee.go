@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// How The Dispatcher Commits The Offsets Of Consumed Messages
+const (
+	CommitModeBeforeDelivery = "before-delivery" // Mark & Commit Each Message Before Delivering It (Never Redelivered)
+	CommitModeAfterDelivery  = "after-delivery"  // Mark Only Delivered Messages & Commit Them Explicitly (Otherwise Redelivered)
+)
+
+// Kafka Topic Configuration Applied By The Delivery Guarantees
+const (
+	TopicConfigUncleanLeaderElection = "unclean.leader.election.enable"
+)
+
+//
+// The Lower-Level Settings Required To Achieve A KafkaChannel's Delivery Guarantee
+//
+// These are applied to the receiver's Producer, the Topic and the dispatcher's ConsumerGroups in place of the
+// corresponding Sarama configuration.  Sarama does not support transactional producers, so "effectively-once" is
+// achieved (short of exactly-once) by an idempotent producer, which the broker de-duplicates retried produce
+// requests of, together with read-committed consumption and commits after delivery.
+//
+type Settings struct {
+	Idempotent            bool                  // Producer.Idempotent (Also Requires A Single Open Request Per Broker)
+	RequiredAcks          sarama.RequiredAcks   // Producer.RequiredAcks
+	ProducerRetries       bool                  // Whether Failed Produce Requests May Be Retried (Producer.Retry.Max Is Zeroed If Not)
+	IsolationLevel        sarama.IsolationLevel // Consumer.IsolationLevel
+	CommitMode            string                // One Of The CommitMode* Values
+	UncleanLeaderElection bool                  // Whether Out-Of-Sync Replicas May Become Leader (Losing Acknowledged Records)
+}
+
+// The Settings Of Each Supported Delivery Guarantee
+var guaranteeSettings = map[string]Settings{
+	kafkav1beta1.DeliveryGuaranteeAtMostOnce: {
+		Idempotent:            false,
+		RequiredAcks:          sarama.WaitForLocal,
+		ProducerRetries:       false,
+		IsolationLevel:        sarama.ReadUncommitted,
+		CommitMode:            CommitModeBeforeDelivery,
+		UncleanLeaderElection: true,
+	},
+	kafkav1beta1.DeliveryGuaranteeAtLeastOnce: {
+		Idempotent:            false,
+		RequiredAcks:          sarama.WaitForAll,
+		ProducerRetries:       true,
+		IsolationLevel:        sarama.ReadUncommitted,
+		CommitMode:            CommitModeAfterDelivery,
+		UncleanLeaderElection: false,
+	},
+	kafkav1beta1.DeliveryGuaranteeEffectivelyOnce: {
+		Idempotent:            true,
+		RequiredAcks:          sarama.WaitForAll,
+		ProducerRetries:       true,
+		IsolationLevel:        sarama.ReadCommitted,
+		CommitMode:            CommitModeAfterDelivery,
+		UncleanLeaderElection: false,
+	},
+}
+
+// Get The Settings Of The Specified Delivery Guarantee (Nil If Empty, Meaning The Sarama Configuration Applies As-Is)
+func GetSettings(guarantee string) (*Settings, error) {
+	if len(guarantee) <= 0 {
+		return nil, nil
+	}
+	settings, ok := guaranteeSettings[guarantee]
+	if !ok {
+		return nil, fmt.Errorf("invalid %s annotation: '%s' must be one of '%s', '%s' or '%s'", kafkav1beta1.DeliveryGuaranteeAnnotationKey, guarantee,
+			kafkav1beta1.DeliveryGuaranteeAtMostOnce, kafkav1beta1.DeliveryGuaranteeAtLeastOnce, kafkav1beta1.DeliveryGuaranteeEffectivelyOnce)
+	}
+	return &settings, nil
+}
+
+//
+// Validate That The Specified Delivery Guarantee Is Compatible With The Specified Sarama Configuration
+//
+// The settings a guarantee consists of override those of the Sarama configuration, but it also relies upon some which
+// it does not override.  Explicitly disabling producer retries undermines the guarantees which never lose acknowledged
+// events, and idempotent production & read-committed consumption require Kafka 0.11.  Such incompatible settings are
+// rejected rather than silently overridden, so that the guarantee is never weaker than requested.
+//
+func Validate(config *sarama.Config, guarantee string) error {
+
+	settings, err := GetSettings(guarantee)
+	if err != nil || settings == nil {
+		return err
+	}
+
+	if settings.ProducerRetries && config.Producer.Retry.Max < 1 {
+		return fmt.Errorf("%s delivery requires producer retries, but Producer.Retry.Max is %d", guarantee, config.Producer.Retry.Max)
+	}
+	if (settings.Idempotent || settings.IsolationLevel == sarama.ReadCommitted) && !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return fmt.Errorf("%s delivery requires Kafka version >= %s (configured version is %s)", guarantee, sarama.V0_11_0_0, config.Version)
+	}
+
+	return nil
+}
+
+// Get A Copy Of The Specified Sarama Configuration With The Producer Settings Of The Specified Delivery Guarantee Applied
+func ConfigureProducer(config *sarama.Config, guarantee string) (*sarama.Config, error) {
+
+	settings, err := GetSettings(guarantee)
+	if err != nil {
+		return nil, err
+	} else if settings == nil {
+		return config, nil
+	} else if err = Validate(config, guarantee); err != nil {
+		return nil, err
+	}
+
+	producerConfig := *config
+	producerConfig.Producer.Idempotent = settings.Idempotent
+	producerConfig.Producer.RequiredAcks = settings.RequiredAcks
+	if !settings.ProducerRetries {
+		producerConfig.Producer.Retry.Max = 0
+	}
+	if settings.Idempotent {
+		producerConfig.Net.MaxOpenRequests = 1
+	}
+	return &producerConfig, nil
+}
+
+// Get A Copy Of The Specified Sarama Configuration With The Consumer Settings Of The Specified Delivery Guarantee Applied
+func ConfigureConsumer(config *sarama.Config, guarantee string) (*sarama.Config, error) {
+
+	settings, err := GetSettings(guarantee)
+	if err != nil {
+		return nil, err
+	} else if settings == nil {
+		return config, nil
+	} else if err = Validate(config, guarantee); err != nil {
+		return nil, err
+	}
+
+	// Auto-Commit Is Disabled As Offsets Are Instead Committed Explicitly Before / After Delivery
+	consumerConfig := *config
+	consumerConfig.Consumer.IsolationLevel = settings.IsolationLevel
+	consumerConfig.Consumer.Offsets.AutoCommit.Enable = false
+	return &consumerConfig, nil
+}
+
+// Get The Kafka Topic Configuration Of The Specified Delivery Guarantee (Empty If None)
+func TopicConfigEntries(guarantee string) (map[string]string, error) {
+
+	settings, err := GetSettings(guarantee)
+	if err != nil || settings == nil {
+		return map[string]string{}, err
+	}
+
+	return map[string]string{
+		TopicConfigUncleanLeaderElection: strconv.FormatBool(settings.UncleanLeaderElection),
+	}, nil
+}
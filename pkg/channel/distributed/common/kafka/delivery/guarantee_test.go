@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+)
+
+// Create A Sarama Config With Settings Opposed To Those Of The Delivery Guarantees
+func createTestConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.ClientID = "TestClientId"
+	config.Version = sarama.V2_0_0_0
+	config.Net.MaxOpenRequests = 5
+	config.Producer.Idempotent = false
+	config.Producer.RequiredAcks = sarama.NoResponse
+	config.Producer.Retry.Max = 3
+	config.Consumer.IsolationLevel = sarama.ReadUncommitted
+	config.Consumer.Offsets.AutoCommit.Enable = true
+	return config
+}
+
+// Test That Each Delivery Guarantee Wires The Expected Producer Settings
+func TestConfigureProducer(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name                string
+		guarantee           string
+		wantIdempotent      bool
+		wantRequiredAcks    sarama.RequiredAcks
+		wantRetryMax        int
+		wantMaxOpenRequests int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Guarantee", guarantee: "", wantIdempotent: false, wantRequiredAcks: sarama.NoResponse, wantRetryMax: 3, wantMaxOpenRequests: 5},
+		{name: "At Most Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, wantIdempotent: false, wantRequiredAcks: sarama.WaitForLocal, wantRetryMax: 0, wantMaxOpenRequests: 5},
+		{name: "At Least Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, wantIdempotent: false, wantRequiredAcks: sarama.WaitForAll, wantRetryMax: 3, wantMaxOpenRequests: 5},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, wantIdempotent: true, wantRequiredAcks: sarama.WaitForAll, wantRetryMax: 3, wantMaxOpenRequests: 1},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createTestConfig()
+			producerConfig, err := ConfigureProducer(config, testCase.guarantee)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.wantIdempotent, producerConfig.Producer.Idempotent)
+			assert.Equal(t, testCase.wantRequiredAcks, producerConfig.Producer.RequiredAcks)
+			assert.Equal(t, testCase.wantRetryMax, producerConfig.Producer.Retry.Max)
+			assert.Equal(t, testCase.wantMaxOpenRequests, producerConfig.Net.MaxOpenRequests)
+			assert.Equal(t, config.ClientID, producerConfig.ClientID)
+			assert.Nil(t, producerConfig.Validate())
+
+			// Verify The Original Config Is Not Modified
+			assert.False(t, config.Producer.Idempotent)
+			assert.Equal(t, sarama.NoResponse, config.Producer.RequiredAcks)
+			assert.Equal(t, 3, config.Producer.Retry.Max)
+			assert.Equal(t, 5, config.Net.MaxOpenRequests)
+		})
+	}
+}
+
+// Test That Each Delivery Guarantee Wires The Expected Consumer Settings
+func TestConfigureConsumer(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name               string
+		guarantee          string
+		wantIsolationLevel sarama.IsolationLevel
+		wantAutoCommit     bool
+		wantCommitMode     string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Guarantee", guarantee: "", wantIsolationLevel: sarama.ReadUncommitted, wantAutoCommit: true},
+		{name: "At Most Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, wantIsolationLevel: sarama.ReadUncommitted, wantAutoCommit: false, wantCommitMode: CommitModeBeforeDelivery},
+		{name: "At Least Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, wantIsolationLevel: sarama.ReadUncommitted, wantAutoCommit: false, wantCommitMode: CommitModeAfterDelivery},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, wantIsolationLevel: sarama.ReadCommitted, wantAutoCommit: false, wantCommitMode: CommitModeAfterDelivery},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createTestConfig()
+			consumerConfig, err := ConfigureConsumer(config, testCase.guarantee)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.wantIsolationLevel, consumerConfig.Consumer.IsolationLevel)
+			assert.Equal(t, testCase.wantAutoCommit, consumerConfig.Consumer.Offsets.AutoCommit.Enable)
+			assert.Equal(t, sarama.ReadUncommitted, config.Consumer.IsolationLevel)
+			assert.True(t, config.Consumer.Offsets.AutoCommit.Enable)
+
+			settings, err := GetSettings(testCase.guarantee)
+			assert.Nil(t, err)
+			if len(testCase.wantCommitMode) > 0 {
+				assert.Equal(t, testCase.wantCommitMode, settings.CommitMode)
+			} else {
+				assert.Nil(t, settings)
+			}
+		})
+	}
+}
+
+// Test That Each Delivery Guarantee Wires The Expected Topic Configuration
+func TestTopicConfigEntries(t *testing.T) {
+	for guarantee, want := range map[string]map[string]string{
+		"":                                       {},
+		kafkav1beta1.DeliveryGuaranteeAtMostOnce: {TopicConfigUncleanLeaderElection: "true"},
+		kafkav1beta1.DeliveryGuaranteeAtLeastOnce:     {TopicConfigUncleanLeaderElection: "false"},
+		kafkav1beta1.DeliveryGuaranteeEffectivelyOnce: {TopicConfigUncleanLeaderElection: "false"},
+	} {
+		configEntries, err := TopicConfigEntries(guarantee)
+		assert.Nil(t, err)
+		assert.Equal(t, want, configEntries, guarantee)
+	}
+}
+
+// Test That Invalid Delivery Guarantees & Incompatible Sarama Settings Are Rejected
+func TestValidate(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		guarantee string
+		version   sarama.KafkaVersion
+		retryMax  int
+		wantErr   bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Guarantee", guarantee: "", version: sarama.V0_10_2_0, retryMax: 0},
+		{name: "Invalid Guarantee", guarantee: "exactly-once", version: sarama.V2_0_0_0, retryMax: 3, wantErr: true},
+		{name: "At Most Once Without Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, version: sarama.V0_10_2_0, retryMax: 0},
+		{name: "At Least Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, version: sarama.V0_10_2_0, retryMax: 3},
+		{name: "At Least Once Without Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, version: sarama.V2_0_0_0, retryMax: 0, wantErr: true},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, version: sarama.V0_11_0_0, retryMax: 3},
+		{name: "Effectively Once Without Retries", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, version: sarama.V2_0_0_0, retryMax: 0, wantErr: true},
+		{name: "Effectively Once Unsupported By Version", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, version: sarama.V0_10_2_0, retryMax: 3, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createTestConfig()
+			config.Version = testCase.version
+			config.Producer.Retry.Max = testCase.retryMax
+
+			assert.Equal(t, testCase.wantErr, Validate(config, testCase.guarantee) != nil)
+
+			// Verify The Incompatible Settings Are Also Rejected When Configuring The Producer & Consumer
+			producerConfig, err := ConfigureProducer(config, testCase.guarantee)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantErr, producerConfig == nil)
+			consumerConfig, err := ConfigureConsumer(config, testCase.guarantee)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantErr, consumerConfig == nil)
+		})
+	}
+}
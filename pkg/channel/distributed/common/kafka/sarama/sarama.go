@@ -24,12 +24,16 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/ghodss/yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
@@ -63,6 +67,75 @@ func UpdateSaramaConfig(config *sarama.Config, clientId string, username string,
 	config.Producer.Return.Successes = true
 }
 
+//
+// Utility Function For Setting The Client Rack (For Rack-Aware Fetching) From The Specified Configuration
+//
+// When a NodeLabel is configured the rack is read from that label (e.g. "topology.kubernetes.io/zone") of the
+// Kubernetes Node with the specified name (as exposed to the Pod via the downward API's "spec.nodeName").  The
+// static RackId is used if no NodeLabel is configured, or if the Node does not have the label.  The provided
+// context must have a Kubernetes client associated with it when a NodeLabel is configured.
+//
+func UpdateSaramaRackId(ctx context.Context, config *sarama.Config, clientRack commonconfig.EKKafkaClientRackConfig, nodeName string) error {
+
+	// Default To The Static RackId
+	rackId := clientRack.RackId
+
+	// Override With The Node's Label If Configured & Present
+	if len(clientRack.NodeLabel) > 0 {
+		if len(nodeName) <= 0 {
+			return fmt.Errorf("unable to determine the client rack from node label '%s' - node name is unknown", clientRack.NodeLabel)
+		}
+		node, err := kubeclient.Get(ctx).CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to determine the client rack from node label '%s' - failed to get node '%s': %w", clientRack.NodeLabel, nodeName, err)
+		}
+		if nodeRackId, ok := node.Labels[clientRack.NodeLabel]; ok && len(nodeRackId) > 0 {
+			rackId = nodeRackId
+		}
+	}
+
+	// Set The Client Rack (Leaving Any RackID From The Sarama Settings If None Is Configured)
+	if len(rackId) > 0 {
+		config.RackID = rackId
+	}
+	return nil
+}
+
+// The SASL Mechanisms Which May Be Specified By The Kafka Secret (Matched Case-Insensitively)
+var saslMechanisms = []sarama.SASLMechanism{sarama.SASLTypePlaintext, sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512}
+
+// Parse The Specified SASL Mechanism (e.g. From The Kafka Secret), Returning An Empty Mechanism If Unspecified
+func ParseSaslMechanism(mechanism string) (sarama.SASLMechanism, error) {
+	if len(mechanism) <= 0 {
+		return "", nil
+	}
+	for _, saslMechanism := range saslMechanisms {
+		if strings.EqualFold(mechanism, string(saslMechanism)) {
+			return saslMechanism, nil
+		}
+	}
+	return "", fmt.Errorf("invalid / unknown sasl mechanism '%s' - must be one of %v", mechanism, saslMechanisms)
+}
+
+//
+// Utility Function For Setting The SASL Mechanism (e.g. From The Kafka Secret) Of The Specified Configuration
+//
+// The SCRAM mechanisms additionally require a SCRAM client, which is provided via the Net.SASL.SCRAMClientGeneratorFunc.
+// An unspecified (empty) mechanism leaves the Sarama settings unchanged (i.e. PLAIN unless otherwise configured), and
+// an unsupported mechanism is rejected without modifying the configuration.
+//
+func UpdateSaramaSaslMechanism(config *sarama.Config, mechanism string) error {
+	saslMechanism, err := ParseSaslMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	if len(saslMechanism) > 0 {
+		config.Net.SASL.Mechanism = saslMechanism
+		config.Net.SASL.SCRAMClientGeneratorFunc = scramClientGenerators[saslMechanism]
+	}
+	return nil
+}
+
 //
 // Extract (Parse & Remove) Top Level Kafka Version From Specified Sarama Confirm YAML String
 //
@@ -226,8 +299,13 @@ func ConfigEqual(config1, config2 *sarama.Config, ignore ...interface{}) bool {
 
 	ignoredUnexported := cmpopts.IgnoreUnexported(config1.Version, x509.CertPool{}, tls.Config{})
 
+	// Functions are never equal unless nil, so the SCRAM client generator (which is determined by the SASL
+	// Mechanism that is compared) is also ignored.
+
+	ignoredFields := cmpopts.IgnoreFields(sarama.Config{}, "Net.SASL.SCRAMClientGeneratorFunc")
+
 	// Compare the two sarama config structs, ignoring types and unexported fields as specified
-	return cmp.Equal(config1, config2, ignoredTypes, ignoredUnexported)
+	return cmp.Equal(config1, config2, ignoredTypes, ignoredUnexported, ignoredFields)
 }
 
 // Extract The Sarama-Specific Settings From A ConfigMap And Merge Them With Existing Settings
@@ -280,10 +358,133 @@ func MergeSaramaSettings(config *sarama.Config, configMap *corev1.ConfigMap) (*s
 		config.Net.TLS.Config = &tls.Config{RootCAs: certPool}
 	}
 
+	// Validate The ConsumerGroup Rebalance Timeout
+	err = validateRebalanceTimeout(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate The Producer Flush (Batching) Triggers
+	err = validateProducerFlush(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate The SASL & TLS Settings (With The Policy From The Same ConfigMap, Whose Errors Are Reported Elsewhere)
+	authValidationPolicy := ""
+	if eventingKafkaConfig, ekErr := LoadEventingKafkaSettings(configMap); ekErr == nil && eventingKafkaConfig != nil {
+		authValidationPolicy = eventingKafkaConfig.Kafka.AuthValidationPolicy
+	}
+	err = validateAuthentication(config, authValidationPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return Success
 	return config, nil
 }
 
+//
+// Validate The Consumer.Group.Rebalance.Timeout Against The Consumer.Group.Session.Timeout
+//
+// The rebalance timeout is the maximum time the Kafka group coordinator will wait for all members
+// to rejoin the ConsumerGroup during a rebalance, and a member must finish processing its current
+// message (ConsumeClaim() must return) before it can rejoin.  Large ConsumerGroups may therefore need
+// a longer timeout to avoid members being evicted, which in turn triggers further rebalances.  Sarama
+// does not enforce any relationship with the session timeout, but a rebalance timeout shorter than the
+// session timeout would evict members which are still considered alive, so it is rejected here.
+//
+// Note that Consumer.MaxProcessingTime is unrelated - it only controls how long Sarama waits for a
+// message to be processed before pausing fetches for that partition, and does not bound how long a
+// Dispatcher may spend delivering (and retrying) a message before it is able to rejoin the group.
+//
+func validateRebalanceTimeout(config *sarama.Config) error {
+	rebalanceTimeout := config.Consumer.Group.Rebalance.Timeout
+	sessionTimeout := config.Consumer.Group.Session.Timeout
+	if rebalanceTimeout < sessionTimeout {
+		return fmt.Errorf("invalid sarama configuration: Consumer.Group.Rebalance.Timeout (%v) must be greater than or equal to Consumer.Group.Session.Timeout (%v)", rebalanceTimeout, sessionTimeout)
+	}
+	return nil
+}
+
+//
+// Validate The Producer.Flush Settings Which Control The Batching Of Produce Requests
+//
+// By default Sarama sends each message as soon as possible, whereas setting Producer.Flush.Bytes and/or
+// Producer.Flush.Messages batches messages (improving throughput at the cost of latency) until either trigger
+// is reached.  Sarama merely logs a warning when neither is accompanied by a Producer.Flush.Frequency, but a
+// partial batch would then wait indefinitely for further messages, which (with the synchronous producer used by
+// the Receiver) holds the sender's request open, so that configuration is rejected here.  Similarly, limiting
+// the batch size with Producer.Flush.MaxMessages is meaningless without a trigger to accumulate the batch, and
+// a Producer.Flush.Bytes at or above the Sarama MaxRequestSize is silently ignored by Sarama.
+//
+func validateProducerFlush(config *sarama.Config) error {
+	flush := config.Producer.Flush
+	switch {
+	case flush.Bytes < 0 || flush.Messages < 0 || flush.Frequency < 0 || flush.MaxMessages < 0:
+		return fmt.Errorf("invalid sarama configuration: Producer.Flush.Bytes (%d), Messages (%d), Frequency (%v) and MaxMessages (%d) must not be negative", flush.Bytes, flush.Messages, flush.Frequency, flush.MaxMessages)
+	case (flush.Bytes > 0 || flush.Messages > 0) && flush.Frequency <= 0:
+		return fmt.Errorf("invalid sarama configuration: Producer.Flush.Frequency must be set when Producer.Flush.Bytes (%d) or Producer.Flush.Messages (%d) are set, or partial batches may never be sent", flush.Bytes, flush.Messages)
+	case flush.MaxMessages > 0 && flush.Bytes <= 0 && flush.Messages <= 0 && flush.Frequency <= 0:
+		return fmt.Errorf("invalid sarama configuration: Producer.Flush.MaxMessages (%d) requires at least one of Producer.Flush.Bytes, Messages or Frequency to batch messages", flush.MaxMessages)
+	case flush.MaxMessages > 0 && flush.Messages > flush.MaxMessages:
+		return fmt.Errorf("invalid sarama configuration: Producer.Flush.MaxMessages (%d) must be greater than or equal to Producer.Flush.Messages (%d)", flush.MaxMessages, flush.Messages)
+	case flush.Bytes >= int(sarama.MaxRequestSize):
+		return fmt.Errorf("invalid sarama configuration: Producer.Flush.Bytes (%d) must be less than the maximum request size (%d)", flush.Bytes, sarama.MaxRequestSize)
+	}
+	return nil
+}
+
+// Validate The Specified AuthValidationPolicy & Return It (Or The Default If Unspecified)
+func ParseAuthValidationPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultAuthValidationPolicy, nil
+	case constants.AuthValidationPolicyConsistent, constants.AuthValidationPolicySecure, constants.AuthValidationPolicyNone:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid auth validation policy '%s' - must be one of '%s', '%s' or '%s'", policy,
+			constants.AuthValidationPolicyConsistent, constants.AuthValidationPolicySecure, constants.AuthValidationPolicyNone)
+	}
+}
+
+//
+// Validate The Consistency Of The Net.SASL & Net.TLS Settings According To The Specified AuthValidationPolicy
+//
+// Sarama silently ignores (or merely logs) SASL & TLS settings which have no effect because they are not enabled,
+// so an inconsistent combination only surfaces as a confusing authentication failure against the brokers.  The
+// "consistent" policy rejects a SCRAM mechanism while SASL is disabled (PLAIN is Sarama's implicit default and so
+// is not considered "set"), TLS root or client certificates while TLS is disabled, and client certificates (mTLS)
+// together with SASL, as the brokers authenticate a client with one or the other.  The "secure" policy additionally
+// rejects SASL without TLS, which sends the credentials (or SCRAM exchange) in the clear, and "none" skips all of it.
+//
+func validateAuthentication(config *sarama.Config, policy string) error {
+	policy, err := ParseAuthValidationPolicy(policy)
+	if err != nil {
+		return err
+	} else if policy == constants.AuthValidationPolicyNone {
+		return nil
+	}
+
+	sasl := config.Net.SASL
+	tlsConfig := config.Net.TLS.Config
+	hasRootCerts := tlsConfig != nil && tlsConfig.RootCAs != nil
+	hasClientCerts := tlsConfig != nil && (len(tlsConfig.Certificates) > 0 || tlsConfig.GetClientCertificate != nil)
+	switch {
+	case !sasl.Enable && sasl.Mechanism != "" && sasl.Mechanism != sarama.SASLTypePlaintext:
+		return fmt.Errorf("invalid sarama configuration: Net.SASL.Mechanism (%s) is set but Net.SASL.Enable is false", sasl.Mechanism)
+	case !config.Net.TLS.Enable && hasRootCerts:
+		return fmt.Errorf("invalid sarama configuration: Net.TLS.Config.RootPEMs are set but Net.TLS.Enable is false")
+	case !config.Net.TLS.Enable && hasClientCerts:
+		return fmt.Errorf("invalid sarama configuration: Net.TLS.Config client certificates (mTLS) are set but Net.TLS.Enable is false")
+	case sasl.Enable && hasClientCerts:
+		return fmt.Errorf("invalid sarama configuration: Net.SASL.Enable and Net.TLS.Config client certificates (mTLS) are mutually exclusive")
+	case policy == constants.AuthValidationPolicySecure && sasl.Enable && !config.Net.TLS.Enable:
+		return fmt.Errorf("invalid sarama configuration: Net.SASL.Enable requires Net.TLS.Enable with the '%s' auth validation policy", constants.AuthValidationPolicySecure)
+	}
+	return nil
+}
+
 // Load The Sarama & EventingKafka Configuration From The ConfigMap
 // The Provided Context Must Have A Kubernetes Client Associated With It
 func LoadSettings(ctx context.Context) (*sarama.Config, *commonconfig.EventingKafkaConfig, error) {
@@ -296,21 +497,60 @@ func LoadSettings(ctx context.Context) (*sarama.Config, *commonconfig.EventingKa
 		return nil, nil, err
 	}
 
+	// Load The EventingKafka Configuration From The ConfigMap
+	eventingKafkaConfig, err := LoadEventingKafkaSettings(configMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Merge The Sarama Settings In The ConfigMap Into A New Base Sarama Config
+	saramaConfig, err := MergeSaramaSettings(nil, configMap)
+
+	return saramaConfig, eventingKafkaConfig, err
+}
+
+// Load The EventingKafka Configuration From The Specified ConfigMap (e.g. One Received By A ConfigMap Watcher)
+func LoadEventingKafkaSettings(configMap *corev1.ConfigMap) (*commonconfig.EventingKafkaConfig, error) {
+
 	// Validate The ConfigMap Data
-	if configMap.Data == nil {
-		return nil, nil, fmt.Errorf("attempted to load configuration from empty configmap")
+	if configMap == nil || configMap.Data == nil {
+		return nil, fmt.Errorf("attempted to load configuration from empty configmap")
 	}
 
 	// Unmarshal The Eventing-Kafka ConfigMap YAML Into A EventingKafkaSettings Struct
 	eventingKafkaConfigString := configMap.Data[commonconfig.EventingKafkaSettingsConfigKey]
 	eventingKafkaConfig := &commonconfig.EventingKafkaConfig{}
-	err = yaml.Unmarshal([]byte(eventingKafkaConfigString), &eventingKafkaConfig)
+	err := yaml.Unmarshal([]byte(eventingKafkaConfigString), &eventingKafkaConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("ConfigMap's eventing-kafka value could not be converted to an EventingKafkaConfig struct: %s : %v", err, eventingKafkaConfigString)
+		return nil, fmt.Errorf("ConfigMap's eventing-kafka value could not be converted to an EventingKafkaConfig struct: %s : %v", err, eventingKafkaConfigString)
 	}
+	return eventingKafkaConfig, nil
+}
 
-	// Merge The Sarama Settings In The ConfigMap Into A New Base Sarama Config
-	saramaConfig, err := MergeSaramaSettings(nil, configMap)
+//
+// Load The Sarama & EventingKafka Configuration, Waiting For The ConfigMap To Exist
+//
+// When the ConfigMap has not yet been created (e.g. while the installation is still being applied) LoadSettings fails,
+// and a component which continued without it would run with no configuration.  Instead the ConfigMap is polled every
+// interval until it exists or the Context is done.  Any other failure to load the settings (e.g. invalid YAML) is
+// returned immediately, as waiting would not resolve it.  The Provided Context Must Have A Kubernetes Client.
+//
+func WaitForSettings(ctx context.Context, logger *zap.Logger, interval time.Duration) (*sarama.Config, *commonconfig.EventingKafkaConfig, error) {
+	logger = logger.With(zap.String("ConfigMap", commonconfig.SettingsConfigMapName))
+	for {
+
+		// Load The Settings & Return Unless The ConfigMap Does Not Yet Exist
+		saramaConfig, eventingKafkaConfig, err := LoadSettings(ctx)
+		if !errors.IsNotFound(err) {
+			return saramaConfig, eventingKafkaConfig, err
+		}
+		logger.Info("Waiting For ConfigMap To Exist", zap.Duration("Interval", interval), zap.Error(err))
 
-	return saramaConfig, eventingKafkaConfig, err
+		// Wait For The Next Poll (Or Give Up)
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("configmap '%s' not available (%v): %w", commonconfig.SettingsConfigMapName, err, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
 }
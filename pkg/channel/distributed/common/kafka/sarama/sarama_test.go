@@ -28,12 +28,15 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 	injectionclient "knative.dev/pkg/client/injection/kube/client"
+	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 )
 
@@ -190,6 +193,93 @@ func TestUpdateSaramaConfig(t *testing.T) {
 	assert.Nil(t, config.Net.TLS.Config)
 }
 
+// Test The UpdateSaramaRackId() Functionality
+func TestUpdateSaramaRackId(t *testing.T) {
+
+	// Test Data
+	nodeName := "TestNodeName"
+	zoneLabel := "topology.kubernetes.io/zone"
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: map[string]string{zoneLabel: "us-east-1a"}}}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name       string
+		clientRack commonconfig.EKKafkaClientRackConfig
+		nodeName   string
+		wantRackId string
+		wantErr    bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unconfigured", clientRack: commonconfig.EKKafkaClientRackConfig{}, nodeName: nodeName, wantRackId: ""},
+		{name: "Static RackId", clientRack: commonconfig.EKKafkaClientRackConfig{RackId: "rack-1"}, nodeName: nodeName, wantRackId: "rack-1"},
+		{name: "Node Label", clientRack: commonconfig.EKKafkaClientRackConfig{NodeLabel: zoneLabel}, nodeName: nodeName, wantRackId: "us-east-1a"},
+		{name: "Node Label Overrides Static RackId", clientRack: commonconfig.EKKafkaClientRackConfig{RackId: "rack-1", NodeLabel: zoneLabel}, nodeName: nodeName, wantRackId: "us-east-1a"},
+		{name: "Missing Node Label Falls Back To Static RackId", clientRack: commonconfig.EKKafkaClientRackConfig{RackId: "rack-1", NodeLabel: "missing"}, nodeName: nodeName, wantRackId: "rack-1"},
+		{name: "Unknown Node Name", clientRack: commonconfig.EKKafkaClientRackConfig{NodeLabel: zoneLabel}, nodeName: "", wantErr: true},
+		{name: "Missing Node", clientRack: commonconfig.EKKafkaClientRackConfig{NodeLabel: zoneLabel}, nodeName: "missing", wantErr: true},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Context With A Fake K8S Client Containing The Node
+			ctx := context.WithValue(context.Background(), injectionclient.Key{}, fake.NewSimpleClientset(node))
+
+			// Perform The Test
+			config := sarama.NewConfig()
+			err := UpdateSaramaRackId(ctx, config, testCase.clientRack, testCase.nodeName)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantRackId, config.RackID)
+		})
+	}
+}
+
+// Test The UpdateSaramaSaslMechanism() Functionality
+func TestUpdateSaramaSaslMechanism(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name          string
+		mechanism     string
+		wantMechanism sarama.SASLMechanism
+		wantScram     bool
+		wantErr       bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", mechanism: "", wantMechanism: ""},
+		{name: "Plain", mechanism: "PLAIN", wantMechanism: sarama.SASLTypePlaintext},
+		{name: "SCRAM-SHA-256", mechanism: "SCRAM-SHA-256", wantMechanism: sarama.SASLTypeSCRAMSHA256, wantScram: true},
+		{name: "SCRAM-SHA-512", mechanism: "SCRAM-SHA-512", wantMechanism: sarama.SASLTypeSCRAMSHA512, wantScram: true},
+		{name: "Lowercase SCRAM-SHA-512", mechanism: "scram-sha-512", wantMechanism: sarama.SASLTypeSCRAMSHA512, wantScram: true},
+		{name: "Unsupported", mechanism: "GSSAPI", wantMechanism: "", wantErr: true},
+		{name: "Unknown", mechanism: "foo", wantMechanism: "", wantErr: true},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := sarama.NewConfig()
+			err := UpdateSaramaSaslMechanism(config, testCase.mechanism)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantMechanism, config.Net.SASL.Mechanism)
+			assert.Equal(t, testCase.wantScram, config.Net.SASL.SCRAMClientGeneratorFunc != nil)
+			if testCase.wantScram {
+				config.Net.SASL.Enable = true
+				config.Net.SASL.User = "TestUsername"
+				config.Net.SASL.Password = "TestPassword"
+				assert.Nil(t, config.Validate())
+			}
+		})
+	}
+}
+
 // This test is specifically to validate that our default settings (used in 200-eventing-kafka-configmap.yaml)
 // are valid.  If the defaults in the file change, change this test to match for verification purposes.
 func TestLoadDefaultSaramaSettings(t *testing.T) {
@@ -287,6 +377,400 @@ func TestMergeSaramaSettings(t *testing.T) {
 	assert.True(t, config.Net.TLS.Config.InsecureSkipVerify)
 }
 
+// Verify The Consumer.Group.Rebalance.Timeout Is Merged & Validated Against The Session Timeout
+func TestMergeSaramaSettingsRebalanceTimeout(t *testing.T) {
+
+	// Get A Default Sarama Config For Verification Of Unspecified Timeouts
+	defaultConfig := sarama.NewConfig()
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                     string
+		consumerGroupYaml        string
+		expectedRebalanceTimeout time.Duration
+		expectErr                bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:                     "Default Rebalance Timeout",
+			expectedRebalanceTimeout: defaultConfig.Consumer.Group.Rebalance.Timeout,
+		},
+		{
+			name: "Custom Rebalance Timeout",
+			consumerGroupYaml: `
+  Group:
+    Rebalance:
+      Timeout: 300000000000
+`,
+			expectedRebalanceTimeout: 5 * time.Minute,
+		},
+		{
+			name: "Rebalance Timeout Equal To Session Timeout",
+			consumerGroupYaml: `
+  Group:
+    Session:
+      Timeout: 30000000000
+    Rebalance:
+      Timeout: 30000000000
+`,
+			expectedRebalanceTimeout: 30 * time.Second,
+		},
+		{
+			name: "Rebalance Timeout Less Than Session Timeout",
+			consumerGroupYaml: `
+  Group:
+    Rebalance:
+      Timeout: 5000000000
+`,
+			expectErr: true,
+		},
+		{
+			name: "Session Timeout Greater Than Default Rebalance Timeout",
+			consumerGroupYaml: `
+  Group:
+    Session:
+      Timeout: 120000000000
+`,
+			expectErr: true,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Append The ConsumerGroup YAML To The (Trailing) Consumer Section Of The Default Sarama Config
+			configMap := commontesting.GetTestSaramaConfigMap(EKDefaultSaramaConfig+testCase.consumerGroupYaml, EKDefaultConfigYaml)
+
+			// Perform The Test
+			config, err := MergeSaramaSettings(nil, configMap)
+
+			// Verify The Results
+			if testCase.expectErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, config)
+				assert.Equal(t, testCase.expectedRebalanceTimeout, config.Consumer.Group.Rebalance.Timeout)
+				assert.NotNil(t, config.Consumer.Group.Rebalance.Strategy)
+			}
+		})
+	}
+}
+
+// Verify The Consumer.Offsets.AutoCommit Settings Are Merged Without Being Forced On
+func TestMergeSaramaSettingsAutoCommit(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name             string
+		autoCommitYaml   string
+		expectedEnable   bool
+		expectedInterval time.Duration
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name: "Auto-Commit Enabled By Default",
+			autoCommitYaml: `
+    AutoCommit:
+        Interval: 5000000000
+`,
+			expectedEnable:   true,
+			expectedInterval: 5 * time.Second,
+		},
+		{
+			name: "Auto-Commit Disabled",
+			autoCommitYaml: `
+    AutoCommit:
+        Enable: false
+        Interval: 2000000000
+`,
+			expectedEnable:   false,
+			expectedInterval: 2 * time.Second,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Replace The AutoCommit Section Of The Default Sarama Config
+			saramaYaml := strings.Replace(EKDefaultSaramaConfig, `
+    AutoCommit:
+        Interval: 5000000000
+`, testCase.autoCommitYaml, 1)
+			configMap := commontesting.GetTestSaramaConfigMap(saramaYaml, EKDefaultConfigYaml)
+
+			// Merge Into Both A New Config & An Existing One With Auto-Commit Enabled
+			for _, baseConfig := range []*sarama.Config{nil, sarama.NewConfig()} {
+
+				// Perform The Test
+				config, err := MergeSaramaSettings(baseConfig, configMap)
+
+				// Verify The Results
+				assert.Nil(t, err)
+				assert.NotNil(t, config)
+				assert.Equal(t, testCase.expectedEnable, config.Consumer.Offsets.AutoCommit.Enable)
+				assert.Equal(t, testCase.expectedInterval, config.Consumer.Offsets.AutoCommit.Interval)
+				assert.Nil(t, config.Validate())
+			}
+		})
+	}
+}
+
+// Verify The Producer.Flush (Batching) Settings Are Merged, Validated & Survive The Common Producer Settings
+func TestMergeSaramaSettingsProducerFlush(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                string
+		producerYaml        string
+		expectedBytes       int
+		expectedMessages    int
+		expectedFrequency   time.Duration
+		expectedMaxMessages int
+		expectErr           bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name: "No Batching By Default",
+		},
+		{
+			name: "Frequency Only",
+			producerYaml: `
+Producer:
+  Flush:
+    Frequency: 10000000
+`,
+			expectedFrequency: 10 * time.Millisecond,
+		},
+		{
+			name: "All Triggers With Idempotent Producer",
+			producerYaml: `
+Net:
+  MaxOpenRequests: 1
+Producer:
+  Idempotent: true
+  RequiredAcks: -1
+  Flush:
+    Bytes: 1048576
+    Messages: 100
+    Frequency: 5000000
+    MaxMessages: 500
+`,
+			expectedBytes:       1048576,
+			expectedMessages:    100,
+			expectedFrequency:   5 * time.Millisecond,
+			expectedMaxMessages: 500,
+		},
+		{
+			name: "Messages Without Frequency",
+			producerYaml: `
+Producer:
+  Flush:
+    Messages: 100
+`,
+			expectErr: true,
+		},
+		{
+			name: "Bytes Without Frequency",
+			producerYaml: `
+Producer:
+  Flush:
+    Bytes: 1048576
+`,
+			expectErr: true,
+		},
+		{
+			name: "MaxMessages Without Trigger",
+			producerYaml: `
+Producer:
+  Flush:
+    MaxMessages: 500
+`,
+			expectErr: true,
+		},
+		{
+			name: "MaxMessages Less Than Messages",
+			producerYaml: `
+Producer:
+  Flush:
+    Messages: 100
+    Frequency: 5000000
+    MaxMessages: 50
+`,
+			expectErr: true,
+		},
+		{
+			name: "Bytes Exceeding Maximum Request Size",
+			producerYaml: `
+Producer:
+  Flush:
+    Bytes: 104857600
+    Frequency: 5000000
+`,
+			expectErr: true,
+		},
+		{
+			name: "Negative Frequency",
+			producerYaml: `
+Producer:
+  Flush:
+    Frequency: -1
+`,
+			expectErr: true,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Append The Producer YAML To The Default Sarama Config
+			configMap := commontesting.GetTestSaramaConfigMap(EKDefaultSaramaConfig+testCase.producerYaml, EKDefaultConfigYaml)
+
+			// Merge Into Both A New Config & An Existing One
+			for _, baseConfig := range []*sarama.Config{nil, sarama.NewConfig()} {
+
+				// Perform The Test
+				config, err := MergeSaramaSettings(baseConfig, configMap)
+
+				// Verify The Results
+				if testCase.expectErr {
+					assert.NotNil(t, err)
+					assert.Nil(t, config)
+				} else {
+					assert.Nil(t, err)
+					assert.NotNil(t, config)
+
+					// Apply The Common Settings As The Receiver Does & Verify The Flush Settings Survive
+					UpdateSaramaConfig(config, "test-client-id", "test-username", "test-password")
+					assert.Equal(t, testCase.expectedBytes, config.Producer.Flush.Bytes)
+					assert.Equal(t, testCase.expectedMessages, config.Producer.Flush.Messages)
+					assert.Equal(t, testCase.expectedFrequency, config.Producer.Flush.Frequency)
+					assert.Equal(t, testCase.expectedMaxMessages, config.Producer.Flush.MaxMessages)
+					assert.Nil(t, config.Validate())
+				}
+			}
+		})
+	}
+}
+
+// Test The MergeSaramaSettings() Validation Of The SASL & TLS Settings
+func TestMergeSaramaSettingsAuthentication(t *testing.T) {
+
+	// Test Data
+	saslScramWithTls := `
+Net:
+  TLS:
+    Enable: true
+  SASL:
+    Enable: true
+    Mechanism: SCRAM-SHA-512
+`
+	saslWithoutTls := `
+Net:
+  SASL:
+    Enable: true
+    Mechanism: PLAIN
+`
+	scramWithoutSasl := `
+Net:
+  SASL:
+    Mechanism: SCRAM-SHA-256
+`
+	rootCertWithoutTls := strings.Replace(EKDefaultSaramaConfigWithRootCert, "Enable: true", "Enable: false", 1)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name       string
+		saramaYaml string
+		policy     string
+		expectErr  bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Defaults", saramaYaml: EKDefaultSaramaConfig},
+		{name: "Root Certificate With TLS", saramaYaml: EKDefaultSaramaConfigWithRootCert},
+		{name: "SASL SCRAM With TLS", saramaYaml: saslScramWithTls},
+		{name: "SASL SCRAM With TLS Secure", saramaYaml: saslScramWithTls, policy: "secure"},
+		{name: "SASL Without TLS", saramaYaml: saslWithoutTls},
+		{name: "SASL Without TLS Secure", saramaYaml: saslWithoutTls, policy: "secure", expectErr: true},
+		{name: "SCRAM Without SASL", saramaYaml: scramWithoutSasl, expectErr: true},
+		{name: "SCRAM Without SASL Unvalidated", saramaYaml: scramWithoutSasl, policy: "none"},
+		{name: "Root Certificate Without TLS", saramaYaml: rootCertWithoutTls, expectErr: true},
+		{name: "Root Certificate Without TLS Unvalidated", saramaYaml: rootCertWithoutTls, policy: "none"},
+		{name: "Invalid Policy", saramaYaml: EKDefaultSaramaConfig, policy: "foo", expectErr: true},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The ConfigMap With The Specified Sarama Settings & Auth Validation Policy
+			configMap := commontesting.GetTestSaramaConfigMap(testCase.saramaYaml, EKDefaultConfigYaml+"  authValidationPolicy: \""+testCase.policy+"\"\n")
+
+			// Perform The Test
+			config, err := MergeSaramaSettings(nil, configMap)
+
+			// Verify The Results
+			if testCase.expectErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, config)
+			}
+		})
+	}
+}
+
+// Test The validateAuthentication() Handling Of Client Certificates (mTLS), Which Cannot Be Set Via The ConfigMap
+func TestValidateAuthenticationClientCertificates(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name       string
+		tlsEnable  bool
+		saslEnable bool
+		policy     string
+		expectErr  bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "mTLS With TLS", tlsEnable: true},
+		{name: "mTLS With TLS Secure", tlsEnable: true, policy: "secure"},
+		{name: "mTLS Without TLS", expectErr: true},
+		{name: "mTLS With SASL", tlsEnable: true, saslEnable: true, expectErr: true},
+		{name: "mTLS With SASL Unvalidated", tlsEnable: true, saslEnable: true, policy: "none"},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Sarama Config With A Client Certificate
+			config := sarama.NewConfig()
+			config.Net.TLS.Enable = testCase.tlsEnable
+			config.Net.TLS.Config = &tls.Config{Certificates: []tls.Certificate{{}}}
+			config.Net.SASL.Enable = testCase.saslEnable
+
+			// Perform The Test & Verify The Results
+			err := validateAuthentication(config, testCase.policy)
+			assert.Equal(t, testCase.expectErr, err != nil)
+		})
+	}
+}
+
 // Verify that comparisons of sarama config structs function as expected
 func TestSaramaConfigEqual(t *testing.T) {
 	config1 := sarama.NewConfig()
@@ -325,6 +809,12 @@ func TestSaramaConfigEqual(t *testing.T) {
 	config2.RackID = "New Rack ID"
 	assert.True(t, ConfigEqual(config1, config2))
 
+	// The SCRAM client generators are ignored, but the SASL mechanism which determines them is not
+	assert.Nil(t, UpdateSaramaSaslMechanism(config1, "SCRAM-SHA-512"))
+	assert.False(t, ConfigEqual(config1, config2))
+	assert.Nil(t, UpdateSaramaSaslMechanism(config2, "SCRAM-SHA-512"))
+	assert.True(t, ConfigEqual(config1, config2))
+
 	// Change a boolean flag in the TLS.Config struct (which is not Sarama-specific) and make sure the compare function
 	// works with those sub-structs as well.
 	config1.Net.TLS.Config = &tls.Config{}
@@ -359,6 +849,22 @@ func TestLoadEventingKafkaSettings(t *testing.T) {
 	saramaConfig, eventingKafkaConfig, err := LoadSettings(ctx)
 	assert.Nil(t, err)
 	verifyTestEKConfigSettings(t, saramaConfig, eventingKafkaConfig)
+
+	// Verify that the eventing-kafka settings are also loaded directly from the configmap (as received by a watcher)
+	eventingKafkaConfig, err = LoadEventingKafkaSettings(configMap)
+	assert.Nil(t, err)
+	assert.Equal(t, commontesting.DispatcherReplicas, strconv.Itoa(eventingKafkaConfig.Dispatcher.Replicas))
+
+	// Verify that nil configmaps, those with no data section, and those with invalid YAML return an error
+	eventingKafkaConfig, err = LoadEventingKafkaSettings(nil)
+	assert.Nil(t, eventingKafkaConfig)
+	assert.NotNil(t, err)
+	eventingKafkaConfig, err = LoadEventingKafkaSettings(&corev1.ConfigMap{})
+	assert.Nil(t, eventingKafkaConfig)
+	assert.NotNil(t, err)
+	eventingKafkaConfig, err = LoadEventingKafkaSettings(commontesting.GetTestSaramaConfigMap(commontesting.OldSaramaConfig, "\tinvalidYaml"))
+	assert.Nil(t, eventingKafkaConfig)
+	assert.NotNil(t, err)
 }
 
 func TestLoadSettings(t *testing.T) {
@@ -394,6 +900,94 @@ func TestLoadSettings(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+// Test The WaitForSettings() Functionality
+func TestWaitForSettings(t *testing.T) {
+
+	// Test Data
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+	logger := logtesting.TestLogger(t).Desugar()
+	interval := 10 * time.Millisecond
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		initial      *corev1.ConfigMap // ConfigMap Existing At Startup
+		delayed      *corev1.ConfigMap // ConfigMap Created After The Delay
+		timeout      time.Duration
+		expectErr    string
+		expectLoaded bool
+	}
+
+	// Create A ConfigMap Without Any Data
+	emptyConfigMap := commontesting.GetTestSaramaConfigMap("", "")
+	emptyConfigMap.Data = nil
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "ConfigMap Exists",
+			initial:      commontesting.GetTestSaramaConfigMap(commontesting.OldSaramaConfig, commontesting.TestEKConfig),
+			timeout:      5 * time.Second,
+			expectLoaded: true,
+		},
+		{
+			name:         "ConfigMap Appears In Time",
+			delayed:      commontesting.GetTestSaramaConfigMap(commontesting.OldSaramaConfig, commontesting.TestEKConfig),
+			timeout:      5 * time.Second,
+			expectLoaded: true,
+		},
+		{
+			name:      "ConfigMap Never Appears",
+			timeout:   100 * time.Millisecond,
+			expectErr: "configmap '" + commonconfig.SettingsConfigMapName + "' not available",
+		},
+		{
+			name:      "ConfigMap Invalid",
+			initial:   emptyConfigMap,
+			timeout:   5 * time.Second,
+			expectErr: "attempted to load configuration from empty configmap",
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Fake K8S Client With The Initial ConfigMap (If Any)
+			fakeK8sClient := fake.NewSimpleClientset()
+			if testCase.initial != nil {
+				fakeK8sClient = fake.NewSimpleClientset(testCase.initial)
+			}
+			ctx := context.WithValue(context.Background(), injectionclient.Key{}, fakeK8sClient)
+
+			// Create The Delayed ConfigMap (If Any) After Several Polls
+			if testCase.delayed != nil {
+				go func() {
+					time.Sleep(5 * interval)
+					_, err := fakeK8sClient.CoreV1().ConfigMaps(testCase.delayed.Namespace).Create(ctx, testCase.delayed, metav1.CreateOptions{})
+					assert.Nil(t, err)
+				}()
+			}
+
+			// Perform The Test
+			timeoutCtx, cancel := context.WithTimeout(ctx, testCase.timeout)
+			defer cancel()
+			saramaConfig, eventingKafkaConfig, err := WaitForSettings(timeoutCtx, logger, interval)
+
+			// Verify The Results
+			if testCase.expectLoaded {
+				assert.Nil(t, err)
+				verifyTestEKConfigSettings(t, saramaConfig, eventingKafkaConfig)
+			} else {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), testCase.expectErr)
+				assert.Nil(t, saramaConfig)
+				assert.Nil(t, eventingKafkaConfig)
+			}
+		})
+	}
+}
+
 func verifyTestEKConfigSettings(t *testing.T, saramaConfig *sarama.Config, eventingKafkaConfig *commonconfig.EventingKafkaConfig) {
 	// Quick checks to make sure the loaded configs aren't complete junk
 	assert.Equal(t, commontesting.OldUsername, saramaConfig.Net.SASL.User)
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarama
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Function Reference For Generating SCRAM Client Nonces (Facilitates Testing)
+var newScramNonce = func() (string, error) {
+	nonce := make([]byte, 24)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(nonce), nil
+}
+
+// Escape A SCRAM User Or Authorization Name ('=' & ',' Are Reserved In SCRAM Messages)
+var scramNameEscaper = strings.NewReplacer("=", "=3D", ",", "=2C")
+
+//
+// SCRAM Client Performing The Client Side Of A SCRAM Authentication Exchange (RFC 5802)
+//
+// Sarama implements the Kafka SASL/SCRAM protocol but delegates the SCRAM conversation itself to a user provided
+// client via the Net.SASL.SCRAMClientGeneratorFunc.  The exchange consists of the client-first message (the user
+// name and a random nonce), the client-final message (proving knowledge of the password salted & iterated as
+// specified by the server-first message) and finally verification of the server's signature so that the broker
+// is also authenticated.  Note that user names and passwords are not SASLprep normalized, which only matters for
+// credentials containing non-ASCII characters.
+//
+type scramClient struct {
+	hashGenerator          func() hash.Hash
+	userName               string
+	password               string
+	gs2Header              string
+	clientNonce            string
+	clientFirstMessageBare string
+	serverSignature        []byte
+	step                   int
+	done                   bool
+}
+
+// Verify The scramClient Implements The Sarama SCRAMClient Interface
+var _ sarama.SCRAMClient = &scramClient{}
+
+// Create A SCRAMClientGeneratorFunc For The Specified Hash (e.g. sha512.New)
+func newScramClientGenerator(hashGenerator func() hash.Hash) func() sarama.SCRAMClient {
+	return func() sarama.SCRAMClient {
+		return &scramClient{hashGenerator: hashGenerator}
+	}
+}
+
+// Prepare The SCRAM Exchange With The Specified Credentials
+func (c *scramClient) Begin(userName string, password string, authzID string) error {
+	nonce, err := newScramNonce()
+	if err != nil {
+		return fmt.Errorf("failed to generate scram nonce: %w", err)
+	}
+	c.userName = userName
+	c.password = password
+	c.gs2Header = "n,,"
+	if len(authzID) > 0 {
+		c.gs2Header = "n,a=" + scramNameEscaper.Replace(authzID) + ","
+	}
+	c.clientNonce = nonce
+	c.step = 0
+	c.done = false
+	return nil
+}
+
+// Perform The Next Step Of The SCRAM Exchange, Returning The Response To The Specified Server Challenge
+func (c *scramClient) Step(challenge string) (string, error) {
+	c.step++
+	switch c.step {
+	case 1:
+		c.clientFirstMessageBare = "n=" + scramNameEscaper.Replace(c.userName) + ",r=" + c.clientNonce
+		return c.gs2Header + c.clientFirstMessageBare, nil
+	case 2:
+		return c.clientFinalMessage(challenge)
+	case 3:
+		c.done = true
+		return "", c.verifyServerFinalMessage(challenge)
+	default:
+		return "", errors.New("scram exchange already completed")
+	}
+}
+
+// Determine Whether The SCRAM Exchange Is Complete
+func (c *scramClient) Done() bool {
+	return c.done
+}
+
+// Create The Client-Final Message From The Server-First Message (Retaining The Expected Server Signature)
+func (c *scramClient) clientFinalMessage(serverFirstMessage string) (string, error) {
+
+	// Parse The Server's Combined Nonce, Salt & Iteration Count
+	attributes := parseScramAttributes(serverFirstMessage)
+	nonce := attributes["r"]
+	if !strings.HasPrefix(nonce, c.clientNonce) || len(nonce) <= len(c.clientNonce) {
+		return "", errors.New("invalid scram server-first message: nonce does not extend the client nonce")
+	}
+	salt, err := base64.StdEncoding.DecodeString(attributes["s"])
+	if err != nil || len(salt) <= 0 {
+		return "", errors.New("invalid scram server-first message: missing or malformed salt")
+	}
+	iterations, err := strconv.Atoi(attributes["i"])
+	if err != nil || iterations <= 0 {
+		return "", errors.New("invalid scram server-first message: missing or malformed iteration count")
+	}
+
+	// Derive The Keys & Sign The Exchange
+	saltedPassword := pbkdf2.Key([]byte(c.password), salt, iterations, c.hashGenerator().Size(), c.hashGenerator)
+	clientKey := c.hmac(saltedPassword, "Client Key")
+	storedKey := c.hash(clientKey)
+	clientFinalMessageWithoutProof := "c=" + base64.StdEncoding.EncodeToString([]byte(c.gs2Header)) + ",r=" + nonce
+	authMessage := c.clientFirstMessageBare + "," + serverFirstMessage + "," + clientFinalMessageWithoutProof
+	clientSignature := c.hmac(storedKey, authMessage)
+	clientProof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		clientProof[i] = clientKey[i] ^ clientSignature[i]
+	}
+	c.serverSignature = c.hmac(c.hmac(saltedPassword, "Server Key"), authMessage)
+
+	return clientFinalMessageWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(clientProof), nil
+}
+
+// Verify The Server-Final Message Contains The Expected Server Signature
+func (c *scramClient) verifyServerFinalMessage(serverFinalMessage string) error {
+	attributes := parseScramAttributes(serverFinalMessage)
+	if serverError, ok := attributes["e"]; ok {
+		return fmt.Errorf("scram authentication failed: %s", serverError)
+	}
+	serverSignature, err := base64.StdEncoding.DecodeString(attributes["v"])
+	if err != nil || !hmac.Equal(serverSignature, c.serverSignature) {
+		return errors.New("invalid scram server-final message: server signature does not match")
+	}
+	return nil
+}
+
+// Compute The HMAC Of The Specified Message With The Specified Key
+func (c *scramClient) hmac(key []byte, message string) []byte {
+	mac := hmac.New(c.hashGenerator, key)
+	_, _ = mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// Compute The Hash Of The Specified Data
+func (c *scramClient) hash(data []byte) []byte {
+	h := c.hashGenerator()
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+// Parse The Comma Separated "<name>=<value>" Attributes Of A SCRAM Message
+func parseScramAttributes(message string) map[string]string {
+	attributes := make(map[string]string)
+	for _, attribute := range strings.Split(message, ",") {
+		if len(attribute) >= 2 && attribute[1] == '=' {
+			attributes[attribute[:1]] = attribute[2:]
+		}
+	}
+	return attributes
+}
+
+// The SCRAMClientGeneratorFuncs Of The Supported SCRAM SASL Mechanisms
+var scramClientGenerators = map[sarama.SASLMechanism]func() sarama.SCRAMClient{
+	sarama.SASLTypeSCRAMSHA256: newScramClientGenerator(sha256.New),
+	sarama.SASLTypeSCRAMSHA512: newScramClientGenerator(sha512.New),
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarama
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The SCRAM Client Against The SCRAM-SHA-256 Example Exchange Of RFC 7677
+func TestScramClient(t *testing.T) {
+
+	// RFC 7677 Example Exchange
+	const (
+		clientNonce        = "rOprNGfwEbeRWgbNEkqO"
+		clientFirstMessage = "n,,n=user,r=rOprNGfwEbeRWgbNEkqO"
+		serverFirstMessage = "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"
+		clientFinalMessage = "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ="
+		serverFinalMessage = "v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="
+	)
+
+	// Replace The Nonce Generation With The Example Nonce & Restore After Test
+	newScramNoncePlaceholder := newScramNonce
+	newScramNonce = func() (string, error) { return clientNonce, nil }
+	defer func() {
+		newScramNonce = newScramNoncePlaceholder
+	}()
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name               string
+		serverFirstMessage string
+		serverFinalMessage string
+		wantFinalErr       bool
+		wantFinalStepErr   bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Success", serverFirstMessage: serverFirstMessage, serverFinalMessage: serverFinalMessage},
+		{name: "Server Signature Mismatch", serverFirstMessage: serverFirstMessage, serverFinalMessage: "v=AAAATRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=", wantFinalStepErr: true},
+		{name: "Server Error", serverFirstMessage: serverFirstMessage, serverFinalMessage: "e=invalid-proof", wantFinalStepErr: true},
+		{name: "Server Nonce Mismatch", serverFirstMessage: "r=otherNonce%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096", wantFinalErr: true},
+		{name: "Missing Salt", serverFirstMessage: "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,i=4096", wantFinalErr: true},
+		{name: "Invalid Iterations", serverFirstMessage: "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=0", wantFinalErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := scramClientGenerators[sarama.SASLTypeSCRAMSHA256]()
+			assert.Nil(t, client.Begin("user", "pencil", ""))
+
+			// Client-First Message
+			response, err := client.Step("")
+			assert.Nil(t, err)
+			assert.Equal(t, clientFirstMessage, response)
+			assert.False(t, client.Done())
+
+			// Client-Final Message
+			response, err = client.Step(testCase.serverFirstMessage)
+			if testCase.wantFinalErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, clientFinalMessage, response)
+			assert.False(t, client.Done())
+
+			// Server-Final Message Verification
+			response, err = client.Step(testCase.serverFinalMessage)
+			assert.Equal(t, testCase.wantFinalStepErr, err != nil)
+			assert.Equal(t, "", response)
+			assert.True(t, client.Done())
+		})
+	}
+}
+
+// Test The SCRAM Client's Escaping Of Names & Use Of The Configured Hash
+func TestScramClientNames(t *testing.T) {
+	newScramNoncePlaceholder := newScramNonce
+	newScramNonce = func() (string, error) { return "nonce", nil }
+	defer func() {
+		newScramNonce = newScramNoncePlaceholder
+	}()
+
+	client := newScramClientGenerator(sha512.New)()
+	assert.Nil(t, client.Begin("us=er,name", "password", "auth,zid"))
+	response, err := client.Step("")
+	assert.Nil(t, err)
+	assert.Equal(t, "n,a=auth=2Czid,n=us=3Der=2Cname,r=nonce", response)
+	response, err = client.Step("r=nonceServer,s=c2FsdA==,i=1")
+	assert.Nil(t, err)
+	assert.Len(t, parseScramAttributes(response)["p"], 88) // Base64 Encoded 64 Byte SHA-512 Proof
+
+	client = newScramClientGenerator(sha256.New)()
+	assert.Nil(t, client.Begin("user", "password", ""))
+	_, err = client.Step("")
+	assert.Nil(t, err)
+	response, err = client.Step("r=nonceServer,s=c2FsdA==,i=1")
+	assert.Nil(t, err)
+	assert.Len(t, parseScramAttributes(response)["p"], 44) // Base64 Encoded 32 Byte SHA-256 Proof
+}
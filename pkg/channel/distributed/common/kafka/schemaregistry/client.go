@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schema Registry REST API Constants
+const (
+	ContentType       = "application/vnd.schemaregistry.v1+json"
+	SchemaTypeAvro    = "AVRO" // The Registry's Default (Omitted From Requests For Compatibility With Older Registries)
+	SchemaTypeJson    = "JSON"
+	SchemaTypeProto   = "PROTOBUF"
+	DefaultSchemaType = SchemaTypeJson
+	DefaultSchema     = "{}" // A JSON Schema Which Accepts Any Value
+	RequestTimeout    = 10 * time.Second
+)
+
+// Schema Registry Client Interface (Facilitates Mocking)
+type ClientInterface interface {
+	RegisterSchema(ctx context.Context, subject string, schemaType string, schema string) (int32, error)
+}
+
+// Verify The Client Implements The ClientInterface
+var _ ClientInterface = &Client{}
+
+//
+// Minimal Confluent Schema Registry REST Client
+//
+// Only the registration of schemas is supported, which returns the ID of the existing schema if an identical
+// schema has already been registered under the subject.  Schema IDs never change once assigned, so they are
+// cached per subject / schema to avoid a registry round-trip for every produced message.
+//
+type Client struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+	cache      map[string]int32
+	cacheLock  sync.RWMutex
+}
+
+// Schema Registry Client Constructor (Basic Authentication Is Used If A Username Is Specified)
+func NewClient(registryUrl string, username string, password string) *Client {
+	return &Client{
+		url:        strings.TrimSuffix(registryUrl, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: RequestTimeout},
+		cache:      make(map[string]int32),
+	}
+}
+
+// Schema Registration Request / Response / Error Bodies
+type registerRequest struct {
+	SchemaType string `json:"schemaType,omitempty"`
+	Schema     string `json:"schema"`
+}
+type registerResponse struct {
+	Id int32 `json:"id"`
+}
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Register The Specified Schema Under The Specified Subject (If Not Already) & Return Its ID
+func (c *Client) RegisterSchema(ctx context.Context, subject string, schemaType string, schema string) (int32, error) {
+
+	// Return The Cached Schema ID If Previously Registered
+	cacheKey := subject + "/" + schemaType + "/" + schema
+	c.cacheLock.RLock()
+	schemaId, ok := c.cache[cacheKey]
+	c.cacheLock.RUnlock()
+	if ok {
+		return schemaId, nil
+	}
+
+	// Create The Request Body (The Default Avro Schema Type Is Implied)
+	request := registerRequest{Schema: schema}
+	if schemaType != SchemaTypeAvro {
+		request.SchemaType = schemaType
+	}
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal schema registration request: %v", err)
+	}
+
+	// Create The HTTP POST Request
+	requestUrl := fmt.Sprintf("%s/subjects/%s/versions", c.url, url.PathEscape(subject))
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, requestUrl, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create schema registration request: %v", err)
+	}
+	httpRequest.Header.Set("Content-Type", ContentType)
+	httpRequest.Header.Set("Accept", ContentType)
+	if len(c.username) > 0 {
+		httpRequest.SetBasicAuth(c.username, c.password)
+	}
+
+	// Make The HTTP Request
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register schema for subject '%s': %v", subject, err)
+	}
+	defer func() { _ = httpResponse.Body.Close() }()
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema registration response for subject '%s': %v", subject, err)
+	}
+
+	// Map Unsuccessful Responses To An Error Including The Registry's Error Message (If Any)
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		registryError := &errorResponse{}
+		if json.Unmarshal(responseBody, registryError) == nil && len(registryError.Message) > 0 {
+			return 0, fmt.Errorf("failed to register schema for subject '%s': status %d, error code %d: %s", subject, httpResponse.StatusCode, registryError.ErrorCode, registryError.Message)
+		}
+		return 0, fmt.Errorf("failed to register schema for subject '%s': status %d", subject, httpResponse.StatusCode)
+	}
+
+	// Parse The Schema ID From The Response
+	response := &registerResponse{}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse schema registration response for subject '%s': %v", subject, err)
+	}
+
+	// Cache & Return The Schema ID
+	c.cacheLock.Lock()
+	c.cache[cacheKey] = response.Id
+	c.cacheLock.Unlock()
+	return response.Id, nil
+}
+
+// Get The Subject Name For The Values Of The Specified Topic (The Registry Serializers' Default "TopicNameStrategy")
+func ValueSubject(topic string) string {
+	return topic + "-value"
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Data
+const (
+	testSubject  = "test-topic-value"
+	testUsername = "test-username"
+	testPassword = "test-password"
+	testSchema   = `{"type":"object"}`
+)
+
+//
+// Mock Schema Registry
+//
+// Assigns sequential IDs to distinct schemas (regardless of subject, as does the real registry) and
+// records the number of registration requests received so that caching can be verified.
+//
+type mockRegistry struct {
+	t        *testing.T
+	lock     sync.Mutex
+	ids      map[string]int32
+	requests int
+	username string
+	password string
+}
+
+func newMockRegistry(t *testing.T, username string, password string) (*mockRegistry, *httptest.Server) {
+	registry := &mockRegistry{t: t, ids: make(map[string]int32), username: username, password: password}
+	return registry, httptest.NewServer(registry)
+}
+
+func (r *mockRegistry) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests++
+
+	// Verify The Request
+	assert.Equal(r.t, http.MethodPost, request.Method)
+	assert.Equal(r.t, ContentType, request.Header.Get("Content-Type"))
+	username, password, ok := request.BasicAuth()
+	if len(r.username) > 0 && (!ok || username != r.username || password != r.password) {
+		response.WriteHeader(http.StatusUnauthorized)
+		_, _ = response.Write([]byte(`{"error_code":401,"message":"Unauthorized"}`))
+		return
+	}
+	if request.URL.Path != "/subjects/"+testSubject+"/versions" {
+		response.WriteHeader(http.StatusNotFound)
+		_, _ = response.Write([]byte(`{"error_code":404,"message":"HTTP 404 Not Found"}`))
+		return
+	}
+
+	// Parse The Request Body
+	body, err := ioutil.ReadAll(request.Body)
+	assert.Nil(r.t, err)
+	registration := &registerRequest{}
+	assert.Nil(r.t, json.Unmarshal(body, registration))
+	if registration.Schema == "invalid" {
+		response.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = response.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+		return
+	}
+
+	// Assign / Lookup The Schema ID
+	key := registration.SchemaType + "/" + registration.Schema
+	id, ok := r.ids[key]
+	if !ok {
+		id = int32(len(r.ids) + 1)
+		r.ids[key] = id
+	}
+	response.Header().Set("Content-Type", ContentType)
+	_ = json.NewEncoder(response).Encode(&registerResponse{Id: id})
+}
+
+func (r *mockRegistry) requestCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests
+}
+
+// Test The NewClient() Functionality
+func TestNewClient(t *testing.T) {
+	client := NewClient("http://registry:8081/", testUsername, testPassword)
+	assert.NotNil(t, client)
+	assert.Equal(t, "http://registry:8081", client.url)
+	assert.Equal(t, testUsername, client.username)
+	assert.Equal(t, testPassword, client.password)
+	assert.NotNil(t, client.httpClient)
+	assert.NotNil(t, client.cache)
+}
+
+// Test The RegisterSchema() Functionality
+func TestRegisterSchema(t *testing.T) {
+
+	// Create The Mock Registry & Client
+	registry, server := newMockRegistry(t, testUsername, testPassword)
+	defer server.Close()
+	client := NewClient(server.URL, testUsername, testPassword)
+	ctx := context.TODO()
+
+	// Register A Schema & Verify It Is Assigned An ID
+	schemaId, err := client.RegisterSchema(ctx, testSubject, SchemaTypeJson, testSchema)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), schemaId)
+	assert.Equal(t, 1, registry.requestCount())
+
+	// Re-Register The Same Schema & Verify The Cached ID Is Returned Without A Request
+	schemaId, err = client.RegisterSchema(ctx, testSubject, SchemaTypeJson, testSchema)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), schemaId)
+	assert.Equal(t, 1, registry.requestCount())
+
+	// Register A Different Schema & Verify It Is Assigned A New ID
+	schemaId, err = client.RegisterSchema(ctx, testSubject, SchemaTypeJson, DefaultSchema)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), schemaId)
+	assert.Equal(t, 2, registry.requestCount())
+
+	// Verify A New Client Obtains The Existing ID From The Registry
+	schemaId, err = NewClient(server.URL, testUsername, testPassword).RegisterSchema(ctx, testSubject, SchemaTypeJson, testSchema)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), schemaId)
+	assert.Equal(t, 3, registry.requestCount())
+}
+
+// Test The RegisterSchema() Functionality's Error Handling
+func TestRegisterSchemaErrors(t *testing.T) {
+
+	// Create The Mock Registry
+	_, server := newMockRegistry(t, testUsername, testPassword)
+	defer server.Close()
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name     string
+		url      string
+		username string
+		subject  string
+		schema   string
+		wantErr  string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unauthorized", url: server.URL, username: "wrong", subject: testSubject, schema: testSchema, wantErr: "status 401, error code 401: Unauthorized"},
+		{name: "Unknown Subject", url: server.URL, username: testUsername, subject: "unknown", schema: testSchema, wantErr: "status 404, error code 404: HTTP 404 Not Found"},
+		{name: "Invalid Schema", url: server.URL, username: testUsername, subject: testSubject, schema: "invalid", wantErr: "status 422, error code 42201: Invalid schema"},
+		{name: "Unreachable Registry", url: "http://127.0.0.1:0", username: testUsername, subject: testSubject, schema: testSchema, wantErr: "failed to register schema for subject"},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := NewClient(testCase.url, testCase.username, testPassword)
+			schemaId, err := client.RegisterSchema(context.TODO(), testCase.subject, SchemaTypeJson, testCase.schema)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), testCase.wantErr)
+			assert.Equal(t, int32(0), schemaId)
+			assert.Len(t, client.cache, 0)
+		})
+	}
+}
+
+// Test The Framing Round-Trip Using A Schema ID Obtained From The Registry
+func TestRegisteredFramingRoundTrip(t *testing.T) {
+	_, server := newMockRegistry(t, "", "")
+	defer server.Close()
+	payload := []byte(`{"foo":"bar"}`)
+	schemaId, err := NewClient(server.URL, "", "").RegisterSchema(context.TODO(), ValueSubject("test-topic"), DefaultSchemaType, DefaultSchema)
+	assert.Nil(t, err)
+	unframedSchemaId, unframedPayload, err := Unframe(Frame(schemaId, payload))
+	assert.Nil(t, err)
+	assert.Equal(t, schemaId, unframedSchemaId)
+	assert.Equal(t, payload, unframedPayload)
+}
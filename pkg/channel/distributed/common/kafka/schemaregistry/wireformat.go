@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+//
+// Confluent Schema Registry Wire Format
+//
+// Kafka message values produced for consumption via the Confluent Schema Registry serializers are prefixed
+// with a single "magic" byte (always zero) followed by the 4 byte (big-endian) ID of the registered schema.
+//
+const (
+	MagicByte    = byte(0)
+	HeaderLength = 5
+)
+
+// Error Returned When Unframing A Value Which Is Not In The Schema Registry Wire Format
+var ErrNotFramed = errors.New("value is not in the schema registry wire format")
+
+// Frame The Specified Payload In The Schema Registry Wire Format With The Specified Schema ID
+func Frame(schemaId int32, payload []byte) []byte {
+	framed := make([]byte, HeaderLength+len(payload))
+	framed[0] = MagicByte
+	binary.BigEndian.PutUint32(framed[1:HeaderLength], uint32(schemaId))
+	copy(framed[HeaderLength:], payload)
+	return framed
+}
+
+// Unframe The Specified Schema Registry Wire Format Value, Returning The Schema ID & Original Payload
+func Unframe(value []byte) (int32, []byte, error) {
+	if len(value) < HeaderLength {
+		return 0, nil, fmt.Errorf("%w: length %d is shorter than the %d byte header", ErrNotFramed, len(value), HeaderLength)
+	} else if value[0] != MagicByte {
+		return 0, nil, fmt.Errorf("%w: unknown magic byte %d", ErrNotFramed, value[0])
+	}
+	return int32(binary.BigEndian.Uint32(value[1:HeaderLength])), value[HeaderLength:], nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The Frame() & Unframe() Functionality
+func TestFrameUnframe(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name     string
+		schemaId int32
+		payload  []byte
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Zero Schema ID", schemaId: 0, payload: []byte(`{"foo":"bar"}`)},
+		{name: "Small Schema ID", schemaId: 7, payload: []byte(`{"foo":"bar"}`)},
+		{name: "Large Schema ID", schemaId: 1<<31 - 1, payload: []byte(`{"foo":"bar"}`)},
+		{name: "Empty Payload", schemaId: 123, payload: []byte{}},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			framed := Frame(testCase.schemaId, testCase.payload)
+			assert.Len(t, framed, HeaderLength+len(testCase.payload))
+			assert.Equal(t, MagicByte, framed[0])
+			schemaId, payload, err := Unframe(framed)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.schemaId, schemaId)
+			assert.Equal(t, testCase.payload, payload)
+		})
+	}
+}
+
+// Test The Frame() Functionality's Big-Endian Schema ID Encoding
+func TestFrame(t *testing.T) {
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x02, 'a', 'b'}, Frame(258, []byte("ab")))
+}
+
+// Test The Unframe() Functionality With Values Not In The Wire Format
+func TestUnframeInvalid(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name  string
+		value []byte
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Nil", value: nil},
+		{name: "Short", value: []byte{0x00, 0x00, 0x01}},
+		{name: "Unknown Magic Byte", value: []byte(`{"foo":"bar"}`)},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			schemaId, payload, err := Unframe(testCase.value)
+			assert.True(t, errors.Is(err, ErrNotFramed))
+			assert.Equal(t, int32(0), schemaId)
+			assert.Nil(t, payload)
+		})
+	}
+}
@@ -63,3 +63,8 @@ func (m *MockConsumerGroup) Close() error {
 	m.Closed = true
 	return nil
 }
+
+// Simulate An Asynchronous ConsumerGroup Error (Blocks Until Received From The Errors Channel)
+func (m *MockConsumerGroup) SendError(err error) {
+	m.errorChan <- err
+}
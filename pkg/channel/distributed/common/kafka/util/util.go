@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Shopify/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
 
@@ -37,3 +38,31 @@ func AppendKafkaChannelServiceNameSuffix(channelName string) string {
 func TrimKafkaChannelServiceNameSuffix(serviceName string) string {
 	return strings.TrimSuffix(serviceName, "-"+constants.KafkaChannelServiceNameSuffix)
 }
+
+// Get The Size (In Bytes) Of The Serialized Key, Value & Headers Of The Specified ProducerMessage
+func ProducerMessageSize(message *sarama.ProducerMessage) int64 {
+	size := encoderLength(message.Key) + encoderLength(message.Value)
+	for _, header := range message.Headers {
+		size += len(header.Key) + len(header.Value)
+	}
+	return int64(size)
+}
+
+// Get The Size (In Bytes) Of The Raw Key, Value & Headers Of The Specified ConsumerMessage
+func ConsumerMessageSize(message *sarama.ConsumerMessage) int64 {
+	size := len(message.Key) + len(message.Value)
+	for _, header := range message.Headers {
+		if header != nil {
+			size += len(header.Key) + len(header.Value)
+		}
+	}
+	return int64(size)
+}
+
+// Nil Safe Length Of The Specified Sarama Encoder
+func encoderLength(encoder sarama.Encoder) int {
+	if encoder == nil {
+		return 0
+	}
+	return encoder.Length()
+}
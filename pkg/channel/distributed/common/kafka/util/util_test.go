@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 )
@@ -67,3 +68,33 @@ func TestTrimKafkaChannelServiceNameSuffix(t *testing.T) {
 	expectedResult := channelName
 	assert.Equal(t, expectedResult, actualResult)
 }
+
+// Test The ProducerMessageSize() Functionality
+func TestProducerMessageSize(t *testing.T) {
+
+	// Test Data (Key=5, Value=10, Headers=3+4)
+	message := &sarama.ProducerMessage{
+		Key:     sarama.StringEncoder("12345"),
+		Value:   sarama.ByteEncoder("0123456789"),
+		Headers: []sarama.RecordHeader{{Key: []byte("abc"), Value: []byte("defg")}},
+	}
+
+	// Perform The Test & Verify The Results
+	assert.Equal(t, int64(22), ProducerMessageSize(message))
+	assert.Equal(t, int64(0), ProducerMessageSize(&sarama.ProducerMessage{}))
+}
+
+// Test The ConsumerMessageSize() Functionality
+func TestConsumerMessageSize(t *testing.T) {
+
+	// Test Data (Key=5, Value=10, Headers=3+4)
+	message := &sarama.ConsumerMessage{
+		Key:     []byte("12345"),
+		Value:   []byte("0123456789"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("abc"), Value: []byte("defg")}, nil},
+	}
+
+	// Perform The Test & Verify The Results
+	assert.Equal(t, int64(22), ConsumerMessageSize(message))
+	assert.Equal(t, int64(0), ConsumerMessageSize(&sarama.ConsumerMessage{}))
+}
@@ -33,6 +33,43 @@ const (
 	// LabelTopic is the label for the immutable name of the topic.
 	LabelTopic = "topic"
 
+	// LabelPolicy is the label for the policy applied to a message which failed deserialization.
+	LabelPolicy = "policy"
+
+	// LabelResult is the label for the outcome of producing a heartbeat event.
+	LabelResult = "result"
+
+	// Heartbeat Result Label Values
+	HeartbeatResultSuccess = "success"
+	HeartbeatResultFailure = "failure"
+
+	// LabelConsumerGroup is the label for the ID of a subscriber's ConsumerGroup.
+	LabelConsumerGroup = "consumer_group"
+
+	// LabelReason is the label for the cause of a failed ConsumerGroup Consume() which is being retried.
+	LabelReason = "reason"
+
+	// Consume Retry Reason Label Values
+	ConsumeRetryReasonCoordinatorUnavailable = "coordinator_unavailable"
+	ConsumeRetryReasonOther                  = "other"
+
+	// Audit Drop Reason Label Values
+	AuditDropReasonQueueFull       = "queue_full"
+	AuditDropReasonDeliveryFailure = "delivery_failure"
+
+	// Rate Limit Rejection Reason Label Values
+	RateLimitReasonRequests = "requests"
+	RateLimitReasonBytes    = "bytes"
+
+	// LabelSubscriber is the label for the UID of the subscriber a message was dispatched to.
+	LabelSubscriber = "subscriber"
+
+	// LabelStatusClass is the label for the class (e.g. "5xx") of the HTTP status with which a dispatch completed.
+	LabelStatusClass = "status_class"
+
+	// Dispatch Status Class Label Value For Dispatches Which Received No HTTP Response
+	DispatchStatusClassUnknown = "unknown"
+
 	// Sarama Metrics
 	RecordSendRateForTopicPrefix = "record-send-rate-for-topic-"
 )
@@ -45,23 +82,243 @@ var (
 		stats.UnitDimensionless,
 	)
 
+	// Counter For The Number Of Consumed Messages Which Could Not Be Deserialized Into CloudEvents
+	deserializationFailureCount = stats.Int64(
+		"deserialization_failure_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Deserialization Failure Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Periodic Authentication Checks Rejected By The Kafka Brokers
+	authenticationFailureCount = stats.Int64(
+		"authentication_failure_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Authentication Failure Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Total Size (In Bytes) Of The Serialized Messages Produced To A Kafka Topic
+	producedBytesTotal = stats.Int64(
+		"kafka_produce_bytes_total", // The METRICS_DOMAIN will be prepended to the name.
+		"Produced Kafka Message Bytes",
+		stats.UnitBytes,
+	)
+
+	// Counter For The Total Size (In Bytes) Of The Raw Messages Consumed From A Kafka Topic
+	consumedBytesTotal = stats.Int64(
+		"kafka_consume_bytes_total", // The METRICS_DOMAIN will be prepended to the name.
+		"Consumed Kafka Message Bytes",
+		stats.UnitBytes,
+	)
+
+	// Counter For The Number Of Consumed Record Batches Which Could Not Be Decompressed
+	consumeDecompressionErrorsTotal = stats.Int64(
+		"consume_decompression_errors_total", // The METRICS_DOMAIN will be prepended to the name.
+		"Consume Decompression Errors",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Received Events Rejected For Exceeding The Maximum Event Age
+	staleEventRejectionCount = stats.Int64(
+		"stale_event_rejection_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Stale Event Rejection Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Consumed Messages Exceeding The Dispatcher's Maximum Message Size
+	oversizedMessageCount = stats.Int64(
+		"oversized_message_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Oversized Message Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Synthetic Heartbeat Events Produced (Or Failed To Be Produced) By The Receiver
+	heartbeatCount = stats.Int64(
+		"heartbeat_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Heartbeat Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Failed ConsumerGroup Consume() Calls Which Were Retried By The Dispatcher
+	consumeRetryCount = stats.Int64(
+		"consume_retry_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Consume Retry Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Replies Which Could Not Be Delivered To A Subscription's Reply Destination (Dropped Or Dead Lettered)
+	replyFailureCount = stats.Int64(
+		"reply_failure_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Reply Failure Count",
+		stats.UnitDimensionless,
+	)
+
+	// Gauge Of The Number Of Messages Spooled To Local Disk By The Receiver Awaiting Replay To Kafka
+	spoolDepth = stats.Int64(
+		"spool_depth", // The METRICS_DOMAIN will be prepended to the name.
+		"Spooled Message Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Produced Records Whose Metadata Could Not Be Delivered To The Receiver's Audit Sink
+	auditDropCount = stats.Int64(
+		"audit_drop_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Audit Drop Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Events Produced By The Receiver Whose Produce Latency Exceeded The Configured SLO
+	produceLatencySloExceededCount = stats.Int64(
+		"produce_latency_slo_exceeded_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Produce Latency SLO Exceeded Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Dispatcher ConsumerGroup Error Logs Dropped By The Error Log Sampling
+	groupErrorLogDropCount = stats.Int64(
+		"consumergroup_error_log_drop_count", // The METRICS_DOMAIN will be prepended to the name.
+		"ConsumerGroup Error Log Drop Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Received Events Rejected For Exceeding The Receiver's Per-Channel Rate Limits
+	rateLimitRejectionCount = stats.Int64(
+		"rate_limit_rejection_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Rate Limit Rejection Count",
+		stats.UnitDimensionless,
+	)
+
+	// Gauge Of The Number Of Messages On A Kafka Topic Not Yet Consumed By A Subscriber's ConsumerGroup
+	consumerLag = stats.Int64(
+		"kafka_consumer_lag", // The METRICS_DOMAIN will be prepended to the name.
+		"Kafka Consumer Lag",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Messages Successfully Dispatched To A Subscriber
+	dispatchSuccessCount = stats.Int64(
+		"dispatch_success_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Dispatch Success Count",
+		stats.UnitDimensionless,
+	)
+
+	// Counter For The Number Of Messages Which Could Not Be Dispatched To A Subscriber (After Any Retries)
+	dispatchFailureCount = stats.Int64(
+		"dispatch_failure_count", // The METRICS_DOMAIN will be prepended to the name.
+		"Dispatch Failure Count",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements in order to validate
 	// that they conform to the restrictions described in go.opencensus.io/tag/validate.go.
 	// Currently those restrictions are...
 	//   - Length between 1 and 255 inclusive
 	//   - Characters are printable US-ASCII
-	topic = tag.MustNewKey(LabelTopic)
+	topic       = tag.MustNewKey(LabelTopic)
+	policy      = tag.MustNewKey(LabelPolicy)
+	result      = tag.MustNewKey(LabelResult)
+	reason      = tag.MustNewKey(LabelReason)
+	group       = tag.MustNewKey(LabelConsumerGroup)
+	subscriber  = tag.MustNewKey(LabelSubscriber)
+	statusClass = tag.MustNewKey(LabelStatusClass)
 )
 
 // Register the OpenCensus View Structures
 func init() {
 
-	// Create A View To See Our Metric
+	// Create Views To See Our Metrics
 	err := view.Register(&view.View{
 		Description: producedMessageCount.Description(),
 		Measure:     producedMessageCount,
 		Aggregation: view.LastValue(),
 		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: deserializationFailureCount.Description(),
+		Measure:     deserializationFailureCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, policy},
+	}, &view.View{
+		Description: authenticationFailureCount.Description(),
+		Measure:     authenticationFailureCount,
+		Aggregation: view.Count(),
+	}, &view.View{
+		Description: producedBytesTotal.Description(),
+		Measure:     producedBytesTotal,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: consumedBytesTotal.Description(),
+		Measure:     consumedBytesTotal,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: consumeDecompressionErrorsTotal.Description(),
+		Measure:     consumeDecompressionErrorsTotal,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, policy},
+	}, &view.View{
+		Description: staleEventRejectionCount.Description(),
+		Measure:     staleEventRejectionCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: oversizedMessageCount.Description(),
+		Measure:     oversizedMessageCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, policy},
+	}, &view.View{
+		Description: heartbeatCount.Description(),
+		Measure:     heartbeatCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, result},
+	}, &view.View{
+		Description: consumeRetryCount.Description(),
+		Measure:     consumeRetryCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, reason},
+	}, &view.View{
+		Description: replyFailureCount.Description(),
+		Measure:     replyFailureCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, policy},
+	}, &view.View{
+		Description: spoolDepth.Description(),
+		Measure:     spoolDepth,
+		Aggregation: view.LastValue(),
+	}, &view.View{
+		Description: auditDropCount.Description(),
+		Measure:     auditDropCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, reason},
+	}, &view.View{
+		Description: produceLatencySloExceededCount.Description(),
+		Measure:     produceLatencySloExceededCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: groupErrorLogDropCount.Description(),
+		Measure:     groupErrorLogDropCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic},
+	}, &view.View{
+		Description: rateLimitRejectionCount.Description(),
+		Measure:     rateLimitRejectionCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, reason},
+	}, &view.View{
+		Description: consumerLag.Description(),
+		Measure:     consumerLag,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{topic, group},
+	}, &view.View{
+		Description: dispatchSuccessCount.Description(),
+		Measure:     dispatchSuccessCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, subscriber, statusClass},
+	}, &view.View{
+		Description: dispatchFailureCount.Description(),
+		Measure:     dispatchFailureCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{topic, subscriber, statusClass},
 	})
 	if err != nil {
 		log.Printf("failed to register opencensus views, %v", err)
@@ -71,6 +328,23 @@ func init() {
 // StatsReporter defines the interface for sending ingress metrics.
 type StatsReporter interface {
 	Report(map[string]map[string]interface{})
+	ReportDeserializationFailure(topicName string, policyName string)
+	ReportAuthenticationFailure()
+	ReportProducedBytes(topicName string, bytes int64)
+	ReportConsumedBytes(topicName string, bytes int64)
+	ReportDecompressionError(topicName string, policyName string)
+	ReportStaleEventRejection(topicName string)
+	ReportOversizedMessage(topicName string, policyName string)
+	ReportHeartbeat(topicName string, success bool)
+	ReportConsumeRetry(topicName string, reasonName string)
+	ReportSpoolDepth(depth int64)
+	ReportReplyFailure(topicName string, policyName string)
+	ReportAuditDrop(topicName string, reasonName string)
+	ReportProduceLatencySloExceeded(topicName string)
+	ReportGroupErrorLogDrop(topicName string)
+	ReportRateLimitRejection(topicName string, reasonName string)
+	ReportConsumerLag(topicName string, groupId string, lag int64)
+	ReportDispatch(topicName string, subscriberUID string, statusClassName string, success bool)
 }
 
 // Verify StatsReporter Implements StatsReporter Interface
@@ -139,3 +413,282 @@ func (r *Reporter) Report(stats map[string]map[string]interface{}) {
 		}
 	}
 }
+
+// Report A Single Consumed Message Which Could Not Be Deserialized (Tagged With The Policy Applied To It)
+func (r *Reporter) ReportDeserializationFailure(topicName string, policyName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Policy
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(policy, policyName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Deserialization Failure", zap.String("Topic", topicName), zap.String("Policy", policyName))
+		return
+	}
+
+	// Record The Deserialization Failure Metric
+	metrics.Record(ctx, deserializationFailureCount.M(1))
+}
+
+// Report A Single Authentication Check Which Was Rejected By The Kafka Brokers
+func (r *Reporter) ReportAuthenticationFailure() {
+	metrics.Record(context.Background(), authenticationFailureCount.M(1))
+}
+
+// Report The Size Of A Single Serialized Message Produced To The Specified Topic
+func (r *Reporter) ReportProducedBytes(topicName string, bytes int64) {
+	r.recordTopicBytes(topicName, producedBytesTotal, bytes)
+}
+
+// Report The Size Of A Single Raw Message Consumed From The Specified Topic
+func (r *Reporter) ReportConsumedBytes(topicName string, bytes int64) {
+	r.recordTopicBytes(topicName, consumedBytesTotal, bytes)
+}
+
+// Report A Single Consumed Record Batch Which Could Not Be Decompressed (Tagged With The Policy Applied To It)
+func (r *Reporter) ReportDecompressionError(topicName string, policyName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Policy
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(policy, policyName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Decompression Error", zap.String("Topic", topicName), zap.String("Policy", policyName))
+		return
+	}
+
+	// Record The Decompression Error Metric
+	metrics.Record(ctx, consumeDecompressionErrorsTotal.M(1))
+}
+
+// Report A Single Received Event Which Was Rejected For Exceeding The Maximum Event Age
+func (r *Reporter) ReportStaleEventRejection(topicName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Stale Event Rejection", zap.String("Topic", topicName))
+		return
+	}
+
+	// Record The Stale Event Rejection Metric
+	metrics.Record(ctx, staleEventRejectionCount.M(1))
+}
+
+// Report A Single Consumed Message Which Exceeded The Maximum Message Size (Tagged With The Policy Applied To It)
+func (r *Reporter) ReportOversizedMessage(topicName string, policyName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Policy
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(policy, policyName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Oversized Message", zap.String("Topic", topicName), zap.String("Policy", policyName))
+		return
+	}
+
+	// Record The Oversized Message Metric
+	metrics.Record(ctx, oversizedMessageCount.M(1))
+}
+
+// Report A Single Heartbeat Event Produced To The Specified Topic (Tagged With Whether It Was Successful)
+func (r *Reporter) ReportHeartbeat(topicName string, success bool) {
+
+	// Determine The Result Tag Value
+	resultName := HeartbeatResultSuccess
+	if !success {
+		resultName = HeartbeatResultFailure
+	}
+
+	// Create A New OpenCensus Tag / Context For The Topic & Result
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(result, resultName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Heartbeat", zap.String("Topic", topicName), zap.String("Result", resultName))
+		return
+	}
+
+	// Record The Heartbeat Metric
+	metrics.Record(ctx, heartbeatCount.M(1))
+}
+
+// Report A Single Failed ConsumerGroup Consume() Which Is Being Retried (Tagged With The Reason For The Failure)
+func (r *Reporter) ReportConsumeRetry(topicName string, reasonName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Reason
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(reason, reasonName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Consume Retry", zap.String("Topic", topicName), zap.String("Reason", reasonName))
+		return
+	}
+
+	// Record The Consume Retry Metric
+	metrics.Record(ctx, consumeRetryCount.M(1))
+}
+
+// Report The Current Number Of Messages Spooled To Local Disk Awaiting Replay To Kafka
+func (r *Reporter) ReportSpoolDepth(depth int64) {
+	metrics.Record(context.Background(), spoolDepth.M(depth))
+}
+
+// Report A Single Reply Which Could Not Be Delivered To Its Reply Destination (Tagged With The Policy Applied To It)
+func (r *Reporter) ReportReplyFailure(topicName string, policyName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Policy
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(policy, policyName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Reply Failure", zap.String("Topic", topicName), zap.String("Policy", policyName))
+		return
+	}
+
+	// Record The Reply Failure Metric
+	metrics.Record(ctx, replyFailureCount.M(1))
+}
+
+// Report A Single Produced Record Whose Metadata Could Not Be Delivered To The Audit Sink (Tagged With The Reason)
+func (r *Reporter) ReportAuditDrop(topicName string, reasonName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Reason
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(reason, reasonName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Audit Drop", zap.String("Topic", topicName), zap.String("Reason", reasonName))
+		return
+	}
+
+	// Record The Audit Drop Metric
+	metrics.Record(ctx, auditDropCount.M(1))
+}
+
+// Report A Single Event Whose Produce Latency Exceeded The Configured SLO
+func (r *Reporter) ReportProduceLatencySloExceeded(topicName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Produce Latency SLO", zap.String("Topic", topicName))
+		return
+	}
+
+	// Record The Produce Latency SLO Exceeded Metric
+	metrics.Record(ctx, produceLatencySloExceededCount.M(1))
+}
+
+// Report A Single ConsumerGroup Error Log Which Was Dropped By The Dispatcher's Error Log Sampling
+func (r *Reporter) ReportGroupErrorLogDrop(topicName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For ConsumerGroup Error Log Drop", zap.String("Topic", topicName))
+		return
+	}
+
+	// Record The ConsumerGroup Error Log Drop Metric
+	metrics.Record(ctx, groupErrorLogDropCount.M(1))
+}
+
+// Record The Specified Byte Count Against The Specified Measure, Tagged With The Topic
+func (r *Reporter) recordTopicBytes(topicName string, measure *stats.Int64Measure, bytes int64) {
+
+	// Create A New OpenCensus Tag / Context For The Topic
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Kafka Topic", zap.String("Topic", topicName))
+		return
+	}
+
+	// Record The Byte Count Metric
+	metrics.Record(ctx, measure.M(bytes))
+}
+
+// Report A Single Received Event Which Was Rejected For Exceeding A Per-Channel Rate Limit (Tagged With The Limit Exceeded)
+func (r *Reporter) ReportRateLimitRejection(topicName string, reasonName string) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & Reason
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(reason, reasonName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Rate Limit Rejection", zap.String("Topic", topicName), zap.String("Reason", reasonName))
+		return
+	}
+
+	// Record The Rate Limit Rejection Metric
+	metrics.Record(ctx, rateLimitRejectionCount.M(1))
+}
+
+// Report The Current Total Lag Across All Partitions Of The Topic For The Specified ConsumerGroup
+func (r *Reporter) ReportConsumerLag(topicName string, groupId string, lag int64) {
+
+	// Create A New OpenCensus Tag / Context For The Topic & ConsumerGroup
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(group, groupId),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Consumer Lag", zap.String("Topic", topicName), zap.String("GroupId", groupId))
+		return
+	}
+
+	// Record The Consumer Lag Metric
+	metrics.Record(ctx, consumerLag.M(lag))
+}
+
+// Report A Single Message Dispatched To A Subscriber (Tagged With The Subscriber & HTTP Status Class, Counted By Outcome)
+func (r *Reporter) ReportDispatch(topicName string, subscriberUID string, statusClassName string, success bool) {
+
+	// Create A New OpenCensus Tag / Context For The Topic, Subscriber & Status Class
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(topic, topicName),
+		tag.Insert(subscriber, subscriberUID),
+		tag.Insert(statusClass, statusClassName),
+	)
+	if err != nil {
+		r.logger.Error("Failed To Create New OpenCensus Tag For Dispatch", zap.String("Topic", topicName), zap.String("Subscriber", subscriberUID), zap.String("StatusClass", statusClassName))
+		return
+	}
+
+	// Record The Dispatch Success Or Failure Metric
+	if success {
+		metrics.Record(ctx, dispatchSuccessCount.M(1))
+	} else {
+		metrics.Record(ctx, dispatchFailureCount.M(1))
+	}
+}
@@ -90,9 +90,39 @@ func TestMetricsServer_Report(t *testing.T) {
 
 	// Perform The Test
 	statsReporter.Report(stats)
+	statsReporter.ReportDeserializationFailure(topicName, "skip")
+	statsReporter.ReportDeserializationFailure(topicName, "skip")
+	statsReporter.ReportAuthenticationFailure()
+	statsReporter.ReportProducedBytes(topicName, 100)
+	statsReporter.ReportProducedBytes(topicName, 150)
+	statsReporter.ReportConsumedBytes(topicName, 75)
+	statsReporter.ReportDecompressionError(topicName, "retry")
+	statsReporter.ReportStaleEventRejection(topicName)
+	statsReporter.ReportOversizedMessage(topicName, "skip")
+	statsReporter.ReportHeartbeat(topicName, true)
+	statsReporter.ReportHeartbeat(topicName, true)
+	statsReporter.ReportHeartbeat(topicName, false)
+	statsReporter.ReportConsumeRetry(topicName, ConsumeRetryReasonCoordinatorUnavailable)
+	statsReporter.ReportSpoolDepth(3)
+	statsReporter.ReportReplyFailure(topicName, "drop")
+	statsReporter.ReportAuditDrop(topicName, AuditDropReasonQueueFull)
+	statsReporter.ReportProduceLatencySloExceeded(topicName)
+	statsReporter.ReportGroupErrorLogDrop(topicName)
+	statsReporter.ReportGroupErrorLogDrop(topicName)
+	statsReporter.ReportRateLimitRejection(topicName, RateLimitReasonRequests)
+	statsReporter.ReportRateLimitRejection(topicName, RateLimitReasonRequests)
+	statsReporter.ReportRateLimitRejection(topicName, RateLimitReasonRequests)
+	statsReporter.ReportConsumerLag(topicName, "test-group-id", 25)
+	statsReporter.ReportConsumerLag(topicName, "test-group-id", 17)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", "2xx", true)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", "2xx", true)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", "5xx", false)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", "5xx", false)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", "5xx", false)
+	statsReporter.ReportDispatch(topicName, "test-subscriber-uid", DispatchStatusClassUnknown, false)
 
 	// Verify The Results By Querying Metrics Endpoint And Parsing Results
-	resp, err := commontesting.RetryGet(fmt.Sprintf("http://localhost:%v/metrics", metricsPort), 100*time.Millisecond, 20)
+	resp, err := commontesting.RetryGet(context.TODO(), fmt.Sprintf("http://localhost:%v/metrics", metricsPort), 100*time.Millisecond, 20, false, 0)
 	assert.Nil(t, err)
 	body, err := ioutil.ReadAll(resp.Body)
 	assert.Nil(t, err)
@@ -100,6 +130,26 @@ func TestMetricsServer_Report(t *testing.T) {
 	assert.Nil(t, err)
 	bodyStrings := strings.Split(string(body), "\n")
 	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_produced_msg_count", topicName, strconv.Itoa(msgCount)))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_deserialization_failure_count", topicName, "2"))
+	assert.True(t, verifyUntaggedMetric(bodyStrings, "eventing_kafka_authentication_failure_count", "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_kafka_produce_bytes_total", topicName, "250"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_kafka_consume_bytes_total", topicName, "75"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_consume_decompression_errors_total", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_stale_event_rejection_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_oversized_message_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_heartbeat_count", topicName, "2"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_heartbeat_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_consume_retry_count", topicName, "1"))
+	assert.True(t, verifyUntaggedMetric(bodyStrings, "eventing_kafka_spool_depth", "3"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_consumergroup_error_log_drop_count", topicName, "2"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_rate_limit_rejection_count", topicName, "3"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_reply_failure_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_audit_drop_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_produce_latency_slo_exceeded_count", topicName, "1"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_kafka_consumer_lag", topicName, "17"))
+	assert.True(t, verifyMetric(bodyStrings, "eventing_kafka_dispatch_success_count", topicName, "2"))
+	assert.True(t, verifyMetric(bodyStrings, `eventing_kafka_dispatch_failure_count.*status_class="5xx"`, topicName, "3"))
+	assert.True(t, verifyMetric(bodyStrings, `eventing_kafka_dispatch_failure_count.*status_class="unknown"`, topicName, "1"))
 }
 
 // Utility Function For Creating Sample Test Metrics  (Representative Data From Sarama Metrics Trace - With Custom Test Data)
@@ -143,6 +193,16 @@ func verifyMetric(body []string, name string, topicName string, expectedValue st
 	return false
 }
 
+// Verifies that the metrics response string slice contains the desired untagged value
+func verifyUntaggedMetric(body []string, name string, expectedValue string) bool {
+	for _, line := range body {
+		if isMatch(line, fmt.Sprintf(`^%s`, name)) && isMatch(line, fmt.Sprintf(` %s$`, expectedValue)) {
+			return true
+		}
+	}
+	return false
+}
+
 // Simple regex match that treats errors as false, for testing only
 func isMatch(source string, regex string) bool {
 	match, err := regexp.MatchString(regex, source)
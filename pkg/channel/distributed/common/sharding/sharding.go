@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Constants
+const (
+
+	// Annotation On The KafkaChannel Specifying The Number Of Dispatcher Deployments Its Subscriptions Are Sharded Across
+	DispatcherShardsAnnotation = "eventing-kafka.knative.dev/dispatcher-shards"
+
+	// Bounds Of The Number Of Dispatcher Shards (A Single Shard Being Equivalent To The Annotation Not Being Present)
+	MinDispatcherShards = 1
+	MaxDispatcherShards = 16
+)
+
+//
+// Parse The KafkaChannel's DispatcherShards Annotation
+//
+// The annotation is the number of Dispatcher Deployments (between MinDispatcherShards and MaxDispatcherShards) across
+// which the KafkaChannel's subscriptions are sharded.  An empty annotation results in a single (unsharded) Dispatcher.
+//
+func ParseDispatcherShards(annotation string) (int, error) {
+
+	if len(annotation) <= 0 {
+		return MinDispatcherShards, nil
+	}
+
+	shards, err := strconv.Atoi(annotation)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation: %w", DispatcherShardsAnnotation, err)
+	}
+	if shards < MinDispatcherShards || shards > MaxDispatcherShards {
+		return 0, fmt.Errorf("invalid %s annotation: %d must be between %d and %d", DispatcherShardsAnnotation, shards, MinDispatcherShards, MaxDispatcherShards)
+	}
+
+	return shards, nil
+}
+
+//
+// Get The Dispatcher Shard (In The Range [0, shards)) Owning The Subscription With The Specified UID
+//
+// Subscriptions are assigned via rendezvous (highest random weight) hashing of the UID against each shard, so that the
+// assignment depends only on the UID and the number of shards (not the other subscriptions), is identical in the
+// Controller and every Dispatcher, and changing the number of shards from N to M only moves the subscriptions which
+// are assigned to one of the added shards (or were assigned to one of the removed shards).  The ConsumerGroup ID of a
+// subscription does not depend on its shard, so a moved subscription resumes from its committed offsets.
+//
+func SubscriptionShard(uid types.UID, shards int) int {
+	ownerShard := 0
+	var ownerWeight uint64
+	for shard := 0; shard < shards; shard++ {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", uid, shard)))
+		if weight := binary.BigEndian.Uint64(hash[:8]); shard == 0 || weight > ownerWeight {
+			ownerShard = shard
+			ownerWeight = weight
+		}
+	}
+	return ownerShard
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Test The ParseDispatcherShards() Functionality
+func TestParseDispatcherShards(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		annotation string
+		want       int
+		wantErr    bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Empty", annotation: "", want: 1},
+		{name: "Single", annotation: "1", want: 1},
+		{name: "Multiple", annotation: "4", want: 4},
+		{name: "Maximum", annotation: "16", want: 16},
+		{name: "Zero", annotation: "0", wantErr: true},
+		{name: "Negative", annotation: "-2", wantErr: true},
+		{name: "Too Many", annotation: "17", wantErr: true},
+		{name: "Not A Number", annotation: "three", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			shards, err := ParseDispatcherShards(testCase.annotation)
+			assert.Equal(t, testCase.want, shards)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That Subscriptions Are Assigned To A Valid Shard Consistently & Roughly Evenly
+func TestSubscriptionShard(t *testing.T) {
+	uids := testUids(1000)
+	for _, shards := range []int{1, 2, 3, 8, 16} {
+		counts := make([]int, shards)
+		for _, uid := range uids {
+			shard := SubscriptionShard(uid, shards)
+			assert.True(t, shard >= 0 && shard < shards)
+			assert.Equal(t, shard, SubscriptionShard(uid, shards)) // Deterministic
+			counts[shard]++
+		}
+		for shard, count := range counts {
+			assert.Greater(t, count, len(uids)/shards/2, "shard %d of %d is underloaded", shard, shards)
+		}
+	}
+}
+
+// Test That Changing The Number Of Shards Only Moves Subscriptions To Added Shards (Or From Removed Shards)
+func TestSubscriptionShardStability(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name string
+		from int
+		to   int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unsharded To Two", from: 1, to: 2},
+		{name: "Scale Out By One", from: 3, to: 4},
+		{name: "Scale Out By Several", from: 4, to: 8},
+		{name: "Scale In By One", from: 4, to: 3},
+		{name: "Scale In To Unsharded", from: 8, to: 1},
+	}
+
+	// Execute The Individual Test Cases
+	uids := testUids(1000)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			moved := 0
+			for _, uid := range uids {
+				fromShard := SubscriptionShard(uid, testCase.from)
+				toShard := SubscriptionShard(uid, testCase.to)
+				if fromShard != toShard {
+					moved++
+					if testCase.to > testCase.from {
+						assert.GreaterOrEqual(t, toShard, testCase.from, "subscription %s moved between existing shards", uid)
+					} else {
+						assert.GreaterOrEqual(t, fromShard, testCase.to, "subscription %s moved from a retained shard", uid)
+					}
+				}
+			}
+
+			// Only The Share Of The Added / Removed Shards Should Move (Allowing For Some Variance)
+			larger, smaller := testCase.to, testCase.from
+			if smaller > larger {
+				larger, smaller = smaller, larger
+			}
+			expectedMoved := len(uids) * (larger - smaller) / larger
+			assert.InDelta(t, expectedMoved, moved, float64(len(uids))/10)
+		})
+	}
+}
+
+// Create The Specified Number Of Test Subscription UIDs
+func testUids(count int) []types.UID {
+	uids := make([]types.UID, count)
+	for index := range uids {
+		uids[index] = types.UID(fmt.Sprintf("5ca4f2a6-2b4e-4b8a-9c1d-%012d", index))
+	}
+	return uids
+}
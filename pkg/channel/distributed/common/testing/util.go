@@ -17,6 +17,8 @@ limitations under the License.
 package testing
 
 import (
+	"context"
+	"math/rand"
 	"net/http"
 	"testing"
 	"time"
@@ -64,19 +66,52 @@ func GetDefaultSaramaConfig(t *testing.T) *sarama.Config {
 	return config
 }
 
-// Retries an HTTP GET request a specified number of times before giving up
-func RetryGet(url string, pause time.Duration, retryCount int) (*http.Response, error) {
+// Retries an HTTP GET request a specified number of times before giving up (or the context is done).  The pause
+// between tries is fixed, unless backoff is true in which case it doubles after each try (up to maxPause) with jitter.
+func RetryGet(ctx context.Context, url string, pause time.Duration, retryCount int, backoff bool, maxPause time.Duration) (*http.Response, error) {
 	var resp *http.Response
 	var err error
 
-	// Retry up to "retryCount" number of attempts, waiting for "pause" duration between tries.
+	// Retry up to "retryCount" number of attempts, waiting for "pause" duration (or the backoff) between tries.
 	for tryCounter := 0; tryCounter < retryCount; tryCounter++ {
-		if resp, err = http.Get(url); err == nil {
+		var req *http.Request
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil); err != nil {
+			return nil, err
+		}
+		if resp, err = http.DefaultClient.Do(req); err == nil {
 			// GET request succeeded; return immediately
 			return resp, err
 		}
-		time.Sleep(pause)
+		if tryCounter < retryCount-1 {
+			wait := pause
+			if backoff {
+				wait = backoffPause(pause, maxPause, tryCounter)
+			}
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				// Test run was cancelled; stop retrying
+				return resp, ctx.Err()
+			}
+		}
 	}
 	// Request failed too many times; return the error for caller to process
 	return resp, err
 }
+
+// Returns the pause after the specified (zero-based) try, doubling the initial pause after each try up to maxPause,
+// and randomized to between half and all of that so that concurrent retries do not stay in lock-step.
+func backoffPause(pause time.Duration, maxPause time.Duration, tryCounter int) time.Duration {
+	backoff := pause
+	for index := 0; index < tryCounter && (maxPause <= 0 || backoff < maxPause); index++ {
+		backoff *= 2
+	}
+	if maxPause > 0 && backoff > maxPause {
+		backoff = maxPause
+	}
+	if backoff <= 1 {
+		return backoff
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
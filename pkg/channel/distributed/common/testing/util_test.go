@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The RetryGet() Functionality
+func TestRetryGet(t *testing.T) {
+
+	// Create A Test Server & The URL Of A Closed One (Refusing Connections)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedUrl := closedServer.URL
+	closedServer.Close()
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		url        string
+		backoff    bool
+		cancelled  bool
+		wantStatus int
+		wantErr    bool
+		maxElapsed time.Duration
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "Success",
+			url:        server.URL,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Success With Backoff",
+			url:        server.URL,
+			backoff:    true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "Retries Exhausted",
+			url:     closedUrl,
+			wantErr: true,
+		},
+		{
+			name:    "Retries Exhausted With Backoff",
+			url:     closedUrl,
+			backoff: true,
+			wantErr: true,
+		},
+		{
+			name:       "Cancelled",
+			url:        closedUrl,
+			cancelled:  true,
+			wantErr:    true,
+			maxElapsed: time.Second,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Cancel The Context Per The TestCase
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			if testCase.cancelled {
+				cancel()
+			}
+
+			// Perform The Test (Long Pauses Would Only Be Interrupted By Cancellation)
+			pause := time.Millisecond
+			if testCase.cancelled {
+				pause = time.Minute
+			}
+			start := time.Now()
+			resp, err := RetryGet(ctx, testCase.url, pause, 3, testCase.backoff, 4*time.Millisecond)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantStatus != 0 {
+				assert.NotNil(t, resp)
+				assert.Equal(t, testCase.wantStatus, resp.StatusCode)
+				assert.Nil(t, resp.Body.Close())
+			}
+			if testCase.maxElapsed > 0 {
+				assert.Less(t, int64(time.Since(start)), int64(testCase.maxElapsed))
+			}
+		})
+	}
+}
+
+// Test The Exponential Backoff (With Jitter) Between RetryGet() Tries
+func TestBackoffPause(t *testing.T) {
+	pause := 100 * time.Millisecond
+	maxPause := time.Second
+	for tryCounter, wantBackoff := range []time.Duration{pause, 2 * pause, 4 * pause, 8 * pause, maxPause, maxPause} {
+		for index := 0; index < 100; index++ {
+			backoff := backoffPause(pause, maxPause, tryCounter)
+			assert.GreaterOrEqual(t, int64(backoff), int64(wantBackoff/2))
+			assert.LessOrEqual(t, int64(backoff), int64(wantBackoff))
+		}
+	}
+
+	// Verify The Backoff Is Uncapped Without A Maximum
+	assert.Greater(t, int64(backoffPause(pause, 0, 10)), int64(maxPause))
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 )
 
 // ConfigurationError is the type of error returned from VerifyOverrides
@@ -45,10 +48,70 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 		return ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: " + configuration.Kafka.AdminType)
 	}
 
+	// Verify & Lowercase The Receiver Mode (Defaulting To One Receiver Per Kafka Secret)
+	lowercaseReceiverMode := strings.ToLower(configuration.Receiver.Mode)
+	switch lowercaseReceiverMode {
+	case "":
+		configuration.Receiver.Mode = constants.DefaultReceiverMode
+	case constants.ReceiverModeSecret, constants.ReceiverModeChannel:
+		configuration.Receiver.Mode = lowercaseReceiverMode
+	default:
+		return ControllerConfigurationError("Invalid / Unknown Receiver Mode: " + configuration.Receiver.Mode)
+	}
+
+	// Verify & Lowercase The Receiver & Dispatcher Naming Policies (Defaulting To Hashed Names)
+	receiverNamingPolicy, err := verifyNamingPolicy("Receiver", configuration.Receiver.NamingPolicy)
+	if err != nil {
+		return err
+	}
+	configuration.Receiver.NamingPolicy = receiverNamingPolicy
+	dispatcherNamingPolicy, err := verifyNamingPolicy("Dispatcher", configuration.Dispatcher.NamingPolicy)
+	if err != nil {
+		return err
+	}
+	configuration.Dispatcher.NamingPolicy = dispatcherNamingPolicy
+
+	// Verify & Lowercase The KafkaChannel Deprecated Spec Field Policy (Defaulting To Migration)
+	lowercaseDeprecatedFieldPolicy := strings.ToLower(configuration.Kafka.DeprecatedFieldPolicy)
+	switch lowercaseDeprecatedFieldPolicy {
+	case "":
+		configuration.Kafka.DeprecatedFieldPolicy = constants.DefaultDeprecatedFieldPolicy
+	case constants.DeprecatedFieldPolicyMigrate, constants.DeprecatedFieldPolicyWarn, constants.DeprecatedFieldPolicyFail:
+		configuration.Kafka.DeprecatedFieldPolicy = lowercaseDeprecatedFieldPolicy
+	default:
+		return ControllerConfigurationError("Invalid / Unknown Kafka Deprecated Field Policy: " + configuration.Kafka.DeprecatedFieldPolicy)
+	}
+
+	// Verify & Lowercase The KafkaChannel Delivery Order Policy (Defaulting To Warning)
+	lowercaseDeliveryOrderPolicy := strings.ToLower(configuration.Kafka.DeliveryOrderPolicy)
+	switch lowercaseDeliveryOrderPolicy {
+	case "":
+		configuration.Kafka.DeliveryOrderPolicy = constants.DefaultDeliveryOrderPolicy
+	case constants.DeliveryOrderPolicyWarn, constants.DeliveryOrderPolicyReject:
+		configuration.Kafka.DeliveryOrderPolicy = lowercaseDeliveryOrderPolicy
+	default:
+		return ControllerConfigurationError("Invalid / Unknown Kafka Delivery Order Policy: " + configuration.Kafka.DeliveryOrderPolicy)
+	}
+
+	// Verify & Lowercase The Kafka Brokers Change Policy (Defaulting To Rollout)
+	lowercaseBrokersChangePolicy := strings.ToLower(configuration.Kafka.BrokersChangePolicy)
+	switch lowercaseBrokersChangePolicy {
+	case "":
+		configuration.Kafka.BrokersChangePolicy = constants.DefaultBrokersChangePolicy
+	case constants.BrokersChangePolicyRollout, constants.BrokersChangePolicyIgnore:
+		configuration.Kafka.BrokersChangePolicy = lowercaseBrokersChangePolicy
+	default:
+		return ControllerConfigurationError("Invalid / Unknown Kafka Brokers Change Policy: " + configuration.Kafka.BrokersChangePolicy)
+	}
+
 	// Verify mandatory configuration settings
 	switch {
 	case configuration.Kafka.Topic.DefaultNumPartitions < 1:
 		return ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be > 0")
+	case configuration.Kafka.Topic.MaxNumPartitions < 0:
+		return ControllerConfigurationError("Kafka.Topic.MaxNumPartitions must be >= 0")
+	case configuration.Kafka.Topic.MaxNumPartitions > 0 && configuration.Kafka.Topic.DefaultNumPartitions > configuration.Kafka.Topic.MaxNumPartitions:
+		return ControllerConfigurationError(fmt.Sprintf("Kafka.Topic.DefaultNumPartitions must be <= %d (Kafka.Topic.MaxNumPartitions)", configuration.Kafka.Topic.MaxNumPartitions))
 	case configuration.Kafka.Topic.DefaultReplicationFactor < 1:
 		return ControllerConfigurationError("Kafka.Topic.DefaultReplicationFactor must be > 0")
 	case configuration.Kafka.Topic.DefaultRetentionMillis < 1:
@@ -63,6 +126,8 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 		return ControllerConfigurationError("Dispatcher.MemoryRequest must be nonzero")
 	case configuration.Dispatcher.Replicas < 1:
 		return ControllerConfigurationError("Dispatcher.Replicas must be > 0")
+	case configuration.Dispatcher.SubscriptionSnapshot.MaxAgeMillis < 0:
+		return ControllerConfigurationError("Dispatcher.SubscriptionSnapshot.MaxAgeMillis must be >= 0")
 	case configuration.Receiver.CpuLimit == resource.Quantity{}:
 		return ControllerConfigurationError("Receiver.CpuLimit must be nonzero")
 	case configuration.Receiver.CpuRequest == resource.Quantity{}:
@@ -74,5 +139,89 @@ func VerifyConfiguration(configuration *config.EventingKafkaConfig) error {
 	case configuration.Receiver.Replicas < 1:
 		return ControllerConfigurationError("Receiver.Replicas must be > 0")
 	}
+
+	// Verify the default topic retention falls within the configured (or representable) bounds
+	if err := verifyRetentionMillis(configuration.Kafka.Topic); err != nil {
+		return err
+	}
+
+	// Verify the dispatcher's termination grace period (if specified) allows it to drain within its shutdown timeout
+	minimumGracePeriodSeconds := util.MinimumDispatcherTerminationGracePeriodSeconds(configuration.Dispatcher)
+	if configuration.Dispatcher.TerminationGracePeriodSeconds < 0 {
+		return ControllerConfigurationError("Dispatcher.TerminationGracePeriodSeconds must be >= 0")
+	} else if configuration.Dispatcher.TerminationGracePeriodSeconds > 0 && configuration.Dispatcher.TerminationGracePeriodSeconds < minimumGracePeriodSeconds {
+		return ControllerConfigurationError(fmt.Sprintf("Dispatcher.TerminationGracePeriodSeconds must be >= %d (Dispatcher.ShutdownTimeoutMillis plus %d seconds)",
+			minimumGracePeriodSeconds, constants.DispatcherTerminationGracePeriodBufferSeconds))
+	}
+
+	// Verify optional scheduling configuration settings
+	if err := verifyTopologySpreadConstraints("Dispatcher", configuration.Dispatcher.TopologySpreadConstraints); err != nil {
+		return err
+	}
+	if err := verifyTopologySpreadConstraints("Receiver", configuration.Receiver.TopologySpreadConstraints); err != nil {
+		return err
+	}
 	return nil // no problems found
 }
+
+// verifyRetentionMillis returns an error if the default topic retention falls outside the optional MinRetentionMillis
+// and MaxRetentionMillis bounds (which should mirror any retention.ms policy enforced by the Kafka brokers, as topic
+// creation would otherwise fail), or exceeds the largest retention representable as a time.Duration.
+func verifyRetentionMillis(topicConfig config.EKKafkaTopicConfig) error {
+
+	// Determine The Effective Maximum (Never Exceeding The Representable Maximum)
+	maxRetentionMillis := int64(constants.MaxTopicRetentionMillis)
+	if topicConfig.MaxRetentionMillis > 0 && topicConfig.MaxRetentionMillis < maxRetentionMillis {
+		maxRetentionMillis = topicConfig.MaxRetentionMillis
+	}
+
+	switch {
+	case topicConfig.MinRetentionMillis < 0:
+		return ControllerConfigurationError("Kafka.Topic.MinRetentionMillis must be >= 0")
+	case topicConfig.MaxRetentionMillis < 0:
+		return ControllerConfigurationError("Kafka.Topic.MaxRetentionMillis must be >= 0")
+	case topicConfig.MinRetentionMillis > maxRetentionMillis:
+		return ControllerConfigurationError(fmt.Sprintf("Kafka.Topic.MinRetentionMillis must be <= %d", maxRetentionMillis))
+	case topicConfig.DefaultRetentionMillis < topicConfig.MinRetentionMillis:
+		return ControllerConfigurationError(fmt.Sprintf("Kafka.Topic.DefaultRetentionMillis must be >= %d (Kafka.Topic.MinRetentionMillis)", topicConfig.MinRetentionMillis))
+	case topicConfig.DefaultRetentionMillis > maxRetentionMillis:
+		return ControllerConfigurationError(fmt.Sprintf("Kafka.Topic.DefaultRetentionMillis must be <= %d", maxRetentionMillis))
+	}
+	return nil
+}
+
+// verifyTopologySpreadConstraints returns an error if any of the specified constraints would be rejected by
+// the Kubernetes API when creating the Deployment (mirrors the relevant apiserver PodSpec validation).
+func verifyTopologySpreadConstraints(component string, constraints []corev1.TopologySpreadConstraint) error {
+	existingConstraints := make(map[string]bool, len(constraints))
+	for index, constraint := range constraints {
+		field := fmt.Sprintf("%s.TopologySpreadConstraints[%d]", component, index)
+		switch {
+		case constraint.MaxSkew < 1:
+			return ControllerConfigurationError(field + ".MaxSkew must be > 0")
+		case len(constraint.TopologyKey) == 0:
+			return ControllerConfigurationError(field + ".TopologyKey must be specified")
+		case constraint.WhenUnsatisfiable != corev1.DoNotSchedule && constraint.WhenUnsatisfiable != corev1.ScheduleAnyway:
+			return ControllerConfigurationError(field + ".WhenUnsatisfiable must be one of DoNotSchedule or ScheduleAnyway")
+		}
+		pair := constraint.TopologyKey + "/" + string(constraint.WhenUnsatisfiable)
+		if existingConstraints[pair] {
+			return ControllerConfigurationError(field + " duplicates an existing TopologyKey & WhenUnsatisfiable pair")
+		}
+		existingConstraints[pair] = true
+	}
+	return nil
+}
+
+// Verify & Lowercase The Specified Component's Naming Policy (Defaulting To Hashed Names)
+func verifyNamingPolicy(component string, namingPolicy string) (string, error) {
+	lowercaseNamingPolicy := strings.ToLower(namingPolicy)
+	switch lowercaseNamingPolicy {
+	case "":
+		return constants.DefaultNamingPolicy, nil
+	case constants.NamingPolicyHashed, constants.NamingPolicyReadable:
+		return lowercaseNamingPolicy, nil
+	default:
+		return "", ControllerConfigurationError("Invalid / Unknown " + component + " Naming Policy: " + namingPolicy)
+	}
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package config
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
 
 // Test Constants
@@ -53,19 +56,40 @@ type TestCase struct {
 	kafkaTopicDefaultNumPartitions     int32
 	kafkaTopicDefaultReplicationFactor int16
 	kafkaTopicDefaultRetentionMillis   int64
+	kafkaTopicMinRetentionMillis       int64
+	kafkaTopicMaxRetentionMillis       int64
+	kafkaTopicMaxNumPartitions         int32
 	kafkaAdminType                     string
 	dispatcherCpuLimit                 resource.Quantity
 	dispatcherCpuRequest               resource.Quantity
 	dispatcherMemoryLimit              resource.Quantity
 	dispatcherMemoryRequest            resource.Quantity
 	dispatcherReplicas                 int
+	dispatcherShutdownTimeoutMillis    int64
+	dispatcherGracePeriodSeconds       int64
+	dispatcherSnapshotMaxAgeMillis     int64
 	channelCpuLimit                    resource.Quantity
 	channelCpuRequest                  resource.Quantity
 	channelMemoryLimit                 resource.Quantity
 	channelMemoryRequest               resource.Quantity
 	channelReplicas                    int
-
-	expectedError error
+	channelMode                        string
+	channelNamingPolicy                string
+	dispatcherNamingPolicy             string
+	deprecatedFieldPolicy              string
+	deliveryOrderPolicy                string
+	brokersChangePolicy                string
+
+	dispatcherTopologySpreadConstraints []corev1.TopologySpreadConstraint
+	channelTopologySpreadConstraints    []corev1.TopologySpreadConstraint
+
+	expectedChannelMode            string
+	expectedChannelNamingPolicy    string
+	expectedDispatcherNamingPolicy string
+	expectedDeprecatedFieldPolicy  string
+	expectedDeliveryOrderPolicy    string
+	expectedBrokersChangePolicy    string
+	expectedError                  error
 }
 
 // Get The Base / Valid Test Case - All Config Specified / No Errors
@@ -86,6 +110,12 @@ func getValidTestCase(name string) TestCase {
 		channelMemoryLimit:                 resource.MustParse(channelMemoryLimit),
 		channelMemoryRequest:               resource.MustParse(channelMemoryRequest),
 		channelReplicas:                    channelReplicas,
+		expectedChannelMode:                constants.DefaultReceiverMode,
+		expectedChannelNamingPolicy:        constants.DefaultNamingPolicy,
+		expectedDispatcherNamingPolicy:     constants.DefaultNamingPolicy,
+		expectedDeprecatedFieldPolicy:      constants.DefaultDeprecatedFieldPolicy,
+		expectedDeliveryOrderPolicy:        constants.DefaultDeliveryOrderPolicy,
+		expectedBrokersChangePolicy:        constants.DefaultBrokersChangePolicy,
 		expectedError:                      nil,
 	}
 }
@@ -104,6 +134,26 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultNumPartitions At Maximum")
+	testCase.kafkaTopicMaxNumPartitions = defaultNumPartitions
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultNumPartitions Above Maximum")
+	testCase.kafkaTopicMaxNumPartitions = defaultNumPartitions - 1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be <= 6 (Kafka.Topic.MaxNumPartitions)")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultNumPartitions Absurd")
+	testCase.kafkaTopicDefaultNumPartitions = 100000
+	testCase.kafkaTopicMaxNumPartitions = 1000
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultNumPartitions must be <= 1000 (Kafka.Topic.MaxNumPartitions)")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.MaxNumPartitions Negative")
+	testCase.kafkaTopicMaxNumPartitions = -1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.MaxNumPartitions must be >= 0")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultReplicationFactor")
 	testCase.kafkaTopicDefaultReplicationFactor = -1
 	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultReplicationFactor must be > 0")
@@ -114,6 +164,60 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultRetentionMillis At Minimum")
+	testCase.kafkaTopicMinRetentionMillis = defaultRetentionMillis
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultRetentionMillis At Maximum")
+	testCase.kafkaTopicMaxRetentionMillis = defaultRetentionMillis
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Kafka.Topic.DefaultRetentionMillis At Representable Maximum")
+	testCase.kafkaTopicDefaultRetentionMillis = constants.MaxTopicRetentionMillis
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis Below Minimum")
+	testCase.kafkaTopicMinRetentionMillis = defaultRetentionMillis + 1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be >= 13580 (Kafka.Topic.MinRetentionMillis)")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis Above Maximum")
+	testCase.kafkaTopicMaxRetentionMillis = defaultRetentionMillis - 1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be <= 13578")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis Above Representable Maximum")
+	testCase.kafkaTopicDefaultRetentionMillis = constants.MaxTopicRetentionMillis + 1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be <= 9223372036854")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis Overflow")
+	testCase.kafkaTopicDefaultRetentionMillis = math.MaxInt64
+	testCase.kafkaTopicMaxRetentionMillis = math.MaxInt64
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be <= 9223372036854")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.DefaultRetentionMillis Negative Overflow")
+	testCase.kafkaTopicDefaultRetentionMillis = math.MinInt64
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.DefaultRetentionMillis must be > 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.MinRetentionMillis Negative")
+	testCase.kafkaTopicMinRetentionMillis = -1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.MinRetentionMillis must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.MaxRetentionMillis Negative")
+	testCase.kafkaTopicMaxRetentionMillis = -1
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.MaxRetentionMillis must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.Topic.MinRetentionMillis Above MaxRetentionMillis")
+	testCase.kafkaTopicMinRetentionMillis = 20000
+	testCase.kafkaTopicMaxRetentionMillis = 10000
+	testCase.expectedError = ControllerConfigurationError("Kafka.Topic.MinRetentionMillis must be <= 10000")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Dispatcher.CpuLimit")
 	testCase.dispatcherCpuLimit = resource.Quantity{}
 	testCase.expectedError = ControllerConfigurationError("Dispatcher.CpuLimit must be nonzero")
@@ -164,6 +268,147 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Receiver.Replicas must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Receiver.Mode Secret")
+	testCase.channelMode = constants.ReceiverModeSecret
+	testCase.expectedChannelMode = constants.ReceiverModeSecret
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Receiver.Mode Channel (Mixed Case)")
+	testCase.channelMode = "Channel"
+	testCase.expectedChannelMode = constants.ReceiverModeChannel
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Receiver.Mode")
+	testCase.channelMode = "namespace"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Receiver Mode: namespace")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - NamingPolicy Readable (Mixed Case)")
+	testCase.channelNamingPolicy = "Readable"
+	testCase.dispatcherNamingPolicy = constants.NamingPolicyReadable
+	testCase.expectedChannelNamingPolicy = constants.NamingPolicyReadable
+	testCase.expectedDispatcherNamingPolicy = constants.NamingPolicyReadable
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - NamingPolicy Hashed")
+	testCase.channelNamingPolicy = constants.NamingPolicyHashed
+	testCase.dispatcherNamingPolicy = constants.NamingPolicyHashed
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Receiver.NamingPolicy")
+	testCase.channelNamingPolicy = "opaque"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Receiver Naming Policy: opaque")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.NamingPolicy")
+	testCase.dispatcherNamingPolicy = "opaque"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Dispatcher Naming Policy: opaque")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - DeprecatedFieldPolicy Warn (Mixed Case)")
+	testCase.deprecatedFieldPolicy = "Warn"
+	testCase.expectedDeprecatedFieldPolicy = constants.DeprecatedFieldPolicyWarn
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - DeprecatedFieldPolicy Fail")
+	testCase.deprecatedFieldPolicy = constants.DeprecatedFieldPolicyFail
+	testCase.expectedDeprecatedFieldPolicy = constants.DeprecatedFieldPolicyFail
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.DeprecatedFieldPolicy")
+	testCase.deprecatedFieldPolicy = "ignore"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Deprecated Field Policy: ignore")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - DeliveryOrderPolicy Reject (Mixed Case)")
+	testCase.deliveryOrderPolicy = "Reject"
+	testCase.expectedDeliveryOrderPolicy = constants.DeliveryOrderPolicyReject
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.DeliveryOrderPolicy")
+	testCase.deliveryOrderPolicy = "ignore"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Delivery Order Policy: ignore")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - BrokersChangePolicy Ignore (Mixed Case)")
+	testCase.brokersChangePolicy = "Ignore"
+	testCase.expectedBrokersChangePolicy = constants.BrokersChangePolicyIgnore
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Kafka.BrokersChangePolicy")
+	testCase.brokersChangePolicy = "restart"
+	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Brokers Change Policy: restart")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - TopologySpreadConstraints")
+	testCase.dispatcherTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(1, corev1.LabelZoneFailureDomainStable, corev1.DoNotSchedule),
+		newTopologySpreadConstraint(2, corev1.LabelHostname, corev1.ScheduleAnyway),
+	}
+	testCase.channelTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(1, corev1.LabelZoneFailureDomainStable, corev1.ScheduleAnyway),
+	}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.TopologySpreadConstraints.MaxSkew")
+	testCase.dispatcherTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(0, corev1.LabelZoneFailureDomainStable, corev1.DoNotSchedule),
+	}
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.TopologySpreadConstraints[0].MaxSkew must be > 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.TopologySpreadConstraints.TopologyKey")
+	testCase.dispatcherTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(1, "", corev1.DoNotSchedule),
+	}
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.TopologySpreadConstraints[0].TopologyKey must be specified")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Receiver.TopologySpreadConstraints.WhenUnsatisfiable")
+	testCase.channelTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(1, corev1.LabelZoneFailureDomainStable, "Sometimes"),
+	}
+	testCase.expectedError = ControllerConfigurationError("Receiver.TopologySpreadConstraints[0].WhenUnsatisfiable must be one of DoNotSchedule or ScheduleAnyway")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Receiver.TopologySpreadConstraints Duplicate")
+	testCase.channelTopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		newTopologySpreadConstraint(1, corev1.LabelZoneFailureDomainStable, corev1.DoNotSchedule),
+		newTopologySpreadConstraint(2, corev1.LabelZoneFailureDomainStable, corev1.DoNotSchedule),
+	}
+	testCase.expectedError = ControllerConfigurationError("Receiver.TopologySpreadConstraints[1] duplicates an existing TopologyKey & WhenUnsatisfiable pair")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher.TerminationGracePeriodSeconds Default Shutdown Timeout")
+	testCase.dispatcherGracePeriodSeconds = 20
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - Dispatcher.TerminationGracePeriodSeconds Configured Shutdown Timeout")
+	testCase.dispatcherShutdownTimeoutMillis = 30500
+	testCase.dispatcherGracePeriodSeconds = 41
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.TerminationGracePeriodSeconds Below Default Shutdown Timeout")
+	testCase.dispatcherGracePeriodSeconds = 19
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.TerminationGracePeriodSeconds must be >= 20 (Dispatcher.ShutdownTimeoutMillis plus 10 seconds)")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.TerminationGracePeriodSeconds Below Configured Shutdown Timeout")
+	testCase.dispatcherShutdownTimeoutMillis = 30500
+	testCase.dispatcherGracePeriodSeconds = 40
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.TerminationGracePeriodSeconds must be >= 41 (Dispatcher.ShutdownTimeoutMillis plus 10 seconds)")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.TerminationGracePeriodSeconds Negative")
+	testCase.dispatcherGracePeriodSeconds = -1
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.TerminationGracePeriodSeconds must be >= 0")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.SubscriptionSnapshot.MaxAgeMillis Negative")
+	testCase.dispatcherSnapshotMaxAgeMillis = -1
+	testCase.expectedError = ControllerConfigurationError("Dispatcher.SubscriptionSnapshot.MaxAgeMillis must be >= 0")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Kafka.Provider")
 	testCase.kafkaAdminType = "invalidadmintype"
 	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: invalidadmintype")
@@ -176,17 +421,31 @@ func TestVerifyConfiguration(t *testing.T) {
 		testConfig.Kafka.Topic.DefaultNumPartitions = testCase.kafkaTopicDefaultNumPartitions
 		testConfig.Kafka.Topic.DefaultReplicationFactor = testCase.kafkaTopicDefaultReplicationFactor
 		testConfig.Kafka.Topic.DefaultRetentionMillis = testCase.kafkaTopicDefaultRetentionMillis
+		testConfig.Kafka.Topic.MinRetentionMillis = testCase.kafkaTopicMinRetentionMillis
+		testConfig.Kafka.Topic.MaxRetentionMillis = testCase.kafkaTopicMaxRetentionMillis
+		testConfig.Kafka.Topic.MaxNumPartitions = testCase.kafkaTopicMaxNumPartitions
 		testConfig.Kafka.AdminType = testCase.kafkaAdminType
 		testConfig.Dispatcher.CpuLimit = testCase.dispatcherCpuLimit
 		testConfig.Dispatcher.CpuRequest = testCase.dispatcherCpuRequest
 		testConfig.Dispatcher.MemoryLimit = testCase.dispatcherMemoryLimit
 		testConfig.Dispatcher.MemoryRequest = testCase.dispatcherMemoryRequest
 		testConfig.Dispatcher.Replicas = testCase.dispatcherReplicas
+		testConfig.Dispatcher.ShutdownTimeoutMillis = testCase.dispatcherShutdownTimeoutMillis
+		testConfig.Dispatcher.TerminationGracePeriodSeconds = testCase.dispatcherGracePeriodSeconds
+		testConfig.Dispatcher.SubscriptionSnapshot.MaxAgeMillis = testCase.dispatcherSnapshotMaxAgeMillis
 		testConfig.Receiver.CpuLimit = testCase.channelCpuLimit
 		testConfig.Receiver.CpuRequest = testCase.channelCpuRequest
 		testConfig.Receiver.MemoryLimit = testCase.channelMemoryLimit
 		testConfig.Receiver.MemoryRequest = testCase.channelMemoryRequest
 		testConfig.Receiver.Replicas = testCase.channelReplicas
+		testConfig.Receiver.Mode = testCase.channelMode
+		testConfig.Receiver.NamingPolicy = testCase.channelNamingPolicy
+		testConfig.Dispatcher.NamingPolicy = testCase.dispatcherNamingPolicy
+		testConfig.Kafka.DeprecatedFieldPolicy = testCase.deprecatedFieldPolicy
+		testConfig.Kafka.DeliveryOrderPolicy = testCase.deliveryOrderPolicy
+		testConfig.Kafka.BrokersChangePolicy = testCase.brokersChangePolicy
+		testConfig.Dispatcher.TopologySpreadConstraints = testCase.dispatcherTopologySpreadConstraints
+		testConfig.Receiver.TopologySpreadConstraints = testCase.channelTopologySpreadConstraints
 
 		// Perform The Test
 		err := VerifyConfiguration(testConfig)
@@ -208,9 +467,24 @@ func TestVerifyConfiguration(t *testing.T) {
 			assert.Equal(t, testCase.channelMemoryLimit, testConfig.Receiver.MemoryLimit)
 			assert.Equal(t, testCase.channelMemoryRequest, testConfig.Receiver.MemoryRequest)
 			assert.Equal(t, testCase.channelReplicas, testConfig.Receiver.Replicas)
+			assert.Equal(t, testCase.expectedChannelMode, testConfig.Receiver.Mode)
+			assert.Equal(t, testCase.expectedChannelNamingPolicy, testConfig.Receiver.NamingPolicy)
+			assert.Equal(t, testCase.expectedDispatcherNamingPolicy, testConfig.Dispatcher.NamingPolicy)
+			assert.Equal(t, testCase.expectedDeprecatedFieldPolicy, testConfig.Kafka.DeprecatedFieldPolicy)
+			assert.Equal(t, testCase.expectedDeliveryOrderPolicy, testConfig.Kafka.DeliveryOrderPolicy)
+			assert.Equal(t, testCase.expectedBrokersChangePolicy, testConfig.Kafka.BrokersChangePolicy)
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
 		}
 
 	}
 }
+
+// Create A TopologySpreadConstraint With The Specified Values For Testing
+func newTopologySpreadConstraint(maxSkew int32, topologyKey string, whenUnsatisfiable corev1.UnsatisfiableConstraintAction) corev1.TopologySpreadConstraint {
+	return corev1.TopologySpreadConstraint{
+		MaxSkew:           maxSkew,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: whenUnsatisfiable,
+	}
+}
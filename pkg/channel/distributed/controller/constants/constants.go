@@ -23,6 +23,32 @@ const (
 	KafkaAdminTypeValueAzure  = "azure"
 	KafkaAdminTypeValueCustom = "custom"
 
+	// Receiver Deployment Modes
+	ReceiverModeSecret  = "secret"  // One Shared Receiver Deployment Per Kafka Auth Secret
+	ReceiverModeChannel = "channel" // One Dedicated Receiver Deployment Per KafkaChannel
+	DefaultReceiverMode = ReceiverModeSecret
+
+	// Receiver & Dispatcher Resource Naming Policies
+	NamingPolicyHashed   = "hashed"   // Truncated Names With A Hash Suffix
+	NamingPolicyReadable = "readable" // Untruncated Names Without A Hash Suffix Where Possible (Otherwise Hashed)
+	DefaultNamingPolicy  = NamingPolicyHashed
+
+	// KafkaChannel Deprecated Spec Field Policies
+	DeprecatedFieldPolicyMigrate = "migrate" // Migrate To The Replacement Field & Emit A Warning Event
+	DeprecatedFieldPolicyWarn    = "warn"    // Leave As-Is & Emit A Warning Event
+	DeprecatedFieldPolicyFail    = "fail"    // Fail The KafkaChannel Until The Fields Are Removed
+	DefaultDeprecatedFieldPolicy = DeprecatedFieldPolicyMigrate
+
+	// Policies For KafkaChannels Requesting Ordered Delivery With Multiple Partitions (Only Ordered Per-Partition)
+	DeliveryOrderPolicyWarn    = "warn"   // Mark The OrderingGuaranteed Condition False & Emit A Warning Event
+	DeliveryOrderPolicyReject  = "reject" // Also Fail The KafkaChannel Until It Has A Single Partition Or Ordering Is Not Requested
+	DefaultDeliveryOrderPolicy = DeliveryOrderPolicyWarn
+
+	// Policies For Receiver & Dispatcher Deployments When The Brokers Of Their Kafka Secret Change
+	BrokersChangePolicyRollout = "rollout" // Roll Out The Deployments So That Their Pods Pick Up The New Brokers
+	BrokersChangePolicyIgnore  = "ignore"  // Leave The Deployments As-Is (New Brokers Only Take Effect When Pods Restart)
+	DefaultBrokersChangePolicy = BrokersChangePolicyRollout
+
 	// The Controller's Component Name (Needs To Be DNS Safe!)
 	ControllerComponentName = "eventing-kafka-channel-controller"
 
@@ -33,9 +59,17 @@ const (
 	SecretKind              = "Secret"
 	ServiceKind             = "Service"
 	DeploymentKind          = "Deployment"
+	IngressKind             = "Ingress"
 	KnativeSubscriptionKind = "Subscription"
 	KafkaChannelKind        = "KafkaChannel"
 
+	// VerticalPodAutoscaler (Optional CRD From The Kubernetes Autoscaler Project)
+	VerticalPodAutoscalerKind          = "VerticalPodAutoscaler"
+	VerticalPodAutoscalerGroup         = "autoscaling.k8s.io"
+	VerticalPodAutoscalerVersion       = "v1"
+	VerticalPodAutoscalerResource      = "verticalpodautoscalers"
+	VerticalPodAutoscalerUpdateModeOff = "Off" // Recommendation Only - Pods Are Never Evicted / Resized
+
 	// HTTP Port
 	HttpPortName = "http"
 	// IMPORTANT: HttpServicePort is the inbound port of the service resource. It must be 80 because the
@@ -52,6 +86,13 @@ const (
 	KafkaSecretDataKeyUsername = "username"
 	KafkaSecretDataKeyPassword = "password"
 
+	// Optional Kafka Secret Data Key For The SASL Mechanism (e.g. "SCRAM-SHA-512", Defaulting To The Sarama Settings)
+	KafkaSecretDataKeySaslMechanism = "saslmechanism"
+
+	// Optional Kafka Secret Data Keys For Schema Registry Authentication
+	KafkaSecretDataKeySchemaRegistryUsername = "schemaRegistryUsername"
+	KafkaSecretDataKeySchemaRegistryPassword = "schemaRegistryPassword"
+
 	// Prometheus MetricsPort
 	MetricsPortName = "metrics"
 
@@ -70,6 +111,24 @@ const (
 	KafkaSecretLabel            = "kafkasecret"             // Secret Label - Indicates The Kafka Secret Of The KafkaChannel
 	KafkaTopicLabel             = "kafkaTopic"              // Topic Label - Indicates The Kafka Topic Of The KnativeChannel
 
+	// Optional KafkaChannel Label Identifying KafkaChannels Consumed By An External System (No Dispatcher Is Provisioned)
+	DispatcherLabel              = "eventing-kafka.knative.dev/dispatcher"
+	DispatcherLabelValueManaged  = "managed"  // The Controller Provisions The Dispatcher (The Default When Not Labelled)
+	DispatcherLabelValueExternal = "external" // The KafkaChannel's Events Are Consumed Externally - No Dispatcher Is Provisioned
+
+	// Label Identifying The Shard Index Of A Sharded Dispatcher's Services & Deployments (Absent On The First Shard)
+	DispatcherShardLabel = "kafkachannel-dispatcher-shard"
+
+	// Optional KafkaChannel Annotations For Scheduling The Dispatcher Alongside Its Subscribers (JSON Values)
+	DispatcherNodeSelectorAnnotation = "eventing-kafka.knative.dev/dispatcher-node-selector" // map[string]string Of Node Labels
+	DispatcherAffinityAnnotation     = "eventing-kafka.knative.dev/dispatcher-affinity"      // corev1.Affinity (Replaces The ConfigMap Dispatcher Affinity)
+
+	// Optional Namespace Annotation Selecting (By Name) The Kafka Secret Of The KafkaChannels In That Namespace
+	KafkaSecretAnnotation = "eventing-kafka.knative.dev/kafka-secret-name"
+
+	// Receiver & Dispatcher Deployment (& Pod Template) Annotation Holding The Checksum Of Their Kafka Secret's Brokers
+	KafkaBrokersChecksumAnnotation = "eventing-kafka.knative.dev/kafka-brokers-checksum"
+
 	// Prometheus ServiceMonitor Selector Labels / Values
 	K8sAppChannelSelectorLabel    = "k8s-app"
 	K8sAppChannelSelectorValue    = "eventing-kafka-channels"
@@ -79,6 +138,9 @@ const (
 	// Kafka Topic Configuration
 	KafkaTopicConfigRetentionMs = "retention.ms"
 
+	// Largest Topic Retention Which Is Representable As A time.Duration (~292 Years) - Anything Larger Is Misconfigured
+	MaxTopicRetentionMillis = 9223372036854 // math.MaxInt64 / int64(time.Millisecond)
+
 	// Health Configuration
 	HealthPort                = 8082
 	ChannelLivenessDelay      = 10
@@ -89,4 +151,12 @@ const (
 	DispatcherLivenessPeriod  = 5
 	DispatcherReadinessDelay  = 10
 	DispatcherReadinessPeriod = 5
+
+	// Time (In Seconds) Added To The Dispatcher's Shutdown Timeout When Determining Its Minimum Termination Grace Period
+	DispatcherTerminationGracePeriodBufferSeconds = 10
+
+	// Dispatcher Subscription Snapshot Volume (An emptyDir, Which Survives Container Restarts) & The Snapshot File Within It
+	DispatcherSnapshotVolumeName = "subscription-snapshot"
+	DispatcherSnapshotMountPath  = "/var/run/eventing-kafka/snapshot"
+	DispatcherSnapshotFileName   = "subscriptions.json"
 )
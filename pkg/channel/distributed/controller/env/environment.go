@@ -17,8 +17,11 @@ limitations under the License.
 package env
 
 import (
+	"strings"
+
 	"go.uber.org/zap"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
 
 // Package Constants
@@ -29,6 +32,18 @@ const (
 
 	// Receiver Configuration
 	ReceiverImageEnvVarKey = "RECEIVER_IMAGE"
+
+	// Namespace Scoping Configuration (Comma Separated List)
+	WatchNamespacesEnvVarKey = "WATCH_NAMESPACES"
+
+	// LimitRange Validation Configuration
+	ValidateLimitRangesEnvVarKey = "VALIDATE_LIMIT_RANGES"
+
+	// VerticalPodAutoscaler Configuration
+	VerticalPodAutoscalersEnvVarKey = "VERTICAL_POD_AUTOSCALERS"
+
+	// Deployment Pod Inspection Configuration
+	InspectDeploymentPodsEnvVarKey = "INSPECT_DEPLOYMENT_PODS"
 )
 
 // Environment Structure
@@ -44,6 +59,18 @@ type Environment struct {
 
 	// Receiver Configuration
 	ReceiverImage string // Required
+
+	// Namespace Scoping Configuration
+	WatchNamespaces []string // Optional (All Namespaces If Empty)
+
+	// LimitRange Validation Configuration
+	ValidateLimitRanges bool // Optional (Defaults To False)
+
+	// VerticalPodAutoscaler Configuration
+	VerticalPodAutoscalers bool // Optional (Defaults To False)
+
+	// Deployment Pod Inspection Configuration
+	InspectDeploymentPods bool // Optional (Defaults To False)
 }
 
 // Get The Environment
@@ -73,6 +100,13 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	// Verify The Metrics Port Is Distinct From The Fixed HTTP & Health Ports Of The Receiver & Dispatcher Deployments
+	err = VerifyDeploymentPorts(environment.MetricsPort)
+	if err != nil {
+		logger.Error("Invalid Port Configuration", zap.Error(err))
+		return nil, err
+	}
+
 	//
 	// Dispatcher Configuration
 	//
@@ -93,9 +127,67 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	//
+	// Namespace Scoping Configuration
+	//
+
+	// Get The Optional WatchNamespaces Config Value & Split Into Individual Namespaces
+	environment.WatchNamespaces = splitNamespaces(env.GetOptionalConfigValue(logger, WatchNamespacesEnvVarKey, ""))
+
+	//
+	// LimitRange Validation Configuration
+	//
+
+	// Get The Optional ValidateLimitRanges Config Value
+	environment.ValidateLimitRanges, err = env.GetOptionalConfigBool(logger, ValidateLimitRangesEnvVarKey, "false", "ValidateLimitRanges")
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// VerticalPodAutoscaler Configuration
+	//
+
+	// Get The Optional VerticalPodAutoscalers Config Value
+	environment.VerticalPodAutoscalers, err = env.GetOptionalConfigBool(logger, VerticalPodAutoscalersEnvVarKey, "false", "VerticalPodAutoscalers")
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Deployment Pod Inspection Configuration
+	//
+
+	// Get The Optional InspectDeploymentPods Config Value
+	environment.InspectDeploymentPods, err = env.GetOptionalConfigBool(logger, InspectDeploymentPodsEnvVarKey, "false", "InspectDeploymentPods")
+	if err != nil {
+		return nil, err
+	}
+
 	// Log The ControllerConfig Loaded From Environment Variables
 	logger.Info("Environment Variables", zap.Any("Environment", environment))
 
 	// Return The Populated ControllerConfig
 	return environment, nil
 }
+
+// Verify The Specified Metrics Port Is Distinct From The HTTP & Health Ports Of The Receiver & Dispatcher Deployments
+func VerifyDeploymentPorts(metricsPort int) error {
+	return env.VerifyDistinctPorts(map[string]int{
+		"HttpPort":    constants.HttpContainerPortNumber,
+		"MetricsPort": metricsPort,
+		"HealthPort":  constants.HealthPort,
+	})
+}
+
+// Split The Specified Comma Separated List Of Namespaces (Ignoring Whitespace & Empty Entries)
+func splitNamespaces(namespaces string) []string {
+	result := make([]string, 0)
+	for _, namespace := range strings.Split(namespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if len(namespace) > 0 {
+			result = append(result, namespace)
+		}
+	}
+	return result
+}
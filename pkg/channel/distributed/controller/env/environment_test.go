@@ -39,18 +39,31 @@ const (
 	dispatcherImage = "TestDispatcherImage"
 
 	receiverImage = "TestReceiverImage"
+
+	watchNamespaces = "namespace1, namespace2,,"
+
+	validateLimitRanges = "true"
+
+	verticalPodAutoscalers = "true"
+
+	inspectDeploymentPods = "true"
 )
 
 // Define The TestCase Struct
 type TestCase struct {
-	name                  string
-	serviceAccount        string
-	metricsPort           string
-	metricsDomain         string
-	defaultKafkaConsumers string
-	dispatcherImage       string
-	channelImage          string
-	expectedError         error
+	name                   string
+	serviceAccount         string
+	metricsPort            string
+	metricsDomain          string
+	defaultKafkaConsumers  string
+	dispatcherImage        string
+	channelImage           string
+	watchNamespaces        string
+	expectedNamespaces     []string
+	validateLimitRanges    string
+	verticalPodAutoscalers string
+	inspectDeploymentPods  string
+	expectedError          error
 }
 
 // Test All Permutations Of The GetEnvironment() Functionality
@@ -64,6 +77,38 @@ func TestGetEnvironment(t *testing.T) {
 	testCase := getValidTestCase("Valid Complete Config")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - No WatchNamespaces")
+	testCase.watchNamespaces = ""
+	testCase.expectedNamespaces = []string{}
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - No ValidateLimitRanges")
+	testCase.validateLimitRanges = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - ValidateLimitRanges")
+	testCase.validateLimitRanges = "NAB"
+	testCase.expectedError = fmt.Errorf("invalid (non boolean) value '%s' for environment variable '%s'", testCase.validateLimitRanges, ValidateLimitRangesEnvVarKey)
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - No VerticalPodAutoscalers")
+	testCase.verticalPodAutoscalers = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - VerticalPodAutoscalers")
+	testCase.verticalPodAutoscalers = "NAB"
+	testCase.expectedError = fmt.Errorf("invalid (non boolean) value '%s' for environment variable '%s'", testCase.verticalPodAutoscalers, VerticalPodAutoscalersEnvVarKey)
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - No InspectDeploymentPods")
+	testCase.inspectDeploymentPods = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - InspectDeploymentPods")
+	testCase.inspectDeploymentPods = "NAB"
+	testCase.expectedError = fmt.Errorf("invalid (non boolean) value '%s' for environment variable '%s'", testCase.inspectDeploymentPods, InspectDeploymentPodsEnvVarKey)
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - ServiceAccount")
 	testCase.serviceAccount = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(env.ServiceAccountEnvVarKey)
@@ -84,6 +129,16 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getInvalidIntEnvironmentVariableError(testCase.metricsPort, env.MetricsPortEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Invalid Config - MetricsPort Conflicts With HttpPort")
+	testCase.metricsPort = "8080"
+	testCase.expectedError = fmt.Errorf("port conflict: HttpPort and MetricsPort are both configured as port 8080")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - MetricsPort Conflicts With HealthPort")
+	testCase.metricsPort = "8082"
+	testCase.expectedError = fmt.Errorf("port conflict: HealthPort and MetricsPort are both configured as port 8082")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - DispatcherImage")
 	testCase.dispatcherImage = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(DispatcherImageEnvVarKey)
@@ -104,6 +159,10 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenvNonempty(t, env.MetricsPortEnvVarKey, testCase.metricsPort)
 		assertSetenv(t, DispatcherImageEnvVarKey, testCase.dispatcherImage)
 		assertSetenv(t, ReceiverImageEnvVarKey, testCase.channelImage)
+		assertSetenvNonempty(t, WatchNamespacesEnvVarKey, testCase.watchNamespaces)
+		assertSetenvNonempty(t, ValidateLimitRangesEnvVarKey, testCase.validateLimitRanges)
+		assertSetenvNonempty(t, VerticalPodAutoscalersEnvVarKey, testCase.verticalPodAutoscalers)
+		assertSetenvNonempty(t, InspectDeploymentPodsEnvVarKey, testCase.inspectDeploymentPods)
 
 		// Perform The Test
 		environment, err := GetEnvironment(logger)
@@ -117,6 +176,10 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.metricsPort, strconv.Itoa(environment.MetricsPort))
 			assert.Equal(t, testCase.channelImage, environment.ReceiverImage)
 			assert.Equal(t, testCase.dispatcherImage, environment.DispatcherImage)
+			assert.Equal(t, testCase.expectedNamespaces, environment.WatchNamespaces)
+			assert.Equal(t, testCase.validateLimitRanges == "true", environment.ValidateLimitRanges)
+			assert.Equal(t, testCase.verticalPodAutoscalers == "true", environment.VerticalPodAutoscalers)
+			assert.Equal(t, testCase.inspectDeploymentPods == "true", environment.InspectDeploymentPods)
 
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
@@ -129,14 +192,19 @@ func TestGetEnvironment(t *testing.T) {
 // Get The Base / Valid Test Case - All Config Specified / No Errors
 func getValidTestCase(name string) TestCase {
 	return TestCase{
-		name:                  name,
-		serviceAccount:        serviceAccount,
-		metricsPort:           metricsPort,
-		metricsDomain:         metricsDomain,
-		defaultKafkaConsumers: defaultKafkaConsumers,
-		dispatcherImage:       dispatcherImage,
-		channelImage:          receiverImage,
-		expectedError:         nil,
+		name:                   name,
+		serviceAccount:         serviceAccount,
+		metricsPort:            metricsPort,
+		metricsDomain:          metricsDomain,
+		defaultKafkaConsumers:  defaultKafkaConsumers,
+		dispatcherImage:        dispatcherImage,
+		channelImage:           receiverImage,
+		watchNamespaces:        watchNamespaces,
+		expectedNamespaces:     []string{"namespace1", "namespace2"},
+		validateLimitRanges:    validateLimitRanges,
+		verticalPodAutoscalers: verticalPodAutoscalers,
+		inspectDeploymentPods:  inspectDeploymentPods,
+		expectedError:          nil,
 	}
 }
 
@@ -39,17 +39,39 @@ const (
 	// Receiver (Kafka Producer) Reconciliation
 	ReceiverServiceReconciliationFailed
 	ReceiverDeploymentReconciliationFailed
+	ReceiverIngressReconciliationFailed
+	ReceiverContainerFailed
 
 	// Kafka Topic Reconciliation
 	KafkaTopicReconciliationFailed
+	KafkaTopicWarmedUp
+	KafkaTopicWarmupFailed
+	KafkaTopicRetentionUpdated
+	KafkaTopicPartitionsIncreased
+	KafkaTopicPartitionsDecreaseRejected
+	KafkaTopicMetadataTimeout
+
+	// KafkaChannel Deprecated Spec Fields
+	KafkaChannelDeprecatedFields
+
+	// KafkaChannel Ordered Delivery Across Multiple Partitions
+	KafkaChannelOrderingNotGuaranteed
+
+	// KafkaChannel Delivery Guarantee Incompatible With The Kafka Configuration
+	KafkaChannelDeliveryGuaranteeIncompatible
 
 	// Dispatcher (Kafka Consumer) Reconciliation
 	DispatcherServiceReconciliationFailed
 	DispatcherDeploymentReconciliationFailed
+	DispatcherNotManaged
+	DispatcherContainerFailed
 
 	// Kafka Secret Reconciliation
 	KafkaSecretReconciled
 	KafkaSecretFinalized
+	KafkaSecretPending
+	KafkaBrokersRolloutFailed
+	KafkaSecretInvalid
 )
 
 // CoreV1 EventType String Value
@@ -76,18 +98,50 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "ReceiverServiceReconciliationFailed"
 	case ReceiverDeploymentReconciliationFailed:
 		eventTypeString = "ReceiverDeploymentReconciliationFailed"
+	case ReceiverIngressReconciliationFailed:
+		eventTypeString = "ReceiverIngressReconciliationFailed"
+	case ReceiverContainerFailed:
+		eventTypeString = "ReceiverContainerFailed"
 	case ChannelStatusReconciliationFailed:
 		eventTypeString = "ChannelStatusReconciliationFailed"
 	case KafkaTopicReconciliationFailed:
 		eventTypeString = "KafkaTopicReconciliationFailed"
+	case KafkaTopicWarmedUp:
+		eventTypeString = "KafkaTopicWarmedUp"
+	case KafkaTopicWarmupFailed:
+		eventTypeString = "KafkaTopicWarmupFailed"
+	case KafkaTopicRetentionUpdated:
+		eventTypeString = "KafkaTopicRetentionUpdated"
+	case KafkaTopicPartitionsIncreased:
+		eventTypeString = "KafkaTopicPartitionsIncreased"
+	case KafkaTopicPartitionsDecreaseRejected:
+		eventTypeString = "KafkaTopicPartitionsDecreaseRejected"
+	case KafkaTopicMetadataTimeout:
+		eventTypeString = "KafkaTopicMetadataTimeout"
+	case KafkaChannelDeprecatedFields:
+		eventTypeString = "KafkaChannelDeprecatedFields"
+	case KafkaChannelOrderingNotGuaranteed:
+		eventTypeString = "KafkaChannelOrderingNotGuaranteed"
+	case KafkaChannelDeliveryGuaranteeIncompatible:
+		eventTypeString = "KafkaChannelDeliveryGuaranteeIncompatible"
 	case DispatcherServiceReconciliationFailed:
 		eventTypeString = "DispatcherServiceReconciliationFailed"
 	case DispatcherDeploymentReconciliationFailed:
 		eventTypeString = "DispatcherDeploymentReconciliationFailed"
+	case DispatcherNotManaged:
+		eventTypeString = "DispatcherNotManaged"
+	case DispatcherContainerFailed:
+		eventTypeString = "DispatcherContainerFailed"
 	case KafkaSecretReconciled:
 		eventTypeString = "KafkaSecretReconciled"
 	case KafkaSecretFinalized:
 		eventTypeString = "KafkaSecretFinalized"
+	case KafkaSecretPending:
+		eventTypeString = "KafkaSecretPending"
+	case KafkaBrokersRolloutFailed:
+		eventTypeString = "KafkaBrokersRolloutFailed"
+	case KafkaSecretInvalid:
+		eventTypeString = "KafkaSecretInvalid"
 	}
 
 	// Return The EventType String Value
@@ -31,11 +31,27 @@ func TestEventTypes(t *testing.T) {
 	performEventTypeStringTest(t, ReceiverServiceReconciliationFailed, "ReceiverServiceReconciliationFailed")
 	performEventTypeStringTest(t, ReceiverServiceReconciliationFailed, "ReceiverServiceReconciliationFailed")
 	performEventTypeStringTest(t, ReceiverDeploymentReconciliationFailed, "ReceiverDeploymentReconciliationFailed")
+	performEventTypeStringTest(t, ReceiverIngressReconciliationFailed, "ReceiverIngressReconciliationFailed")
+	performEventTypeStringTest(t, ReceiverContainerFailed, "ReceiverContainerFailed")
 	performEventTypeStringTest(t, KafkaTopicReconciliationFailed, "KafkaTopicReconciliationFailed")
+	performEventTypeStringTest(t, KafkaTopicWarmedUp, "KafkaTopicWarmedUp")
+	performEventTypeStringTest(t, KafkaTopicWarmupFailed, "KafkaTopicWarmupFailed")
+	performEventTypeStringTest(t, KafkaTopicRetentionUpdated, "KafkaTopicRetentionUpdated")
+	performEventTypeStringTest(t, KafkaTopicPartitionsIncreased, "KafkaTopicPartitionsIncreased")
+	performEventTypeStringTest(t, KafkaTopicPartitionsDecreaseRejected, "KafkaTopicPartitionsDecreaseRejected")
+	performEventTypeStringTest(t, KafkaTopicMetadataTimeout, "KafkaTopicMetadataTimeout")
+	performEventTypeStringTest(t, KafkaChannelDeprecatedFields, "KafkaChannelDeprecatedFields")
+	performEventTypeStringTest(t, KafkaChannelOrderingNotGuaranteed, "KafkaChannelOrderingNotGuaranteed")
+	performEventTypeStringTest(t, KafkaChannelDeliveryGuaranteeIncompatible, "KafkaChannelDeliveryGuaranteeIncompatible")
+	performEventTypeStringTest(t, DispatcherNotManaged, "DispatcherNotManaged")
+	performEventTypeStringTest(t, DispatcherContainerFailed, "DispatcherContainerFailed")
 	performEventTypeStringTest(t, DispatcherServiceReconciliationFailed, "DispatcherServiceReconciliationFailed")
 	performEventTypeStringTest(t, DispatcherDeploymentReconciliationFailed, "DispatcherDeploymentReconciliationFailed")
 	performEventTypeStringTest(t, KafkaSecretReconciled, "KafkaSecretReconciled")
 	performEventTypeStringTest(t, KafkaSecretFinalized, "KafkaSecretFinalized")
+	performEventTypeStringTest(t, KafkaSecretPending, "KafkaSecretPending")
+	performEventTypeStringTest(t, KafkaBrokersRolloutFailed, "KafkaBrokersRolloutFailed")
+	performEventTypeStringTest(t, KafkaSecretInvalid, "KafkaSecretInvalid")
 }
 
 // Perform A Single Instance Of The CoreV1 EventType String Test
@@ -58,8 +58,9 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, channel *kafkav1beta1
 // KafkaChannel Kafka Channel Service
 //
 // One K8S Service per KafkaChannel, in the same namespace as the KafkaChannel, with an
-// ExternalName reference to the single K8S Service in the knative-eventing namespace
-// for the Channel Deployment/Pods.
+// ExternalName reference to the Receiver K8S Service in the knative-eventing namespace
+// for the Channel Deployment/Pods (either shared per Kafka Secret or dedicated to the
+// KafkaChannel when the Receiver is configured for "channel" mode).
 //
 
 // Reconcile The KafkaChannel Service
@@ -87,6 +88,21 @@ func (r *Reconciler) reconcileKafkaChannelService(ctx context.Context, channel *
 			channel.Status.MarkChannelServiceFailed(event.KafkaChannelServiceReconciliationFailed.String(), "Failed To Get KafkaChannel Service: %v", err)
 			return err
 		}
+	} else if expectedExternalName := r.receiverServiceAddress(channel); service.Spec.ExternalName != expectedExternalName {
+
+		// The Receiver Mode Has Changed - Repoint The Service At The Appropriate Receiver Service
+		r.logger.Info("KafkaChannel Service ExternalName Changed - Updating", zap.String("ExternalName", expectedExternalName))
+		service = service.DeepCopy()
+		service.Spec.ExternalName = expectedExternalName
+		service, err = r.kubeClientset.CoreV1().Services(service.Namespace).Update(ctx, service, metav1.UpdateOptions{})
+		if err != nil {
+			r.logger.Error("Failed To Update KafkaChannel Service", zap.Error(err))
+			channel.Status.MarkChannelServiceFailed(event.KafkaChannelServiceReconciliationFailed.String(), "Failed To Update KafkaChannel Service: %v", err)
+			return err
+		} else {
+			r.logger.Info("Successfully Updated KafkaChannel Service")
+			// Continue To Update Channel Status
+		}
 	} else {
 		r.logger.Info("Successfully Verified KafkaChannel Service")
 		// Continue To Update Channel Status
@@ -122,10 +138,6 @@ func (r *Reconciler) newKafkaChannelService(channel *kafkav1beta1.KafkaChannel)
 	// Get The KafkaChannel Service Name
 	serviceName := kafkautil.AppendKafkaChannelServiceNameSuffix(channel.Name)
 
-	// Get The Receiver Service Name For The Kafka Secret (One Receiver Service Per Kafka Secret)
-	deploymentName := util.ReceiverDnsSafeName(r.kafkaSecretName(channel))
-	serviceAddress := fmt.Sprintf("%s.%s.svc.%s", deploymentName, commonconstants.KnativeEventingNamespace, network.GetClusterDomainName())
-
 	// Create & Return The Service Model
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -147,11 +159,24 @@ func (r *Reconciler) newKafkaChannelService(channel *kafkav1beta1.KafkaChannel)
 		},
 		Spec: corev1.ServiceSpec{
 			Type:         corev1.ServiceTypeExternalName,
-			ExternalName: serviceAddress,
+			ExternalName: r.receiverServiceAddress(channel),
 		},
 	}
 }
 
+// Get The Address Of The Receiver Service Which Handles The Specified Channel's Inbound Events
+func (r *Reconciler) receiverServiceAddress(channel *kafkav1beta1.KafkaChannel) string {
+
+	// Get The Receiver Service Name For The Channel (Dedicated) Or Its Kafka Secret (Shared By All The Secret's Channels)
+	var deploymentName string
+	if util.ReceiverPerChannel(r.config) {
+		deploymentName = util.ChannelReceiverDnsSafeName(r.config.Receiver.NamingPolicy, channel)
+	} else {
+		deploymentName = util.ReceiverDnsSafeName(r.config.Receiver.NamingPolicy, r.kafkaSecretName(channel))
+	}
+	return fmt.Sprintf("%s.%s.svc.%s", deploymentName, commonconstants.KnativeEventingNamespace, network.GetClusterDomainName())
+}
+
 //
 // Utility Functions (Uses AdminClient)
 //
@@ -28,15 +28,20 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinformer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	kafkaclientsetinjection "knative.dev/eventing-kafka/pkg/client/injection/client"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
 )
 
 // Track The Reconciler For Shutdown() Usage
@@ -50,8 +55,10 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 
 	// Get The Needed Informers
 	kafkachannelInformer := kafkachannel.Get(ctx)
+	kafkaSecretInformer := kafkasecretinformer.Get(ctx)
 	deploymentInformer := deployment.Get(ctx)
 	serviceInformer := service.Get(ctx)
+	namespaceInformer := namespace.Get(ctx)
 
 	// Load The Environment Variables
 	environment, err := env.GetEnvironment(logger)
@@ -83,6 +90,7 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	rec = &Reconciler{
 		logger:               logger,
 		kubeClientset:        kubeclient.Get(ctx),
+		dynamicClient:        dynamicclient.Get(ctx),
 		environment:          environment,
 		config:               configuration,
 		saramaConfig:         saramaConfig,
@@ -91,9 +99,12 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 		kafkachannelInformer: kafkachannelInformer.Informer(),
 		deploymentLister:     deploymentInformer.Lister(),
 		serviceLister:        serviceInformer.Lister(),
+		namespaceLister:      namespaceInformer.Lister(),
+		kafkaSecretLister:    kafkaSecretInformer.Lister(),
 		adminClientType:      kafkaAdminClientType,
 		adminClient:          nil,
 		adminMutex:           &sync.Mutex{},
+		topicOperations:      newTopicOperationLimiter(configuration.Kafka.MaxConcurrentTopicOperations),
 		configObserver:       rec.configMapObserver, // Maintains a reference so that the ConfigWatcher can call it
 	}
 
@@ -106,6 +117,12 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	// Create A New KafkaChannel Controller Impl With The Reconciler
 	controllerImpl := kafkachannelreconciler.NewImpl(ctx, rec)
 
+	// Restrict Reconciliation To The Watched Namespaces (If Specified)
+	if len(environment.WatchNamespaces) > 0 {
+		logger.Info("Restricting KafkaChannel Reconciliation To Watched Namespaces", zap.Strings("Namespaces", environment.WatchNamespaces))
+		controllerImpl.Reconciler = newNamespaceScopedReconciler(controllerImpl.Reconciler, environment.WatchNamespaces)
+	}
+
 	//
 	// Configure The Informers' EventHandlers
 	//
@@ -115,17 +132,25 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	//        information.
 	//
 	rec.logger.Info("Setting Up EventHandlers")
-	kafkachannelInformer.Informer().AddEventHandler(
-		controller.HandleAll(controllerImpl.Enqueue),
-	)
+	kafkachannelInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: util.FilterNamespaces(environment.WatchNamespaces),
+		Handler:    controller.HandleAll(controllerImpl.Enqueue),
+	})
 	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		FilterFunc: controller.FilterControllerGVK(kafkachannelv1beta1.SchemeGroupVersion.WithKind(constants.KafkaChannelKind)),
-		Handler:    controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
+		FilterFunc: reconciler.ChainFilterFuncs(
+			controller.FilterControllerGVK(kafkachannelv1beta1.SchemeGroupVersion.WithKind(constants.KafkaChannelKind)),
+			util.FilterNamespaceLabel(environment.WatchNamespaces, constants.KafkaChannelNamespaceLabel),
+		),
+		Handler: controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
 	})
 	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		FilterFunc: controller.FilterControllerGVK(kafkachannelv1beta1.SchemeGroupVersion.WithKind(constants.KafkaChannelKind)),
-		Handler:    controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
+		FilterFunc: reconciler.ChainFilterFuncs(
+			controller.FilterControllerGVK(kafkachannelv1beta1.SchemeGroupVersion.WithKind(constants.KafkaChannelKind)),
+			util.FilterNamespaceLabel(environment.WatchNamespaces, constants.KafkaChannelNamespaceLabel),
+		),
+		Handler: controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
 	})
+	namespaceInformer.Informer().AddEventHandler(rec.namespaceKafkaSecretEventHandler(environment.WatchNamespaces, controllerImpl.Enqueue))
 
 	// Return The KafkaChannel Controller Impl
 	return controllerImpl
@@ -135,3 +160,38 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 func Shutdown() {
 	rec.ClearKafkaAdminClient()
 }
+
+// The Generated KafkaChannel Reconciler Is Both A Controller Reconciler & LeaderAware
+type leaderAwareReconciler interface {
+	controller.Reconciler
+	reconciler.LeaderAware
+}
+
+//
+// Controller Reconciler Wrapper Which Ignores KafkaChannels Outside The Watched Namespaces
+//
+// Filtering the informers' EventHandlers is not sufficient on its own, as the generated reconciler
+// enqueues ALL KafkaChannels from the lister upon being promoted to leader, and would then proceed to
+// add finalizers and update the status of KafkaChannels in namespaces which should have been ignored.
+//
+type namespaceScopedReconciler struct {
+	leaderAwareReconciler
+	namespaces []string
+}
+
+// Wrap The Specified Controller Reconciler So That It Only Reconciles The Specified Namespaces
+func newNamespaceScopedReconciler(r controller.Reconciler, namespaces []string) controller.Reconciler {
+	if leaderAware, ok := r.(leaderAwareReconciler); ok {
+		return &namespaceScopedReconciler{leaderAwareReconciler: leaderAware, namespaces: namespaces}
+	}
+	return r // Should Never Happen With The Generated Reconciler
+}
+
+// Reconcile The Specified KafkaChannel Key Only If It Is In One Of The Watched Namespaces
+func (r *namespaceScopedReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err == nil && !util.NamespaceInScope(r.namespaces, namespace) {
+		return nil
+	}
+	return r.leaderAwareReconciler.Reconcile(ctx, key)
+}
@@ -20,26 +20,50 @@ import (
 	"context"
 	"os"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	commontesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/testing"
 	controllerenv "knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
+	_ "knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinformer/fake" // Knative Fake Informer Injection
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	fakeKafkaClient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel/fake" // Knative Fake Informer Injection
+	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/client/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake" // Knative Fake Informer Injection
-	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"    // Knative Fake Informer Injection
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake" // Knative Fake Informer Injection
+	fakefactory "knative.dev/pkg/client/injection/kube/informers/factory/fake"
+	"knative.dev/pkg/configmap"
+	pkgcontroller "knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
+	_ "knative.dev/pkg/metrics/testing" // Initializes The Metrics Config So That Measurements Are Recorded
+	"knative.dev/pkg/reconciler"
+	. "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
 )
 
+// Register A Fake Namespace Informer (Equivalent To The Knative Fake Informer Injection, Which Is Not Vendored)
+func init() {
+	injection.Fake.RegisterInformer(func(ctx context.Context) (context.Context, pkgcontroller.Informer) {
+		namespaceInformer := fakefactory.Get(ctx).Core().V1().Namespaces()
+		return context.WithValue(ctx, namespace.Key{}, namespaceInformer), namespaceInformer.Informer()
+	})
+}
+
 // Test The NewController() Functionality
 func TestNewController(t *testing.T) {
 
@@ -71,6 +95,152 @@ func TestNewController(t *testing.T) {
 	assert.NotNil(t, controller.Reconciler)
 }
 
+// Test The Standard Knative Workqueue Metrics Of The KafkaChannel Controller As Keys Are Enqueued, Processed & Retried
+func TestNewControllerWorkqueueMetrics(t *testing.T) {
+
+	// Verify The Knative Workqueue Views Are Registered
+	for _, viewName := range []string{"workqueue_depth", "workqueue_adds_total", "workqueue_queue_latency_seconds", "workqueue_work_duration_seconds", "workqueue_retries_total"} {
+		assert.NotNil(t, view.Find(viewName), viewName)
+	}
+
+	// Create The KafkaChannel Controller With Fake Informers / Clientsets
+	populateEnvironmentVariables(t)
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+	configMap := commontesting.GetTestSaramaConfigMap(controllertesting.SaramaConfigYaml, controllertesting.ControllerConfigYaml)
+	ctx, _ = fake.With(ctx, configMap)
+	ctx, _ = fakeKafkaClient.With(ctx)
+	controller := NewController(ctx, nil)
+	queue := controller.WorkQueue()
+	defer queue.ShutDown()
+	queueName := controller.Name + "-consumer" // The Two Lane Queue Moves Keys From The Fast / Slow Lanes To The Consumer Queue
+	interval := 10 * time.Millisecond
+
+	// Enqueue A Key & Verify The Depth & Adds Metrics Update
+	key := types.NamespacedName{Namespace: controllertesting.KafkaChannelNamespace, Name: controllertesting.KafkaChannelName}
+	controller.EnqueueKey(key)
+	assert.Eventually(t, func() bool { return lastValue(t, "workqueue_depth", queueName) == 1 }, time.Second, interval)
+	assert.Eventually(t, func() bool { return count(t, "workqueue_adds_total", queueName) >= 1 }, time.Second, interval)
+
+	// Process The Key & Verify The Depth Returns To Zero While The Latency & Duration Metrics Update
+	item, shutdown := queue.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, key, item)
+	queue.Done(item)
+	assert.Eventually(t, func() bool { return lastValue(t, "workqueue_depth", queueName) == 0 }, time.Second, interval)
+	assert.Eventually(t, func() bool { return count(t, "workqueue_queue_latency_seconds", queueName) >= 1 }, time.Second, interval)
+	assert.Eventually(t, func() bool { return count(t, "workqueue_work_duration_seconds", queueName) >= 1 }, time.Second, interval)
+
+	// Retry The Key & Verify The Retries Metric Of The (Rate Limited) Fast Lane Updates
+	queue.AddRateLimited(item)
+	assert.Eventually(t, func() bool { return count(t, "workqueue_retries_total", controller.Name+"-fast") >= 1 }, time.Second, interval)
+}
+
+// Test The NewController() Functionality When Restricted To Watched Namespaces
+func TestNewControllerWatchNamespaces(t *testing.T) {
+
+	// Populate Environment Variables For Testing (Including The Watched Namespaces)
+	populateEnvironmentVariables(t)
+	assert.Nil(t, os.Setenv(controllerenv.WatchNamespacesEnvVarKey, controllertesting.KafkaChannelNamespace))
+	defer func() { assert.Nil(t, os.Unsetenv(controllerenv.WatchNamespacesEnvVarKey)) }()
+
+	// Create A Context With Test Logger & Fake Informers / Clientsets
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+	configMap := commontesting.GetTestSaramaConfigMap(controllertesting.SaramaConfigYaml, controllertesting.ControllerConfigYaml)
+	ctx, _ = fake.With(ctx, configMap)
+	ctx, _ = fakeKafkaClient.With(ctx)
+
+	// Perform The Test (Create The KafkaChannel Controller)
+	controller := NewController(ctx, nil)
+
+	// Verify The Results (Reconciler Is Namespace Scoped & Still LeaderAware)
+	assert.NotNil(t, controller)
+	scopedReconciler, ok := controller.Reconciler.(*namespaceScopedReconciler)
+	assert.True(t, ok)
+	assert.Equal(t, []string{controllertesting.KafkaChannelNamespace}, scopedReconciler.namespaces)
+	_, ok = controller.Reconciler.(reconciler.LeaderAware)
+	assert.True(t, ok)
+}
+
+// Test That KafkaChannels Outside The Watched Namespaces Are Not Reconciled
+func TestNamespaceScopedReconcile(t *testing.T) {
+
+	// Define The Test Cases (No Finalizers, Status Updates, Resources Or Events Expected For Out-Of-Scope KafkaChannels)
+	tableTest := TableTest{
+		{
+			Name:                    "Out Of Scope KafkaChannel",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions),
+			},
+		},
+		{
+			Name:                    "Out Of Scope Deleted KafkaChannel",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(controllertesting.WithFinalizer, controllertesting.WithDeletionTimestamp),
+			},
+		},
+	}
+
+	// Run The TableTest Using A Namespace Scoped KafkaChannel Reconciler Which Excludes The KafkaChannel's Namespace
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) pkgcontroller.Reconciler {
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             controllertesting.NewConfig(),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+			namespaceLister:    listers.GetNamespaceLister(),
+			kafkaClientSet:     fakeKafkaClient.Get(ctx),
+			adminMutex:         &sync.Mutex{},
+		}
+		kafkachannelReconciler := kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), pkgcontroller.GetEventRecorder(ctx), r)
+		return newNamespaceScopedReconciler(kafkachannelReconciler, []string{"other-namespace"})
+	}, logger.Desugar()))
+}
+
+// Test The namespaceScopedReconciler Delegates In-Scope Keys & LeaderAware Functions
+func TestNamespaceScopedReconciler(t *testing.T) {
+
+	// Create A Mock Reconciler To Wrap
+	mockReconciler := &mockLeaderAwareReconciler{}
+	scopedReconciler := newNamespaceScopedReconciler(mockReconciler, []string{"namespace1", "namespace2"})
+
+	// Perform The Test
+	assert.Nil(t, scopedReconciler.Reconcile(context.TODO(), "namespace1/name1"))
+	assert.Nil(t, scopedReconciler.Reconcile(context.TODO(), "namespace2/name2"))
+	assert.Nil(t, scopedReconciler.Reconcile(context.TODO(), "namespace3/name3"))
+	assert.Nil(t, scopedReconciler.(reconciler.LeaderAware).Promote(reconciler.UniversalBucket(), func(reconciler.Bucket, types.NamespacedName) {}))
+
+	// Verify The Results
+	assert.Equal(t, []string{"namespace1/name1", "namespace2/name2"}, mockReconciler.keys)
+	assert.True(t, mockReconciler.promoted)
+}
+
+// Mock LeaderAware Controller Reconciler Which Tracks Reconciled Keys
+type mockLeaderAwareReconciler struct {
+	reconciler.LeaderAwareFuncs
+	keys     []string
+	promoted bool
+}
+
+func (m *mockLeaderAwareReconciler) Reconcile(_ context.Context, key string) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+func (m *mockLeaderAwareReconciler) Promote(_ reconciler.Bucket, _ func(reconciler.Bucket, types.NamespacedName)) error {
+	m.promoted = true
+	return nil
+}
+
 // Test The Shutdown() Functionality
 func TestShutdown(t *testing.T) {
 
@@ -96,3 +266,44 @@ func populateEnvironmentVariables(t *testing.T) {
 	assert.Nil(t, os.Setenv(controllerenv.DispatcherImageEnvVarKey, controllertesting.DispatcherImage))
 	assert.Nil(t, os.Setenv(controllerenv.ReceiverImageEnvVarKey, controllertesting.ReceiverImage))
 }
+
+// Utility Function For Getting The LastValue Of The Specified Workqueue View's Row For The Named Queue (-1 If Not Found)
+func lastValue(t *testing.T, viewName string, queueName string) float64 {
+	row := findRow(t, viewName, queueName)
+	if row == nil {
+		return -1
+	}
+	return row.Data.(*view.LastValueData).Value
+}
+
+// Utility Function For Getting The Count Of The Specified Workqueue View's Row For The Named Queue (Zero If Not Found)
+func count(t *testing.T, viewName string, queueName string) int64 {
+	row := findRow(t, viewName, queueName)
+	if row == nil {
+		return 0
+	}
+	switch data := row.Data.(type) {
+	case *view.CountData:
+		return data.Value
+	case *view.DistributionData:
+		return data.Count
+	default:
+		t.Fatalf("unexpected aggregation data type %T for view %s", row.Data, viewName)
+		return 0
+	}
+}
+
+// Utility Function For Finding The Specified Workqueue View's Row For The Named Queue (Nil If Not Found)
+func findRow(t *testing.T, viewName string, queueName string) *view.Row {
+	rows, err := view.RetrieveData(viewName)
+	assert.Nil(t, err)
+	key := tag.MustNewKey("name")
+	for _, row := range rows {
+		for _, rowTag := range row.Tags {
+			if rowTag.Key == key && rowTag.Value == queueName {
+				return row
+			}
+		}
+	}
+	return nil
+}
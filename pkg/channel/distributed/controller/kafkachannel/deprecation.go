@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Reconcile Any Deprecated Spec Fields Of The Specified KafkaChannel According To The DeprecatedFieldPolicy
+//
+// KafkaChannels created against older versions of the CRD may carry spec fields which are no longer supported (see
+// kafkav1beta1.DeprecatedSpecFields).  By default ("migrate") they are migrated in-place to their replacements, with
+// a Warning Event recording the migration, and the returned bool indicates the spec was modified and must be
+// persisted.  The "warn" policy only emits the Warning Event, and the "fail" policy instead fails the KafkaChannel
+// until the deprecated fields are removed.
+//
+func (r *Reconciler) reconcileDeprecatedFields(ctx context.Context, channel *kafkav1beta1.KafkaChannel) (bool, error) {
+
+	// Nothing To Do If No Deprecated Fields Are Set
+	deprecatedFields := channel.Spec.DeprecatedFields()
+	if len(deprecatedFields) <= 0 {
+		return false, nil
+	}
+
+	// Get Channel Specific Logger & Event Recorder
+	logger := util.ChannelLogger(r.logger, channel).With(zap.Strings("DeprecatedFields", deprecatedFields))
+	recorder := controller.GetEventRecorder(ctx)
+
+	// Determine The Configured Policy
+	policy := constants.DefaultDeprecatedFieldPolicy
+	if r.config != nil && len(r.config.Kafka.DeprecatedFieldPolicy) > 0 {
+		policy = r.config.Kafka.DeprecatedFieldPolicy
+	}
+
+	switch policy {
+
+	case constants.DeprecatedFieldPolicyFail:
+		logger.Error("KafkaChannel Has Deprecated Spec Fields - Failing")
+		channel.Status.MarkConfigFailed(event.KafkaChannelDeprecatedFields.String(), "KafkaChannel Has Deprecated Spec Fields: %s", describeDeprecatedFields(deprecatedFields))
+		recorder.Eventf(channel, corev1.EventTypeWarning, event.KafkaChannelDeprecatedFields.String(), "KafkaChannel Has Deprecated Spec Fields: %s", describeDeprecatedFields(deprecatedFields))
+		return false, fmt.Errorf(constants.ReconciliationFailedError)
+
+	case constants.DeprecatedFieldPolicyWarn:
+		logger.Warn("KafkaChannel Has Deprecated Spec Fields")
+		recorder.Eventf(channel, corev1.EventTypeWarning, event.KafkaChannelDeprecatedFields.String(), "KafkaChannel Has Deprecated Spec Fields: %s", describeDeprecatedFields(deprecatedFields))
+		return false, nil
+
+	default:
+		migratedFields, err := channel.Spec.MigrateDeprecatedFields(ctx)
+		if err != nil {
+			logger.Error("Failed To Migrate KafkaChannel Deprecated Spec Fields", zap.Error(err))
+			recorder.Eventf(channel, corev1.EventTypeWarning, event.KafkaChannelDeprecatedFields.String(), "Failed To Migrate KafkaChannel Deprecated Spec Fields: %v", err)
+			return false, fmt.Errorf(constants.ReconciliationFailedError)
+		}
+		if len(migratedFields) > 0 {
+			logger.Warn("Migrated KafkaChannel Deprecated Spec Fields", zap.Strings("MigratedFields", migratedFields))
+			recorder.Eventf(channel, corev1.EventTypeWarning, event.KafkaChannelDeprecatedFields.String(), "Migrated KafkaChannel Deprecated Spec Fields: %s", describeDeprecatedFields(migratedFields))
+		}
+		if len(migratedFields) < len(deprecatedFields) {
+			logger.Warn("KafkaChannel Has Deprecated Spec Fields Which Cannot Be Migrated")
+			recorder.Eventf(channel, corev1.EventTypeWarning, event.KafkaChannelDeprecatedFields.String(), "KafkaChannel Has Deprecated Spec Fields: %s", describeDeprecatedFields(channel.Spec.DeprecatedFields()))
+		}
+		return len(migratedFields) > 0, nil
+	}
+}
+
+// Describe The Specified Deprecated Spec Fields & Their Replacements (If Any) For Inclusion In An Event
+func describeDeprecatedFields(fields []string) string {
+	descriptions := make([]string, len(fields))
+	for index, field := range fields {
+		if replacement := kafkav1beta1.DeprecatedSpecFields[field].Replacement; len(replacement) > 0 {
+			descriptions[index] = fmt.Sprintf("%s (replaced by %s)", field, replacement)
+		} else {
+			descriptions[index] = field
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	fakekafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Reconciler's reconcileDeprecatedFields() Functionality
+func TestReconcileDeprecatedFields(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name             string
+		policy           string
+		deprecated       bool
+		wantModified     bool
+		wantErr          bool
+		wantEvent        string
+		wantSubscribable bool
+		wantSubscribers  int
+		wantConfigState  corev1.ConditionStatus
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Deprecated Fields", policy: constants.DeprecatedFieldPolicyMigrate, wantConfigState: corev1.ConditionUnknown},
+		{
+			name:            "Migrate",
+			policy:          constants.DeprecatedFieldPolicyMigrate,
+			deprecated:      true,
+			wantModified:    true,
+			wantEvent:       "Warning KafkaChannelDeprecatedFields Migrated KafkaChannel Deprecated Spec Fields: spec.subscribable (replaced by spec.subscribers)",
+			wantSubscribers: 1,
+			wantConfigState: corev1.ConditionUnknown,
+		},
+		{
+			name:             "Warn",
+			policy:           constants.DeprecatedFieldPolicyWarn,
+			deprecated:       true,
+			wantEvent:        "Warning KafkaChannelDeprecatedFields KafkaChannel Has Deprecated Spec Fields: spec.subscribable (replaced by spec.subscribers)",
+			wantSubscribable: true,
+			wantConfigState:  corev1.ConditionUnknown,
+		},
+		{
+			name:             "Fail",
+			policy:           constants.DeprecatedFieldPolicyFail,
+			deprecated:       true,
+			wantErr:          true,
+			wantEvent:        "Warning KafkaChannelDeprecatedFields KafkaChannel Has Deprecated Spec Fields: spec.subscribable (replaced by spec.subscribers)",
+			wantSubscribable: true,
+			wantConfigState:  corev1.ConditionFalse,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With A Fake Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create The Reconciler & KafkaChannel To Test
+			config := controllertesting.NewConfig()
+			config.Kafka.DeprecatedFieldPolicy = testCase.policy
+			reconciler := &Reconciler{logger: logtesting.TestLogger(t).Desugar(), config: config}
+			channel := controllertesting.NewKafkaChannel()
+			channel.Status.InitializeConditions()
+			if testCase.deprecated {
+				channel.Spec.Subscribable = newDeprecatedSubscribable()
+			}
+
+			// Perform The Test
+			modified, err := reconciler.reconcileDeprecatedFields(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantModified, modified)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantErr {
+				assert.Equal(t, constants.ReconciliationFailedError, err.Error())
+				assert.Equal(t, event.KafkaChannelDeprecatedFields.String(), channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Reason)
+			}
+			assert.Equal(t, testCase.wantConfigState, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+			assert.Equal(t, testCase.wantSubscribable, channel.Spec.Subscribable != nil)
+			assert.Len(t, channel.Spec.Subscribers, testCase.wantSubscribers)
+			if len(testCase.wantEvent) > 0 {
+				assert.Len(t, recorder.Events, 1)
+				assert.Equal(t, testCase.wantEvent, <-recorder.Events)
+			} else {
+				assert.Len(t, recorder.Events, 0)
+			}
+		})
+	}
+}
+
+// Test That The Reconciler Persists A KafkaChannel Whose Deprecated Spec Fields Were Migrated
+func TestReconcileMetaDataPersistsMigratedSpec(t *testing.T) {
+
+	// Create A KafkaChannel With Up-To-Date MetaData & A Deprecated Field
+	channel := controllertesting.NewKafkaChannel(controllertesting.WithMetaData)
+	channel.Spec.Subscribable = newDeprecatedSubscribable()
+	kafkaClientSet := fakekafkaclientset.NewSimpleClientset(channel)
+
+	// Create The Reconciler To Test
+	reconciler := &Reconciler{
+		logger:         logtesting.TestLogger(t).Desugar(),
+		kafkaClientSet: kafkaClientSet,
+		adminClient:    &controllertesting.MockAdminClient{},
+		config:         controllertesting.NewConfig(),
+	}
+
+	// Migrate The Deprecated Field
+	migrated, err := channel.Spec.MigrateDeprecatedFields(context.TODO())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, migrated)
+
+	// Perform The Test
+	err = reconciler.reconcileMetaData(context.TODO(), channel, true)
+
+	// Verify The Migrated Spec Was Persisted
+	assert.Nil(t, err)
+	updatedChannel, err := kafkaClientSet.MessagingV1beta1().KafkaChannels(channel.Namespace).Get(context.TODO(), channel.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, updatedChannel.Spec.Subscribable)
+	assert.Len(t, updatedChannel.Spec.Subscribers, 1)
+	assert.Equal(t, apis.HTTP("subscriber.example.com"), updatedChannel.Spec.Subscribers[0].SubscriberURI)
+}
+
+// Utility Function For Creating A Deprecated (Pre-v1 Duck) Subscribable With A Single Subscriber
+func newDeprecatedSubscribable() *eventingduckv1alpha1.Subscribable {
+	return &eventingduckv1alpha1.Subscribable{
+		Subscribers: []eventingduckv1alpha1.SubscriberSpec{
+			{UID: "deprecated-uid", SubscriberURI: apis.HTTP("subscriber.example.com")},
+		},
+	}
+}
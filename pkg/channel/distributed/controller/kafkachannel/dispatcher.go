@@ -19,19 +19,24 @@ package kafkachannel
 import (
 	"context"
 	"fmt"
+	"path"
 	"strconv"
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/pkg/controller"
@@ -44,50 +49,224 @@ import (
 //
 func (r *Reconciler) reconcileDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
 
+	// KafkaChannels Consumed By An External System Have No Dispatcher
+	if !isDispatcherManaged(channel) {
+		return r.reconcileExternalDispatcher(ctx, channel)
+	}
+
 	// Get Channel Specific Logger
 	logger := util.ChannelLogger(r.logger, channel)
 
-	// Reconcile The Dispatcher's Service (For Prometheus Only)
-	serviceErr := r.reconcileDispatcherService(ctx, channel)
+	// Get The Number Of Dispatcher Shards (Deployments) Across Which The KafkaChannel's Subscriptions Are Assigned
+	shards, err := sharding.ParseDispatcherShards(channel.Annotations[sharding.DispatcherShardsAnnotation])
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Invalid Dispatcher Shards: %v", err)
+		logger.Error("Invalid Dispatcher Shards", zap.Error(err))
+		channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Invalid Dispatcher Shards: %v", err)
+		return err
+	}
+
+	// Reconcile The Dispatcher Service Of Each Shard (For Prometheus Only)
+	var serviceErr error
+	for shard := 0; shard < shards && serviceErr == nil; shard++ {
+		serviceErr = r.reconcileDispatcherService(ctx, channel, shard)
+	}
 	if serviceErr != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherServiceReconciliationFailed.String(), "Failed To Reconcile Dispatcher Service: %v", serviceErr)
 		logger.Error("Failed To Reconcile Dispatcher Service", zap.Error(serviceErr))
 	} else {
-		logger.Info("Successfully Reconciled Dispatcher Service")
+		logger.Info("Successfully Reconciled Dispatcher Service", zap.Int("Shards", shards))
 	}
 
-	// Reconcile The Dispatcher's Deployment
-	deploymentErr := r.reconcileDispatcherDeployment(ctx, channel)
+	// Reconcile The Dispatcher Deployment Of Each Shard
+	deploymentErr := r.reconcileDispatcherDeployments(ctx, channel, shards)
 	if deploymentErr != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Deployment: %v", deploymentErr)
 		logger.Error("Failed To Reconcile Dispatcher Deployment", zap.Error(deploymentErr))
 	} else {
-		logger.Info("Successfully Reconciled Dispatcher Deployment")
+		logger.Info("Successfully Reconciled Dispatcher Deployment", zap.Int("Shards", shards))
+	}
+
+	// Delete The Services & Deployments Of Any Shards Removed By Reducing The Number Of Shards
+	shardErr := r.deleteExcessDispatcherShards(ctx, channel, shards)
+	if shardErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Excess Dispatcher Shards: %v", shardErr)
+		logger.Error("Failed To Delete Excess Dispatcher Shards", zap.Error(shardErr))
 	}
 
 	// Return Results
-	if serviceErr != nil || deploymentErr != nil {
+	if serviceErr != nil || deploymentErr != nil || shardErr != nil {
 		return fmt.Errorf("failed to reconcile dispatcher resources")
 	} else {
 		return nil
 	}
 }
 
+// Determine Whether The Controller Provisions The Specified KafkaChannel's Dispatcher (i.e. It Is Not Labelled As External)
+func isDispatcherManaged(channel *kafkav1beta1.KafkaChannel) bool {
+	return channel.Labels[constants.DispatcherLabel] != constants.DispatcherLabelValueExternal
+}
+
+//
+// Reconcile A KafkaChannel Whose Events Are Consumed By An External System (A "Bring Your Own Dispatcher")
+//
+// The KafkaChannel's Topic & Receiver are reconciled as usual, but no Dispatcher is provisioned.  Any Dispatcher
+// created before the KafkaChannel was labelled is deleted (so that its events are not also consumed by the built-in
+// Dispatcher), and the DispatcherReady condition is marked True with the DispatcherNotManaged reason so that the
+// KafkaChannel can become Ready without one.
+//
+func (r *Reconciler) reconcileExternalDispatcher(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Get Channel Specific Logger
+	logger := util.ChannelLogger(r.logger, channel)
+
+	// Delete Any Previously Provisioned Dispatcher Service
+	serviceErr := r.deleteDispatcherService(ctx, channel)
+	if serviceErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherServiceReconciliationFailed.String(), "Failed To Delete Dispatcher Service: %v", serviceErr)
+		logger.Error("Failed To Delete Dispatcher Service", zap.Error(serviceErr))
+	}
+
+	// Delete Any Previously Provisioned Dispatcher Deployment
+	deploymentErr := r.deleteDispatcherDeployment(ctx, channel)
+	if deploymentErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Dispatcher Deployment: %v", deploymentErr)
+		logger.Error("Failed To Delete Dispatcher Deployment", zap.Error(deploymentErr))
+		channel.Status.MarkDispatcherUnknown(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Dispatcher Deployment: %v", deploymentErr)
+	}
+
+	// Delete Any Previously Provisioned Dispatcher Shards (Beyond The First Deleted Above)
+	shardErr := r.deleteExcessDispatcherShards(ctx, channel, 1)
+	if shardErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Dispatcher Shards: %v", shardErr)
+		logger.Error("Failed To Delete Dispatcher Shards", zap.Error(shardErr))
+		channel.Status.MarkDispatcherUnknown(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Delete Dispatcher Shards: %v", shardErr)
+	}
+
+	// Return Results
+	if serviceErr != nil || deploymentErr != nil || shardErr != nil {
+		return fmt.Errorf("failed to reconcile dispatcher resources")
+	}
+	logger.Info("KafkaChannel Is Consumed Externally - No Dispatcher Managed")
+	channel.Status.MarkDispatcherNotManaged(event.DispatcherNotManaged.String(), "No Dispatcher Is Managed For KafkaChannels Labelled %s=%s", constants.DispatcherLabel, constants.DispatcherLabelValueExternal)
+	return nil
+}
+
+// Delete The Dispatcher Service Associated With The Specified Channel (If Any)
+func (r *Reconciler) deleteDispatcherService(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+	service, err := r.getDispatcherService(channel, 0)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	r.logger.Info("Deleting Dispatcher Service Of Externally Consumed KafkaChannel", zap.String("Service", service.Name))
+	err = r.kubeClientset.CoreV1().Services(service.Namespace).Delete(ctx, service.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Delete The Dispatcher Deployment Associated With The Specified Channel (If Any)
+func (r *Reconciler) deleteDispatcherDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+	deployment, err := r.getDispatcherDeployment(channel, 0)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	r.logger.Info("Deleting Dispatcher Deployment Of Externally Consumed KafkaChannel", zap.String("Deployment", deployment.Name))
+	err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+//
+// Delete The Services & Deployments Of The Specified Channel's Dispatcher Shards Numbered At Or Above The Specified Count
+//
+// The shards are identified by their constants.DispatcherShardLabel, which is present on all but the first shard, so
+// the first shard (the unsharded Dispatcher) is never deleted here.  The subscriptions of the deleted shards are
+// reassigned to the remaining shards, whose Dispatchers start consuming them from the committed offsets of the
+// (unchanged) ConsumerGroups once they observe the reduced number of shards.
+//
+func (r *Reconciler) deleteExcessDispatcherShards(ctx context.Context, channel *kafkav1beta1.KafkaChannel, shards int) error {
+
+	// Select The Channel's Dispatcher Resources Which Identify Their Shard
+	selector := labels.SelectorFromSet(map[string]string{
+		constants.KafkaChannelDispatcherLabel: "true",
+		constants.KafkaChannelNameLabel:       channel.Name,
+		constants.KafkaChannelNamespaceLabel:  channel.Namespace,
+	})
+
+	// Delete The Services Of Any Excess Shards
+	services, err := r.serviceLister.Services(commonconstants.KnativeEventingNamespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		if isExcessDispatcherShard(service.Labels, shards) {
+			r.logger.Info("Deleting Dispatcher Service Of Excess Shard", zap.String("Service", service.Name))
+			err = r.kubeClientset.CoreV1().Services(service.Namespace).Delete(ctx, service.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	// Delete The Deployments Of Any Excess Shards
+	deployments, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if isExcessDispatcherShard(deployment.Labels, shards) {
+			r.logger.Info("Deleting Dispatcher Deployment Of Excess Shard", zap.String("Deployment", deployment.Name))
+			err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Determine Whether The Dispatcher Resource With The Specified Labels Belongs To A Shard Numbered At Or Above The Count
+func isExcessDispatcherShard(resourceLabels map[string]string, shards int) bool {
+	shardLabel, ok := resourceLabels[constants.DispatcherShardLabel]
+	if !ok {
+		return false // The First Shard
+	}
+	shard, err := strconv.Atoi(shardLabel)
+	return err == nil && shard >= shards
+}
+
+// Get The Labels Identifying The Specified Dispatcher Shard (None For The First Shard, Which Is The Unsharded Dispatcher)
+func addDispatcherShardLabel(resourceLabels map[string]string, shard int) map[string]string {
+	if shard > 0 {
+		resourceLabels[constants.DispatcherShardLabel] = strconv.Itoa(shard)
+	}
+	return resourceLabels
+}
+
 //
 // Dispatcher Service (For Prometheus Only)
 //
 
-// Reconcile The Dispatcher Service
-func (r *Reconciler) reconcileDispatcherService(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+// Reconcile The Dispatcher Service Of The Specified Shard
+func (r *Reconciler) reconcileDispatcherService(ctx context.Context, channel *kafkav1beta1.KafkaChannel, shard int) error {
 
 	// Attempt To Get The Dispatcher Service Associated With The Specified Channel
-	_, err := r.getDispatcherService(channel)
+	_, err := r.getDispatcherService(channel, shard)
 	if err != nil {
 
 		// If The Service Was Not Found - Then Create A New One For The Channel
 		if errors.IsNotFound(err) {
 			r.logger.Info("Dispatcher Service Not Found - Creating New One")
-			service := r.newDispatcherService(channel)
+			service := r.newDispatcherService(channel, shard)
 			_, err = r.kubeClientset.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
 			if err != nil {
 				r.logger.Error("Failed To Create Dispatcher Service", zap.Error(err))
@@ -106,11 +285,11 @@ func (r *Reconciler) reconcileDispatcherService(ctx context.Context, channel *ka
 	}
 }
 
-// Get The Dispatcher Service Associated With The Specified Channel
-func (r *Reconciler) getDispatcherService(channel *kafkav1beta1.KafkaChannel) (*corev1.Service, error) {
+// Get The Dispatcher Service Of The Specified Shard Associated With The Specified Channel
+func (r *Reconciler) getDispatcherService(channel *kafkav1beta1.KafkaChannel, shard int) (*corev1.Service, error) {
 
 	// Get The Dispatcher Service Name
-	serviceName := util.DispatcherDnsSafeName(channel)
+	serviceName := util.DispatcherShardDnsSafeName(r.config.Dispatcher.NamingPolicy, channel, shard)
 
 	// Get The Service By Namespace / Name
 	service, err := r.serviceLister.Services(commonconstants.KnativeEventingNamespace).Get(serviceName)
@@ -119,11 +298,11 @@ func (r *Reconciler) getDispatcherService(channel *kafkav1beta1.KafkaChannel) (*
 	return service, err
 }
 
-// Create Dispatcher Service Model For The Specified Subscription
-func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel) *corev1.Service {
+// Create Dispatcher Service Model For The Specified Shard Of The Channel
+func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel, shard int) *corev1.Service {
 
 	// Get The Dispatcher Service Name For The Channel
-	serviceName := util.DispatcherDnsSafeName(channel)
+	serviceName := util.DispatcherShardDnsSafeName(r.config.Dispatcher.NamingPolicy, channel, shard)
 
 	// Create & Return The Service Model
 	return &corev1.Service{
@@ -134,12 +313,12 @@ func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel) *c
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      serviceName,
 			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
+			Labels: addDispatcherShardLabel(map[string]string{
 				constants.KafkaChannelDispatcherLabel:   "true",                                  // Identifies the Service as being a KafkaChannel "Dispatcher"
 				constants.KafkaChannelNameLabel:         channel.Name,                            // Identifies the Service's Owning KafkaChannel's Name
 				constants.KafkaChannelNamespaceLabel:    channel.Namespace,                       // Identifies the Service's Owning KafkaChannel's Namespace
 				constants.K8sAppDispatcherSelectorLabel: constants.K8sAppDispatcherSelectorValue, // Prometheus ServiceMonitor
-			},
+			}, shard),
 			OwnerReferences: []metav1.OwnerReference{
 				util.NewChannelOwnerReference(channel),
 			},
@@ -163,11 +342,43 @@ func (r *Reconciler) newDispatcherService(channel *kafkav1beta1.KafkaChannel) *c
 // Dispatcher Deployment
 //
 
-// Reconcile The Dispatcher Deployment
-func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+//
+// Reconcile The Dispatcher Deployments Of The Specified Number Of Shards
+//
+// The DispatcherReady condition reflects the least available shard, since the subscriptions assigned to a shard are
+// not consumed while its Deployment is unavailable.
+//
+func (r *Reconciler) reconcileDispatcherDeployments(ctx context.Context, channel *kafkav1beta1.KafkaChannel, shards int) error {
+
+	// Reconcile Each Shard's Deployment (The Status Having Been Marked If Any Fails)
+	deployments := make([]*appsv1.Deployment, 0, shards)
+	for shard := 0; shard < shards; shard++ {
+		deployment, err := r.reconcileDispatcherDeployment(ctx, channel, shard)
+		if err != nil {
+			return err
+		}
+		deployments = append(deployments, deployment)
+	}
+
+	// Propagate The Status Of Each Shard's Deployment (Or Its Failing Containers) Until One Is Not Available
+	for _, deployment := range deployments {
+		channel.Status.PropagateDispatcherStatus(&deployment.Status)
+		if failure := r.findContainerFailure(ctx, deployment); failure != nil {
+			controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.DispatcherContainerFailed.String(), "Dispatcher Deployment Container Failed: %v", failure)
+			channel.Status.MarkDispatcherFailed(event.DispatcherContainerFailed.String(), "Dispatcher Deployment Container Failed: %v", failure)
+		}
+		if !channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionDispatcherReady).IsTrue() {
+			break
+		}
+	}
+	return nil
+}
+
+// Reconcile The Dispatcher Deployment Of The Specified Shard
+func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel, shard int) (*appsv1.Deployment, error) {
 
 	// Attempt To Get The Dispatcher Deployment Associated With The Specified Channel
-	deployment, err := r.getDispatcherDeployment(channel)
+	deployment, err := r.getDispatcherDeployment(channel, shard)
 	if err != nil {
 
 		// If The Dispatcher Deployment Was Not Found - Then Create A New Deployment For The Channel
@@ -175,42 +386,185 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, channel
 
 			// Then Create The New Deployment
 			r.logger.Info("Dispatcher Deployment Not Found - Creating New One")
-			deployment, err = r.newDispatcherDeployment(channel)
+			deployment, err = r.newDispatcherDeployment(channel, shard)
 			if err != nil {
 				r.logger.Error("Failed To Create Dispatcher Deployment YAML", zap.Error(err))
 				channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Generate Dispatcher Deployment: %v", err)
-				return err
+				return nil, err
+			} else if err = r.checkLimitRanges(ctx, deployment); err != nil {
+				r.logger.Error("Dispatcher Deployment Resources Would Be Rejected By LimitRange", zap.Error(err))
+				channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Dispatcher Deployment Resources Rejected: %v", err)
+				return nil, err
 			} else {
 				deployment, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
 				if err != nil {
 					r.logger.Error("Failed To Create Dispatcher Deployment", zap.Error(err))
 					channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Create Dispatcher Deployment: %v", err)
-					return err
+					return nil, err
 				} else {
 					r.logger.Info("Successfully Created Dispatcher Deployment")
-					channel.Status.PropagateDispatcherStatus(&deployment.Status)
-					return nil
+					r.reconcileVerticalPodAutoscaler(ctx, deployment)
+					return deployment, nil
 				}
 			}
 		} else {
 			// Failed In Attempt To Get Deployment From K8S
 			r.logger.Error("Failed To Get KafkaChannel Deployment", zap.Error(err))
 			channel.Status.MarkDispatcherUnknown(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Get Dispatcher Deployment: %v", err)
-			return err
+			return nil, err
 		}
 	} else {
+		// Generate The Desired Dispatcher Deployment (Surfacing Invalid Annotations Even Though It Already Exists)
+		desired, err := r.newDispatcherDeployment(channel, shard)
+		if err != nil {
+			r.logger.Error("Failed To Create Dispatcher Deployment YAML", zap.Error(err))
+			channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Generate Dispatcher Deployment: %v", err)
+			return nil, err
+		}
+
+		// Update The Existing Dispatcher Deployment If It Has Drifted From The KafkaChannel (Or Its Namespace)
+		deployment, err = r.updateDispatcherDeployment(ctx, deployment, desired)
+		if err != nil {
+			r.logger.Error("Failed To Update Dispatcher Deployment", zap.Error(err))
+			channel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Update Dispatcher Deployment: %v", err)
+			return nil, err
+		}
+
 		// Successfully Verified Dispatcher Deployment
 		r.logger.Info("Successfully Verified Dispatcher Deployment")
-		channel.Status.PropagateDispatcherStatus(&deployment.Status)
+		r.reconcileVerticalPodAutoscaler(ctx, deployment)
+		return deployment, nil
+	}
+}
+
+//
+// Update The Existing Dispatcher Deployment If It Has Drifted From The Desired Deployment
+//
+// Only the fields of the Pod template which are derived from the KafkaChannel (or its namespace) after creation are
+// reconciled, and any differences are applied to the existing Deployment, thereby rolling it out...
+//
+//   - Kafka Secret: The KafkaChannel's namespace may select a different Kafka Secret at any time (see
+//     selectedKafkaSecretName), in which case the EnvVars & Kafka Brokers checksum are replaced with the new ones.
+//   - Scheduling: The NodeSelector & Affinity annotations of the KafkaChannel may be changed at any time.
+//   - Termination Grace Period: The configured (or minimum) grace period may differ from the one the Deployment was
+//     created with, in which case it is replaced along with the EnvVars (which pass it to the Dispatcher).
+//
+// Any other differences are left as they are.
+//
+func (r *Reconciler) updateDispatcherDeployment(ctx context.Context, existing *appsv1.Deployment, desired *appsv1.Deployment) (*appsv1.Deployment, error) {
+
+	// Determine Which Of The Reconciled Fields Of The Existing Deployment Differ From The Desired Deployment
+	existingPodSpec := &existing.Spec.Template.Spec
+	desiredPodSpec := &desired.Spec.Template.Spec
+	existingSecretNames := util.ReferencedSecretNames(existingPodSpec.Containers)
+	desiredSecretNames := util.ReferencedSecretNames(desiredPodSpec.Containers)
+	kafkaSecretDrifted := !equality.Semantic.DeepEqual(existingSecretNames, desiredSecretNames)
+	schedulingDrifted := !equality.Semantic.DeepEqual(existingPodSpec.NodeSelector, desiredPodSpec.NodeSelector) ||
+		!equality.Semantic.DeepEqual(existingPodSpec.Affinity, desiredPodSpec.Affinity)
+	gracePeriodDrifted := !equality.Semantic.DeepEqual(existingPodSpec.TerminationGracePeriodSeconds, desiredPodSpec.TerminationGracePeriodSeconds)
+
+	// Nothing To Update If None Have Drifted
+	if !kafkaSecretDrifted && !schedulingDrifted && !gracePeriodDrifted {
+		return existing, nil
+	}
+	updated := existing.DeepCopy()
+
+	// Replace The EnvVars Of The Existing Deployment's Containers (Which Include The Kafka Secret & Grace Period)
+	if kafkaSecretDrifted || gracePeriodDrifted {
+		for i := range updated.Spec.Template.Spec.Containers {
+			for _, desiredContainer := range desiredPodSpec.Containers {
+				if updated.Spec.Template.Spec.Containers[i].Name == desiredContainer.Name {
+					updated.Spec.Template.Spec.Containers[i].Env = desiredContainer.Env
+				}
+			}
+		}
+	}
+
+	// Replace The Kafka Brokers Checksum Of The Existing Deployment
+	if kafkaSecretDrifted {
+		r.logger.Info("Dispatcher Deployment References Previously Selected Kafka Secret - Updating",
+			zap.Strings("Existing", existingSecretNames), zap.Strings("Desired", desiredSecretNames))
+		updated.Annotations = replaceKafkaBrokersChecksumAnnotation(updated.Annotations, desired.Annotations)
+		updated.Spec.Template.Annotations = replaceKafkaBrokersChecksumAnnotation(updated.Spec.Template.Annotations, desired.Spec.Template.Annotations)
+	}
+
+	// Replace The NodeSelector & Affinity Of The Existing Deployment
+	if schedulingDrifted {
+		r.logger.Info("Dispatcher Deployment NodeSelector / Affinity Differs From KafkaChannel Annotations - Updating")
+		updated.Spec.Template.Spec.NodeSelector = desiredPodSpec.NodeSelector
+		updated.Spec.Template.Spec.Affinity = desiredPodSpec.Affinity
+	}
+
+	// Replace The TerminationGracePeriodSeconds Of The Existing Deployment
+	if gracePeriodDrifted {
+		r.logger.Info("Dispatcher Deployment TerminationGracePeriodSeconds Differs From Configuration - Updating",
+			zap.Int64p("Existing", existingPodSpec.TerminationGracePeriodSeconds), zap.Int64p("Desired", desiredPodSpec.TerminationGracePeriodSeconds))
+		updated.Spec.Template.Spec.TerminationGracePeriodSeconds = desiredPodSpec.TerminationGracePeriodSeconds
+	}
+
+	// Update The Dispatcher Deployment
+	return r.kubeClientset.AppsV1().Deployments(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+}
+
+// Replace The Kafka Brokers Checksum Annotation With The Desired One (Removing It If The Desired Checksum Is Unknown)
+func replaceKafkaBrokersChecksumAnnotation(annotations map[string]string, desiredAnnotations map[string]string) map[string]string {
+	if checksum, ok := desiredAnnotations[constants.KafkaBrokersChecksumAnnotation]; ok {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[constants.KafkaBrokersChecksumAnnotation] = checksum
+	} else {
+		delete(annotations, constants.KafkaBrokersChecksumAnnotation)
+	}
+	return annotations
+}
+
+// Verify The (Dispatcher / Receiver) Deployment's Resources Are Permitted By The Namespace's LimitRanges (If Enabled)
+func (r *Reconciler) checkLimitRanges(ctx context.Context, deployment *appsv1.Deployment) error {
+	if !r.environment.ValidateLimitRanges {
 		return nil
 	}
+	return util.CheckLimitRanges(ctx, r.kubeClientset, deployment.Namespace, &deployment.Spec.Template.Spec)
 }
 
-// Get The Dispatcher Deployment Associated With The Specified Channel
-func (r *Reconciler) getDispatcherDeployment(channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+//
+// Find A Container Of The (Dispatcher / Receiver) Deployment's Pods Which Is Failing To Start (If Enabled)
+//
+// Only Deployments with unavailable replicas are inspected, so that the Pods are not listed for every healthy
+// Deployment on every reconciliation.  Failing to list the Pods is logged but otherwise ignored, as the Deployment's
+// own status is still reflected in the KafkaChannel's conditions.
+//
+func (r *Reconciler) findContainerFailure(ctx context.Context, deployment *appsv1.Deployment) *util.ContainerFailure {
+	if !r.environment.InspectDeploymentPods || deployment.Status.UnavailableReplicas == 0 {
+		return nil
+	}
+	failure, err := util.FindContainerFailure(ctx, r.kubeClientset, deployment)
+	if err != nil {
+		r.logger.Warn("Failed To Inspect Deployment Pods - Container Failures Will Not Be Reported", zap.String("Deployment", deployment.Name), zap.Error(err))
+		return nil
+	}
+	if failure != nil {
+		r.logger.Warn("Deployment Container Failing To Start", zap.String("Deployment", deployment.Name), zap.Error(failure))
+	}
+	return failure
+}
+
+// Reconcile The (Dispatcher / Receiver) Deployment's Recommendation-Only VerticalPodAutoscaler (If Enabled)
+func (r *Reconciler) reconcileVerticalPodAutoscaler(ctx context.Context, deployment *appsv1.Deployment) {
+	if !r.environment.VerticalPodAutoscalers {
+		return
+	}
+	err := util.ReconcileVerticalPodAutoscaler(ctx, r.logger, r.dynamicClient, deployment)
+	if err != nil {
+		r.logger.Warn("Failed To Reconcile VerticalPodAutoscaler - Sizing Recommendations Unavailable", zap.String("Deployment", deployment.Name), zap.Error(err))
+	}
+}
+
+// Get The Dispatcher Deployment Of The Specified Shard Associated With The Specified Channel
+func (r *Reconciler) getDispatcherDeployment(channel *kafkav1beta1.KafkaChannel, shard int) (*appsv1.Deployment, error) {
 
 	// Get The Dispatcher Deployment Name For The Channel
-	deploymentName := util.DispatcherDnsSafeName(channel)
+	deploymentName := util.DispatcherShardDnsSafeName(r.config.Dispatcher.NamingPolicy, channel, shard)
 
 	// Get The Dispatcher Deployment By Namespace / Name
 	deployment, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(deploymentName)
@@ -219,22 +573,61 @@ func (r *Reconciler) getDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 	return deployment, err
 }
 
-// Create Dispatcher Deployment Model For The Specified Channel
-func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+// Create Dispatcher Deployment Model For The Specified Shard Of The Channel
+func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel, shard int) (*appsv1.Deployment, error) {
 
 	// Get The Dispatcher Deployment Name For The Channel
-	deploymentName := util.DispatcherDnsSafeName(channel)
+	deploymentName := util.DispatcherShardDnsSafeName(r.config.Dispatcher.NamingPolicy, channel, shard)
+
+	// Verify The Deployment's Ports Are Distinct (Otherwise The Dispatcher Would Fail To Bind Them)
+	err := env.VerifyDeploymentPorts(r.environment.MetricsPort)
+	if err != nil {
+		r.logger.Error("Invalid Dispatcher Deployment Ports", zap.Error(err))
+		return nil, err
+	}
 
 	// Replicas Int Value For De-Referencing
 	replicas := int32(r.config.Dispatcher.Replicas)
 
 	// Create The Dispatcher Container Environment Variables
-	envVars, err := r.dispatcherDeploymentEnvVars(channel)
+	envVars, err := r.dispatcherDeploymentEnvVars(channel, shard)
 	if err != nil {
 		r.logger.Error("Failed To Create Dispatcher Deployment Environment Variables", zap.Error(err))
 		return nil, err
 	}
 
+	// Get The Dispatcher's (Optional) Per-KafkaChannel NodeSelector & Affinity
+	nodeSelector, err := util.DispatcherNodeSelector(channel)
+	if err != nil {
+		r.logger.Error("Failed To Get Dispatcher Deployment NodeSelector", zap.Error(err))
+		return nil, err
+	}
+	affinity, err := util.DispatcherAffinity(channel, r.config.Dispatcher.Affinity)
+	if err != nil {
+		r.logger.Error("Failed To Get Dispatcher Deployment Affinity", zap.Error(err))
+		return nil, err
+	}
+
+	// Record The Checksum Of The Kafka Brokers Injected Into The Dispatcher (Changes Roll Out The Deployment)
+	brokersChecksum := r.kafkaBrokersChecksum(r.kafkaSecretName(channel))
+
+	// Allow The Dispatcher To Drain Within Its Shutdown Timeout Before Being Killed
+	terminationGracePeriodSeconds := util.DispatcherTerminationGracePeriodSeconds(r.config.Dispatcher)
+
+	// Provide The Dispatcher A Volume To Persist Its Subscription Snapshot In (If Enabled)
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	if r.config.Dispatcher.SubscriptionSnapshot.Enabled {
+		volumes = []corev1.Volume{{
+			Name:         constants.DispatcherSnapshotVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}}
+		volumeMounts = []corev1.VolumeMount{{
+			Name:      constants.DispatcherSnapshotVolumeName,
+			MountPath: constants.DispatcherSnapshotMountPath,
+		}}
+	}
+
 	// Create The Dispatcher's Deployment
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -244,12 +637,13 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deploymentName,
 			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
+			Labels: addDispatcherShardLabel(map[string]string{
 				constants.AppLabel:                    deploymentName,    // Matches K8S Service Selector Key/Value Below
 				constants.KafkaChannelDispatcherLabel: "true",            // Identifies the Deployment as being a KafkaChannel "Dispatcher"
 				constants.KafkaChannelNameLabel:       channel.Name,      // Identifies the Deployment's Owning KafkaChannel's Name
 				constants.KafkaChannelNamespaceLabel:  channel.Namespace, // Identifies the Deployment's Owning KafkaChannel's Namespace
-			},
+			}, shard),
+			Annotations: util.KafkaBrokersChecksumAnnotations(brokersChecksum),
 			OwnerReferences: []metav1.OwnerReference{
 				util.NewChannelOwnerReference(channel),
 			},
@@ -266,9 +660,15 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 					Labels: map[string]string{
 						constants.AppLabel: deploymentName, // Matched By Deployment Selector Above
 					},
+					Annotations: util.KafkaBrokersChecksumAnnotations(brokersChecksum),
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: r.environment.ServiceAccount,
+					ServiceAccountName:            r.environment.ServiceAccount,
+					TopologySpreadConstraints:     r.config.Dispatcher.TopologySpreadConstraints,
+					NodeSelector:                  nodeSelector,
+					Affinity:                      affinity,
+					TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+					Volumes:                       volumes,
 					Containers: []corev1.Container{
 						{
 							Name: deploymentName,
@@ -294,6 +694,7 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 							},
 							Image:           r.environment.DispatcherImage,
 							Env:             envVars,
+							VolumeMounts:    volumeMounts,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
@@ -316,8 +717,8 @@ func (r *Reconciler) newDispatcherDeployment(channel *kafkav1beta1.KafkaChannel)
 	return deployment, nil
 }
 
-// Create The Dispatcher Container's Env Vars
-func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChannel) ([]corev1.EnvVar, error) {
+// Create The Dispatcher Container's Env Vars For The Specified Shard
+func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChannel, shard int) ([]corev1.EnvVar, error) {
 
 	// Get The TopicName For Specified Channel
 	topicName := util.TopicName(channel)
@@ -350,12 +751,46 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 		},
 		{
 			Name:  commonenv.ServiceNameEnvVarKey,
-			Value: util.DispatcherDnsSafeName(channel),
+			Value: util.DispatcherShardDnsSafeName(r.config.Dispatcher.NamingPolicy, channel, shard),
 		},
 		{
 			Name:  commonenv.KafkaTopicEnvVarKey,
 			Value: topicName,
 		},
+		{
+			Name: commonenv.NodeNameEnvVarKey,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+			},
+		},
+		{
+			Name:  commonenv.TerminationGracePeriodEnvVarKey,
+			Value: strconv.FormatInt(util.DispatcherTerminationGracePeriodSeconds(r.config.Dispatcher), 10),
+		},
+	}
+
+	// Identify All But The First Shard To The Dispatcher (Which Otherwise Consumes All Subscriptions)
+	if shard > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.DispatcherShardEnvVarKey,
+			Value: strconv.Itoa(shard),
+		})
+	}
+
+	// Have The Dispatcher Persist Its Subscriptions For Warm Restarts (If Enabled) In The Snapshot Volume
+	if r.config.Dispatcher.SubscriptionSnapshot.Enabled {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.SubscriptionSnapshotPathEnvVarKey,
+			Value: path.Join(constants.DispatcherSnapshotMountPath, constants.DispatcherSnapshotFileName),
+		})
+	}
+
+	// Have The Dispatcher Wait For The ConfigMap At Startup (If Configured) Rather Than Terminating Immediately
+	if r.config.Dispatcher.ConfigMapWaitTimeoutMillis > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.ConfigMapWaitTimeoutEnvVarKey,
+			Value: strconv.FormatInt(r.config.Dispatcher.ConfigMapWaitTimeoutMillis, 10),
+		})
 	}
 
 	// Get The Kafka Secret From The Kafka Admin Client
@@ -401,6 +836,9 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 				},
 			},
 		})
+
+		// Append The (Optional) Kafka SASL Mechanism As Env Var
+		envVars = append(envVars, util.KafkaSaslMechanismEnvVar(kafkaSecret))
 	}
 
 	// Return The Dispatcher Deployment EnvVars Array
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Validate The Delivery Guarantee Requested Of The Specified KafkaChannel (If Any) Against The Sarama Configuration
+//
+// The "delivery-guarantee" annotation configures the receiver, topic and dispatcher consistently, overriding the
+// corresponding Sarama settings, but relies upon some settings which it does not override (e.g. producer retries and
+// the Kafka version).  A guarantee which is incompatible with them fails the KafkaChannel (and receives a Warning
+// Event) until either the configuration or the annotation is corrected, rather than delivering with a weaker guarantee.
+//
+func (r *Reconciler) reconcileDeliveryGuarantee(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Validate If No Delivery Guarantee Is Requested (Or There Is No Configuration To Validate It Against)
+	guarantee := channel.DeliveryGuarantee()
+	if len(guarantee) <= 0 || r.saramaConfig == nil {
+		return nil
+	}
+
+	// Validate The Delivery Guarantee
+	err := delivery.Validate(r.saramaConfig, guarantee)
+	if err == nil {
+		return nil
+	}
+
+	// Report & Fail The KafkaChannel With The Incompatible Delivery Guarantee
+	logger := util.ChannelLogger(r.logger, channel).With(zap.String("DeliveryGuarantee", guarantee))
+	logger.Error("KafkaChannel Delivery Guarantee Is Incompatible With The Kafka Configuration - Rejecting", zap.Error(err))
+	message := fmt.Sprintf("Delivery Guarantee Incompatible With The Kafka Configuration: %v", err)
+	channel.Status.MarkConfigFailed(event.KafkaChannelDeliveryGuaranteeIncompatible.String(), message)
+	controller.GetEventRecorder(ctx).Event(channel, corev1.EventTypeWarning, event.KafkaChannelDeliveryGuaranteeIncompatible.String(), message)
+	return fmt.Errorf(constants.ReconciliationFailedError)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Reconciler's reconcileDeliveryGuarantee() Functionality
+func TestReconcileDeliveryGuarantee(t *testing.T) {
+
+	// Test Data
+	noRetriesEvent := "Warning KafkaChannelDeliveryGuaranteeIncompatible Delivery Guarantee Incompatible With The Kafka Configuration: at-least-once delivery requires producer retries, but Producer.Retry.Max is 0"
+	oldVersionEvent := "Warning KafkaChannelDeliveryGuaranteeIncompatible Delivery Guarantee Incompatible With The Kafka Configuration: effectively-once delivery requires Kafka version >= 0.11.0.0 (configured version is 0.10.2.0)"
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name       string
+		guarantee  string
+		retryMax   int
+		version    sarama.KafkaVersion
+		wantErr    bool
+		wantEvent  string
+		wantConfig corev1.ConditionStatus
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Not Requested", retryMax: 0, version: sarama.V2_0_0_0, wantConfig: corev1.ConditionUnknown},
+		{name: "At Most Once Without Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, retryMax: 0, version: sarama.V2_0_0_0, wantConfig: corev1.ConditionUnknown},
+		{name: "At Least Once With Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, retryMax: 3, version: sarama.V2_0_0_0, wantConfig: corev1.ConditionUnknown},
+		{name: "At Least Once Without Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, retryMax: 0, version: sarama.V2_0_0_0, wantErr: true, wantEvent: noRetriesEvent, wantConfig: corev1.ConditionFalse},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, retryMax: 3, version: sarama.V2_0_0_0, wantConfig: corev1.ConditionUnknown},
+		{name: "Effectively Once Old Kafka Version", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, retryMax: 3, version: sarama.V0_10_2_0, wantErr: true, wantEvent: oldVersionEvent, wantConfig: corev1.ConditionFalse},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With A Fake Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create The Reconciler & KafkaChannel To Test
+			saramaConfig := sarama.NewConfig()
+			saramaConfig.Producer.Retry.Max = testCase.retryMax
+			saramaConfig.Version = testCase.version
+			reconciler := &Reconciler{logger: logtesting.TestLogger(t).Desugar(), saramaConfig: saramaConfig}
+			channel := controllertesting.NewKafkaChannel()
+			if len(testCase.guarantee) > 0 {
+				channel.Annotations = map[string]string{kafkav1beta1.DeliveryGuaranteeAnnotationKey: testCase.guarantee}
+			}
+			channel.Status.InitializeConditions()
+
+			// Perform The Test
+			err := reconciler.reconcileDeliveryGuarantee(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantErr {
+				assert.Equal(t, constants.ReconciliationFailedError, err.Error())
+				assert.Equal(t, event.KafkaChannelDeliveryGuaranteeIncompatible.String(), channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Reason)
+			}
+			assert.Equal(t, testCase.wantConfig, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+			if len(testCase.wantEvent) > 0 {
+				assert.Len(t, recorder.Events, 1)
+				assert.Equal(t, testCase.wantEvent, <-recorder.Events)
+			} else {
+				assert.Len(t, recorder.Events, 0)
+			}
+		})
+	}
+}
@@ -29,14 +29,14 @@ import (
 	"knative.dev/eventing/pkg/apis/messaging"
 )
 
-// Reconcile The KafkaChannel Itself - After Channel Reconciliation (Add MetaData)
-func (r *Reconciler) reconcileKafkaChannel(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+// Reconcile The KafkaChannel Itself - After Channel Reconciliation (Add MetaData & Persist Any Migrated Spec)
+func (r *Reconciler) reconcileKafkaChannel(ctx context.Context, channel *kafkav1beta1.KafkaChannel, specModified bool) error {
 
 	// Get Channel Specific Logger
 	logger := util.ChannelLogger(r.logger, channel)
 
 	// Reconcile The KafkaChannel's MetaData
-	err := r.reconcileMetaData(ctx, channel)
+	err := r.reconcileMetaData(ctx, channel, specModified)
 	if err != nil {
 		logger.Error("Failed To Reconcile KafkaChannel MetaData", zap.Error(err))
 		return fmt.Errorf("failed to reconcile kafkachannel metadata")
@@ -46,15 +46,15 @@ func (r *Reconciler) reconcileKafkaChannel(ctx context.Context, channel *kafkav1
 	}
 }
 
-// Reconcile The KafkaChannels MetaData (Annotations, Labels, etc...)
-func (r *Reconciler) reconcileMetaData(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+// Reconcile The KafkaChannels MetaData (Annotations, Labels, etc...) - Also Persisting The Spec If Modified
+func (r *Reconciler) reconcileMetaData(ctx context.Context, channel *kafkav1beta1.KafkaChannel, specModified bool) error {
 
 	// Update The MetaData (Annotations, Labels, etc...)
 	annotationsModified := r.reconcileAnnotations(channel)
 	labelsModified := r.reconcileLabels(channel)
 
-	// If The KafkaChannel's MetaData Was Modified
-	if annotationsModified || labelsModified {
+	// If The KafkaChannel's MetaData (Or Spec) Was Modified
+	if annotationsModified || labelsModified || specModified {
 
 		// Then Persist Changes To Kubernetes
 		_, err := r.kafkaClientSet.MessagingV1beta1().KafkaChannels(channel.Namespace).Update(ctx, channel, metav1.UpdateOptions{})
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Reconcile The Ordered Delivery Requested Of The Specified KafkaChannel (If Any) According To The DeliveryOrderPolicy
+//
+// Kafka only orders events within a partition, so ordered delivery (as requested by the "delivery-order" annotation)
+// can only be guaranteed globally for KafkaChannels with a single partition.  The OrderingGuaranteed condition reports
+// whether this is the case (without affecting readiness), and multi-partition KafkaChannels additionally receive a
+// Warning Event.  The "reject" policy instead fails the KafkaChannel until either the partitions or the annotation
+// are corrected.  KafkaChannels which do not request ordered delivery have no OrderingGuaranteed condition.
+//
+func (r *Reconciler) reconcileDeliveryOrder(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Validate If Ordered Delivery Is Not Requested
+	if !channel.IsOrderedDelivery() {
+		channel.Status.ClearOrderingCondition()
+		return nil
+	}
+
+	// Ordering Is Guaranteed Globally With A Single Partition
+	if channel.IsOrderingGuaranteed() {
+		channel.Status.MarkOrderingGuaranteed()
+		return nil
+	}
+
+	// Get Channel Specific Logger & Event Recorder
+	logger := util.ChannelLogger(r.logger, channel).With(zap.Int32("NumPartitions", channel.Spec.NumPartitions))
+	recorder := controller.GetEventRecorder(ctx)
+
+	// Determine The Configured Policy
+	policy := constants.DefaultDeliveryOrderPolicy
+	if r.config != nil && len(r.config.Kafka.DeliveryOrderPolicy) > 0 {
+		policy = r.config.Kafka.DeliveryOrderPolicy
+	}
+
+	// Report That Ordering Is Only Guaranteed Per-Partition
+	message := fmt.Sprintf("Ordered Delivery Requested Of A KafkaChannel With %d Partitions - Ordering Is Only Guaranteed Per-Partition", channel.Spec.NumPartitions)
+	channel.Status.MarkOrderingNotGuaranteed(event.KafkaChannelOrderingNotGuaranteed.String(), message)
+	recorder.Event(channel, corev1.EventTypeWarning, event.KafkaChannelOrderingNotGuaranteed.String(), message)
+
+	// Fail The KafkaChannel If Rejecting
+	if policy == constants.DeliveryOrderPolicyReject {
+		logger.Error("KafkaChannel Requests Ordered Delivery With Multiple Partitions - Rejecting")
+		channel.Status.MarkConfigFailed(event.KafkaChannelOrderingNotGuaranteed.String(), message)
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
+	logger.Warn("KafkaChannel Requests Ordered Delivery With Multiple Partitions")
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Reconciler's reconcileDeliveryOrder() Functionality
+func TestReconcileDeliveryOrder(t *testing.T) {
+
+	// Test Data
+	multiPartitionEvent := "Warning KafkaChannelOrderingNotGuaranteed Ordered Delivery Requested Of A KafkaChannel With 3 Partitions - Ordering Is Only Guaranteed Per-Partition"
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name              string
+		policy            string
+		deliveryOrder     string
+		numPartitions     int32
+		wantErr           bool
+		wantEvent         string
+		wantOrderingState corev1.ConditionStatus // Empty If No OrderingGuaranteed Condition Is Expected
+		wantConfigState   corev1.ConditionStatus
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Not Requested", policy: constants.DeliveryOrderPolicyReject, numPartitions: 3, wantConfigState: corev1.ConditionUnknown},
+		{name: "Unordered", policy: constants.DeliveryOrderPolicyReject, deliveryOrder: kafkav1beta1.DeliveryOrderUnordered, numPartitions: 3, wantConfigState: corev1.ConditionUnknown},
+		{name: "Ordered Single Partition", policy: constants.DeliveryOrderPolicyReject, deliveryOrder: kafkav1beta1.DeliveryOrderOrdered, numPartitions: 1, wantOrderingState: corev1.ConditionTrue, wantConfigState: corev1.ConditionUnknown},
+		{
+			name:              "Ordered Multiple Partitions Warn",
+			policy:            constants.DeliveryOrderPolicyWarn,
+			deliveryOrder:     kafkav1beta1.DeliveryOrderOrdered,
+			numPartitions:     3,
+			wantEvent:         multiPartitionEvent,
+			wantOrderingState: corev1.ConditionFalse,
+			wantConfigState:   corev1.ConditionUnknown,
+		},
+		{
+			name:              "Ordered Multiple Partitions Reject",
+			policy:            constants.DeliveryOrderPolicyReject,
+			deliveryOrder:     kafkav1beta1.DeliveryOrderOrdered,
+			numPartitions:     3,
+			wantErr:           true,
+			wantEvent:         multiPartitionEvent,
+			wantOrderingState: corev1.ConditionFalse,
+			wantConfigState:   corev1.ConditionFalse,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With A Fake Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create The Reconciler & KafkaChannel To Test
+			config := controllertesting.NewConfig()
+			config.Kafka.DeliveryOrderPolicy = testCase.policy
+			reconciler := &Reconciler{logger: logtesting.TestLogger(t).Desugar(), config: config}
+			channel := controllertesting.NewKafkaChannel()
+			channel.Spec.NumPartitions = testCase.numPartitions
+			if len(testCase.deliveryOrder) > 0 {
+				channel.Annotations = map[string]string{kafkav1beta1.DeliveryOrderAnnotationKey: testCase.deliveryOrder}
+			}
+			channel.Status.InitializeConditions()
+			channel.Status.MarkOrderingGuaranteed() // Verify Any Stale Condition Is Replaced Or Cleared
+
+			// Perform The Test
+			err := reconciler.reconcileDeliveryOrder(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantErr {
+				assert.Equal(t, constants.ReconciliationFailedError, err.Error())
+				assert.Equal(t, event.KafkaChannelOrderingNotGuaranteed.String(), channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Reason)
+			}
+			assert.Equal(t, testCase.wantConfigState, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+			orderingCondition := channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionOrderingGuaranteed)
+			if len(testCase.wantOrderingState) > 0 {
+				assert.NotNil(t, orderingCondition)
+				assert.Equal(t, testCase.wantOrderingState, orderingCondition.Status)
+			} else {
+				assert.Nil(t, orderingCondition)
+			}
+			if len(testCase.wantEvent) > 0 {
+				assert.Len(t, recorder.Events, 1)
+				assert.Equal(t, testCase.wantEvent, <-recorder.Events)
+			} else {
+				assert.Len(t, recorder.Events, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/controller"
+)
+
+//
+// Reconcile The Dedicated Receiver (Kafka Producer) For The Specified KafkaChannel
+//
+// The Receiver is only reconciled here when configured for "channel" mode.  Otherwise a single Receiver
+// is shared by all KafkaChannels using the same Kafka Secret and is reconciled by the KafkaSecret controller.
+//
+func (r *Reconciler) reconcileReceiver(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Do Unless Receivers Are Dedicated To Individual KafkaChannels
+	if !util.ReceiverPerChannel(r.config) {
+		return nil
+	}
+
+	// Get Channel Specific Logger
+	logger := util.ChannelLogger(r.logger, channel)
+
+	// Reconcile The Receiver's Service
+	serviceErr := r.reconcileReceiverService(ctx, channel)
+	if serviceErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.ReceiverServiceReconciliationFailed.String(), "Failed To Reconcile Receiver Service: %v", serviceErr)
+		logger.Error("Failed To Reconcile Receiver Service", zap.Error(serviceErr))
+		channel.Status.MarkServiceFailed(event.ReceiverServiceReconciliationFailed.String(), "Receiver Service Failed: %v", serviceErr)
+	} else {
+		logger.Info("Successfully Reconciled Receiver Service")
+		channel.Status.MarkServiceTrue()
+	}
+
+	// Reconcile The Receiver's Deployment (Reporting Any Of Its Containers Failing To Start)
+	deployment, deploymentErr := r.reconcileReceiverDeployment(ctx, channel)
+	if deploymentErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.ReceiverDeploymentReconciliationFailed.String(), "Failed To Reconcile Receiver Deployment: %v", deploymentErr)
+		logger.Error("Failed To Reconcile Receiver Deployment", zap.Error(deploymentErr))
+		channel.Status.MarkEndpointsFailed(event.ReceiverDeploymentReconciliationFailed.String(), "Receiver Deployment Failed: %v", deploymentErr)
+	} else if failure := r.findContainerFailure(ctx, deployment); failure != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.ReceiverContainerFailed.String(), "Receiver Deployment Container Failed: %v", failure)
+		channel.Status.MarkEndpointsFailed(event.ReceiverContainerFailed.String(), "Receiver Deployment Container Failed: %v", failure)
+	} else {
+		logger.Info("Successfully Reconciled Receiver Deployment")
+		channel.Status.MarkEndpointsTrue()
+	}
+
+	// Reconcile The Receiver's (Optional) Ingress
+	ingressErr := r.reconcileReceiverIngress(ctx, channel)
+	if ingressErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.ReceiverIngressReconciliationFailed.String(), "Failed To Reconcile Receiver Ingress: %v", ingressErr)
+		logger.Error("Failed To Reconcile Receiver Ingress", zap.Error(ingressErr))
+	}
+
+	// Return Results
+	if serviceErr != nil || deploymentErr != nil || ingressErr != nil {
+		return fmt.Errorf("failed to reconcile receiver resources")
+	} else {
+		return nil
+	}
+}
+
+//
+// Receiver Service
+//
+
+// Reconcile The Receiver Service(s) - Including The Separate Receiver Metrics Service (If Enabled)
+func (r *Reconciler) reconcileReceiverService(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Reconcile The Receiver (HTTP) Service
+	receiver := r.receiver(channel)
+	err := util.ReconcileReceiverService(ctx, r.logger, r.kubeClientset, r.serviceLister, util.NewReceiverService(receiver, r.config, r.environment))
+	if err != nil {
+		return err
+	}
+
+	// Reconcile The Separate Receiver Metrics Service (If Enabled)
+	if r.config.Receiver.SeparateMetricsService {
+		return util.ReconcileReceiverService(ctx, r.logger, r.kubeClientset, r.serviceLister, util.NewReceiverMetricsService(receiver, r.environment))
+	}
+	return nil
+}
+
+// Get The Receiver Dedicated To The Specified Channel (Referencing The Channel's Selected Kafka Secret)
+func (r *Reconciler) receiver(channel *kafkav1beta1.KafkaChannel) util.Receiver {
+	kafkaSecretName := r.kafkaSecretName(channel)
+	return util.NewChannelReceiver(r.config.Receiver.NamingPolicy, channel, kafkaSecretName, r.kafkaBrokersChecksum(kafkaSecretName))
+}
+
+//
+// Receiver Ingress - Optional External Access To The Receiver Service
+//
+
+// Reconcile The Receiver Ingress (If Enabled)
+func (r *Reconciler) reconcileReceiverIngress(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Do Unless External Access Has Been Enabled
+	if !r.config.Receiver.Ingress.Enabled {
+		return nil
+	}
+
+	// Reconcile The Receiver Ingress
+	return util.ReconcileReceiverIngress(ctx, r.logger, r.kubeClientset, util.NewReceiverIngress(r.receiver(channel), r.config))
+}
+
+//
+// Receiver Deployment - The Kafka Producer Implementation
+//
+
+// Reconcile The Receiver Deployment
+func (r *Reconciler) reconcileReceiverDeployment(ctx context.Context, channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+
+	// Attempt To Get The Receiver Deployment Associated With The Specified Channel
+	deployment, err := r.getReceiverDeployment(channel)
+	if err != nil {
+
+		// If The Receiver Deployment Was Not Found - Then Create A New Deployment For The Channel
+		if errors.IsNotFound(err) {
+			r.logger.Info("Receiver Deployment Not Found - Creating New One")
+			deployment, err = r.newReceiverDeployment(channel)
+			if err != nil {
+				r.logger.Error("Failed To Create Receiver Deployment YAML", zap.Error(err))
+				return nil, err
+			} else if err = r.checkLimitRanges(ctx, deployment); err != nil {
+				r.logger.Error("Receiver Deployment Resources Would Be Rejected By LimitRange", zap.Error(err))
+				return nil, err
+			} else {
+				deployment, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+				if err != nil {
+					r.logger.Error("Failed To Create Receiver Deployment", zap.Error(err))
+					return nil, err
+				} else {
+					r.logger.Info("Successfully Created Receiver Deployment")
+					r.reconcileVerticalPodAutoscaler(ctx, deployment)
+					return deployment, nil
+				}
+			}
+		} else {
+			r.logger.Error("Failed To Get Receiver Deployment", zap.Error(err))
+			return nil, err
+		}
+	} else {
+		r.logger.Info("Successfully Verified Receiver Deployment")
+		r.reconcileVerticalPodAutoscaler(ctx, deployment)
+		return deployment, nil
+	}
+}
+
+// Get The Receiver Deployment Associated With The Specified Channel
+func (r *Reconciler) getReceiverDeployment(channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+
+	// Get The Receiver Deployment Name For The Channel
+	deploymentName := util.ChannelReceiverDnsSafeName(r.config.Receiver.NamingPolicy, channel)
+
+	// Get The Receiver Deployment By Namespace / Name
+	deployment, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(deploymentName)
+
+	// Return The Results
+	return deployment, err
+}
+
+// Create Receiver Deployment Model For The Specified Channel
+func (r *Reconciler) newReceiverDeployment(channel *kafkav1beta1.KafkaChannel) (*appsv1.Deployment, error) {
+
+	// Verify The Channel's Kafka Secret Is Known - Cannot Proceed Otherwise
+	receiver := r.receiver(channel)
+	if len(receiver.KafkaSecretName) <= 0 {
+		return nil, fmt.Errorf("invalid kafkaSecret for topic '%s'", util.TopicName(channel))
+	}
+
+	// Create The Receiver Deployment
+	deployment, err := util.NewReceiverDeployment(receiver, r.config, r.environment)
+	if err != nil {
+		r.logger.Error("Invalid Receiver Deployment Ports", zap.Error(err))
+		return nil, err
+	}
+	return deployment, nil
+}
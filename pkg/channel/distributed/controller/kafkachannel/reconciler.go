@@ -18,12 +18,14 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -47,6 +49,7 @@ import (
 type Reconciler struct {
 	logger               *zap.Logger
 	kubeClientset        kubernetes.Interface
+	dynamicClient        dynamic.Interface
 	kafkaClientSet       kafkaclientset.Interface
 	adminClientType      kafkaadmin.AdminClientType
 	adminClient          kafkaadmin.AdminClientInterface
@@ -57,8 +60,11 @@ type Reconciler struct {
 	kafkachannelInformer cache.SharedIndexInformer
 	deploymentLister     appsv1listers.DeploymentLister
 	serviceLister        corev1listers.ServiceLister
+	namespaceLister      corev1listers.NamespaceLister
+	kafkaSecretLister    corev1listers.SecretLister
 	configObserver       func(configMap *corev1.ConfigMap)
 	adminMutex           *sync.Mutex
+	topicOperations      *topicOperationLimiter
 }
 
 var (
@@ -111,7 +117,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
 
-	// Create A New Kafka AdminClient For Each Reconciliation Attempt
+	// Create A New Kafka AdminClient (For The KafkaChannel's Kafka Secret) For Each Reconciliation Attempt
+	kafkaSecretName, err := r.selectedKafkaSecretName(channel)
+	if err != nil {
+		r.logger.Error("Failed To Select Kafka Secret For KafkaChannel", zap.Any("Channel", channel), zap.Error(err))
+		return err
+	}
+	ctx = kafkaadmin.WithKafkaSecretName(ctx, kafkaSecretName)
 	r.SetKafkaAdminClient(ctx)
 	defer r.ClearKafkaAdminClient()
 
@@ -120,7 +132,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 
 	// Perform The KafkaChannel Reconciliation & Handle Error Response
 	r.logger.Info("Channel Owned By Controller - Reconciling", zap.Any("Channel.Spec", channel.Spec))
-	err := r.reconcile(ctx, channel)
+	err = r.reconcile(ctx, channel)
 	if err != nil {
 		r.logger.Error("Failed To Reconcile KafkaChannel", zap.Any("Channel", channel), zap.Error(err))
 		return err
@@ -144,7 +156,13 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
 
-	// Create A New Kafka AdminClient For Each Reconciliation Attempt
+	// Create A New Kafka AdminClient (For The KafkaChannel's Kafka Secret) For Each Reconciliation Attempt
+	kafkaSecretName, err := r.selectedKafkaSecretName(channel)
+	if err != nil {
+		r.logger.Error("Failed To Select Kafka Secret For KafkaChannel", zap.Any("Channel", channel), zap.Error(err))
+		return err
+	}
+	ctx = kafkaadmin.WithKafkaSecretName(ctx, kafkaSecretName)
 	r.SetKafkaAdminClient(ctx)
 	defer r.ClearKafkaAdminClient()
 
@@ -152,7 +170,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 	topicName := util.TopicName(channel)
 
 	// Delete The Kafka Topic & Handle Error Response
-	err := r.deleteTopic(ctx, topicName)
+	err = r.deleteTopic(ctx, topicName)
 	if err != nil {
 		r.logger.Error("Failed To Finalize KafkaChannel", zap.Any("Channel", channel), zap.Error(err))
 		return err
@@ -169,9 +187,35 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 	// NOTE - The sequential order of reconciliation must be "Topic" then "Channel / Dispatcher" in order for the
 	//        EventHub Cache to know the dynamically determined EventHub Namespace / Kafka Secret selected for the topic.
 
-	// Reconcile The KafkaChannel's Kafka Topic
-	err := r.reconcileTopic(ctx, channel)
+	// Handle Any Deprecated Spec Fields (Migrated Fields Are Persisted With The KafkaChannel's MetaData Below)
+	specModified, err := r.reconcileDeprecatedFields(ctx, channel)
 	if err != nil {
+		return err
+	}
+
+	// Validate Any Requested Ordered Delivery Against The KafkaChannel's Partitions
+	err = r.reconcileDeliveryOrder(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	// Validate Any Delivery Guarantee Against The Kafka Configuration
+	err = r.reconcileDeliveryGuarantee(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	// Wait For The Kafka Secret To Be Created (If Configured) Before Reconciling Anything Else
+	err = r.waitForKafkaSecret(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	// Reconcile The KafkaChannel's Kafka Topic (Requeuing Without Failing When Its Metadata Was Not Fetched In Time)
+	err = r.reconcileTopic(ctx, channel)
+	if errors.Is(err, errTopicMetadataTimeout) {
+		return fmt.Errorf("%w", reconciler.NewEvent(corev1.EventTypeNormal, event.KafkaTopicMetadataTimeout.String(), "Timed Out Fetching Kafka Topic Metadata - Requeued: \"%s/%s\"", channel.Namespace, channel.Name))
+	} else if err != nil {
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
@@ -189,15 +233,16 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
-	// Reconcile The KafkaChannel's Channel & Dispatcher Deployment/Service
+	// Reconcile The KafkaChannel's Channel, (Dedicated) Receiver & Dispatcher Deployment/Service
 	channelError := r.reconcileChannel(ctx, channel)
+	receiverError := r.reconcileReceiver(ctx, channel)
 	dispatcherError := r.reconcileDispatcher(ctx, channel)
-	if channelError != nil || dispatcherError != nil {
+	if channelError != nil || receiverError != nil || dispatcherError != nil {
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
 	// Reconcile The KafkaChannel Itself (MetaData, etc...)
-	err = r.reconcileKafkaChannel(ctx, channel)
+	err = r.reconcileKafkaChannel(ctx, channel, specModified)
 	if err != nil {
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
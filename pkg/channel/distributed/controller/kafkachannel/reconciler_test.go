@@ -18,17 +18,21 @@ package kafkachannel
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
@@ -37,6 +41,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
@@ -424,9 +429,1654 @@ func TestReconcile(t *testing.T) {
 			kafkachannelInformer: nil,
 			deploymentLister:     listers.GetDeploymentLister(),
 			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
 			kafkaClientSet:       fakekafkaclient.Get(ctx),
 			adminMutex:           &sync.Mutex{},
 		}
 		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
 	}, logger.Desugar()))
 }
+
+// Test The Reconcile & Finalize Functionality Performs No Kafka Topic Mutations In Read-Only Mode
+func TestReconcileReadOnly(t *testing.T) {
+
+	// Define The Test Cases (The Full Reconciliation & Finalization Paths)
+	tableTest := TableTest{
+		{
+			Name:                    "Complete Reconciliation Success",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				controllertesting.NewKafkaChannelLabelUpdate(
+					controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaChannelFinalizerUpdateEvent(),
+				controllertesting.NewKafkaChannelSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name: "Finalize Deleted KafkaChannel",
+			Key:  controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithLabels,
+					controllertesting.WithDeletionTimestamp,
+				),
+			},
+			WantEvents: []string{
+				controllertesting.NewKafkaChannelSuccessfulFinalizedEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test (Failing On Any Topic Mutation)
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return newReadOnlyMockAdminClient(t), nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler Configured For Read-Only Mode
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Kafka.ReadOnly = true
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When Fetching The Kafka Topic Metadata Times Out (Requeued With An Unknown Topic)
+func TestReconcileMetadataTimeout(t *testing.T) {
+
+	// Test Data
+	metadataTimeoutMillis := int64(10)
+	withTopicMetadataTimeout := func(kafkachannel *kafkav1beta1.KafkaChannel) {
+		kafkachannel.Status.MarkTopicUnknown(event.KafkaTopicMetadataTimeout.String(), "Channel Kafka Topic Metadata Not Fetched In Time: timed out fetching kafka topic metadata within 10ms")
+	}
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Topic Metadata Timeout",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithInitializedConditions,
+						withTopicMetadataTimeout,
+					),
+				},
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaChannelFinalizerUpdateEvent(),
+				Eventf(corev1.EventTypeNormal, event.KafkaTopicMetadataTimeout.String(), `Timed Out Fetching Kafka Topic Metadata - Requeued: "%s/%s"`, controllertesting.KafkaChannelNamespace, controllertesting.KafkaChannelName),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test (Describing Topics Blocks Until The Test Completes)
+	releaseBroker := make(chan struct{})
+	defer close(releaseBroker)
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		mockAdminClient := newReadOnlyMockAdminClient(t)
+		mockAdminClient.MockDescribeTopicFunc = func(_ context.Context, _ string) *sarama.TopicError {
+			<-releaseBroker
+			return nil
+		}
+		return mockAdminClient, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A Read-Only KafkaChannel Reconciler With A Metadata Timeout
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Kafka.ReadOnly = true
+		config.Kafka.MetadataTimeoutMillis = metadataTimeoutMillis
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Dispatcher Resources Violate A LimitRange
+func TestReconcileLimitRanges(t *testing.T) {
+
+	// Define The Test Cases (Dispatcher Deployment Should Not Be Created)
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment Error(LimitRange)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewLimitRange(),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherLimitRangeFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Deployment: %s", controllertesting.NewDispatcherLimitRangeError()),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With LimitRange Validation Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.ValidateLimitRanges = true
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          environment,
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When Deployment Pods Are Inspected For Containers Failing To Start
+func TestReconcileContainerFailures(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Unavailable Deployments With ImagePullBackOff Pods",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverService(),
+				controllertesting.WithDeploymentUnavailable(controllertesting.NewDedicatedReceiverDeployment()),
+				controllertesting.NewImagePullBackOffPod(controllertesting.NewDedicatedReceiverDeployment()),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.WithDeploymentUnavailable(controllertesting.NewKafkaChannelDispatcherDeployment()),
+				controllertesting.NewImagePullBackOffPod(controllertesting.NewKafkaChannelDispatcherDeployment()),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverContainerFailed,
+						controllertesting.WithDispatcherContainerFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverContainerFailed.String(), "Receiver Deployment Container Failed: %v", controllertesting.NewContainerFailure(controllertesting.NewDedicatedReceiverDeployment())),
+				Eventf(corev1.EventTypeWarning, event.DispatcherContainerFailed.String(), "Dispatcher Deployment Container Failed: %v", controllertesting.NewContainerFailure(controllertesting.NewKafkaChannelDispatcherDeployment())),
+				controllertesting.NewKafkaChannelSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name:                    "Reconcile Available Deployments With ImagePullBackOff Pods",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverService(),
+				controllertesting.NewDedicatedReceiverDeployment(),
+				controllertesting.NewImagePullBackOffPod(controllertesting.NewDedicatedReceiverDeployment()),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+				controllertesting.NewImagePullBackOffPod(controllertesting.NewKafkaChannelDispatcherDeployment()),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With Dedicated Receivers & Deployment Pod Inspection Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.InspectDeploymentPods = true
+		config := controllertesting.NewConfig()
+		config.Receiver.Mode = constants.ReceiverModeChannel
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          environment,
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When VerticalPodAutoscalers Are Enabled
+func TestReconcileVerticalPodAutoscalers(t *testing.T) {
+
+	// Define The Test Cases (Dynamic Client Creates Are Recorded Before Kube Client Creates)
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment With VerticalPodAutoscaler",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelDispatcherVerticalPodAutoscaler(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Existing Dispatcher Deployment Without VerticalPodAutoscaler",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantCreates: []runtime.Object{controllertesting.NewKafkaChannelDispatcherVerticalPodAutoscaler()},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment Without VerticalPodAutoscaler CRD",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{controllertesting.InduceVerticalPodAutoscalerCrdNotFound},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelDispatcherVerticalPodAutoscaler(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With VerticalPodAutoscalers Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.VerticalPodAutoscalers = true
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			dynamicClient:        fakedynamicclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          environment,
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Receiver Is Configured For "channel" Mode (Dedicated Receiver Per KafkaChannel)
+func TestReconcileDedicatedReceiver(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Complete Reconciliation Success",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverService(),
+				controllertesting.NewDedicatedReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				controllertesting.NewKafkaChannelLabelUpdate(
+					controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaChannelFinalizerUpdateEvent(),
+				controllertesting.NewKafkaChannelSuccessfulReconciliationEvent(),
+			},
+		},
+		{
+			Name:                    "Reconcile KafkaChannel Service Referencing Shared Receiver",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverService(),
+				controllertesting.NewDedicatedReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: controllertesting.NewDedicatedReceiverKafkaChannelService()}},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Missing Receiver Deployment Error(Create)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverService(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("create", "Deployments")},
+			WantErr:      true,
+			WantCreates:  []runtime.Object{controllertesting.NewDedicatedReceiverDeployment()},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentFailed,
+						controllertesting.WithDispatcherDeploymentReady,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverDeploymentReconciliationFailed.String(), "Failed To Reconcile Receiver Deployment: inducing failure for create deployments"),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler Configured For Dedicated Receivers
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.Mode = constants.ReceiverModeChannel
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality Of Dedicated Receivers With A Separate Metrics Service & Ingress
+func TestReconcileDedicatedReceiverMetricsServiceAndIngress(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Receiver Metrics Service & Ingress Success",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverHttpService(),
+				controllertesting.NewDedicatedReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewDedicatedReceiverMetricsService(),
+				controllertesting.NewDedicatedReceiverIngress(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Missing Receiver Ingress Error(Create)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewDedicatedReceiverKafkaChannelService(),
+				controllertesting.NewDedicatedReceiverHttpService(),
+				controllertesting.NewDedicatedReceiverMetricsService(),
+				controllertesting.NewDedicatedReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("create", "ingresses")},
+			WantErr:      true,
+			WantCreates:  []runtime.Object{controllertesting.NewDedicatedReceiverIngress()},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverIngressReconciliationFailed.String(), "Failed To Reconcile Receiver Ingress: inducing failure for create ingresses"),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler Configured For Dedicated Receivers With Metrics Service & Ingress
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.Mode = constants.ReceiverModeChannel
+		config.Receiver.SeparateMetricsService = true
+		config.Receiver.Ingress = controllertesting.NewReceiverIngressConfig()
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When Dispatcher Scheduling Constraints Are Configured
+func TestReconcileTopologySpreadConstraints(t *testing.T) {
+
+	// Create The Expected Dispatcher Deployment With The Configured Scheduling Constraints
+	dispatcherDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	dispatcherDeployment.Spec.Template.Spec.TopologySpreadConstraints = controllertesting.NewTopologySpreadConstraints()
+	dispatcherDeployment.Spec.Template.Spec.Affinity = controllertesting.NewAffinity()
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment With TopologySpreadConstraints & Affinity",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WantCreates: []runtime.Object{dispatcherDeployment},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With Dispatcher Scheduling Constraints Configured
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Dispatcher.TopologySpreadConstraints = controllertesting.NewTopologySpreadConstraints()
+		config.Dispatcher.Affinity = controllertesting.NewAffinity()
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When A Dispatcher Shutdown Timeout Is Configured
+func TestReconcileDispatcherTerminationGracePeriod(t *testing.T) {
+
+	// Create The Expected Dispatcher Deployment With A Grace Period Of The Shutdown Timeout (Rounded Up) Plus The Buffer
+	terminationGracePeriodSeconds := int64(56)
+	dispatcherDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	dispatcherDeployment.Spec.Template.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	envVars := dispatcherDeployment.Spec.Template.Spec.Containers[0].Env
+	for i := range envVars {
+		if envVars[i].Name == commonenv.TerminationGracePeriodEnvVarKey {
+			envVars[i].Value = strconv.FormatInt(terminationGracePeriodSeconds, 10)
+		}
+	}
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment With Shutdown Timeout",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WantCreates: []runtime.Object{dispatcherDeployment},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With A Dispatcher Shutdown Timeout Configured
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Dispatcher.ShutdownTimeoutMillis = 45500
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality With Per-KafkaChannel Dispatcher NodeSelector & Affinity Annotations
+func TestReconcileDispatcherSchedulingAnnotations(t *testing.T) {
+
+	// Create The Expected Dispatcher Deployment With The Annotated Scheduling Constraints (Replacing The Configured Affinity)
+	dispatcherDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	dispatcherDeployment.Spec.Template.Spec.NodeSelector = controllertesting.NewDispatcherNodeSelector()
+	dispatcherDeployment.Spec.Template.Spec.Affinity = controllertesting.NewDispatcherPodAffinity()
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment With NodeSelector & Affinity Annotations",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithDispatcherSchedulingAnnotations,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WantCreates: []runtime.Object{dispatcherDeployment},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Missing Dispatcher Deployment Error(Invalid Affinity Annotation)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithInvalidDispatcherAffinityAnnotation,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithInvalidDispatcherAffinityAnnotation,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherAffinityFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Deployment: %s", controllertesting.NewDispatcherAffinityError()),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+		{
+			Name:                    "Reconcile Existing Dispatcher Deployment With Changed NodeSelector & Affinity Annotations",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithDispatcherSchedulingAnnotations,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: dispatcherDeployment}},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Existing Dispatcher Deployment Error(Invalid Affinity Annotation)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithInvalidDispatcherAffinityAnnotation,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithInvalidDispatcherAffinityAnnotation,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherAffinityFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Deployment: %s", controllertesting.NewDispatcherAffinityError()),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using A KafkaChannel Reconciler With A Configured Dispatcher Affinity (Replaced By The Annotation)
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Dispatcher.Affinity = controllertesting.NewAffinity()
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               config,
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality With The Per-KafkaChannel Dispatcher Shards Annotation
+func TestReconcileDispatcherShards(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Sharded KafkaChannel Creates Additional Dispatcher Shards",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithDispatcherShardsAnnotation,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelDispatcherShardService(1),
+				controllertesting.NewKafkaChannelDispatcherShardDeployment(1),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Unsharded KafkaChannel Deletes Excess Dispatcher Shards",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+				controllertesting.NewKafkaChannelDispatcherShardService(1),
+				controllertesting.NewKafkaChannelDispatcherShardDeployment(1),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				controllertesting.NewDeleteActionImpl("services", controllertesting.NewKafkaChannelDispatcherShardService(1).Name),
+				controllertesting.NewDeleteActionImpl("deployments", controllertesting.NewKafkaChannelDispatcherShardDeployment(1).Name),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile KafkaChannel Error(Invalid Dispatcher Shards Annotation)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithInvalidDispatcherShardsAnnotation,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithInvalidDispatcherShardsAnnotation,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherShardsFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Invalid Dispatcher Shards: %s", controllertesting.NewDispatcherShardsError()),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconciliation Of KafkaChannels Labelled As Consumed By An External System (No Dispatcher)
+func TestReconcileExternalDispatcher(t *testing.T) {
+
+	// Get The Name Of The Dispatcher Deployment / Service Which Would Otherwise Be Provisioned
+	dispatcherName := controllertesting.NewKafkaChannelDispatcherDeployment().Name
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Dispatcher-Managed KafkaChannel",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Externally Consumed KafkaChannel",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithExternalDispatcherLabel,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithExternalDispatcherLabel,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithDispatcherNotManaged,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Externally Consumed KafkaChannel With Previously Provisioned Dispatcher",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithExternalDispatcherLabel,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithDispatcherNotManaged,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				controllertesting.NewDeleteActionImpl("services", dispatcherName),
+				controllertesting.NewDeleteActionImpl("deployments", dispatcherName),
+			},
+			WantEvents: []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality Updates Dispatcher Deployments Referencing A Previously Selected Kafka Secret
+func TestReconcileDispatcherKafkaSecretChange(t *testing.T) {
+
+	// Dispatcher Deployment Referencing The Kafka Secret Selected Before The Namespace's Annotation Changed
+	previousKafkaSecretDeployment := func() *appsv1.Deployment {
+		deployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+		for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+			if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+				envVar.ValueFrom.SecretKeyRef.Name = "previous-" + controllertesting.KafkaSecretName
+			}
+		}
+		return deployment
+	}
+
+	// The KafkaChannel Being Reconciled (All Dependencies Ready)
+	newKafkaChannel := func() *kafkav1beta1.KafkaChannel {
+		return controllertesting.NewKafkaChannel(
+			controllertesting.WithFinalizer,
+			controllertesting.WithMetaData,
+			controllertesting.WithAddress,
+			controllertesting.WithInitializedConditions,
+			controllertesting.WithKafkaChannelServiceReady,
+			controllertesting.WithReceiverServiceReady,
+			controllertesting.WithReceiverDeploymentReady,
+			controllertesting.WithDispatcherDeploymentReady,
+			controllertesting.WithTopicReady,
+		)
+	}
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Dispatcher Deployment Referencing Previous Kafka Secret",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				newKafkaChannel(),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				previousKafkaSecretDeployment(),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: controllertesting.NewKafkaChannelDispatcherDeployment()}},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+		{
+			Name:                    "Reconcile Dispatcher Deployment Referencing Previous Kafka Secret Error(Update)",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				newKafkaChannel(),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				previousKafkaSecretDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("update", "deployments")},
+			WantErr:      true,
+			WantUpdates:  []clientgotesting.UpdateActionImpl{{Object: controllertesting.NewKafkaChannelDispatcherDeployment()}},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithFinalizer,
+						controllertesting.WithMetaData,
+						controllertesting.WithAddress,
+						controllertesting.WithInitializedConditions,
+						controllertesting.WithKafkaChannelServiceReady,
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentReady,
+						controllertesting.WithDispatcherUpdateFailed,
+						controllertesting.WithTopicReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Reconcile Dispatcher Deployment: inducing failure for update deployments"),
+				controllertesting.NewKafkaChannelFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Dispatcher Deployment Is Updated When Its TerminationGracePeriodSeconds Differs From The Configuration
+func TestReconcileDispatcherTerminationGracePeriodChange(t *testing.T) {
+
+	// Dispatcher Deployment Created With A Previously Configured Termination Grace Period
+	previousGracePeriodDeployment := controllertesting.NewKafkaChannelDispatcherDeployment()
+	previousGracePeriodSeconds := int64(300)
+	previousGracePeriodDeployment.Spec.Template.Spec.TerminationGracePeriodSeconds = &previousGracePeriodSeconds
+	for i, envVar := range previousGracePeriodDeployment.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == commonenv.TerminationGracePeriodEnvVarKey {
+			previousGracePeriodDeployment.Spec.Template.Spec.Containers[0].Env[i].Value = strconv.FormatInt(previousGracePeriodSeconds, 10)
+		}
+	}
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name:                    "Reconcile Dispatcher Deployment With Previous TerminationGracePeriodSeconds",
+			SkipNamespaceValidation: true,
+			Key:                     controllertesting.KafkaChannelKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithFinalizer,
+					controllertesting.WithMetaData,
+					controllertesting.WithAddress,
+					controllertesting.WithInitializedConditions,
+					controllertesting.WithKafkaChannelServiceReady,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+					controllertesting.WithDispatcherDeploymentReady,
+					controllertesting.WithTopicReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherService(),
+				previousGracePeriodDeployment,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{Object: controllertesting.NewKafkaChannelDispatcherDeployment()}},
+			WantEvents:  []string{controllertesting.NewKafkaChannelSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Mock The Common Kafka AdminClient Creation For Test
+	newKafkaAdminClientWrapperPlaceholder := kafkaadmin.NewKafkaAdminClientWrapper
+	kafkaadmin.NewKafkaAdminClientWrapper = func(ctx context.Context, saramaConfig *sarama.Config, clientId string, namespace string) (kafkaadmin.AdminClientInterface, error) {
+		return &controllertesting.MockAdminClient{}, nil
+	}
+	defer func() {
+		kafkaadmin.NewKafkaAdminClientWrapper = newKafkaAdminClientWrapperPlaceholder
+	}()
+
+	// Run The TableTest Using The KafkaChannel Reconciler Provided By The Factory
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			logger:               logging.FromContext(ctx).Desugar(),
+			kubeClientset:        kubeclient.Get(ctx),
+			adminClientType:      kafkaadmin.Kafka,
+			adminClient:          nil,
+			environment:          controllertesting.NewEnvironment(),
+			config:               controllertesting.NewConfig(),
+			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			kafkachannelInformer: nil,
+			deploymentLister:     listers.GetDeploymentLister(),
+			serviceLister:        listers.GetServiceLister(),
+			namespaceLister:      listers.GetNamespaceLister(),
+			kafkaClientSet:       fakekafkaclient.Get(ctx),
+			adminMutex:           &sync.Mutex{},
+		}
+		return kafkachannelreconciler.NewReconciler(ctx, r.logger.Sugar(), r.kafkaClientSet, listers.GetKafkaChannelLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test That The Receiver & Dispatcher Deployment Builders Reject A Metrics Port Conflicting With Their Fixed Ports
+func TestNewDeploymentsConflictingPorts(t *testing.T) {
+	for _, metricsPort := range []int{constants.HttpContainerPortNumber, constants.HealthPort} {
+		environment := controllertesting.NewEnvironment()
+		environment.MetricsPort = metricsPort
+		reconciler := &Reconciler{logger: logtesting.TestLogger(t).Desugar(), environment: environment, config: controllertesting.NewConfig(), adminClient: &controllertesting.MockAdminClient{}}
+		channel := controllertesting.NewKafkaChannel()
+
+		receiverDeployment, err := reconciler.newReceiverDeployment(channel)
+		assert.Nil(t, receiverDeployment)
+		assert.Contains(t, err.Error(), "port conflict")
+
+		dispatcherDeployment, err := reconciler.newDispatcherDeployment(channel, 0)
+		assert.Nil(t, dispatcherDeployment)
+		assert.Contains(t, err.Error(), "port conflict")
+	}
+}
+
+// Test That The Dispatcher & Receiver Deployments Are Created With The Checksum Of Their Kafka Secret's Brokers (If Known)
+func TestNewDeploymentsKafkaBrokersChecksum(t *testing.T) {
+	for _, secretExists := range []bool{false, true} {
+		var objects []runtime.Object
+		if secretExists {
+			objects = append(objects, controllertesting.NewKafkaSecret())
+		}
+		listers := controllertesting.NewListers(objects)
+		reconciler := &Reconciler{
+			logger:            logtesting.TestLogger(t).Desugar(),
+			environment:       controllertesting.NewEnvironment(),
+			config:            controllertesting.NewConfig(),
+			adminClient:       &controllertesting.MockAdminClient{},
+			kafkaSecretLister: listers.GetSecretLister(),
+		}
+
+		dispatcherDeployment, err := reconciler.newDispatcherDeployment(controllertesting.NewKafkaChannel(), 0)
+		assert.Nil(t, err)
+		receiverDeployment, err := reconciler.newReceiverDeployment(controllertesting.NewKafkaChannel())
+		assert.Nil(t, err)
+		for _, deployment := range []*appsv1.Deployment{dispatcherDeployment, receiverDeployment} {
+			if secretExists {
+				checksum := controllertesting.NewKafkaBrokersChecksum(controllertesting.KafkaSecretDataValueBrokers)
+				assert.Equal(t, checksum, deployment.Annotations[constants.KafkaBrokersChecksumAnnotation])
+				assert.Equal(t, checksum, deployment.Spec.Template.Annotations[constants.KafkaBrokersChecksumAnnotation])
+			} else {
+				assert.Empty(t, deployment.Annotations)
+				assert.Empty(t, deployment.Spec.Template.Annotations)
+			}
+		}
+	}
+}
+
+// Test That The Dispatcher Deployment Only Persists A Subscription Snapshot (In An emptyDir Volume) When Enabled
+func TestNewDispatcherDeploymentSubscriptionSnapshot(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		config := controllertesting.NewConfig()
+		config.Dispatcher.SubscriptionSnapshot.Enabled = enabled
+		reconciler := &Reconciler{
+			logger:      logtesting.TestLogger(t).Desugar(),
+			environment: controllertesting.NewEnvironment(),
+			config:      config,
+			adminClient: &controllertesting.MockAdminClient{},
+		}
+
+		dispatcherDeployment, err := reconciler.newDispatcherDeployment(controllertesting.NewKafkaChannel(), 0)
+		assert.Nil(t, err)
+		podSpec := dispatcherDeployment.Spec.Template.Spec
+		var snapshotEnvVars []string
+		for _, envVar := range podSpec.Containers[0].Env {
+			if envVar.Name == commonenv.SubscriptionSnapshotPathEnvVarKey {
+				snapshotEnvVars = append(snapshotEnvVars, envVar.Value)
+			}
+		}
+		if enabled {
+			assert.Equal(t, []string{"/var/run/eventing-kafka/snapshot/subscriptions.json"}, snapshotEnvVars)
+			assert.Equal(t, []corev1.Volume{{
+				Name:         constants.DispatcherSnapshotVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}, podSpec.Volumes)
+			assert.Equal(t, []corev1.VolumeMount{{
+				Name:      constants.DispatcherSnapshotVolumeName,
+				MountPath: constants.DispatcherSnapshotMountPath,
+			}}, podSpec.Containers[0].VolumeMounts)
+		} else {
+			assert.Empty(t, snapshotEnvVars)
+			assert.Empty(t, podSpec.Volumes)
+			assert.Empty(t, podSpec.Containers[0].VolumeMounts)
+		}
+	}
+}
+
+// Test That The Dispatcher Deployment Only Waits For The ConfigMap At Startup When A Timeout Is Configured
+func TestNewDispatcherDeploymentConfigMapWaitTimeout(t *testing.T) {
+	for _, timeoutMillis := range []int64{0, 30000} {
+		config := controllertesting.NewConfig()
+		config.Dispatcher.ConfigMapWaitTimeoutMillis = timeoutMillis
+		reconciler := &Reconciler{
+			logger:      logtesting.TestLogger(t).Desugar(),
+			environment: controllertesting.NewEnvironment(),
+			config:      config,
+			adminClient: &controllertesting.MockAdminClient{},
+		}
+
+		dispatcherDeployment, err := reconciler.newDispatcherDeployment(controllertesting.NewKafkaChannel(), 0)
+		assert.Nil(t, err)
+		var timeoutEnvVars []string
+		for _, envVar := range dispatcherDeployment.Spec.Template.Spec.Containers[0].Env {
+			if envVar.Name == commonenv.ConfigMapWaitTimeoutEnvVarKey {
+				timeoutEnvVars = append(timeoutEnvVars, envVar.Value)
+			}
+		}
+		if timeoutMillis > 0 {
+			assert.Equal(t, []string{strconv.FormatInt(timeoutMillis, 10)}, timeoutEnvVars)
+		} else {
+			assert.Empty(t, timeoutEnvVars)
+		}
+	}
+}
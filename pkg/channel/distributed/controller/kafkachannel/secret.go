@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	adminutil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/pkg/reconciler"
+)
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var now = time.Now
+
+//
+// Select The Name Of The Kafka Secret For The Specified KafkaChannel
+//
+// Multi-tenant clusters may connect the KafkaChannels of different namespaces to different Kafka clusters, each
+// with its own (labelled) Kafka Secret in the Knative eventing namespace.  The KafkaChannel's namespace selects
+// one of them by name via the KafkaSecretAnnotation, otherwise the configured DefaultSecretName is used.  An empty
+// name selects nothing, leaving the Kafka AdminClient to use the only Kafka Secret as it always has.  Only the Kafka
+// AdminClient supports multiple Kafka Secrets (the EventHub AdminClient manages its own pool of them).  Failure to
+// get the namespace (other than it not existing) is returned rather than falling back to the default, so that the
+// KafkaChannel's Topic is never reconciled against the wrong Kafka cluster.
+//
+func (r *Reconciler) selectedKafkaSecretName(channel *kafkav1beta1.KafkaChannel) (string, error) {
+
+	// Only The Kafka AdminClient Supports Selecting The Kafka Secret
+	if r.adminClientType != kafkaadmin.Kafka {
+		return "", nil
+	}
+
+	// The Configured Default Kafka Secret (If Any)
+	var kafkaSecretName string
+	if r.config != nil {
+		kafkaSecretName = r.config.Kafka.DefaultSecretName
+	}
+
+	// Use The Kafka Secret Selected By The KafkaChannel's Namespace If Annotated
+	namespace, err := r.namespaceLister.Get(channel.Namespace)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get namespace '%s' of KafkaChannel: %w", channel.Namespace, err)
+		}
+	} else if annotatedSecretName := namespace.Annotations[constants.KafkaSecretAnnotation]; len(annotatedSecretName) > 0 {
+		kafkaSecretName = annotatedSecretName
+	}
+
+	return kafkaSecretName, nil
+}
+
+//
+// Create A Namespace EventHandler Which Enqueues The Namespace's KafkaChannels When Its Kafka Secret Annotation Changes
+//
+// The KafkaSecretAnnotation is read from the namespace during each reconciliation of its KafkaChannels, and so
+// changing it only takes effect once they are next reconciled.  Rather than waiting for the next resync, the
+// KafkaChannels of the (watched) namespace are enqueued as soon as the annotation is observed to change.
+//
+func (r *Reconciler) namespaceKafkaSecretEventHandler(watchNamespaces []string, enqueue func(interface{})) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNamespace, oldOk := oldObj.(*corev1.Namespace)
+			newNamespace, newOk := newObj.(*corev1.Namespace)
+			if !oldOk || !newOk || !util.NamespaceInScope(watchNamespaces, newNamespace.Name) ||
+				oldNamespace.Annotations[constants.KafkaSecretAnnotation] == newNamespace.Annotations[constants.KafkaSecretAnnotation] {
+				return
+			}
+			channels, err := r.kafkachannelLister.KafkaChannels(newNamespace.Name).List(labels.Everything())
+			if err != nil {
+				r.logger.Error("Failed To List KafkaChannels Of Namespace With Changed Kafka Secret", zap.String("Namespace", newNamespace.Name), zap.Error(err))
+				return
+			}
+			r.logger.Info("Enqueuing KafkaChannels Of Namespace With Changed Kafka Secret", zap.String("Namespace", newNamespace.Name), zap.Int("Count", len(channels)))
+			for _, channel := range channels {
+				enqueue(channel)
+			}
+		},
+	}
+}
+
+// Get The Checksum Of The Brokers In The Specified Kafka Secret (Empty If The Secret Is Not Yet Known, In Which Case
+// The KafkaSecret Controller Records It On The Deployments Once The Secret Is Reconciled)
+func (r *Reconciler) kafkaBrokersChecksum(kafkaSecretName string) string {
+	if r.kafkaSecretLister == nil || len(kafkaSecretName) <= 0 {
+		return ""
+	}
+	secret, err := r.kafkaSecretLister.Secrets(commonconstants.KnativeEventingNamespace).Get(kafkaSecretName)
+	if err != nil {
+		r.logger.Warn("Failed To Get Kafka Secret - Omitting Kafka Brokers Checksum", zap.String("Secret", kafkaSecretName), zap.Error(err))
+		return ""
+	}
+	return util.KafkaBrokersChecksum(secret)
+}
+
+//
+// Wait For The Kafka Secret(s) To Exist Before Reconciling The Specified KafkaChannel
+//
+// During bootstrap a KafkaChannel may be created before the Kafka Secret it depends upon, in which case every
+// reconciliation would fail until the Secret appears.  When the SecretWaitTimeoutMillis is configured, the absence
+// of any Kafka Secret within that time of the KafkaChannel's creation is instead treated as transient - the
+// ConfigurationReady condition is marked Unknown and the returned error (wrapping a Normal Event) requeues the
+// KafkaChannel with the usual rate-limited backoff.  Once the timeout has elapsed the KafkaChannel is failed as
+// it would have been without waiting.  A nil error indicates reconciliation may proceed.
+//
+func (r *Reconciler) waitForKafkaSecret(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
+
+	// Nothing To Wait For Unless Configured
+	if r.config == nil || r.config.Kafka.SecretWaitTimeoutMillis <= 0 {
+		return nil
+	}
+
+	// Get Channel Specific Logger
+	logger := util.ChannelLogger(r.logger, channel)
+
+	// Look For The Kafka Secret(s) - Any Failure Is Left For The Regular Reconciliation To Report
+	kafkaSecrets, err := adminutil.GetKafkaSecrets(ctx, r.kubeClientset, commonconstants.KnativeEventingNamespace)
+	if err != nil {
+		logger.Warn("Failed To List Kafka Secrets - Skipping Wait For Kafka Secret", zap.Error(err))
+		return nil
+	} else if selectedSecretName := kafkaadmin.KafkaSecretNameFromContext(ctx); len(selectedSecretName) > 0 {
+		for _, kafkaSecret := range kafkaSecrets.Items {
+			if kafkaSecret.Name == selectedSecretName {
+				return nil
+			}
+		}
+	} else if len(kafkaSecrets.Items) > 0 {
+		return nil
+	}
+
+	// Fail The KafkaChannel If The Kafka Secret Has Not Appeared Within The Timeout
+	timeout := time.Duration(r.config.Kafka.SecretWaitTimeoutMillis) * time.Millisecond
+	waited := now().Sub(channel.CreationTimestamp.Time)
+	if waited >= timeout {
+		logger.Error("Kafka Secret Not Created Within Timeout", zap.Duration("Timeout", timeout))
+		channel.Status.MarkConfigFailed(event.KafkaSecretReconciled.String(), "No Kafka Secret For KafkaChannel Within %s Of Its Creation", timeout)
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
+	// Otherwise Wait For The Kafka Secret (Requeue With Backoff)
+	logger.Info("Waiting For Kafka Secret", zap.Duration("Waited", waited), zap.Duration("Timeout", timeout))
+	channel.Status.MarkConfigUnknown(event.KafkaSecretPending.String(), "Waiting For Kafka Secret (Up To %s After KafkaChannel Creation)", timeout)
+	return fmt.Errorf("%w", reconciler.NewEvent(corev1.EventTypeNormal, event.KafkaSecretPending.String(), "Waiting For Kafka Secret: \"%s/%s\"", channel.Namespace, channel.Name))
+}
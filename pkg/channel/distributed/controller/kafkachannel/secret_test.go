@@ -0,0 +1,353 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+)
+
+// Test The Reconciler's selectedKafkaSecretName() Functionality
+func TestSelectedKafkaSecretName(t *testing.T) {
+
+	// Test Data
+	annotatedSecretName := "annotated-kafka-secret"
+	defaultSecretName := "default-kafka-secret"
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name              string
+		adminClientType   kafkaadmin.AdminClientType
+		namespace         *corev1.Namespace
+		namespaceErr      error
+		defaultSecretName string
+		want              string
+		wantErr           bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Namespace", adminClientType: kafkaadmin.Kafka, want: ""},
+		{name: "No Namespace With Default", adminClientType: kafkaadmin.Kafka, defaultSecretName: defaultSecretName, want: defaultSecretName},
+		{name: "Unannotated Namespace", adminClientType: kafkaadmin.Kafka, namespace: newKafkaChannelNamespace(""), want: ""},
+		{name: "Unannotated Namespace With Default", adminClientType: kafkaadmin.Kafka, namespace: newKafkaChannelNamespace(""), defaultSecretName: defaultSecretName, want: defaultSecretName},
+		{name: "Annotated Namespace", adminClientType: kafkaadmin.Kafka, namespace: newKafkaChannelNamespace(annotatedSecretName), want: annotatedSecretName},
+		{name: "Annotated Namespace With Default", adminClientType: kafkaadmin.Kafka, namespace: newKafkaChannelNamespace(annotatedSecretName), defaultSecretName: defaultSecretName, want: annotatedSecretName},
+		{name: "EventHub AdminClient", adminClientType: kafkaadmin.EventHub, namespace: newKafkaChannelNamespace(annotatedSecretName), defaultSecretName: defaultSecretName, want: ""},
+		{name: "Namespace Error With Default", adminClientType: kafkaadmin.Kafka, namespaceErr: errors.New("test namespace error"), defaultSecretName: defaultSecretName, want: "", wantErr: true},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Reconciler To Test
+			var objects []runtime.Object
+			if testCase.namespace != nil {
+				objects = append(objects, testCase.namespace)
+			}
+			listers := controllertesting.NewListers(objects)
+			namespaceLister := listers.GetNamespaceLister()
+			if testCase.namespaceErr != nil {
+				namespaceLister = &errorNamespaceLister{err: testCase.namespaceErr}
+			}
+			config := controllertesting.NewConfig()
+			config.Kafka.DefaultSecretName = testCase.defaultSecretName
+			reconciler := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				namespaceLister: namespaceLister,
+				config:          config,
+				adminClientType: testCase.adminClientType,
+			}
+
+			// Perform The Test & Verify The Results
+			kafkaSecretName, err := reconciler.selectedKafkaSecretName(controllertesting.NewKafkaChannel())
+			assert.Equal(t, testCase.want, kafkaSecretName)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That Reconciliation & Finalization Are Retried (Not Performed With The Default Kafka Secret) If The Namespace Is Unavailable
+func TestReconcileNamespaceError(t *testing.T) {
+
+	// Create The Reconciler To Test With A Failing Namespace Lister
+	config := controllertesting.NewConfig()
+	config.Kafka.DefaultSecretName = "default-kafka-secret"
+	reconciler := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		kubeClientset:   fakekubeclientset.NewSimpleClientset(),
+		namespaceLister: &errorNamespaceLister{err: errors.New("test namespace error")},
+		config:          config,
+		adminClientType: kafkaadmin.Kafka,
+		adminMutex:      &sync.Mutex{},
+	}
+
+	// Perform The Test & Verify Both Return The Error Without Creating A Kafka AdminClient
+	err := reconciler.ReconcileKind(context.TODO(), controllertesting.NewKafkaChannel())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "test namespace error")
+	err = reconciler.FinalizeKind(context.TODO(), controllertesting.NewKafkaChannel())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "test namespace error")
+	assert.Nil(t, reconciler.adminClient)
+}
+
+// Test The Reconciler's namespaceKafkaSecretEventHandler() Functionality
+func TestNamespaceKafkaSecretEventHandler(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name            string
+		watchNamespaces []string
+		oldNamespace    interface{}
+		newNamespace    interface{}
+		wantEnqueued    bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Annotation Added", oldNamespace: newKafkaChannelNamespace(""), newNamespace: newKafkaChannelNamespace("kafka-secret"), wantEnqueued: true},
+		{name: "Annotation Changed", oldNamespace: newKafkaChannelNamespace("kafka-secret-1"), newNamespace: newKafkaChannelNamespace("kafka-secret-2"), wantEnqueued: true},
+		{name: "Annotation Removed", oldNamespace: newKafkaChannelNamespace("kafka-secret"), newNamespace: newKafkaChannelNamespace(""), wantEnqueued: true},
+		{name: "Annotation Unchanged", oldNamespace: newKafkaChannelNamespace("kafka-secret"), newNamespace: newKafkaChannelNamespace("kafka-secret"), wantEnqueued: false},
+		{name: "Watched Namespace", watchNamespaces: []string{controllertesting.KafkaChannelNamespace}, oldNamespace: newKafkaChannelNamespace(""), newNamespace: newKafkaChannelNamespace("kafka-secret"), wantEnqueued: true},
+		{name: "Unwatched Namespace", watchNamespaces: []string{"other-namespace"}, oldNamespace: newKafkaChannelNamespace(""), newNamespace: newKafkaChannelNamespace("kafka-secret"), wantEnqueued: false},
+		{name: "Not A Namespace", oldNamespace: controllertesting.NewKafkaChannel(), newNamespace: controllertesting.NewKafkaChannel(), wantEnqueued: false},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Reconciler To Test
+			listers := controllertesting.NewListers([]runtime.Object{controllertesting.NewKafkaChannel()})
+			reconciler := &Reconciler{
+				logger:             logtesting.TestLogger(t).Desugar(),
+				kafkachannelLister: listers.GetKafkaChannelLister(),
+			}
+
+			// Record The Enqueued KafkaChannels
+			var enqueued []interface{}
+			handler := reconciler.namespaceKafkaSecretEventHandler(testCase.watchNamespaces, func(obj interface{}) {
+				enqueued = append(enqueued, obj)
+			})
+
+			// Perform The Test & Verify The Results
+			handler.OnUpdate(testCase.oldNamespace, testCase.newNamespace)
+			if testCase.wantEnqueued {
+				assert.Len(t, enqueued, 1)
+				assert.Equal(t, controllertesting.KafkaChannelName, enqueued[0].(*kafkav1beta1.KafkaChannel).Name)
+			} else {
+				assert.Empty(t, enqueued)
+			}
+		})
+	}
+}
+
+// Test The Reconciler's waitForKafkaSecret() Functionality
+func TestWaitForKafkaSecret(t *testing.T) {
+
+	// Mock The Current Time
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Test Data
+	timeoutMillis := int64(60000)
+	freshTime := mockNow.Add(-time.Second)
+	staleTime := mockNow.Add(-time.Minute)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name            string
+		timeoutMillis   int64
+		creationTime    time.Time
+		secretExists    bool
+		selectedSecret  string
+		wantErr         bool
+		wantPending     bool
+		wantConfigState corev1.ConditionStatus
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", timeoutMillis: 0, creationTime: staleTime, wantConfigState: corev1.ConditionUnknown},
+		{name: "Secret Exists", timeoutMillis: timeoutMillis, creationTime: freshTime, secretExists: true, wantConfigState: corev1.ConditionUnknown},
+		{name: "Selected Secret Exists", timeoutMillis: timeoutMillis, creationTime: freshTime, secretExists: true, selectedSecret: controllertesting.KafkaSecretName, wantConfigState: corev1.ConditionUnknown},
+		{name: "Selected Secret Pending", timeoutMillis: timeoutMillis, creationTime: freshTime, secretExists: true, selectedSecret: "other-kafka-secret", wantErr: true, wantPending: true, wantConfigState: corev1.ConditionUnknown},
+		{name: "Secret Pending", timeoutMillis: timeoutMillis, creationTime: freshTime, wantErr: true, wantPending: true, wantConfigState: corev1.ConditionUnknown},
+		{name: "Secret Timed Out", timeoutMillis: timeoutMillis, creationTime: staleTime, wantErr: true, wantConfigState: corev1.ConditionFalse},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Reconciler & KafkaChannel To Test
+			reconciler := newSecretWaitReconciler(t, testCase.timeoutMillis, testCase.secretExists)
+			channel := newSecretWaitKafkaChannel(testCase.creationTime)
+
+			// Perform The Test
+			ctx := kafkaadmin.WithKafkaSecretName(context.TODO(), testCase.selectedSecret)
+			err := reconciler.waitForKafkaSecret(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantPending, isKafkaSecretPendingEvent(err))
+			if testCase.wantErr && !testCase.wantPending {
+				assert.Equal(t, constants.ReconciliationFailedError, err.Error())
+			}
+			assert.Equal(t, testCase.wantConfigState, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+		})
+	}
+}
+
+// Test The Reconciler's waitForKafkaSecret() Functionality Over Successive Reconciliations
+func TestWaitForKafkaSecretSequence(t *testing.T) {
+
+	// Mock The Current Time (Advanced By The Test)
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name         string
+		secretAppear bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Secret Appears In Time", secretAppear: true},
+		{name: "Secret Never Appears", secretAppear: false},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Reconciler & A KafkaChannel Created "Now"
+			creationTime := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+			mockNow = creationTime
+			reconciler := newSecretWaitReconciler(t, 60000, false)
+			channel := newSecretWaitKafkaChannel(creationTime)
+
+			// Verify Initial Reconciliations Wait For The Kafka Secret
+			for _, elapsed := range []time.Duration{0, time.Second, 30 * time.Second} {
+				mockNow = creationTime.Add(elapsed)
+				channel.Status.InitializeConditions()
+				assert.True(t, isKafkaSecretPendingEvent(reconciler.waitForKafkaSecret(context.TODO(), channel)))
+				assert.Equal(t, event.KafkaSecretPending.String(), channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Reason)
+			}
+
+			// Optionally Create The Kafka Secret Before The Timeout
+			if testCase.secretAppear {
+				_, err := reconciler.kubeClientset.CoreV1().Secrets(controllertesting.KafkaSecretNamespace).Create(context.TODO(), newLabelledKafkaSecret(), metav1.CreateOptions{})
+				assert.Nil(t, err)
+			}
+
+			// Verify The Subsequent Reconciliation After The Timeout Proceeds Or Fails
+			mockNow = creationTime.Add(time.Minute)
+			channel.Status.InitializeConditions()
+			err := reconciler.waitForKafkaSecret(context.TODO(), channel)
+			if testCase.secretAppear {
+				assert.Nil(t, err)
+				assert.Equal(t, corev1.ConditionUnknown, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+			} else {
+				assert.NotNil(t, err)
+				assert.False(t, isKafkaSecretPendingEvent(err))
+				assert.Equal(t, corev1.ConditionFalse, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionConfigReady).Status)
+			}
+		})
+	}
+}
+
+// Utility Function For Creating A Reconciler With The Specified Secret Wait Timeout & Optional Kafka Secret
+func newSecretWaitReconciler(t *testing.T, timeoutMillis int64, secretExists bool) *Reconciler {
+	kubeClientset := fakekubeclientset.NewSimpleClientset()
+	if secretExists {
+		kubeClientset = fakekubeclientset.NewSimpleClientset(newLabelledKafkaSecret())
+	}
+	config := controllertesting.NewConfig()
+	config.Kafka.SecretWaitTimeoutMillis = timeoutMillis
+	return &Reconciler{
+		logger:        logtesting.TestLogger(t).Desugar(),
+		kubeClientset: kubeClientset,
+		config:        config,
+	}
+}
+
+// Utility Function For Creating A KafkaChannel With Initialized (Unknown) Conditions & The Specified Creation Time
+func newSecretWaitKafkaChannel(creationTime time.Time) *kafkav1beta1.KafkaChannel {
+	channel := controllertesting.NewKafkaChannel()
+	channel.Status.InitializeConditions()
+	channel.CreationTimestamp = metav1.NewTime(creationTime)
+	return channel
+}
+
+// Utility Function For Creating A Kafka Secret With The Label Identifying It As Such
+func newLabelledKafkaSecret() *corev1.Secret {
+	return controllertesting.NewKafkaSecret(func(secret *corev1.Secret) {
+		secret.Labels = map[string]string{kafkaconstants.KafkaSecretLabel: "true"}
+	})
+}
+
+// Utility Function For Creating The KafkaChannel's Namespace, Optionally Annotated With The Specified Kafka Secret
+func newKafkaChannelNamespace(kafkaSecretName string) *corev1.Namespace {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: controllertesting.KafkaChannelNamespace}}
+	if len(kafkaSecretName) > 0 {
+		namespace.Annotations = map[string]string{constants.KafkaSecretAnnotation: kafkaSecretName}
+	}
+	return namespace
+}
+
+// NamespaceLister Which Fails To Get Any Namespace With The Specified Error
+type errorNamespaceLister struct {
+	corev1listers.NamespaceLister
+	err error
+}
+
+func (l *errorNamespaceLister) Get(_ string) (*corev1.Namespace, error) {
+	return nil, l.err
+}
+
+// Utility Function For Determining Whether The Specified Error Wraps A Normal KafkaSecretPending Event
+func isKafkaSecretPendingEvent(err error) bool {
+	var reconcilerEvent *reconciler.ReconcilerEvent
+	return reconciler.EventAs(err, &reconcilerEvent) &&
+		reconcilerEvent.EventType == corev1.EventTypeNormal &&
+		reconcilerEvent.Reason == event.KafkaSecretPending.String()
+}
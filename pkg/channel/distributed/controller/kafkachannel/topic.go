@@ -18,19 +18,26 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/pkg/controller"
 )
 
+// The Error Returned When The Kafka Topic Metadata Is Not Fetched Within The Configured MetadataTimeoutMillis
+var errTopicMetadataTimeout = errors.New("timed out fetching kafka topic metadata")
+
 // Reconcile The Kafka Topic Associated With The Specified Channel & Return The Kafka Secret
 func (r *Reconciler) reconcileTopic(ctx context.Context, channel *kafkav1beta1.KafkaChannel) error {
 
@@ -44,12 +51,40 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *kafkav1beta1.K
 	numPartitions := util.NumPartitions(channel, r.config, r.logger)
 	replicationFactor := util.ReplicationFactor(channel, r.config, r.logger)
 	retentionMillis := util.RetentionMillis(channel, r.config, r.logger)
+	configEntries, _ := delivery.TopicConfigEntries(channel.DeliveryGuarantee()) // Validated By reconcileDeliveryGuarantee()
 
-	// Create The Topic (Handles Case Where Already Exists)
-	err := r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	// Create The Topic (Handles Case Where Already Exists), Or Only Verify It Exists When Topic Mutations Are Disabled
+	var err error
+	created := false
+	if r.config.Kafka.ReadOnly {
+		err = r.verifyTopic(ctx, topicName)
+	} else if err = r.validateTopicSettings(channel, numPartitions, retentionMillis); err == nil {
+		created, err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis, configEntries)
 
-	// Log Results & Return Status
-	if err != nil {
+		// Apply Any Change In The Partitions Or Retention Of An Existing Topic (Only Supported By The Kafka AdminClient)
+		if err == nil && !created && r.adminClientType == kafkaadmin.Kafka {
+			err = r.reconcileTopicPartitions(ctx, channel, topicName, numPartitions)
+			if err == nil {
+				err = r.reconcileTopicRetention(ctx, channel, topicName, retentionMillis)
+			}
+		}
+	}
+
+	// Warm Up The Partitions Of Newly Created Topics (If Enabled) - Failures Only Delay The First Events So Are Not Fatal
+	if err == nil && created && r.config.Kafka.Topic.Warmup.Enabled {
+		warmupErr := r.warmupTopic(ctx, topicName, numPartitions)
+		if warmupErr != nil {
+			controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicWarmupFailed.String(), "Failed To Warm Up Kafka Topic Partitions For Channel: %v", warmupErr)
+		} else {
+			controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeNormal, event.KafkaTopicWarmedUp.String(), "Warmed Up %d Kafka Topic Partitions For Channel", numPartitions)
+		}
+	}
+
+	// Log Results & Return Status (A Metadata Timeout Leaves The Topic Condition Unknown Until The Requeued Reconciliation)
+	if errors.Is(err, errTopicMetadataTimeout) {
+		logger.Warn("Timed Out Fetching Topic Metadata", zap.Error(err))
+		channel.Status.MarkTopicUnknown(event.KafkaTopicMetadataTimeout.String(), "Channel Kafka Topic Metadata Not Fetched In Time: %s", err)
+	} else if err != nil {
 		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicReconciliationFailed.String(), "Failed To Reconcile Kafka Topic For Channel: %v", err)
 		logger.Error("Failed To Reconcile Topic", zap.Error(err))
 		channel.Status.MarkTopicFailed("TopicFailed", fmt.Sprintf("Channel Kafka Topic Failed: %s", err))
@@ -60,8 +95,8 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *kafkav1beta1.K
 	return err
 }
 
-// Create The Specified Kafka Topic
-func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, retentionMillis int64) error {
+// Create The Specified Kafka Topic (With Any Additional Config Entries) & Return Whether It Was Newly Created (As Opposed To Already Existing)
+func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, retentionMillis int64, configEntries map[string]string) (bool, error) {
 
 	// Setup The Logger
 	logger := r.logger.With(zap.String("Topic", topicName))
@@ -76,6 +111,17 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 			constants.KafkaTopicConfigRetentionMs: &retentionMillisString,
 		},
 	}
+	for name, value := range configEntries {
+		value := value
+		topicDetail.ConfigEntries[name] = &value
+	}
+
+	// Wait For The Kafka Cluster's Capacity For Concurrent Topic Operations
+	if limitErr := r.topicOperations.acquire(ctx); limitErr != nil {
+		logger.Error("Failed Waiting To Perform Topic Operation", zap.Error(limitErr))
+		return false, limitErr
+	}
+	defer r.topicOperations.release()
 
 	// Attempt To Create The Topic & Process TopicError Results (Including Success ;)
 	err := r.adminClient.CreateTopic(ctx, topicName, topicDetail)
@@ -83,18 +129,256 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 		switch err.Err {
 		case sarama.ErrNoError:
 			logger.Info("Successfully Created New Kafka Topic (ErrNoError)")
-			return nil
+			return true, nil
 		case sarama.ErrTopicAlreadyExists:
 			logger.Info("Kafka Topic Already Exists - No Creation Required")
-			return nil
+			return false, nil
 		default:
 			logger.Error("Failed To Create Topic", zap.Any("TopicError", err))
-			return err
+			return false, err
 		}
 	} else {
 		logger.Info("Successfully Created New Kafka Topic (Nil TopicError)")
+		return true, nil
+	}
+}
+
+// Validate A KafkaChannel's Topic Settings Against The Configured Bounds Before Creating (Or Altering) Its Topic
+func (r *Reconciler) validateTopicSettings(channel *kafkav1beta1.KafkaChannel, numPartitions int32, retentionMillis int64) error {
+	if err := r.validateNumPartitions(numPartitions); err != nil {
+		return err
+	}
+	return r.validateRetentionMillis(channel, retentionMillis)
+}
+
+// Validate A KafkaChannel's Partitions Against The Configured Maximum (Which Should Mirror Any Cluster Partition Limit)
+func (r *Reconciler) validateNumPartitions(numPartitions int32) error {
+	maxNumPartitions := r.config.Kafka.Topic.MaxNumPartitions
+	if maxNumPartitions > 0 && numPartitions > maxNumPartitions {
+		return fmt.Errorf("numPartitions %d must not exceed %d (kafka.topic.maxNumPartitions)", numPartitions, maxNumPartitions)
+	}
+	return nil
+}
+
+// Validate A KafkaChannel's Retention Against The Configured Bounds (Which Should Mirror Any Broker retention.ms Policy)
+func (r *Reconciler) validateRetentionMillis(channel *kafkav1beta1.KafkaChannel, retentionMillis int64) error {
+	if channel.Spec.RetentionMillis <= 0 {
+		return nil // The Configured Default Was Validated At Startup
+	}
+	maxRetentionMillis := int64(constants.MaxTopicRetentionMillis)
+	if r.config.Kafka.Topic.MaxRetentionMillis > 0 && r.config.Kafka.Topic.MaxRetentionMillis < maxRetentionMillis {
+		maxRetentionMillis = r.config.Kafka.Topic.MaxRetentionMillis
+	}
+	if retentionMillis < r.config.Kafka.Topic.MinRetentionMillis || retentionMillis > maxRetentionMillis {
+		return fmt.Errorf("retentionMillis %d must be between %d and %d", retentionMillis, r.config.Kafka.Topic.MinRetentionMillis, maxRetentionMillis)
+	}
+	return nil
+}
+
+//
+// Alter The Retention Of The Specified Existing Kafka Topic If It Differs From The KafkaChannel's
+//
+// The retention.ms of a Topic is only set when it is created, so a subsequent change to the KafkaChannel's
+// RetentionMillis (or to the configured default for KafkaChannels without one) would otherwise be ignored.  Sarama's
+// AlterConfig() replaces all of a Topic's config overrides, so the existing overrides (e.g. those of the delivery
+// guarantee, or any applied by an administrator) are described first and carried over with only the retention.ms
+// replaced.  A Normal Event is recorded on the KafkaChannel when the retention is altered.
+//
+func (r *Reconciler) reconcileTopicRetention(ctx context.Context, channel *kafkav1beta1.KafkaChannel, topicName string, retentionMillis int64) error {
+
+	// Setup The Logger
+	logger := r.logger.With(zap.String("Topic", topicName))
+
+	// Describe The Topic's Current Config Overrides & Compare The Retention
+	var topicConfig map[string]string
+	var describeErr *sarama.TopicError
+	if fetchErr := r.fetchTopicMetadata(ctx, func(fetchCtx context.Context, adminClient kafkaadmin.AdminClientInterface) {
+		topicConfig, describeErr = adminClient.DescribeTopicConfig(fetchCtx, topicName)
+	}); fetchErr != nil {
+		logger.Error("Failed To Fetch Topic Config", zap.Error(fetchErr))
+		return fetchErr
+	}
+	if describeErr != nil && describeErr.Err != sarama.ErrNoError {
+		logger.Error("Failed To Describe Topic Config", zap.Any("TopicError", describeErr))
+		return describeErr
+	}
+	retentionMillisString := strconv.FormatInt(retentionMillis, 10)
+	currentRetentionMillisString, found := topicConfig[constants.KafkaTopicConfigRetentionMs]
+	if found && currentRetentionMillisString == retentionMillisString {
 		return nil
 	}
+
+	// Wait For The Kafka Cluster's Capacity For Concurrent Topic Operations
+	if limitErr := r.topicOperations.acquire(ctx); limitErr != nil {
+		logger.Error("Failed Waiting To Perform Topic Operation", zap.Error(limitErr))
+		return limitErr
+	}
+	defer r.topicOperations.release()
+
+	// Alter The Topic Config, Carrying Over All Other Overrides
+	configEntries := make(map[string]*string, len(topicConfig)+1)
+	for name, value := range topicConfig {
+		value := value
+		configEntries[name] = &value
+	}
+	configEntries[constants.KafkaTopicConfigRetentionMs] = &retentionMillisString
+	alterErr := r.adminClient.AlterTopicConfig(ctx, topicName, configEntries)
+	if alterErr != nil && alterErr.Err != sarama.ErrNoError {
+		logger.Error("Failed To Alter Topic Retention", zap.Any("TopicError", alterErr))
+		return alterErr
+	}
+
+	// Record The Retention Change
+	logger.Info("Altered Kafka Topic Retention", zap.String("Previous", currentRetentionMillisString), zap.String("Current", retentionMillisString))
+	controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeNormal, event.KafkaTopicRetentionUpdated.String(), "Updated Kafka Topic Retention To %s Milliseconds", retentionMillisString)
+	return nil
+}
+
+//
+// Increase The Partitions Of The Specified Existing Kafka Topic If The KafkaChannel Now Requires More
+//
+// The partitions of a Topic are only set when it is created, so a subsequent increase in the KafkaChannel's
+// NumPartitions would otherwise be ignored.  Kafka does not support removing the partitions of a Topic though, so a
+// decrease is reported with a Warning Event and returned as an error (failing the Topic condition) until the
+// KafkaChannel's NumPartitions is restored.  A Normal Event is recorded on the KafkaChannel when partitions are added.
+//
+func (r *Reconciler) reconcileTopicPartitions(ctx context.Context, channel *kafkav1beta1.KafkaChannel, topicName string, numPartitions int32) error {
+
+	// Setup The Logger
+	logger := r.logger.With(zap.String("Topic", topicName))
+
+	// Describe The Topic's Current Partitions & Compare With The KafkaChannel's
+	var currentNumPartitions int32
+	var describeErr *sarama.TopicError
+	if fetchErr := r.fetchTopicMetadata(ctx, func(fetchCtx context.Context, adminClient kafkaadmin.AdminClientInterface) {
+		currentNumPartitions, describeErr = adminClient.DescribeTopicPartitions(fetchCtx, topicName)
+	}); fetchErr != nil {
+		logger.Error("Failed To Fetch Topic Partitions", zap.Error(fetchErr))
+		return fetchErr
+	}
+	if describeErr != nil && describeErr.Err != sarama.ErrNoError {
+		logger.Error("Failed To Describe Topic Partitions", zap.Any("TopicError", describeErr))
+		return describeErr
+	}
+	if currentNumPartitions == numPartitions {
+		return nil
+	} else if currentNumPartitions > numPartitions {
+		logger.Warn("Unable To Decrease Kafka Topic Partitions", zap.Int32("Current", currentNumPartitions), zap.Int32("Requested", numPartitions))
+		controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeWarning, event.KafkaTopicPartitionsDecreaseRejected.String(), "Unable To Decrease Kafka Topic Partitions From %d To %d (Kafka Does Not Support Removing Partitions)", currentNumPartitions, numPartitions)
+		return fmt.Errorf("numPartitions cannot be decreased from %d to %d", currentNumPartitions, numPartitions)
+	}
+
+	// Wait For The Kafka Cluster's Capacity For Concurrent Topic Operations
+	if limitErr := r.topicOperations.acquire(ctx); limitErr != nil {
+		logger.Error("Failed Waiting To Perform Topic Operation", zap.Error(limitErr))
+		return limitErr
+	}
+	defer r.topicOperations.release()
+
+	// Increase The Topic's Partitions
+	createErr := r.adminClient.CreatePartitions(ctx, topicName, numPartitions)
+	if createErr != nil && createErr.Err != sarama.ErrNoError {
+		logger.Error("Failed To Increase Topic Partitions", zap.Any("TopicError", createErr))
+		return createErr
+	}
+
+	// Record The Partitions Change
+	logger.Info("Increased Kafka Topic Partitions", zap.Int32("Previous", currentNumPartitions), zap.Int32("Current", numPartitions))
+	controller.GetEventRecorder(ctx).Eventf(channel, corev1.EventTypeNormal, event.KafkaTopicPartitionsIncreased.String(), "Increased Kafka Topic Partitions From %d To %d", currentNumPartitions, numPartitions)
+	return nil
+}
+
+// Verify The Specified Kafka Topic Exists (Without Creating Or Modifying It)
+func (r *Reconciler) verifyTopic(ctx context.Context, topicName string) error {
+
+	// Setup The Logger
+	logger := r.logger.With(zap.String("Topic", topicName))
+
+	// Attempt To Describe The Topic & Process TopicError Results (Including Success ;)
+	var err *sarama.TopicError
+	if fetchErr := r.fetchTopicMetadata(ctx, func(fetchCtx context.Context, adminClient kafkaadmin.AdminClientInterface) {
+		err = adminClient.DescribeTopic(fetchCtx, topicName)
+	}); fetchErr != nil {
+		logger.Error("Failed To Fetch Topic Metadata", zap.Error(fetchErr))
+		return fetchErr
+	}
+	if err != nil {
+		switch err.Err {
+		case sarama.ErrNoError:
+			logger.Info("Verified Existing Kafka Topic (Read-Only Mode)")
+			return nil
+		case sarama.ErrUnknownTopicOrPartition:
+			logger.Warn("Kafka Topic Not Found - Creation Disabled In Read-Only Mode")
+			return fmt.Errorf("kafka topic '%s' does not exist and cannot be created in read-only mode", topicName)
+		default:
+			logger.Error("Failed To Verify Topic", zap.Any("TopicError", err))
+			return err
+		}
+	} else {
+		logger.Info("Verified Existing Kafka Topic (Nil TopicError)")
+		return nil
+	}
+}
+
+//
+// Fetch Kafka Topic Metadata (e.g. Describing A Topic) Within The Configured MetadataTimeoutMillis
+//
+// The Sarama ClusterAdmin blocks until the brokers respond (or its own, much longer, admin timeout elapses), and as
+// reconciliations share the Kafka AdminClient (and the limited Topic operation slots) a slow broker would otherwise
+// hold up every KafkaChannel's reconciliation.  When the MetadataTimeoutMillis is configured, the specified fetch
+// (including waiting for a Topic operation slot) is abandoned once it elapses, and an error wrapping
+// errTopicMetadataTimeout is returned so that the KafkaChannel is requeued.  The abandoned fetch continues to hold its
+// Topic operation slot until the broker responds, so that slow brokers are not sent ever more concurrent requests.
+// The fetch function must only set its results (which are not to be used unless a nil error is returned) using the
+// specified AdminClient, which remains valid for an abandoned fetch after the Reconciler's AdminClient is cleared.
+//
+func (r *Reconciler) fetchTopicMetadata(ctx context.Context, fetch func(fetchCtx context.Context, adminClient kafkaadmin.AdminClientInterface)) error {
+
+	// Bound The Fetch By The Metadata Timeout (If Configured)
+	fetchCtx := ctx
+	var timeout time.Duration
+	if r.config != nil && r.config.Kafka.MetadataTimeoutMillis > 0 {
+		timeout = time.Duration(r.config.Kafka.MetadataTimeoutMillis) * time.Millisecond
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Wait For The Kafka Cluster's Capacity For Concurrent Topic Operations
+	if limitErr := r.topicOperations.acquire(fetchCtx); limitErr != nil {
+		return r.topicMetadataError(ctx, limitErr, timeout)
+	}
+
+	// Perform The Fetch Asynchronously, Releasing The Topic Operation Slot Only Once It Has Completed
+	adminClient := r.adminClient
+	topicOperations := r.topicOperations
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer topicOperations.release()
+		fetch(fetchCtx, adminClient)
+	}()
+
+	// Wait For The Fetch To Complete Or Be Abandoned
+	select {
+	case <-done:
+		return nil
+	case <-fetchCtx.Done():
+		select {
+		case <-done:
+			return nil // Completed Just In Time
+		default:
+			return r.topicMetadataError(ctx, fetchCtx.Err(), timeout)
+		}
+	}
+}
+
+// Get The Error For A Topic Metadata Fetch Which Was Abandoned Due To The Specified Context Error
+func (r *Reconciler) topicMetadataError(ctx context.Context, err error, timeout time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w within %s", errTopicMetadataTimeout, timeout)
+	}
+	return err
 }
 
 // Delete The Specified Kafka Topic
@@ -103,6 +387,19 @@ func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 	// Setup The Logger
 	logger := r.logger.With(zap.String("Topic", topicName))
 
+	// Leave The Topic For Its Owner To Delete When Topic Mutations Are Disabled
+	if r.config.Kafka.ReadOnly {
+		logger.Info("Skipping Kafka Topic Deletion (Read-Only Mode)")
+		return nil
+	}
+
+	// Wait For The Kafka Cluster's Capacity For Concurrent Topic Operations
+	if limitErr := r.topicOperations.acquire(ctx); limitErr != nil {
+		logger.Error("Failed Waiting To Perform Topic Operation", zap.Error(limitErr))
+		return limitErr
+	}
+	defer r.topicOperations.release()
+
 	// Attempt To Delete The Topic & Process Results
 	err := r.adminClient.DeleteTopic(ctx, topicName)
 	if err != nil {
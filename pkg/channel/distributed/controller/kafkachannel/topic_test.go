@@ -18,15 +18,24 @@ package kafkachannel
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1alpha1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
 	"knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -52,6 +61,9 @@ type TopicTestCase struct {
 //
 func TestReconcileTopic(t *testing.T) {
 
+	// Test Data
+	uncleanLeaderElectionDisabled := "false"
+
 	// Define & Initialize The TopicTestCases
 	topicTestCases := []TopicTestCase{
 		{
@@ -88,6 +100,45 @@ func TestReconcileTopic(t *testing.T) {
 				ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
 			},
 		},
+		{
+			Name: "Create New Topic With Retention",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithRetentionMillis,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
+			},
+		},
+		{
+			Name: "Create New Topic With Delivery Guarantee",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+				controllertesting.WithAtLeastOnceDeliveryGuarantee,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries: map[string]*string{
+					constants.KafkaTopicConfigRetentionMs:     &controllertesting.DefaultRetentionMillisString,
+					delivery.TopicConfigUncleanLeaderElection: &uncleanLeaderElectionDisabled,
+				},
+			},
+		},
 		{
 			Name: "Create Preexisting Topic",
 			Channel: controllertesting.NewKafkaChannel(
@@ -182,6 +233,696 @@ func TestReconcileTopic(t *testing.T) {
 	}
 }
 
+// Test The Kafka Topic Retention Reconciliation Of Existing Topics
+func TestReconcileTopicRetention(t *testing.T) {
+
+	// Test Data
+	cleanupPolicy := "delete"
+	outOfBoundsRetentionMillis := int64(controllertesting.RetentionMillis * 2)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name             string
+		adminClientType  kafkaadmin.AdminClientType
+		retentionMillis  int64
+		topicConfig      map[string]string
+		describeErr      sarama.KError
+		alterErr         sarama.KError
+		wantCreate       bool
+		wantAlterEntries map[string]*string
+		wantErr          bool
+		wantEvent        bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:        "Unchanged Default Retention",
+			topicConfig: map[string]string{constants.KafkaTopicConfigRetentionMs: controllertesting.DefaultRetentionMillisString},
+			wantCreate:  true,
+		},
+		{
+			name:            "Unchanged Retention",
+			retentionMillis: controllertesting.RetentionMillis,
+			topicConfig:     map[string]string{constants.KafkaTopicConfigRetentionMs: controllertesting.RetentionMillisString},
+			wantCreate:      true,
+		},
+		{
+			name:             "Changed Retention",
+			retentionMillis:  controllertesting.RetentionMillis,
+			topicConfig:      map[string]string{constants.KafkaTopicConfigRetentionMs: controllertesting.DefaultRetentionMillisString, "cleanup.policy": cleanupPolicy},
+			wantCreate:       true,
+			wantAlterEntries: map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString, "cleanup.policy": &cleanupPolicy},
+			wantEvent:        true,
+		},
+		{
+			name:             "Missing Retention",
+			topicConfig:      map[string]string{},
+			wantCreate:       true,
+			wantAlterEntries: map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+			wantEvent:        true,
+		},
+		{
+			name:            "Describe Error",
+			retentionMillis: controllertesting.RetentionMillis,
+			describeErr:     sarama.ErrBrokerNotAvailable,
+			wantCreate:      true,
+			wantErr:         true,
+		},
+		{
+			name:             "Alter Error",
+			retentionMillis:  controllertesting.RetentionMillis,
+			topicConfig:      map[string]string{},
+			alterErr:         sarama.ErrPolicyViolation,
+			wantCreate:       true,
+			wantAlterEntries: map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
+			wantErr:          true,
+		},
+		{
+			name:            "Retention Out Of Bounds",
+			retentionMillis: outOfBoundsRetentionMillis,
+			wantErr:         true,
+		},
+		{
+			name:            "EventHub AdminClient",
+			adminClientType: kafkaadmin.EventHub,
+			retentionMillis: controllertesting.RetentionMillis,
+			wantCreate:      true,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Of A Preexisting Topic With The TestCase's Config
+			createCalled := false
+			var alterEntries map[string]*string
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(_ context.Context, _ string, _ *sarama.TopicDetail) *sarama.TopicError {
+					createCalled = true
+					return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+				},
+				MockDescribeTopicConfigFunc: func(_ context.Context, topicName string) (map[string]string, *sarama.TopicError) {
+					assert.NotEqual(t, kafkaadmin.EventHub, testCase.adminClientType)
+					assert.Equal(t, controllertesting.TopicName, topicName)
+					return testCase.topicConfig, &sarama.TopicError{Err: testCase.describeErr}
+				},
+				MockAlterTopicConfigFunc: func(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+					assert.Equal(t, controllertesting.TopicName, topicName)
+					alterEntries = configEntries
+					return &sarama.TopicError{Err: testCase.alterErr}
+				},
+			}
+
+			// Create The Reconciler To Test (Bounding The Retention Between The Default & Channel Retention)
+			config := controllertesting.NewConfig()
+			config.Kafka.Topic.MaxRetentionMillis = controllertesting.RetentionMillis
+			reconciler := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				adminClient:     mockAdminClient,
+				adminClientType: testCase.adminClientType,
+				config:          config,
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			channel.Spec.RetentionMillis = testCase.retentionMillis
+			err := reconciler.reconcileTopic(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantCreate, createCalled)
+			assert.Equal(t, testCase.wantAlterEntries, alterEntries)
+			assert.Equal(t, !testCase.wantErr, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).IsTrue())
+			events := 0
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, event.KafkaTopicRetentionUpdated.String()) {
+					events++
+				}
+			}
+			assert.Equal(t, testCase.wantEvent, events == 1)
+		})
+	}
+}
+
+// Test The Reconciliation Of The Partitions Of Preexisting Kafka Topics
+func TestReconcileTopicPartitions(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name             string
+		adminClientType  kafkaadmin.AdminClientType
+		topicPartitions  int32
+		describeErr      sarama.KError
+		createErr        sarama.KError
+		wantDescribe     bool
+		wantCreateCount  int32
+		wantErr          bool
+		wantEvent        event.CoreV1EventType
+		wantEventMessage string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:            "Unchanged Partitions",
+			topicPartitions: controllertesting.NumPartitions,
+			wantDescribe:    true,
+		},
+		{
+			name:             "Increased Partitions",
+			topicPartitions:  controllertesting.NumPartitions - 3,
+			wantDescribe:     true,
+			wantCreateCount:  controllertesting.NumPartitions,
+			wantEvent:        event.KafkaTopicPartitionsIncreased,
+			wantEventMessage: "Increased Kafka Topic Partitions From 120 To 123",
+		},
+		{
+			name:             "Decreased Partitions",
+			topicPartitions:  controllertesting.NumPartitions + 1,
+			wantDescribe:     true,
+			wantErr:          true,
+			wantEvent:        event.KafkaTopicPartitionsDecreaseRejected,
+			wantEventMessage: "Unable To Decrease Kafka Topic Partitions From 124 To 123",
+		},
+		{
+			name:         "Describe Error",
+			describeErr:  sarama.ErrBrokerNotAvailable,
+			wantDescribe: true,
+			wantErr:      true,
+		},
+		{
+			name:            "Create Partitions Error",
+			topicPartitions: 1,
+			createErr:       sarama.ErrPolicyViolation,
+			wantDescribe:    true,
+			wantCreateCount: controllertesting.NumPartitions,
+			wantErr:         true,
+		},
+		{
+			name:            "EventHub AdminClient",
+			adminClientType: kafkaadmin.EventHub,
+			topicPartitions: 1,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Of A Preexisting Topic With The TestCase's Partitions
+			describeCalled := false
+			createCount := int32(0)
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(_ context.Context, _ string, _ *sarama.TopicDetail) *sarama.TopicError {
+					return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+				},
+				MockDescribeTopicPartitionsFunc: func(_ context.Context, topicName string) (int32, *sarama.TopicError) {
+					assert.Equal(t, controllertesting.TopicName, topicName)
+					describeCalled = true
+					return testCase.topicPartitions, &sarama.TopicError{Err: testCase.describeErr}
+				},
+				MockCreatePartitionsFunc: func(_ context.Context, topicName string, count int32) *sarama.TopicError {
+					assert.Equal(t, controllertesting.TopicName, topicName)
+					createCount = count
+					return &sarama.TopicError{Err: testCase.createErr}
+				},
+			}
+
+			// Create The Reconciler To Test
+			reconciler := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				adminClient:     mockAdminClient,
+				adminClientType: testCase.adminClientType,
+				config:          controllertesting.NewConfig(),
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			err := reconciler.reconcileTopic(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantDescribe, describeCalled)
+			assert.Equal(t, testCase.wantCreateCount, createCount)
+			assert.Equal(t, !testCase.wantErr, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).IsTrue())
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			if testCase.wantEventMessage != "" {
+				assert.Contains(t, strings.Join(events, "\n"), testCase.wantEvent.String()+" "+testCase.wantEventMessage)
+			} else {
+				assert.NotContains(t, strings.Join(events, "\n"), "KafkaTopicPartitions")
+			}
+		})
+	}
+}
+
+// Test The Kafka Topic Reconciliation Of KafkaChannels Against The Configured Maximum Partitions
+func TestReconcileTopicMaxNumPartitions(t *testing.T) {
+
+	// Test Data
+	maxNumPartitions := int32(controllertesting.NumPartitions)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                 string
+		maxNumPartitions     int32
+		numPartitions        int32
+		topicExists          bool
+		wantCreateTopic      bool
+		wantCreatePartitions bool
+		wantErr              string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:             "Below Maximum",
+			maxNumPartitions: maxNumPartitions,
+			numPartitions:    maxNumPartitions - 1,
+			wantCreateTopic:  true,
+		},
+		{
+			name:             "At Maximum",
+			maxNumPartitions: maxNumPartitions,
+			numPartitions:    maxNumPartitions,
+			wantCreateTopic:  true,
+		},
+		{
+			name:             "Above Maximum",
+			maxNumPartitions: maxNumPartitions,
+			numPartitions:    maxNumPartitions + 1,
+			wantErr:          "numPartitions 124 must not exceed 123 (kafka.topic.maxNumPartitions)",
+		},
+		{
+			name:             "Absurd Partitions",
+			maxNumPartitions: maxNumPartitions,
+			numPartitions:    100000,
+			wantErr:          "numPartitions 100000 must not exceed 123 (kafka.topic.maxNumPartitions)",
+		},
+		{
+			name:            "Absurd Partitions Without Maximum",
+			numPartitions:   100000,
+			wantCreateTopic: true,
+		},
+		{
+			name:             "Increase Above Maximum",
+			maxNumPartitions: maxNumPartitions,
+			numPartitions:    maxNumPartitions + 1,
+			topicExists:      true,
+			wantErr:          "numPartitions 124 must not exceed 123 (kafka.topic.maxNumPartitions)",
+		},
+		{
+			name:                 "Increase Without Maximum",
+			numPartitions:        maxNumPartitions + 1,
+			topicExists:          true,
+			wantCreateTopic:      true,
+			wantCreatePartitions: true,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Tracking The Topic Creation (Existing Topics Have The Test Partitions)
+			createTopicCalled := false
+			createPartitionsCalled := false
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(_ context.Context, _ string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+					createTopicCalled = true
+					assert.Equal(t, testCase.numPartitions, topicDetail.NumPartitions)
+					if testCase.topicExists {
+						return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+					}
+					return nil
+				},
+				MockCreatePartitionsFunc: func(_ context.Context, _ string, count int32) *sarama.TopicError {
+					createPartitionsCalled = true
+					assert.Equal(t, testCase.numPartitions, count)
+					return nil
+				},
+			}
+
+			// Create The Reconciler To Test With The TestCase's Maximum
+			config := controllertesting.NewConfig()
+			config.Kafka.Topic.MaxNumPartitions = testCase.maxNumPartitions
+			reconciler := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				adminClient:     mockAdminClient,
+				adminClientType: kafkaadmin.Kafka,
+				config:          config,
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			channel.Spec.NumPartitions = testCase.numPartitions
+			err := reconciler.reconcileTopic(ctx, channel)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantCreateTopic, createTopicCalled)
+			assert.Equal(t, testCase.wantCreatePartitions, createPartitionsCalled)
+			condition := channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady)
+			if testCase.wantErr != "" {
+				assert.NotNil(t, err)
+				assert.Equal(t, testCase.wantErr, err.Error())
+				assert.True(t, condition.IsFalse())
+				assert.Contains(t, condition.Message, testCase.wantErr)
+			} else {
+				assert.Nil(t, err)
+				assert.True(t, condition.IsTrue())
+			}
+		})
+	}
+}
+
+// Test The Kafka Topic Reconciliation When Fetching The Topic Metadata Times Out
+func TestReconcileTopicMetadataTimeout(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name           string
+		readOnly       bool
+		slowPartitions bool
+		slowConfig     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:     "Slow Topic Verification",
+			readOnly: true,
+		},
+		{
+			name:           "Slow Topic Partitions",
+			slowPartitions: true,
+		},
+		{
+			name:       "Slow Topic Config",
+			slowConfig: true,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Of A Preexisting Topic Whose Metadata Is Slow Per The TestCase (Abandoned
+			// Fetches Outlive The Test Run, So Must Not Reference The TestCase)
+			slowPartitions := testCase.slowPartitions
+			slowConfig := testCase.slowConfig
+			releaseBroker := make(chan struct{})
+			defer close(releaseBroker)
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(_ context.Context, _ string, _ *sarama.TopicDetail) *sarama.TopicError {
+					return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+				},
+				MockDescribeTopicFunc: func(_ context.Context, _ string) *sarama.TopicError {
+					<-releaseBroker
+					return nil
+				},
+				MockDescribeTopicPartitionsFunc: func(_ context.Context, _ string) (int32, *sarama.TopicError) {
+					if slowPartitions {
+						<-releaseBroker
+					}
+					return controllertesting.NumPartitions, nil
+				},
+				MockDescribeTopicConfigFunc: func(_ context.Context, _ string) (map[string]string, *sarama.TopicError) {
+					if slowConfig {
+						<-releaseBroker
+					}
+					return map[string]string{constants.KafkaTopicConfigRetentionMs: controllertesting.DefaultRetentionMillisString}, nil
+				},
+			}
+
+			// Create The Reconciler To Test With A Metadata Timeout
+			config := controllertesting.NewConfig()
+			config.Kafka.ReadOnly = testCase.readOnly
+			config.Kafka.MetadataTimeoutMillis = 10
+			reconciler := &Reconciler{
+				logger:          logtesting.TestLogger(t).Desugar(),
+				adminClient:     mockAdminClient,
+				adminClientType: kafkaadmin.Kafka,
+				config:          config,
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			err := reconciler.reconcileTopic(ctx, channel)
+
+			// Verify The Topic Condition Is Left Unknown Without A Failure Event (The KafkaChannel Is Requeued)
+			assert.True(t, errors.Is(err, errTopicMetadataTimeout))
+			condition := channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady)
+			assert.True(t, condition.IsUnknown())
+			assert.Equal(t, event.KafkaTopicMetadataTimeout.String(), condition.Reason)
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+// Test The Fetching Of Kafka Topic Metadata Within The Metadata Timeout
+func TestFetchTopicMetadata(t *testing.T) {
+
+	// Create The Reconciler To Test With A Single Topic Operation Slot & A Metadata Timeout
+	config := controllertesting.NewConfig()
+	config.Kafka.MetadataTimeoutMillis = 10
+	reconciler := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		config:          config,
+		topicOperations: newTopicOperationLimiter(1),
+	}
+	activeOperations := func() int32 {
+		reconciler.topicOperations.lock.Lock()
+		defer reconciler.topicOperations.lock.Unlock()
+		return reconciler.topicOperations.active
+	}
+
+	// Verify A Fetch Completing Within The Timeout Succeeds & Releases Its Slot
+	fetched := false
+	assert.Nil(t, reconciler.fetchTopicMetadata(context.TODO(), func(_ context.Context, _ kafkaadmin.AdminClientInterface) { fetched = true }))
+	assert.True(t, fetched)
+	assert.Equal(t, int32(0), activeOperations())
+
+	// Verify A Slow Fetch Times Out, But Holds Its Slot Until The Broker Responds
+	releaseBroker := make(chan struct{})
+	fetchDone := make(chan struct{})
+	err := reconciler.fetchTopicMetadata(context.TODO(), func(_ context.Context, _ kafkaadmin.AdminClientInterface) {
+		defer close(fetchDone)
+		<-releaseBroker
+	})
+	assert.True(t, errors.Is(err, errTopicMetadataTimeout))
+	assert.Equal(t, int32(1), activeOperations())
+
+	// Verify Waiting For The Held Slot Also Times Out
+	err = reconciler.fetchTopicMetadata(context.TODO(), func(_ context.Context, _ kafkaadmin.AdminClientInterface) {
+		t.Error("unexpected fetch without a topic operation slot")
+	})
+	assert.True(t, errors.Is(err, errTopicMetadataTimeout))
+
+	// Verify The Slot Is Released Once The Broker Responds
+	close(releaseBroker)
+	<-fetchDone
+	assert.Eventually(t, func() bool { return activeOperations() == 0 }, time.Second, time.Millisecond)
+
+	// Verify A Cancelled Reconciliation Is Not Reported As A Timeout
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	reconciler.topicOperations = newTopicOperationLimiter(1)
+	assert.Nil(t, reconciler.topicOperations.acquire(context.TODO()))
+	err = reconciler.fetchTopicMetadata(ctx, func(_ context.Context, _ kafkaadmin.AdminClientInterface) {})
+	assert.Equal(t, context.Canceled, err)
+
+	// Verify Fetches Are Not Bounded When The Timeout Is Disabled
+	config.Kafka.MetadataTimeoutMillis = 0
+	reconciler.topicOperations = newTopicOperationLimiter(0)
+	assert.Nil(t, reconciler.fetchTopicMetadata(context.TODO(), func(_ context.Context, _ kafkaadmin.AdminClientInterface) { time.Sleep(20 * time.Millisecond) }))
+}
+
+// Test The Kafka Topic Reconciliation & Deletion In Read-Only Mode (No Topic Mutations)
+func TestReconcileTopicReadOnly(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		mockErrorCode sarama.KError
+		wantError     string
+		wantReady     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:          "Existing Topic",
+			mockErrorCode: sarama.ErrNoError,
+			wantReady:     true,
+		},
+		{
+			name:          "Nonexistent Topic",
+			mockErrorCode: sarama.ErrUnknownTopicOrPartition,
+			wantError:     "kafka topic '" + controllertesting.TopicName + "' does not exist and cannot be created in read-only mode",
+		},
+		{
+			name:          "Error Describing Topic",
+			mockErrorCode: sarama.ErrBrokerNotAvailable,
+			wantError:     sarama.ErrBrokerNotAvailable.Error() + " - " + controllertesting.ErrorString,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With New Recorder For Testing
+			recorder := record.NewBroadcaster().NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestEventSource"})
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Create A Mock Kafka AdminClient Which Fails On Any Mutation & Describes The Topic Per The TestCase
+			mockAdminClient := newReadOnlyMockAdminClient(t)
+			mockAdminClient.MockDescribeTopicFunc = func(ctx context.Context, topicName string) *sarama.TopicError {
+				assert.Equal(t, controllertesting.TopicName, topicName)
+				errMsg := controllertesting.SuccessString
+				if testCase.mockErrorCode != sarama.ErrNoError {
+					errMsg = controllertesting.ErrorString
+				}
+				return &sarama.TopicError{Err: testCase.mockErrorCode, ErrMsg: &errMsg}
+			}
+
+			// Initialize The Reconciler In Read-Only Mode
+			config := controllertesting.NewConfig()
+			config.Kafka.ReadOnly = true
+			r := &Reconciler{
+				logger:      logtesting.TestLogger(t).Desugar(),
+				adminClient: mockAdminClient,
+				config:      config,
+			}
+
+			// Perform The Test (Reconcile & Finalize)
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithFinalizer, controllertesting.WithInitializedConditions)
+			reconcileErr := r.reconcileTopic(ctx, channel)
+			deleteErr := r.deleteTopic(ctx, controllertesting.TopicName)
+
+			// Verify The Results
+			if len(testCase.wantError) > 0 {
+				assert.NotNil(t, reconcileErr)
+				assert.Equal(t, testCase.wantError, reconcileErr.Error())
+			} else {
+				assert.Nil(t, reconcileErr)
+			}
+			assert.Equal(t, testCase.wantReady, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).IsTrue())
+			assert.Nil(t, deleteErr)
+			assert.True(t, mockAdminClient.DescribeTopicsCalled())
+			assert.False(t, mockAdminClient.CreateTopicsCalled())
+			assert.False(t, mockAdminClient.DeleteTopicsCalled())
+		})
+	}
+}
+
+//
+// Test The Kafka Topic Reconciliation Of KafkaChannels Converted From / Stored As v1alpha1
+//
+// The controller only watches v1beta1 KafkaChannels, relying upon the conversion webhook to convert those created as
+// (or stored in) v1alpha1, so the converted channels must result in equivalent Kafka Topic settings.
+//
+func TestReconcileTopicConvertedChannel(t *testing.T) {
+
+	// The Expected TopicDetails For The Test KafkaChannel's Topic Settings & The Configured Defaults
+	wantTopicDetail := &sarama.TopicDetail{
+		NumPartitions:     controllertesting.NumPartitions,
+		ReplicationFactor: controllertesting.ReplicationFactor,
+		ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+	}
+	wantDefaultTopicDetail := &sarama.TopicDetail{
+		NumPartitions:     controllertesting.DefaultNumPartitions,
+		ReplicationFactor: controllertesting.DefaultReplicationFactor,
+		ConfigEntries:     map[string]*string{constants.KafkaTopicConfigRetentionMs: &controllertesting.DefaultRetentionMillisString},
+	}
+
+	// Define & Initialize The TopicTestCases
+	topicTestCases := []TopicTestCase{
+		{
+			Name:            "Native v1beta1 Channel",
+			Channel:         controllertesting.NewKafkaChannel(),
+			WantCreate:      true,
+			WantTopicDetail: wantTopicDetail,
+		},
+		{
+			Name:            "v1beta1 Channel Stored As v1alpha1",
+			Channel:         convertToV1beta1(t, convertToV1alpha1(t, controllertesting.NewKafkaChannel())),
+			WantCreate:      true,
+			WantTopicDetail: wantTopicDetail,
+		},
+		{
+			Name: "v1alpha1 Channel",
+			Channel: convertToV1beta1(t, &kafkav1alpha1.KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: controllertesting.KafkaChannelNamespace, Name: controllertesting.KafkaChannelName},
+				Spec:       kafkav1alpha1.KafkaChannelSpec{NumPartitions: controllertesting.NumPartitions, ReplicationFactor: controllertesting.ReplicationFactor},
+			}),
+			WantCreate:      true,
+			WantTopicDetail: wantTopicDetail,
+		},
+		{
+			Name: "v1alpha1 Channel Without Topic Settings",
+			Channel: convertToV1beta1(t, &kafkav1alpha1.KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: controllertesting.KafkaChannelNamespace, Name: controllertesting.KafkaChannelName},
+			}),
+			WantCreate:      true,
+			WantTopicDetail: wantDefaultTopicDetail,
+		},
+	}
+
+	// Run All The TopicTestCases
+	for _, tc := range topicTestCases {
+		t.Run(tc.Name, topicTestCaseFactory(tc))
+	}
+}
+
+// Convert The Specified v1beta1 KafkaChannel To v1alpha1 (As When Stored In v1alpha1)
+func convertToV1alpha1(t *testing.T, channel *kafkav1beta1.KafkaChannel) *kafkav1alpha1.KafkaChannel {
+	converted := &kafkav1alpha1.KafkaChannel{}
+	assert.Nil(t, converted.ConvertFrom(context.TODO(), channel))
+	return converted
+}
+
+// Convert The Specified v1alpha1 KafkaChannel To v1beta1 (As When Read By The Controller)
+func convertToV1beta1(t *testing.T, channel *kafkav1alpha1.KafkaChannel) *kafkav1beta1.KafkaChannel {
+	converted := &kafkav1beta1.KafkaChannel{}
+	assert.Nil(t, channel.ConvertTo(context.TODO(), converted))
+	return converted
+}
+
+// Create A Mock Kafka AdminClient Which Fails The Test On Any Topic Mutation
+func newReadOnlyMockAdminClient(t *testing.T) *controllertesting.MockAdminClient {
+	return &controllertesting.MockAdminClient{
+		MockCreateTopicFunc: func(_ context.Context, topicName string, _ *sarama.TopicDetail) *sarama.TopicError {
+			t.Errorf("unexpected CreateTopic() call for topic '%s' in read-only mode", topicName)
+			return nil
+		},
+		MockDeleteTopicFunc: func(_ context.Context, topicName string) *sarama.TopicError {
+			t.Errorf("unexpected DeleteTopic() call for topic '%s' in read-only mode", topicName)
+			return nil
+		},
+	}
+}
+
 // Factory For Creating A Go Test Function For The Specified TopicTestCase
 func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 	return func(t *testing.T) {
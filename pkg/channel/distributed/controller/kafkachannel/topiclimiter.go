@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"sync"
+)
+
+//
+// Limit The Number Of Concurrent Kafka Topic Operations Across All Reconciliations
+//
+// When many KafkaChannels are created (or deleted) at once, each reconciliation issues its own Topic operation
+// against the Kafka cluster, which can overwhelm the broker acting as the cluster's controller.  Every Topic
+// create / describe / delete is therefore performed while holding one of a fixed number of slots, pacing bulk
+// operations independently of the number of reconciliations in progress.  A limit of zero leaves them unbounded.
+//
+type topicOperationLimiter struct {
+	slots  chan struct{} // Buffered To The Limit (Nil If Unbounded)
+	lock   sync.Mutex
+	active int32 // Current Number Of Topic Operations In Progress
+	peak   int32 // Highest Number Of Concurrent Topic Operations Observed (For Observability / Testing)
+}
+
+// topicOperationLimiter Constructor
+func newTopicOperationLimiter(limit int32) *topicOperationLimiter {
+	limiter := &topicOperationLimiter{}
+	if limit > 0 {
+		limiter.slots = make(chan struct{}, limit)
+	}
+	return limiter
+}
+
+// Wait Until Another Topic Operation Is Permitted (Or The Context Is Done) & Track It As In Progress
+func (l *topicOperationLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.active++
+	if l.active > l.peak {
+		l.peak = l.active
+	}
+	return nil
+}
+
+// Track The Completion Of A Topic Operation (Permitting A Waiting Operation To Proceed)
+func (l *topicOperationLimiter) release() {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	l.active--
+	l.lock.Unlock()
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// Get The Highest Number Of Concurrent Topic Operations Observed
+func (l *topicOperationLimiter) peakOperations() int32 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.peak
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test That A Burst Of Topic Reconciliations Never Exceeds The Maximum Concurrent Topic Operations
+func TestReconcileTopicConcurrencyLimit(t *testing.T) {
+
+	// Test Data
+	limit := int32(3)
+	channelCount := 30
+
+	// Create A Mock Kafka AdminClient Which Tracks The Concurrent CreateTopic() Calls
+	adminClient := &burstAdminClient{MockAdminClient: &controllertesting.MockAdminClient{}, duration: 10 * time.Millisecond}
+
+	// Create The Reconciler To Test With The Limit
+	config := controllertesting.NewConfig()
+	config.Kafka.MaxConcurrentTopicOperations = limit
+	reconciler := &Reconciler{
+		logger:          logtesting.TestLogger(t).Desugar(),
+		adminClient:     adminClient,
+		config:          config,
+		topicOperations: newTopicOperationLimiter(config.Kafka.MaxConcurrentTopicOperations),
+	}
+
+	// Perform The Test - Reconcile The Topics Of Many KafkaChannels At Once
+	var waitGroup sync.WaitGroup
+	for index := 0; index < channelCount; index++ {
+		channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+		channel.Name = fmt.Sprintf("%s-%d", channel.Name, index)
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			assert.Nil(t, reconciler.reconcileTopic(context.TODO(), channel))
+		}()
+	}
+	waitGroup.Wait()
+
+	// Verify Every Topic Was Created Without Ever Exceeding The Limit
+	assert.Equal(t, int32(channelCount), atomic.LoadInt32(&adminClient.calls))
+	assert.LessOrEqual(t, atomic.LoadInt32(&adminClient.peak), limit)
+	assert.LessOrEqual(t, reconciler.topicOperations.peakOperations(), limit)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&adminClient.active))
+}
+
+// Test That Topic Operations Are Unbounded Without A Limit & Stop Waiting When The Context Is Done
+func TestTopicOperationLimiter(t *testing.T) {
+
+	// Verify An Unbounded Limiter Never Waits
+	unbounded := newTopicOperationLimiter(0)
+	for index := 0; index < 10; index++ {
+		assert.Nil(t, unbounded.acquire(context.TODO()))
+	}
+	assert.Equal(t, int32(10), unbounded.peakOperations())
+
+	// Verify A Nil Limiter (Reconcilers Created Without One) Never Waits
+	var nilLimiter *topicOperationLimiter
+	assert.Nil(t, nilLimiter.acquire(context.TODO()))
+	nilLimiter.release()
+
+	// Verify Waiting For A Bounded Limiter Stops When The Context Is Done
+	bounded := newTopicOperationLimiter(1)
+	assert.Nil(t, bounded.acquire(context.TODO()))
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, bounded.acquire(ctx))
+
+	// Verify The Operation Is Permitted Once Another Is Released
+	bounded.release()
+	assert.Nil(t, bounded.acquire(context.TODO()))
+	assert.Equal(t, int32(1), bounded.peakOperations())
+}
+
+// Mock Kafka AdminClient Which Tracks The Number Of Concurrent CreateTopic() Calls
+type burstAdminClient struct {
+	*controllertesting.MockAdminClient
+	duration time.Duration
+	calls    int32
+	active   int32
+	peak     int32
+}
+
+func (c *burstAdminClient) CreateTopic(_ context.Context, _ string, _ *sarama.TopicDetail) *sarama.TopicError {
+	atomic.AddInt32(&c.calls, 1)
+	active := atomic.AddInt32(&c.active, 1)
+	for {
+		peak := atomic.LoadInt32(&c.peak)
+		if active <= peak || atomic.CompareAndSwapInt32(&c.peak, peak, active) {
+			break
+		}
+	}
+	time.Sleep(c.duration)
+	atomic.AddInt32(&c.active, -1)
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+// Sarama NewSyncProducer() Wrapper Function Variable To Facilitate Unit Testing
+var newWarmupProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	return sarama.NewSyncProducer(brokers, config)
+}
+
+//
+// Warm Up The Partitions Of A Newly Created Kafka Topic
+//
+// Newly created partitions are not immediately writable (their leaders are still being elected) which delays the
+// first events sent to a KafkaChannel.  Producing a marker record (identified by the WarmupMarkerHeaderKey header,
+// and skipped by the Dispatcher) to every partition ensures each has a leader before the Topic is first used.  The
+// records are produced with the Kafka Secret's credentials and the Topic is unusable only until they have all been
+// acknowledged, so failures are returned for reporting but need not fail the reconciliation.
+//
+func (r *Reconciler) warmupTopic(ctx context.Context, topicName string, partitions int32) error {
+
+	// Setup The Logger
+	logger := r.logger.With(zap.String("Topic", topicName), zap.Int32("Partitions", partitions))
+
+	// Get The Brokers & Credentials From The Kafka Secret Associated With The Topic
+	kafkaSecretName := r.adminClient.GetKafkaSecretName(topicName)
+	if len(kafkaSecretName) <= 0 {
+		return fmt.Errorf("no kafka secret found for topic '%s'", topicName)
+	}
+	kafkaSecret, err := r.kubeClientset.CoreV1().Secrets(commonconstants.KnativeEventingNamespace).Get(ctx, kafkaSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get kafka secret '%s': %w", kafkaSecretName, err)
+	}
+	brokers := strings.Split(string(kafkaSecret.Data[kafkaconstants.KafkaSecretKeyBrokers]), ",")
+	username := string(kafkaSecret.Data[kafkaconstants.KafkaSecretKeyUsername])
+	password := string(kafkaSecret.Data[kafkaconstants.KafkaSecretKeyPassword])
+	saslMechanism := string(kafkaSecret.Data[kafkaconstants.KafkaSecretKeySaslMechanism])
+
+	// Copy The Sarama Config For A Producer Which Writes To Explicit Partitions & Waits For All In-Sync Replicas
+	saramaConfig := *r.saramaConfig
+	kafkasarama.UpdateSaramaConfig(&saramaConfig, constants.ControllerComponentName, username, password)
+	if err = kafkasarama.UpdateSaramaSaslMechanism(&saramaConfig, saslMechanism); err != nil {
+		return fmt.Errorf("invalid kafka secret '%s': %w", kafkaSecretName, err)
+	}
+	saramaConfig.Producer.Partitioner = sarama.NewManualPartitioner
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+
+	// Create The SyncProducer (Only For The Duration Of The Warm-Up)
+	producer, err := newWarmupProducerWrapper(brokers, &saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+	defer func() {
+		if closeErr := producer.Close(); closeErr != nil {
+			logger.Warn("Failed To Close Topic Warm-Up Producer", zap.Error(closeErr))
+		}
+	}()
+
+	// Produce A Marker Record To Every Partition & Return Any Failures
+	markers := make([]*sarama.ProducerMessage, partitions)
+	for partition := int32(0); partition < partitions; partition++ {
+		markers[partition] = &sarama.ProducerMessage{
+			Topic:     topicName,
+			Partition: partition,
+			Value:     sarama.StringEncoder(r.config.Kafka.Topic.Warmup.MarkerValue),
+			Headers:   []sarama.RecordHeader{{Key: []byte(kafkaconstants.WarmupMarkerHeaderKey), Value: []byte("true")}},
+		}
+	}
+	err = producer.SendMessages(markers)
+	if err != nil {
+		logger.Error("Failed To Warm Up Topic Partitions", zap.Error(err))
+		return fmt.Errorf("failed to produce warm-up marker records: %w", err)
+	}
+	logger.Info("Successfully Warmed Up Topic Partitions")
+	return nil
+}
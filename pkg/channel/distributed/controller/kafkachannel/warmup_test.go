@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The Warm-Up Of Newly Created Kafka Topic Partitions
+func TestReconcileTopicWarmup(t *testing.T) {
+
+	// Test Data
+	testPartitions := int32(3)
+	testMarkerValue := "TestMarkerValue"
+	producerErr := errors.New("test producer error")
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name            string
+		disabled        bool
+		createErrorCode sarama.KError
+		noSecret        bool
+		newProducerErr  error
+		sendErr         error
+		wantWarmup      bool
+		wantEvent       string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "New Topic",
+			wantWarmup: true,
+			wantEvent:  fmt.Sprintf("%s %s Warmed Up %d Kafka Topic Partitions For Channel", corev1.EventTypeNormal, event.KafkaTopicWarmedUp.String(), testPartitions),
+		},
+		{
+			name:     "New Topic With Warm-Up Disabled",
+			disabled: true,
+		},
+		{
+			name:            "Preexisting Topic",
+			createErrorCode: sarama.ErrTopicAlreadyExists,
+		},
+		{
+			name:      "Missing Kafka Secret",
+			noSecret:  true,
+			wantEvent: fmt.Sprintf("%s %s Failed To Warm Up Kafka Topic Partitions For Channel: failed to get kafka secret '%s': secrets \"%s\" not found", corev1.EventTypeWarning, event.KafkaTopicWarmupFailed.String(), controllertesting.KafkaSecretName, controllertesting.KafkaSecretName),
+		},
+		{
+			name:           "Error Creating Producer",
+			newProducerErr: producerErr,
+			wantEvent:      fmt.Sprintf("%s %s Failed To Warm Up Kafka Topic Partitions For Channel: failed to create kafka producer: %v", corev1.EventTypeWarning, event.KafkaTopicWarmupFailed.String(), producerErr),
+		},
+		{
+			name:       "Error Producing Marker Records",
+			sendErr:    producerErr,
+			wantWarmup: true,
+			wantEvent:  fmt.Sprintf("%s %s Failed To Warm Up Kafka Topic Partitions For Channel: failed to produce warm-up marker records: %v", corev1.EventTypeWarning, event.KafkaTopicWarmupFailed.String(), producerErr),
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Setup Context With A Fake Recorder For Testing
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.TODO(), recorder)
+
+			// Mock The Warm-Up SyncProducer (And Restore Post-Test)
+			mockProducer := &warmupSyncProducer{sendErr: testCase.sendErr}
+			var producerBrokers []string
+			var producerConfig *sarama.Config
+			newWarmupProducerWrapperPlaceholder := newWarmupProducerWrapper
+			newWarmupProducerWrapper = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+				producerBrokers = brokers
+				producerConfig = config
+				if testCase.newProducerErr != nil {
+					return nil, testCase.newProducerErr
+				}
+				return mockProducer, nil
+			}
+			defer func() { newWarmupProducerWrapper = newWarmupProducerWrapperPlaceholder }()
+
+			// Create A Mock Kafka AdminClient Which Creates The Topic Per The TestCase (Preexisting Topics Have The Same Partitions)
+			mockAdminClient := &controllertesting.MockAdminClient{
+				MockCreateTopicFunc: func(ctx context.Context, topicName string, topicDetail *sarama.TopicDetail) *sarama.TopicError {
+					assert.Equal(t, testPartitions, topicDetail.NumPartitions)
+					errMsg := controllertesting.SuccessString
+					return &sarama.TopicError{Err: testCase.createErrorCode, ErrMsg: &errMsg}
+				},
+				MockDescribeTopicPartitionsFunc: func(ctx context.Context, topicName string) (int32, *sarama.TopicError) {
+					return testPartitions, &sarama.TopicError{Err: sarama.ErrNoError}
+				},
+			}
+
+			// Create The Reconciler To Test
+			config := controllertesting.NewConfig()
+			config.Kafka.Topic.Warmup.Enabled = !testCase.disabled
+			config.Kafka.Topic.Warmup.MarkerValue = testMarkerValue
+			kubeClientset := fakekubeclientset.NewSimpleClientset()
+			if !testCase.noSecret {
+				kubeClientset = fakekubeclientset.NewSimpleClientset(controllertesting.NewKafkaSecret())
+			}
+			reconciler := &Reconciler{
+				logger:        logtesting.TestLogger(t).Desugar(),
+				kubeClientset: kubeClientset,
+				adminClient:   mockAdminClient,
+				config:        config,
+				saramaConfig:  sarama.NewConfig(),
+			}
+
+			// Perform The Test
+			channel := controllertesting.NewKafkaChannel(controllertesting.WithInitializedConditions)
+			channel.Spec.NumPartitions = testPartitions
+			err := reconciler.reconcileTopic(ctx, channel)
+
+			// Verify The Topic Was Reconciled Regardless Of The Warm-Up Results
+			assert.Nil(t, err)
+			assert.True(t, channel.Status.GetCondition(kafkav1beta1.KafkaChannelConditionTopicReady).IsTrue())
+
+			// Verify One Marker Record Was Produced To Each Partition Via A Producer Using The Kafka Secret
+			if testCase.wantWarmup {
+				assert.Equal(t, []string{controllertesting.KafkaSecretDataValueBrokers}, producerBrokers)
+				assert.Equal(t, controllertesting.KafkaSecretDataValueUsername, producerConfig.Net.SASL.User)
+				assert.Equal(t, constants.ControllerComponentName, producerConfig.ClientID)
+				assert.Equal(t, sarama.WaitForAll, producerConfig.Producer.RequiredAcks)
+				assert.Len(t, mockProducer.messages, int(testPartitions))
+				for index, message := range mockProducer.messages {
+					assert.Equal(t, controllertesting.TopicName, message.Topic)
+					assert.Equal(t, int32(index), message.Partition)
+					value, _ := message.Value.Encode()
+					assert.Equal(t, testMarkerValue, string(value))
+					assert.Len(t, message.Headers, 1)
+					assert.Equal(t, kafkaconstants.WarmupMarkerHeaderKey, string(message.Headers[0].Key))
+				}
+				assert.True(t, mockProducer.closed)
+			} else {
+				assert.Len(t, mockProducer.messages, 0)
+			}
+
+			// Verify The Warm-Up Event (If Any)
+			if len(testCase.wantEvent) > 0 {
+				assert.Len(t, recorder.Events, 1)
+				assert.Equal(t, testCase.wantEvent, <-recorder.Events)
+			} else {
+				assert.Len(t, recorder.Events, 0)
+			}
+		})
+	}
+}
+
+// Mock SyncProducer Which Records The Messages It Is Asked To Send
+type warmupSyncProducer struct {
+	sendErr  error
+	messages []*sarama.ProducerMessage
+	closed   bool
+}
+
+func (p *warmupSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	p.messages = append(p.messages, message)
+	return message.Partition, 0, p.sendErr
+}
+
+func (p *warmupSyncProducer) SendMessages(messages []*sarama.ProducerMessage) error {
+	p.messages = append(p.messages, messages...)
+	return p.sendErr
+}
+
+func (p *warmupSyncProducer) Close() error {
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasecret
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+)
+
+//
+// Reconcile The Receiver & Dispatcher Deployments Using The Specified Kafka Secret With Its Current Brokers
+//
+// The brokers are injected into the Deployments' Pods as environment variables, so the Pods only pick up a change
+// when they are restarted.  Each Deployment (& its Pod template) is therefore annotated with a checksum of the brokers
+// when it is created, and whenever the Pod template's checksum differs from that of the current brokers it is updated
+// (triggering a rolling restart).  This includes Deployments whose Pods were never annotated (e.g. those created prior
+// to upgrading), as there is no knowing which brokers their Pods were started with.
+//
+func (r *Reconciler) reconcileKafkaBrokers(ctx context.Context, secret *corev1.Secret) error {
+
+	// Get Secret Specific Logger
+	logger := util.SecretLogger(r.logger, secret)
+
+	// Nothing To Do If Brokers Changes Are Ignored
+	if r.config.Kafka.BrokersChangePolicy == constants.BrokersChangePolicyIgnore {
+		logger.Debug("Kafka Brokers Change Policy Is Ignore - Skipping Deployment Rollouts")
+		return nil
+	}
+
+	// Get The Deployments Using The Secret
+	deployments, err := r.getKafkaSecretDeployments(secret)
+	if err != nil {
+		logger.Error("Failed To Get Deployments Using Kafka Secret", zap.Error(err))
+		return err
+	}
+
+	// Reconcile Each Deployment With The Current Brokers Checksum (Process All Regardless Of Error)
+	checksum := util.KafkaBrokersChecksum(secret)
+	failedDeployments := 0
+	for _, deployment := range deployments {
+		err = r.reconcileDeploymentKafkaBrokers(ctx, deployment, checksum)
+		if err != nil {
+			logger.Error("Failed To Reconcile Deployment With Kafka Brokers", zap.String("Deployment", deployment.Name), zap.Error(err))
+			failedDeployments++
+		}
+	}
+
+	// Return Results
+	if failedDeployments > 0 {
+		return fmt.Errorf("failed to roll out %d deployment(s) with the current kafka brokers", failedDeployments)
+	}
+	return nil
+}
+
+// Get The Receiver & Dispatcher Deployments Using The Specified Kafka Secret
+func (r *Reconciler) getKafkaSecretDeployments(secret *corev1.Secret) ([]*appsv1.Deployment, error) {
+
+	var deployments []*appsv1.Deployment
+
+	// Include The Shared Receiver Deployment (If It Exists)
+	if !util.ReceiverPerChannel(r.config) {
+		deployment, err := r.getReceiverDeployment(secret)
+		if err == nil {
+			deployments = append(deployments, deployment)
+		} else if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	// List The KafkaChannels Using The Secret (All Namespaces)
+	kafkaChannels, err := r.kafkachannelLister.List(labels.SelectorFromSet(map[string]string{constants.KafkaSecretLabel: secret.Name}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Include Each KafkaChannel's Dispatcher (& Dedicated Receiver) Deployments
+	for _, kafkaChannel := range kafkaChannels {
+		if kafkaChannel != nil && util.NamespaceInScope(r.environment.WatchNamespaces, kafkaChannel.Namespace) {
+			channelDeployments, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).List(labels.SelectorFromSet(map[string]string{
+				constants.KafkaChannelNameLabel:      kafkaChannel.Name,
+				constants.KafkaChannelNamespaceLabel: kafkaChannel.Namespace,
+			}))
+			if err != nil {
+				return nil, err
+			}
+			deployments = append(deployments, channelDeployments...)
+		}
+	}
+
+	// Return The Deployments
+	return deployments, nil
+}
+
+// Reconcile The Specified Deployment With The Current Kafka Brokers Checksum (Rolling It Out If The Brokers Changed)
+func (r *Reconciler) reconcileDeploymentKafkaBrokers(ctx context.Context, deployment *appsv1.Deployment, checksum string) error {
+
+	// Get Deployment Specific Logger
+	logger := r.logger.With(zap.String("Deployment", deployment.Name))
+
+	// Nothing To Do If The Deployment & Its Pods Were Last Reconciled With The Current Brokers
+	podChecksum := deployment.Spec.Template.Annotations[constants.KafkaBrokersChecksumAnnotation]
+	if deployment.Annotations[constants.KafkaBrokersChecksumAnnotation] == checksum && podChecksum == checksum {
+		return nil
+	}
+
+	// Clone The Deployment So As Not To Perturb Informers Copy & Record The Current Checksum
+	updatedDeployment := deployment.DeepCopy()
+	if updatedDeployment.Annotations == nil {
+		updatedDeployment.Annotations = map[string]string{}
+	}
+	updatedDeployment.Annotations[constants.KafkaBrokersChecksumAnnotation] = checksum
+
+	// Roll Out The Deployment's Pods If They Were Not Started With The Current Brokers
+	if podChecksum != checksum {
+		if updatedDeployment.Spec.Template.Annotations == nil {
+			updatedDeployment.Spec.Template.Annotations = map[string]string{}
+		}
+		updatedDeployment.Spec.Template.Annotations[constants.KafkaBrokersChecksumAnnotation] = checksum
+		logger.Info("Kafka Brokers Changed - Rolling Out Deployment", zap.String("PreviousChecksum", podChecksum))
+	}
+
+	// Update The Deployment
+	_, err := r.kubeClientset.AppsV1().Deployments(updatedDeployment.Namespace).Update(ctx, updatedDeployment, metav1.UpdateOptions{})
+	if err != nil {
+		logger.Error("Failed To Update Deployment With Kafka Brokers Checksum", zap.Error(err))
+		return err
+	}
+	logger.Info("Successfully Updated Deployment With Kafka Brokers Checksum")
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinformer"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinjection"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	injectionclient "knative.dev/eventing-kafka/pkg/client/injection/client"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -38,6 +39,7 @@ import (
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 )
 
@@ -75,6 +77,7 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	r := &Reconciler{
 		logger:             logger,
 		kubeClientset:      kubeclient.Get(ctx),
+		dynamicClient:      dynamicclient.Get(ctx),
 		config:             configuration,
 		environment:        environment,
 		kafkaChannelClient: injectionclient.Get(ctx),
@@ -99,9 +102,10 @@ func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 		FilterFunc: controller.FilterControllerGVK(corev1.SchemeGroupVersion.WithKind(constants.SecretKind)),
 		Handler:    controller.HandleAll(controllerImpl.EnqueueControllerOf),
 	})
-	kafkachannelInformer.Informer().AddEventHandler(
-		controller.HandleAll(enqueueSecretOfKafkaChannel(controllerImpl)),
-	)
+	kafkachannelInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: util.FilterNamespaces(environment.WatchNamespaces),
+		Handler:    controller.HandleAll(enqueueSecretOfKafkaChannel(controllerImpl)),
+	})
 
 	// Return The KafkaSecret Controller Impl
 	return controllerImpl
@@ -64,7 +64,7 @@ func (r *Reconciler) reconcileKafkaChannelStatus(ctx context.Context,
 	// Update All The KafkaChannels Status As Specified (Process All Regardless Of Error)
 	statusUpdateErrors := false
 	for _, kafkaChannel := range kafkaChannels {
-		if kafkaChannel != nil {
+		if kafkaChannel != nil && util.NamespaceInScope(r.environment.WatchNamespaces, kafkaChannel.Namespace) {
 			err := r.updateKafkaChannelStatus(ctx, kafkaChannel, serviceValid, serviceReason, serviceMessage, deploymentValid, deploymentReason, deploymentMessage)
 			if err != nil {
 				logger.Error("Failed To Update KafkaChannel Status", zap.Error(err))
@@ -19,23 +19,17 @@ package kafkasecret
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
-	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
-	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	"knative.dev/pkg/controller"
-	"knative.dev/pkg/logging"
-	"knative.dev/pkg/system"
 )
 
 // Reconcile The Receiver (Kafka Producer) For The Specified KafkaChannel
@@ -44,6 +38,12 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 	// Get Secret Specific Logger
 	logger := util.SecretLogger(r.logger, secret)
 
+	// Dedicated Receivers Are Reconciled By The KafkaChannel Controller When Configured For "channel" Mode
+	if util.ReceiverPerChannel(r.config) {
+		logger.Info("Receiver Mode Is Per KafkaChannel - Skipping Shared Receiver Reconciliation")
+		return nil
+	}
+
 	// Reconcile The Receiver Service
 	serviceErr := r.reconcileReceiverService(ctx, secret)
 	if serviceErr != nil {
@@ -62,6 +62,15 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 		logger.Info("Successfully Reconciled Receiver Deployment")
 	}
 
+	// Reconcile The (Optional) Receiver Ingress
+	ingressErr := r.reconcileReceiverIngress(ctx, secret)
+	if ingressErr != nil {
+		controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeWarning, event.ReceiverIngressReconciliationFailed.String(), "Failed To Reconcile Receiver Ingress: %v", ingressErr)
+		logger.Error("Failed To Reconcile Receiver Ingress", zap.Error(ingressErr))
+	} else {
+		logger.Info("Successfully Reconciled Receiver Ingress")
+	}
+
 	// Reconcile Channel's KafkaChannel Status
 	statusErr := r.reconcileKafkaChannelStatus(ctx,
 		secret,
@@ -75,7 +84,7 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 	}
 
 	// Return Results
-	if serviceErr != nil || deploymentErr != nil || statusErr != nil {
+	if serviceErr != nil || deploymentErr != nil || ingressErr != nil || statusErr != nil {
 		return fmt.Errorf("failed to reconcile channel resources")
 	} else {
 		return nil // Success
@@ -86,96 +95,56 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, secret *corev1.Secret
 // Kafka Receiver Service
 //
 
-// Reconcile The Receiver Service
+// Reconcile The Receiver Service(s) - Including The Separate Receiver Metrics Service (If Enabled)
 func (r *Reconciler) reconcileReceiverService(ctx context.Context, secret *corev1.Secret) error {
 
-	// Attempt To Get The Receiver Service Associated With The Specified Secret
-	_, err := r.getReceiverService(secret)
+	// Reconcile The Receiver (HTTP) Service
+	err := util.ReconcileReceiverService(ctx, r.logger, r.kubeClientset, r.serviceLister, r.newReceiverService(secret))
 	if err != nil {
+		return err
+	}
 
-		// If The Service Was Not Found - Then Create A New One For The Secret
-		if errors.IsNotFound(err) {
-
-			// Then Create The New Receiver Service
-			r.logger.Info("Receiver Service Not Found - Creating New One")
-			service := r.newReceiverService(secret)
-			_, err = r.kubeClientset.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
-			if err != nil {
-				r.logger.Error("Failed To Create Receiver Service", zap.Error(err))
-				return err
-			} else {
-				r.logger.Info("Successfully Created Receiver Service")
-				return nil
-			}
+	// Reconcile The Separate Receiver Metrics Service (If Enabled)
+	if r.config.Receiver.SeparateMetricsService {
+		return util.ReconcileReceiverService(ctx, r.logger, r.kubeClientset, r.serviceLister, r.newReceiverMetricsService(secret))
+	}
+	return nil
+}
 
-		} else {
+// Get The Receiver Shared By The KafkaChannels Using The Specified Secret
+func (r *Reconciler) receiver(secret *corev1.Secret) util.Receiver {
+	return util.NewSecretReceiver(r.config.Receiver.NamingPolicy, secret)
+}
 
-			// Failed In Attempt To Get Receiver Service From K8S
-			r.logger.Error("Failed To Get Receiver Service", zap.Error(err))
-			return err
-		}
-	} else {
+// Create Receiver Service Model For The Specified Secret
+func (r *Reconciler) newReceiverService(secret *corev1.Secret) *corev1.Service {
+	return util.NewReceiverService(r.receiver(secret), r.config, r.environment)
+}
 
-		// Verified The Receiver Service Exists
-		r.logger.Info("Successfully Verified Receiver Service")
-		return nil
-	}
+// Create The Separate Receiver Metrics Service Model For The Specified Secret
+func (r *Reconciler) newReceiverMetricsService(secret *corev1.Secret) *corev1.Service {
+	return util.NewReceiverMetricsService(r.receiver(secret), r.environment)
 }
 
-// Get The Kafka Receiver Service Associated With The Specified Channel
-func (r *Reconciler) getReceiverService(secret *corev1.Secret) (*corev1.Service, error) {
+//
+// Kafka Receiver Ingress - Optional External Access To The Receiver Service
+//
 
-	// Get The Receiver Deployment Name For The Receiver - Use Same For Service
-	deploymentName := util.ReceiverDnsSafeName(secret.Name)
+// Reconcile The Receiver Ingress (If Enabled)
+func (r *Reconciler) reconcileReceiverIngress(ctx context.Context, secret *corev1.Secret) error {
 
-	// Get The Receiver Service By Namespace / Name
-	service, err := r.serviceLister.Services(commonconstants.KnativeEventingNamespace).Get(deploymentName)
+	// Nothing To Do Unless External Access Has Been Enabled
+	if !r.config.Receiver.Ingress.Enabled {
+		return nil
+	}
 
-	// Return The Results
-	return service, err
+	// Reconcile The Receiver Ingress
+	return util.ReconcileReceiverIngress(ctx, r.logger, r.kubeClientset, r.newReceiverIngress(secret))
 }
 
-// Create Receiver Service Model For The Specified Secret
-func (r *Reconciler) newReceiverService(secret *corev1.Secret) *corev1.Service {
-
-	// Get The Receiver Deployment Name For The Secret - Use Same For Service
-	deploymentName := util.ReceiverDnsSafeName(secret.Name)
-
-	// Create & Return The Receiver Service Model
-	return &corev1.Service{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: corev1.SchemeGroupVersion.String(),
-			Kind:       constants.ServiceKind,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      deploymentName,
-			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
-				constants.KafkaChannelReceiverLabel:  "true",                               // Allows for identification of Receivers
-				constants.K8sAppChannelSelectorLabel: constants.K8sAppChannelSelectorValue, // Prometheus ServiceMonitor
-			},
-			OwnerReferences: []metav1.OwnerReference{
-				util.NewSecretOwnerReference(secret),
-			},
-		},
-		Spec: corev1.ServiceSpec{
-			Ports: []corev1.ServicePort{
-				{
-					Name:       constants.HttpPortName,
-					Port:       constants.HttpServicePortNumber,
-					TargetPort: intstr.FromInt(constants.HttpContainerPortNumber),
-				},
-				{
-					Name:       constants.MetricsPortName,
-					Port:       int32(r.environment.MetricsPort),
-					TargetPort: intstr.FromInt(r.environment.MetricsPort),
-				},
-			},
-			Selector: map[string]string{
-				constants.AppLabel: deploymentName, // Matches Deployment Label Key/Value
-			},
-		},
-	}
+// Create Receiver Ingress Model For The Specified Secret
+func (r *Reconciler) newReceiverIngress(secret *corev1.Secret) *networkingv1beta1.Ingress {
+	return util.NewReceiverIngress(r.receiver(secret), r.config)
 }
 
 //
@@ -186,7 +155,7 @@ func (r *Reconciler) newReceiverService(secret *corev1.Secret) *corev1.Service {
 func (r *Reconciler) reconcileReceiverDeployment(ctx context.Context, secret *corev1.Secret) error {
 
 	// Attempt To Get The Receiver Deployment Associated With The Specified Secret
-	_, err := r.getReceiverDeployment(secret)
+	deployment, err := r.getReceiverDeployment(secret)
 	if err != nil {
 
 		// If The Receiver Deployment Was Not Found - Then Create A New Deployment For The Secret
@@ -194,17 +163,21 @@ func (r *Reconciler) reconcileReceiverDeployment(ctx context.Context, secret *co
 
 			// Then Create The New Receiver Deployment
 			r.logger.Info("Receiver Deployment Not Found - Creating New One")
-			deployment, err := r.newChannelDeployment(secret)
+			deployment, err = r.newChannelDeployment(secret)
 			if err != nil {
 				r.logger.Error("Failed To Create Receiver Deployment YAML", zap.Error(err))
 				return err
+			} else if err = r.checkLimitRanges(ctx, deployment); err != nil {
+				r.logger.Error("Receiver Deployment Resources Would Be Rejected By LimitRange", zap.Error(err))
+				return err
 			} else {
-				_, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+				deployment, err = r.kubeClientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
 				if err != nil {
 					r.logger.Error("Failed To Create Receiver Deployment", zap.Error(err))
 					return err
 				} else {
 					r.logger.Info("Successfully Created Receiver Deployment")
+					r.reconcileVerticalPodAutoscaler(ctx, deployment)
 					return nil
 				}
 			}
@@ -219,15 +192,35 @@ func (r *Reconciler) reconcileReceiverDeployment(ctx context.Context, secret *co
 
 		// Verified The Receiver Deployment Exists
 		r.logger.Info("Successfully Verified Receiver Deployment")
+		r.reconcileVerticalPodAutoscaler(ctx, deployment)
+		return nil
+	}
+}
+
+// Verify The Receiver Deployment's Resources Are Permitted By The Namespace's LimitRanges (If Enabled)
+func (r *Reconciler) checkLimitRanges(ctx context.Context, deployment *appsv1.Deployment) error {
+	if !r.environment.ValidateLimitRanges {
 		return nil
 	}
+	return util.CheckLimitRanges(ctx, r.kubeClientset, deployment.Namespace, &deployment.Spec.Template.Spec)
+}
+
+// Reconcile The Receiver Deployment's Recommendation-Only VerticalPodAutoscaler (If Enabled)
+func (r *Reconciler) reconcileVerticalPodAutoscaler(ctx context.Context, deployment *appsv1.Deployment) {
+	if !r.environment.VerticalPodAutoscalers {
+		return
+	}
+	err := util.ReconcileVerticalPodAutoscaler(ctx, r.logger, r.dynamicClient, deployment)
+	if err != nil {
+		r.logger.Warn("Failed To Reconcile VerticalPodAutoscaler - Sizing Recommendations Unavailable", zap.String("Deployment", deployment.Name), zap.Error(err))
+	}
 }
 
 // Get The Receiver Deployment Associated With The Specified Secret
 func (r *Reconciler) getReceiverDeployment(secret *corev1.Secret) (*appsv1.Deployment, error) {
 
 	// Get The Receiver Deployment Name (One Receiver Deployment Per Kafka Auth Secret)
-	deploymentName := util.ReceiverDnsSafeName(secret.Name)
+	deploymentName := util.ReceiverDnsSafeName(r.config.Receiver.NamingPolicy, secret.Name)
 
 	// Get The Receiver Deployment By Namespace / Name
 	deployment, err := r.deploymentLister.Deployments(commonconstants.KnativeEventingNamespace).Get(deploymentName)
@@ -238,169 +231,5 @@ func (r *Reconciler) getReceiverDeployment(secret *corev1.Secret) (*appsv1.Deplo
 
 // Create Receiver Deployment Model For The Specified Secret
 func (r *Reconciler) newChannelDeployment(secret *corev1.Secret) (*appsv1.Deployment, error) {
-
-	// Get The Receiver Deployment Name (One Receiver Deployment Per Kafka Auth Secret)
-	deploymentName := util.ReceiverDnsSafeName(secret.Name)
-
-	// Replicas Int Value For De-Referencing
-	replicas := int32(r.config.Receiver.Replicas)
-
-	// Create The Receiver Container Environment Variables
-	channelEnvVars, err := r.receiverDeploymentEnvVars(secret)
-	if err != nil {
-		r.logger.Error("Failed To Create Receiver Deployment Environment Variables", zap.Error(err))
-		return nil, err
-	}
-
-	// Create The Receiver Deployment
-	deployment := &appsv1.Deployment{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: appsv1.SchemeGroupVersion.String(),
-			Kind:       constants.DeploymentKind,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      deploymentName,
-			Namespace: commonconstants.KnativeEventingNamespace,
-			Labels: map[string]string{
-				constants.AppLabel:                  deploymentName, // Matches Service Selector Key/Value Below
-				constants.KafkaChannelReceiverLabel: "true",         // Allows for identification of Receivers
-			},
-			OwnerReferences: []metav1.OwnerReference{
-				util.NewSecretOwnerReference(secret),
-			},
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					constants.AppLabel: deploymentName, // Matches Template ObjectMeta Pods
-				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						constants.AppLabel: deploymentName, // Matched By Deployment Selector Above
-					},
-				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: r.environment.ServiceAccount,
-					Containers: []corev1.Container{
-						{
-							Name: deploymentName,
-							LivenessProbe: &corev1.Probe{
-								Handler: corev1.Handler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Port: intstr.FromInt(constants.HealthPort),
-										Path: health.LivenessPath,
-									},
-								},
-								InitialDelaySeconds: constants.ChannelLivenessDelay,
-								PeriodSeconds:       constants.ChannelLivenessPeriod,
-							},
-							ReadinessProbe: &corev1.Probe{
-								Handler: corev1.Handler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Port: intstr.FromInt(constants.HealthPort),
-										Path: health.ReadinessPath,
-									},
-								},
-								InitialDelaySeconds: constants.ChannelReadinessDelay,
-								PeriodSeconds:       constants.ChannelReadinessPeriod,
-							},
-							Image: r.environment.ReceiverImage,
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "server",
-									ContainerPort: int32(constants.HttpContainerPortNumber),
-								},
-							},
-							Env:             channelEnvVars,
-							ImagePullPolicy: corev1.PullIfNotPresent,
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    r.config.Receiver.CpuRequest,
-									corev1.ResourceMemory: r.config.Receiver.MemoryRequest,
-								},
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    r.config.Receiver.CpuLimit,
-									corev1.ResourceMemory: r.config.Receiver.MemoryLimit,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-
-	// Return Receiver Deployment
-	return deployment, nil
-}
-
-// Create The Receiver Deployment's Env Vars
-func (r *Reconciler) receiverDeploymentEnvVars(secret *corev1.Secret) ([]corev1.EnvVar, error) {
-
-	// Create The Receiver Deployment EnvVars
-	envVars := []corev1.EnvVar{
-		{
-			Name:  system.NamespaceEnvKey,
-			Value: commonconstants.KnativeEventingNamespace,
-		},
-		{
-			Name:  commonenv.KnativeLoggingConfigMapNameEnvVarKey,
-			Value: logging.ConfigMapName(),
-		},
-		{
-			Name:  commonenv.ServiceNameEnvVarKey,
-			Value: util.ReceiverDnsSafeName(secret.Name),
-		},
-		{
-			Name:  commonenv.MetricsPortEnvVarKey,
-			Value: strconv.Itoa(r.environment.MetricsPort),
-		},
-		{
-			Name:  commonenv.MetricsDomainEnvVarKey,
-			Value: r.environment.MetricsDomain,
-		},
-		{
-			Name:  commonenv.HealthPortEnvVarKey,
-			Value: strconv.Itoa(constants.HealthPort),
-		},
-	}
-
-	// Append The Kafka Brokers As Env Var
-	envVars = append(envVars, corev1.EnvVar{
-		Name: commonenv.KafkaBrokerEnvVarKey,
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
-				Key:                  constants.KafkaSecretDataKeyBrokers,
-			},
-		},
-	})
-
-	// Append The Kafka Username As Env Var
-	envVars = append(envVars, corev1.EnvVar{
-		Name: commonenv.KafkaUsernameEnvVarKey,
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
-				Key:                  constants.KafkaSecretDataKeyUsername,
-			},
-		},
-	})
-
-	// Append The Kafka Password As Env Var
-	envVars = append(envVars, corev1.EnvVar{
-		Name: commonenv.KafkaPasswordEnvVarKey,
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
-				Key:                  constants.KafkaSecretDataKeyPassword,
-			},
-		},
-	})
-
-	// Return The Receiver Deployment EnvVars Array
-	return envVars, nil
+	return util.NewReceiverDeployment(r.receiver(secret), r.config, r.environment)
 }
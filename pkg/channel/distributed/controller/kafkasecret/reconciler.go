@@ -22,16 +22,19 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinjection"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/reconciler"
 )
 
@@ -39,6 +42,7 @@ import (
 type Reconciler struct {
 	logger             *zap.Logger
 	kubeClientset      kubernetes.Interface
+	dynamicClient      dynamic.Interface
 	config             *config.EventingKafkaConfig
 	environment        *env.Environment
 	kafkaChannelClient versioned.Interface
@@ -97,9 +101,23 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, secret *corev1.Secret) re
 // Perform The Actual Secret Reconciliation
 func (r *Reconciler) reconcile(ctx context.Context, secret *corev1.Secret) error {
 
+	// Reject Kafka Secrets Specifying An Unknown SASL Mechanism
+	_, err := kafkasarama.ParseSaslMechanism(string(secret.Data[constants.KafkaSecretDataKeySaslMechanism]))
+	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeWarning, event.KafkaSecretInvalid.String(), "Invalid Kafka Secret: %v", err)
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
 	// Perform The Kafka Secret Reconciliation
-	err := r.reconcileChannel(ctx, secret)
+	err = r.reconcileChannel(ctx, secret)
+	if err != nil {
+		return fmt.Errorf(constants.ReconciliationFailedError)
+	}
+
+	// Roll Out The Deployments Using The Kafka Secret If Its Brokers Changed
+	err = r.reconcileKafkaBrokers(ctx, secret)
 	if err != nil {
+		controller.GetEventRecorder(ctx).Eventf(secret, corev1.EventTypeWarning, event.KafkaBrokersRolloutFailed.String(), "Failed To Roll Out Deployments With Kafka Brokers: %v", err)
 		return fmt.Errorf(constants.ReconciliationFailedError)
 	}
 
@@ -20,11 +20,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/kafkasecretinjection"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
@@ -33,6 +38,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
@@ -117,6 +123,19 @@ func TestReconcile(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "Reconcile Invalid SASL Mechanism",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer, controllertesting.WithKafkaSecretSaslMechanism("SCRAM-SHA-1")),
+			},
+			WantErr: true,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.KafkaSecretInvalid.String(), "Invalid Kafka Secret: invalid / unknown sasl mechanism 'SCRAM-SHA-1' - must be one of [PLAIN SCRAM-SHA-256 SCRAM-SHA-512]"),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
+
 		//
 		// KafkaChannel Secret Deletion (Finalizer)
 		//
@@ -260,3 +279,657 @@ func TestReconcile(t *testing.T) {
 		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
 	}, logger.Desugar()))
 }
+
+// Test That The Status Of KafkaChannels Outside The Watched Namespaces Is Not Updated
+func TestReconcileWatchNamespaces(t *testing.T) {
+
+	// Define The Test Cases (Receiver Is Still Created, But The Out-Of-Scope KafkaChannel Is Untouched)
+	tableTest := TableTest{
+		{
+			Name: "Complete Reconciliation With Out Of Scope KafkaChannel",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
+				controllertesting.NewKafkaChannel(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewKafkaSecretFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaSecretFinalizerUpdateEvent(),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler Restricted To Another Namespace
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.WatchNamespaces = []string{"other-namespace"}
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        environment,
+			config:             controllertesting.NewConfig(),
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Receiver Resources Violate A LimitRange
+func TestReconcileLimitRanges(t *testing.T) {
+
+	// Define The Test Cases (Receiver Deployment Should Not Be Created)
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Missing Receiver Deployment Error(LimitRange)",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewLimitRange(),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithReceiverServiceReady,
+						controllertesting.WithReceiverDeploymentLimitRangeFailed,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverDeploymentReconciliationFailed.String(), "Failed To Reconcile Receiver Deployment: %s", controllertesting.NewReceiverLimitRangeError()),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With LimitRange Validation Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.ValidateLimitRanges = true
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        environment,
+			config:             controllertesting.NewConfig(),
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When Receiver Scheduling Constraints Are Configured
+func TestReconcileTopologySpreadConstraints(t *testing.T) {
+
+	// Create The Expected Receiver Deployment With The Configured Scheduling Constraints
+	receiverDeployment := controllertesting.NewKafkaChannelReceiverDeployment()
+	receiverDeployment.Spec.Template.Spec.TopologySpreadConstraints = controllertesting.NewTopologySpreadConstraints()
+	receiverDeployment.Spec.Template.Spec.Affinity = controllertesting.NewAffinity()
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Missing Receiver Deployment With TopologySpreadConstraints & Affinity",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+			},
+			WantCreates: []runtime.Object{receiverDeployment},
+			WantEvents:  []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With Receiver Scheduling Constraints Configured
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.TopologySpreadConstraints = controllertesting.NewTopologySpreadConstraints()
+		config.Receiver.Affinity = controllertesting.NewAffinity()
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             config,
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When VerticalPodAutoscalers Are Enabled
+func TestReconcileVerticalPodAutoscalers(t *testing.T) {
+
+	// Define The Test Cases (Dynamic Client Creates Are Recorded Before Kube Client Creates)
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Missing Receiver Deployment With VerticalPodAutoscaler",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelReceiverVerticalPodAutoscaler(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Missing Receiver Deployment Without VerticalPodAutoscaler CRD",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelService(),
+				controllertesting.NewKafkaChannelReceiverService(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{controllertesting.InduceVerticalPodAutoscalerCrdNotFound},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelReceiverVerticalPodAutoscaler(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With VerticalPodAutoscalers Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		environment := controllertesting.NewEnvironment()
+		environment.VerticalPodAutoscalers = true
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			dynamicClient:      fakedynamicclient.Get(ctx),
+			environment:        environment,
+			config:             controllertesting.NewConfig(),
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Receiver Is Configured For "channel" Mode (No Shared Receiver)
+func TestReconcileDedicatedReceiver(t *testing.T) {
+
+	// Define The Test Cases (Shared Receiver Resources & KafkaChannel Status Are Left To The KafkaChannel Controller)
+	tableTest := TableTest{
+		{
+			Name: "Complete Reconciliation With KafkaChannel",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(),
+				controllertesting.NewKafkaChannel(),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{controllertesting.NewKafkaSecretFinalizerPatchActionImpl()},
+			WantEvents: []string{
+				controllertesting.NewKafkaSecretFinalizerUpdateEvent(),
+				controllertesting.NewKafkaSecretSuccessfulReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler Configured For Dedicated Receivers
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.Mode = constants.ReceiverModeChannel
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             config,
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Receiver Metrics Are Exposed By A Separate Service
+func TestReconcileReceiverSeparateMetricsService(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Missing Receiver Services Success",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantCreates: []runtime.Object{
+				controllertesting.NewKafkaChannelReceiverHttpService(),
+				controllertesting.NewKafkaChannelReceiverMetricsService(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Missing Receiver Metrics Service Success",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverHttpService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantCreates: []runtime.Object{controllertesting.NewKafkaChannelReceiverMetricsService()},
+			WantEvents:  []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Existing Receiver Services",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverHttpService(),
+				controllertesting.NewKafkaChannelReceiverMetricsService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Missing Receiver Metrics Service Error(Create)",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverHttpService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("create", "services")},
+			WantErr:      true,
+			WantCreates:  []runtime.Object{controllertesting.NewKafkaChannelReceiverMetricsService()},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewKafkaChannel(
+						controllertesting.WithReceiverServiceFailed,
+						controllertesting.WithReceiverDeploymentReady,
+					),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverServiceReconciliationFailed.String(), "Failed To Reconcile Receiver Service: inducing failure for create services"),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With The Separate Metrics Service Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.SeparateMetricsService = true
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             config,
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Receiver Service Models With The Combined (Default) & Separate Metrics Service Layouts
+func TestNewReceiverServiceLayouts(t *testing.T) {
+
+	// Combined (Default) - A Single Service Exposing Both Ports & Selected By The ServiceMonitor
+	r := &Reconciler{config: controllertesting.NewConfig(), environment: controllertesting.NewEnvironment()}
+	assert.Equal(t, controllertesting.NewKafkaChannelReceiverService(), r.newReceiverService(controllertesting.NewKafkaSecret()))
+
+	// Separate - The HTTP Service Excludes The Metrics Port & ServiceMonitor Label, Which Move To The Metrics Service
+	r.config.Receiver.SeparateMetricsService = true
+	httpService := r.newReceiverService(controllertesting.NewKafkaSecret())
+	metricsService := r.newReceiverMetricsService(controllertesting.NewKafkaSecret())
+	assert.Equal(t, controllertesting.NewKafkaChannelReceiverHttpService(), httpService)
+	assert.Equal(t, controllertesting.NewKafkaChannelReceiverMetricsService(), metricsService)
+	assert.Equal(t, httpService.Spec.Selector, metricsService.Spec.Selector)
+	assert.Equal(t, constants.K8sAppChannelSelectorValue, metricsService.Labels[constants.K8sAppChannelSelectorLabel])
+	assert.NotContains(t, httpService.Labels, constants.K8sAppChannelSelectorLabel)
+}
+
+// Test The Reconcile Functionality When The Receiver Ingress Is Enabled
+func TestReconcileReceiverIngress(t *testing.T) {
+
+	// Define The Test Cases
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Missing Receiver Ingress Success",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WantCreates: []runtime.Object{controllertesting.NewKafkaChannelReceiverIngress()},
+			WantEvents:  []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Existing Receiver Ingress",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelReceiverIngress(),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Missing Receiver Ingress API Unavailable",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{
+				func(action clientgotesting.Action) (bool, runtime.Object, error) {
+					if action.GetVerb() == "create" && action.GetResource().Resource == "ingresses" {
+						return true, nil, errors.NewNotFound(action.GetResource().GroupResource(), "")
+					}
+					return false, nil, nil
+				},
+			},
+			WantCreates: []runtime.Object{controllertesting.NewKafkaChannelReceiverIngress()},
+			WantEvents:  []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Missing Receiver Ingress Error(Create)",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("create", "ingresses")},
+			WantErr:      true,
+			WantCreates:  []runtime.Object{controllertesting.NewKafkaChannelReceiverIngress()},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.ReceiverIngressReconciliationFailed.String(), "Failed To Reconcile Receiver Ingress: inducing failure for create ingresses"),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With The Receiver Ingress Enabled
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Receiver.Ingress = controllertesting.NewReceiverIngressConfig()
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             config,
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar()))
+}
+
+// Test The Reconcile Functionality When The Kafka Secret's Brokers Change
+func TestReconcileKafkaBrokers(t *testing.T) {
+
+	// Define The Test Cases (The Shared Receiver Is Reconciled Before The KafkaChannels' Deployments)
+	tableTest := TableTest{
+		{
+			Name: "Reconcile Changed Kafka Brokers Rolls Out Deployments",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelReceiverDeployment(), controllertesting.PreviousKafkaSecretDataValueBrokers, true),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.PreviousKafkaSecretDataValueBrokers, true),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.NewKafkaChannelReceiverDeployment()},
+				{Object: controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.KafkaSecretDataValueBrokers, true)},
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Unchanged Kafka Brokers",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.KafkaSecretDataValueBrokers, true),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Kafka Brokers Missing From Pod Template Rolls Out Deployment",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.KafkaSecretDataValueBrokers, false),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.KafkaSecretDataValueBrokers, true)},
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Unrecorded Kafka Brokers Rolls Out Deployment",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.NewKafkaChannelReceiverDeployment(),
+				controllertesting.NewKafkaChannelDispatcherDeployment(),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.KafkaSecretDataValueBrokers, true)},
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+		{
+			Name: "Reconcile Changed Kafka Brokers Error(Update)",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelReceiverDeployment(), controllertesting.PreviousKafkaSecretDataValueBrokers, true),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{InduceFailure("update", "deployments")},
+			WantErr:      true,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				{Object: controllertesting.NewKafkaChannelReceiverDeployment()},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, event.KafkaBrokersRolloutFailed.String(), "Failed To Roll Out Deployments With Kafka Brokers: failed to roll out 1 deployment(s) with the current kafka brokers"),
+				controllertesting.NewKafkaSecretFailedReconciliationEvent(),
+			},
+		},
+	}
+
+	// Run The TableTest Using A KafkaSecret Reconciler With The Default (Rollout) Brokers Change Policy
+	logger := logtesting.TestLogger(t)
+	tableTest.Test(t, newKafkaBrokersTestFactory(constants.DefaultBrokersChangePolicy, logger))
+
+	// Verify Deployments Are Left As-Is When Brokers Changes Are Ignored
+	ignoreTableTest := TableTest{
+		{
+			Name: "Reconcile Changed Kafka Brokers Ignored",
+			Key:  controllertesting.KafkaSecretKey,
+			Objects: []runtime.Object{
+				controllertesting.NewKafkaSecret(controllertesting.WithKafkaSecretFinalizer),
+				controllertesting.NewKafkaChannel(
+					controllertesting.WithLabels,
+					controllertesting.WithReceiverServiceReady,
+					controllertesting.WithReceiverDeploymentReady,
+				),
+				controllertesting.NewKafkaChannelReceiverService(),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelReceiverDeployment(), controllertesting.PreviousKafkaSecretDataValueBrokers, true),
+				controllertesting.WithKafkaBrokersChecksum(controllertesting.NewKafkaChannelDispatcherDeployment(), controllertesting.PreviousKafkaSecretDataValueBrokers, true),
+			},
+			WantEvents: []string{controllertesting.NewKafkaSecretSuccessfulReconciliationEvent()},
+		},
+	}
+	ignoreTableTest.Test(t, newKafkaBrokersTestFactory(constants.BrokersChangePolicyIgnore, logger))
+}
+
+// Create A TableTest Factory For A KafkaSecret Reconciler With The Specified Brokers Change Policy
+func newKafkaBrokersTestFactory(brokersChangePolicy string, logger *zap.SugaredLogger) Factory {
+	return controllertesting.MakeFactory(func(ctx context.Context, listers *controllertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		config := controllertesting.NewConfig()
+		config.Kafka.BrokersChangePolicy = brokersChangePolicy
+		r := &Reconciler{
+			logger:             logging.FromContext(ctx).Desugar(),
+			kubeClientset:      kubeclient.Get(ctx),
+			environment:        controllertesting.NewEnvironment(),
+			config:             config,
+			kafkaChannelClient: fakekafkaclient.Get(ctx),
+			kafkachannelLister: listers.GetKafkaChannelLister(),
+			deploymentLister:   listers.GetDeploymentLister(),
+			serviceLister:      listers.GetServiceLister(),
+		}
+		return kafkasecretinjection.NewReconciler(ctx, r.logger.Sugar(), r.kubeClientset.CoreV1(), listers.GetSecretLister(), controller.GetEventRecorder(ctx), r)
+	}, logger.Desugar())
+}
+
+// Test That No Receiver Ingress Is Reconciled By Default (External Access Disabled)
+func TestReconcileReceiverIngressDisabled(t *testing.T) {
+
+	// Create A Reconciler With The Default (Disabled) Receiver Ingress Config
+	kubeClientset := fakekubeclientset.NewSimpleClientset()
+	r := &Reconciler{
+		logger:        logtesting.TestLogger(t).Desugar(),
+		kubeClientset: kubeClientset,
+		config:        controllertesting.NewConfig(),
+	}
+
+	// Perform The Test
+	err := r.reconcileReceiverIngress(context.TODO(), controllertesting.NewKafkaSecret())
+
+	// Verify The Results (No Ingress Was Requested From Or Created In Kubernetes)
+	assert.Nil(t, err)
+	assert.False(t, r.config.Receiver.Ingress.Enabled)
+	assert.Empty(t, kubeClientset.Actions())
+}
+
+// Test The Receiver Ingress Model Without The Optional Host, Class & TLS Settings
+func TestNewReceiverIngressMinimal(t *testing.T) {
+
+	// Create A Reconciler With A Minimal (Enabled Only) Receiver Ingress Config
+	config := controllertesting.NewConfig()
+	config.Receiver.Ingress.Enabled = true
+	r := &Reconciler{config: config}
+
+	// Perform The Test
+	ingress := r.newReceiverIngress(controllertesting.NewKafkaSecret())
+
+	// Verify The Results (All Hosts Routed To The Receiver Service Without TLS)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, controllertesting.ReceiverDeploymentName, ingress.Name)
+	assert.Nil(t, ingress.Annotations)
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Empty(t, ingress.Spec.TLS)
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	assert.Equal(t, controllertesting.ReceiverServiceName, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName)
+}
@@ -23,8 +23,12 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgotesting "k8s.io/client-go/testing"
@@ -35,6 +39,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
 	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
@@ -74,15 +79,19 @@ const (
 	KafkaSecretKey         = KafkaSecretNamespace + "/" + KafkaSecretName
 	ReceiverDeploymentName = KafkaSecretName + "-b9176d5f-receiver" // Truncated MD5 Hash Of KafkaSecretName
 	ReceiverServiceName    = ReceiverDeploymentName
+	ReceiverMetricsService = ReceiverDeploymentName + "-metrics"
 	TopicName              = KafkaChannelNamespace + "." + KafkaChannelName
 
 	KafkaSecretDataValueBrokers  = "TestKafkaSecretDataBrokers"
 	KafkaSecretDataValueUsername = "TestKafkaSecretDataUsername"
 	KafkaSecretDataValuePassword = "TestKafkaSecretDataPassword"
 
+	PreviousKafkaSecretDataValueBrokers = "TestPreviousKafkaSecretDataBrokers"
+
 	// ChannelSpec Test Data
 	NumPartitions     = 123
 	ReplicationFactor = 456
+	RetentionMillis   = 3600000
 
 	// Test MetaData
 	ErrorString   = "Expected Mock Test Error"
@@ -100,6 +109,24 @@ const (
 	ReceiverCpuRequest    = "10m"
 	ReceiverCpuLimit      = "100m"
 
+	// Test LimitRange Data (Max Container CPU Below Both The Dispatcher & Receiver CPU Limits)
+	LimitRangeName   = "test-limitrange"
+	LimitRangeCpuMax = "50m"
+
+	// Test Dispatcher Scheduling Annotation Data
+	DispatcherNodeSelectorJson    = `{"topology.kubernetes.io/zone":"us-east-1a"}`
+	DispatcherAffinityJson        = `{"podAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"labelSelector":{"matchLabels":{"app":"subscriber"}},"topologyKey":"kubernetes.io/hostname"}]}}`
+	InvalidDispatcherAffinityJson = `{"podAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"labelSelector":{"matchLabels":{"app":"subscriber"}}}]}}`
+
+	// Test Dispatcher Sharding Annotation Data
+	DispatcherShards        = "2"
+	InvalidDispatcherShards = "17"
+
+	// Test Receiver Ingress Data
+	ReceiverIngressHost          = "kafka-receiver.example.com"
+	ReceiverIngressClassName     = "nginx"
+	ReceiverIngressTLSSecretName = "kafka-receiver-tls"
+
 	ControllerConfigYaml = `
 receiver:
   cpuLimit: 200m
@@ -151,6 +178,7 @@ Producer:
 
 var (
 	DefaultRetentionMillisString = strconv.FormatInt(DefaultRetentionMillis, 10)
+	RetentionMillisString        = strconv.FormatInt(RetentionMillis, 10)
 )
 
 //
@@ -244,6 +272,13 @@ func WithKafkaSecretDeleted(secret *corev1.Secret) {
 	secret.ObjectMeta.SetDeletionTimestamp(&deleteTime)
 }
 
+// Set The Kafka Secret's SASL Mechanism
+func WithKafkaSecretSaslMechanism(mechanism string) KafkaSecretOption {
+	return func(secret *corev1.Secret) {
+		secret.Data[constants.KafkaSecretDataKeySaslMechanism] = []byte(mechanism)
+	}
+}
+
 // Set The Kafka Secret's Finalizer
 func WithKafkaSecretFinalizer(secret *corev1.Secret) {
 	secret.ObjectMeta.Finalizers = []string{constants.EventingKafkaFinalizerPrefix + "kafkasecrets.eventing-kafka.knative.dev"}
@@ -307,7 +342,6 @@ func NewKafkaChannel(options ...KafkaChannelOption) *kafkav1beta1.KafkaChannel {
 		Spec: kafkav1beta1.KafkaChannelSpec{
 			NumPartitions:     NumPartitions,
 			ReplicationFactor: ReplicationFactor,
-			// TODO RetentionMillis:   RetentionMillis,
 		},
 	}
 
@@ -350,6 +384,40 @@ func WithAnnotations(kafkachannel *kafkav1beta1.KafkaChannel) {
 	}
 }
 
+// Set The KafkaChannel's Dispatcher NodeSelector & Affinity Annotations (Must Follow WithMetaData / WithAnnotations)
+func WithDispatcherSchedulingAnnotations(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Annotations[constants.DispatcherNodeSelectorAnnotation] = DispatcherNodeSelectorJson
+	kafkachannel.ObjectMeta.Annotations[constants.DispatcherAffinityAnnotation] = DispatcherAffinityJson
+}
+
+// Set The KafkaChannel's Dispatcher Affinity Annotation To An Invalid Value (Must Follow WithMetaData / WithAnnotations)
+func WithInvalidDispatcherAffinityAnnotation(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Annotations[constants.DispatcherAffinityAnnotation] = InvalidDispatcherAffinityJson
+}
+
+// Set The KafkaChannel's Dispatcher Shards Annotation (Must Follow WithMetaData / WithAnnotations)
+func WithDispatcherShardsAnnotation(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Annotations[sharding.DispatcherShardsAnnotation] = DispatcherShards
+}
+
+// Set The KafkaChannel's Dispatcher Shards Annotation To An Invalid Value (Must Follow WithMetaData / WithAnnotations)
+func WithInvalidDispatcherShardsAnnotation(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Annotations[sharding.DispatcherShardsAnnotation] = InvalidDispatcherShards
+}
+
+// Set The KafkaChannel's (Non-Default) Topic Retention
+func WithRetentionMillis(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.RetentionMillis = RetentionMillis
+}
+
+// Set The KafkaChannel's Delivery Guarantee Annotation To "at-least-once"
+func WithAtLeastOnceDeliveryGuarantee(kafkachannel *kafkav1beta1.KafkaChannel) {
+	if kafkachannel.ObjectMeta.Annotations == nil {
+		kafkachannel.ObjectMeta.Annotations = map[string]string{}
+	}
+	kafkachannel.ObjectMeta.Annotations[kafkav1beta1.DeliveryGuaranteeAnnotationKey] = kafkav1beta1.DeliveryGuaranteeAtLeastOnce
+}
+
 // Set The KafkaChannel's Labels
 func WithLabels(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.ObjectMeta.Labels = map[string]string{
@@ -358,6 +426,11 @@ func WithLabels(kafkachannel *kafkav1beta1.KafkaChannel) {
 	}
 }
 
+// Set The KafkaChannel's External Dispatcher Label (Must Follow WithMetaData / WithLabels)
+func WithExternalDispatcherLabel(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.ObjectMeta.Labels[constants.DispatcherLabel] = constants.DispatcherLabelValueExternal
+}
+
 // Set The KafkaChannel's Address
 func WithAddress(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Status.SetAddress(&apis.URL{
@@ -413,11 +486,51 @@ func WithDispatcherDeploymentReady(kafkachannel *kafkav1beta1.KafkaChannel) {
 	// kafkachannel.Status.PropagateDispatcherStatus()
 }
 
+// Set The KafkaChannel's Dispatcher As Not Managed (Consumed By An External System)
+func WithDispatcherNotManaged(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherNotManaged(event.DispatcherNotManaged.String(), "No Dispatcher Is Managed For KafkaChannels Labelled %s=%s", constants.DispatcherLabel, constants.DispatcherLabelValueExternal)
+}
+
 // Set The KafkaChannel's Dispatcher Deployment As Failed
 func WithDispatcherFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Create Dispatcher Deployment: inducing failure for create deployments")
 }
 
+// Set The KafkaChannel's Dispatcher Deployment As Failed To Update
+func WithDispatcherUpdateFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Update Dispatcher Deployment: inducing failure for update deployments")
+}
+
+// Set The KafkaChannel's Dispatcher Deployment As Failed Due To LimitRange Violations
+func WithDispatcherLimitRangeFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Dispatcher Deployment Resources Rejected: %s", NewDispatcherLimitRangeError())
+}
+
+// Set The KafkaChannel's Dispatcher Deployment As Failed Due To An Invalid Affinity Annotation
+func WithDispatcherAffinityFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Failed To Generate Dispatcher Deployment: %s", NewDispatcherAffinityError())
+}
+
+// Set The KafkaChannel's Dispatcher Deployment As Failed Due To An Invalid Shards Annotation
+func WithDispatcherShardsFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherDeploymentReconciliationFailed.String(), "Invalid Dispatcher Shards: %s", NewDispatcherShardsError())
+}
+
+// Set The KafkaChannel's Receiver Deployment As Failed Due To LimitRange Violations
+func WithReceiverDeploymentLimitRangeFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkEndpointsFailed(event.ReceiverDeploymentReconciliationFailed.String(), "Receiver Deployment Failed: %s", NewReceiverLimitRangeError())
+}
+
+// Set The KafkaChannel's Receiver Deployment As Failed Due To A Container Failing To Start
+func WithReceiverContainerFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkEndpointsFailed(event.ReceiverContainerFailed.String(), "Receiver Deployment Container Failed: %v", NewContainerFailure(NewDedicatedReceiverDeployment()))
+}
+
+// Set The KafkaChannel's Dispatcher Deployment As Failed Due To A Container Failing To Start
+func WithDispatcherContainerFailed(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Status.MarkDispatcherFailed(event.DispatcherContainerFailed.String(), "Dispatcher Deployment Container Failed: %v", NewContainerFailure(NewKafkaChannelDispatcherDeployment()))
+}
+
 // Set The KafkaChannel's Topic READY
 func WithTopicReady(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Status.MarkTopicTrue()
@@ -488,6 +601,86 @@ func NewKafkaChannelReceiverService() *corev1.Service {
 	}
 }
 
+// Utility Function For Creating The Receiver (HTTP Only) Service Expected With A Separate Metrics Service
+func NewKafkaChannelReceiverHttpService() *corev1.Service {
+	service := NewKafkaChannelReceiverService()
+	delete(service.Labels, "k8s-app")
+	service.Spec.Ports = service.Spec.Ports[:1]
+	return service
+}
+
+// Utility Function For Creating The Separate Receiver Metrics Service
+func NewKafkaChannelReceiverMetricsService() *corev1.Service {
+	service := NewKafkaChannelReceiverService()
+	service.Name = ReceiverMetricsService
+	service.Spec.Ports = service.Spec.Ports[1:]
+	return service
+}
+
+// Utility Function For Creating A Receiver Ingress Config (Enabled With Host, Class & TLS) For Testing
+func NewReceiverIngressConfig() config.EKReceiverIngressConfig {
+	return config.EKReceiverIngressConfig{
+		Enabled:       true,
+		Host:          ReceiverIngressHost,
+		ClassName:     ReceiverIngressClassName,
+		TLSSecretName: ReceiverIngressTLSSecretName,
+		Annotations: map[string]string{
+			"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+		},
+	}
+}
+
+// Utility Function For Creating The Receiver Ingress Expected For NewReceiverIngressConfig()
+func NewKafkaChannelReceiverIngress() *networkingv1beta1.Ingress {
+	className := ReceiverIngressClassName
+	return &networkingv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1beta1.SchemeGroupVersion.String(),
+			Kind:       constants.IngressKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ReceiverDeploymentName,
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+			},
+			Labels: map[string]string{
+				"kafkachannel-receiver": "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				NewSecretOwnerRef(),
+			},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			IngressClassName: &className,
+			TLS: []networkingv1beta1.IngressTLS{
+				{
+					Hosts:      []string{ReceiverIngressHost},
+					SecretName: ReceiverIngressTLSSecretName,
+				},
+			},
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: ReceiverIngressHost,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: ReceiverServiceName,
+										ServicePort: intstr.FromInt(constants.HttpServicePortNumber),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // Utility Function For Creating A Receiver Deployment For The Test Channel
 func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 	replicas := int32(ReceiverReplicas)
@@ -503,6 +696,9 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 				"app":                   ReceiverDeploymentName,
 				"kafkachannel-receiver": "true",
 			},
+			Annotations: map[string]string{
+				constants.KafkaBrokersChecksumAnnotation: NewKafkaBrokersChecksum(KafkaSecretDataValueBrokers),
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				NewSecretOwnerRef(),
 			},
@@ -519,6 +715,9 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 					Labels: map[string]string{
 						"app": ReceiverDeploymentName,
 					},
+					Annotations: map[string]string{
+						constants.KafkaBrokersChecksumAnnotation: NewKafkaBrokersChecksum(KafkaSecretDataValueBrokers),
+					},
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccount,
@@ -577,6 +776,12 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 									Name:  commonenv.HealthPortEnvVarKey,
 									Value: strconv.Itoa(HealthPort),
 								},
+								{
+									Name: commonenv.PodNameEnvVarKey,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+									},
+								},
 								{
 									Name: commonenv.KafkaBrokerEnvVarKey,
 									ValueFrom: &corev1.EnvVarSource{
@@ -604,6 +809,7 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 										},
 									},
 								},
+								util.KafkaSaslMechanismEnvVar(KafkaSecretName),
 							},
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							Resources: corev1.ResourceRequirements{
@@ -624,11 +830,104 @@ func NewKafkaChannelReceiverDeployment() *appsv1.Deployment {
 	}
 }
 
+// Utility Function For Creating A KafkaChannel "Channel" Service Referencing The Dedicated Receiver For Testing
+func NewDedicatedReceiverKafkaChannelService() *corev1.Service {
+	service := NewKafkaChannelService()
+	service.Spec.ExternalName = dedicatedReceiverName() + "." + commonconstants.KnativeEventingNamespace + ".svc.cluster.local"
+	return service
+}
+
+// Utility Function For Creating A Dedicated (Receiver "channel" Mode) Receiver Service For Testing
+func NewDedicatedReceiverService() *corev1.Service {
+
+	// Start With The Shared Receiver Service & Apply The Dedicated Name, Labels & Owner
+	receiverName := dedicatedReceiverName()
+	service := NewKafkaChannelReceiverService()
+	service.ObjectMeta.Name = receiverName
+	service.ObjectMeta.Labels = map[string]string{
+		constants.KafkaChannelReceiverLabel:  "true",
+		constants.KafkaChannelNameLabel:      KafkaChannelName,
+		constants.KafkaChannelNamespaceLabel: KafkaChannelNamespace,
+		constants.K8sAppChannelSelectorLabel: constants.K8sAppChannelSelectorValue,
+	}
+	service.ObjectMeta.OwnerReferences = []metav1.OwnerReference{NewChannelOwnerRef()}
+	service.Spec.Selector = map[string]string{"app": receiverName}
+	return service
+}
+
+// Utility Function For Creating The Dedicated Receiver (HTTP Only) Service Expected With A Separate Metrics Service
+func NewDedicatedReceiverHttpService() *corev1.Service {
+	service := NewDedicatedReceiverService()
+	delete(service.Labels, constants.K8sAppChannelSelectorLabel)
+	service.Spec.Ports = service.Spec.Ports[:1]
+	return service
+}
+
+// Utility Function For Creating The Separate Dedicated Receiver Metrics Service
+func NewDedicatedReceiverMetricsService() *corev1.Service {
+	service := NewDedicatedReceiverService()
+	service.Name = util.ChannelReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName},
+	})
+	service.Spec.Ports = service.Spec.Ports[1:]
+	return service
+}
+
+// Utility Function For Creating The Dedicated Receiver Ingress Expected For NewReceiverIngressConfig()
+func NewDedicatedReceiverIngress() *networkingv1beta1.Ingress {
+	receiverName := dedicatedReceiverName()
+	ingress := NewKafkaChannelReceiverIngress()
+	ingress.ObjectMeta.Name = receiverName
+	ingress.ObjectMeta.Labels = map[string]string{
+		constants.KafkaChannelReceiverLabel:  "true",
+		constants.KafkaChannelNameLabel:      KafkaChannelName,
+		constants.KafkaChannelNamespaceLabel: KafkaChannelNamespace,
+	}
+	ingress.ObjectMeta.OwnerReferences = []metav1.OwnerReference{NewChannelOwnerRef()}
+	ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName = receiverName
+	return ingress
+}
+
+// Utility Function For Creating A Dedicated (Receiver "channel" Mode) Receiver Deployment For Testing
+func NewDedicatedReceiverDeployment() *appsv1.Deployment {
+
+	// Start With The Shared Receiver Deployment & Apply The Dedicated Name, Labels & Owner
+	receiverName := dedicatedReceiverName()
+	deployment := NewKafkaChannelReceiverDeployment()
+	deployment.ObjectMeta.Name = receiverName
+	deployment.ObjectMeta.Labels = map[string]string{
+		constants.AppLabel:                   receiverName,
+		constants.KafkaChannelReceiverLabel:  "true",
+		constants.KafkaChannelNameLabel:      KafkaChannelName,
+		constants.KafkaChannelNamespaceLabel: KafkaChannelNamespace,
+	}
+	deployment.ObjectMeta.Annotations = nil
+	deployment.ObjectMeta.OwnerReferences = []metav1.OwnerReference{NewChannelOwnerRef()}
+	deployment.Spec.Selector.MatchLabels = map[string]string{"app": receiverName}
+	deployment.Spec.Template.ObjectMeta.Labels = map[string]string{"app": receiverName}
+	deployment.Spec.Template.ObjectMeta.Annotations = nil
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Name = receiverName
+	for index := range container.Env {
+		if container.Env[index].Name == commonenv.ServiceNameEnvVarKey {
+			container.Env[index].Value = receiverName
+		}
+	}
+	return deployment
+}
+
+// Get The Expected Dedicated Receiver Name For The Test KafkaChannel
+func dedicatedReceiverName() string {
+	return util.ChannelReceiverDnsSafeName(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName},
+	})
+}
+
 // Utility Function For Creating A Custom KafkaChannel Dispatcher Service For Testing
 func NewKafkaChannelDispatcherService() *corev1.Service {
 
 	// Get The Expected Service Name For The Test KafkaChannel
-	serviceName := util.DispatcherDnsSafeName(&kafkav1beta1.KafkaChannel{
+	serviceName := util.DispatcherDnsSafeName(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{
 		ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName},
 	})
 
@@ -670,12 +969,15 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 
 	// Get The Expected Dispatcher & Topic Names For The Test KafkaChannel
 	sparseKafkaChannel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName}}
-	dispatcherName := util.DispatcherDnsSafeName(sparseKafkaChannel)
+	dispatcherName := util.DispatcherDnsSafeName(constants.DefaultNamingPolicy, sparseKafkaChannel)
 	topicName := util.TopicName(sparseKafkaChannel)
 
 	// Replicas Int Reference
 	replicas := int32(DispatcherReplicas)
 
+	// Termination Grace Period Of The Default Shutdown Timeout Plus The Buffer
+	terminationGracePeriodSeconds := int64(20)
+
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: appsv1.SchemeGroupVersion.String(),
@@ -708,7 +1010,8 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccount,
+					ServiceAccountName:            ServiceAccount,
+					TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
 					Containers: []corev1.Container{
 						{
 							Name:  dispatcherName,
@@ -766,6 +1069,16 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 									Name:  commonenv.KafkaTopicEnvVarKey,
 									Value: topicName,
 								},
+								{
+									Name: commonenv.NodeNameEnvVarKey,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+									},
+								},
+								{
+									Name:  commonenv.TerminationGracePeriodEnvVarKey,
+									Value: strconv.FormatInt(terminationGracePeriodSeconds, 10),
+								},
 								{
 									Name: commonenv.KafkaBrokerEnvVarKey,
 									ValueFrom: &corev1.EnvVarSource{
@@ -793,6 +1106,7 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 										},
 									},
 								},
+								util.KafkaSaslMechanismEnvVar(KafkaSecretName),
 							},
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							Resources: corev1.ResourceRequirements{
@@ -813,6 +1127,119 @@ func NewKafkaChannelDispatcherDeployment() *appsv1.Deployment {
 	}
 }
 
+// Utility Function For Creating The Dispatcher Service Of The Specified Shard (Above Zero) Of The Test KafkaChannel
+func NewKafkaChannelDispatcherShardService(shard int) *corev1.Service {
+	serviceName := util.DispatcherShardDnsSafeName(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName},
+	}, shard)
+	service := NewKafkaChannelDispatcherService()
+	service.Name = serviceName
+	service.Labels[constants.DispatcherShardLabel] = strconv.Itoa(shard)
+	service.Spec.Selector["app"] = serviceName
+	return service
+}
+
+// Utility Function For Creating The Dispatcher Deployment Of The Specified Shard (Above Zero) Of The Test KafkaChannel
+func NewKafkaChannelDispatcherShardDeployment(shard int) *appsv1.Deployment {
+	dispatcherName := util.DispatcherShardDnsSafeName(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName},
+	}, shard)
+	deployment := NewKafkaChannelDispatcherDeployment()
+	deployment.Name = dispatcherName
+	deployment.Labels["app"] = dispatcherName
+	deployment.Labels[constants.DispatcherShardLabel] = strconv.Itoa(shard)
+	deployment.Spec.Selector.MatchLabels["app"] = dispatcherName
+	deployment.Spec.Template.Labels["app"] = dispatcherName
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Name = dispatcherName
+	envVars := make([]corev1.EnvVar, 0, len(container.Env)+1)
+	for _, envVar := range container.Env {
+		if envVar.Name == commonenv.ServiceNameEnvVarKey {
+			envVar.Value = dispatcherName
+		}
+		if envVar.Name == commonenv.KafkaBrokerEnvVarKey {
+			envVars = append(envVars, corev1.EnvVar{Name: commonenv.DispatcherShardEnvVarKey, Value: strconv.Itoa(shard)})
+		}
+		envVars = append(envVars, envVar)
+	}
+	container.Env = envVars
+	return deployment
+}
+
+// Utility Function For Creating The Checksum Of The Specified Kafka Secret Brokers
+func NewKafkaBrokersChecksum(brokers string) string {
+	return util.KafkaBrokersChecksum(&corev1.Secret{Data: map[string][]byte{constants.KafkaSecretDataKeyBrokers: []byte(brokers)}})
+}
+
+// Utility Function For Annotating The Specified Deployment (& Optionally Its Pod Template) With The Checksum Of The Specified Kafka Brokers
+func WithKafkaBrokersChecksum(deployment *appsv1.Deployment, brokers string, podTemplate bool) *appsv1.Deployment {
+	checksum := NewKafkaBrokersChecksum(brokers)
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[constants.KafkaBrokersChecksumAnnotation] = checksum
+	if podTemplate {
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[constants.KafkaBrokersChecksumAnnotation] = checksum
+	}
+	return deployment
+}
+
+// Utility Function For Marking The Specified Deployment's Replicas As Unavailable
+func WithDeploymentUnavailable(deployment *appsv1.Deployment) *appsv1.Deployment {
+	deployment.Status.Replicas = *deployment.Spec.Replicas
+	deployment.Status.UnavailableReplicas = *deployment.Spec.Replicas
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentAvailable,
+		Status:  corev1.ConditionFalse,
+		Reason:  "MinimumReplicasUnavailable",
+		Message: "Deployment does not have minimum availability.",
+	}}
+	return deployment
+}
+
+// Utility Function For Creating A Pod Of The Specified Deployment Whose Container Is Failing To Pull Its Image
+func NewImagePullBackOffPod(deployment *appsv1.Deployment) *corev1.Pod {
+	failure := NewContainerFailure(deployment)
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: deployment.Namespace,
+			Name:      failure.Pod,
+			Labels:    deployment.Spec.Selector.MatchLabels,
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  failure.Container,
+				Image: deployment.Spec.Template.Spec.Containers[0].Image,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: failure.Reason, Message: failure.Message}},
+			}},
+		},
+	}
+}
+
+// Utility Function For Creating The Expected ContainerFailure Of The Specified Deployment's ImagePullBackOff Pod
+func NewContainerFailure(deployment *appsv1.Deployment) *util.ContainerFailure {
+	container := deployment.Spec.Template.Spec.Containers[0]
+	return &util.ContainerFailure{
+		Pod:       deployment.Name + "-pod",
+		Container: container.Name,
+		Reason:    "ImagePullBackOff",
+		Message:   fmt.Sprintf("Back-off pulling image \"%s\"", container.Image),
+	}
+}
+
+// Utility Function For Creating The Expected Error Message For The InvalidDispatcherShards Annotation
+func NewDispatcherShardsError() string {
+	_, err := sharding.ParseDispatcherShards(InvalidDispatcherShards)
+	return err.Error()
+}
+
 // Utility Function For Creating A New OwnerReference Model For The Test Kafka Secret
 func NewSecretOwnerRef() metav1.OwnerReference {
 	blockOwnerDeletion := true
@@ -854,6 +1281,18 @@ func NewKafkaChannelLabelUpdate(kafkachannel *kafkav1beta1.KafkaChannel) clientg
 	}
 }
 
+// Utility Function For Creating A DeleteActionImpl For The Specified Resource In The Knative Eventing Namespace
+func NewDeleteActionImpl(resource string, name string) clientgotesting.DeleteActionImpl {
+	return clientgotesting.DeleteActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Verb:      "delete",
+			Resource:  schema.GroupVersionResource{Resource: resource},
+		},
+		Name: name,
+	}
+}
+
 // Utility Function For Creating A PatchActionImpl For The Finalizer Patch Command
 func NewFinalizerPatchActionImpl() clientgotesting.PatchActionImpl {
 	return clientgotesting.PatchActionImpl{
@@ -889,3 +1328,114 @@ func NewKafkaChannelFinalizerUpdateEvent() string {
 func NewKafkaChannelSuccessfulFinalizedEvent() string {
 	return reconcilertesting.Eventf(corev1.EventTypeNormal, event.KafkaChannelFinalized.String(), fmt.Sprintf("KafkaChannel Finalized Successfully: \"%s/%s\"", KafkaChannelNamespace, KafkaChannelName))
 }
+
+// Utility Function For Creating The Expected VerticalPodAutoscaler Of The Test Dispatcher Deployment
+func NewKafkaChannelDispatcherVerticalPodAutoscaler() *unstructured.Unstructured {
+	return util.NewVerticalPodAutoscaler(NewKafkaChannelDispatcherDeployment())
+}
+
+// Utility Function For Creating The Expected VerticalPodAutoscaler Of The Test Receiver Deployment
+func NewKafkaChannelReceiverVerticalPodAutoscaler() *unstructured.Unstructured {
+	return util.NewVerticalPodAutoscaler(NewKafkaChannelReceiverDeployment())
+}
+
+// Utility Reactor For Simulating The Absence Of The (Optional) VerticalPodAutoscaler CRD
+func InduceVerticalPodAutoscalerCrdNotFound(action clientgotesting.Action) (bool, runtime.Object, error) {
+	if action.Matches("create", constants.VerticalPodAutoscalerResource) {
+		return true, nil, k8serrors.NewNotFound(util.VerticalPodAutoscalerGVR.GroupResource(), "")
+	}
+	return false, nil, nil
+}
+
+// Utility Function For Creating A Restrictive LimitRange In The Knative-Eventing Namespace For Testing
+func NewLimitRange() *corev1.LimitRange {
+	return &corev1.LimitRange{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "LimitRange",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Name:      LimitRangeName,
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type: corev1.LimitTypeContainer,
+					Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(LimitRangeCpuMax)},
+				},
+			},
+		},
+	}
+}
+
+// Utility Function For Creating The Expected LimitRange Violation Error Message For The Test Dispatcher Deployment
+func NewDispatcherLimitRangeError() string {
+	sparseKafkaChannel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Namespace: KafkaChannelNamespace, Name: KafkaChannelName}}
+	dispatcherName := util.DispatcherDnsSafeName(constants.DefaultNamingPolicy, sparseKafkaChannel)
+	return fmt.Sprintf("resources violate LimitRange: container '%s' cpu limit %s exceeds maximum %s (%s); container '%s' cpu request %s exceeds maximum %s (%s)",
+		dispatcherName, DispatcherCpuLimit, LimitRangeCpuMax, LimitRangeName,
+		dispatcherName, DispatcherCpuRequest, LimitRangeCpuMax, LimitRangeName)
+}
+
+// Utility Function For Creating The Expected LimitRange Violation Error Message For The Test Receiver Deployment
+func NewReceiverLimitRangeError() string {
+	return fmt.Sprintf("resources violate LimitRange: container '%s' cpu limit %s exceeds maximum %s (%s)",
+		ReceiverDeploymentName, ReceiverCpuLimit, LimitRangeCpuMax, LimitRangeName)
+}
+
+// Utility Function For Creating TopologySpreadConstraints (Across Zones) For Testing
+func NewTopologySpreadConstraints() []corev1.TopologySpreadConstraint {
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelZoneFailureDomainStable,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		},
+	}
+}
+
+// Utility Function For Creating A Node Affinity (Requiring Linux Nodes) For Testing
+func NewAffinity() *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      corev1.LabelOSStable,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{"linux"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Utility Function For Creating The Dispatcher NodeSelector Matching DispatcherNodeSelectorJson
+func NewDispatcherNodeSelector() map[string]string {
+	return map[string]string{corev1.LabelZoneFailureDomainStable: "us-east-1a"}
+}
+
+// Utility Function For Creating The Dispatcher Pod Affinity (Toward Subscriber Pods) Matching DispatcherAffinityJson
+func NewDispatcherPodAffinity() *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "subscriber"}},
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+		},
+	}
+}
+
+// Utility Function For Creating The Expected Error Message For The InvalidDispatcherAffinityJson Annotation
+func NewDispatcherAffinityError() string {
+	return fmt.Sprintf("invalid '%s' annotation: podAffinity.requiredDuringSchedulingIgnoredDuringExecution[0]: topologyKey must be specified", constants.DispatcherAffinityAnnotation)
+}
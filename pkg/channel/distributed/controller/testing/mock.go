@@ -21,6 +21,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
 
 //
@@ -32,11 +33,19 @@ var _ kafkaadmin.AdminClientInterface = &MockAdminClient{}
 
 // Mock Kafka AdminClient Implementation
 type MockAdminClient struct {
-	closeCalled         bool
-	createTopicsCalled  bool
-	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
-	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
+	closeCalled                     bool
+	createTopicsCalled              bool
+	deleteTopicsCalled              bool
+	describeTopicsCalled            bool
+	alterTopicConfigCalled          bool
+	createPartitionsCalled          bool
+	MockCreateTopicFunc             func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockDeleteTopicFunc             func(context.Context, string) *sarama.TopicError
+	MockDescribeTopicFunc           func(context.Context, string) *sarama.TopicError
+	MockDescribeTopicConfigFunc     func(context.Context, string) (map[string]string, *sarama.TopicError)
+	MockAlterTopicConfigFunc        func(context.Context, string, map[string]*string) *sarama.TopicError
+	MockDescribeTopicPartitionsFunc func(context.Context, string) (int32, *sarama.TopicError)
+	MockCreatePartitionsFunc        func(context.Context, string, int32) *sarama.TopicError
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
@@ -69,6 +78,69 @@ func (m *MockAdminClient) DeleteTopicsCalled() bool {
 	return m.deleteTopicsCalled
 }
 
+// Mock Kafka AdminClient DescribeTopic() Function - Calls Custom DescribeTopic() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) DescribeTopic(ctx context.Context, topicName string) *sarama.TopicError {
+	m.describeTopicsCalled = true
+	if m.MockDescribeTopicFunc != nil {
+		return m.MockDescribeTopicFunc(ctx, topicName)
+	}
+	errMsg := "mock DescribeTopic() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To DescribeTopics()
+func (m *MockAdminClient) DescribeTopicsCalled() bool {
+	return m.describeTopicsCalled
+}
+
+// Mock Kafka AdminClient DescribeTopicConfig() Function - Calls Custom DescribeTopicConfig() If Specified, Otherwise Returns The Default Retention
+func (m *MockAdminClient) DescribeTopicConfig(ctx context.Context, topicName string) (map[string]string, *sarama.TopicError) {
+	if m.MockDescribeTopicConfigFunc != nil {
+		return m.MockDescribeTopicConfigFunc(ctx, topicName)
+	}
+	errMsg := "mock DescribeTopicConfig() success"
+	return map[string]string{constants.KafkaTopicConfigRetentionMs: DefaultRetentionMillisString}, &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Mock Kafka AdminClient AlterTopicConfig() Function - Calls Custom AlterTopicConfig() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	m.alterTopicConfigCalled = true
+	if m.MockAlterTopicConfigFunc != nil {
+		return m.MockAlterTopicConfigFunc(ctx, topicName, configEntries)
+	}
+	errMsg := "mock AlterTopicConfig() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To AlterTopicConfig()
+func (m *MockAdminClient) AlterTopicConfigCalled() bool {
+	return m.alterTopicConfigCalled
+}
+
+// Mock Kafka AdminClient DescribeTopicPartitions() Function - Calls Custom DescribeTopicPartitions() If Specified, Otherwise Returns The Test Data Partitions
+func (m *MockAdminClient) DescribeTopicPartitions(ctx context.Context, topicName string) (int32, *sarama.TopicError) {
+	if m.MockDescribeTopicPartitionsFunc != nil {
+		return m.MockDescribeTopicPartitionsFunc(ctx, topicName)
+	}
+	errMsg := "mock DescribeTopicPartitions() success"
+	return NumPartitions, &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Mock Kafka AdminClient CreatePartitions() Function - Calls Custom CreatePartitions() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+	m.createPartitionsCalled = true
+	if m.MockCreatePartitionsFunc != nil {
+		return m.MockCreatePartitionsFunc(ctx, topicName, count)
+	}
+	errMsg := "mock CreatePartitions() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To CreatePartitions()
+func (m *MockAdminClient) CreatePartitionsCalled() bool {
+	return m.createPartitionsCalled
+}
+
 // Mock Kafka AdminClient Close Function - NoOp
 func (m *MockAdminClient) Close() error {
 	m.closeCalled = true
@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+//
+// Get The Dispatcher NodeSelector From The Specified KafkaChannel's (Optional) Annotation
+//
+// The annotation value is a JSON object of node label keys / values, (e.g. {"topology.kubernetes.io/zone":"us-east-1a"})
+// allowing the Dispatcher of a latency-sensitive KafkaChannel to be scheduled alongside its Subscribers.  A nil
+// NodeSelector is returned if the annotation is not present.
+//
+func DispatcherNodeSelector(channel *kafkav1beta1.KafkaChannel) (map[string]string, error) {
+
+	// Get The Annotation Value (If Any)
+	value, ok := channel.Annotations[constants.DispatcherNodeSelectorAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	// Parse The NodeSelector
+	nodeSelector := map[string]string{}
+	err := unmarshalAnnotation(value, &nodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' annotation: %v", constants.DispatcherNodeSelectorAnnotation, err)
+	}
+
+	// Validate The Label Keys & Values
+	for key, labelValue := range nodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid '%s' annotation: key '%s': %s", constants.DispatcherNodeSelectorAnnotation, key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(labelValue); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid '%s' annotation: value '%s': %s", constants.DispatcherNodeSelectorAnnotation, labelValue, strings.Join(errs, "; "))
+		}
+	}
+
+	// Return The Validated NodeSelector
+	return nodeSelector, nil
+}
+
+//
+// Get The Dispatcher Affinity From The Specified KafkaChannel's (Optional) Annotation
+//
+// The annotation value is a JSON Kubernetes Affinity (node / pod / pod anti-affinity), typically a pod affinity
+// toward the Subscriber's pods, which replaces the Dispatcher Affinity configured in the config-eventing-kafka
+// ConfigMap for the KafkaChannel.  The specified default is returned if the annotation is not present.
+//
+func DispatcherAffinity(channel *kafkav1beta1.KafkaChannel, defaultAffinity *corev1.Affinity) (*corev1.Affinity, error) {
+
+	// Get The Annotation Value (If Any)
+	value, ok := channel.Annotations[constants.DispatcherAffinityAnnotation]
+	if !ok {
+		return defaultAffinity, nil
+	}
+
+	// Parse The Affinity
+	affinity := &corev1.Affinity{}
+	err := unmarshalAnnotation(value, affinity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' annotation: %v", constants.DispatcherAffinityAnnotation, err)
+	}
+
+	// Validate The Affinity
+	err = verifyAffinity(affinity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' annotation: %v", constants.DispatcherAffinityAnnotation, err)
+	}
+
+	// Return The Validated Affinity
+	return affinity, nil
+}
+
+// Strictly Unmarshal The Specified JSON Annotation Value (Rejecting Unknown Fields Which Are Likely Typos)
+func unmarshalAnnotation(value string, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// Verify The Specified Affinity Would Be Accepted By The Kubernetes API (Mirrors The Relevant apiserver PodSpec Validation)
+func verifyAffinity(affinity *corev1.Affinity) error {
+
+	// Verify The NodeAffinity
+	if affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if len(required.NodeSelectorTerms) == 0 {
+				return fmt.Errorf("nodeAffinity.requiredDuringSchedulingIgnoredDuringExecution.nodeSelectorTerms must be specified")
+			}
+			for index, term := range required.NodeSelectorTerms {
+				if err := verifyNodeSelectorTerm(term); err != nil {
+					return fmt.Errorf("nodeAffinity.requiredDuringSchedulingIgnoredDuringExecution.nodeSelectorTerms[%d]: %v", index, err)
+				}
+			}
+		}
+		for index, preferred := range affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			if preferred.Weight < 1 || preferred.Weight > 100 {
+				return fmt.Errorf("nodeAffinity.preferredDuringSchedulingIgnoredDuringExecution[%d].weight must be between 1 and 100", index)
+			}
+			if err := verifyNodeSelectorTerm(preferred.Preference); err != nil {
+				return fmt.Errorf("nodeAffinity.preferredDuringSchedulingIgnoredDuringExecution[%d].preference: %v", index, err)
+			}
+		}
+	}
+
+	// Verify The PodAffinity & PodAntiAffinity
+	if affinity.PodAffinity != nil {
+		if err := verifyPodAffinityTerms("podAffinity", affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution); err != nil {
+			return err
+		}
+	}
+	if affinity.PodAntiAffinity != nil {
+		if err := verifyPodAffinityTerms("podAntiAffinity", affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify The Specified NodeSelectorTerm's Requirements
+func verifyNodeSelectorTerm(term corev1.NodeSelectorTerm) error {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return fmt.Errorf("at least one of matchExpressions or matchFields must be specified")
+	}
+	for index, requirement := range term.MatchExpressions {
+		if errs := validation.IsQualifiedName(requirement.Key); len(errs) > 0 {
+			return fmt.Errorf("matchExpressions[%d].key '%s': %s", index, requirement.Key, strings.Join(errs, "; "))
+		}
+		if err := verifyNodeSelectorOperator(requirement); err != nil {
+			return fmt.Errorf("matchExpressions[%d]: %v", index, err)
+		}
+	}
+	for index, requirement := range term.MatchFields {
+		if requirement.Key != "metadata.name" {
+			return fmt.Errorf("matchFields[%d].key '%s' must be metadata.name", index, requirement.Key)
+		}
+		if requirement.Operator != corev1.NodeSelectorOpIn && requirement.Operator != corev1.NodeSelectorOpNotIn || len(requirement.Values) != 1 {
+			return fmt.Errorf("matchFields[%d] must use the In or NotIn operator with a single value", index)
+		}
+	}
+	return nil
+}
+
+// Verify The Specified NodeSelectorRequirement's Operator & Values Are Consistent
+func verifyNodeSelectorOperator(requirement corev1.NodeSelectorRequirement) error {
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+		if len(requirement.Values) == 0 {
+			return fmt.Errorf("values must be specified for operator %s", requirement.Operator)
+		}
+	case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+		if len(requirement.Values) > 0 {
+			return fmt.Errorf("values must not be specified for operator %s", requirement.Operator)
+		}
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(requirement.Values) != 1 {
+			return fmt.Errorf("a single value must be specified for operator %s", requirement.Operator)
+		}
+		if _, err := strconv.ParseInt(requirement.Values[0], 10, 64); err != nil {
+			return fmt.Errorf("value '%s' must be an integer for operator %s", requirement.Values[0], requirement.Operator)
+		}
+	default:
+		return fmt.Errorf("unknown operator '%s'", requirement.Operator)
+	}
+	return nil
+}
+
+// Verify The Specified Required & Preferred PodAffinityTerms
+func verifyPodAffinityTerms(field string, required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) error {
+	for index, term := range required {
+		if err := verifyPodAffinityTerm(term); err != nil {
+			return fmt.Errorf("%s.requiredDuringSchedulingIgnoredDuringExecution[%d]: %v", field, index, err)
+		}
+	}
+	for index, weightedTerm := range preferred {
+		if weightedTerm.Weight < 1 || weightedTerm.Weight > 100 {
+			return fmt.Errorf("%s.preferredDuringSchedulingIgnoredDuringExecution[%d].weight must be between 1 and 100", field, index)
+		}
+		if err := verifyPodAffinityTerm(weightedTerm.PodAffinityTerm); err != nil {
+			return fmt.Errorf("%s.preferredDuringSchedulingIgnoredDuringExecution[%d].podAffinityTerm: %v", field, index, err)
+		}
+	}
+	return nil
+}
+
+// Verify The Specified PodAffinityTerm's TopologyKey & LabelSelector
+func verifyPodAffinityTerm(term corev1.PodAffinityTerm) error {
+	if len(term.TopologyKey) == 0 {
+		return fmt.Errorf("topologyKey must be specified")
+	}
+	if errs := validation.IsQualifiedName(term.TopologyKey); len(errs) > 0 {
+		return fmt.Errorf("topologyKey '%s': %s", term.TopologyKey, strings.Join(errs, "; "))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(term.LabelSelector); err != nil {
+		return fmt.Errorf("labelSelector: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+// Test The DispatcherNodeSelector() Functionality
+func TestDispatcherNodeSelector(t *testing.T) {
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name        string
+		annotations map[string]string
+		want        map[string]string
+		wantErr     string
+	}
+
+	// Define The Test Cases
+	testCases := []testCase{
+		{
+			name: "No Annotation",
+		},
+		{
+			name:        "Valid NodeSelector",
+			annotations: map[string]string{constants.DispatcherNodeSelectorAnnotation: `{"topology.kubernetes.io/zone":"us-east-1a","disktype":"ssd"}`},
+			want:        map[string]string{"topology.kubernetes.io/zone": "us-east-1a", "disktype": "ssd"},
+		},
+		{
+			name:        "Malformed JSON",
+			annotations: map[string]string{constants.DispatcherNodeSelectorAnnotation: `{"disktype":`},
+			wantErr:     "invalid 'eventing-kafka.knative.dev/dispatcher-node-selector' annotation: unexpected EOF",
+		},
+		{
+			name:        "Invalid Key",
+			annotations: map[string]string{constants.DispatcherNodeSelectorAnnotation: `{"disk type":"ssd"}`},
+			wantErr:     "invalid 'eventing-kafka.knative.dev/dispatcher-node-selector' annotation: key 'disk type'",
+		},
+		{
+			name:        "Invalid Value",
+			annotations: map[string]string{constants.DispatcherNodeSelectorAnnotation: `{"disktype":"fast ssd"}`},
+			wantErr:     "invalid 'eventing-kafka.knative.dev/dispatcher-node-selector' annotation: value 'fast ssd'",
+		},
+	}
+
+	// Run The Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Annotations: testCase.annotations}}
+			nodeSelector, err := DispatcherNodeSelector(channel)
+			if testCase.wantErr != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), testCase.wantErr)
+				assert.Nil(t, nodeSelector)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.want, nodeSelector)
+			}
+		})
+	}
+}
+
+// Test The DispatcherAffinity() Functionality
+func TestDispatcherAffinity(t *testing.T) {
+
+	// Test Data
+	defaultAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{
+					Weight:     10,
+					Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "disktype", Operator: corev1.NodeSelectorOpExists}}},
+				},
+			},
+		},
+	}
+	podAffinity := &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "subscriber"}},
+						TopologyKey:   corev1.LabelZoneFailureDomainStable,
+					},
+				},
+			},
+		},
+	}
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name         string
+		affinity     string
+		noAnnotation bool
+		want         *corev1.Affinity
+		wantErr      string
+	}
+
+	// Define The Test Cases
+	testCases := []testCase{
+		{
+			name:         "No Annotation Uses Default",
+			noAnnotation: true,
+			want:         defaultAffinity,
+		},
+		{
+			name:     "Valid Pod Affinity",
+			affinity: `{"podAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"podAffinityTerm":{"labelSelector":{"matchLabels":{"app":"subscriber"}},"topologyKey":"topology.kubernetes.io/zone"}}]}}`,
+			want:     podAffinity,
+		},
+		{
+			name:     "Valid Node & Pod Anti Affinity",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"cpu-count","operator":"Gt","values":["4"]}]}]}},"podAntiAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"labelSelector":{"matchExpressions":[{"key":"app","operator":"In","values":["noisy"]}]},"topologyKey":"kubernetes.io/hostname"}]}}`,
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "cpu-count", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}}}},
+						},
+					},
+				},
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"noisy"}}}},
+							TopologyKey:   corev1.LabelHostname,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "Malformed JSON",
+			affinity: `{"podAffinity":`,
+			wantErr:  "invalid 'eventing-kafka.knative.dev/dispatcher-affinity' annotation: unexpected EOF",
+		},
+		{
+			name:     "Unknown Field",
+			affinity: `{"podAfinity":{}}`,
+			wantErr:  `invalid 'eventing-kafka.knative.dev/dispatcher-affinity' annotation: json: unknown field "podAfinity"`,
+		},
+		{
+			name:     "Empty Required NodeSelectorTerms",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[]}}}`,
+			wantErr:  "nodeAffinity.requiredDuringSchedulingIgnoredDuringExecution.nodeSelectorTerms must be specified",
+		},
+		{
+			name:     "Empty NodeSelectorTerm",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{}]}}}`,
+			wantErr:  "nodeSelectorTerms[0]: at least one of matchExpressions or matchFields must be specified",
+		},
+		{
+			name:     "Unknown Node Operator",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"disktype","operator":"Like","values":["ssd"]}]}]}}}`,
+			wantErr:  "nodeSelectorTerms[0]: matchExpressions[0]: unknown operator 'Like'",
+		},
+		{
+			name:     "In Operator Without Values",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"disktype","operator":"In"}]}]}}}`,
+			wantErr:  "matchExpressions[0]: values must be specified for operator In",
+		},
+		{
+			name:     "Non-Integer Gt Value",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"cpu-count","operator":"Gt","values":["four"]}]}]}}}`,
+			wantErr:  "matchExpressions[0]: value 'four' must be an integer for operator Gt",
+		},
+		{
+			name:     "Invalid MatchFields Key",
+			affinity: `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchFields":[{"key":"metadata.labels","operator":"In","values":["a"]}]}]}}}`,
+			wantErr:  "nodeSelectorTerms[0]: matchFields[0].key 'metadata.labels' must be metadata.name",
+		},
+		{
+			name:     "Preferred Node Weight Out Of Range",
+			affinity: `{"nodeAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":0,"preference":{"matchExpressions":[{"key":"disktype","operator":"Exists"}]}}]}}`,
+			wantErr:  "nodeAffinity.preferredDuringSchedulingIgnoredDuringExecution[0].weight must be between 1 and 100",
+		},
+		{
+			name:     "Pod Affinity Missing TopologyKey",
+			affinity: `{"podAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"labelSelector":{"matchLabels":{"app":"subscriber"}}}]}}`,
+			wantErr:  "podAffinity.requiredDuringSchedulingIgnoredDuringExecution[0]: topologyKey must be specified",
+		},
+		{
+			name:     "Pod Affinity Invalid LabelSelector",
+			affinity: `{"podAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":[{"labelSelector":{"matchExpressions":[{"key":"app","operator":"Near"}]},"topologyKey":"kubernetes.io/hostname"}]}}`,
+			wantErr:  "podAffinity.requiredDuringSchedulingIgnoredDuringExecution[0]: labelSelector:",
+		},
+		{
+			name:     "Pod Anti Affinity Weight Out Of Range",
+			affinity: `{"podAntiAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":101,"podAffinityTerm":{"topologyKey":"kubernetes.io/hostname"}}]}}`,
+			wantErr:  "podAntiAffinity.preferredDuringSchedulingIgnoredDuringExecution[0].weight must be between 1 and 100",
+		},
+	}
+
+	// Run The Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			channel := &kafkav1beta1.KafkaChannel{}
+			if !testCase.noAnnotation {
+				channel.Annotations = map[string]string{constants.DispatcherAffinityAnnotation: testCase.affinity}
+			}
+			affinity, err := DispatcherAffinity(channel, defaultAffinity)
+			if testCase.wantErr != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), testCase.wantErr)
+				assert.Nil(t, affinity)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.want, affinity)
+			}
+		})
+	}
+}
@@ -65,7 +65,14 @@ func NewChannelOwnerReference(channel *kafkav1beta1.KafkaChannel) metav1.OwnerRe
 // Note - The current implementation creates a single Receiver Deployment for each
 //        Kafka Authentication (K8S Secrets) instance.
 //
-func ReceiverDnsSafeName(kafkaSecretName string) string {
+func ReceiverDnsSafeName(namingPolicy string, kafkaSecretName string) string {
+
+	// Use The Readable Name If Configured & Possible
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("receiver", kafkaSecretName); ok {
+			return readableDnsName
+		}
+	}
 
 	// In order for the resulting name to be a valid DNS component it's length must be no more than 63 characters.
 	// We are consuming 18 chars for the component separators, hash, and Receiver suffix, which reduces the
@@ -75,6 +82,64 @@ func ReceiverDnsSafeName(kafkaSecretName string) string {
 	return fmt.Sprintf("%s-%s-receiver", safeSecretName, GenerateHash(kafkaSecretName, 8))
 }
 
+// Create A DNS Safe Name For The Separate Metrics Service Of The Receiver Using The Specified Kafka Secret
+func ReceiverMetricsDnsSafeName(namingPolicy string, kafkaSecretName string) string {
+
+	// Use The Readable Name If Configured & Possible
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("receiver-metrics", kafkaSecretName); ok {
+			return readableDnsName
+		}
+	}
+
+	// The longer suffix consumes 26 chars for the component separators, hash, and Receiver metrics suffix, which
+	// reduces the available length to 37. We will allocate 32 characters to the kafka secret name leaving an extra buffer.
+	safeSecretName := GenerateValidDnsName(kafkaSecretName, 32, true, false)
+
+	return fmt.Sprintf("%s-%s-receiver-metrics", safeSecretName, GenerateHash(kafkaSecretName, 8))
+}
+
+// Create A DNS Safe Name For The Dedicated Receiver Deployment Of The Specified KafkaChannel (Receiver "channel" Mode)
+func ChannelReceiverDnsSafeName(namingPolicy string, channel *kafkav1beta1.KafkaChannel) string {
+
+	// Use The Readable Name If Configured & Possible
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("receiver", channel.Name, channel.Namespace); ok {
+			return readableDnsName
+		}
+	}
+
+	// Mirrors the Dispatcher naming (26 characters for the channel, 16 for the namespace and an 8 character hash)
+	// with the 9 character Receiver suffix, which keeps the resulting name within the 63 character DNS limit.
+	safeChannelName := GenerateValidDnsName(channel.Name, 26, true, false)
+	safeChannelNamespace := GenerateValidDnsName(channel.Namespace, 16, false, false)
+	hash := GenerateHash(channel.Name+channel.Namespace, 8)
+	return fmt.Sprintf("%s-%s-%s-receiver", safeChannelName, safeChannelNamespace, hash)
+}
+
+// Create A DNS Safe Name For The Separate Metrics Service Of The Dedicated Receiver Of The Specified KafkaChannel
+func ChannelReceiverMetricsDnsSafeName(namingPolicy string, channel *kafkav1beta1.KafkaChannel) string {
+
+	// Use The Readable Name If Configured & Possible
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("receiver-metrics", channel.Name, channel.Namespace); ok {
+			return readableDnsName
+		}
+	}
+
+	// The longer 17 character Receiver metrics suffix reduces the allocation (see ChannelReceiverDnsSafeName) to 20
+	// characters for the channel and 14 for the namespace, which keeps the resulting name within the DNS limit.
+	safeChannelName := GenerateValidDnsName(channel.Name, 20, true, false)
+	safeChannelNamespace := GenerateValidDnsName(channel.Namespace, 14, false, false)
+	hash := GenerateHash(channel.Name+channel.Namespace, 8)
+	return fmt.Sprintf("%s-%s-%s-receiver-metrics", safeChannelName, safeChannelNamespace, hash)
+}
+
+// Determine Whether The Configuration Specifies A Dedicated Receiver Per KafkaChannel (Rather Than Per Kafka Secret)
+func ReceiverPerChannel(configuration *config.EventingKafkaConfig) bool {
+	return configuration.Receiver.Mode == constants.ReceiverModeChannel
+}
+
 // Channel Host Naming Utility
 func ChannelHostName(channelName, channelNamespace string) string {
 	return fmt.Sprintf("%s.%s.channels.%s", channelName, channelNamespace, network.GetClusterDomainName())
@@ -102,15 +167,10 @@ func ReplicationFactor(channel *kafkav1beta1.KafkaChannel, configuration *config
 
 // Utility Function To Get The RetentionMillis - First From Channel Spec And Then From ConfigMap-Provided Settings
 func RetentionMillis(channel *kafkav1beta1.KafkaChannel, configuration *config.EventingKafkaConfig, logger *zap.Logger) int64 {
-	//
-	// TODO - The eventing-contrib KafkaChannel CRD does not include RetentionMillis so we're
-	//        currently just using the default value specified in Controller Environment Variables.
-	//
-	//value := channel.Spec.RetentionMillis
-	//if value <= 0 {
-	//	logger.Debug("Kafka Channel Spec 'RetentionMillis' Not Specified - Using Default", zap.Int64("Value", environment.DefaultRetentionMillis))
-	//	value = environment.DefaultRetentionMillis
-	//}
-	//return value
-	return configuration.Kafka.Topic.DefaultRetentionMillis
+	value := channel.Spec.RetentionMillis
+	if value <= 0 {
+		logger.Debug("Kafka Channel Spec 'RetentionMillis' Not Specified - Using Default", zap.Int64("Value", configuration.Kafka.Topic.DefaultRetentionMillis))
+		value = configuration.Kafka.Topic.DefaultRetentionMillis
+	}
+	return value
 }
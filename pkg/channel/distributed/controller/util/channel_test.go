@@ -38,6 +38,7 @@ const (
 	defaultNumPartitions     = int32(987)
 	replicationFactor        = int16(22)
 	defaultReplicationFactor = int16(33)
+	retentionMillis          = int64(3600000)
 	defaultRetentionMillis   = int64(55555)
 )
 
@@ -97,13 +98,127 @@ func TestNewChannelOwnerReference(t *testing.T) {
 func TestReceiverDnsSafeName(t *testing.T) {
 
 	// Perform The Test
-	actualResult := ReceiverDnsSafeName(kafkaSecret)
+	actualResult := ReceiverDnsSafeName(constants.DefaultNamingPolicy, kafkaSecret)
 
 	// Verify The Results
 	expectedResult := fmt.Sprintf("%s-%s-receiver", strings.ToLower(kafkaSecret), GenerateHash(kafkaSecret, 8))
 	assert.Equal(t, expectedResult, actualResult)
 }
 
+// Test The ReceiverDnsSafeName() Functionality With The Readable Naming Policy
+func TestReceiverDnsSafeNameReadable(t *testing.T) {
+
+	// Verify Short Secret Names Are Readable
+	assert.Equal(t, kafkaSecret+"-receiver", ReceiverDnsSafeName(constants.NamingPolicyReadable, kafkaSecret))
+	assert.Equal(t, kafkaSecret+"-receiver-metrics", ReceiverMetricsDnsSafeName(constants.NamingPolicyReadable, kafkaSecret))
+
+	// Verify Long Secret Names Fall Back To The Truncated & Hashed Names
+	longSecretName := "kubernetes-maximum-length-of-secret-name-is-sixty-three-chars-x"
+	assert.Equal(t, ReceiverDnsSafeName(constants.NamingPolicyHashed, longSecretName), ReceiverDnsSafeName(constants.NamingPolicyReadable, longSecretName))
+	assert.Equal(t, ReceiverMetricsDnsSafeName(constants.NamingPolicyHashed, longSecretName), ReceiverMetricsDnsSafeName(constants.NamingPolicyReadable, longSecretName))
+}
+
+// Test The ReceiverMetricsDnsSafeName() Functionality
+func TestReceiverMetricsDnsSafeName(t *testing.T) {
+
+	// Verify The Results For A Short Secret Name
+	expectedResult := fmt.Sprintf("%s-%s-receiver-metrics", strings.ToLower(kafkaSecret), GenerateHash(kafkaSecret, 8))
+	assert.Equal(t, expectedResult, ReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, kafkaSecret))
+
+	// Verify The Results For A Maximum Length Secret Name Remain DNS Safe
+	longSecretName := "kubernetes-maximum-length-of-secret-name-is-sixty-three-chars-x"
+	actualResult := ReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, longSecretName)
+	assert.True(t, len(actualResult) <= 63)
+	assert.True(t, strings.HasSuffix(actualResult, "-"+GenerateHash(longSecretName, 8)+"-receiver-metrics"))
+}
+
+// Test The ChannelReceiverDnsSafeName() Functionality
+func TestChannelReceiverDnsSafeName(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		Name      string
+		Namespace string
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{Name: channelName, Namespace: channelNamespace},
+		{Name: "short", Namespace: "kubernetes-maximum-length-for-namespace-with-sixty-three-chars"},
+		{Name: "kubernetes-maximum-length-of-channel-name-is-sixty-three-chars", Namespace: "kubernetes-maximum-length-for-namespace-with-sixty-three-chars"},
+		{Name: "a", Namespace: "b"},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		// Test Data
+		channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: testCase.Name, Namespace: testCase.Namespace}}
+
+		// Perform The Test
+		actualResult := ChannelReceiverDnsSafeName(constants.DefaultNamingPolicy, channel)
+		hash := GenerateHash(testCase.Name+testCase.Namespace, 8)
+		truncateName := fmt.Sprintf("%.26s", testCase.Name)
+		truncateNamespace := fmt.Sprintf("%.16s", testCase.Namespace)
+		expectedResult := fmt.Sprintf("%s-%s-%s-receiver", truncateName, truncateNamespace, hash)
+
+		// Verify The Results
+		assert.Equal(t, expectedResult, actualResult)
+		assert.True(t, len(actualResult) <= 63)
+		assert.NotEqual(t, DispatcherDnsSafeName(constants.DefaultNamingPolicy, channel), actualResult)
+	}
+}
+
+// Test The ChannelReceiverDnsSafeName() Functionality With The Readable Naming Policy
+func TestChannelReceiverDnsSafeNameReadable(t *testing.T) {
+
+	// Verify Short Names Are Readable (& Distinct From The Dispatcher)
+	channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: channelNamespace}}
+	actualResult := ChannelReceiverDnsSafeName(constants.NamingPolicyReadable, channel)
+	assert.Equal(t, channelName+"--"+channelNamespace+"-receiver", actualResult)
+	assert.NotEqual(t, DispatcherDnsSafeName(constants.NamingPolicyReadable, channel), actualResult)
+
+	// Verify Long Names Fall Back To The Truncated & Hashed Name
+	channel = &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: "kubernetes-maximum-length-of-channel-name-is-sixty-three-chars", Namespace: "short"}}
+	assert.Equal(t, ChannelReceiverDnsSafeName(constants.NamingPolicyHashed, channel), ChannelReceiverDnsSafeName(constants.NamingPolicyReadable, channel))
+}
+
+// Test The ChannelReceiverMetricsDnsSafeName() Functionality
+func TestChannelReceiverMetricsDnsSafeName(t *testing.T) {
+
+	// Create The TestCases
+	testCases := []*kafkav1beta1.KafkaChannel{
+		{ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: channelNamespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "short", Namespace: "kubernetes-maximum-length-for-namespace-with-sixty-three-chars"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kubernetes-maximum-length-of-channel-name-is-sixty-three-chars", Namespace: "kubernetes-maximum-length-for-namespace-with-sixty-three-chars"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "b"}},
+	}
+
+	// Run The TestCases
+	for _, channel := range testCases {
+
+		// Perform The Test
+		actualResult := ChannelReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, channel)
+		hash := GenerateHash(channel.Name+channel.Namespace, 8)
+		expectedResult := fmt.Sprintf("%.20s-%.14s-%s-receiver-metrics", channel.Name, channel.Namespace, hash)
+
+		// Verify The Results
+		assert.Equal(t, expectedResult, actualResult)
+		assert.True(t, len(actualResult) <= 63)
+		assert.NotEqual(t, ChannelReceiverDnsSafeName(constants.DefaultNamingPolicy, channel), actualResult)
+	}
+
+	// Verify Short Names Are Readable With The Readable Naming Policy
+	channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: channelNamespace}}
+	assert.Equal(t, channelName+"--"+channelNamespace+"-receiver-metrics", ChannelReceiverMetricsDnsSafeName(constants.NamingPolicyReadable, channel))
+}
+
+// Test The ReceiverPerChannel() Functionality
+func TestReceiverPerChannel(t *testing.T) {
+	assert.False(t, ReceiverPerChannel(&config.EventingKafkaConfig{}))
+	assert.False(t, ReceiverPerChannel(&config.EventingKafkaConfig{Receiver: config.EKReceiverConfig{Mode: constants.ReceiverModeSecret}}))
+	assert.True(t, ReceiverPerChannel(&config.EventingKafkaConfig{Receiver: config.EKReceiverConfig{Mode: constants.ReceiverModeChannel}}))
+}
+
 // Test The Channel Host Name Formatter / Generator
 func TestChannelHostName(t *testing.T) {
 	testChannelName := "TestChannelName"
@@ -167,9 +282,8 @@ func TestRetentionMillis(t *testing.T) {
 	actualRetentionMillis := RetentionMillis(channel, configuration, logger)
 	assert.Equal(t, defaultRetentionMillis, actualRetentionMillis)
 
-	// TODO - No RetentionMillis In eventing-contrib KafkaChannel
-	//// Test The Valid RetentionMillis Use Case
-	//channel = &kafkav1beta1.KafkaChannel{Spec: kafkav1beta1.KafkaChannelSpec{RetentionMillis: retentionMillis}}
-	//actualRetentionMillis = RetentionMillis(channel, environment, logger)
-	//assert.Equal(t, retentionMillis, actualRetentionMillis)
+	// Test The Valid RetentionMillis Use Case
+	channel = &kafkav1beta1.KafkaChannel{Spec: kafkav1beta1.KafkaChannelSpec{RetentionMillis: retentionMillis}}
+	actualRetentionMillis = RetentionMillis(channel, configuration, logger)
+	assert.Equal(t, retentionMillis, actualRetentionMillis)
 }
@@ -18,12 +18,23 @@ package util
 
 import (
 	"fmt"
+	"strconv"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	dispatcherconstants "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 )
 
 // Create A DNS Safe Name For The Specified KafkaChannel Suitable For Use With K8S Services
-func DispatcherDnsSafeName(channel *kafkav1beta1.KafkaChannel) string {
+func DispatcherDnsSafeName(namingPolicy string, channel *kafkav1beta1.KafkaChannel) string {
+
+	// Use The Readable Name If Configured & Possible
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("dispatcher", channel.Name, channel.Namespace); ok {
+			return readableDnsName
+		}
+	}
 
 	// In order for the resulting name to be a valid DNS component is 63 characters.  We are appending 13 characters to
 	// separate the components and to indicate this is a Dispatcher, and adding 8 hash characters, which further reduces
@@ -34,3 +45,49 @@ func DispatcherDnsSafeName(channel *kafkav1beta1.KafkaChannel) string {
 	hash := GenerateHash(channel.Name+channel.Namespace, 8)
 	return fmt.Sprintf("%s-%s-%s-dispatcher", safeChannelName, safeChannelNamespace, hash)
 }
+
+//
+// Create A DNS Safe Name For The Specified Shard Of The KafkaChannel's Dispatcher
+//
+// The first shard (zero) is named as per DispatcherDnsSafeName() so that an unsharded KafkaChannel's Dispatcher is
+// retained as its first shard when sharded.  The other shards are suffixed with their index, the channel name being
+// truncated further (with the hashed naming policy) so that the name remains a valid DNS component.
+//
+func DispatcherShardDnsSafeName(namingPolicy string, channel *kafkav1beta1.KafkaChannel, shard int) string {
+
+	// The First Shard Is The (Unsharded) Dispatcher
+	if shard == 0 {
+		return DispatcherDnsSafeName(namingPolicy, channel)
+	}
+
+	// Use The Readable Name If Configured & Possible
+	shardSuffix := strconv.Itoa(shard)
+	if namingPolicy == constants.NamingPolicyReadable {
+		if readableDnsName, ok := ReadableDnsName("dispatcher-"+shardSuffix, channel.Name, channel.Namespace); ok {
+			return readableDnsName
+		}
+	}
+
+	// Reduce The Channel's Allocation (See DispatcherDnsSafeName) By The Length Of The Separated Shard Suffix
+	safeChannelName := GenerateValidDnsName(channel.Name, 26-len(shardSuffix)-1, true, false)
+	safeChannelNamespace := GenerateValidDnsName(channel.Namespace, 16, false, false)
+	hash := GenerateHash(channel.Name+channel.Namespace, 8)
+	return fmt.Sprintf("%s-%s-%s-dispatcher-%s", safeChannelName, safeChannelNamespace, hash, shardSuffix)
+}
+
+// Get The Minimum Termination Grace Period (In Seconds) Allowing The Dispatcher To Drain Within Its Shutdown Timeout
+func MinimumDispatcherTerminationGracePeriodSeconds(dispatcherConfig config.EKDispatcherConfig) int64 {
+	shutdownTimeoutMillis := dispatcherConfig.ShutdownTimeoutMillis
+	if shutdownTimeoutMillis <= 0 {
+		shutdownTimeoutMillis = dispatcherconstants.DefaultShutdownTimeoutMillis
+	}
+	return (shutdownTimeoutMillis+999)/1000 + constants.DispatcherTerminationGracePeriodBufferSeconds
+}
+
+// Get The Dispatcher's Termination Grace Period (In Seconds) - As Configured Or Otherwise The Minimum
+func DispatcherTerminationGracePeriodSeconds(dispatcherConfig config.EKDispatcherConfig) int64 {
+	if dispatcherConfig.TerminationGracePeriodSeconds > 0 {
+		return dispatcherConfig.TerminationGracePeriodSeconds
+	}
+	return MinimumDispatcherTerminationGracePeriodSeconds(dispatcherConfig)
+}
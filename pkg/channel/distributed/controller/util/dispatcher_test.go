@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 )
 
@@ -67,7 +69,7 @@ func TestDispatcherDnsSafeName(t *testing.T) {
 		channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: testCase.Name, Namespace: testCase.Namespace}}
 
 		// Perform The Test
-		actualResult := DispatcherDnsSafeName(channel)
+		actualResult := DispatcherDnsSafeName(constants.DefaultNamingPolicy, channel)
 		hash := GenerateHash(testCase.Name+testCase.Namespace, 8)
 		truncateName := fmt.Sprintf("%.26s", testCase.Name)
 		truncateNamespace := fmt.Sprintf("%.16s", testCase.Namespace)
@@ -78,6 +80,80 @@ func TestDispatcherDnsSafeName(t *testing.T) {
 	}
 }
 
+// Test The DispatcherDnsSafeName() Functionality With The Readable Naming Policy
+func TestDispatcherDnsSafeNameReadable(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		Name      string
+		Namespace string
+		Readable  bool
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{Name: channelName, Namespace: channelNamespace, Readable: true},
+		{Name: "a", Namespace: "b", Readable: true},
+		{Name: "kafkachannel-kne-trigger", Namespace: "test-broker-redelivery-kafka-channel-messaging-knative-devg4l9d", Readable: false},
+		{Name: "kubernetes-maximum-length-of-channel-name-is-sixty-three-chars", Namespace: "short", Readable: false},
+		{Name: "double--hyphen", Namespace: "short", Readable: false},
+		{Name: "dotted.name", Namespace: "short", Readable: false},
+		{Name: "short", Namespace: "hash-like-0123abcd", Readable: false},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		// Test Data
+		channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: testCase.Name, Namespace: testCase.Namespace}}
+
+		// Perform The Test
+		actualResult := DispatcherDnsSafeName(constants.NamingPolicyReadable, channel)
+
+		// Verify The Results (Readable Where Possible, Otherwise The Hashed Name)
+		if testCase.Readable {
+			assert.Equal(t, testCase.Name+"--"+testCase.Namespace+"-dispatcher", actualResult)
+		} else {
+			assert.Equal(t, DispatcherDnsSafeName(constants.NamingPolicyHashed, channel), actualResult)
+		}
+		assert.True(t, len(actualResult) <= 63)
+	}
+}
+
+// Test The DispatcherShardDnsSafeName() Functionality
+func TestDispatcherShardDnsSafeName(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		Name         string
+		Namespace    string
+		NamingPolicy string
+		Shard        int
+		Expected     string
+	}
+
+	// Create The TestCases
+	longName := "kubernetes-maximum-length-of-channel-name-is-sixty-three-chars"
+	longNamespace := "kubernetes-maximum-length-for-namespace-with-sixty-three-chars"
+	longHash := GenerateHash(longName+longNamespace, 8)
+	testCases := []TestCase{
+		{Name: "a", Namespace: "b", NamingPolicy: constants.NamingPolicyHashed, Shard: 0, Expected: "a-b-" + GenerateHash("ab", 8) + "-dispatcher"},
+		{Name: "a", Namespace: "b", NamingPolicy: constants.NamingPolicyHashed, Shard: 3, Expected: "a-b-" + GenerateHash("ab", 8) + "-dispatcher-3"},
+		{Name: "a", Namespace: "b", NamingPolicy: constants.NamingPolicyReadable, Shard: 0, Expected: "a--b-dispatcher"},
+		{Name: "a", Namespace: "b", NamingPolicy: constants.NamingPolicyReadable, Shard: 3, Expected: "a--b-dispatcher-3"},
+		{Name: longName, Namespace: longNamespace, NamingPolicy: constants.NamingPolicyHashed, Shard: 7, Expected: fmt.Sprintf("%.24s-%.16s-%s-dispatcher-7", longName, longNamespace, longHash)},
+		{Name: longName, Namespace: longNamespace, NamingPolicy: constants.NamingPolicyHashed, Shard: 15, Expected: fmt.Sprintf("%.23s-%.16s-%s-dispatcher-15", longName, longNamespace, longHash)},
+		{Name: longName, Namespace: "b", NamingPolicy: constants.NamingPolicyReadable, Shard: 15, Expected: fmt.Sprintf("%.23s-b-%s-dispatcher-15", longName, GenerateHash(longName+"b", 8))},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: testCase.Name, Namespace: testCase.Namespace}}
+		actualResult := DispatcherShardDnsSafeName(testCase.NamingPolicy, channel, testCase.Shard)
+		assert.Equal(t, testCase.Expected, actualResult)
+		assert.True(t, len(actualResult) <= 63)
+	}
+}
+
 // Test The DispatcherDnsSafeName() Functionality
 func TestDispatcherDnsSafeName_LongNamesDifferent(t *testing.T) {
 
@@ -138,13 +214,13 @@ func TestDispatcherDnsSafeName_LongNamesDifferent(t *testing.T) {
 		channel2 := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: testCase.Name2, Namespace: testCase.Namespace2}}
 
 		// Perform The Test
-		actualResult1 := DispatcherDnsSafeName(channel1)
+		actualResult1 := DispatcherDnsSafeName(constants.DefaultNamingPolicy, channel1)
 		hash1 := GenerateHash(testCase.Name1+testCase.Namespace1, 8)
 		truncateName1 := fmt.Sprintf("%.26s", testCase.Name1)
 		truncateNamespace1 := fmt.Sprintf("%.16s", testCase.Namespace1)
 		expectedResult1 := fmt.Sprintf("%s-%s-%s-dispatcher", truncateName1, truncateNamespace1, hash1)
 
-		actualResult2 := DispatcherDnsSafeName(channel2)
+		actualResult2 := DispatcherDnsSafeName(constants.DefaultNamingPolicy, channel2)
 		hash2 := GenerateHash(testCase.Name2+testCase.Namespace2, 8)
 		truncateName2 := fmt.Sprintf("%.26s", testCase.Name2)
 		truncateNamespace2 := fmt.Sprintf("%.16s", testCase.Namespace2)
@@ -156,3 +232,36 @@ func TestDispatcherDnsSafeName_LongNamesDifferent(t *testing.T) {
 		assert.NotEqual(t, actualResult1, actualResult2)
 	}
 }
+
+// Test The MinimumDispatcherTerminationGracePeriodSeconds() & DispatcherTerminationGracePeriodSeconds() Functionality
+func TestDispatcherTerminationGracePeriodSeconds(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                  string
+		shutdownTimeoutMillis int64
+		gracePeriodSeconds    int64
+		expectedMinimum       int64
+		expectedGracePeriod   int64
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Defaults", expectedMinimum: 20, expectedGracePeriod: 20},
+		{name: "Whole Second Shutdown Timeout", shutdownTimeoutMillis: 60000, expectedMinimum: 70, expectedGracePeriod: 70},
+		{name: "Fractional Second Shutdown Timeout", shutdownTimeoutMillis: 60001, expectedMinimum: 71, expectedGracePeriod: 71},
+		{name: "Configured Grace Period", shutdownTimeoutMillis: 5000, gracePeriodSeconds: 90, expectedMinimum: 15, expectedGracePeriod: 90},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dispatcherConfig := config.EKDispatcherConfig{
+				ShutdownTimeoutMillis:         testCase.shutdownTimeoutMillis,
+				TerminationGracePeriodSeconds: testCase.gracePeriodSeconds,
+			}
+			assert.Equal(t, testCase.expectedMinimum, MinimumDispatcherTerminationGracePeriodSeconds(dispatcherConfig))
+			assert.Equal(t, testCase.expectedGracePeriod, DispatcherTerminationGracePeriodSeconds(dispatcherConfig))
+		})
+	}
+}
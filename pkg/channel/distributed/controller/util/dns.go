@@ -19,12 +19,18 @@ package util
 import (
 	"regexp"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Compiled RegExps
 var startsWithLowercaseAlphaCharRegExp = regexp.MustCompile(`^[a-z].*$`)
 var endsWithLowercaseAlphaCharRegExp = regexp.MustCompile(`^.*[a-z]$`)
 var invalidK8sServiceCharactersRegExp = regexp.MustCompile(`[^a-z0-9\-]+`)
+var endsWithHashRegExp = regexp.MustCompile(`-[0-9a-f]{8}$`)
+
+// The Separator Between The Components Of A Readable DNS Name (Which Cannot Appear Within The Components)
+const readableDnsNameSeparator = "--"
 
 // Return A Valid DNS Name Which Is As Close To The Specified Name As Possible & Truncated To The Smaller Of Specified Length / 63
 func GenerateValidDnsName(name string, length int, prefix bool, suffix bool) string {
@@ -58,3 +64,37 @@ func GenerateValidDnsName(name string, length int, prefix bool, suffix bool) str
 	// Return The Valid DNS Name
 	return validDnsName
 }
+
+//
+// Return A Readable DNS Name Joining The Specified Components & Suffix, If Possible Without Truncation Or Hashing
+//
+// The components are joined with a double hyphen, so the name is only readable (and the second return value true)
+// when each component is already a valid DNS-1123 label without a double hyphen, in which case the components can
+// be unambiguously recovered from the name.  The components must also not end with something resembling the hash
+// used by the truncated names (a hyphen followed by 8 hexadecimal characters), so that readable names can never
+// collide with the hashed names generated for other resources, and the full name must fit within 63 characters.
+//
+func ReadableDnsName(suffix string, components ...string) (string, bool) {
+
+	// Verify Each Component Is A Valid DNS-1123 Label Which Can Be Recovered From The Name
+	for _, component := range components {
+		if len(validation.IsDNS1123Label(component)) > 0 || strings.Contains(component, readableDnsNameSeparator) {
+			return "", false
+		}
+	}
+
+	// Verify The Joined Components Cannot Be Mistaken For A Hashed Name
+	joinedComponents := strings.Join(components, readableDnsNameSeparator)
+	if endsWithHashRegExp.MatchString(joinedComponents) {
+		return "", false
+	}
+
+	// Verify The Name Fits Without Truncation
+	readableDnsName := joinedComponents + "-" + suffix
+	if len(readableDnsName) > validation.DNS1123LabelMaxLength {
+		return "", false
+	}
+
+	// Return The Readable DNS Name
+	return readableDnsName, true
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,3 +61,38 @@ func TestGenerateValidDnsName(t *testing.T) {
 		assert.Equal(t, testCase.Result, actualDnsName)
 	}
 }
+
+// Test The ReadableDnsName() Functionality
+func TestReadableDnsName(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		Name       string
+		Components []string
+		Result     string
+		Readable   bool
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{Name: "Single Component", Components: []string{"my-secret"}, Result: "my-secret-receiver", Readable: true},
+		{Name: "Multiple Components", Components: []string{"my-channel", "my-namespace"}, Result: "my-channel--my-namespace-receiver", Readable: true},
+		{Name: "Maximum Length", Components: []string{strings.Repeat("a", 54)}, Result: strings.Repeat("a", 54) + "-receiver", Readable: true},
+		{Name: "Too Long", Components: []string{strings.Repeat("a", 55)}, Readable: false},
+		{Name: "Uppercase", Components: []string{"My-Secret"}, Readable: false},
+		{Name: "Dotted", Components: []string{"my.secret"}, Readable: false},
+		{Name: "Empty", Components: []string{""}, Readable: false},
+		{Name: "Double Hyphen", Components: []string{"my--channel", "namespace"}, Readable: false},
+		{Name: "Hash Like Ending", Components: []string{"my-channel", "namespace-0123abcd"}, Readable: false},
+		{Name: "Hex Without Hyphen", Components: []string{"0123abcd"}, Result: "0123abcd-receiver", Readable: true},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			result, readable := ReadableDnsName("receiver", testCase.Components...)
+			assert.Equal(t, testCase.Result, result)
+			assert.Equal(t, testCase.Readable, readable)
+		})
+	}
+}
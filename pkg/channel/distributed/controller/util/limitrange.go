@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Verify The Specified PodSpec's Resources Are Permitted By All LimitRanges In The Specified Namespace
+func CheckLimitRanges(ctx context.Context, kubeClientset kubernetes.Interface, namespace string, podSpec *corev1.PodSpec) error {
+
+	// List The LimitRanges In The Namespace (Direct Read As LimitRanges Are Not Otherwise Watched)
+	limitRangeList, err := kubeClientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list LimitRanges in namespace '%s': %v", namespace, err)
+	}
+
+	// Validate The PodSpec Against The LimitRanges
+	return ValidateLimitRanges(limitRangeList.Items, podSpec)
+}
+
+//
+// Validate The Specified PodSpec's Resources Against The Specified LimitRanges
+//
+// Only the Container & Pod LimitRange types are considered, and only for the explicitly specified
+// (non-zero) requests / limits, which is sufficient to detect when the configured Dispatcher / Receiver
+// resources would be rejected by the LimitRanger admission plugin.  All violations are aggregated into
+// a single error so that they can be corrected at once.
+//
+func ValidateLimitRanges(limitRanges []corev1.LimitRange, podSpec *corev1.PodSpec) error {
+
+	// Accumulate All Violations
+	violations := make([]string, 0)
+
+	// Sum The Container Resources For Pod Level Validation
+	podRequests := corev1.ResourceList{}
+	podLimits := corev1.ResourceList{}
+	for _, container := range podSpec.Containers {
+		addResources(podRequests, container.Resources.Requests)
+		addResources(podLimits, container.Resources.Limits)
+	}
+
+	// Validate Against Each LimitRange Item
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			switch item.Type {
+			case corev1.LimitTypeContainer:
+				for _, container := range podSpec.Containers {
+					subject := fmt.Sprintf("container '%s'", container.Name)
+					violations = append(violations, validateLimitRangeItem(limitRange.Name, subject, item, container.Resources.Requests, container.Resources.Limits)...)
+				}
+			case corev1.LimitTypePod:
+				violations = append(violations, validateLimitRangeItem(limitRange.Name, "pod", item, podRequests, podLimits)...)
+			}
+		}
+	}
+
+	// Return Any Violations As A Single Error (Sorted For A Stable Status Message)
+	if len(violations) > 0 {
+		sort.Strings(violations)
+		return fmt.Errorf("resources violate LimitRange: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// Validate The Specified Requests / Limits Against A Single LimitRange Item
+func validateLimitRangeItem(limitRangeName string, subject string, item corev1.LimitRangeItem, requests corev1.ResourceList, limits corev1.ResourceList) []string {
+
+	violations := make([]string, 0)
+
+	// Verify Requests & Limits Are Not Below The Minimum
+	for resourceName, min := range item.Min {
+		if request, ok := specifiedQuantity(requests, resourceName); ok && request.Cmp(min) < 0 {
+			violations = append(violations, fmt.Sprintf("%s %s request %s is less than minimum %s (%s)", subject, resourceName, request.String(), min.String(), limitRangeName))
+		}
+		if limit, ok := specifiedQuantity(limits, resourceName); ok && limit.Cmp(min) < 0 {
+			violations = append(violations, fmt.Sprintf("%s %s limit %s is less than minimum %s (%s)", subject, resourceName, limit.String(), min.String(), limitRangeName))
+		}
+	}
+
+	// Verify Requests & Limits Do Not Exceed The Maximum
+	for resourceName, max := range item.Max {
+		if request, ok := specifiedQuantity(requests, resourceName); ok && request.Cmp(max) > 0 {
+			violations = append(violations, fmt.Sprintf("%s %s request %s exceeds maximum %s (%s)", subject, resourceName, request.String(), max.String(), limitRangeName))
+		}
+		if limit, ok := specifiedQuantity(limits, resourceName); ok && limit.Cmp(max) > 0 {
+			violations = append(violations, fmt.Sprintf("%s %s limit %s exceeds maximum %s (%s)", subject, resourceName, limit.String(), max.String(), limitRangeName))
+		}
+	}
+
+	// Verify The Limit / Request Ratio Does Not Exceed The Maximum
+	for resourceName, maxRatio := range item.MaxLimitRequestRatio {
+		request, requestOk := specifiedQuantity(requests, resourceName)
+		limit, limitOk := specifiedQuantity(limits, resourceName)
+		if requestOk && limitOk {
+			ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+			if ratio > float64(maxRatio.MilliValue())/1000 {
+				violations = append(violations, fmt.Sprintf("%s %s limit to request ratio %.2f exceeds maximum %s (%s)", subject, resourceName, ratio, maxRatio.String(), limitRangeName))
+			}
+		}
+	}
+
+	return violations
+}
+
+// Get The Specified (Non-Zero) Quantity For The Specified Resource
+func specifiedQuantity(resources corev1.ResourceList, resourceName corev1.ResourceName) (resource.Quantity, bool) {
+	quantity, ok := resources[resourceName]
+	return quantity, ok && !quantity.IsZero()
+}
+
+// Add The Specified Resources To The Specified Totals
+func addResources(totals corev1.ResourceList, resources corev1.ResourceList) {
+	for resourceName, quantity := range resources {
+		total := totals[resourceName]
+		total.Add(quantity)
+		totals[resourceName] = total
+	}
+}
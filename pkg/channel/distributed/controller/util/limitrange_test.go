@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// Test The ValidateLimitRanges() Functionality
+func TestValidateLimitRanges(t *testing.T) {
+
+	// Test Data
+	podSpec := newLimitRangeTestPodSpec()
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name        string
+		limitRanges []corev1.LimitRange
+		wantErr     string
+	}
+
+	// Define The Test Cases
+	testCases := []testCase{
+		{
+			name: "No LimitRanges",
+		},
+		{
+			name: "Permissive LimitRange",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Min:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			})},
+		},
+		{
+			name: "Container Maximum Exceeded",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("150m")},
+			})},
+			wantErr: "resources violate LimitRange: container 'container1' cpu limit 200m exceeds maximum 150m (TestLimitRange)",
+		},
+		{
+			name: "Container Minimum Violated",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Min:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			})},
+			wantErr: "resources violate LimitRange: container 'container1' memory request 32Mi is less than minimum 64Mi (TestLimitRange); " +
+				"container 'container2' memory limit 32Mi is less than minimum 64Mi (TestLimitRange); " +
+				"container 'container2' memory request 16Mi is less than minimum 64Mi (TestLimitRange)",
+		},
+		{
+			name: "Pod Maximum Exceeded",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypePod,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			})},
+			wantErr: "resources violate LimitRange: pod cpu limit 300m exceeds maximum 250m (TestLimitRange)",
+		},
+		{
+			name: "Limit To Request Ratio Exceeded",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type:                 corev1.LimitTypeContainer,
+				MaxLimitRequestRatio: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+			})},
+			wantErr: "resources violate LimitRange: container 'container1' cpu limit to request ratio 4.00 exceeds maximum 3 (TestLimitRange)",
+		},
+		{
+			name: "Other LimitRange Types Ignored",
+			limitRanges: []corev1.LimitRange{newLimitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypePersistentVolumeClaim,
+				Max:  corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Mi")},
+			})},
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateLimitRanges(testCase.limitRanges, podSpec)
+			if testCase.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+				assert.Equal(t, testCase.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+// Test The CheckLimitRanges() Functionality
+func TestCheckLimitRanges(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	podSpec := newLimitRangeTestPodSpec()
+	limitRange := newLimitRange(corev1.LimitRangeItem{
+		Type: corev1.LimitTypePod,
+		Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+	})
+
+	// Verify LimitRanges In The Namespace Are Enforced
+	kubeClientset := fake.NewSimpleClientset(&limitRange)
+	err := CheckLimitRanges(ctx, kubeClientset, limitRange.Namespace, podSpec)
+	assert.NotNil(t, err)
+	assert.Equal(t, "resources violate LimitRange: pod cpu limit 300m exceeds maximum 250m (TestLimitRange)", err.Error())
+
+	// Verify LimitRanges In Other Namespaces Are Ignored
+	err = CheckLimitRanges(ctx, kubeClientset, "OtherNamespace", podSpec)
+	assert.Nil(t, err)
+
+	// Verify Failure To List LimitRanges Is Returned
+	kubeClientset.PrependReactor("list", "limitranges", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("test list error")
+	})
+	err = CheckLimitRanges(ctx, kubeClientset, limitRange.Namespace, podSpec)
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed to list LimitRanges in namespace 'TestNamespace': test list error", err.Error())
+}
+
+// Utility Function For Creating A LimitRange With The Specified Item
+func newLimitRange(item corev1.LimitRangeItem) corev1.LimitRange {
+	return corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "TestLimitRange", Namespace: "TestNamespace"},
+		Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+	}
+}
+
+// Utility Function For Creating A Two Container PodSpec With Resources
+func newLimitRangeTestPodSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "container1",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("32Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			},
+			{
+				Name: "container2",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("16Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("32Mi")},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Determine Whether The Specified Namespace Is In Scope (An Empty List Of Namespaces Means All Namespaces)
+func NamespaceInScope(namespaces []string, namespace string) bool {
+	if len(namespaces) <= 0 {
+		return true
+	}
+	for _, scopedNamespace := range namespaces {
+		if scopedNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// Create An Informer FilterFunc Which Only Accepts Resources In The Specified Namespaces
+func FilterNamespaces(namespaces []string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := metaObject(obj); ok {
+			return NamespaceInScope(namespaces, object.GetNamespace())
+		}
+		return false
+	}
+}
+
+// Create An Informer FilterFunc Which Only Accepts Resources Whose Specified Label Contains One Of The Specified Namespaces
+func FilterNamespaceLabel(namespaces []string, label string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := metaObject(obj); ok {
+			return NamespaceInScope(namespaces, object.GetLabels()[label])
+		}
+		return false
+	}
+}
+
+// Extract The metav1.Object From The Specified Informer Object (Unwrapping Deleted "Tombstones")
+func metaObject(obj interface{}) (metav1.Object, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(metav1.Object)
+	return object, ok
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+// Test The NamespaceInScope() Functionality
+func TestNamespaceInScope(t *testing.T) {
+	assert.True(t, NamespaceInScope(nil, "namespace1"))
+	assert.True(t, NamespaceInScope([]string{}, "namespace1"))
+	assert.True(t, NamespaceInScope([]string{"namespace1", "namespace2"}, "namespace1"))
+	assert.True(t, NamespaceInScope([]string{"namespace1", "namespace2"}, "namespace2"))
+	assert.False(t, NamespaceInScope([]string{"namespace1", "namespace2"}, "namespace3"))
+	assert.False(t, NamespaceInScope([]string{"namespace1"}, ""))
+}
+
+// Test The FilterNamespaces() Functionality
+func TestFilterNamespaces(t *testing.T) {
+
+	// Test Data
+	inScopeService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "TestName", Namespace: "namespace1"}}
+	outOfScopeService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "TestName", Namespace: "namespace3"}}
+
+	// Perform The Test
+	filterFunc := FilterNamespaces([]string{"namespace1", "namespace2"})
+
+	// Verify The Results
+	assert.True(t, filterFunc(inScopeService))
+	assert.True(t, filterFunc(cache.DeletedFinalStateUnknown{Obj: inScopeService}))
+	assert.False(t, filterFunc(outOfScopeService))
+	assert.False(t, filterFunc(cache.DeletedFinalStateUnknown{Obj: outOfScopeService}))
+	assert.False(t, filterFunc("NotAnObject"))
+	assert.True(t, FilterNamespaces(nil)(outOfScopeService))
+}
+
+// Test The FilterNamespaceLabel() Functionality
+func TestFilterNamespaceLabel(t *testing.T) {
+
+	// Test Data
+	inScopeService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "TestName",
+		Namespace: "knative-eventing",
+		Labels:    map[string]string{constants.KafkaChannelNamespaceLabel: "namespace1"},
+	}}
+	outOfScopeService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "TestName",
+		Namespace: "namespace1",
+		Labels:    map[string]string{constants.KafkaChannelNamespaceLabel: "namespace3"},
+	}}
+	unlabelledService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "TestName", Namespace: "namespace1"}}
+
+	// Perform The Test
+	filterFunc := FilterNamespaceLabel([]string{"namespace1", "namespace2"}, constants.KafkaChannelNamespaceLabel)
+
+	// Verify The Results
+	assert.True(t, filterFunc(inScopeService))
+	assert.True(t, filterFunc(cache.DeletedFinalStateUnknown{Obj: inScopeService}))
+	assert.False(t, filterFunc(outOfScopeService))
+	assert.False(t, filterFunc(unlabelledService))
+	assert.False(t, filterFunc("NotAnObject"))
+	assert.True(t, FilterNamespaceLabel(nil, constants.KafkaChannelNamespaceLabel)(unlabelledService))
+}
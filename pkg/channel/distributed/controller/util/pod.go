@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The Waiting Reasons Of Containers Which Will Not Start Without Intervention (Wrong / Unpullable Image, Invalid Config)
+var containerFailureReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerError":       true,
+	"CreateContainerConfigError": true,
+}
+
+// ContainerFailure Describes A Container Of A Deployment's Pod Which Is Failing To Start
+type ContainerFailure struct {
+	Pod       string
+	Container string
+	Reason    string
+	Message   string
+}
+
+// Error Implements The error Interface So That The ContainerFailure May Be Reported As Such
+func (f *ContainerFailure) Error() string {
+	return fmt.Sprintf("container '%s' of pod '%s' is waiting with reason %s: %s", f.Container, f.Pod, f.Reason, f.Message)
+}
+
+//
+// Find The First (By Pod Name) Container Of The Specified Deployment's Pods Which Is Failing To Start
+//
+// The Pods are listed directly (they are not otherwise watched) using the Deployment's selector, and both init and
+// regular containers are considered.  Only waiting reasons which will not resolve on their own are reported (e.g.
+// ImagePullBackOff or CreateContainerError), so that transient states such as ContainerCreating are ignored.  A nil
+// ContainerFailure is returned if there are no such containers.
+//
+func FindContainerFailure(ctx context.Context, kubeClientset kubernetes.Interface, deployment *appsv1.Deployment) (*ContainerFailure, error) {
+
+	// Convert The Deployment's LabelSelector Into A Selector For Listing Its Pods
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for deployment '%s': %v", deployment.Name, err)
+	}
+
+	// List The Deployment's Pods
+	podList, err := kubeClientset.CoreV1().Pods(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of deployment '%s': %v", deployment.Name, err)
+	}
+
+	// Sort The Pods By Name For A Stable Status Message
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	// Return The First Container Failing To Start (If Any)
+	for _, pod := range pods {
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				if status.State.Waiting != nil && containerFailureReasons[status.State.Waiting.Reason] {
+					return &ContainerFailure{
+						Pod:       pod.Name,
+						Container: status.Name,
+						Reason:    status.State.Waiting.Reason,
+						Message:   status.State.Waiting.Message,
+					}, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
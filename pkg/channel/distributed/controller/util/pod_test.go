@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// Test The FindContainerFailure() Functionality
+func TestFindContainerFailure(t *testing.T) {
+
+	// Test Data
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "TestDeployment", Namespace: "TestNamespace"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "TestDeployment"}},
+		},
+	}
+	imagePullBackOff := &ContainerFailure{Pod: "pod-b", Container: "container", Reason: "ImagePullBackOff", Message: "Back-off pulling image \"bad\""}
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name        string
+		pods        []runtime.Object
+		wantFailure *ContainerFailure
+	}
+
+	// Define The Test Cases
+	testCases := []testCase{
+		{
+			name: "No Pods",
+		},
+		{
+			name: "Running Pod",
+			pods: []runtime.Object{newContainerFailureTestPod("pod-a", "TestDeployment", false, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}})},
+		},
+		{
+			name: "Creating Pod",
+			pods: []runtime.Object{newContainerFailureTestPod("pod-a", "TestDeployment", false, newWaitingState("ContainerCreating", ""))},
+		},
+		{
+			name: "ImagePullBackOff",
+			pods: []runtime.Object{
+				newContainerFailureTestPod("pod-c", "TestDeployment", false, newWaitingState("CreateContainerError", "failed to create")),
+				newContainerFailureTestPod("pod-a", "TestDeployment", false, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}),
+				newContainerFailureTestPod("pod-b", "TestDeployment", false, newWaitingState(imagePullBackOff.Reason, imagePullBackOff.Message)),
+			},
+			wantFailure: imagePullBackOff,
+		},
+		{
+			name:        "Init Container CreateContainerConfigError",
+			pods:        []runtime.Object{newContainerFailureTestPod("pod-a", "TestDeployment", true, newWaitingState("CreateContainerConfigError", "secret not found"))},
+			wantFailure: &ContainerFailure{Pod: "pod-a", Container: "container", Reason: "CreateContainerConfigError", Message: "secret not found"},
+		},
+		{
+			name: "Other Deployment's Pod",
+			pods: []runtime.Object{newContainerFailureTestPod("pod-a", "OtherDeployment", false, newWaitingState("ErrImagePull", "not found"))},
+		},
+	}
+
+	// Run The Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			kubeClientset := fake.NewSimpleClientset(testCase.pods...)
+			failure, err := FindContainerFailure(context.TODO(), kubeClientset, deployment)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.wantFailure, failure)
+		})
+	}
+
+	// Verify The ContainerFailure's Error Message
+	assert.Equal(t, "container 'container' of pod 'pod-b' is waiting with reason ImagePullBackOff: Back-off pulling image \"bad\"", imagePullBackOff.Error())
+
+	// Verify Failure To List Pods Is Returned
+	kubeClientset := fake.NewSimpleClientset()
+	kubeClientset.PrependReactor("list", "pods", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("test list error")
+	})
+	failure, err := FindContainerFailure(context.TODO(), kubeClientset, deployment)
+	assert.Nil(t, failure)
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed to list pods of deployment 'TestDeployment': test list error", err.Error())
+}
+
+// Utility Function For Creating A Pod Of The Specified Deployment With A Single (Init) Container In The Specified State
+func newContainerFailureTestPod(name string, deploymentName string, initContainer bool, state corev1.ContainerState) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "TestNamespace", Labels: map[string]string{"app": deploymentName}},
+	}
+	statuses := []corev1.ContainerStatus{{Name: "container", State: state}}
+	if initContainer {
+		pod.Status.InitContainerStatuses = statuses
+	} else {
+		pod.Status.ContainerStatuses = statuses
+	}
+	return pod
+}
+
+// Utility Function For Creating A Waiting ContainerState With The Specified Reason & Message
+func newWaitingState(reason string, message string) corev1.ContainerState {
+	return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}}
+}
@@ -0,0 +1,517 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/health"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+)
+
+//
+// The Identity Of A Receiver (Kafka Producer) Whose Resources Are To Be Created
+//
+// A Receiver is either shared by all the KafkaChannels using the same Kafka Secret (and owned by that Secret), or is
+// dedicated to a single KafkaChannel (and owned by it) when configured for "channel" mode.  Both are created from the
+// same Service, Ingress & Deployment models, which differ only in the naming, labels & owner of the resources and in
+// the Kafka Secret they reference.
+//
+type Receiver struct {
+	Name            string                // The Name Of The Receiver Deployment, Service & Ingress
+	MetricsName     string                // The Name Of The Separate Receiver Metrics Service (If Enabled)
+	Labels          map[string]string     // Additional Labels Identifying The Owner Of The Receiver's Resources
+	OwnerReference  metav1.OwnerReference // The Owner Of The Receiver's Resources
+	KafkaSecretName string                // The Kafka Secret Referenced By The Receiver Deployment
+	BrokersChecksum string                // The Checksum Of The Kafka Brokers In The Kafka Secret (Empty If Unknown)
+}
+
+// Create The Receiver Shared By The KafkaChannels Using The Specified Kafka Secret
+func NewSecretReceiver(namingPolicy string, secret *corev1.Secret) Receiver {
+	return Receiver{
+		Name:            ReceiverDnsSafeName(namingPolicy, secret.Name),
+		MetricsName:     ReceiverMetricsDnsSafeName(namingPolicy, secret.Name),
+		OwnerReference:  NewSecretOwnerReference(secret),
+		KafkaSecretName: secret.Name,
+		BrokersChecksum: KafkaBrokersChecksum(secret),
+	}
+}
+
+// Create The Receiver Dedicated To The Specified KafkaChannel (Receiver "channel" Mode)
+func NewChannelReceiver(namingPolicy string, channel *kafkav1beta1.KafkaChannel, kafkaSecretName string, brokersChecksum string) Receiver {
+	return Receiver{
+		Name:        ChannelReceiverDnsSafeName(namingPolicy, channel),
+		MetricsName: ChannelReceiverMetricsDnsSafeName(namingPolicy, channel),
+		Labels: map[string]string{
+			constants.KafkaChannelNameLabel:      channel.Name,      // Identifies the Resource's Owning KafkaChannel's Name
+			constants.KafkaChannelNamespaceLabel: channel.Namespace, // Identifies the Resource's Owning KafkaChannel's Namespace
+		},
+		OwnerReference:  NewChannelOwnerReference(channel),
+		KafkaSecretName: kafkaSecretName,
+		BrokersChecksum: brokersChecksum,
+	}
+}
+
+// Create The Labels Of One Of The Receiver's Resources (The Specified Labels Plus The Receiver's Owner Labels)
+func (r Receiver) labels(labels map[string]string) map[string]string {
+	for key, value := range r.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+//
+// Create The Receiver Service Model
+//
+// By default a single Service exposes both the Receiver's HTTP & metrics ports.  When configured with a separate
+// metrics Service, the HTTP Service only exposes the HTTP port and the metrics port is instead exposed by its own
+// Service (carrying the Prometheus ServiceMonitor selector label), so that network policies can restrict metrics
+// scraping independently of event ingestion.
+//
+func NewReceiverService(receiver Receiver, configuration *config.EventingKafkaConfig, environment *env.Environment) *corev1.Service {
+
+	// Create The Receiver Service Model
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       constants.ServiceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      receiver.Name,
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Labels: receiver.labels(map[string]string{
+				constants.KafkaChannelReceiverLabel:  "true",                               // Allows for identification of Receivers
+				constants.K8sAppChannelSelectorLabel: constants.K8sAppChannelSelectorValue, // Prometheus ServiceMonitor
+			}),
+			OwnerReferences: []metav1.OwnerReference{
+				receiver.OwnerReference,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       constants.HttpPortName,
+					Port:       constants.HttpServicePortNumber,
+					TargetPort: intstr.FromInt(constants.HttpContainerPortNumber),
+				},
+				newReceiverMetricsServicePort(environment),
+			},
+			Selector: map[string]string{
+				constants.AppLabel: receiver.Name, // Matches Deployment Label Key/Value
+			},
+		},
+	}
+
+	// Leave The Metrics Port (& ServiceMonitor Selection) To The Separate Metrics Service If Enabled
+	if configuration.Receiver.SeparateMetricsService {
+		delete(service.Labels, constants.K8sAppChannelSelectorLabel)
+		service.Spec.Ports = service.Spec.Ports[:1]
+	}
+
+	// Return The Receiver Service Model
+	return service
+}
+
+// Create The Separate Receiver Metrics Service Model
+func NewReceiverMetricsService(receiver Receiver, environment *env.Environment) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       constants.ServiceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      receiver.MetricsName,
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Labels: receiver.labels(map[string]string{
+				constants.KafkaChannelReceiverLabel:  "true",                               // Allows for identification of Receivers
+				constants.K8sAppChannelSelectorLabel: constants.K8sAppChannelSelectorValue, // Prometheus ServiceMonitor
+			}),
+			OwnerReferences: []metav1.OwnerReference{
+				receiver.OwnerReference,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				newReceiverMetricsServicePort(environment),
+			},
+			Selector: map[string]string{
+				constants.AppLabel: receiver.Name, // Matches Deployment Label Key/Value
+			},
+		},
+	}
+}
+
+// Create The Receiver Metrics ServicePort
+func newReceiverMetricsServicePort(environment *env.Environment) corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       constants.MetricsPortName,
+		Port:       int32(environment.MetricsPort),
+		TargetPort: intstr.FromInt(environment.MetricsPort),
+	}
+}
+
+// Create The Receiver Ingress Model Routing All Paths To The Receiver Service
+func NewReceiverIngress(receiver Receiver, configuration *config.EventingKafkaConfig) *networkingv1beta1.Ingress {
+
+	// The Receiver's Ingress Configuration
+	ingressConfig := configuration.Receiver.Ingress
+
+	// Create The Receiver Ingress Model
+	ingress := &networkingv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1beta1.SchemeGroupVersion.String(),
+			Kind:       constants.IngressKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        receiver.Name,
+			Namespace:   commonconstants.KnativeEventingNamespace,
+			Annotations: ingressConfig.Annotations,
+			Labels: receiver.labels(map[string]string{
+				constants.KafkaChannelReceiverLabel: "true", // Allows for identification of Receivers
+			}),
+			OwnerReferences: []metav1.OwnerReference{
+				receiver.OwnerReference,
+			},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: ingressConfig.Host,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: receiver.Name,
+										ServicePort: intstr.FromInt(constants.HttpServicePortNumber),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Specify The IngressClass If Configured
+	if len(ingressConfig.ClassName) > 0 {
+		className := ingressConfig.ClassName
+		ingress.Spec.IngressClassName = &className
+	}
+
+	// Terminate TLS With The Configured Secret (In The Receiver's Namespace) If Specified
+	if len(ingressConfig.TLSSecretName) > 0 {
+		ingressTLS := networkingv1beta1.IngressTLS{SecretName: ingressConfig.TLSSecretName}
+		if len(ingressConfig.Host) > 0 {
+			ingressTLS.Hosts = []string{ingressConfig.Host}
+		}
+		ingress.Spec.TLS = []networkingv1beta1.IngressTLS{ingressTLS}
+	}
+
+	// Return The Receiver Ingress Model
+	return ingress
+}
+
+// Create The Receiver Deployment Model
+func NewReceiverDeployment(receiver Receiver, configuration *config.EventingKafkaConfig, environment *env.Environment) (*appsv1.Deployment, error) {
+
+	// Verify The Deployment's Ports Are Distinct (Otherwise The Receiver Would Fail To Bind Them)
+	err := env.VerifyDeploymentPorts(environment.MetricsPort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas Int Value For De-Referencing
+	replicas := int32(configuration.Receiver.Replicas)
+
+	// Create The Receiver Deployment
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       constants.DeploymentKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      receiver.Name,
+			Namespace: commonconstants.KnativeEventingNamespace,
+			Labels: receiver.labels(map[string]string{
+				constants.AppLabel:                  receiver.Name, // Matches Service Selector Key/Value
+				constants.KafkaChannelReceiverLabel: "true",        // Allows for identification of Receivers
+			}),
+			Annotations: KafkaBrokersChecksumAnnotations(receiver.BrokersChecksum),
+			OwnerReferences: []metav1.OwnerReference{
+				receiver.OwnerReference,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.AppLabel: receiver.Name, // Matches Template ObjectMeta Pods
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						constants.AppLabel: receiver.Name, // Matched By Deployment Selector Above
+					},
+					Annotations: KafkaBrokersChecksumAnnotations(receiver.BrokersChecksum),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        environment.ServiceAccount,
+					TopologySpreadConstraints: configuration.Receiver.TopologySpreadConstraints,
+					Affinity:                  configuration.Receiver.Affinity,
+					Containers: []corev1.Container{
+						{
+							Name: receiver.Name,
+							LivenessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Port: intstr.FromInt(constants.HealthPort),
+										Path: health.LivenessPath,
+									},
+								},
+								InitialDelaySeconds: constants.ChannelLivenessDelay,
+								PeriodSeconds:       constants.ChannelLivenessPeriod,
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Port: intstr.FromInt(constants.HealthPort),
+										Path: health.ReadinessPath,
+									},
+								},
+								InitialDelaySeconds: constants.ChannelReadinessDelay,
+								PeriodSeconds:       constants.ChannelReadinessPeriod,
+							},
+							Image: environment.ReceiverImage,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "server",
+									ContainerPort: int32(constants.HttpContainerPortNumber),
+								},
+							},
+							Env:             receiverDeploymentEnvVars(receiver, configuration, environment),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    configuration.Receiver.CpuRequest,
+									corev1.ResourceMemory: configuration.Receiver.MemoryRequest,
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    configuration.Receiver.CpuLimit,
+									corev1.ResourceMemory: configuration.Receiver.MemoryLimit,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Return The Receiver Deployment
+	return deployment, nil
+}
+
+// Create The Receiver Container's Env Vars
+func receiverDeploymentEnvVars(receiver Receiver, configuration *config.EventingKafkaConfig, environment *env.Environment) []corev1.EnvVar {
+
+	// Create The Receiver Deployment EnvVars
+	envVars := []corev1.EnvVar{
+		{
+			Name:  system.NamespaceEnvKey,
+			Value: commonconstants.KnativeEventingNamespace,
+		},
+		{
+			Name:  commonenv.KnativeLoggingConfigMapNameEnvVarKey,
+			Value: logging.ConfigMapName(),
+		},
+		{
+			Name:  commonenv.ServiceNameEnvVarKey,
+			Value: receiver.Name,
+		},
+		{
+			Name:  commonenv.MetricsPortEnvVarKey,
+			Value: strconv.Itoa(environment.MetricsPort),
+		},
+		{
+			Name:  commonenv.MetricsDomainEnvVarKey,
+			Value: environment.MetricsDomain,
+		},
+		{
+			Name:  commonenv.HealthPortEnvVarKey,
+			Value: strconv.Itoa(constants.HealthPort),
+		},
+		{
+			Name: commonenv.PodNameEnvVarKey,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		},
+	}
+
+	// Append The Kafka Brokers As Env Var
+	envVars = append(envVars, corev1.EnvVar{
+		Name: commonenv.KafkaBrokerEnvVarKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: receiver.KafkaSecretName},
+				Key:                  constants.KafkaSecretDataKeyBrokers,
+			},
+		},
+	})
+
+	// Append The Kafka Username As Env Var
+	envVars = append(envVars, corev1.EnvVar{
+		Name: commonenv.KafkaUsernameEnvVarKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: receiver.KafkaSecretName},
+				Key:                  constants.KafkaSecretDataKeyUsername,
+			},
+		},
+	})
+
+	// Append The Kafka Password As Env Var
+	envVars = append(envVars, corev1.EnvVar{
+		Name: commonenv.KafkaPasswordEnvVarKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: receiver.KafkaSecretName},
+				Key:                  constants.KafkaSecretDataKeyPassword,
+			},
+		},
+	})
+
+	// Append The (Optional) Kafka SASL Mechanism As Env Var
+	envVars = append(envVars, KafkaSaslMechanismEnvVar(receiver.KafkaSecretName))
+
+	// Append The (Optional) Schema Registry Credentials As Env Vars If Framing Is Enabled
+	if len(configuration.Kafka.SchemaRegistry.Url) > 0 {
+		envVars = append(envVars, SchemaRegistryEnvVars(receiver.KafkaSecretName)...)
+	}
+
+	// Return The Receiver Deployment EnvVars Array
+	return envVars
+}
+
+// Reconcile The Specified Receiver Service Model (Creating It If It Does Not Exist)
+func ReconcileReceiverService(ctx context.Context, logger *zap.Logger, kubeClientset kubernetes.Interface, serviceLister corev1listers.ServiceLister, service *corev1.Service) error {
+
+	// Get Service Specific Logger
+	logger = logger.With(zap.String("Service", service.Name))
+
+	// Attempt To Get The Receiver Service By Namespace / Name
+	_, err := serviceLister.Services(service.Namespace).Get(service.Name)
+	if err != nil {
+
+		// If The Service Was Not Found - Then Create A New One
+		if errors.IsNotFound(err) {
+
+			// Then Create The New Receiver Service
+			logger.Info("Receiver Service Not Found - Creating New One")
+			_, err = kubeClientset.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
+			if err != nil {
+				logger.Error("Failed To Create Receiver Service", zap.Error(err))
+				return err
+			} else {
+				logger.Info("Successfully Created Receiver Service")
+				return nil
+			}
+
+		} else {
+
+			// Failed In Attempt To Get Receiver Service From K8S
+			logger.Error("Failed To Get Receiver Service", zap.Error(err))
+			return err
+		}
+	} else {
+
+		// Verified The Receiver Service Exists
+		logger.Info("Successfully Verified Receiver Service")
+		return nil
+	}
+}
+
+//
+// Reconcile The Specified Receiver Ingress Model (Creating It If It Does Not Exist)
+//
+// Ingresses are not watched by the controller, and so are read directly from Kubernetes.  A NotFound error when
+// creating the Ingress indicates that the cluster does not serve the Ingress API, and is logged and otherwise ignored.
+//
+func ReconcileReceiverIngress(ctx context.Context, logger *zap.Logger, kubeClientset kubernetes.Interface, ingress *networkingv1beta1.Ingress) error {
+
+	// Get Ingress Specific Logger
+	logger = logger.With(zap.String("Ingress", ingress.Name))
+
+	// Attempt To Get The Receiver Ingress By Namespace / Name
+	_, err := kubeClientset.NetworkingV1beta1().Ingresses(ingress.Namespace).Get(ctx, ingress.Name, metav1.GetOptions{})
+	if err != nil {
+
+		// If The Ingress Was Not Found - Then Create A New One
+		if errors.IsNotFound(err) {
+
+			// Then Create The New Receiver Ingress
+			logger.Info("Receiver Ingress Not Found - Creating New One")
+			_, err = kubeClientset.NetworkingV1beta1().Ingresses(ingress.Namespace).Create(ctx, ingress, metav1.CreateOptions{})
+			if err != nil {
+
+				// A NotFound Error On Create Indicates The Cluster Does Not Serve The Ingress API - Skip Rather Than Fail
+				if errors.IsNotFound(err) {
+					logger.Warn("Ingress API Not Available - Skipping Receiver Ingress", zap.Error(err))
+					return nil
+				}
+
+				logger.Error("Failed To Create Receiver Ingress", zap.Error(err))
+				return err
+			} else {
+				logger.Info("Successfully Created Receiver Ingress")
+				return nil
+			}
+
+		} else {
+
+			// Failed In Attempt To Get Receiver Ingress From K8S
+			logger.Error("Failed To Get Receiver Ingress", zap.Error(err))
+			return err
+		}
+	} else {
+
+		// Verified The Receiver Ingress Exists
+		logger.Info("Successfully Verified Receiver Ingress")
+		return nil
+	}
+}
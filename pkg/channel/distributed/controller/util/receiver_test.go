@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
+)
+
+// Test That The Shared & Dedicated Receivers' Resources Are Named, Labelled & Owned As Expected
+func TestReceivers(t *testing.T) {
+
+	// Test Data
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-secret", Namespace: "knative-eventing"},
+		Data:       map[string][]byte{constants.KafkaSecretDataKeyBrokers: []byte("broker:9092")},
+	}
+	channel := &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: channelNamespace}}
+	configuration := &config.EventingKafkaConfig{Receiver: config.EKReceiverConfig{
+		SeparateMetricsService: true,
+		Ingress:                config.EKReceiverIngressConfig{Enabled: true, Host: "receiver.example.com"},
+	}}
+	environment := &env.Environment{MetricsPort: 8081}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                string
+		receiver            Receiver
+		expectedName        string
+		expectedMetricsName string
+		expectedLabels      map[string]string
+		expectedOwner       metav1.OwnerReference
+		expectedSecretName  string
+		expectedChecksum    string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:                "Shared Receiver",
+			receiver:            NewSecretReceiver(constants.DefaultNamingPolicy, secret),
+			expectedName:        ReceiverDnsSafeName(constants.DefaultNamingPolicy, secret.Name),
+			expectedMetricsName: ReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, secret.Name),
+			expectedOwner:       NewSecretOwnerReference(secret),
+			expectedSecretName:  secret.Name,
+			expectedChecksum:    KafkaBrokersChecksum(secret),
+		},
+		{
+			name:                "Dedicated Receiver",
+			receiver:            NewChannelReceiver(constants.DefaultNamingPolicy, channel, secret.Name, "checksum"),
+			expectedName:        ChannelReceiverDnsSafeName(constants.DefaultNamingPolicy, channel),
+			expectedMetricsName: ChannelReceiverMetricsDnsSafeName(constants.DefaultNamingPolicy, channel),
+			expectedLabels:      map[string]string{constants.KafkaChannelNameLabel: channelName, constants.KafkaChannelNamespaceLabel: channelNamespace},
+			expectedOwner:       NewChannelOwnerReference(channel),
+			expectedSecretName:  secret.Name,
+			expectedChecksum:    "checksum",
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Perform The Test
+			service := NewReceiverService(testCase.receiver, configuration, environment)
+			metricsService := NewReceiverMetricsService(testCase.receiver, environment)
+			ingress := NewReceiverIngress(testCase.receiver, configuration)
+			deployment, err := NewReceiverDeployment(testCase.receiver, configuration, environment)
+			assert.Nil(t, err)
+
+			// Verify The Names & Owners Of The Receiver's Resources
+			assert.Equal(t, testCase.expectedName, service.Name)
+			assert.Equal(t, testCase.expectedMetricsName, metricsService.Name)
+			assert.Equal(t, testCase.expectedName, ingress.Name)
+			assert.Equal(t, testCase.expectedName, deployment.Name)
+			for _, ownerReferences := range [][]metav1.OwnerReference{service.OwnerReferences, metricsService.OwnerReferences, ingress.OwnerReferences, deployment.OwnerReferences} {
+				assert.Equal(t, []metav1.OwnerReference{testCase.expectedOwner}, ownerReferences)
+			}
+
+			// Verify The Owner Labels Of The Receiver's Resources
+			for _, labels := range []map[string]string{service.Labels, metricsService.Labels, ingress.Labels, deployment.Labels} {
+				assert.Equal(t, "true", labels[constants.KafkaChannelReceiverLabel])
+				for key, value := range testCase.expectedLabels {
+					assert.Equal(t, value, labels[key])
+				}
+			}
+
+			// Verify The Separate Metrics Service Carries The Metrics Port & ServiceMonitor Selection
+			assert.Len(t, service.Spec.Ports, 1)
+			assert.Empty(t, service.Labels[constants.K8sAppChannelSelectorLabel])
+			assert.Equal(t, constants.K8sAppChannelSelectorValue, metricsService.Labels[constants.K8sAppChannelSelectorLabel])
+			assert.Equal(t, service.Spec.Selector, metricsService.Spec.Selector)
+
+			// Verify The Deployment References The Kafka Secret & Records Its Brokers Checksum
+			assert.Equal(t, []string{testCase.expectedSecretName, testCase.expectedSecretName, testCase.expectedSecretName, testCase.expectedSecretName},
+				ReferencedSecretNames(deployment.Spec.Template.Spec.Containers))
+			assert.Equal(t, testCase.expectedChecksum, deployment.Annotations[constants.KafkaBrokersChecksumAnnotation])
+			assert.Equal(t, testCase.expectedChecksum, deployment.Spec.Template.Annotations[constants.KafkaBrokersChecksumAnnotation])
+		})
+	}
+}
+
+// Test That The Receiver Deployment Is Rejected When The Metrics Port Conflicts With Its Fixed Ports
+func TestNewReceiverDeploymentConflictingPorts(t *testing.T) {
+	receiver := NewChannelReceiver(constants.DefaultNamingPolicy, &kafkav1beta1.KafkaChannel{ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: channelNamespace}}, "kafka-secret", "")
+	deployment, err := NewReceiverDeployment(receiver, &config.EventingKafkaConfig{}, &env.Environment{MetricsPort: constants.HealthPort})
+	assert.Nil(t, deployment)
+	assert.NotNil(t, err)
+}
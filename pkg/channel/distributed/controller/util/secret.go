@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 )
 
@@ -49,3 +50,71 @@ func NewSecretOwnerReference(secret *corev1.Secret) metav1.OwnerReference {
 		Controller:         &controller,
 	}
 }
+
+// Create The (Optional) Schema Registry Authentication EnvVars Referencing The Specified Kafka Secret
+func SchemaRegistryEnvVars(secretName string) []corev1.EnvVar {
+	optional := true
+	return []corev1.EnvVar{
+		{
+			Name: commonenv.SchemaRegistryUsernameEnvVarKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  constants.KafkaSecretDataKeySchemaRegistryUsername,
+					Optional:             &optional,
+				},
+			},
+		},
+		{
+			Name: commonenv.SchemaRegistryPasswordEnvVarKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  constants.KafkaSecretDataKeySchemaRegistryPassword,
+					Optional:             &optional,
+				},
+			},
+		},
+	}
+}
+
+// Create The (Optional) Kafka SASL Mechanism EnvVar Referencing The Specified Kafka Secret
+func KafkaSaslMechanismEnvVar(secretName string) corev1.EnvVar {
+	optional := true
+	return corev1.EnvVar{
+		Name: commonenv.KafkaSaslMechanismEnvVarKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  constants.KafkaSecretDataKeySaslMechanism,
+				Optional:             &optional,
+			},
+		},
+	}
+}
+
+// Get A Checksum Of The Brokers In The Specified Kafka Secret (Identifies When The Brokers Have Changed)
+func KafkaBrokersChecksum(secret *corev1.Secret) string {
+	return GenerateHash(string(secret.Data[constants.KafkaSecretDataKeyBrokers]), 32)
+}
+
+// Get The Annotations Recording The Specified Kafka Brokers Checksum On A Deployment Or Pod Template (Nil If Empty)
+func KafkaBrokersChecksumAnnotations(checksum string) map[string]string {
+	if len(checksum) <= 0 {
+		return nil
+	}
+	return map[string]string{constants.KafkaBrokersChecksumAnnotation: checksum}
+}
+
+// Get The Names Of The Secrets Referenced By The Specified Containers' EnvVars (In Order Of Reference)
+func ReferencedSecretNames(containers []corev1.Container) []string {
+	var secretNames []string
+	for _, container := range containers {
+		for _, envVar := range container.Env {
+			if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+				secretNames = append(secretNames, envVar.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return secretNames
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -64,3 +65,78 @@ func TestNewSecretOwnerReference(t *testing.T) {
 	assert.True(t, *controllerRef.BlockOwnerDeletion)
 	assert.True(t, *controllerRef.Controller)
 }
+
+// Test The SchemaRegistryEnvVars() Functionality
+func TestSchemaRegistryEnvVars(t *testing.T) {
+
+	// Test Data
+	const secretName = "TestSecretName"
+
+	// Perform The Test
+	envVars := SchemaRegistryEnvVars(secretName)
+
+	// Validate Results
+	assert.Len(t, envVars, 2)
+	assert.Equal(t, commonenv.SchemaRegistryUsernameEnvVarKey, envVars[0].Name)
+	assert.Equal(t, constants.KafkaSecretDataKeySchemaRegistryUsername, envVars[0].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, commonenv.SchemaRegistryPasswordEnvVarKey, envVars[1].Name)
+	assert.Equal(t, constants.KafkaSecretDataKeySchemaRegistryPassword, envVars[1].ValueFrom.SecretKeyRef.Key)
+	for _, envVar := range envVars {
+		assert.Equal(t, secretName, envVar.ValueFrom.SecretKeyRef.Name)
+		assert.True(t, *envVar.ValueFrom.SecretKeyRef.Optional)
+	}
+}
+
+// Test The KafkaSaslMechanismEnvVar() Functionality
+func TestKafkaSaslMechanismEnvVar(t *testing.T) {
+
+	// Test Data
+	const secretName = "TestSecretName"
+
+	// Perform The Test
+	envVar := KafkaSaslMechanismEnvVar(secretName)
+
+	// Validate Results
+	assert.Equal(t, commonenv.KafkaSaslMechanismEnvVarKey, envVar.Name)
+	assert.Equal(t, constants.KafkaSecretDataKeySaslMechanism, envVar.ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, secretName, envVar.ValueFrom.SecretKeyRef.Name)
+	assert.True(t, *envVar.ValueFrom.SecretKeyRef.Optional)
+}
+
+// Test The KafkaBrokersChecksum() Functionality
+func TestKafkaBrokersChecksum(t *testing.T) {
+
+	// Test Data
+	newSecret := func(brokers string, password string) *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{
+				constants.KafkaSecretDataKeyBrokers:  []byte(brokers),
+				constants.KafkaSecretDataKeyPassword: []byte(password),
+			},
+		}
+	}
+
+	// Perform The Test
+	checksum := KafkaBrokersChecksum(newSecret("broker1:9092", "password1"))
+
+	// Validate Results (Only Changes To The Brokers Change The Checksum)
+	assert.Len(t, checksum, 32)
+	assert.Equal(t, checksum, KafkaBrokersChecksum(newSecret("broker1:9092", "password2")))
+	assert.NotEqual(t, checksum, KafkaBrokersChecksum(newSecret("broker2:9092", "password1")))
+}
+
+// Test The KafkaBrokersChecksumAnnotations() Functionality
+func TestKafkaBrokersChecksumAnnotations(t *testing.T) {
+	assert.Nil(t, KafkaBrokersChecksumAnnotations(""))
+	assert.Equal(t, map[string]string{constants.KafkaBrokersChecksumAnnotation: "checksum"}, KafkaBrokersChecksumAnnotations("checksum"))
+}
+
+// Test The ReferencedSecretNames() Functionality
+func TestReferencedSecretNames(t *testing.T) {
+	assert.Nil(t, ReferencedSecretNames(nil))
+	containers := []corev1.Container{
+		{Env: []corev1.EnvVar{{Name: "PLAIN", Value: "value"}, KafkaSaslMechanismEnvVar("secret1")}},
+		{Env: SchemaRegistryEnvVars("secret2")},
+	}
+	assert.Equal(t, []string{"secret1", "secret2", "secret2"}, ReferencedSecretNames(containers))
+}
@@ -22,6 +22,11 @@ import (
 )
 
 // Get The TopicName For Specified KafkaChannel (ChannelNamespace.ChannelName)
+//
+// Namespaces are DNS labels (which cannot contain a '.') so the first '.' always separates the namespace from the
+// KafkaChannel name, and neither may contain an '_' (which Kafka considers to collide with '.').  Distinct
+// KafkaChannels therefore never resolve to the same (or a colliding) Topic, so no collision detection is required
+// unless the format is ever made configurable.
 func TopicName(channel *kafkav1beta1.KafkaChannel) string {
 	return commonkafkautil.TopicName(channel.Namespace, channel.Name)
 }
@@ -45,3 +45,26 @@ func TestTopicName(t *testing.T) {
 	expectedTopicName := channelNamespace + "." + channelName
 	assert.Equal(t, expectedTopicName, actualTopicName)
 }
+
+// Test That Distinct KafkaChannels Never Resolve To The Same TopicName
+func TestTopicNameUniqueness(t *testing.T) {
+
+	// KafkaChannels Whose Names Contain Dots (Which Would Be Ambiguous If Namespaces Could Also Contain Them)
+	channels := []*kafkav1beta1.KafkaChannel{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "b.c"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "b-c"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "a-b", Name: "c"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ab", Name: "c"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "bc"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "b.c.d"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "c.d"}},
+	}
+
+	// Verify Each KafkaChannel Has A Distinct TopicName
+	topicNames := make(map[string]*kafkav1beta1.KafkaChannel, len(channels))
+	for _, channel := range channels {
+		topicName := TopicName(channel)
+		assert.NotContains(t, topicNames, topicName, "%s/%s collides with another KafkaChannel", channel.Namespace, channel.Name)
+		topicNames[topicName] = channel
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+)
+
+// The GroupVersionResource Of The (Optional) VerticalPodAutoscaler CRD
+var VerticalPodAutoscalerGVR = schema.GroupVersionResource{
+	Group:    constants.VerticalPodAutoscalerGroup,
+	Version:  constants.VerticalPodAutoscalerVersion,
+	Resource: constants.VerticalPodAutoscalerResource,
+}
+
+//
+// Reconcile The VerticalPodAutoscaler For The Specified (Receiver / Dispatcher) Deployment
+//
+// The VerticalPodAutoscaler CRD is not part of core Kubernetes, so it is managed via the dynamic client and its
+// absence (reported as NotFound when creating the resource) is logged and otherwise ignored.  An existing
+// VerticalPodAutoscaler is left untouched so that operators are free to adjust it (e.g. its resourcePolicy).
+//
+func ReconcileVerticalPodAutoscaler(ctx context.Context, logger *zap.Logger, dynamicClient dynamic.Interface, deployment *appsv1.Deployment) error {
+
+	// Get A Namespaced Client For VerticalPodAutoscalers
+	vpaClient := dynamicClient.Resource(VerticalPodAutoscalerGVR).Namespace(deployment.Namespace)
+
+	// Attempt To Get The Deployment's VerticalPodAutoscaler (Same Name As The Deployment)
+	_, err := vpaClient.Get(ctx, deployment.Name, metav1.GetOptions{})
+	if err == nil {
+		logger.Debug("Successfully Verified VerticalPodAutoscaler", zap.String("Deployment", deployment.Name))
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get VerticalPodAutoscaler '%s': %v", deployment.Name, err)
+	}
+
+	// Not Found - Create A New VerticalPodAutoscaler For The Deployment
+	_, err = vpaClient.Create(ctx, NewVerticalPodAutoscaler(deployment), metav1.CreateOptions{})
+	if err == nil {
+		logger.Info("Successfully Created VerticalPodAutoscaler", zap.String("Deployment", deployment.Name))
+		return nil
+	} else if errors.IsNotFound(err) {
+		logger.Warn("VerticalPodAutoscaler CRD Not Installed - Skipping VerticalPodAutoscaler Creation", zap.String("Deployment", deployment.Name))
+		return nil
+	} else if errors.IsAlreadyExists(err) {
+		return nil
+	} else {
+		return fmt.Errorf("failed to create VerticalPodAutoscaler '%s': %v", deployment.Name, err)
+	}
+}
+
+//
+// Create A Recommendation-Only VerticalPodAutoscaler Model For The Specified Deployment
+//
+// The "Off" update mode causes the VerticalPodAutoscaler to publish its resource recommendations in its
+// status without ever evicting / resizing the Deployment's Pods, so that it does not fight with the
+// controller over the configured Receiver / Dispatcher resources.
+//
+func NewVerticalPodAutoscaler(deployment *appsv1.Deployment) *unstructured.Unstructured {
+
+	// Create The VerticalPodAutoscaler Targeting The Deployment
+	vpa := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": VerticalPodAutoscalerGVR.GroupVersion().String(),
+			"kind":       constants.VerticalPodAutoscalerKind,
+			"spec": map[string]interface{}{
+				"targetRef": map[string]interface{}{
+					"apiVersion": appsv1.SchemeGroupVersion.String(),
+					"kind":       constants.DeploymentKind,
+					"name":       deployment.Name,
+				},
+				"updatePolicy": map[string]interface{}{
+					"updateMode": constants.VerticalPodAutoscalerUpdateModeOff,
+				},
+			},
+		},
+	}
+
+	// Share The Deployment's Name, Labels & Owner So That It Is Garbage Collected Along With The Deployment
+	vpa.SetName(deployment.Name)
+	vpa.SetNamespace(deployment.Namespace)
+	labels := make(map[string]string, len(deployment.Labels))
+	for key, value := range deployment.Labels {
+		labels[key] = value
+	}
+	vpa.SetLabels(labels)
+	vpa.SetOwnerReferences(deployment.OwnerReferences)
+
+	// Return The VerticalPodAutoscaler
+	return vpa
+}
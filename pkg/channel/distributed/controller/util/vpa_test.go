@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewVerticalPodAutoscaler() Functionality
+func TestNewVerticalPodAutoscaler(t *testing.T) {
+
+	// Test Data
+	deployment := newVpaTestDeployment()
+
+	// Perform The Test
+	vpa := NewVerticalPodAutoscaler(deployment)
+
+	// Verify The Results
+	assert.NotNil(t, vpa)
+	assert.Equal(t, "autoscaling.k8s.io/v1", vpa.GetAPIVersion())
+	assert.Equal(t, "VerticalPodAutoscaler", vpa.GetKind())
+	assert.Equal(t, deployment.Name, vpa.GetName())
+	assert.Equal(t, deployment.Namespace, vpa.GetNamespace())
+	assert.Equal(t, deployment.Labels, vpa.GetLabels())
+	assert.Equal(t, deployment.OwnerReferences, vpa.GetOwnerReferences())
+	targetRef, found, err := unstructured.NestedStringMap(vpa.Object, "spec", "targetRef")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, map[string]string{"apiVersion": "apps/v1", "kind": "Deployment", "name": deployment.Name}, targetRef)
+	updateMode, found, err := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "Off", updateMode)
+
+	// Verify The Labels Are Copied Rather Than Shared
+	vpa.SetLabels(map[string]string{"foo": "bar"})
+	assert.Equal(t, channelName, deployment.Labels[constants.KafkaChannelNameLabel])
+}
+
+// Test The ReconcileVerticalPodAutoscaler() Functionality
+func TestReconcileVerticalPodAutoscaler(t *testing.T) {
+
+	// Test Data
+	deployment := newVpaTestDeployment()
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name        string
+		objects     []runtime.Object
+		reactor     clientgotesting.ReactionFunc
+		wantCreate  bool
+		wantErr     bool
+		wantCreated bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:        "Create Missing VerticalPodAutoscaler",
+			wantCreate:  true,
+			wantCreated: true,
+		},
+		{
+			name:    "Existing VerticalPodAutoscaler",
+			objects: []runtime.Object{NewVerticalPodAutoscaler(deployment)},
+		},
+		{
+			name: "VerticalPodAutoscaler CRD Not Installed",
+			reactor: func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, nil, k8serrors.NewNotFound(VerticalPodAutoscalerGVR.GroupResource(), deployment.Name)
+			},
+			wantCreate: true,
+		},
+		{
+			name: "Create Error",
+			reactor: func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("create failure")
+			},
+			wantCreate: true,
+			wantErr:    true,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Fake Dynamic Client With The Specified Objects / Create Reactor
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), testCase.objects...)
+			if testCase.reactor != nil {
+				dynamicClient.PrependReactor("create", constants.VerticalPodAutoscalerResource, testCase.reactor)
+			}
+
+			// Perform The Test
+			err := ReconcileVerticalPodAutoscaler(context.TODO(), logtesting.TestLogger(t).Desugar(), dynamicClient, deployment)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			creates := 0
+			for _, action := range dynamicClient.Actions() {
+				if action.GetVerb() == "create" {
+					creates++
+				}
+			}
+			assert.Equal(t, testCase.wantCreate, creates > 0)
+			vpa, err := dynamicClient.Resource(VerticalPodAutoscalerGVR).Namespace(deployment.Namespace).Get(context.TODO(), deployment.Name, metav1.GetOptions{})
+			if testCase.wantCreated || len(testCase.objects) > 0 {
+				assert.Nil(t, err)
+				assert.Equal(t, NewVerticalPodAutoscaler(deployment), vpa)
+			} else {
+				assert.True(t, k8serrors.IsNotFound(err))
+			}
+		})
+	}
+}
+
+// Utility Function For Creating A Test (Dispatcher) Deployment
+func newVpaTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dispatcher",
+			Namespace: "knative-eventing",
+			Labels: map[string]string{
+				constants.AppLabel:                    "test-dispatcher",
+				constants.KafkaChannelNameLabel:       channelName,
+				constants.KafkaChannelDispatcherLabel: "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "messaging.knative.dev/v1beta1", Kind: "KafkaChannel", Name: channelName},
+			},
+		},
+	}
+}
@@ -19,4 +19,144 @@ package constants
 // Global Constants
 const (
 	Component = "eventing-kafka-channel-dispatcher"
+
+	// Policies For Handling Consumed Messages Which Cannot Be Deserialized Into CloudEvents
+	DeserializationFailurePolicySkip       = "skip"       // Mark The Message As Consumed & Drop It
+	DeserializationFailurePolicyDeadLetter = "deadletter" // Route The Message To The Subscriber's DeadLetterSink
+	DeserializationFailurePolicyFail       = "fail"       // Block The Partition, Redelivering The Message With A Back-Off
+	DefaultDeserializationFailurePolicy    = DeserializationFailurePolicySkip
+
+	// Policies For Handling Tombstone Records (Null Value, Marking A Key For Deletion On Compacted Topics)
+	TombstonePolicySkip     = "skip"     // Mark The Record As Consumed Without Dispatching It
+	TombstonePolicyDispatch = "dispatch" // Dispatch A Delete-Typed CloudEvent Identifying The Record's Key
+	DefaultTombstonePolicy  = TombstonePolicySkip
+
+	// Policies For Handling Consumed Record Batches Which Cannot Be Decompressed (Corrupt Data)
+	DecompressionFailurePolicyRetry   = "retry" // Keep Re-Fetching The Batch (Sarama's Default Behavior)
+	DecompressionFailurePolicyFail    = "fail"  // Stop The Subscriber's ConsumerGroup & Report The Subscriber As Failed
+	DefaultDecompressionFailurePolicy = DecompressionFailurePolicyRetry
+
+	// Policies For Handling Consumed Messages Larger Than The Configured Maximum Message Size
+	OversizedMessagePolicySkip       = "skip"       // Mark The Message As Consumed & Drop It
+	OversizedMessagePolicyDeadLetter = "deadletter" // Route The Message To The Subscriber's DeadLetterSink (Skipped If There Is None)
+	DefaultOversizedMessagePolicy    = OversizedMessagePolicySkip
+
+	// Policies For Handling Replies Which Cannot Be Delivered To A Subscription's Reply Destination (e.g. Since Removed)
+	ReplyFailurePolicyFail       = "fail"       // Treat The Delivery As Failed (Sending The Original Event To Any DeadLetterSink)
+	ReplyFailurePolicyDrop       = "drop"       // Drop The Reply Once Its Retries Are Exhausted, Treating The Delivery As Successful
+	ReplyFailurePolicyDeadLetter = "deadletter" // Route The Reply To The Subscriber's DeadLetterSink Once Its Retries Are Exhausted (Dropped If There Is None)
+	DefaultReplyFailurePolicy    = ReplyFailurePolicyFail
+
+	// Policies For Handling Redirect (3xx) Responses From Subscribers
+	RedirectPolicyFollow   = "follow"   // Follow Redirects Per The Go HTTP Client (301-303 Are Re-Sent As GET Requests Without The Event)
+	RedirectPolicyPreserve = "preserve" // Follow Redirects Re-Sending The CloudEvent's Method, Headers & Body Unchanged
+	RedirectPolicyFail     = "fail"     // Treat The Redirect As A Failed Delivery (Sending The Event To Any DeadLetterSink)
+	DefaultRedirectPolicy  = RedirectPolicyFollow
+
+	// Policies For Deriving Each Subscriber's ConsumerGroup ID
+	GroupIdPolicyUid     = "uid"    // Identified By The Subscription UID (A Changed UID Results In A New ConsumerGroup)
+	GroupIdPolicyStable  = "stable" // Derived From The Topic, Subscriber & Reply URIs And GroupIdKey (Survives UID Changes)
+	DefaultGroupIdPolicy = GroupIdPolicyUid
+
+	// Policies For Handling Subscription UIDs Containing Characters Other Than ASCII Alphanumerics, '.', '_' & '-'
+	GroupIdSanitizationPolicyReplace = "replace" // Replace Each Such Character With An '_' In The "uid" ConsumerGroup ID
+	GroupIdSanitizationPolicyReject  = "reject"  // Fail The Subscription Without Creating A ConsumerGroup
+	DefaultGroupIdSanitizationPolicy = GroupIdSanitizationPolicyReplace
+
+	// Policies For Choosing Which SubscriberSpec To Retain When Multiple Share The Same UID
+	DuplicateSubscriberPolicyFirst   = "first" // Retain The First SubscriberSpec With A Given UID
+	DuplicateSubscriberPolicyLast    = "last"  // Retain The Last SubscriberSpec With A Given UID
+	DefaultDuplicateSubscriberPolicy = DuplicateSubscriberPolicyFirst
+
+	// Policies For Handling SubscriberSpecs Without A SubscriberURI (i.e. Reply-Only Subscriptions)
+	MissingSubscriberPolicyAllow   = "allow"  // Consume The Subscription, Forwarding Its Events Directly To Any Reply
+	MissingSubscriberPolicyReject  = "reject" // Fail The Subscription Without Creating A ConsumerGroup
+	DefaultMissingSubscriberPolicy = MissingSubscriberPolicyAllow
+
+	// Policies For Handling The Kafka Brokers Being Unreachable At Startup
+	BrokerUnavailablePolicyCrash    = "crash"    // Exit Non-Zero So That Kubernetes Restarts The Dispatcher With Back-Off
+	BrokerUnavailablePolicyDegraded = "degraded" // Stay Up Reporting Not Ready While Retrying In The Background
+	DefaultBrokerUnavailablePolicy  = BrokerUnavailablePolicyDegraded
+
+	// Interval Between Attempts To Reach The Kafka Brokers While Degraded (If No Interval Is Configured)
+	DefaultBrokerRetryIntervalMillis = 5000
+
+	// Interval Between Checks For The Topic's Existence At Startup (If Waiting Is Enabled But No Interval Is Configured)
+	DefaultTopicWaitIntervalMillis = 1000
+
+	// Interval Between Checks For The ConfigMap's Existence At Startup (If Waiting Is Enabled)
+	ConfigMapWaitIntervalMillis = 1000
+
+	// Maximum Time To Wait For Each Subscriber's Consume Loop To Exit When Closing Its ConsumerGroup (If Not Configured)
+	DefaultShutdownTimeoutMillis = 10000
+
+	// Kubernetes' Default Termination Grace Period (Assumed If The Dispatcher's Deployment Does Not Specify Its Own)
+	DefaultTerminationGracePeriodSeconds = 30
+
+	// Time Reserved At The End Of The Termination Grace Period For Exiting After Draining (If Not Configured)
+	DefaultDrainMarginMillis = 2000
+
+	// Duration For Which A Partition's Consumption Is Paused When Its Subscriber Is Overloaded (If Not Configured)
+	DefaultOverloadPauseCooldownMillis = 5000
+
+	// Maximum Time Spent Retrying A Message Of A Subscriber Favoring Progress Over Ordering (If Not Configured)
+	DefaultRetryBudgetMillis = 60000
+
+	// Interval Before Retrying A Consume() Which Failed Because The ConsumerGroup Coordinator Was Unavailable (If Not Configured)
+	DefaultCoordinatorRetryIntervalMillis = 100
+
+	// Interval Before Retrying A Consume() Which Failed For Any Other Reason, e.g. The Brokers Being Down (If Not Configured)
+	DefaultConsumeRetryIntervalMillis = 1000
+
+	// Maximum Back-Off Before Redelivering A Message Which Blocks Its Partition (Doubled From The Consume Retry Interval)
+	MaxRedeliveryIntervalMillis = 60000
+
+	// Interval Between Attempts To Deliver A Reply With The "drop" / "deadletter" ReplyFailurePolicy (If Not Configured)
+	DefaultReplyRetryIntervalMillis = 1000
+
+	// Maximum Number Of Consecutive Redirects Followed For A Single Delivery (If Not Configured, Matching The Go HTTP Client)
+	DefaultRedirectMax = 10
+
+	// Number Of Identical Delivery Error Logs Written Per Sampling Interval Before Only Every "Thereafter" Is Written (If Not Configured)
+	DefaultErrorLogSamplingFirst      = 1
+	DefaultErrorLogSamplingThereafter = 100
+
+	// Path Of The Health Server Endpoint Reporting The Consumer Lag Derived Scaling Signal (If A Target Lag Is Configured)
+	ScalingPath = "/scaling"
+
+	// Reasons Of The Kubernetes Events Recorded On The KafkaChannel For ConsumerGroup Lifecycle Transitions
+	ConsumerGroupJoinedEventReason = "ConsumerGroupJoined"
+	PartitionsAssignedEventReason  = "PartitionsAssigned"
+	PartitionsRevokedEventReason   = "PartitionsRevoked"
+	ConsumerGroupLeftEventReason   = "ConsumerGroupLeft"
+
+	// Label Identifying A Secret (In The KafkaChannel's Namespace) Containing A Subscriber's TLS Client Certificate
+	SubscriberTLSSecretLabel = "eventing-kafka.knative.dev/subscriber-uid" // Value Is The Subscriber's UID
+	SubscriberTLSCACertKey   = "ca.crt"                                    // Optional CA Used To Verify The Subscriber (In Addition To tls.crt / tls.key)
+
+	// Annotation On The KafkaChannel Specifying Where Newly Created Subscriber ConsumerGroups Start Consuming
+	InitialOffsetsAnnotation = "eventing-kafka.knative.dev/initial-offsets" // JSON Map Of Subscriber UID Or URI To InitialOffset
+	InitialOffsetOldest      = "oldest"                                     // Replay The Topic From The Oldest Available Offset
+	InitialOffsetNewest      = "newest"                                     // Skip The Topic's Backlog & Consume Only New Messages
+	InitialOffsetGroupPrefix = "group:"                                     // Prefix Of A Source ConsumerGroup Whose Committed Offsets Are Copied
+
+	// Annotation On The KafkaChannel Specifying Whether Subscribers Favor Ordering Or Progress Past Undeliverable Messages
+	HeadOfLinePoliciesAnnotation = "eventing-kafka.knative.dev/head-of-line-policies" // JSON Map Of Subscriber UID Or URI To HeadOfLinePolicy
+	HeadOfLinePolicyOrdering     = "ordering"                                         // Retry Per The Delivery Spec Without Ever Skipping A Message (Default)
+	HeadOfLinePolicyProgress     = "progress"                                         // Dead-Letter Or Skip A Message Once Its Retry Budget Is Exhausted
+	DefaultHeadOfLinePolicy      = HeadOfLinePolicyOrdering
+
+	// Annotation On The KafkaChannel Marking It As A Bridge For Legacy (Non-CloudEvent) Records
+	LegacyBridgeAnnotation           = "eventing-kafka.knative.dev/legacy-bridge" // JSON Object With The "type" & "source" Of Synthesized CloudEvents
+	LegacyBridgeJsonDataContentType  = "application/json"                         // DataContentType Of Records With A Valid JSON Value
+	LegacyBridgeBytesDataContentType = "application/octet-stream"                 // DataContentType Of All Other Records
+
+	// CloudEvent Wrapping Undeserializable Messages Routed To The DeadLetterSink
+	DeserializationFailureEventType       = "dev.knative.kafka.deserializationfailure"
+	DeserializationFailureErrorExtension  = "deserializationerror"
+	DeserializationFailureDataContentType = "application/octet-stream"
+
+	// CloudEvent Dispatched For Tombstone Records When The TombstonePolicy Is "dispatch"
+	TombstoneEventType    = "dev.knative.kafka.delete"
+	TombstoneKeyExtension = "partitionkey" // The Record's Key (As Per The CloudEvents Partitioning Extension)
 )
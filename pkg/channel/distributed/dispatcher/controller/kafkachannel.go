@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -25,12 +26,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
+	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
@@ -58,6 +66,7 @@ type Reconciler struct {
 	dispatcher           dispatcher.Dispatcher
 	kafkachannelInformer cache.SharedIndexInformer
 	kafkachannelLister   listers.KafkaChannelLister
+	secretLister         corev1listers.SecretLister
 	impl                 *controller.Impl
 	recorder             record.EventRecorder
 	kafkaClientSet       versioned.Interface
@@ -72,6 +81,7 @@ func NewController(
 	channelKey string,
 	dispatcher dispatcher.Dispatcher,
 	kafkachannelInformer informers.KafkaChannelInformer,
+	secretInformer corev1informers.SecretInformer,
 	kubeClient kubernetes.Interface,
 	kafkaClientSet versioned.Interface,
 	stopChannel <-chan struct{},
@@ -83,6 +93,7 @@ func NewController(
 		dispatcher:           dispatcher,
 		kafkachannelInformer: kafkachannelInformer.Informer(),
 		kafkachannelLister:   kafkachannelInformer.Lister(),
+		secretLister:         secretInformer.Lister(),
 		kafkaClientSet:       kafkaClientSet,
 	}
 	reconciler.impl = controller.NewImpl(reconciler, reconciler.logger.Sugar(), ReconcilerName)
@@ -91,6 +102,15 @@ func NewController(
 
 	// Watch for kafka channels.
 	kafkachannelInformer.Informer().AddEventHandler(controller.HandleAll(reconciler.impl.Enqueue))
+
+	// Watch for subscriber TLS secrets, re-reconciling this dispatcher's KafkaChannel so that changes are reloaded.
+	channelNamespace, channelName, _ := cache.SplitMetaNamespaceKey(channelKey)
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: filterSubscriberTLSSecrets(channelNamespace),
+		Handler: controller.HandleAll(func(interface{}) {
+			reconciler.impl.EnqueueKey(types.NamespacedName{Namespace: channelNamespace, Name: channelName})
+		}),
+	})
 	logger.Debug("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcaster()
 	watches := []watch.Interface{
@@ -162,6 +182,11 @@ func (r Reconciler) Reconcile(ctx context.Context, key string) error {
 		r.logger.Info("Successfully Verified / Updated KafkaChannel Status")
 	}
 
+	// Requeue The KafkaChannel If The Dispatcher Has Not Yet Loaded Its Configuration
+	if errors.Is(reconcileError, dispatcher.ErrDispatcherNotConfigured) {
+		return reconcileError
+	}
+
 	// Return Success
 	return nil
 }
@@ -179,11 +204,69 @@ func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
 		subscribers = make([]eventingduck.SubscriberSpec, 0)
 	}
 
-	// Update The ConsumerGroups To Align With Current KafkaChannel Subscribers
-	failedSubscriptions := r.dispatcher.UpdateSubscriptions(subscribers)
+	// Update The Subscriber TLS Client Configuration First So That Any New Subscribers Use It Immediately
+	failedSubscriberTLS, err := r.updateSubscriberTLS(channel.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Record Any ConsumerGroup Lifecycle Events On This KafkaChannel
+	r.dispatcher.UpdateEventRecorder(r.recorder, channel.DeepCopy())
+
+	// Apply The KafkaChannel's Delivery Concurrency Limit (Reporting The Effective Value In Status)
+	channel.Status.MaxDeliveryConcurrency = r.dispatcher.UpdateMaxDeliveryConcurrency(channel.Spec.MaxDeliveryConcurrency)
+
+	// Apply The Initial Offsets Of Any New Subscribers' ConsumerGroups Before Creating Them
+	err = r.dispatcher.UpdateInitialOffsets(channel.Annotations[constants.InitialOffsetsAnnotation])
+	if err != nil {
+		return err
+	}
+
+	// Apply The Subscribers' Ordering / Progress Tradeoff For Messages Which Cannot Be Delivered
+	err = r.dispatcher.UpdateHeadOfLinePolicies(channel.Annotations[constants.HeadOfLinePoliciesAnnotation])
+	if err != nil {
+		return err
+	}
+
+	// Apply The KafkaChannel's Delivery Guarantee (After The HeadOfLinePolicies It Is Validated Against)
+	err = r.dispatcher.UpdateDeliveryGuarantee(channel.DeliveryGuarantee())
+	if err != nil {
+		return err
+	}
+
+	// Wrap Any Records Which Are Not CloudEvents If The KafkaChannel Is Marked As A Legacy Bridge
+	err = r.dispatcher.UpdateLegacyBridge(channel.Annotations[constants.LegacyBridgeAnnotation])
+	if err != nil {
+		return err
+	}
+
+	// Apply The Number Of Dispatcher Shards Before Determining Which Subscribers This Dispatcher Consumes
+	err = r.dispatcher.UpdateShards(channel.Annotations[sharding.DispatcherShardsAnnotation])
+	if err != nil {
+		return err
+	}
+
+	// Update The ConsumerGroups To Align With Current KafkaChannel Subscribers (Requeuing If Not Yet Configured)
+	failedSubscriptions, err := r.dispatcher.UpdateSubscriptions(subscribers)
+	if err != nil {
+		r.logger.Warn("Unable To Update Subscriptions - Requeuing", zap.Error(err))
+		return err
+	}
+
+	// Include Any Subscribers Whose TLS Client Configuration Could Not Be Loaded As Failed
+	if len(failedSubscriberTLS) > 0 {
+		if failedSubscriptions == nil {
+			failedSubscriptions = make(map[eventingduck.SubscriberSpec]error)
+		}
+		for _, subscriber := range subscribers {
+			if err, ok := failedSubscriberTLS[subscriber.UID]; ok {
+				failedSubscriptions[subscriber] = err
+			}
+		}
+	}
 
 	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status
-	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, failedSubscriptions)
+	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, failedSubscriptions, channel.Status.Subscribers)
 
 	// Log Failed Subscriptions & Return Error
 	if len(failedSubscriptions) > 0 {
@@ -195,12 +278,60 @@ func (r Reconciler) reconcile(channel *kafkav1beta1.KafkaChannel) error {
 	return nil
 }
 
+// Update The Dispatcher's Subscriber TLS Client Configuration From The Labelled Secrets In The Specified Namespace
+func (r Reconciler) updateSubscriberTLS(namespace string) (map[types.UID]error, error) {
+
+	// Select Only Secrets Which Identify A Subscriber
+	requirement, err := labels.NewRequirement(constants.SubscriberTLSSecretLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// List The Subscriber TLS Secrets In The KafkaChannel's Namespace
+	secrets, err := r.secretLister.Secrets(namespace).List(labels.NewSelector().Add(*requirement))
+	if err != nil {
+		r.logger.Error("Failed To List Subscriber TLS Secrets", zap.Error(err))
+		return nil, err
+	}
+
+	// Update The Dispatcher With The Current Secrets
+	return r.dispatcher.UpdateSubscriberTLSSecrets(secrets), nil
+}
+
+// Filter Function Accepting Only Subscriber TLS Secrets In The Specified Namespace
+func filterSubscriberTLSSecrets(namespace string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if secret, ok := obj.(*corev1.Secret); ok {
+			_, labelled := secret.Labels[constants.SubscriberTLSSecretLabel]
+			return labelled && secret.Namespace == namespace
+		}
+		return false
+	}
+}
+
+//
 // Create The SubscribableStatus Block Based On The Updated Subscriptions
-func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error) eventingduck.SubscribableStatus {
+//
+// The status of subscribers assigned to another Dispatcher shard is retained from the specified current status (as
+// reported by that shard's Dispatcher) so that the sharded Dispatchers do not overwrite each other's subscriber status.
+//
+func (r *Reconciler) createSubscribableStatus(subscribers []eventingduck.SubscriberSpec, failedSubscriptions map[eventingduck.SubscriberSpec]error, currentStatus []eventingduck.SubscriberStatus) eventingduck.SubscribableStatus {
 
 	subscriberStatus := make([]eventingduck.SubscriberStatus, 0)
 
 	for _, subscriber := range subscribers {
+		if !r.dispatcher.OwnsSubscription(subscriber.UID) {
+			for _, status := range currentStatus {
+				if status.UID == subscriber.UID {
+					subscriberStatus = append(subscriberStatus, status)
+					break
+				}
+			}
+			continue
+		}
 		status := eventingduck.SubscriberStatus{
 			UID:                subscriber.UID,
 			ObservedGeneration: subscriber.Generation,
@@ -17,17 +17,26 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/dispatcher"
 	reconciletesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
@@ -62,10 +71,11 @@ func TestNewController(t *testing.T) {
 	fakeK8sClientSet := fake.NewSimpleClientset()
 	kafkaInformerFactory := externalversions.NewSharedInformerFactory(fakeKafkaChannelClientSet, kncontroller.DefaultResyncPeriod)
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
+	secretInformer := kubeinformers.NewSharedInformerFactory(fakeK8sClientSet, kncontroller.DefaultResyncPeriod).Core().V1().Secrets()
 	stopChan := make(chan struct{})
 
 	// Perform The Test
-	c := NewController(logger, channelKey, mockDispatcher, kafkaChannelInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan)
+	c := NewController(logger, channelKey, mockDispatcher, kafkaChannelInformer, secretInformer, fakeK8sClientSet, fakeKafkaChannelClientSet, stopChan)
 
 	// Verify Results
 	assert.NotNil(t, c)
@@ -155,6 +165,241 @@ func TestAllCases(t *testing.T) {
 				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
 			},
 		},
+		{
+			Name: "channel ready, max delivery concurrency specified",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithMaxDeliveryConcurrency(5),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithMaxDeliveryConcurrency(5),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberReady("1"),
+					reconciletesting.WithEffectiveMaxDeliveryConcurrency(5),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, valid initial offsets annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithInitialOffsets(`{"1":"oldest"}`),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithInitialOffsets(`{"1":"oldest"}`),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriberReady("1"),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, unparseable initial offsets annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithInitialOffsets(`oldest`),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: invalid eventing-kafka.knative.dev/initial-offsets annotation: invalid character 'o' looking for beginning of value"),
+			},
+		},
+		{
+			Name: "channel ready, invalid head-of-line policies annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithHeadOfLinePolicies(`{"1":"skip"}`),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: invalid eventing-kafka.knative.dev/head-of-line-policies annotation: head-of-line policy 'skip' of subscriber '1' must be one of 'ordering' or 'progress'"),
+			},
+		},
+		{
+			Name: "channel ready, invalid legacy bridge annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithLegacyBridge(`{"source":"/legacy"}`),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: invalid eventing-kafka.knative.dev/legacy-bridge annotation: type must be specified"),
+			},
+		},
+		{
+			Name: "channel ready, invalid delivery guarantee annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithDeliveryGuarantee("exactly-once"),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: invalid eventing-kafka.knative.dev/delivery-guarantee annotation: 'exactly-once' must be one of 'at-most-once', 'at-least-once' or 'effectively-once'"),
+			},
+		},
+		{
+			Name: "channel ready, invalid dispatcher shards annotation",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://foobar"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithDispatcherShards("0"),
+					reconciletesting.WithSubscriber("1", "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: invalid eventing-kafka.knative.dev/dispatcher-shards annotation: 0 must be between 1 and 16"),
+			},
+		},
+		{
+			Name: "channel ready, sharded, status of other shard's subscriber retained",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithDispatcherShards("2"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(mockOtherShardSubscriberUID, "http://foobar2"),
+					reconciletesting.WithSubscriberFailed(mockOtherShardSubscriberUID, "other shard failure")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithDispatcherShards("2"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(mockOtherShardSubscriberUID, "http://foobar2"),
+					reconciletesting.WithSubscriberReady("1"),
+					reconciletesting.WithSubscriberFailed(mockOtherShardSubscriberUID, "other shard failure"),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, sharded, other shard's subscriber not yet reported",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithDispatcherShards("2"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(mockOtherShardSubscriberUID, "http://foobar2")),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithDispatcherShards("2"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber(mockOtherShardSubscriberUID, "http://foobar2"),
+					reconciletesting.WithSubscriberReady("1"),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, channelReconciled, "KafkaChannel Reconciled"),
+			},
+		},
+		{
+			Name: "channel ready, subscriber tls secret invalid",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber("2", "http://foobar2")),
+				reconciletesting.NewSubscriberTLSSecret("subscriber-2-tls", testNS, "2"),
+				reconciletesting.NewSubscriberTLSSecret("other-namespace-tls", "other-namespace", "1"),
+			},
+			Key:     kcKey,
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithSubscriber("1", "http://foobar"),
+					reconciletesting.WithSubscriber("2", "http://foobar2"),
+					reconciletesting.WithSubscriberReady("1"),
+					reconciletesting.WithSubscriberFailed("2", mockTLSSecretError.Error()),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: some kafka subscribers failed to subscribe"),
+			},
+		},
+		{
+			Name: "channel ready, dispatcher not yet configured, should requeue",
+			Objects: []runtime.Object{
+				reconciletesting.NewKafkaChannel(kcName, testNS,
+					reconciletesting.WithInitKafkaChannelConditions,
+					reconciletesting.WithKafkaChannelAddress("http://channel"),
+					reconciletesting.WithKafkaChannelReady,
+					reconciletesting.WithSubscriber(mockNotConfiguredSubscriberUID, "http://foobar")),
+			},
+			Key:     kcKey,
+			WantErr: true,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, channelReconcileFailed, "KafkaChannel Reconciliation Failed: dispatcher has no sarama config"),
+			},
+		},
 	}
 
 	table.Test(t, reconciletesting.MakeFactory(func(listers *reconciletesting.Listers, kafkaClient versioned.Interface, eventRecorder record.EventRecorder) controller.Reconciler {
@@ -163,6 +408,7 @@ func TestAllCases(t *testing.T) {
 			channelKey:           kcKey,
 			kafkachannelInformer: nil,
 			kafkachannelLister:   listers.GetKafkaChannelLister(),
+			secretLister:         listers.GetSecretLister(),
 			dispatcher:           NewMockDispatcher(t),
 			recorder:             eventRecorder,
 			kafkaClientSet:       kafkaClient,
@@ -190,13 +436,90 @@ func NewMockDispatcher(t *testing.T) MockDispatcher {
 	return MockDispatcher{t: t}
 }
 
-func (m MockDispatcher) Shutdown() {
+func (m MockDispatcher) Shutdown(_ context.Context) {
 }
 
-func (m MockDispatcher) UpdateSubscriptions(_ []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error {
+// Mock Subscriber UID Whose Subscription Finds The Dispatcher Not Yet Configured
+const mockNotConfiguredSubscriberUID = "not-configured"
+
+func (m MockDispatcher) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) (map[eventingduck.SubscriberSpec]error, error) {
+	for _, subscriberSpec := range subscriberSpecs {
+		if subscriberSpec.UID == mockNotConfiguredSubscriberUID {
+			return nil, dispatcher.ErrDispatcherNotConfigured
+		}
+	}
+	return nil, nil
+}
+
+// Mock Error Returned For Every Subscriber TLS Secret
+var mockTLSSecretError = errors.New("mock subscriber tls secret error")
+
+func (m MockDispatcher) UpdateSubscriberTLSSecrets(secrets []*corev1.Secret) map[types.UID]error {
+	failedSubscriberTLS := make(map[types.UID]error)
+	for _, secret := range secrets {
+		failedSubscriberTLS[types.UID(secret.Labels[constants.SubscriberTLSSecretLabel])] = mockTLSSecretError
+	}
+	return failedSubscriberTLS
+}
+
+func (m MockDispatcher) UpdateMaxDeliveryConcurrency(maxDeliveryConcurrency int32) int32 {
+	return maxDeliveryConcurrency
+}
+
+func (m MockDispatcher) UpdateEventRecorder(_ record.EventRecorder, _ runtime.Object) {
+}
+
+func (m MockDispatcher) UpdateInitialOffsets(annotation string) error {
+	_, _, _, err := dispatcher.ParseInitialOffsets(annotation)
+	return err
+}
+
+func (m MockDispatcher) UpdateHeadOfLinePolicies(annotation string) error {
+	_, err := dispatcher.ParseHeadOfLinePolicies(annotation)
+	return err
+}
+
+func (m MockDispatcher) UpdateLegacyBridge(annotation string) error {
+	_, err := dispatcher.ParseLegacyBridge(annotation)
+	return err
+}
+
+func (m MockDispatcher) UpdateDeliveryGuarantee(annotation string) error {
+	_, err := delivery.GetSettings(annotation)
+	return err
+}
+
+func (m MockDispatcher) UpdateShards(annotation string) error {
+	_, err := sharding.ParseDispatcherShards(annotation)
+	return err
+}
+
+// Mock Subscriber UID Assigned To Another Dispatcher Shard
+const mockOtherShardSubscriberUID = "other-shard"
+
+func (m MockDispatcher) OwnsSubscription(uid types.UID) bool {
+	return uid != mockOtherShardSubscriberUID
+}
+
+func (m MockDispatcher) ConsumerGroupIds() []string {
 	return nil
 }
 
 func (m MockDispatcher) ConfigChanged(*corev1.ConfigMap) dispatcher.Dispatcher {
 	return nil
 }
+
+func (m MockDispatcher) RestoreSubscriptionSnapshot() map[eventingduck.SubscriberSpec]error {
+	return nil
+}
+
+// Test The filterSubscriberTLSSecrets() Functionality
+func TestFilterSubscriberTLSSecrets(t *testing.T) {
+	filter := filterSubscriberTLSSecrets(testNS)
+	secret := reconciletesting.NewSubscriberTLSSecret("subscriber-tls", testNS, "1")
+	assert.True(t, filter(secret))
+	assert.True(t, filter(cache.DeletedFinalStateUnknown{Key: testNS + "/subscriber-tls", Obj: secret}))
+	assert.False(t, filter(reconciletesting.NewSubscriberTLSSecret("subscriber-tls", "other-namespace", "1")))
+	assert.False(t, filter(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: testNS}}))
+	assert.False(t, filter(&corev1.ConfigMap{}))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+//
+// Limit The Number Of Concurrent Deliveries Across All Of A KafkaChannel's Subscribers
+//
+// Each partition claimed by each subscriber's ConsumerGroup delivers its messages sequentially, so the number
+// of concurrent deliveries otherwise grows with the number of subscribers and partitions.  The limit may be
+// changed at any time (e.g. when the KafkaChannel's spec is updated), with any waiting deliveries proceeding
+// as soon as the new limit permits.  A limit of zero leaves deliveries unbounded.
+//
+type deliveryLimiter struct {
+	lock      sync.Mutex
+	available *sync.Cond
+	limit     int32 // Maximum Concurrent Deliveries (Unbounded If Zero)
+	active    int32 // Current Number Of Deliveries In Progress
+	peak      int32 // Highest Number Of Concurrent Deliveries Observed (For Observability / Testing)
+}
+
+// deliveryLimiter Constructor
+func newDeliveryLimiter(limit int32) *deliveryLimiter {
+	limiter := &deliveryLimiter{limit: limit}
+	limiter.available = sync.NewCond(&limiter.lock)
+	return limiter
+}
+
+// Update The Maximum Number Of Concurrent Deliveries (Releasing Any Waiting Deliveries The New Limit Permits)
+func (l *deliveryLimiter) setLimit(limit int32) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.limit = limit
+	l.available.Broadcast()
+}
+
+// Get The Maximum Number Of Concurrent Deliveries
+func (l *deliveryLimiter) getLimit() int32 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.limit
+}
+
+// Wait Until Another Delivery Is Permitted & Track It As In Progress
+func (l *deliveryLimiter) acquire() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for l.limit > 0 && l.active >= l.limit {
+		l.available.Wait()
+	}
+	l.active++
+	if l.active > l.peak {
+		l.peak = l.active
+	}
+}
+
+// Track The Completion Of A Delivery (Permitting A Waiting Delivery To Proceed)
+func (l *deliveryLimiter) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.active--
+	l.available.Signal()
+}
+
+// Get The Highest Number Of Concurrent Deliveries Observed
+func (l *deliveryLimiter) peakDeliveries() int32 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.peak
+}
+
+//
+// MessageDispatcher Wrapper Which Bounds Its Deliveries By The Specified deliveryLimiter
+//
+// All deliveries (to subscribers, as well as to DeadLetterSinks) are performed via the handler's MessageDispatcher,
+// so wrapping it ensures that each counts toward the limit for the full duration of its retries.
+//
+type limitedMessageDispatcher struct {
+	channel.MessageDispatcher
+	limiter *deliveryLimiter
+}
+
+// limitedMessageDispatcher Constructor
+func newLimitedMessageDispatcher(messageDispatcher channel.MessageDispatcher, limiter *deliveryLimiter) channel.MessageDispatcher {
+	return &limitedMessageDispatcher{MessageDispatcher: messageDispatcher, limiter: limiter}
+}
+
+// Dispatch The Message (With Retries) Once Permitted By The deliveryLimiter
+func (d *limitedMessageDispatcher) DispatchMessageWithRetries(ctx context.Context, message binding.Message, additionalHeaders http.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL, config *kncloudevents.RetryConfig) error {
+	d.limiter.acquire()
+	defer d.limiter.release()
+	return d.MessageDispatcher.DispatchMessageWithRetries(ctx, message, additionalHeaders, destination, reply, deadLetter, config)
+}
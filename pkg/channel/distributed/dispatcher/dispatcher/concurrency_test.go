@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test That The limitedMessageDispatcher Enforces The deliveryLimiter's Limit
+func TestLimitedMessageDispatcher(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		limit      int32
+		deliveries int
+		wantPeak   int32
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unbounded", limit: 0, deliveries: 8, wantPeak: 8},
+		{name: "Limit Of One", limit: 1, deliveries: 8, wantPeak: 1},
+		{name: "Limit Of Three", limit: 3, deliveries: 8, wantPeak: 3},
+		{name: "Limit Exceeding Deliveries", limit: 10, deliveries: 8, wantPeak: 8},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A limitedMessageDispatcher Wrapping A Mock Which Blocks Until Released
+			limiter := newDeliveryLimiter(testCase.limit)
+			blockingDispatcher := newBlockingMessageDispatcher()
+			messageDispatcher := newLimitedMessageDispatcher(blockingDispatcher, limiter)
+
+			// Perform The Deliveries Concurrently (As Multiple Subscribers / Partitions Would)
+			waitGroup := sync.WaitGroup{}
+			for i := 0; i < testCase.deliveries; i++ {
+				waitGroup.Add(1)
+				go func() {
+					defer waitGroup.Done()
+					assert.Nil(t, messageDispatcher.DispatchMessageWithRetries(context.Background(), nil, nil, nil, nil, nil, nil))
+				}()
+			}
+
+			// Verify Only The Permitted Number Of Deliveries Are In Progress
+			assert.Eventually(t, func() bool { return blockingDispatcher.inProgress() == int(testCase.wantPeak) }, 5*time.Second, 5*time.Millisecond)
+			time.Sleep(50 * time.Millisecond) // Allow Any Deliveries Incorrectly Exceeding The Limit To Start
+			assert.Equal(t, int(testCase.wantPeak), blockingDispatcher.inProgress())
+
+			// Release The Deliveries & Verify They All Complete Without Ever Exceeding The Limit
+			blockingDispatcher.releaseAll()
+			waitGroup.Wait()
+			assert.Equal(t, testCase.deliveries, blockingDispatcher.completed())
+			assert.Equal(t, testCase.wantPeak, limiter.peakDeliveries())
+		})
+	}
+}
+
+// Test That Changing The deliveryLimiter's Limit Releases Waiting Deliveries
+func TestDeliveryLimiterSetLimit(t *testing.T) {
+
+	// Create A limitedMessageDispatcher With A Limit Of One
+	limiter := newDeliveryLimiter(1)
+	blockingDispatcher := newBlockingMessageDispatcher()
+	messageDispatcher := newLimitedMessageDispatcher(blockingDispatcher, limiter)
+
+	// Perform Several Deliveries Concurrently
+	deliveries := 4
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < deliveries; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			assert.Nil(t, messageDispatcher.DispatchMessageWithRetries(context.Background(), nil, nil, nil, nil, nil, nil))
+		}()
+	}
+	assert.Eventually(t, func() bool { return blockingDispatcher.inProgress() == 1 }, 5*time.Second, 5*time.Millisecond)
+
+	// Raise The Limit & Verify The Waiting Deliveries Proceed
+	limiter.setLimit(3)
+	assert.Equal(t, int32(3), limiter.getLimit())
+	assert.Eventually(t, func() bool { return blockingDispatcher.inProgress() == 3 }, 5*time.Second, 5*time.Millisecond)
+
+	// Remove The Limit & Verify All Deliveries Proceed
+	limiter.setLimit(0)
+	assert.Eventually(t, func() bool { return blockingDispatcher.inProgress() == deliveries }, 5*time.Second, 5*time.Millisecond)
+
+	// Release The Deliveries & Verify They All Complete
+	blockingDispatcher.releaseAll()
+	waitGroup.Wait()
+	assert.Equal(t, deliveries, blockingDispatcher.completed())
+}
+
+// Test The UpdateMaxDeliveryConcurrency() Functionality
+func TestUpdateMaxDeliveryConcurrency(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		configDefault int32
+		channelValue  int32
+		want          int32
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", configDefault: 0, channelValue: 0, want: 0},
+		{name: "Config Default", configDefault: 10, channelValue: 0, want: 10},
+		{name: "Channel Value", configDefault: 0, channelValue: 5, want: 5},
+		{name: "Channel Value Overrides Config Default", configDefault: 10, channelValue: 5, want: 5},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                 logtesting.TestLogger(t).Desugar(),
+				MaxDeliveryConcurrency: testCase.configDefault,
+			}).(*DispatcherImpl)
+			assert.Equal(t, testCase.configDefault, dispatcher.deliveryLimiter.getLimit())
+			assert.Equal(t, testCase.want, dispatcher.UpdateMaxDeliveryConcurrency(testCase.channelValue))
+			assert.Equal(t, testCase.want, dispatcher.deliveryLimiter.getLimit())
+		})
+	}
+}
+
+//
+// MessageDispatcher Which Blocks Each Delivery Until Released Or Abandoned (Tracking The Deliveries In Progress)
+//
+type blockingMessageDispatcher struct {
+	channel.MessageDispatcher
+	lock            sync.Mutex
+	releaseChan     chan struct{}
+	inProgressCount int
+	completedCount  int
+}
+
+func newBlockingMessageDispatcher() *blockingMessageDispatcher {
+	return &blockingMessageDispatcher{releaseChan: make(chan struct{})}
+}
+
+func (d *blockingMessageDispatcher) DispatchMessageWithRetries(ctx context.Context, _ binding.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL, _ *kncloudevents.RetryConfig) error {
+	d.lock.Lock()
+	d.inProgressCount++
+	d.lock.Unlock()
+	var err error
+	select {
+	case <-d.releaseChan:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	d.lock.Lock()
+	d.inProgressCount--
+	if err == nil {
+		d.completedCount++
+	}
+	d.lock.Unlock()
+	return err
+}
+
+func (d *blockingMessageDispatcher) releaseAll() {
+	close(d.releaseChan)
+}
+
+func (d *blockingMessageDispatcher) inProgress() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.inProgressCount
+}
+
+func (d *blockingMessageDispatcher) completed() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.completedCount
+}
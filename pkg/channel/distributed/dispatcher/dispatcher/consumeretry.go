@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+)
+
+//
+// Kafka Errors Indicating The ConsumerGroup Coordinator Is Temporarily Unavailable
+//
+// These are returned by Consume() while the coordinator is being elected or is loading the group's offsets (e.g.
+// during a broker rolling restart), and typically clear within a few hundred milliseconds.  They are therefore
+// retried after the (short) CoordinatorRetryInterval rather than the ConsumeRetryInterval used for other failures
+// such as the brokers being down.
+//
+var coordinatorUnavailableErrors = []sarama.KError{
+	sarama.ErrConsumerCoordinatorNotAvailable,
+	sarama.ErrNotCoordinatorForConsumer,
+	sarama.ErrOffsetsLoadInProgress,
+}
+
+// Determine Whether The Specified Consume() Error Indicates The ConsumerGroup Coordinator Is Temporarily Unavailable
+func IsCoordinatorUnavailableError(err error) bool {
+
+	// Nil Errors Are Never Coordinator Errors
+	if err == nil {
+		return false
+	}
+
+	// Unwrap Any ConsumerError To The Underlying Cause (Sarama's ConsumerError Does Not Implement Unwrap)
+	var consumerError *sarama.ConsumerError
+	if errors.As(err, &consumerError) && consumerError.Err != nil {
+		err = consumerError.Err
+	}
+
+	// Check For The Known Coordinator Errors
+	var kError sarama.KError
+	if errors.As(err, &kError) {
+		for _, coordinatorUnavailableError := range coordinatorUnavailableErrors {
+			if kError == coordinatorUnavailableError {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Log & Count The Specified Consume() Error, Then Wait To Retry (Returning False If The Subscriber Was Stopped Meanwhile)
+func (d *DispatcherImpl) awaitConsumeRetry(logger *zap.Logger, subscriber *SubscriberWrapper, err error) bool {
+
+	// Determine The Retry Interval & Metric Reason Based On The Type Of Error
+	retryInterval := d.ConsumeRetryInterval
+	reason := metrics.ConsumeRetryReasonOther
+	if IsCoordinatorUnavailableError(err) {
+		retryInterval = d.CoordinatorRetryInterval
+		reason = metrics.ConsumeRetryReasonCoordinatorUnavailable
+		logger.Warn("ConsumerGroup Coordinator Unavailable - Retrying Consume", zap.Duration("RetryInterval", retryInterval), zap.Error(err))
+	} else {
+		logger.Error("ConsumerGroup Failed To Consume Messages", zap.Duration("RetryInterval", retryInterval), zap.Error(err))
+	}
+	d.StatsReporter.ReportConsumeRetry(d.Topic, reason)
+
+	// Retry Immediately If No Interval Is Configured
+	if retryInterval <= 0 {
+		return true
+	}
+
+	// Wait For The Retry Interval Unless The Subscriber Is Stopped First
+	timer := time.NewTimer(retryInterval)
+	defer timer.Stop()
+	select {
+	case <-subscriber.StopChan:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The IsCoordinatorUnavailableError() Functionality
+func TestIsCoordinatorUnavailableError(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name string
+		err  error
+		want bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Nil", err: nil, want: false},
+		{name: "Coordinator Not Available", err: sarama.ErrConsumerCoordinatorNotAvailable, want: true},
+		{name: "Not Coordinator For Consumer", err: sarama.ErrNotCoordinatorForConsumer, want: true},
+		{name: "Offsets Load In Progress", err: sarama.ErrOffsetsLoadInProgress, want: true},
+		{name: "Wrapped Coordinator Not Available", err: fmt.Errorf("join failed: %w", sarama.ErrConsumerCoordinatorNotAvailable), want: true},
+		{name: "ConsumerError Coordinator Not Available", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: sarama.ErrConsumerCoordinatorNotAvailable}, want: true},
+		{name: "Broker Unavailable", err: sarama.ErrOutOfBrokers, want: false},
+		{name: "Broker Not Available", err: sarama.ErrBrokerNotAvailable, want: false},
+		{name: "Rebalance In Progress", err: sarama.ErrRebalanceInProgress, want: false},
+		{name: "Other Error", err: errors.New("other"), want: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, IsCoordinatorUnavailableError(testCase.err))
+		})
+	}
+}
+
+// Test The Dispatcher's Retrying Of Consume() Calls Which Fail
+func TestConsumeRetry(t *testing.T) {
+
+	// Test Data
+	subscriberSpec := eventingduck.SubscriberSpec{UID: uid123}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		err          error
+		errCount     int
+		wantConsumes int
+		wantReason   string
+	}
+
+	// Define The TestCases (The ConsumeRetryInterval Is Too Long For A Second Consume() Within The Test)
+	testCases := []TestCase{
+		{name: "Coordinator Unavailable Recovers Quickly", err: sarama.ErrConsumerCoordinatorNotAvailable, errCount: 3, wantConsumes: 4, wantReason: metrics.ConsumeRetryReasonCoordinatorUnavailable},
+		{name: "Offsets Load In Progress Recovers Quickly", err: sarama.ErrOffsetsLoadInProgress, errCount: 2, wantConsumes: 3, wantReason: metrics.ConsumeRetryReasonCoordinatorUnavailable},
+		{name: "Broker Unavailable Awaits Consume Retry Interval", err: sarama.ErrOutOfBrokers, errCount: 1, wantConsumes: 1, wantReason: metrics.ConsumeRetryReasonOther},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After TestCase
+			consumerGroup := newFailingConsumerGroup(testCase.err, testCase.errCount)
+			newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+			kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+				return consumerGroup, nil
+			}
+			defer func() {
+				kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+			}()
+
+			// Create A New DispatcherImpl To Test With A Single Subscriber
+			statsReporter := dispatchertesting.NewMockStatsReporter()
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                   logtesting.TestLogger(t).Desugar(),
+				Topic:                    testTopic,
+				StatsReporter:            statsReporter,
+				SaramaConfig:             getSaramaConfigFromYaml(t, TestConfigBase),
+				ShutdownTimeout:          5 * time.Second,
+				CoordinatorRetryInterval: 10 * time.Millisecond,
+				ConsumeRetryInterval:     time.Hour,
+			}).(*DispatcherImpl)
+			failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+			assert.Len(t, failedSubscriptions, 0)
+
+			// Verify The Expected Consume() Calls & Retries Were Made
+			assert.Eventually(t, func() bool {
+				return consumerGroup.consumeCount() == testCase.wantConsumes && statsReporter.ConsumeRetries(testCase.wantReason) == testCase.errCount
+			}, 2*time.Second, 5*time.Millisecond)
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, testCase.wantConsumes, consumerGroup.consumeCount())
+			assert.Equal(t, testCase.errCount, statsReporter.ConsumeRetries(testCase.wantReason))
+
+			// Verify Shutdown Is Not Delayed By A Pending Retry
+			start := time.Now()
+			dispatcher.Shutdown(context.TODO())
+			assert.Less(t, int64(time.Since(start)), int64(time.Second))
+			assert.Equal(t, testCase.wantConsumes, consumerGroup.consumeCount())
+		})
+	}
+}
+
+//
+// Mock ConsumerGroup Whose Consume() Fails A Number Of Times Before Consuming Until Closed
+//
+type failingConsumerGroup struct {
+	lock       sync.Mutex
+	err        error
+	errCount   int
+	consumes   int
+	errorChan  chan error
+	closedChan chan struct{}
+	closeOnce  sync.Once
+}
+
+// Verify The Mock ConsumerGroup Implements The Interface
+var _ sarama.ConsumerGroup = &failingConsumerGroup{}
+
+// Mock ConsumerGroup Constructor
+func newFailingConsumerGroup(err error, errCount int) *failingConsumerGroup {
+	return &failingConsumerGroup{err: err, errCount: errCount, errorChan: make(chan error), closedChan: make(chan struct{})}
+}
+
+func (c *failingConsumerGroup) Consume(_ context.Context, _ []string, _ sarama.ConsumerGroupHandler) error {
+	c.lock.Lock()
+	c.consumes++
+	failed := c.consumes <= c.errCount
+	c.lock.Unlock()
+	if failed {
+		return c.err
+	}
+	<-c.closedChan
+	return sarama.ErrClosedConsumerGroup
+}
+
+func (c *failingConsumerGroup) Errors() <-chan error {
+	return c.errorChan
+}
+
+func (c *failingConsumerGroup) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closedChan)
+		close(c.errorChan)
+	})
+	return nil
+}
+
+func (c *failingConsumerGroup) consumeCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.consumes
+}
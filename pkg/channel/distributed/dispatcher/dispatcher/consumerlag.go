@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// Function Reference For Creating The Sarama Client Used To Poll Consumer Lag (Facilitates Testing)
+var newConsumerLagClient = sarama.NewClient
+
+//
+// Periodically Report The Consumer Lag Of The Specified Subscriber's ConsumerGroup Via The StatsReporter
+//
+// Every ConsumerLagInterval the high-water mark of each partition of the Topic is compared with the offset
+// committed by the subscriber's ConsumerGroup, and the total is reported as the kafka_consumer_lag gauge tagged
+// with the Topic and the GroupId.  Partitions without a committed offset only count as lag if the ConsumerGroup
+// starts consuming from the oldest offset.  Failures to determine the lag (e.g. the brokers being down) are
+// logged and the previously reported value is left in place until the next successful poll.  Polling ends once
+// the subscriber's StopChan is closed, and is disabled entirely if the interval is not positive.
+//
+func (d *DispatcherImpl) startConsumerLagPolling(logger *zap.Logger, subscriber *SubscriberWrapper) {
+
+	// Nothing To Do If Disabled Or There Is Nowhere To Report The Lag
+	if d.ConsumerLagInterval <= 0 || d.StatsReporter == nil {
+		return
+	}
+
+	// Use A Shallow Copy Of The Sarama Config Without Metadata Retries (Each Poll Is A Single Attempt)
+	config := *d.SaramaConfig
+	config.Metadata.Retry.Max = 0
+	brokers := d.Brokers
+	topic := d.Topic
+	interval := d.ConsumerLagInterval
+
+	// Poll Asynchronously Until The Subscriber Is Stopped
+	go func() {
+		logger.Info("ConsumerGroup Lag Polling Initiated", zap.Duration("Interval", interval))
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-subscriber.StopChan:
+				logger.Info("ConsumerGroup Lag Polling Terminated")
+				return
+			case <-ticker.C:
+				lag, err := consumerGroupLag(brokers, &config, topic, subscriber.GroupId, subscriber.InitialOffset)
+				if err != nil {
+					logger.Warn("Failed To Determine ConsumerGroup Lag", zap.Error(err))
+				} else {
+					d.StatsReporter.ReportConsumerLag(topic, subscriber.GroupId, lag)
+				}
+			}
+		}
+	}()
+}
+
+// Get The Total Lag Across All Partitions Of The Topic For The Specified ConsumerGroup
+func consumerGroupLag(brokers []string, config *sarama.Config, topic string, groupId string, initialOffset int64) (int64, error) {
+
+	// Create A Client & ClusterAdmin (Closing The ClusterAdmin Closes The Client)
+	client, err := newConsumerLagClient(brokers, config)
+	if err != nil {
+		return 0, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return 0, err
+	}
+	defer func() { _ = admin.Close() }()
+
+	// Get The ConsumerGroup's Committed Offset Of Each Partition
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return 0, err
+	}
+	response, err := admin.ListConsumerGroupOffsets(groupId, map[string][]int32{topic: partitions})
+	if err != nil {
+		return 0, err
+	}
+	if response.Err != sarama.ErrNoError {
+		return 0, fmt.Errorf("failed to fetch offsets of consumer group '%s': %w", groupId, response.Err)
+	}
+
+	// Sum The Lag Behind The High-Water Mark Of Each Partition
+	var lag int64
+	for _, partition := range partitions {
+		highWaterMark, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return 0, err
+		}
+		offset := highWaterMark
+		if block := response.GetBlock(topic, partition); block != nil && block.Err != sarama.ErrNoError {
+			return 0, fmt.Errorf("failed to fetch offset of consumer group '%s' for partition %d: %w", groupId, partition, block.Err)
+		} else if block != nil && block.Offset >= 0 {
+			offset = block.Offset
+		} else if initialOffset == sarama.OffsetOldest {
+			if offset, err = client.GetOffset(topic, partition, sarama.OffsetOldest); err != nil {
+				return 0, err
+			}
+		}
+		if highWaterMark > offset {
+			lag += highWaterMark - offset
+		}
+	}
+	return lag, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The consumerGroupLag() Functionality Against A Mock Broker
+func TestConsumerGroupLag(t *testing.T) {
+
+	// Create A Mock Broker With Two Partitions & Two ConsumerGroups
+	broker := newConsumerLagMockBroker(t)
+	defer broker.Close()
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		groupId       string
+		initialOffset int64
+		want          int64
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Committed Offsets", groupId: "group1", initialOffset: sarama.OffsetNewest, want: 60},
+		{name: "Uncommitted Partition With Initial Offset Newest", groupId: "group2", initialOffset: sarama.OffsetNewest, want: 10},
+		{name: "Uncommitted Partition With Initial Offset Oldest", groupId: "group2", initialOffset: sarama.OffsetOldest, want: 40},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lag, err := consumerGroupLag([]string{broker.Addr()}, createConsumerLagConfig(), testTopic, testCase.groupId, testCase.initialOffset)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.want, lag)
+		})
+	}
+}
+
+// Test The consumerGroupLag() Functionality When The Brokers Are Unreachable
+func TestConsumerGroupLagUnavailable(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+	config := createConsumerLagConfig()
+	config.Metadata.Retry.Max = 0
+	lag, err := consumerGroupLag([]string{brokerAddr}, config, testTopic, "group1", sarama.OffsetNewest)
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), lag)
+}
+
+// Test The Periodic Reporting Of A Subscriber's ConsumerGroup Lag Until It Is Stopped
+func TestConsumerLagPolling(t *testing.T) {
+
+	// Create A Mock Broker With Two Partitions & Two ConsumerGroups
+	broker := newConsumerLagMockBroker(t)
+	defer broker.Close()
+
+	// Replace The Consumer Lag Client Creation With One Counting The Polls & Restore After Test
+	var polls int64
+	newConsumerLagClientPlaceholder := newConsumerLagClient
+	newConsumerLagClient = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		atomic.AddInt64(&polls, 1)
+		return sarama.NewClient(addrs, config)
+	}
+	defer func() {
+		newConsumerLagClient = newConsumerLagClientPlaceholder
+	}()
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		interval      time.Duration
+		statsReporter bool
+		wantReported  bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Enabled", interval: 10 * time.Millisecond, statsReporter: true, wantReported: true},
+		{name: "Disabled", interval: 0, statsReporter: true, wantReported: false},
+		{name: "No StatsReporter", interval: 10 * time.Millisecond, statsReporter: false, wantReported: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			atomic.StoreInt64(&polls, 0)
+
+			// Create A DispatcherImpl & Subscriber To Poll The Lag Of
+			mockStatsReporter := dispatchertesting.NewMockStatsReporter()
+			dispatcher := &DispatcherImpl{DispatcherConfig: DispatcherConfig{
+				Logger:              logtesting.TestLogger(t).Desugar(),
+				Brokers:             []string{broker.Addr()},
+				Topic:               testTopic,
+				SaramaConfig:        createConsumerLagConfig(),
+				ConsumerLagInterval: testCase.interval,
+			}}
+			if testCase.statsReporter {
+				dispatcher.StatsReporter = mockStatsReporter
+			}
+			subscriber := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, "group1", nil)
+			subscriber.InitialOffset = sarama.OffsetNewest
+
+			// Start Polling & Verify The Lag Is (Or Is Not) Reported
+			dispatcher.startConsumerLagPolling(dispatcher.Logger, subscriber)
+			if testCase.wantReported {
+				assert.Eventually(t, func() bool {
+					lag, ok := mockStatsReporter.ConsumerLag("group1")
+					return ok && lag == 60
+				}, 2*time.Second, 5*time.Millisecond)
+			} else {
+				time.Sleep(50 * time.Millisecond)
+				_, ok := mockStatsReporter.ConsumerLag("group1")
+				assert.False(t, ok)
+				assert.Equal(t, int64(0), atomic.LoadInt64(&polls))
+			}
+
+			// Stop The Subscriber & Verify Polling Ceases
+			subscriber.stop()
+			time.Sleep(50 * time.Millisecond)
+			stoppedPolls := atomic.LoadInt64(&polls)
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, stoppedPolls, atomic.LoadInt64(&polls))
+		})
+	}
+}
+
+//
+// Create A Mock Broker With Two Partitions & Two ConsumerGroups
+//   - Partition 0 Has Offsets 10 - 100, Partition 1 Has Offsets 20 - 50
+//   - group1 Has Committed 40 & 50 (Lag 60 + 0)
+//   - group2 Has Committed 90 On Partition 0 Only (Lag 10 + Either 0 Or 30 Depending On The Initial Offset)
+//
+func newConsumerLagMockBroker(t *testing.T) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()).
+			SetLeader(testTopic, 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(testTopic, 0, sarama.OffsetNewest, 100).
+			SetOffset(testTopic, 0, sarama.OffsetOldest, 10).
+			SetOffset(testTopic, 1, sarama.OffsetNewest, 50).
+			SetOffset(testTopic, 1, sarama.OffsetOldest, 20),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "group1", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "group2", broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("group1", testTopic, 0, 40, "", sarama.ErrNoError).
+			SetOffset("group1", testTopic, 1, 50, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 0, 90, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 1, -1, "", sarama.ErrNoError),
+	})
+	return broker
+}
+
+// Create A Sarama Config Compatible With The Mock Broker
+func createConsumerLagConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Net.DialTimeout = time.Second
+	return config
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+//
+// Error Message Fragments Of The Codecs Used By Sarama To Decompress Record Batches
+//
+// Sarama returns the codec errors as-is (wrapped in a ConsumerError), and only the gzip / flate errors are
+// available without depending directly on the (vendored) snappy, lz4 & zstd libraries, so the remaining
+// codecs are identified by the text of their corrupt input errors.
+//
+var decompressionErrorMessages = []string{
+	"snappy:",                       // github.com/golang/snappy
+	"malformed xerial framing",      // github.com/eapache/go-xerial-snappy
+	"lz4:",                          // github.com/pierrec/lz4
+	"invalid input:",                // github.com/klauspost/compress/zstd
+	"CRC check failed",              // github.com/klauspost/compress/zstd
+	"invalid compression specified", // github.com/Shopify/sarama (Unknown Codec, Wrapped In A PacketDecodingError)
+}
+
+// Determine Whether The Specified ConsumerGroup Error Represents A Record Batch Which Could Not Be Decompressed
+func IsDecompressionError(err error) bool {
+
+	// Nil Errors Are Never Decompression Errors
+	if err == nil {
+		return false
+	}
+
+	// Unwrap Any ConsumerError To The Underlying Cause (Sarama's ConsumerError Does Not Implement Unwrap)
+	var consumerError *sarama.ConsumerError
+	if errors.As(err, &consumerError) && consumerError.Err != nil {
+		err = consumerError.Err
+	}
+
+	// Check For The Standard Library gzip / flate Errors
+	var corruptInputError flate.CorruptInputError
+	if errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) || errors.As(err, &corruptInputError) {
+		return true
+	}
+
+	// Check For The Known Errors Of The Other Codecs
+	message := err.Error()
+	for _, decompressionErrorMessage := range decompressionErrorMessages {
+		if strings.Contains(message, decompressionErrorMessage) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate The Specified DecompressionFailurePolicy & Return It (Or The Default If Unspecified)
+func ParseDecompressionFailurePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultDecompressionFailurePolicy, nil
+	case constants.DecompressionFailurePolicyRetry, constants.DecompressionFailurePolicyFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid decompression failure policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.DecompressionFailurePolicyRetry, constants.DecompressionFailurePolicyFail)
+	}
+}
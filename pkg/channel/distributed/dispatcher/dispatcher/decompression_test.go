@@ -0,0 +1,209 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The IsDecompressionError() Functionality
+func TestIsDecompressionError(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name string
+		err  error
+		want bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Nil", err: nil, want: false},
+		{name: "Corrupt Gzip Batch", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: corruptGzipError(t)}, want: true},
+		{name: "Corrupt Gzip Checksum", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: gzip.ErrChecksum}, want: true},
+		{name: "Corrupt Flate Stream", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: flate.CorruptInputError(10)}, want: true},
+		{name: "Corrupt Snappy Batch", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: errors.New("snappy: corrupt input")}, want: true},
+		{name: "Malformed Xerial Snappy Framing", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: errors.New("malformed xerial framing")}, want: true},
+		{name: "Corrupt LZ4 Batch", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: errors.New("lz4: invalid block checksum: got 1; expected 2")}, want: true},
+		{name: "Corrupt ZSTD Batch", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: errors.New("invalid input: magic number mismatch")}, want: true},
+		{name: "ZSTD CRC Mismatch", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: errors.New("CRC check failed")}, want: true},
+		{name: "Unknown Codec", err: sarama.PacketDecodingError{Info: "invalid compression specified (7)"}, want: true},
+		{name: "Unwrapped Gzip Error", err: fmt.Errorf("fetch failed: %w", gzip.ErrHeader), want: true},
+		{name: "Offset Out Of Range", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: sarama.ErrOffsetOutOfRange}, want: false},
+		{name: "Broker Unavailable", err: sarama.ErrOutOfBrokers, want: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, IsDecompressionError(testCase.err))
+		})
+	}
+}
+
+// Test The ParseDecompressionFailurePolicy() Functionality
+func TestParseDecompressionFailurePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultDecompressionFailurePolicy},
+		{name: "Retry", policy: constants.DecompressionFailurePolicyRetry, want: constants.DecompressionFailurePolicyRetry},
+		{name: "Fail", policy: constants.DecompressionFailurePolicyFail, want: constants.DecompressionFailurePolicyFail},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseDecompressionFailurePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The Dispatcher's Handling Of Decompression Failures Reported By A Subscriber's ConsumerGroup
+func TestDecompressionFailurePolicy(t *testing.T) {
+
+	// Test Data
+	subscriberSpec := eventingduck.SubscriberSpec{UID: uid123}
+	decompressionError := &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: corruptGzipError(t)}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		policy     string
+		wantPolicy string
+		wantHalted bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", wantPolicy: constants.DecompressionFailurePolicyRetry, wantHalted: false},
+		{name: "Retry", policy: constants.DecompressionFailurePolicyRetry, wantPolicy: constants.DecompressionFailurePolicyRetry, wantHalted: false},
+		{name: "Fail", policy: constants.DecompressionFailurePolicyFail, wantPolicy: constants.DecompressionFailurePolicyFail, wantHalted: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After TestCase
+			consumerGroup := kafkatesting.NewMockConsumerGroup(t)
+			newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+			kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+				return consumerGroup, nil
+			}
+			defer func() {
+				kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+			}()
+
+			// Create A New DispatcherImpl To Test With A Single Subscriber
+			statsReporter := dispatchertesting.NewMockStatsReporter()
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                     logtesting.TestLogger(t).Desugar(),
+				Topic:                      testTopic,
+				StatsReporter:              statsReporter,
+				SaramaConfig:               getSaramaConfigFromYaml(t, TestConfigBase),
+				DecompressionFailurePolicy: testCase.policy,
+			}).(*DispatcherImpl)
+			failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+			assert.Len(t, failedSubscriptions, 0)
+
+			// Perform The Test - Simulate Sarama Reporting A Corrupt Compressed Batch
+			consumerGroup.SendError(decompressionError)
+
+			if testCase.wantHalted {
+
+				// Verify The Subscriber Is Halted & Remains Failed When Next Updated
+				assert.Eventually(t, func() bool {
+					dispatcher.consumerUpdateLock.Lock()
+					defer dispatcher.consumerUpdateLock.Unlock()
+					return len(dispatcher.subscribers) == 0
+				}, 5*time.Second, 10*time.Millisecond)
+				assert.True(t, consumerGroup.Closed)
+				failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+				assert.Len(t, failedSubscriptions, 1)
+				assert.Contains(t, failedSubscriptions[subscriberSpec].Error(), gzip.ErrHeader.Error())
+				assert.Len(t, dispatcher.subscribers, 0)
+
+				// Verify The Subscriber Remains Halted By A Dispatcher Recreated Due To A Sarama Config Change
+				configMap := getBaseConfigMap()
+				configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+				dispatcher = dispatcher.ConfigChanged(configMap).(*DispatcherImpl)
+				failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+				assert.Len(t, failedSubscriptions, 1)
+				assert.Contains(t, failedSubscriptions[subscriberSpec].Error(), gzip.ErrHeader.Error())
+				assert.Len(t, dispatcher.subscribers, 0)
+
+				// Verify Removing The Subscriber Forgets The Halted State
+				failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{})
+				assert.Len(t, failedSubscriptions, 0)
+				assert.Len(t, dispatcher.haltedSubscribers, 0)
+
+			} else {
+
+				// Send A Subsequent Error To Ensure The Decompression Error Was Fully Processed
+				consumerGroup.SendError(sarama.ErrOutOfBrokers)
+
+				// Verify The Subscriber Continues Consuming
+				assert.False(t, consumerGroup.Closed)
+				assert.NotNil(t, dispatcher.subscribers[subscriberSpec.UID])
+				failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+				assert.Len(t, failedSubscriptions, 0)
+			}
+
+			// Verify The Decompression Error Was Reported With The Effective Policy
+			assert.Equal(t, map[string]int{testCase.wantPolicy: 1}, statsReporter.DecompressionErrors)
+
+			// Shutdown The Dispatcher to Cleanup Resources
+			dispatcher.Shutdown(context.TODO())
+		})
+	}
+}
+
+// Utility Function For Obtaining The Error Produced When Decompressing A Corrupt Gzip Record Batch
+func corruptGzipError(t *testing.T) error {
+	_, err := gzip.NewReader(bytes.NewReader([]byte("corrupt gzip record batch")))
+	assert.Equal(t, gzip.ErrHeader, err)
+	return err
+}
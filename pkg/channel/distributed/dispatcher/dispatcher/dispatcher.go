@@ -18,18 +18,27 @@ package dispatcher
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/apis"
 )
 
 // Define A Dispatcher Config Struct To Hold Configuration
@@ -44,6 +53,120 @@ type DispatcherConfig struct {
 	StatsReporter   metrics.StatsReporter
 	SaramaConfig    *sarama.Config
 	SubscriberSpecs []eventingduck.SubscriberSpec
+
+	// Optional File Path For Persisting Subscriptions Across Restarts (Disabled If Empty)
+	SubscriptionSnapshotPath string
+
+	// Maximum Age Of A Subscription Snapshot Which Is Restored (DefaultSubscriptionSnapshotMaxAge If Zero)
+	SubscriptionSnapshotMaxAge time.Duration
+
+	// Handling Of Messages Which Cannot Be Deserialized Into CloudEvents (One Of The constants.DeserializationFailurePolicy* Values)
+	DeserializationFailurePolicy string
+
+	// Handling Of Tombstone Records On Compacted Topics (One Of The constants.TombstonePolicy* Values)
+	TombstonePolicy string
+
+	// Handling Of Record Batches Which Cannot Be Decompressed (One Of The constants.DecompressionFailurePolicy* Values)
+	DecompressionFailurePolicy string
+
+	// Maximum Size Of A Consumed Message (Key, Value & Headers) Which Will Be Processed (Unlimited If Zero)
+	MaxMessageBytes int64
+
+	// Handling Of Messages Exceeding The MaxMessageBytes (One Of The constants.OversizedMessagePolicy* Values)
+	OversizedMessagePolicy string
+
+	// How Each Subscriber's ConsumerGroup ID Is Derived (One Of The constants.GroupIdPolicy* Values)
+	GroupIdPolicy string
+
+	// User Provided Key Included In Stable ConsumerGroup IDs (Only Used With The "stable" GroupIdPolicy)
+	GroupIdKey string
+
+	// How UIDs With Illegal ConsumerGroup ID Characters Are Handled (One Of The constants.GroupIdSanitizationPolicy* Values)
+	GroupIdSanitizationPolicy string
+
+	// Which Of Multiple SubscriberSpecs With The Same UID To Retain (One Of The constants.DuplicateSubscriberPolicy* Values)
+	DuplicateSubscriberPolicy string
+
+	// How To Handle SubscriberSpecs Without A SubscriberURI (One Of The constants.MissingSubscriberPolicy* Values)
+	MissingSubscriberPolicy string
+
+	// Whether To Omit The Receiver's Provenance Kafka Headers When Dispatching To Subscribers (Forwarded As HTTP Headers Otherwise)
+	StripProvenanceHeaders bool
+
+	// Whether Message Values Are Framed In The Schema Registry Wire Format (Stripped Before Deserializing If So)
+	SchemaRegistryFraming bool
+
+	// Number Of Delivered Messages Which Triggers A Commit With Auto-Commit Disabled (Only The Interval Applies If Zero)
+	CommitBatchSize int
+
+	// Interval Between Commits With Auto-Commit Disabled (Sarama's AutoCommit.Interval Is Used If Zero)
+	CommitBatchInterval time.Duration
+
+	// Whether To Commit The Marked Offsets When A Re-Balance Revokes The ConsumerGroup's Partitions
+	CommitOnRebalance bool
+
+	// Time To Wait For A New ConsumerGroup Session After A Missed Heartbeat Before Forcing A Rejoin (Disabled If Zero)
+	SessionLivenessTimeout time.Duration
+
+	// Maximum Time To Wait For A Subscriber's Consume Loop To Exit After Closing Its ConsumerGroup (Not Awaited If Zero)
+	ShutdownTimeout time.Duration
+
+	// Default Maximum Concurrent Deliveries Across All Subscribers When Not Specified By The KafkaChannel (Unbounded If Zero)
+	MaxDeliveryConcurrency int32
+
+	// Minimum Interval Between ConsumerGroup Lifecycle Events Of The Same Reason Recorded On The KafkaChannel (Disabled If Zero)
+	ConsumerGroupEventInterval time.Duration
+
+	// Interval Between Reports Of Each Subscriber's ConsumerGroup Lag Via The StatsReporter (Disabled If Zero)
+	ConsumerLagInterval time.Duration
+
+	// Optional Callback Invoked With The ConsumerGroup ID Whenever A Subscriber's ConsumerGroup (Re)Joins After A Re-Balance
+	OnRebalance func(groupId string)
+
+	// Optional Predicate Identifying Successful (2xx) Subscriber Responses Which Describe A Failure (Status Code Only If Nil)
+	SuccessPredicate *SuccessPredicate
+
+	// Consecutive 429 (Too Many Requests) Subscriber Responses Which Pause Consumption Of A Partition (Disabled If Zero)
+	OverloadPauseThreshold int
+
+	// Duration For Which A Partition's Consumption Is Paused When Its Subscriber Is Overloaded
+	OverloadPauseCooldown time.Duration
+
+	// Maximum Time Spent Retrying Each Message Of A Subscriber With The "progress" HeadOfLinePolicy Before Proceeding Past It
+	RetryBudget time.Duration
+
+	// Optional Sampling Of Repeated Identical Subscriber Delivery Error Logs (Every Error Is Logged If Nil)
+	ErrorLogSampling *ErrorLogSampling
+
+	// Optional Sampling Of Repeated Identical ConsumerGroup Error Logs (Every Error Is Logged If Nil)
+	GroupErrorLogSampling *ErrorLogSampling
+
+	// Interval Before Retrying A Consume() Which Failed Because The ConsumerGroup Coordinator Was Unavailable
+	CoordinatorRetryInterval time.Duration
+
+	// Interval Before Retrying A Consume() Which Failed For Any Other Reason (e.g. The Kafka Brokers Being Down)
+	ConsumeRetryInterval time.Duration
+
+	// Handling Of Replies Which Cannot Be Delivered To A Subscription's Reply Destination (One Of The constants.ReplyFailurePolicy* Values)
+	ReplyFailurePolicy string
+
+	// Number Of Retries Of An Undeliverable Reply Before The "drop" / "deadletter" ReplyFailurePolicy Is Applied
+	ReplyRetryMax int
+
+	// Interval Between Attempts To Deliver A Reply With The "drop" / "deadletter" ReplyFailurePolicy
+	ReplyRetryInterval time.Duration
+
+	// Handling Of Redirect (3xx) Responses From Subscribers (One Of The constants.RedirectPolicy* Values)
+	RedirectPolicy string
+
+	// Maximum Number Of Consecutive Redirects Followed For A Single Delivery
+	RedirectMax int
+
+	// Optional Filter Of The CloudEvent Extensions Dispatched To Subscribers (All Extensions Are Dispatched If Nil)
+	ExtensionFilter *ExtensionFilter
+
+	// Index Of This Dispatcher Among The KafkaChannel's Sharded Dispatcher Deployments (Zero If Not Sharded)
+	Shard int
 }
 
 // Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup
@@ -52,26 +175,112 @@ type SubscriberWrapper struct {
 	GroupId       string
 	ConsumerGroup sarama.ConsumerGroup
 	StopChan      chan struct{}
+	Transport     *SubscriberTransport // Optional Per-Subscription HTTP Transport (Default MessageDispatcher Used If Nil)
+	InitialOffset int64                // Initial Offset The ConsumerGroup Was Created With (Recreated If It Changes)
+	SourceGroupId string               // ConsumerGroup Whose Offsets Were Copied On Creation, If Any (Recreated If It Changes)
+	stopOnce      sync.Once            // Guards Against Closing The StopChan More Than Once (e.g. Retried ConsumerGroup Close)
+	stoppedChan   chan struct{}        // Closed When The Consume Loop Exits (Nil If Consumption Was Never Started)
+	errorsDone    chan struct{}        // Closed When Processing Of The ConsumerGroup's Errors Exits (Nil If Never Started)
+	deliveryCtx   context.Context      // Context Of The Subscriber's Deliveries (Cancelled Once It Is Stopped Or Abandoned)
+	abandon       context.CancelFunc   // Cancels Any In-Flight Deliveries (Safe To Call Multiple Times)
 }
 
 // SubscriberWrapper Constructor
 func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string, consumerGroup sarama.ConsumerGroup) *SubscriberWrapper {
-	return &SubscriberWrapper{subscriberSpec, groupId, consumerGroup, make(chan struct{})}
+	deliveryCtx, abandon := context.WithCancel(context.Background())
+	return &SubscriberWrapper{SubscriberSpec: subscriberSpec, GroupId: groupId, ConsumerGroup: consumerGroup, StopChan: make(chan struct{}), deliveryCtx: deliveryCtx, abandon: abandon}
+}
+
+// Mark The Subscriber As Stopped (Safe To Call Multiple Times)
+func (s *SubscriberWrapper) stop() {
+	s.stopOnce.Do(func() { close(s.StopChan) })
+}
+
+// Cancel The Subscriber's In-Flight Deliveries (Safe To Call Multiple Times, And On A Zero-Value SubscriberWrapper)
+func (s *SubscriberWrapper) abandonDeliveries() {
+	if s.abandon != nil {
+		s.abandon()
+	}
 }
 
+// Get The Context Of The Subscriber's Deliveries (Never Cancelled On A Zero-Value SubscriberWrapper)
+func (s *SubscriberWrapper) deliveryContext() context.Context {
+	if s.deliveryCtx == nil {
+		return context.Background()
+	}
+	return s.deliveryCtx
+}
+
+// TLS Client Configuration Loaded From A Subscriber's TLS Secret (Tracked By Version To Avoid Needless Reloads)
+type subscriberTLS struct {
+	secretVersion string
+	config        *tls.Config
+}
+
+// Nil Safe Accessor For The TLS Client Configuration
+func (s *subscriberTLS) tlsConfig() *tls.Config {
+	if s == nil {
+		return nil
+	}
+	return s.config
+}
+
+// Error Returned When The Dispatcher's Subscriptions Are Updated Before Its Sarama Config Is Loaded (Retry Later)
+var ErrDispatcherNotConfigured = errors.New("dispatcher has no sarama config")
+
 //  Dispatcher Interface
 type Dispatcher interface {
 	ConfigChanged(*v1.ConfigMap) Dispatcher
-	Shutdown()
-	UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error
+	RestoreSubscriptionSnapshot() map[eventingduck.SubscriberSpec]error
+	Shutdown(ctx context.Context)
+	UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) (map[eventingduck.SubscriberSpec]error, error)
+	UpdateSubscriberTLSSecrets(secrets []*v1.Secret) map[types.UID]error
+	UpdateMaxDeliveryConcurrency(maxDeliveryConcurrency int32) int32
+	UpdateEventRecorder(recorder record.EventRecorder, channel runtime.Object)
+	UpdateInitialOffsets(annotation string) error
+	UpdateHeadOfLinePolicies(annotation string) error
+	UpdateLegacyBridge(annotation string) error
+	UpdateShards(annotation string) error
+	UpdateDeliveryGuarantee(annotation string) error
+	OwnsSubscription(uid types.UID) bool
+	ConsumerGroupIds() []string
 }
 
 // Define A DispatcherImpl Struct With Configuration & ConsumerGroup State
 type DispatcherImpl struct {
 	DispatcherConfig
+	retainedState
 	subscribers        map[types.UID]*SubscriberWrapper
+	haltedSubscribers  map[types.UID]error // Subscribers Stopped Due To Decompression Failures (Not Restarted Until Removed)
 	consumerUpdateLock sync.Mutex
 	messageDispatcher  channel.MessageDispatcher
+	headOfLineLock     sync.RWMutex // Separate From The consumerUpdateLock As Policies Are Read By Consume Loops
+	legacyBridgeLock   sync.RWMutex // Separate From The consumerUpdateLock As The LegacyBridge Is Read By Consume Loops
+	shutdown           bool         // Set Once Shutdown, After Which Subscription Updates Are Ignored
+}
+
+// State Of The DispatcherImpl Which Is Not Derived From The DispatcherConfig & Is Retained When It Is Recreated (See ConfigChanged())
+type retainedState struct {
+	subscriberTLS         map[types.UID]*subscriberTLS
+	deliveryLimiter       *deliveryLimiter            // Shared By All Subscribers To Bound Concurrent Deliveries
+	groupEvents           *consumerGroupEventRecorder // Shared By All Subscribers To Record ConsumerGroup Lifecycle Events
+	initialOffsets        map[string]int64            // Initial Offsets Of ConsumerGroups Keyed By Subscriber UID Or URI
+	sourceGroupIds        map[string]string           // ConsumerGroups Whose Offsets Are Copied To New ConsumerGroups Keyed By Subscriber UID Or URI
+	seededSourceGroupIds  map[types.UID]string        // Source ConsumerGroups Already Copied To Each Subscriber's ConsumerGroup (Not Copied Again)
+	invalidInitialOffsets map[string]error            // Errors For Invalid Initial Offsets Keyed By Subscriber UID Or URI
+	headOfLinePolicies    map[string]string           // HeadOfLinePolicies Keyed By Subscriber UID Or URI (Guarded By headOfLineLock)
+	legacyBridge          *LegacyBridge               // Wrapping Of Non-CloudEvent Records (Guarded By legacyBridgeLock & Disabled If Nil)
+	shards                int                         // Number Of Dispatcher Shards Across Which Subscriptions Are Assigned (Not Sharded If <= 1)
+	deliveryGuarantee     string                      // The KafkaChannel's Delivery Guarantee (The Sarama Configuration Applies As-Is If Empty)
+}
+
+// Get A Copy Of The Dispatcher's Retained State (Reading The Fields Guarded By Their Own Locks Under Those Locks)
+func (d *DispatcherImpl) currentRetainedState() retainedState {
+	d.headOfLineLock.RLock()
+	defer d.headOfLineLock.RUnlock()
+	d.legacyBridgeLock.RLock()
+	defer d.legacyBridgeLock.RUnlock()
+	return d.retainedState
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -82,8 +291,14 @@ func NewDispatcher(dispatcherConfig DispatcherConfig) Dispatcher {
 
 	// Create The DispatcherImpl With Specified Configuration
 	dispatcher := &DispatcherImpl{
-		DispatcherConfig:  dispatcherConfig,
+		DispatcherConfig: dispatcherConfig,
+		retainedState: retainedState{
+			seededSourceGroupIds: make(map[types.UID]string),
+			deliveryLimiter:      newDeliveryLimiter(dispatcherConfig.MaxDeliveryConcurrency),
+			groupEvents:          newConsumerGroupEventRecorder(dispatcherConfig.ConsumerGroupEventInterval),
+		},
 		subscribers:       make(map[types.UID]*SubscriberWrapper),
+		haltedSubscribers: make(map[types.UID]error),
 		messageDispatcher: channel.NewMessageDispatcher(dispatcherConfig.Logger),
 	}
 
@@ -91,45 +306,149 @@ func NewDispatcher(dispatcherConfig DispatcherConfig) Dispatcher {
 	return dispatcher
 }
 
-// Shutdown The Dispatcher
-func (d *DispatcherImpl) Shutdown() {
+//
+// Shutdown The Dispatcher, Draining Its Subscribers Until The Specified Context's Deadline (If Any)
+//
+// The subscribers' ConsumerGroups are closed concurrently, so that a subscriber which is slow to finish its in-flight
+// message does not use up the others' share of the deadline, and each is awaited for at most the ShutdownTimeout.
+// Subscribers which have not drained by the deadline are logged and abandoned (their in-flight deliveries being
+// cancelled and their undelivered messages being redelivered from the last committed offsets) rather than the
+// Dispatcher being killed part way through committing.
+// Subsequent subscription updates are ignored so that in-flight reconciliations cannot restart any subscribers.
+//
+func (d *DispatcherImpl) Shutdown(ctx context.Context) {
 
-	// Close ConsumerGroups Of All Subscriptions
+	// Thread Safe - Subscriptions May Be Updated Or Halted Concurrently
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	d.shutdown = true
+
+	// Log The Number Of Subscribers To Drain & The Deadline (If Any)
+	remaining := len(d.subscribers)
+	logger := d.Logger.With(zap.Int("Subscribers", remaining))
+	if deadline, ok := ctx.Deadline(); ok {
+		logger = logger.With(zap.Time("Deadline", deadline))
+	}
+	logger.Info("Draining Subscribers")
+
+	// Close ConsumerGroups Of All Subscriptions Concurrently
+	type stopResult struct {
+		subscriber *SubscriberWrapper
+		removable  bool
+	}
+	resultChan := make(chan stopResult, remaining)
+	pendingSubscribers := make(map[types.UID]*SubscriberWrapper, remaining)
 	for _, subscriber := range d.subscribers {
-		d.closeConsumerGroup(subscriber)
+		pendingSubscribers[subscriber.UID] = subscriber
+		go func(subscriber *SubscriberWrapper) {
+			resultChan <- stopResult{subscriber: subscriber, removable: d.stopConsumerGroup(ctx, subscriber)}
+		}(subscriber)
 	}
+
+	// Await Each Subscriber (Logging The Drain Progress) Until All Have Drained Or The Deadline Is Reached
+	for remaining > 0 {
+		select {
+		case result := <-resultChan:
+			remaining--
+			delete(pendingSubscribers, result.subscriber.UID)
+			if result.removable {
+				delete(d.subscribers, result.subscriber.UID)
+			}
+			logger.Info("Subscriber Drained", zap.String("GroupId", result.subscriber.GroupId), zap.Int("Remaining", remaining))
+		case <-ctx.Done():
+			logger.Warn("Shutdown Deadline Reached Before All Subscribers Drained - Abandoning Them", zap.Int("Remaining", remaining))
+			for _, subscriber := range pendingSubscribers {
+				logger.Warn("Abandoning Subscriber - In-Flight Deliveries Cancelled & Undelivered Messages Left For Redelivery",
+					zap.String("GroupId", subscriber.GroupId), zap.String("URI", urlString(subscriber.SubscriberURI)))
+				subscriber.abandonDeliveries()
+			}
+			return
+		}
+	}
+	logger.Info("All Subscribers Drained")
 }
 
-// Update The Dispatcher's Subscriptions To Align With New State
-func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error {
+// Update The Dispatcher's Subscriptions To Align With New State, Returning The Subscriptions Which Failed (Or
+// ErrDispatcherNotConfigured If The Subscriptions Cannot Be Updated Until The Sarama Config Has Been Loaded)
+func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.SubscriberSpec) (map[eventingduck.SubscriberSpec]error, error) {
 
 	if d.SaramaConfig == nil {
 		d.Logger.Error("Dispatcher has no config!")
-		return nil
+		return nil, ErrDispatcherNotConfigured
 	}
 
-	// Maps For Tracking Subscriber State
+	// Maps For Tracking Subscriber State (Invalid & Duplicate SubscriberSpecs Are Discarded & Tracked As Failed)
 	activeSubscriptions := make(map[types.UID]bool)
-	failedSubscriptions := make(map[eventingduck.SubscriberSpec]error)
+	subscriberSpecs, failedSubscriptions := d.removeInvalidSubscriberSpecs(subscriberSpecs)
+	subscriberSpecs, duplicateSubscriptions := d.removeDuplicateSubscriberSpecs(subscriberSpecs)
+	for subscriberSpec, err := range duplicateSubscriptions {
+		failedSubscriptions[subscriberSpec] = err
+	}
+	subscriberSpecs, groupIds, conflictingSubscriptions := d.assignGroupIds(subscriberSpecs)
+	for subscriberSpec, err := range conflictingSubscriptions {
+		failedSubscriptions[subscriberSpec] = err
+	}
 
 	// Thread Safe ;)
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
 
+	// Subscribers Are Not (Re)Started Once The Dispatcher Has Been Shutdown
+	if d.shutdown {
+		d.Logger.Warn("Dispatcher Has Been Shutdown - Ignoring Subscription Update")
+		return failedSubscriptions, nil
+	}
+
 	// Loop Over All All The Specified Subscribers
 	for _, subscriberSpec := range subscriberSpecs {
 
-		// If The Subscriber Wrapper For The SubscriberSpec Does Not Exist Then Create One
-		if _, ok := d.subscribers[subscriberSpec.UID]; !ok {
+		// Subscribers Assigned To Another Dispatcher Shard Are Not Consumed Here (Closed Below If Previously Assigned Here)
+		if !d.ownsSubscription(subscriberSpec.UID) {
+			continue
+		}
+
+		// Subscribers Halted Due To Decompression Failures Remain Failed (Rather Than Endlessly Re-Consuming The Corrupt Batch)
+		if haltErr, ok := d.haltedSubscribers[subscriberSpec.UID]; ok {
+			failedSubscriptions[subscriberSpec] = haltErr
+			activeSubscriptions[subscriberSpec.UID] = true
+			continue
+		}
 
-			// Format The GroupId For The Specified Subscriber
-			groupId := fmt.Sprintf("kafka.%s", subscriberSpec.UID)
+		// Get The GroupId For The Specified Subscriber & Create A ConsumerGroup Logger
+		groupId := groupIds[subscriberSpec.UID]
+		logger := d.Logger.With(zap.String("GroupId", groupId))
 
-			// Create A ConsumerGroup Logger
-			logger := d.Logger.With(zap.String("GroupId", groupId))
+		// Subscribers With An Invalid Initial Offset Are Failed (Leaving Any Existing ConsumerGroup As-Is)
+		initialOffset, sourceGroupId, err := d.subscriberInitialOffset(subscriberSpec)
+		if err != nil {
+			logger.Error("Invalid Subscriber Initial Offset", zap.Error(err))
+			failedSubscriptions[subscriberSpec] = err
+			if _, ok := d.subscribers[subscriberSpec.UID]; ok {
+				activeSubscriptions[subscriberSpec.UID] = true
+			}
+			continue
+		}
 
-			// Attempt To Create A Kafka ConsumerGroup
-			consumerGroup, _, err := consumer.CreateConsumerGroup(d.Brokers, d.SaramaConfig, groupId)
+		// Close The ConsumerGroup Of An Existing Subscriber Whose Initial Offset (Or Source ConsumerGroup) Has Changed So That It Is Recreated Below
+		if subscriber, ok := d.subscribers[subscriberSpec.UID]; ok && (subscriber.InitialOffset != initialOffset || subscriber.SourceGroupId != sourceGroupId) {
+			logger.Info("Recreating ConsumerGroup With Changed Initial Offset",
+				zap.Int64("OldInitialOffset", subscriber.InitialOffset), zap.Int64("InitialOffset", initialOffset),
+				zap.String("OldSourceGroupId", subscriber.SourceGroupId), zap.String("SourceGroupId", sourceGroupId))
+			d.closeConsumerGroup(subscriber)
+		}
+
+		// If The Subscriber Wrapper For The SubscriberSpec Does Not Exist Then Create One
+		if _, ok := d.subscribers[subscriberSpec.UID]; !ok {
+
+			// Attempt To Create A Kafka ConsumerGroup (Once Any Offsets Of A Source ConsumerGroup Not Already Copied Have Been)
+			var consumerGroup sarama.ConsumerGroup
+			var err error
+			if d.seededSourceGroupIds[subscriberSpec.UID] != sourceGroupId {
+				err = d.seedConsumerGroupOffsets(logger, groupId, sourceGroupId)
+			}
+			if err == nil {
+				consumerGroup, _, err = consumer.CreateConsumerGroup(d.Brokers, d.consumerGroupConfig(initialOffset), groupId)
+			}
 			if err != nil {
 
 				// Log & Return Failure
@@ -138,8 +457,15 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 
 			} else {
 
-				// Create A New SubscriberWrapper With The ConsumerGroup
+				// Create A New SubscriberWrapper With The ConsumerGroup & A Transport Using Any TLS Client Configuration
 				subscriber := NewSubscriberWrapper(subscriberSpec, groupId, consumerGroup)
+				subscriber.Transport = NewSubscriberTransport(d.subscriberTLS[subscriberSpec.UID].tlsConfig())
+				subscriber.InitialOffset = initialOffset
+				subscriber.SourceGroupId = sourceGroupId
+				if d.seededSourceGroupIds == nil {
+					d.seededSourceGroupIds = make(map[types.UID]string)
+				}
+				d.seededSourceGroupIds[subscriberSpec.UID] = sourceGroupId
 
 				// Should start observing metrics from Sarama Config.MetricsRegistry from CreateConsumerGroup() above ; )
 
@@ -171,10 +497,259 @@ func (d *DispatcherImpl) UpdateSubscriptions(subscriberSpecs []eventingduck.Subs
 		}
 	}
 
+	// Forget Halted Subscribers Which Have Been Removed
+	for uid := range d.haltedSubscribers {
+		if !activeSubscriptions[uid] {
+			delete(d.haltedSubscribers, uid)
+		}
+	}
+
+	// Forget The Seeded Source ConsumerGroups Of Subscribers Which Have Been Removed (Seeded Again If Re-Added)
+	for uid := range d.seededSourceGroupIds {
+		if _, ok := d.subscribers[uid]; !ok {
+			delete(d.seededSourceGroupIds, uid)
+		}
+	}
+
+	// Persist The Active Subscriptions For Use In Warm Restarts (If Enabled)
+	d.saveSubscriptionSnapshot()
+
 	// Return Any Failed Subscriber Errors
+	return failedSubscriptions, nil
+}
+
+//
+// Update The TLS Client Configuration Used When Delivering To Subscribers From The Specified Subscriber TLS Secrets
+//
+// Each Secret is associated with a subscriber via the constants.SubscriberTLSSecretLabel, and the resulting TLS
+// configuration is applied to the subscriber's transport immediately (for active subscribers) or upon creation (for
+// subscribers which are added later).  Subscribers whose Secret has been removed revert to the default configuration.
+// Secrets which cannot be loaded are returned as failures keyed by subscriber UID, in which case any previously
+// loaded configuration for that subscriber is retained.
+//
+func (d *DispatcherImpl) UpdateSubscriberTLSSecrets(secrets []*v1.Secret) map[types.UID]error {
+
+	// Thread Safe ;)
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Load The TLS Client Configuration For Each Subscriber
+	failedSubscriberTLS := make(map[types.UID]error)
+	newSubscriberTLS := make(map[types.UID]*subscriberTLS)
+	for _, secret := range secrets {
+
+		// Create A Secret Logger
+		uid := types.UID(secret.Labels[constants.SubscriberTLSSecretLabel])
+		logger := d.Logger.With(zap.String("UID", string(uid)), zap.String("Secret", secret.Namespace+"/"+secret.Name))
+
+		// Multiple Secrets For A Single Subscriber Are Ambiguous
+		if _, ok := newSubscriberTLS[uid]; ok {
+			logger.Error("Multiple Subscriber TLS Secrets Found - Ignoring")
+			failedSubscriberTLS[uid] = fmt.Errorf("multiple subscriber tls secrets found for subscriber UID '%s'", uid)
+			continue
+		}
+
+		// Reuse The Existing Configuration If The Secret Has Not Changed
+		secretVersion := secret.Namespace + "/" + secret.Name + "/" + secret.ResourceVersion
+		currentSubscriberTLS := d.subscriberTLS[uid]
+		if currentSubscriberTLS != nil && currentSubscriberTLS.secretVersion == secretVersion {
+			newSubscriberTLS[uid] = currentSubscriberTLS
+			continue
+		}
+
+		// Otherwise Load The TLS Client Configuration From The Secret (Retaining Any Prior Configuration On Failure)
+		tlsConfig, err := NewSubscriberTLSConfig(secret)
+		if err != nil {
+			logger.Error("Failed To Load Subscriber TLS Secret", zap.Error(err))
+			failedSubscriberTLS[uid] = err
+			if currentSubscriberTLS != nil {
+				newSubscriberTLS[uid] = currentSubscriberTLS
+			}
+			continue
+		}
+		newSubscriberTLS[uid] = &subscriberTLS{secretVersion: secretVersion, config: tlsConfig}
+	}
+
+	// Reload The Transports Of Any Active Subscribers Whose TLS Client Configuration Changed
+	for uid, subscriber := range d.subscribers {
+		if subscriber.Transport != nil && newSubscriberTLS[uid] != d.subscriberTLS[uid] {
+			d.Logger.Info("Reloading Subscriber TLS Client Configuration", zap.String("UID", string(uid)))
+			subscriber.Transport.Update(newSubscriberTLS[uid].tlsConfig())
+		}
+	}
+
+	// Track The New TLS Client Configuration For Subsequent Subscribers & Return Any Failures
+	d.subscriberTLS = newSubscriberTLS
+	return failedSubscriberTLS
+}
+
+//
+// Update The Maximum Number Of Concurrent Deliveries Across All Subscribers & Return The Effective Value
+//
+// The KafkaChannel's MaxDeliveryConcurrency takes precedence when specified (positive), with the dispatcher's
+// configured default applying otherwise.  The new limit is applied immediately to the deliveries of all current
+// and future subscribers, and the effective value (zero meaning unbounded) is returned for reporting in status.
+//
+func (d *DispatcherImpl) UpdateMaxDeliveryConcurrency(maxDeliveryConcurrency int32) int32 {
+	effectiveMaxDeliveryConcurrency := d.MaxDeliveryConcurrency
+	if maxDeliveryConcurrency > 0 {
+		effectiveMaxDeliveryConcurrency = maxDeliveryConcurrency
+	}
+	if d.deliveryLimiter.getLimit() != effectiveMaxDeliveryConcurrency {
+		d.Logger.Info("Updating Maximum Delivery Concurrency", zap.Int32("MaxDeliveryConcurrency", effectiveMaxDeliveryConcurrency))
+		d.deliveryLimiter.setLimit(effectiveMaxDeliveryConcurrency)
+	}
+	return effectiveMaxDeliveryConcurrency
+}
+
+// Update The EventRecorder & KafkaChannel On Which ConsumerGroup Lifecycle Events Are Recorded (If Enabled)
+func (d *DispatcherImpl) UpdateEventRecorder(recorder record.EventRecorder, channel runtime.Object) {
+	d.groupEvents.update(recorder, channel)
+}
+
+//
+// Remove Any SubscriberSpecs Without A SubscriberURI (If Rejected), Returning The Remaining SubscriberSpecs & A Failure For Each Removed
+//
+// A SubscriberSpec without a SubscriberURI (or with an empty one) is delivered directly to its ReplyURI (if any) by
+// default.  The "reject" MissingSubscriberPolicy instead returns such SubscriberSpecs as failures, without creating a
+// ConsumerGroup for them, so that the reconciler can flag them in the KafkaChannel's SubscribableStatus.  Any
+// ConsumerGroup previously created for them is closed as for a removed subscription.
+//
+func (d *DispatcherImpl) removeInvalidSubscriberSpecs(subscriberSpecs []eventingduck.SubscriberSpec) ([]eventingduck.SubscriberSpec, map[eventingduck.SubscriberSpec]error) {
+
+	validSubscriberSpecs := make([]eventingduck.SubscriberSpec, 0, len(subscriberSpecs))
+	invalidSubscriberSpecs := make(map[eventingduck.SubscriberSpec]error)
+
+	for _, subscriberSpec := range subscriberSpecs {
+		if d.MissingSubscriberPolicy == constants.MissingSubscriberPolicyReject && subscriberSpec.SubscriberURI.IsEmpty() {
+			d.Logger.Warn("Discarding SubscriberSpec Without A SubscriberURI", zap.String("UID", string(subscriberSpec.UID)))
+			invalidSubscriberSpecs[subscriberSpec] = fmt.Errorf("subscriber '%s' has no SubscriberURI", subscriberSpec.UID)
+			continue
+		}
+		validSubscriberSpecs = append(validSubscriberSpecs, subscriberSpec)
+	}
+
+	return validSubscriberSpecs, invalidSubscriberSpecs
+}
+
+//
+// Remove Any SubscriberSpecs Which Share A UID With Another, Returning The Remaining SubscriberSpecs & A Failure For Each Duplicate
+//
+// Multiple SubscriberSpecs with the same UID represent malformed input which would otherwise result in one of
+// them silently replacing the other.  The DuplicateSubscriberPolicy determines whether the first or last such
+// SubscriberSpec is retained, and the discarded SubscriberSpecs are returned as failures so that the reconciler
+// can flag the misconfiguration in the KafkaChannel's SubscribableStatus.
+//
+func (d *DispatcherImpl) removeDuplicateSubscriberSpecs(subscriberSpecs []eventingduck.SubscriberSpec) ([]eventingduck.SubscriberSpec, map[eventingduck.SubscriberSpec]error) {
+
+	uniqueSubscriberSpecs := make([]eventingduck.SubscriberSpec, 0, len(subscriberSpecs))
+	uniqueIndices := make(map[types.UID]int)
+	duplicateSubscriberSpecs := make(map[eventingduck.SubscriberSpec]error)
+
+	for _, subscriberSpec := range subscriberSpecs {
+
+		// Track The First Occurrence Of Each UID
+		index, ok := uniqueIndices[subscriberSpec.UID]
+		if !ok {
+			uniqueIndices[subscriberSpec.UID] = len(uniqueSubscriberSpecs)
+			uniqueSubscriberSpecs = append(uniqueSubscriberSpecs, subscriberSpec)
+			continue
+		}
+
+		// Determine Which Of The Duplicate SubscriberSpecs To Discard Based On The Policy
+		discardedSubscriberSpec := subscriberSpec
+		if d.DuplicateSubscriberPolicy == constants.DuplicateSubscriberPolicyLast {
+			discardedSubscriberSpec = uniqueSubscriberSpecs[index]
+			uniqueSubscriberSpecs[index] = subscriberSpec
+		}
+
+		// Log & Track The Discarded SubscriberSpec As Failed
+		d.Logger.Warn("Discarding Duplicate SubscriberSpec", zap.String("UID", string(subscriberSpec.UID)), zap.String("Policy", d.DuplicateSubscriberPolicy))
+		duplicateSubscriberSpecs[discardedSubscriberSpec] = fmt.Errorf("duplicate subscriber UID '%s'", subscriberSpec.UID)
+	}
+
+	return uniqueSubscriberSpecs, duplicateSubscriberSpecs
+}
+
+// Validate The Specified DuplicateSubscriberPolicy & Return It (Or The Default If Unspecified)
+func ParseDuplicateSubscriberPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultDuplicateSubscriberPolicy, nil
+	case constants.DuplicateSubscriberPolicyFirst, constants.DuplicateSubscriberPolicyLast:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid duplicate subscriber policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.DuplicateSubscriberPolicyFirst, constants.DuplicateSubscriberPolicyLast)
+	}
+}
+
+// Validate The Specified MissingSubscriberPolicy & Return It (Or The Default If Unspecified)
+func ParseMissingSubscriberPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultMissingSubscriberPolicy, nil
+	case constants.MissingSubscriberPolicyAllow, constants.MissingSubscriberPolicyReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid missing subscriber policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.MissingSubscriberPolicyAllow, constants.MissingSubscriberPolicyReject)
+	}
+}
+
+//
+// Optimistically Start Consuming The Subscriptions From Any Persisted Snapshot (Warm Restart)
+//
+// This is intended to be called once at startup, prior to the KafkaChannel being reconciled, so that
+// message delivery can resume without waiting on the reconciler.  Once the authoritative list of
+// subscriptions arrives via UpdateSubscriptions() any differences (e.g. subscriptions which were removed
+// while the Dispatcher was down) will be reconciled by closing the corresponding ConsumerGroups.
+//
+func (d *DispatcherImpl) RestoreSubscriptionSnapshot() map[eventingduck.SubscriberSpec]error {
+
+	// Nothing To Do If Snapshots Are Not Enabled
+	if len(d.SubscriptionSnapshotPath) <= 0 {
+		return nil
+	}
+
+	// Create A Snapshot Logger
+	maxAge := d.SubscriptionSnapshotMaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultSubscriptionSnapshotMaxAge
+	}
+	logger := d.Logger.With(zap.String("Path", d.SubscriptionSnapshotPath), zap.Duration("MaxAge", maxAge))
+
+	// Load The SubscriberSpecs From The Snapshot (Stale Or Missing Snapshots Return No SubscriberSpecs)
+	subscriberSpecs, err := LoadSubscriptionSnapshot(d.SubscriptionSnapshotPath, d.ChannelKey, maxAge)
+	if err != nil {
+		logger.Warn("Failed To Load Subscription Snapshot - Skipping Warm Restart", zap.Error(err))
+		return nil
+	} else if len(subscriberSpecs) <= 0 {
+		logger.Info("No Valid Subscription Snapshot Found - Skipping Warm Restart")
+		return nil
+	}
+
+	// Start Consuming The Snapshot's Subscriptions
+	logger.Info("Warm Restarting Subscriptions From Snapshot", zap.Int("Count", len(subscriberSpecs)))
+	failedSubscriptions, err := d.UpdateSubscriptions(subscriberSpecs)
+	if err != nil {
+		logger.Warn("Failed To Update Subscriptions - Skipping Warm Restart", zap.Error(err))
+		return nil
+	}
 	return failedSubscriptions
 }
 
+// Persist The Dispatcher's Active SubscriberSpecs To The Snapshot File (If Enabled)
+func (d *DispatcherImpl) saveSubscriptionSnapshot() {
+	if len(d.SubscriptionSnapshotPath) > 0 {
+		err := SaveSubscriptionSnapshot(d.SubscriptionSnapshotPath, d.ChannelKey, d.SubscriberSpecs)
+		if err != nil {
+			// Simply Log Snapshot Failures - They Only Impact Warm Restart Performance
+			d.Logger.Warn("Failed To Save Subscription Snapshot", zap.String("Path", d.SubscriptionSnapshotPath), zap.Error(err))
+		}
+	}
+}
+
 // Start Consuming Messages With The Specified Subscriber's ConsumerGroup
 func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 
@@ -184,23 +759,95 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 		// Setup The ConsumerGroup Level Logger
 		logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId))
 
-		// Asynchronously Process ConsumerGroup's Error Channel
+		// Create A Monitor To Force A Rejoin If The Session Is Lost (e.g. Evicted By The Coordinator During A Network Partition)
+		monitor := newSessionMonitor(logger, d.SessionLivenessTimeout)
+
+		// Asynchronously Process ConsumerGroup's Error Channel (Sampling The Logs So As To Keep Up With Bursts)
+		groupErrors := newGroupErrorLog(logger, d.GroupErrorLogSampling, d.Topic, d.StatsReporter)
+		errorsDone := make(chan struct{})
+		subscriber.errorsDone = errorsDone
 		go func() {
+			defer close(errorsDone)
 			logger.Info("ConsumerGroup Error Processing Initiated")
 			for err := range subscriber.ConsumerGroup.Errors() { // Closing ConsumerGroup Will Break Out Of This
-				logger.Error("ConsumerGroup Error", zap.Error(err))
+				if IsDecompressionError(err) {
+					d.handleDecompressionError(logger, subscriber, err)
+				} else {
+					groupErrors.log(err)
+					if IsSessionError(err) {
+						monitor.heartbeatMissed(err)
+					}
+				}
 			}
+			groupErrors.flush()
+			monitor.stop()
 			logger.Info("ConsumerGroup Error Processing Terminated")
 		}()
 
+		// Periodically Report The ConsumerGroup's Lag (If Enabled)
+		d.startConsumerLagPolling(logger, subscriber)
+
 		// Create A New ConsumerGroupHandler To Consume Messages With
-		handler := NewHandler(logger, &subscriber.SubscriberSpec)
+		handler := NewHandler(logger, &subscriber.SubscriberSpec, d.DeserializationFailurePolicy, d.TombstonePolicy, d.StripProvenanceHeaders, d.StatsReporter)
+		handler.sessionMonitor = monitor
+		handler.groupId = subscriber.GroupId
+		handler.groupEvents = d.groupEvents
+		handler.onRebalance = d.OnRebalance
+		handler.SchemaRegistryFraming = d.SchemaRegistryFraming
+		handler.MaxMessageBytes = d.MaxMessageBytes
+		handler.OversizedMessagePolicy = d.OversizedMessagePolicy
+		handler.ExtensionFilter = d.ExtensionFilter
+		handler.OverloadPauseThreshold = d.OverloadPauseThreshold
+		handler.OverloadPauseCooldown = d.OverloadPauseCooldown
+		handler.RetryBudget = d.RetryBudget
+		handler.RedeliveryInterval = d.ConsumeRetryInterval
+		handler.headOfLinePolicy = d.headOfLinePolicyFunc(subscriber.SubscriberSpec)
+		handler.legacyBridge = d.currentLegacyBridge
+		handler.deliveryCtx = subscriber.deliveryContext()
+		if d.ErrorLogSampling != nil {
+			handler.deliveryErrors = newDeliveryErrorLog(logger, d.ErrorLogSampling, subscriber.SubscriberURI.URL())
+		}
+		if subscriber.Transport != nil {
+			var transport http.RoundTripper = subscriber.Transport
+			if d.SuccessPredicate != nil {
+				transport = newSuccessPredicateTransport(logger, transport, d.SuccessPredicate, subscriber.SubscriberURI)
+			}
+			if isReplyFailureHandled(d.ReplyFailurePolicy) && !subscriber.ReplyURI.IsEmpty() {
+				var deadLetterURI *apis.URL
+				if subscriber.Delivery != nil && subscriber.Delivery.DeadLetterSink != nil && !subscriber.Delivery.DeadLetterSink.URI.IsEmpty() {
+					deadLetterURI = subscriber.Delivery.DeadLetterSink.URI
+				}
+				transport = newReplyFailureTransport(logger, transport, d.ReplyFailurePolicy, subscriber.ReplyURI, deadLetterURI, d.ReplyRetryMax, d.ReplyRetryInterval, d.Topic, d.StatsReporter)
+			}
+			handler.MessageDispatcher = newSubscriberMessageDispatcherWrapper(logger, transport, newCheckRedirect(logger, d.RedirectPolicy, d.RedirectMax, subscriber.SubscriberURI))
+		}
+		handler.MessageDispatcher = newLimitedMessageDispatcher(handler.MessageDispatcher, d.deliveryLimiter)
+
+		// Take Over Offset Marking & Committing If Sarama's Auto-Commit Has Been Disabled (Or As The Delivery Guarantee Requires)
+		commitMode := d.commitMode()
+		if commitMode == delivery.CommitModeBeforeDelivery {
+			handler.CommitBeforeDelivery = true
+		} else if commitMode == delivery.CommitModeAfterDelivery || !d.SaramaConfig.Consumer.Offsets.AutoCommit.Enable {
+			handler.ManualCommit = true
+			handler.ManualCommitInterval = d.SaramaConfig.Consumer.Offsets.AutoCommit.Interval
+			if d.CommitBatchInterval > 0 {
+				handler.ManualCommitInterval = d.CommitBatchInterval
+			}
+			handler.ManualCommitBatchSize = d.CommitBatchSize
+		}
+		handler.CommitOnRebalance = d.CommitOnRebalance
 
-		// Consume Messages Asynchronously
+		// Consume Messages Asynchronously (Signalling The Loop's Exit So That Closing The ConsumerGroup May Await It)
+		stoppedChan := make(chan struct{})
+		subscriber.stoppedChan = stoppedChan
 		go func() {
+			defer close(stoppedChan)
+			defer func() {
+				d.groupEvents.event(subscriber.GroupId, constants.ConsumerGroupLeftEventReason, "ConsumerGroup %s left", subscriber.GroupId)
+				d.groupEvents.forget(subscriber.GroupId)
+			}()
 
-			// Infinite Loop To Support Server-Side ConsumerGroup Re-Balance Which Ends Consume() Execution
-			ctx := context.Background()
+			// Infinite Loop To Support Server-Side ConsumerGroup Re-Balance (Or A Forced Rejoin) Which Ends Consume() Execution
 			for {
 				select {
 
@@ -212,13 +859,14 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 				// Start ConsumerGroup Consumption
 				default:
 					logger.Info("ConsumerGroup Message Consumption Initiated")
-					err := subscriber.ConsumerGroup.Consume(ctx, []string{d.Topic}, handler)
+					err := subscriber.ConsumerGroup.Consume(monitor.consumeContext(), []string{d.Topic}, handler)
 					if err != nil {
 						if err == sarama.ErrClosedConsumerGroup {
-							logger.Info("ConsumerGroup Closed Error - Ceasing Consumption") // Should be caught above but here as added precaution.
-							break
-						} else {
-							logger.Error("ConsumerGroup Failed To Consume Messages", zap.Error(err))
+							logger.Info("ConsumerGroup Closed Error - Ceasing Consumption") // Closed Between StopChan Check & Consume() (e.g. Mid Re-Balance)
+							return
+						} else if !d.awaitConsumeRetry(logger, subscriber, err) {
+							logger.Info("ConsumerGroup Closed Awaiting Consume Retry - Ceasing Consumption")
+							return
 						}
 					}
 				}
@@ -227,36 +875,135 @@ func (d *DispatcherImpl) startConsuming(subscriber *SubscriberWrapper) {
 	}
 }
 
+//
+// Handle A Record Batch Which Could Not Be Decompressed According To The DecompressionFailurePolicy
+//
+// Sarama reports such errors asynchronously and then re-fetches the same batch, which will never succeed if the
+// data is corrupt.  The "retry" policy preserves that behavior (as the corruption may be transient, e.g. a broker
+// serving a bad replica), whereas the "fail" policy closes the subscriber's ConsumerGroup and reports the subscriber
+// as failed via UpdateSubscriptions() until it is removed or the Dispatcher is restarted.  Either way the error is
+// counted so that producer / broker corruption can be detected.
+//
+func (d *DispatcherImpl) handleDecompressionError(logger *zap.Logger, subscriber *SubscriberWrapper, err error) {
+
+	// Include The Partition If Known
+	var consumerError *sarama.ConsumerError
+	if errors.As(err, &consumerError) {
+		logger = logger.With(zap.Int32("Partition", consumerError.Partition))
+	}
+
+	// Determine The Effective Policy & Record The Decompression Error
+	policy := d.DecompressionFailurePolicy
+	if policy != constants.DecompressionFailurePolicyFail {
+		policy = constants.DecompressionFailurePolicyRetry
+	}
+	if d.StatsReporter != nil {
+		d.StatsReporter.ReportDecompressionError(d.Topic, policy)
+	}
+
+	// Retry Policy Simply Leaves Sarama To Re-Fetch The Batch
+	if policy == constants.DecompressionFailurePolicyRetry {
+		logger.Error("ConsumerGroup Failed To Decompress Record Batch - Retrying", zap.Error(err))
+		return
+	}
+
+	// Otherwise Stop The Subscriber (Unless It Has Already Been Replaced Or Removed)
+	logger.Error("ConsumerGroup Failed To Decompress Record Batch - Halting Subscriber", zap.Error(err))
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	if d.subscribers[subscriber.UID] == subscriber {
+		d.haltedSubscribers[subscriber.UID] = fmt.Errorf("failed to decompress record batch from topic '%s': %w", d.Topic, err)
+		d.closeConsumerGroup(subscriber)
+		delete(d.subscribers, subscriber.UID) // Close May Return Undrained Errors - The Subscriber Is Halted Regardless
+	}
+}
+
 // Close The ConsumerGroup Associated With A Single Subscriber
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
+	if d.stopConsumerGroup(context.Background(), subscriber) {
+		delete(d.subscribers, subscriber.UID)
+	}
+}
+
+// Stop The Specified Subscriber's ConsumerGroup, Returning Whether It May Be Removed (False If Its Close Should Be Retried)
+func (d *DispatcherImpl) stopConsumerGroup(ctx context.Context, subscriber *SubscriberWrapper) bool {
 
 	// Get The ConsumerGroup Associated with The Specified Subscriber
 	consumerGroup := subscriber.ConsumerGroup
 
 	// Create Logger With GroupId & Subscriber URI
-	logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId), zap.String("URI", subscriber.SubscriberURI.String()))
+	logger := d.Logger.With(zap.String("GroupId", subscriber.GroupId), zap.String("URI", urlString(subscriber.SubscriberURI)))
 
 	// If The ConsumerGroup Is Valid
 	if consumerGroup != nil {
 
 		// Mark The Subscriber's ConsumerGroup As Stopped
-		close(subscriber.StopChan)
+		subscriber.stop()
 
 		// Close The ConsumerGroup
+		removable := true
 		err := consumerGroup.Close()
 		if err != nil {
 			// Simply Log ConsumerGroup Close Failures
 			//   - Don't include in failedSubscriptions response as that is used to update Subscription Status.
 			//   - Don't delete from ConsumerGroups Map to force retry of Close next time around.
 			logger.Error("Failed To Close ConsumerGroup", zap.Error(err))
+			removable = false
 		} else {
 			logger.Info("Successfully Closed ConsumerGroup")
-			delete(d.subscribers, subscriber.UID)
 		}
-	} else {
-		logger.Warn("Successfully Closed Subscriber With Nil ConsumerGroup")
-		delete(d.subscribers, subscriber.UID)
+
+		// Wait For The Consume Loop To Exit So That It Does Not Outlive The ConsumerGroup, Then Cancel Any Delivery Still In-Flight
+		d.awaitConsumptionStopped(ctx, logger, subscriber)
+		subscriber.abandonDeliveries()
+		return removable
 	}
+	logger.Warn("Successfully Closed Subscriber With Nil ConsumerGroup")
+	return true
+}
+
+//
+// Wait (Up To The ShutdownTimeout Or The Context's Deadline) For The Specified Subscriber's Consume Loop & Error
+// Processing To Exit
+//
+// The loop may be mid re-balance (between Consume() calls) when the ConsumerGroup is closed, in which case it
+// observes the StopChan or the ErrClosedConsumerGroup from its next Consume() and exits, whereas the error processing
+// exits once the closed ConsumerGroup's Errors() channel has been drained.  Either which has not exited within the
+// timeout (e.g. a Subscriber still processing a message) is logged and left to finish on its own.
+//
+func (d *DispatcherImpl) awaitConsumptionStopped(ctx context.Context, logger *zap.Logger, subscriber *SubscriberWrapper) {
+	if d.ShutdownTimeout <= 0 {
+		return
+	}
+	timer := time.NewTimer(d.ShutdownTimeout)
+	defer timer.Stop()
+	for _, doneChan := range []chan struct{}{subscriber.stoppedChan, subscriber.errorsDone} {
+		if doneChan == nil {
+			continue
+		}
+		select {
+		case <-doneChan:
+		case <-timer.C:
+			logger.Warn("ConsumerGroup Consumption Did Not Stop Within Shutdown Timeout", zap.Duration("ShutdownTimeout", d.ShutdownTimeout))
+			return
+		case <-ctx.Done():
+			logger.Warn("ConsumerGroup Consumption Did Not Stop Before Shutdown Deadline")
+			return
+		}
+	}
+	logger.Debug("ConsumerGroup Consumption Stopped")
+}
+
+//
+// Get The Sections Of The Specified Sarama Config Whose Changes Do Not Require Recreating The Dispatcher
+//
+// Only the ConsumerGroups' settings affect a running Dispatcher.  The Producer section is unused by it, and changes
+// to the Metadata (e.g. RefreshFrequency) & Admin sections do not warrant the ConsumerGroup re-balances which
+// recreating the Dispatcher causes across every replica.  Changes to these sections alone are therefore ignored, and
+// only take effect once the Dispatcher is next recreated (or restarted).
+//
+func ignoredConfigSections(config *sarama.Config) []interface{} {
+	return []interface{}{config.Producer, config.Metadata, config.Admin}
 }
 
 // ConfigChanged is called by the configMapObserver handler function in main() so that
@@ -284,8 +1031,15 @@ func (d *DispatcherImpl) ConfigChanged(configMap *v1.ConfigMap) Dispatcher {
 		// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
 		kafkasarama.UpdateSaramaConfig(newConfig, d.SaramaConfig.ClientID, d.SaramaConfig.Net.SASL.User, d.SaramaConfig.Net.SASL.Password)
 
-		// Ignore the "Producer" section as changes to that do not require recreating the Dispatcher
-		if kafkasarama.ConfigEqual(newConfig, d.SaramaConfig, newConfig.Producer) {
+		// The client rack is determined at startup (possibly from the Node's labels) so it is also retained
+		newConfig.RackID = d.SaramaConfig.RackID
+
+		// The SASL mechanism is determined at startup (possibly from the Kafka Secret) so it is also retained
+		newConfig.Net.SASL.Mechanism = d.SaramaConfig.Net.SASL.Mechanism
+		newConfig.Net.SASL.SCRAMClientGeneratorFunc = d.SaramaConfig.Net.SASL.SCRAMClientGeneratorFunc
+
+		// Ignore the sections whose changes do not require recreating the Dispatcher (see ignoredConfigSections())
+		if kafkasarama.ConfigEqual(newConfig, d.SaramaConfig, ignoredConfigSections(newConfig)...) {
 			d.Logger.Info("No Consumer Changes Detected In New Configuration - Ignoring")
 			return nil
 		}
@@ -293,13 +1047,42 @@ func (d *DispatcherImpl) ConfigChanged(configMap *v1.ConfigMap) Dispatcher {
 
 	// Create A New Dispatcher With The New Configuration (Reusing All Other Existing Config)
 	d.Logger.Info("Consumer Changes Detected In New Configuration - Recreating Dispatcher")
-	d.Shutdown()
+	d.Shutdown(context.Background())
 	d.DispatcherConfig.SaramaConfig = newConfig
 	newDispatcher := NewDispatcher(d.DispatcherConfig)
-	failedSubscriptions := newDispatcher.UpdateSubscriptions(d.SubscriberSpecs)
+	newDispatcher.(*DispatcherImpl).retainedState = d.currentRetainedState()
+	failedSubscriptions, err := newDispatcher.UpdateSubscriptions(d.SubscriberSpecs)
+	if err != nil {
+		d.Logger.Fatal("Failed To Update Subscriptions For New Dispatcher", zap.Error(err))
+		return nil
+	}
+
+	// Subscribers With An Invalid Initial Offset Remain Failed Until The Annotation Is Corrected (Only Failures To
+	// Create The ConsumerGroups Are Fatal)
+	for subscriberSpec, failure := range failedSubscriptions {
+		if _, _, initialOffsetErr := newDispatcher.(*DispatcherImpl).subscriberInitialOffset(subscriberSpec); initialOffsetErr != nil {
+			d.Logger.Warn("Subscriber With Invalid Initial Offset Not Subscribed By New Dispatcher", zap.String("UID", string(subscriberSpec.UID)), zap.Error(failure))
+			delete(failedSubscriptions, subscriberSpec)
+		}
+	}
 	if len(failedSubscriptions) > 0 {
 		d.Logger.Fatal("Failed To Subscribe Kafka Subscriptions For New Dispatcher", zap.Int("Count", len(failedSubscriptions)))
 		return nil
 	}
+
+	// Retain The Halted Subscribers So They Remain Failed (Added After The Update Above, Which Would Otherwise Forget
+	// Them As They Are Not Among The Active SubscriberSpecs Being Replayed)
+	d.consumerUpdateLock.Lock()
+	haltedSubscribers := make(map[types.UID]error, len(d.haltedSubscribers))
+	for uid, haltErr := range d.haltedSubscribers {
+		haltedSubscribers[uid] = haltErr
+	}
+	d.consumerUpdateLock.Unlock()
+	newDispatcher.(*DispatcherImpl).consumerUpdateLock.Lock()
+	for uid, haltErr := range haltedSubscribers {
+		newDispatcher.(*DispatcherImpl).haltedSubscribers[uid] = haltErr
+	}
+	newDispatcher.(*DispatcherImpl).consumerUpdateLock.Unlock()
+
 	return newDispatcher
 }
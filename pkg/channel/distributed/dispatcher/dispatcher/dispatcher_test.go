@@ -17,9 +17,14 @@ limitations under the License.
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"os"
+	"runtime"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"github.com/ghodss/yaml"
@@ -30,9 +35,12 @@ import (
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
 	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
 	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	dispatcherconstants "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/apis"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 
@@ -163,7 +171,7 @@ func TestShutdown(t *testing.T) {
 	}
 
 	// Perform The Test
-	dispatcher.Shutdown()
+	dispatcher.Shutdown(context.TODO())
 
 	// Verify The Results
 	assert.True(t, consumerGroup1.Closed)
@@ -172,6 +180,230 @@ func TestShutdown(t *testing.T) {
 	assert.Len(t, dispatcher.subscribers, 0)
 }
 
+// Test The Shutdown() Functionality Is Idempotent For A Subscriber Whose ConsumerGroup Failed To Close
+func TestShutdownRetriedClose(t *testing.T) {
+
+	// Create A Dispatcher With A Single Subscriber Whose First ConsumerGroup Close Fails
+	consumerGroup := newRebalancingConsumerGroup()
+	consumerGroup.closeErr = sarama.ErrOutOfBrokers
+	subscriber := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, "kafka."+id123, consumerGroup)
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()},
+		subscribers:      map[types.UID]*SubscriberWrapper{uid123: subscriber},
+	}
+
+	// Verify The Failed Close Retains The Subscriber & The Retry Does Not Panic (StopChan Only Closed Once)
+	dispatcher.Shutdown(context.TODO())
+	assert.Len(t, dispatcher.subscribers, 1)
+	consumerGroup.closeErr = nil
+	assert.NotPanics(t, func() { dispatcher.Shutdown(context.TODO()) })
+	assert.Len(t, dispatcher.subscribers, 0)
+}
+
+// Test The Shutdown() Functionality Abandons Subscribers Which Have Not Drained By The Context's Deadline
+func TestShutdownDeadline(t *testing.T) {
+
+	// Create A Dispatcher With A Drained Subscriber & One Whose Consume Loop Never Exits (e.g. Stuck Delivering)
+	drainedSubscriber := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, "kafka."+id123, kafkatesting.NewMockConsumerGroup(t))
+	stuckSubscriber := NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid456}, "kafka."+id456, kafkatesting.NewMockConsumerGroup(t))
+	stuckSubscriber.stoppedChan = make(chan struct{})
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:          logtesting.TestLogger(t).Desugar(),
+			SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+			ShutdownTimeout: time.Minute,
+		},
+		subscribers: map[types.UID]*SubscriberWrapper{uid123: drainedSubscriber, uid456: stuckSubscriber},
+	}
+
+	// Perform The Test - Shutdown With A Deadline Well Before The ShutdownTimeout
+	deadline := 100 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.TODO(), deadline)
+	defer cancel()
+	startTime := time.Now()
+	dispatcher.Shutdown(ctx)
+	elapsed := time.Since(startTime)
+
+	// Verify Shutdown Returned At The Deadline Having Removed Only The Drained Subscriber
+	assert.True(t, elapsed >= deadline && elapsed < 10*deadline, "shutdown after %v", elapsed)
+	dispatcher.consumerUpdateLock.Lock()
+	assert.Len(t, dispatcher.subscribers, 1)
+	assert.Equal(t, stuckSubscriber, dispatcher.subscribers[uid456])
+	dispatcher.consumerUpdateLock.Unlock()
+
+	// Verify The Abandoned Subscriber's In-Flight Deliveries Were Cancelled
+	assert.NotNil(t, stuckSubscriber.deliveryContext().Err())
+
+	// Verify Subsequent Subscription Updates Are Ignored
+	assert.Len(t, updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{{UID: uid789}}), 0)
+	dispatcher.consumerUpdateLock.Lock()
+	assert.Nil(t, dispatcher.subscribers[uid789])
+	dispatcher.consumerUpdateLock.Unlock()
+}
+
+// Stress Test The Shutdown() Functionality While Subscribers' ConsumerGroups Are Continuously Re-Balancing
+func TestShutdownDuringRebalance(t *testing.T) {
+
+	// Test Data
+	const iterations = 100
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}, {UID: uid789}}
+
+	// Replace The NewConsumerGroupWrapper With A Re-Balancing Mock For Testing & Restore After Test
+	var consumerGroupsLock sync.Mutex
+	var consumerGroups []*rebalancingConsumerGroup
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		consumerGroupsLock.Lock()
+		defer consumerGroupsLock.Unlock()
+		consumerGroup := newRebalancingConsumerGroup()
+		consumerGroups = append(consumerGroups, consumerGroup)
+		return consumerGroup, nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Record The Baseline Goroutine Count
+	baselineGoroutines := runtime.NumGoroutine()
+
+	// Repeatedly Start Consuming & Shutdown (Concurrently With A Subscription Update) At A Random Point In The Re-Balancing
+	for i := 0; i < iterations; i++ {
+
+		// Create A New DispatcherImpl With Subscribers Which Continuously Re-Balance
+		dispatcher := NewDispatcher(DispatcherConfig{
+			Logger:          logtesting.TestLogger(t).Desugar(),
+			Topic:           testTopic,
+			SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+			ShutdownTimeout: 5 * time.Second,
+		}).(*DispatcherImpl)
+		failedSubscriptions := updateSubscriptions(t, dispatcher, subscriberSpecs)
+		assert.Len(t, failedSubscriptions, 0)
+
+		// Capture The SubscriberWrappers To Verify Their Consume Loops Have Exited
+		subscribers := make([]*SubscriberWrapper, 0, len(subscriberSpecs))
+		dispatcher.consumerUpdateLock.Lock()
+		for _, subscriber := range dispatcher.subscribers {
+			subscribers = append(subscribers, subscriber)
+		}
+		dispatcher.consumerUpdateLock.Unlock()
+
+		// Perform The Test - Shutdown Mid Re-Balance While Concurrently Removing All Subscriptions
+		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			dispatcher.Shutdown(context.TODO())
+		}()
+		go func() {
+			defer waitGroup.Done()
+			dispatcher.UpdateSubscriptions([]eventingduck.SubscriberSpec{})
+		}()
+		waitGroup.Wait()
+
+		// Verify All Subscribers Were Removed & Their Consume Loops Exited Before Shutdown() Returned
+		assert.Len(t, dispatcher.subscribers, 0)
+		for _, subscriber := range subscribers {
+			select {
+			case <-subscriber.stoppedChan:
+			default:
+				assert.Fail(t, "consume loop still running after shutdown", "subscriber %s", subscriber.UID)
+			}
+		}
+	}
+
+	// Verify All ConsumerGroups Were Closed Exactly Once & Were Not Consumed After Being Closed
+	consumerGroupsLock.Lock()
+	assert.Len(t, consumerGroups, iterations*len(subscriberSpecs))
+	for _, consumerGroup := range consumerGroups {
+		assert.Equal(t, 1, consumerGroup.closeCount())
+		assert.Equal(t, 0, consumerGroup.consumesAfterClose())
+	}
+	consumerGroupsLock.Unlock()
+
+	// Verify No Goroutines Were Leaked (Polling Rather Than assert.Eventually() Which Runs Its Own Goroutine)
+	for i := 0; i < 500 && runtime.NumGoroutine() > baselineGoroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baselineGoroutines)
+}
+
+//
+// Mock ConsumerGroup Which Continuously Re-Balances
+//
+// Each Consume() call joins a new session which ends after a random (short) period, simulating the server-side
+// re-balances which cause the Dispatcher's consume loop to repeatedly call Consume().  Calls made after the group
+// has been closed are counted (beyond the first, which is expected when closed between sessions).
+//
+type rebalancingConsumerGroup struct {
+	lock          sync.Mutex
+	errorChan     chan error
+	closedChan    chan struct{}
+	closeErr      error
+	closes        int
+	lateConsumes  int
+	closedConsume bool
+}
+
+// Verify The Mock ConsumerGroup Implements The Interface
+var _ sarama.ConsumerGroup = &rebalancingConsumerGroup{}
+
+// Mock ConsumerGroup Constructor
+func newRebalancingConsumerGroup() *rebalancingConsumerGroup {
+	return &rebalancingConsumerGroup{errorChan: make(chan error), closedChan: make(chan struct{})}
+}
+
+func (c *rebalancingConsumerGroup) Consume(ctx context.Context, _ []string, handler sarama.ConsumerGroupHandler) error {
+	select {
+	case <-c.closedChan:
+		c.lock.Lock()
+		if c.closedConsume {
+			c.lateConsumes++
+		}
+		c.closedConsume = true
+		c.lock.Unlock()
+		return sarama.ErrClosedConsumerGroup
+	default:
+	}
+	_ = handler.Setup(nil)
+	select {
+	case <-time.After(time.Duration(rand.Intn(200)) * time.Microsecond): // Session Ended By A Re-Balance
+		return nil
+	case <-ctx.Done():
+		return nil
+	case <-c.closedChan:
+		return nil // Sarama Returns The Session's Release Error (Typically Nil) When Closed Mid-Session
+	}
+}
+
+func (c *rebalancingConsumerGroup) Errors() <-chan error {
+	return c.errorChan
+}
+
+func (c *rebalancingConsumerGroup) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closeErr != nil {
+		return c.closeErr
+	}
+	c.closes++
+	if c.closes == 1 {
+		close(c.closedChan)
+		close(c.errorChan)
+	}
+	return nil
+}
+
+func (c *rebalancingConsumerGroup) closeCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.closes
+}
+
+func (c *rebalancingConsumerGroup) consumesAfterClose() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lateConsumes
+}
+
 func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 	var config *sarama.Config
 	jsonSettings, err := yaml.YAMLToJSON([]byte(saramaYaml))
@@ -180,6 +412,32 @@ func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 	return config
 }
 
+// Update The Specified Dispatcher's Subscriptions (Asserting It Was Configured) & Return The Failed Subscriptions
+func updateSubscriptions(t *testing.T, dispatcher Dispatcher, subscriberSpecs []eventingduck.SubscriberSpec) map[eventingduck.SubscriberSpec]error {
+	failedSubscriptions, err := dispatcher.UpdateSubscriptions(subscriberSpecs)
+	assert.Nil(t, err)
+	return failedSubscriptions
+}
+
+// Test The UpdateSubscriptions() Functionality Before The Dispatcher's Sarama Config Is Loaded
+func TestUpdateSubscriptionsNotConfigured(t *testing.T) {
+
+	// Create A New DispatcherImpl Without Any Sarama Config
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()},
+		subscribers:      map[types.UID]*SubscriberWrapper{},
+	}
+
+	// Verify The Distinct Error Is Returned Regardless Of Whether There Are Subscriptions To Update
+	for _, subscriberSpecs := range [][]eventingduck.SubscriberSpec{{{UID: uid123}}, {}} {
+		failedSubscriptions, err := dispatcher.UpdateSubscriptions(subscriberSpecs)
+		assert.Nil(t, failedSubscriptions)
+		assert.NotNil(t, err)
+		assert.True(t, errors.Is(err, ErrDispatcherNotConfigured))
+		assert.Empty(t, dispatcher.subscribers)
+	}
+}
+
 // Test The UpdateSubscriptions() Functionality
 func TestUpdateSubscriptions(t *testing.T) {
 
@@ -315,7 +573,7 @@ func TestUpdateSubscriptions(t *testing.T) {
 			}
 
 			// Perform The Test
-			got := dispatcher.UpdateSubscriptions(tt.args.subscriberSpecs)
+			got := updateSubscriptions(t, dispatcher, tt.args.subscriberSpecs)
 
 			// Verify Results
 			assert.Equal(t, tt.want, got)
@@ -327,7 +585,7 @@ func TestUpdateSubscriptions(t *testing.T) {
 			}
 
 			// Shutdown The Dispatcher to Cleanup Resources
-			dispatcher.Shutdown()
+			dispatcher.Shutdown(context.TODO())
 			assert.Len(t, dispatcher.subscribers, 0)
 
 			// Pause Briefly To Let Any Async Shutdown Finish (Lame But Only For Visual Confirmation Of Logging ;)
@@ -336,6 +594,229 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test The UpdateSubscriptions() Functionality With Duplicate Subscriber UIDs
+func TestUpdateSubscriptionsDuplicates(t *testing.T) {
+
+	// Test Data
+	firstURI, err := apis.ParseURL("http://first.example.com")
+	assert.Nil(t, err)
+	lastURI, err := apis.ParseURL("http://last.example.com")
+	assert.Nil(t, err)
+	firstSubscriberSpec := eventingduck.SubscriberSpec{UID: uid123, Generation: 1, SubscriberURI: firstURI}
+	lastSubscriberSpec := eventingduck.SubscriberSpec{UID: uid123, Generation: 2, SubscriberURI: lastURI}
+	otherSubscriberSpec := eventingduck.SubscriberSpec{UID: uid456}
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name          string
+		policy        string
+		wantRetained  eventingduck.SubscriberSpec
+		wantDiscarded eventingduck.SubscriberSpec
+	}
+
+	// Define The Test Cases
+	tests := []testCase{
+		{
+			name:          "Default Policy Retains First",
+			policy:        "",
+			wantRetained:  firstSubscriberSpec,
+			wantDiscarded: lastSubscriberSpec,
+		},
+		{
+			name:          "First Policy",
+			policy:        dispatcherconstants.DuplicateSubscriberPolicyFirst,
+			wantRetained:  firstSubscriberSpec,
+			wantDiscarded: lastSubscriberSpec,
+		},
+		{
+			name:          "Last Policy",
+			policy:        dispatcherconstants.DuplicateSubscriberPolicyLast,
+			wantRetained:  lastSubscriberSpec,
+			wantDiscarded: firstSubscriberSpec,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After TestCase
+			newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+			kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+				return kafkatesting.NewMockConsumerGroup(t), nil
+			}
+			defer func() {
+				kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+			}()
+
+			// Create A New DispatcherImpl To Test
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                    logtesting.TestLogger(t).Desugar(),
+				SaramaConfig:              getSaramaConfigFromYaml(t, TestConfigBase),
+				DuplicateSubscriberPolicy: tt.policy,
+				ShutdownTimeout:           time.Second, // Await Consume Loops & Error Processing Of Closed ConsumerGroups So They Do Not Outlive The Test
+			}).(*DispatcherImpl)
+
+			// Perform The Test
+			got := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{firstSubscriberSpec, otherSubscriberSpec, lastSubscriberSpec})
+
+			// Verify The Discarded Duplicate Is Reported As Failed
+			assert.Len(t, got, 1)
+			assert.NotNil(t, got[tt.wantDiscarded])
+			assert.Contains(t, got[tt.wantDiscarded].Error(), "duplicate subscriber UID")
+
+			// Verify The Retained Subscriber (And The Other Subscriber) Are Active
+			assert.Len(t, dispatcher.subscribers, 2)
+			assert.Equal(t, tt.wantRetained, dispatcher.subscribers[uid123].SubscriberSpec)
+			assert.NotNil(t, dispatcher.subscribers[uid456])
+			assert.ElementsMatch(t, []eventingduck.SubscriberSpec{tt.wantRetained, otherSubscriberSpec}, dispatcher.SubscriberSpecs)
+
+			// Shutdown The Dispatcher to Cleanup Resources
+			dispatcher.Shutdown(context.TODO())
+		})
+	}
+}
+
+// Test The UpdateSubscriptions() Functionality With Subscribers Without A SubscriberURI
+func TestUpdateSubscriptionsMissingSubscriber(t *testing.T) {
+
+	// Test Data
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	replyURI, err := apis.ParseURL("http://reply.example.com")
+	assert.Nil(t, err)
+	validSubscriberSpec := eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: subscriberURI}
+	nilSubscriberSpec := eventingduck.SubscriberSpec{UID: uid456, ReplyURI: replyURI}
+	emptySubscriberSpec := eventingduck.SubscriberSpec{UID: uid789, SubscriberURI: &apis.URL{}}
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name       string
+		policy     string
+		wantFailed []eventingduck.SubscriberSpec
+		wantActive []eventingduck.SubscriberSpec
+	}
+
+	// Define The Test Cases
+	tests := []testCase{
+		{
+			name:       "Default Policy Allows",
+			policy:     "",
+			wantActive: []eventingduck.SubscriberSpec{validSubscriberSpec, nilSubscriberSpec, emptySubscriberSpec},
+		},
+		{
+			name:       "Allow Policy",
+			policy:     dispatcherconstants.MissingSubscriberPolicyAllow,
+			wantActive: []eventingduck.SubscriberSpec{validSubscriberSpec, nilSubscriberSpec, emptySubscriberSpec},
+		},
+		{
+			name:       "Reject Policy",
+			policy:     dispatcherconstants.MissingSubscriberPolicyReject,
+			wantFailed: []eventingduck.SubscriberSpec{nilSubscriberSpec, emptySubscriberSpec},
+			wantActive: []eventingduck.SubscriberSpec{validSubscriberSpec},
+		},
+	}
+
+	// Execute The Test Cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After TestCase
+			newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+			kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+				return kafkatesting.NewMockConsumerGroup(t), nil
+			}
+			defer func() {
+				kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+			}()
+
+			// Create A New DispatcherImpl To Test
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                  logtesting.TestLogger(t).Desugar(),
+				SaramaConfig:            getSaramaConfigFromYaml(t, TestConfigBase),
+				MissingSubscriberPolicy: tt.policy,
+				ShutdownTimeout:         time.Second, // Await Consume Loops & Error Processing Of Closed ConsumerGroups So They Do Not Outlive The Test
+			}).(*DispatcherImpl)
+
+			// Perform The Test
+			got := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{validSubscriberSpec, nilSubscriberSpec, emptySubscriberSpec})
+
+			// Verify The Rejected Subscribers Are Reported As Failed Without A ConsumerGroup
+			assert.Len(t, got, len(tt.wantFailed))
+			for _, failedSubscriberSpec := range tt.wantFailed {
+				assert.NotNil(t, got[failedSubscriberSpec])
+				assert.Contains(t, got[failedSubscriberSpec].Error(), "has no SubscriberURI")
+				assert.Nil(t, dispatcher.subscribers[failedSubscriberSpec.UID])
+			}
+
+			// Verify The Remaining Subscribers Are Active
+			assert.Len(t, dispatcher.subscribers, len(tt.wantActive))
+			assert.ElementsMatch(t, tt.wantActive, dispatcher.SubscriberSpecs)
+
+			// Shutdown The Dispatcher to Cleanup Resources (Closing Any ConsumerGroups Without Panicking)
+			assert.NotPanics(t, func() { dispatcher.Shutdown(context.TODO()) })
+		})
+	}
+}
+
+// Test The ParseMissingSubscriberPolicy() Functionality
+func TestParseMissingSubscriberPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: dispatcherconstants.DefaultMissingSubscriberPolicy},
+		{name: "Allow", policy: dispatcherconstants.MissingSubscriberPolicyAllow, want: dispatcherconstants.MissingSubscriberPolicyAllow},
+		{name: "Reject", policy: dispatcherconstants.MissingSubscriberPolicyReject, want: dispatcherconstants.MissingSubscriberPolicyReject},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseMissingSubscriberPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ParseDuplicateSubscriberPolicy() Functionality
+func TestParseDuplicateSubscriberPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: dispatcherconstants.DefaultDuplicateSubscriberPolicy},
+		{name: "First", policy: dispatcherconstants.DuplicateSubscriberPolicyFirst, want: dispatcherconstants.DuplicateSubscriberPolicyFirst},
+		{name: "Last", policy: dispatcherconstants.DuplicateSubscriberPolicyLast, want: dispatcherconstants.DuplicateSubscriberPolicyLast},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseDuplicateSubscriberPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
 // Utility Function For Creating A SubscriberWrapper With Specified UID & Mock ConsumerGroup
 func createSubscriberWrapper(t *testing.T, uid types.UID) *SubscriberWrapper {
 	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, fmt.Sprintf("kafka.%s", string(uid)), kafkatesting.NewMockConsumerGroup(t))
@@ -378,17 +859,115 @@ func TestConfigChanged(t *testing.T) {
 	// Apply an additional setting to the Consumer config
 	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigConsumerAdd, true)
 
-	// Change one of the metadata settings
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigMetadataChange, true)
+	// Verify that metadata changes do not cause Reconfigure to be called
+	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigMetadataChange, false)
 
-	// Change one of the admin settings
-	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigAdminChange, true)
+	// Verify that admin changes do not cause Reconfigure to be called
+	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigAdminChange, false)
 
 	// Verify that Producer changes do not cause Reconfigure to be called
 	dispatcher = runConfigChangedTest(t, dispatcher, getBaseConfigMap(), TestConfigProducerChange, false)
 	assert.NotNil(t, dispatcher)
 }
 
+// Test Exactly Which Sarama Config Sections Are Ignored When Determining Whether To Recreate The Dispatcher
+func TestIgnoredConfigSections(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		change      func(config *sarama.Config)
+		wantIgnored bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Producer", change: func(config *sarama.Config) { config.Producer.Flush.Frequency++ }, wantIgnored: true},
+		{name: "Metadata RefreshFrequency", change: func(config *sarama.Config) { config.Metadata.RefreshFrequency++ }, wantIgnored: true},
+		{name: "Metadata Retry", change: func(config *sarama.Config) { config.Metadata.Retry.Max++ }, wantIgnored: true},
+		{name: "Admin", change: func(config *sarama.Config) { config.Admin.Timeout++ }, wantIgnored: true},
+		{name: "Consumer", change: func(config *sarama.Config) { config.Consumer.Fetch.Default++ }, wantIgnored: false},
+		{name: "Consumer Group", change: func(config *sarama.Config) { config.Consumer.Group.Session.Timeout++ }, wantIgnored: false},
+		{name: "Net", change: func(config *sarama.Config) { config.Net.DialTimeout++ }, wantIgnored: false},
+		{name: "ClientID", change: func(config *sarama.Config) { config.ClientID += "-changed" }, wantIgnored: false},
+		{name: "ChannelBufferSize", change: func(config *sarama.Config) { config.ChannelBufferSize++ }, wantIgnored: false},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create The Current & New Configs From The Base ConfigMap, Applying The TestCase's Change To The New One
+			currentConfig, err := kafkasarama.MergeSaramaSettings(nil, getBaseConfigMap())
+			assert.Nil(t, err)
+			newConfig, err := kafkasarama.MergeSaramaSettings(nil, getBaseConfigMap())
+			assert.Nil(t, err)
+			testCase.change(newConfig)
+
+			// Verify The Change Is Only Ignored If It Is In One Of The Ignored Sections
+			assert.False(t, kafkasarama.ConfigEqual(newConfig, currentConfig))
+			assert.Equal(t, testCase.wantIgnored, kafkasarama.ConfigEqual(newConfig, currentConfig, ignoredConfigSections(newConfig)...))
+		})
+	}
+}
+
+// Test The Dispatcher's ConfigChanged Functionality Retains The SASL Mechanism (e.g. From The Kafka Secret)
+func TestConfigChangedSaslMechanism(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Create A Dispatcher With A SCRAM SASL Mechanism Applied To The Base Config
+	saramaConfig, err := kafkasarama.MergeSaramaSettings(nil, getBaseConfigMap())
+	assert.Nil(t, err)
+	assert.Nil(t, kafkasarama.UpdateSaramaSaslMechanism(saramaConfig, sarama.SASLTypeSCRAMSHA512))
+	var dispatcher Dispatcher = &DispatcherImpl{
+		DispatcherConfig:  DispatcherConfig{Logger: logger, SaramaConfig: saramaConfig},
+		subscribers:       make(map[types.UID]*SubscriberWrapper),
+		messageDispatcher: channel.NewMessageDispatcher(logger),
+	}
+
+	// Unchanged Settings Do Not Recreate The Dispatcher (The SASL Mechanism & SCRAM Client Are Not A Change)
+	assert.Nil(t, dispatcher.ConfigChanged(getBaseConfigMap()))
+
+	// Changed Settings Recreate The Dispatcher With The Retained SASL Mechanism & SCRAM Client
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	newDispatcher := dispatcher.ConfigChanged(configMap)
+	assert.NotNil(t, newDispatcher)
+	newSaramaConfig := newDispatcher.(*DispatcherImpl).SaramaConfig
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), newSaramaConfig.Net.SASL.Mechanism)
+	assert.NotNil(t, newSaramaConfig.Net.SASL.SCRAMClientGeneratorFunc)
+	newDispatcher.Shutdown(context.TODO())
+}
+
+// Test The Dispatcher's ConfigChanged Functionality Retains The State Set From The KafkaChannel
+func TestConfigChangedRetainedState(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, constants.KnativeEventingNamespace))
+
+	// Create A Dispatcher With Retained State
+	saramaConfig, err := kafkasarama.MergeSaramaSettings(nil, getBaseConfigMap())
+	assert.Nil(t, err)
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logger, SaramaConfig: saramaConfig}).(*DispatcherImpl)
+	dispatcher.subscriberTLS = map[types.UID]*subscriberTLS{"uid": {}}
+	dispatcher.initialOffsets = map[string]int64{"uid": 1}
+	dispatcher.sourceGroupIds = map[string]string{"uid": "source-group"}
+	dispatcher.seededSourceGroupIds[types.UID("uid")] = "source-group"
+	dispatcher.invalidInitialOffsets = map[string]error{"other-uid": errors.New("invalid initial offset")}
+	dispatcher.headOfLinePolicies = map[string]string{"uid": "skip"}
+	dispatcher.legacyBridge = &LegacyBridge{Type: "com.example.legacy", Source: "/legacy/orders"}
+	dispatcher.shards = 2
+	dispatcher.deliveryGuarantee = "at-least-once"
+
+	// Changed Settings Recreate The Dispatcher With The Same Retained State
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	newDispatcher := dispatcher.ConfigChanged(configMap)
+	assert.NotNil(t, newDispatcher)
+	assert.Equal(t, dispatcher.retainedState, newDispatcher.(*DispatcherImpl).retainedState)
+	newDispatcher.Shutdown(context.TODO())
+}
+
 func runConfigChangedTest(t *testing.T, originalDispatcher Dispatcher, base *corev1.ConfigMap, changed string, expectedNewDispatcher bool) Dispatcher {
 	// Change the Consumer settings to the base config
 	newDispatcher := originalDispatcher.ConfigChanged(base)
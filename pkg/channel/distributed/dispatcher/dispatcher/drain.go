@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+// Determine The Time Allowed For Draining After A Termination Signal From The Grace Period & Drain Margin (Defaulted If Unspecified)
+func ParseDrainTimeout(terminationGracePeriodSeconds int64, drainMarginMillis int64) (time.Duration, error) {
+	if terminationGracePeriodSeconds <= 0 {
+		terminationGracePeriodSeconds = constants.DefaultTerminationGracePeriodSeconds
+	}
+	if drainMarginMillis < 0 {
+		return 0, fmt.Errorf("invalid drain margin %dms - must be >= 0", drainMarginMillis)
+	}
+	if drainMarginMillis == 0 {
+		drainMarginMillis = constants.DefaultDrainMarginMillis
+	}
+	drainTimeout := time.Duration(terminationGracePeriodSeconds)*time.Second - time.Duration(drainMarginMillis)*time.Millisecond
+	if drainTimeout <= 0 {
+		return 0, fmt.Errorf("invalid drain margin %dms - must be less than the termination grace period of %ds", drainMarginMillis, terminationGracePeriodSeconds)
+	}
+	return drainTimeout, nil
+}
+
+//
+// Drain The Dispatcher Upon Termination
+//
+// Kubernetes sends SIGTERM when a Dispatcher Pod is terminated, and SIGKILL once its termination grace period has
+// elapsed.  The Drainer begins shutting down the Dispatcher as soon as the signal is received (rather than once the
+// controllers have stopped), with a deadline of the drain timeout after the signal so that the Dispatcher stops
+// waiting on its subscribers, and exits, before it can be killed part way through committing offsets.  Draining
+// happens at most once, whether triggered by a signal or explicitly via Drain().
+//
+type Drainer struct {
+	logger      *zap.Logger
+	dispatcher  func() Dispatcher // The Current Dispatcher (Replaced When The Sarama Configuration Changes)
+	timeout     time.Duration     // Time After Draining Starts By Which The Dispatcher Must Have Shutdown
+	beforeDrain func()            // Optional Preparation For Draining (e.g. Clearing Readiness)
+	once        sync.Once
+}
+
+// Drainer Constructor
+func NewDrainer(logger *zap.Logger, dispatcher func() Dispatcher, timeout time.Duration, beforeDrain func()) *Drainer {
+	return &Drainer{logger: logger, dispatcher: dispatcher, timeout: timeout, beforeDrain: beforeDrain}
+}
+
+// Start Draining The Dispatcher In The Background Upon The First Signal From The Specified Channel
+func (d *Drainer) Start(signals <-chan os.Signal) {
+	go func() {
+		if signal, ok := <-signals; ok {
+			d.logger.Info("Received Termination Signal - Draining Dispatcher", zap.String("Signal", signal.String()), zap.Duration("DrainTimeout", d.timeout))
+			d.Drain()
+		}
+	}()
+}
+
+// Drain The Dispatcher Unless Already Started (e.g. By A Signal), Blocking Until Draining Has Completed
+func (d *Drainer) Drain() {
+	d.once.Do(func() {
+
+		// The Deadline Includes The Preparation So That It Is Relative To The Signal
+		startTime := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+		defer cancel()
+		if d.beforeDrain != nil {
+			d.beforeDrain()
+		}
+
+		// Shutdown The Dispatcher & Log The Outcome
+		d.dispatcher().Shutdown(ctx)
+		if ctx.Err() != nil {
+			d.logger.Warn("Dispatcher Drain Timed Out - Exiting Without Awaiting Remaining Subscribers", zap.Duration("DrainTimeout", d.timeout))
+		} else {
+			d.logger.Info("Dispatcher Drained", zap.Duration("Duration", time.Since(startTime)))
+		}
+	})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseDrainTimeout() Functionality
+func TestParseDrainTimeout(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name               string
+		gracePeriodSeconds int64
+		drainMarginMillis  int64
+		want               time.Duration
+		wantErr            bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Defaults", want: constants.DefaultTerminationGracePeriodSeconds*time.Second - constants.DefaultDrainMarginMillis*time.Millisecond},
+		{name: "Grace Period", gracePeriodSeconds: 20, want: 18 * time.Second},
+		{name: "Grace Period & Margin", gracePeriodSeconds: 20, drainMarginMillis: 5500, want: 14500 * time.Millisecond},
+		{name: "Negative Margin", gracePeriodSeconds: 20, drainMarginMillis: -1, wantErr: true},
+		{name: "Margin Exceeds Grace Period", gracePeriodSeconds: 20, drainMarginMillis: 20000, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			drainTimeout, err := ParseDrainTimeout(testCase.gracePeriodSeconds, testCase.drainMarginMillis)
+			assert.Equal(t, testCase.want, drainTimeout)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That A Termination Signal Prepares For & Then Starts Draining The Dispatcher Immediately (Exactly Once)
+func TestDrainerSignal(t *testing.T) {
+
+	// Create A Mock Dispatcher Recording The Sequence Of Draining Steps
+	drainTimeout := 5 * time.Second
+	mockDispatcher := newDrainRecordingDispatcher(false)
+	drainer := NewDrainer(logtesting.TestLogger(t).Desugar(), func() Dispatcher { return mockDispatcher }, drainTimeout, func() {
+		mockDispatcher.record("beforeDrain")
+	})
+
+	// Start The Drainer & Verify Nothing Happens Until A Signal Is Received
+	signals := make(chan os.Signal, 1)
+	drainer.Start(signals)
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, mockDispatcher.recorded())
+
+	// Perform The Test - Send A SIGTERM & Wait For The Dispatcher To Have Been Shutdown
+	signalTime := time.Now()
+	signals <- syscall.SIGTERM
+	<-mockDispatcher.shutdownChan
+	shutdownTime := time.Now()
+
+	// Verify The Preparation Preceded The Shutdown, Whose Deadline Is The Drain Timeout After The Signal
+	assert.Equal(t, []string{"beforeDrain", "shutdown"}, mockDispatcher.recorded())
+	deadline := mockDispatcher.shutdownDeadline()
+	assert.False(t, deadline.Before(signalTime.Add(drainTimeout)), "deadline %v after signal", deadline.Sub(signalTime))
+	assert.False(t, deadline.After(shutdownTime.Add(drainTimeout)), "deadline %v after shutdown", deadline.Sub(shutdownTime))
+
+	// Verify Subsequently Draining Explicitly (As Once The Controllers Have Stopped) Does Not Drain Again
+	drainer.Drain()
+	signals <- syscall.SIGTERM
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"beforeDrain", "shutdown"}, mockDispatcher.recorded())
+}
+
+// Test That Draining Explicitly Blocks Until A Drain Started By A Signal Has Timed Out
+func TestDrainerTimeout(t *testing.T) {
+
+	// Create A Mock Dispatcher Whose Shutdown Only Returns Once Its Deadline Has Been Reached
+	drainTimeout := 200 * time.Millisecond
+	mockDispatcher := newDrainRecordingDispatcher(true)
+	drainer := NewDrainer(logtesting.TestLogger(t).Desugar(), func() Dispatcher { return mockDispatcher }, drainTimeout, nil)
+
+	// Perform The Test - Signal & Then Drain Explicitly Once The Dispatcher Shutdown Has Started
+	signals := make(chan os.Signal, 1)
+	drainer.Start(signals)
+	startTime := time.Now()
+	signals <- syscall.SIGINT
+	<-mockDispatcher.shutdownChan
+	drainer.Drain()
+	elapsed := time.Since(startTime)
+
+	// Verify Draining Completed At The Deadline Having Only Shutdown Once
+	assert.True(t, elapsed >= drainTimeout && elapsed < 5*drainTimeout, "drained after %v", elapsed)
+	assert.Equal(t, []string{"shutdown"}, mockDispatcher.recorded())
+}
+
+// Test That The Dispatcher Is Drained Explicitly Without Any Signal (e.g. The Controllers Stopping Otherwise)
+func TestDrainerWithoutSignal(t *testing.T) {
+
+	// Create A Drainer Of A Mock Dispatcher Which Has Not Been Started
+	mockDispatcher := newDrainRecordingDispatcher(false)
+	drainer := NewDrainer(logtesting.TestLogger(t).Desugar(), func() Dispatcher { return mockDispatcher }, time.Second, nil)
+
+	// Perform The Test
+	drainer.Drain()
+
+	// Verify The Dispatcher Was Shutdown With A Deadline
+	assert.Equal(t, []string{"shutdown"}, mockDispatcher.recorded())
+	assert.False(t, mockDispatcher.shutdownDeadline().IsZero())
+}
+
+//
+// Mock Dispatcher Recording The Sequence Of Draining Steps (Optionally Blocking Shutdown Until Its Deadline)
+//
+type drainRecordingDispatcher struct {
+	Dispatcher
+	blockShutdown bool
+	shutdownChan  chan struct{}
+	lock          sync.Mutex
+	steps         []string
+	deadline      time.Time
+}
+
+func newDrainRecordingDispatcher(blockShutdown bool) *drainRecordingDispatcher {
+	return &drainRecordingDispatcher{blockShutdown: blockShutdown, shutdownChan: make(chan struct{}), steps: make([]string, 0)}
+}
+
+func (d *drainRecordingDispatcher) Shutdown(ctx context.Context) {
+	d.lock.Lock()
+	d.steps = append(d.steps, "shutdown")
+	d.deadline, _ = ctx.Deadline()
+	d.lock.Unlock()
+	close(d.shutdownChan)
+	if d.blockShutdown {
+		<-ctx.Done()
+	}
+}
+
+func (d *drainRecordingDispatcher) record(step string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.steps = append(d.steps, step)
+}
+
+func (d *drainRecordingDispatcher) recorded() []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return append([]string{}, d.steps...)
+}
+
+func (d *drainRecordingDispatcher) shutdownDeadline() time.Time {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.deadline
+}
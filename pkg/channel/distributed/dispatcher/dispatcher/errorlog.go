@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+// Sampling Of Repeated Identical Subscriber Delivery Error Logs
+type ErrorLogSampling struct {
+	Interval   time.Duration // The Sampling Interval (Also The Minimum Interval Between Suppressed Log Roll-Ups)
+	First      int           // Number Of Identical Error Logs Written Per Interval
+	Thereafter int           // Only Every Thereafter'th Identical Error Log Is Written Per Interval After The First
+}
+
+// Create The ErrorLogSampling For The Specified Configuration (Nil If None Is Configured)
+func NewErrorLogSampling(config commonconfig.EKDispatcherErrorLogSamplingConfig) (*ErrorLogSampling, error) {
+
+	// Validate The Configuration
+	if config.IntervalMillis < 0 {
+		return nil, fmt.Errorf("invalid error log sampling interval %dms - must be >= 0", config.IntervalMillis)
+	}
+	if config.First < 0 || config.Thereafter < 0 {
+		return nil, fmt.Errorf("invalid error log sampling first %d / thereafter %d - must be >= 0", config.First, config.Thereafter)
+	}
+
+	// No Sampling If No Interval Is Specified
+	if config.IntervalMillis == 0 {
+		return nil, nil
+	}
+
+	// Default The First & Thereafter If Unspecified
+	first := config.First
+	if first == 0 {
+		first = constants.DefaultErrorLogSamplingFirst
+	}
+	thereafter := config.Thereafter
+	if thereafter == 0 {
+		thereafter = constants.DefaultErrorLogSamplingThereafter
+	}
+
+	return &ErrorLogSampling{
+		Interval:   time.Duration(config.IntervalMillis) * time.Millisecond,
+		First:      first,
+		Thereafter: thereafter,
+	}, nil
+}
+
+//
+// Logger Of A Subscriber's Delivery Errors Which Samples Repeated Identical Errors
+//
+// During a sustained subscriber outage every delivery attempt fails with the same error, and logging each of them
+// floods the logs.  The first error after the subscriber was last successfully delivered to is always logged, after
+// which identical errors (same level & message) are sampled by zap - the first N of each interval are logged and
+// then only every Nth.  The number of errors dropped by the sampling is logged as a roll-up at most once per
+// interval (as further errors occur), and when the subscriber next responds successfully its recovery is logged
+// along with any errors dropped since the last roll-up.
+//
+type deliveryErrorLog struct {
+	logger        *zap.Logger // Unsampled Logger (First Error, Roll-Ups & Recovery)
+	sampledLogger *zap.Logger // Sampled Logger (Subsequent Errors)
+	interval      time.Duration
+	subscriberURL *url.URL
+	suppressed    int64 // Number Of Error Logs Dropped By The Sampling Since The Last Roll-Up (Atomic)
+	lock          sync.Mutex
+	failing       bool      // Whether An Error Has Been Logged Since The Subscriber Last Responded Successfully
+	lastRollUp    time.Time // Time Of The Last Roll-Up Of Suppressed Error Logs
+}
+
+// deliveryErrorLog Constructor
+func newDeliveryErrorLog(logger *zap.Logger, sampling *ErrorLogSampling, subscriberURL *url.URL) *deliveryErrorLog {
+	errorLog := &deliveryErrorLog{logger: logger, interval: sampling.Interval, subscriberURL: subscriberURL}
+	errorLog.sampledLogger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, sampling.Interval, sampling.First, sampling.Thereafter,
+			zapcore.SamplerHook(func(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+				if decision&zapcore.LogDropped > 0 {
+					atomic.AddInt64(&errorLog.suppressed, 1)
+				}
+			}))
+	}))
+	return errorLog
+}
+
+// Log The Specified Delivery Error (Sampled Unless It Is The First Since The Subscriber Last Responded Successfully)
+func (l *deliveryErrorLog) log(level zapcore.Level, message string, fields ...zap.Field) {
+
+	// Determine Whether This Is The First Error & Whether A Roll-Up Is Due
+	l.lock.Lock()
+	first := !l.failing
+	rollUp := !first && now().Sub(l.lastRollUp) >= l.interval
+	if first || rollUp {
+		l.lastRollUp = now()
+	}
+	l.failing = true
+	l.lock.Unlock()
+
+	// Always Log The First Error
+	if first {
+		if checkedEntry := l.logger.Check(level, message); checkedEntry != nil {
+			checkedEntry.Write(fields...)
+		}
+		return
+	}
+
+	// Otherwise Log The Error Via The Sampler & Periodically Roll-Up The Number Of Suppressed Errors
+	if checkedEntry := l.sampledLogger.Check(level, message); checkedEntry != nil {
+		checkedEntry.Write(fields...)
+	}
+	if rollUp {
+		if suppressed := atomic.SwapInt64(&l.suppressed, 0); suppressed > 0 {
+			l.logger.Warn("Suppressed Repeated Subscriber Delivery Error Logs", zap.Int64("Suppressed", suppressed), zap.Duration("Interval", l.interval))
+		}
+	}
+}
+
+// Observe The Specified Response, Logging The Subscriber's Recovery If It Is A Success From The Subscriber After Errors
+func (l *deliveryErrorLog) observe(response *http.Response) {
+	if !isResponseFrom(response, l.subscriberURL) || response.StatusCode < 200 || response.StatusCode > 299 {
+		return
+	}
+	l.lock.Lock()
+	recovered := l.failing
+	l.failing = false
+	l.lock.Unlock()
+	if recovered {
+		l.logger.Info("Subscriber Delivery Recovered", zap.Int64("Suppressed", atomic.SwapInt64(&l.suppressed, 0)))
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// Test The NewErrorLogSampling() Functionality
+func TestNewErrorLogSampling(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKDispatcherErrorLogSamplingConfig
+		want    *ErrorLogSampling
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", config: commonconfig.EKDispatcherErrorLogSamplingConfig{}, want: nil},
+		{name: "Disabled", config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: 0, First: 5, Thereafter: 10}, want: nil},
+		{
+			name:   "Defaulted",
+			config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: 60000},
+			want:   &ErrorLogSampling{Interval: time.Minute, First: constants.DefaultErrorLogSamplingFirst, Thereafter: constants.DefaultErrorLogSamplingThereafter},
+		},
+		{
+			name:   "Specified",
+			config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: 5000, First: 3, Thereafter: 20},
+			want:   &ErrorLogSampling{Interval: 5 * time.Second, First: 3, Thereafter: 20},
+		},
+		{name: "Negative Interval", config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: -1}, wantErr: true},
+		{name: "Negative First", config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: 1000, First: -1}, wantErr: true},
+		{name: "Negative Thereafter", config: commonconfig.EKDispatcherErrorLogSamplingConfig{IntervalMillis: 1000, Thereafter: -1}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sampling, err := NewErrorLogSampling(testCase.config)
+			assert.Equal(t, testCase.want, sampling)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That The deliveryErrorLog Bounds The Volume Of Logs Written During A Burst Of Identical Errors
+func TestDeliveryErrorLogBurst(t *testing.T) {
+
+	// Mock The Current Time
+	mockTime := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockTime }
+	t.Cleanup(func() { now = time.Now })
+
+	// Create A deliveryErrorLog Writing To A Buffer
+	logger, buffer := newBufferLogger()
+	sampling := &ErrorLogSampling{Interval: time.Hour, First: 1, Thereafter: 10}
+	errorLog := newDeliveryErrorLog(logger, sampling, testSubscriberURI.URL())
+
+	// Perform The Test - A Burst Of 100 Identical Errors
+	for i := 0; i < 100; i++ {
+		errorLog.log(zapcore.WarnLevel, "Failed To Send Message To Subscriber Service - Retrying", zap.Int("StatusCode", 503))
+	}
+
+	// Verify The First Error Was Logged Unsampled & Only Every 10th Of The Remaining 99 Thereafter
+	assert.Equal(t, 1+1+9, countLogs(buffer, "Failed To Send Message To Subscriber Service - Retrying"))
+	assert.Equal(t, 0, countLogs(buffer, "Suppressed Repeated Subscriber Delivery Error Logs"))
+
+	// Verify The Suppressed Errors Are Rolled-Up Once The Interval Has Elapsed
+	mockTime = mockTime.Add(time.Hour)
+	errorLog.log(zapcore.WarnLevel, "Failed To Send Message To Subscriber Service - Retrying", zap.Int("StatusCode", 503))
+	assert.Equal(t, 1, countLogs(buffer, "Suppressed Repeated Subscriber Delivery Error Logs"))
+	assert.Contains(t, buffer.String(), `"Suppressed": 90`)
+
+	// Verify A Successful Response From Another Destination (e.g. The Reply) Is Not Considered A Recovery
+	errorLog.observe(newTestResponse(t, "http://reply.example.com", http.StatusOK))
+	assert.Equal(t, 0, countLogs(buffer, "Subscriber Delivery Recovered"))
+
+	// Verify A Failed Response From The Subscriber Is Not Considered A Recovery
+	errorLog.observe(newTestResponse(t, testSubscriberURI.String(), http.StatusServiceUnavailable))
+	assert.Equal(t, 0, countLogs(buffer, "Subscriber Delivery Recovered"))
+
+	// Verify A Successful Response From The Subscriber Logs The Recovery (Once)
+	errorLog.observe(newTestResponse(t, testSubscriberURI.String(), http.StatusOK))
+	errorLog.observe(newTestResponse(t, testSubscriberURI.String(), http.StatusOK))
+	assert.Equal(t, 1, countLogs(buffer, "Subscriber Delivery Recovered"))
+
+	// Verify The First Error After Recovery Is Always Logged
+	buffer.Reset()
+	errorLog.log(zapcore.WarnLevel, "Failed To Send Message To Subscriber Service - Retrying", zap.Int("StatusCode", 503))
+	assert.Equal(t, 1, countLogs(buffer, "Failed To Send Message To Subscriber Service - Retrying"))
+}
+
+// Test The Handler's Logging Of Delivery Errors With & Without Sampling
+func TestHandlerLogDeliveryError(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name     string
+		sampling *ErrorLogSampling
+		want     int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unsampled", sampling: nil, want: 50},
+		{name: "Sampled", sampling: &ErrorLogSampling{Interval: time.Hour, First: 2, Thereafter: 25}, want: 1 + 2 + 1},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Handler Logging To A Buffer
+			logger, buffer := newBufferLogger()
+			handler := NewHandler(logger, &eventingduck.SubscriberSpec{}, "", "", false, nil)
+			if testCase.sampling != nil {
+				handler.deliveryErrors = newDeliveryErrorLog(logger, testCase.sampling, &url.URL{})
+			}
+
+			// Perform The Test - A Burst Of Identical Retried Errors
+			for i := 0; i < 50; i++ {
+				retry, err := handler.checkRetry(nil, &http.Response{StatusCode: 400}, errors.New("test error"))
+				assert.True(t, retry)
+				assert.Nil(t, err)
+			}
+
+			// Verify The Expected Number Of Errors Were Logged
+			assert.Equal(t, testCase.want, countLogs(buffer, "Received Response Error - Retrying"))
+		})
+	}
+}
+
+// Utility Function For Creating A Logger Which Writes To The Returned Buffer
+func newBufferLogger() (*zap.Logger, *bytes.Buffer) {
+	buffer := &bytes.Buffer{}
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(buffer), zapcore.DebugLevel)), buffer
+}
+
+// Utility Function For Counting The Logs With The Specified Message In The Specified Buffer
+func countLogs(buffer *bytes.Buffer, message string) int {
+	return strings.Count(buffer.String(), "\t"+message)
+}
+
+// Utility Function For Creating A Response To A Request To The Specified URL
+func newTestResponse(t *testing.T, requestURL string, statusCode int) *http.Response {
+	request, err := http.NewRequest(http.MethodPost, requestURL, nil)
+	assert.Nil(t, err)
+	return &http.Response{StatusCode: statusCode, Request: request}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+)
+
+// Prefix Of The Kafka Headers Carrying The Attributes & Extensions Of Binary Content Mode CloudEvents
+const cloudEventHeaderPrefix = "ce_"
+
+// CloudEvent Spec Versions Of Binary Content Mode Kafka Messages (Identifying Which Headers Are Attributes)
+var cloudEventHeaderSpecs = spec.WithPrefix(cloudEventHeaderPrefix)
+
+//
+// Filter Restricting The CloudEvent Extension Attributes Propagated To Subscribers
+//
+// By default every extension attribute round-trips through the KafkaChannel, including internal metadata (e.g.
+// tracing or routing extensions) which should not be exposed to external subscribers.  With an Allow list only the
+// listed extensions are dispatched, and with a Deny list all but the listed extensions are dispatched.  The required
+// and optional CloudEvent context attributes (id, source, time, etc.) are never removed.
+//
+type ExtensionFilter struct {
+	allow map[string]bool // Extensions Which Are Dispatched (All Others Are Removed) If Non-Nil
+	deny  map[string]bool // Extensions Which Are Removed (All Others Are Dispatched) If Non-Nil
+}
+
+// Create An ExtensionFilter From The Specified Configuration (Nil If All Extensions Should Be Passed Through)
+func NewExtensionFilter(config commonconfig.EKDispatcherExtensionsConfig) (*ExtensionFilter, error) {
+
+	// Validate That At Most One Of The Allow & Deny Lists Is Specified
+	if len(config.Allow) > 0 && len(config.Deny) > 0 {
+		return nil, fmt.Errorf("invalid extensions configuration - only one of the allow %v and deny %v lists may be specified", config.Allow, config.Deny)
+	}
+
+	// No Filter If Neither Is Specified
+	if len(config.Allow) <= 0 && len(config.Deny) <= 0 {
+		return nil, nil
+	}
+
+	// Validate The Extension Names (Which Must Be Lower-Case Alphanumeric As Per The CloudEvents Spec)
+	names := make(map[string]bool, len(config.Allow)+len(config.Deny))
+	for _, name := range append(config.Allow, config.Deny...) {
+		if len(name) <= 0 || !event.IsAlphaNumeric(name) || strings.ToLower(name) != name {
+			return nil, fmt.Errorf("invalid extensions configuration - '%s' is not a valid CloudEvent extension name", name)
+		}
+		names[name] = true
+	}
+
+	if len(config.Allow) > 0 {
+		return &ExtensionFilter{allow: names}, nil
+	}
+	return &ExtensionFilter{deny: names}, nil
+}
+
+// Determine Whether The Specified Extension Should Be Dispatched To Subscribers
+func (f *ExtensionFilter) retains(name string) bool {
+	if f.allow != nil {
+		return f.allow[name]
+	}
+	return !f.deny[name]
+}
+
+//
+// Remove The Extensions Which Should Not Be Dispatched From A Copy Of The Specified ConsumerMessage
+//
+// The extensions of structured content mode messages are removed from the (re-encoded) message value, and those of
+// binary content mode messages from the "ce_" prefixed Kafka headers.  Messages which are neither are returned as-is
+// (to be handled as a deserialization failure).
+//
+func (f *ExtensionFilter) filter(consumerMessage *sarama.ConsumerMessage) (*sarama.ConsumerMessage, error) {
+
+	// Locate The Content-Type & Spec Version Headers (As The SaramaKafka Protocol Does)
+	var contentType string
+	var version spec.Version
+	for _, header := range consumerMessage.Headers {
+		if header == nil {
+			continue
+		}
+		switch strings.ToLower(string(header.Key)) {
+		case "content-type":
+			contentType = string(header.Value)
+		case cloudEventHeaderSpecs.PrefixedSpecVersionName():
+			version = cloudEventHeaderSpecs.Version(string(header.Value))
+		}
+	}
+
+	filteredMessage := *consumerMessage
+
+	// Structured Content Mode - Remove The Extensions From The Decoded CloudEvent & Re-Encode It
+	if eventFormat := format.Lookup(contentType); eventFormat != nil {
+		cloudEvent := event.New()
+		err := eventFormat.Unmarshal(consumerMessage.Value, &cloudEvent)
+		if err != nil {
+			return nil, err
+		}
+		for name := range cloudEvent.Extensions() {
+			if !f.retains(name) {
+				_ = cloudEvent.Context.SetExtension(name, nil)
+			}
+		}
+		filteredMessage.Value, err = eventFormat.Marshal(&cloudEvent)
+		if err != nil {
+			return nil, err
+		}
+		return &filteredMessage, nil
+	}
+
+	// Binary Content Mode - Remove The Kafka Headers Of The Extensions (Any Prefixed Header Which Isn't An Attribute)
+	if version != nil {
+		filteredMessage.Headers = make([]*sarama.RecordHeader, 0, len(consumerMessage.Headers))
+		for _, header := range consumerMessage.Headers {
+			if header != nil {
+				key := strings.ToLower(string(header.Key))
+				if strings.HasPrefix(key, cloudEventHeaderPrefix) && version.Attribute(key) == nil && !f.retains(strings.TrimPrefix(key, cloudEventHeaderPrefix)) {
+					continue
+				}
+			}
+			filteredMessage.Headers = append(filteredMessage.Headers, header)
+		}
+		return &filteredMessage, nil
+	}
+
+	return consumerMessage, nil
+}
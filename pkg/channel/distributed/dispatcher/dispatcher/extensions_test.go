@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// Test The NewExtensionFilter() Functionality
+func TestNewExtensionFilter(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKDispatcherExtensionsConfig
+		want    *ExtensionFilter
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", config: commonconfig.EKDispatcherExtensionsConfig{}, want: nil},
+		{
+			name:   "Allow",
+			config: commonconfig.EKDispatcherExtensionsConfig{Allow: []string{"partitionkey", "eventtypeversion"}},
+			want:   &ExtensionFilter{allow: map[string]bool{"partitionkey": true, "eventtypeversion": true}},
+		},
+		{
+			name:   "Deny",
+			config: commonconfig.EKDispatcherExtensionsConfig{Deny: []string{"knativehistory"}},
+			want:   &ExtensionFilter{deny: map[string]bool{"knativehistory": true}},
+		},
+		{name: "Allow And Deny", config: commonconfig.EKDispatcherExtensionsConfig{Allow: []string{"partitionkey"}, Deny: []string{"knativehistory"}}, wantErr: true},
+		{name: "Upper-Case Name", config: commonconfig.EKDispatcherExtensionsConfig{Deny: []string{"knativeHistory"}}, wantErr: true},
+		{name: "Invalid Name", config: commonconfig.EKDispatcherExtensionsConfig{Allow: []string{"partition-key"}}, wantErr: true},
+		{name: "Empty Name", config: commonconfig.EKDispatcherExtensionsConfig{Deny: []string{""}}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			extensionFilter, err := NewExtensionFilter(testCase.config)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.want, extensionFilter)
+		})
+	}
+}
+
+// Test The CloudEvent Extensions Dispatched By The Handler With The Passthrough, Allow-List & Deny-List Configurations
+func TestHandlerConsumeMessageExtensions(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		config         commonconfig.EKDispatcherExtensionsConfig
+		wantExtensions map[string]interface{}
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Passthrough",
+			config:         commonconfig.EKDispatcherExtensionsConfig{},
+			wantExtensions: map[string]interface{}{"eventtypeversion": testMsgEventTypeVersion, "knativehistory": testMsgKnativeHistory},
+		},
+		{
+			name:           "Allow List",
+			config:         commonconfig.EKDispatcherExtensionsConfig{Allow: []string{"eventtypeversion", "partitionkey"}},
+			wantExtensions: map[string]interface{}{"eventtypeversion": testMsgEventTypeVersion},
+		},
+		{
+			name:           "Allow List Without Matches",
+			config:         commonconfig.EKDispatcherExtensionsConfig{Allow: []string{"partitionkey"}},
+			wantExtensions: nil,
+		},
+		{
+			name:           "Deny List",
+			config:         commonconfig.EKDispatcherExtensionsConfig{Deny: []string{"knativehistory", "traceparent"}},
+			wantExtensions: map[string]interface{}{"eventtypeversion": testMsgEventTypeVersion},
+		},
+	}
+
+	// Execute The Individual Test Cases For Both Binary & Structured Content Mode Messages
+	for _, testCase := range testCases {
+		for _, structured := range []bool{false, true} {
+			name := testCase.name + " Binary"
+			if structured {
+				name = testCase.name + " Structured"
+			}
+			t.Run(name, func(t *testing.T) {
+
+				// Create The Handler With The ExtensionFilter For The Specified Configuration
+				extensionFilter, err := NewExtensionFilter(testCase.config)
+				assert.Nil(t, err)
+				retryConfig := kncloudevents.NoRetries()
+				mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+				handler := createTestHandler(t, testSubscriberURI, nil, nil)
+				handler.MessageDispatcher = mockMessageDispatcher
+				handler.ExtensionFilter = extensionFilter
+
+				// Perform The Test
+				consumerMessage := createConsumerMessage(t)
+				if structured {
+					consumerMessage = createStructuredConsumerMessage(t, consumerMessage)
+				}
+				originalHeaders := len(consumerMessage.Headers)
+				originalValue := string(consumerMessage.Value)
+				err = handler.consumeMessage(consumerMessage, testSubscriberURI.URL(), nil, nil, &retryConfig)
+				assert.Nil(t, err)
+
+				// Verify The Dispatched CloudEvent Retains Its Attributes & Only The Expected Extensions
+				dispatchedEvent, err := binding.ToEvent(context.TODO(), mockMessageDispatcher.Message())
+				assert.Nil(t, err)
+				assert.Equal(t, testMsgId, dispatchedEvent.ID())
+				assert.Equal(t, testMsgSource, dispatchedEvent.Source())
+				assert.Equal(t, testMsgType, dispatchedEvent.Type())
+				assert.JSONEq(t, testMsgJsonContentString, string(dispatchedEvent.Data()))
+				assert.Equal(t, testCase.wantExtensions, dispatchedEvent.Extensions())
+
+				// Verify The Consumed Message Was Not Modified
+				assert.Len(t, consumerMessage.Headers, originalHeaders)
+				assert.Equal(t, originalValue, string(consumerMessage.Value))
+			})
+		}
+	}
+}
+
+// Test That A Structured Content Mode Message Which Cannot Be Decoded Is Handled As A Deserialization Failure
+func TestHandlerConsumeMessageExtensionsInvalidStructured(t *testing.T) {
+	extensionFilter, err := NewExtensionFilter(commonconfig.EKDispatcherExtensionsConfig{Deny: []string{"knativehistory"}})
+	assert.Nil(t, err)
+	retryConfig := kncloudevents.NoRetries()
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.ExtensionFilter = extensionFilter
+	handler.DeserializationFailurePolicy = constants.DeserializationFailurePolicyFail
+	consumerMessage := &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{{Key: []byte("content-type"), Value: []byte(event.ApplicationCloudEventsJSON)}},
+		Value:   []byte(testMalformedMsgContent),
+		Topic:   testTopic,
+	}
+	err = handler.consumeMessage(consumerMessage, testSubscriberURI.URL(), nil, nil, &retryConfig)
+	assert.True(t, errors.Is(err, ErrDeserializationFailure))
+}
+
+// Utility Function For Converting A (Binary Content Mode) ConsumerMessage Into A Structured Content Mode ConsumerMessage
+func createStructuredConsumerMessage(t *testing.T, consumerMessage *sarama.ConsumerMessage) *sarama.ConsumerMessage {
+	cloudEvent, err := binding.ToEvent(context.TODO(), kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage))
+	assert.Nil(t, err)
+	value, err := format.JSON.Marshal(cloudEvent)
+	assert.Nil(t, err)
+	structuredMessage := *consumerMessage
+	structuredMessage.Headers = []*sarama.RecordHeader{{Key: []byte("content-type"), Value: []byte(event.ApplicationCloudEventsJSON)}}
+	structuredMessage.Value = value
+	assert.Equal(t, binding.EncodingStructured, kafkasaramaprotocol.NewMessageFromConsumerMessage(&structuredMessage).ReadEncoding())
+	return &structuredMessage
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+)
+
+//
+// Logger Of A ConsumerGroup's Errors Which Samples Repeated Identical Errors
+//
+// Sarama only reports errors via the ConsumerGroup's Errors channel on a best-effort basis - once the channel's buffer
+// (Consumer.Return.Errors / ChannelBufferSize) is full further errors are silently discarded, so the goroutine draining
+// it must keep up with bursts (e.g. every partition failing to fetch during a broker outage).  Sampling the logging of
+// repeated identical errors (same message) bounds the time spent per error, with the number dropped being recorded
+// via the StatsReporter and logged as a roll-up at most once per interval (as further errors occur) and when the
+// ConsumerGroup's error processing terminates.  Only the logging is sampled - every error is otherwise still handled.
+//
+type groupErrorLog struct {
+	logger        *zap.Logger // Unsampled Logger (Roll-Ups)
+	sampledLogger *zap.Logger // Sampled Logger (Errors - Unsampled If No Sampling Is Configured)
+	interval      time.Duration
+	suppressed    int64     // Number Of Error Logs Dropped By The Sampling Since The Last Roll-Up (Atomic)
+	lastRollUp    time.Time // Time Of The Last Roll-Up Of Suppressed Error Logs (Only Accessed By The Error Processing)
+}
+
+// groupErrorLog Constructor (Every Error Is Logged If The Sampling Is Nil)
+func newGroupErrorLog(logger *zap.Logger, sampling *ErrorLogSampling, topic string, statsReporter metrics.StatsReporter) *groupErrorLog {
+	errorLog := &groupErrorLog{logger: logger, sampledLogger: logger, lastRollUp: now()}
+	if sampling != nil {
+		errorLog.interval = sampling.Interval
+		errorLog.sampledLogger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, sampling.Interval, sampling.First, sampling.Thereafter,
+				zapcore.SamplerHook(func(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+					if decision&zapcore.LogDropped > 0 {
+						atomic.AddInt64(&errorLog.suppressed, 1)
+						if statsReporter != nil {
+							statsReporter.ReportGroupErrorLogDrop(topic)
+						}
+					}
+				}))
+		}))
+	}
+	return errorLog
+}
+
+// Log The Specified ConsumerGroup Error Via The Sampler & Periodically Roll-Up The Number Of Suppressed Errors
+func (l *groupErrorLog) log(err error) {
+	l.sampledLogger.Error("ConsumerGroup Error", zap.Error(err))
+	if l.interval > 0 && now().Sub(l.lastRollUp) >= l.interval {
+		l.lastRollUp = now()
+		l.flush()
+	}
+}
+
+// Log The Number Of Errors Suppressed Since The Last Roll-Up (If Any)
+func (l *groupErrorLog) flush() {
+	if suppressed := atomic.SwapInt64(&l.suppressed, 0); suppressed > 0 {
+		l.logger.Warn("Suppressed Repeated ConsumerGroup Error Logs", zap.Int64("Suppressed", suppressed), zap.Duration("Interval", l.interval))
+	}
+}
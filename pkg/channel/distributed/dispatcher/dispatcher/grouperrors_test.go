@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test That The groupErrorLog Bounds The Volume Of Logs Written During A Burst Of ConsumerGroup Errors
+func TestGroupErrorLog(t *testing.T) {
+
+	// Mock The Current Time
+	mockTime := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockTime }
+	t.Cleanup(func() { now = time.Now })
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		sampling      *ErrorLogSampling
+		wantLogged    int
+		wantDropped   int64
+		wantRolledUp  int
+		wantFlushedUp int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unsampled", sampling: nil, wantLogged: 100, wantDropped: 0, wantRolledUp: 0, wantFlushedUp: 0},
+		{name: "Sampled", sampling: &ErrorLogSampling{Interval: time.Hour, First: 1, Thereafter: 10}, wantLogged: 1 + 9, wantDropped: 90, wantRolledUp: 1, wantFlushedUp: 1},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A groupErrorLog Writing To A Buffer
+			logger, buffer := newBufferLogger()
+			statsReporter := dispatchertesting.NewMockStatsReporter()
+			errorLog := newGroupErrorLog(logger, testCase.sampling, testTopic, statsReporter)
+
+			// Perform The Test - A Burst Of 99 ConsumerGroup Errors Within The Interval
+			for i := 0; i < 99; i++ {
+				errorLog.log(sarama.ErrOutOfBrokers)
+			}
+			assert.Equal(t, 0, countLogs(buffer, "Suppressed Repeated ConsumerGroup Error Logs"))
+
+			// Verify The Suppressed Errors Are Rolled-Up Once The Interval Has Elapsed
+			mockTime = mockTime.Add(time.Hour)
+			errorLog.log(sarama.ErrOutOfBrokers)
+			assert.Equal(t, testCase.wantLogged, countLogs(buffer, "ConsumerGroup Error"))
+			assert.Equal(t, testCase.wantRolledUp, countLogs(buffer, "Suppressed Repeated ConsumerGroup Error Logs"))
+			assert.Equal(t, testCase.wantDropped, statsReporter.GroupErrorLogDrops())
+
+			// Verify Errors Suppressed Since The Roll-Up Are Logged When Flushed (And Only Once)
+			for i := 0; i < 20; i++ {
+				errorLog.log(sarama.ErrOutOfBrokers)
+			}
+			errorLog.flush()
+			errorLog.flush()
+			assert.Equal(t, testCase.wantRolledUp+testCase.wantFlushedUp, countLogs(buffer, "Suppressed Repeated ConsumerGroup Error Logs"))
+		})
+	}
+}
+
+// Test That Flooding A Subscriber's ConsumerGroup Errors Channel Does Not Block The Dispatcher's Error Processing
+func TestGroupErrorFlood(t *testing.T) {
+
+	// Test Data
+	subscriberSpec := eventingduck.SubscriberSpec{UID: uid123}
+	errorCount := 10000
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	consumerGroup := kafkatesting.NewMockConsumerGroup(t)
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return consumerGroup, nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A New DispatcherImpl To Test With A Single Subscriber & Sampled ConsumerGroup Error Logs (Awaiting The
+	// Error Processing, Which Logs Its Roll-Up Once The ConsumerGroup Is Closed, On Shutdown)
+	statsReporter := dispatchertesting.NewMockStatsReporter()
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:                logtesting.TestLogger(t).Desugar(),
+		Topic:                 testTopic,
+		StatsReporter:         statsReporter,
+		SaramaConfig:          getSaramaConfigFromYaml(t, TestConfigBase),
+		GroupErrorLogSampling: &ErrorLogSampling{Interval: time.Minute, First: 1, Thereafter: 1000},
+		ShutdownTimeout:       5 * time.Second, // Await Consume Loop & Error Processing So They Do Not Outlive The Test
+	}).(*DispatcherImpl)
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriberSpec})
+	assert.Len(t, failedSubscriptions, 0)
+
+	// Perform The Test - Flood The (Unbuffered) Errors Channel, Each Send Blocking Until The Error Is Received
+	flooded := make(chan struct{})
+	go func() {
+		for i := 0; i < errorCount; i++ {
+			consumerGroup.SendError(errors.New("test consumer group error"))
+		}
+		close(flooded)
+	}()
+
+	// Verify Every Error Was Received Without Blocking & The Dropped Logs Were Reported
+	select {
+	case <-flooded:
+	case <-time.After(10 * time.Second):
+		t.Fatal("ConsumerGroup Error Processing Blocked")
+	}
+	assert.Eventually(t, func() bool {
+		return statsReporter.GroupErrorLogDrops() == int64(errorCount-1-(errorCount-1)/1000)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Verify The Subscriber Continues Consuming
+	assert.False(t, consumerGroup.Closed)
+	subscriber := dispatcher.subscribers[subscriberSpec.UID]
+	assert.NotNil(t, subscriber)
+
+	// Shutdown The Dispatcher to Cleanup Resources & Verify The Error Processing Terminated Before It Returned
+	dispatcher.Shutdown(context.TODO())
+	assert.True(t, consumerGroup.Closed)
+	select {
+	case <-subscriber.errorsDone:
+	default:
+		assert.Fail(t, "ConsumerGroup error processing outlived the Dispatcher's Shutdown()")
+	}
+}
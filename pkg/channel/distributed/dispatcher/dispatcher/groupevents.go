@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+//
+// Recorder Of ConsumerGroup Lifecycle Transitions As Kubernetes Events On The KafkaChannel
+//
+// The transitions (joining the group, partitions being assigned & revoked, and leaving the group) aid in debugging
+// re-balance issues via "kubectl describe".  A re-balance storm could otherwise flood the KafkaChannel with Events
+// (and exhaust the Kubernetes EventRecorder's own per-object spam filter, hiding the reconciler's Events), so at
+// most one Event of each reason is recorded per ConsumerGroup within the interval.  Events are only recorded once
+// the KafkaChannel has been reconciled (which provides the EventRecorder & the KafkaChannel to record them on),
+// and are disabled entirely if the interval is zero.
+//
+type consumerGroupEventRecorder struct {
+	lock       sync.Mutex
+	interval   time.Duration
+	recorder   record.EventRecorder
+	object     runtime.Object
+	lastEvents map[string]time.Time // Time Of The Last Recorded Event Keyed By GroupId & Reason
+}
+
+// consumerGroupEventRecorder Constructor
+func newConsumerGroupEventRecorder(interval time.Duration) *consumerGroupEventRecorder {
+	return &consumerGroupEventRecorder{interval: interval, lastEvents: make(map[string]time.Time)}
+}
+
+// Update The EventRecorder & The Object (KafkaChannel) On Which Subsequent Events Are Recorded
+func (r *consumerGroupEventRecorder) update(recorder record.EventRecorder, object runtime.Object) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.recorder = recorder
+	r.object = object
+}
+
+// Record A ConsumerGroup Transition Event (Unless Disabled Or An Event With The Same Reason Was Recently Recorded)
+func (r *consumerGroupEventRecorder) event(groupId string, reason string, messageFmt string, args ...interface{}) {
+
+	// Nil Safe To Simplify Handlers Created Without A Recorder
+	if r == nil || r.interval <= 0 {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Events Cannot Be Recorded Until The KafkaChannel Has Been Reconciled
+	if r.recorder == nil || r.object == nil {
+		return
+	}
+
+	// Suppress Events Recorded Too Soon After The Last Of The Same Reason For The ConsumerGroup
+	key := groupId + "/" + reason
+	currentTime := now()
+	if lastEvent, ok := r.lastEvents[key]; ok && currentTime.Sub(lastEvent) < r.interval {
+		return
+	}
+	r.lastEvents[key] = currentTime
+
+	r.recorder.Eventf(r.object, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// Forget The Rate Limiting State Of The Specified ConsumerGroup (e.g. When The Subscriber Is Removed)
+func (r *consumerGroupEventRecorder) forget(groupId string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for key := range r.lastEvents {
+		if strings.HasPrefix(key, groupId+"/") {
+			delete(r.lastEvents, key)
+		}
+	}
+}
+
+// Format The Specified ConsumerGroupSession Claims For Inclusion In An Event (e.g. "topic-a[0,1,2]")
+func formatClaims(claims map[string][]int32) string {
+	topics := make([]string, 0, len(claims))
+	for topic := range claims {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	formattedClaims := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		partitions := append([]int32(nil), claims[topic]...)
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+		formattedPartitions := make([]string, 0, len(partitions))
+		for _, partition := range partitions {
+			formattedPartitions = append(formattedPartitions, fmt.Sprint(partition))
+		}
+		formattedClaims = append(formattedClaims, fmt.Sprintf("%s[%s]", topic, strings.Join(formattedPartitions, ",")))
+	}
+	return strings.Join(formattedClaims, " ")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testGroupId       = "kafka." + id123
+	testEventInterval = time.Minute
+)
+
+// Test The consumerGroupEventRecorder's Enablement & Rate Limiting
+func TestConsumerGroupEventRecorder(t *testing.T) {
+
+	// Mock The Current Time
+	mockTime := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockTime }
+	t.Cleanup(func() { now = time.Now })
+
+	// A Nil Recorder Is Safe To Use
+	var nilRecorder *consumerGroupEventRecorder
+	nilRecorder.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "joined")
+	nilRecorder.forget(testGroupId)
+
+	// Events Are Not Recorded When Disabled
+	fakeRecorder := record.NewFakeRecorder(10)
+	disabledRecorder := newConsumerGroupEventRecorder(0)
+	disabledRecorder.update(fakeRecorder, newTestKafkaChannel())
+	disabledRecorder.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "joined")
+	assert.Len(t, fakeRecorder.Events, 0)
+
+	// Events Are Not Recorded Until The KafkaChannel Has Been Reconciled
+	groupEvents := newConsumerGroupEventRecorder(testEventInterval)
+	groupEvents.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "joined")
+	assert.Len(t, fakeRecorder.Events, 0)
+
+	// Events Are Recorded Once The Recorder & KafkaChannel Are Known
+	groupEvents.update(fakeRecorder, newTestKafkaChannel())
+	groupEvents.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "ConsumerGroup %s joined", testGroupId)
+	assert.Equal(t, "Normal ConsumerGroupJoined ConsumerGroup "+testGroupId+" joined", <-fakeRecorder.Events)
+
+	// Events Of The Same Reason & ConsumerGroup Are Suppressed Within The Interval
+	mockTime = mockTime.Add(testEventInterval - time.Second)
+	groupEvents.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "rejoined")
+	assert.Len(t, fakeRecorder.Events, 0)
+
+	// Events Of Other Reasons Or ConsumerGroups Are Not Suppressed
+	groupEvents.event(testGroupId, constants.PartitionsAssignedEventReason, "assigned")
+	assert.Equal(t, "Normal PartitionsAssigned assigned", <-fakeRecorder.Events)
+	groupEvents.event("kafka."+id456, constants.ConsumerGroupJoinedEventReason, "other")
+	assert.Equal(t, "Normal ConsumerGroupJoined other", <-fakeRecorder.Events)
+
+	// Events Are Recorded Again Once The Interval Has Elapsed
+	mockTime = mockTime.Add(time.Second)
+	groupEvents.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "rejoined")
+	assert.Equal(t, "Normal ConsumerGroupJoined rejoined", <-fakeRecorder.Events)
+
+	// Forgetting A ConsumerGroup Resets Its Rate Limiting
+	groupEvents.forget(testGroupId)
+	groupEvents.event(testGroupId, constants.ConsumerGroupJoinedEventReason, "joined again")
+	assert.Equal(t, "Normal ConsumerGroupJoined joined again", <-fakeRecorder.Events)
+	groupEvents.event("kafka."+id456, constants.ConsumerGroupJoinedEventReason, "other")
+	assert.Len(t, fakeRecorder.Events, 0)
+}
+
+// Test That The Handler's Setup() & Cleanup() Record The Corresponding ConsumerGroup Events
+func TestHandlerConsumerGroupEvents(t *testing.T) {
+
+	// Create A Handler Recording ConsumerGroup Events
+	fakeRecorder := record.NewFakeRecorder(10)
+	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
+	handler.groupId = testGroupId
+	handler.groupEvents = newConsumerGroupEventRecorder(testEventInterval)
+	handler.groupEvents.update(fakeRecorder, newTestKafkaChannel())
+
+	// Create A ConsumerGroupSession With Claimed Partitions
+	session := dispatchertesting.NewMockConsumerGroupSession(t)
+	session.ClaimedPartitions = map[string][]int32{testTopic: {2, 0, 1}}
+	session.Member = "member-1"
+	session.Generation = 3
+
+	// Verify The Joined & Assigned Events Are Recorded By Setup()
+	assert.Nil(t, handler.Setup(session))
+	assert.Equal(t, "Normal ConsumerGroupJoined ConsumerGroup "+testGroupId+" joined as member member-1 (generation 3)", <-fakeRecorder.Events)
+	assert.Equal(t, "Normal PartitionsAssigned ConsumerGroup "+testGroupId+" assigned partitions "+testTopic+"[0,1,2]", <-fakeRecorder.Events)
+
+	// Verify The Revoked Event Is Recorded By Cleanup()
+	assert.Nil(t, handler.Cleanup(session))
+	assert.Equal(t, "Normal PartitionsRevoked ConsumerGroup "+testGroupId+" revoked partitions "+testTopic+"[0,1,2]", <-fakeRecorder.Events)
+	assert.Len(t, fakeRecorder.Events, 0)
+}
+
+// Test That The Dispatcher Records The Left Event When A Subscriber's Consumption Stops
+func TestDispatcherConsumerGroupLeftEvent(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A New DispatcherImpl Recording ConsumerGroup Events On The KafkaChannel
+	fakeRecorder := record.NewFakeRecorder(10)
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:                     logtesting.TestLogger(t).Desugar(),
+		Topic:                      testTopic,
+		SaramaConfig:               getSaramaConfigFromYaml(t, TestConfigBase),
+		ShutdownTimeout:            5 * time.Second,
+		ConsumerGroupEventInterval: testEventInterval,
+	}).(*DispatcherImpl)
+	dispatcher.UpdateEventRecorder(fakeRecorder, newTestKafkaChannel())
+
+	// Add & Then Remove A Subscriber
+	assert.Len(t, updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{{UID: uid123}}), 0)
+	assert.Len(t, updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{}), 0)
+
+	// Verify The Left Event Was Recorded
+	assert.Equal(t, "Normal ConsumerGroupLeft ConsumerGroup "+testGroupId+" left", <-fakeRecorder.Events)
+	dispatcher.Shutdown(context.TODO())
+}
+
+// Utility Function For Creating The KafkaChannel On Which ConsumerGroup Events Are Recorded
+func newTestKafkaChannel() *kafkav1beta1.KafkaChannel {
+	return &kafkav1beta1.KafkaChannel{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kafkav1beta1.SchemeGroupVersion.String(), Kind: "KafkaChannel"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "test-channel"},
+	}
+}
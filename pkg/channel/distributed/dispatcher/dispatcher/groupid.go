@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+)
+
+//
+// Determine The ConsumerGroup ID Of Each Of The Specified Subscribers According To The GroupIdPolicy
+//
+// By default each subscriber's ConsumerGroup is identified by its (Subscription) UID, so a resubscription which
+// changes the UID results in a brand-new ConsumerGroup and a full replay of the Topic.  The "stable" policy instead
+// derives the ID from the Topic, the subscriber & reply URIs and the configured GroupIdKey, so that the committed
+// offsets survive UID churn for what is logically the same subscriber.  Distinct subscribers sharing a stable ID
+// would otherwise silently split the Topic's messages between them, so all but the first are returned as failures.
+//
+// UIDs are expected to be Kubernetes UUIDs, but any characters other than ASCII alphanumerics, '.', '_' and '-' are
+// replaced by an '_' (or the subscriber is returned as a failure with the "reject" GroupIdSanitizationPolicy) so that
+// the ConsumerGroup ID remains usable with Kafka tooling and ACLs.  Distinct UIDs which sanitize to the same ID are
+// returned as failures in the same way as conflicting stable IDs, rather than sharing a ConsumerGroup.
+//
+func (d *DispatcherImpl) assignGroupIds(subscriberSpecs []eventingduck.SubscriberSpec) ([]eventingduck.SubscriberSpec, map[types.UID]string, map[eventingduck.SubscriberSpec]error) {
+
+	assignedSubscriberSpecs := make([]eventingduck.SubscriberSpec, 0, len(subscriberSpecs))
+	groupIds := make(map[types.UID]string)
+	groupIdOwners := make(map[string]types.UID)
+	conflictingSubscriberSpecs := make(map[eventingduck.SubscriberSpec]error)
+
+	for _, subscriberSpec := range subscriberSpecs {
+
+		// Determine The GroupId For The Subscriber
+		var groupId string
+		if d.GroupIdPolicy == constants.GroupIdPolicyStable {
+			groupId = stableGroupId(d.Topic, subscriberSpec, d.GroupIdKey)
+		} else {
+			var err error
+			groupId, err = uidGroupId(subscriberSpec.UID, d.GroupIdSanitizationPolicy)
+			if err != nil {
+				d.Logger.Warn("Discarding SubscriberSpec With Invalid UID", zap.String("UID", string(subscriberSpec.UID)), zap.Error(err))
+				conflictingSubscriberSpecs[subscriberSpec] = err
+				continue
+			}
+		}
+
+		// Reject Subscribers Whose GroupId Is Already Used By Another Subscriber
+		if ownerUID, ok := groupIdOwners[groupId]; ok {
+			kind := "sanitized"
+			if d.GroupIdPolicy == constants.GroupIdPolicyStable {
+				kind = "stable"
+			}
+			d.Logger.Warn("Discarding SubscriberSpec With Conflicting GroupId", zap.String("UID", string(subscriberSpec.UID)), zap.String("GroupId", groupId), zap.String("OwnerUID", string(ownerUID)))
+			conflictingSubscriberSpecs[subscriberSpec] = fmt.Errorf("subscriber UID '%s' has the same %s consumer group ID '%s' as subscriber UID '%s'", subscriberSpec.UID, kind, groupId, ownerUID)
+			continue
+		}
+
+		// Track The GroupId Of The Subscriber
+		groupIdOwners[groupId] = subscriberSpec.UID
+		groupIds[subscriberSpec.UID] = groupId
+		assignedSubscriberSpecs = append(assignedSubscriberSpecs, subscriberSpec)
+	}
+
+	return assignedSubscriberSpecs, groupIds, conflictingSubscriberSpecs
+}
+
+// Get The (Sorted) IDs Of The ConsumerGroups Of The Current Subscribers
+func (d *DispatcherImpl) ConsumerGroupIds() []string {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	groupIds := make([]string, 0, len(d.subscribers))
+	for _, subscriber := range d.subscribers {
+		groupIds = append(groupIds, subscriber.GroupId)
+	}
+	sort.Strings(groupIds)
+	return groupIds
+}
+
+// Get The ConsumerGroup ID For The Specified Subscription UID, Replacing (Or Rejecting) Any Illegal Characters Per The Policy
+func uidGroupId(uid types.UID, policy string) (string, error) {
+	if len(uid) == 0 {
+		return "", fmt.Errorf("subscriber UID is empty")
+	}
+	sanitized := strings.Map(func(r rune) rune {
+		if isLegalGroupIdRune(r) {
+			return r
+		}
+		return '_'
+	}, string(uid))
+	if sanitized != string(uid) && policy == constants.GroupIdSanitizationPolicyReject {
+		return "", fmt.Errorf("subscriber UID '%s' contains characters other than ASCII alphanumerics, '.', '_' and '-'", uid)
+	}
+	return fmt.Sprintf("kafka.%s", sanitized), nil
+}
+
+// Determine Whether The Specified Rune Is Legal In A ConsumerGroup ID (The Same Characters As Are Legal In Topic Names)
+func isLegalGroupIdRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-'
+}
+
+// Get The Stable (UID Independent) ConsumerGroup ID For The Specified Subscriber Of The Specified Topic
+func stableGroupId(topic string, subscriberSpec eventingduck.SubscriberSpec, key string) string {
+	hash := sha256.New()
+	for _, component := range []string{topic, urlString(subscriberSpec.SubscriberURI), urlString(subscriberSpec.ReplyURI), key} {
+		_, _ = hash.Write([]byte(component))
+		_, _ = hash.Write([]byte{0}) // Separator Preventing Ambiguous Concatenations
+	}
+	return fmt.Sprintf("kafka.stable.%x", hash.Sum(nil)[:16])
+}
+
+// Get The String Representation Of The Specified (Optional) URL
+func urlString(url *apis.URL) string {
+	if url == nil {
+		return ""
+	}
+	return url.String()
+}
+
+// Validate The Specified GroupIdPolicy & Return It (Or The Default If Unspecified)
+func ParseGroupIdPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultGroupIdPolicy, nil
+	case constants.GroupIdPolicyUid, constants.GroupIdPolicyStable:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid group id policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.GroupIdPolicyUid, constants.GroupIdPolicyStable)
+	}
+}
+
+// Validate The Specified GroupIdSanitizationPolicy & Return It (Or The Default If Unspecified)
+func ParseGroupIdSanitizationPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultGroupIdSanitizationPolicy, nil
+	case constants.GroupIdSanitizationPolicyReplace, constants.GroupIdSanitizationPolicyReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid group id sanitization policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.GroupIdSanitizationPolicyReplace, constants.GroupIdSanitizationPolicyReject)
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ConsumerGroup IDs Used For A Logically Unchanged Subscriber Whose UID Changes
+func TestUpdateSubscriptionsGroupIdAcrossUidChanges(t *testing.T) {
+
+	// Test Data
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	originalSubscriberSpec := eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: subscriberURI}
+	resubscribedSubscriberSpec := eventingduck.SubscriberSpec{UID: uid456, SubscriberURI: subscriberURI}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		policy        string
+		wantSameGroup bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Default Policy", policy: "", wantSameGroup: false},
+		{name: "UID Policy", policy: constants.GroupIdPolicyUid, wantSameGroup: false},
+		{name: "Stable Policy", policy: constants.GroupIdPolicyStable, wantSameGroup: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Replace The NewConsumerGroupWrapper With Mock Recording The GroupIds & Restore After TestCase
+			var groupIds []string
+			var groupIdsLock sync.Mutex
+			newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+			kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+				groupIdsLock.Lock()
+				groupIds = append(groupIds, groupIdArg)
+				groupIdsLock.Unlock()
+				return kafkatesting.NewMockConsumerGroup(t), nil
+			}
+			defer func() {
+				kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+			}()
+
+			// Create A New DispatcherImpl To Test
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:          logtesting.TestLogger(t).Desugar(),
+				Topic:           testTopic,
+				SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+				GroupIdPolicy:   testCase.policy,
+				GroupIdKey:      "TestKey",
+				ShutdownTimeout: time.Second, // Await Consume Loops & Error Processing Of Closed ConsumerGroups So They Do Not Outlive The Test
+			}).(*DispatcherImpl)
+
+			// Perform The Test - Subscribe & Then Resubscribe With A New UID
+			failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{originalSubscriberSpec})
+			assert.Len(t, failedSubscriptions, 0)
+			failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{resubscribedSubscriberSpec})
+			assert.Len(t, failedSubscriptions, 0)
+
+			// Verify The Resubscribed Subscriber Replaced The Original With The Expected GroupId
+			assert.Len(t, dispatcher.subscribers, 1)
+			assert.NotNil(t, dispatcher.subscribers[uid456])
+			groupIdsLock.Lock()
+			assert.Len(t, groupIds, 2)
+			assert.Equal(t, testCase.wantSameGroup, groupIds[0] == groupIds[1])
+			groupIdsLock.Unlock()
+			assert.Equal(t, groupIds[1], dispatcher.subscribers[uid456].GroupId)
+			if !testCase.wantSameGroup {
+				assert.Equal(t, "kafka."+string(uid456), dispatcher.subscribers[uid456].GroupId)
+			}
+
+			// Shutdown The Dispatcher to Cleanup Resources
+			dispatcher.Shutdown(context.TODO())
+		})
+	}
+}
+
+// Test Subscribers Sharing A Stable ConsumerGroup ID Are Reported As Failed
+func TestUpdateSubscriptionsStableGroupIdConflict(t *testing.T) {
+
+	// Test Data
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	firstSubscriberSpec := eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: subscriberURI}
+	conflictingSubscriberSpec := eventingduck.SubscriberSpec{UID: uid456, SubscriberURI: subscriberURI}
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:          logtesting.TestLogger(t).Desugar(),
+		Topic:           testTopic,
+		SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+		GroupIdPolicy:   constants.GroupIdPolicyStable,
+		ShutdownTimeout: time.Second, // Await Consume Loops & Error Processing Of Closed ConsumerGroups So They Do Not Outlive The Test
+	}).(*DispatcherImpl)
+
+	// Perform The Test
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{firstSubscriberSpec, conflictingSubscriberSpec})
+
+	// Verify The Conflicting Subscriber Is Reported As Failed & The First Is Active
+	assert.Len(t, failedSubscriptions, 1)
+	assert.NotNil(t, failedSubscriptions[conflictingSubscriberSpec])
+	assert.Contains(t, failedSubscriptions[conflictingSubscriberSpec].Error(), "same stable consumer group ID")
+	assert.Len(t, dispatcher.subscribers, 1)
+	assert.NotNil(t, dispatcher.subscribers[uid123])
+
+	// Shutdown The Dispatcher to Cleanup Resources
+	dispatcher.Shutdown(context.TODO())
+}
+
+// Test The stableGroupId() Functionality
+func TestStableGroupId(t *testing.T) {
+
+	// Test Data
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	otherSubscriberURI, err := apis.ParseURL("http://other.example.com")
+	assert.Nil(t, err)
+	replyURI, err := apis.ParseURL("http://reply.example.com")
+	assert.Nil(t, err)
+	subscriberSpec := eventingduck.SubscriberSpec{UID: uid123, Generation: 1, SubscriberURI: subscriberURI}
+	baseGroupId := stableGroupId(testTopic, subscriberSpec, "TestKey")
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		topic          string
+		subscriberSpec eventingduck.SubscriberSpec
+		key            string
+		wantSame       bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Same Subscriber", topic: testTopic, subscriberSpec: subscriberSpec, key: "TestKey", wantSame: true},
+		{name: "Changed UID & Generation", topic: testTopic, subscriberSpec: eventingduck.SubscriberSpec{UID: types.UID("changed"), Generation: 2, SubscriberURI: subscriberURI}, key: "TestKey", wantSame: true},
+		{name: "Different Key", topic: testTopic, subscriberSpec: subscriberSpec, key: "OtherKey", wantSame: false},
+		{name: "Different Topic", topic: "OtherTopic", subscriberSpec: subscriberSpec, key: "TestKey", wantSame: false},
+		{name: "Different Subscriber URI", topic: testTopic, subscriberSpec: eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: otherSubscriberURI}, key: "TestKey", wantSame: false},
+		{name: "Added Reply URI", topic: testTopic, subscriberSpec: eventingduck.SubscriberSpec{UID: uid123, SubscriberURI: subscriberURI, ReplyURI: replyURI}, key: "TestKey", wantSame: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			groupId := stableGroupId(testCase.topic, testCase.subscriberSpec, testCase.key)
+			assert.Regexp(t, "^kafka\\.stable\\.[0-9a-f]{32}$", groupId)
+			assert.Equal(t, testCase.wantSame, groupId == baseGroupId)
+		})
+	}
+}
+
+// Test The uidGroupId() Functionality
+func TestUidGroupId(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		uid     types.UID
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Kubernetes UUID", uid: "0f4d5b1c-6f3e-4b8a-9d2e-1a2b3c4d5e6f", policy: constants.GroupIdSanitizationPolicyReplace, want: "kafka.0f4d5b1c-6f3e-4b8a-9d2e-1a2b3c4d5e6f"},
+		{name: "Legal Punctuation", uid: "A.b_C-9", policy: constants.GroupIdSanitizationPolicyReject, want: "kafka.A.b_C-9"},
+		{name: "Replace Slash & Space", uid: "a/b c", policy: constants.GroupIdSanitizationPolicyReplace, want: "kafka.a_b_c"},
+		{name: "Replace Control Characters", uid: "a\nb\x00c", policy: constants.GroupIdSanitizationPolicyReplace, want: "kafka.a_b_c"},
+		{name: "Replace Multi-Byte Unicode", uid: "ü名", policy: constants.GroupIdSanitizationPolicyReplace, want: "kafka.__"},
+		{name: "Unspecified Policy Replaces", uid: "a:b", policy: "", want: "kafka.a_b"},
+		{name: "Reject Slash", uid: "a/b", policy: constants.GroupIdSanitizationPolicyReject, wantErr: true},
+		{name: "Reject Unicode", uid: "ü", policy: constants.GroupIdSanitizationPolicyReject, wantErr: true},
+		{name: "Empty UID", uid: "", policy: constants.GroupIdSanitizationPolicyReplace, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			groupId, err := uidGroupId(testCase.uid, testCase.policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.want, groupId)
+			if !testCase.wantErr {
+				assert.Regexp(t, "^kafka\\.[a-zA-Z0-9._-]+$", groupId)
+			}
+		})
+	}
+}
+
+// Test The UpdateSubscriptions() Functionality With UIDs Which Sanitize To The Same ConsumerGroup ID
+func TestUpdateSubscriptionsSanitizedGroupIdConflict(t *testing.T) {
+
+	// Test Data
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	firstSubscriberSpec := eventingduck.SubscriberSpec{UID: "a/b", SubscriberURI: subscriberURI}
+	conflictingSubscriberSpec := eventingduck.SubscriberSpec{UID: "a:b", SubscriberURI: subscriberURI}
+	distinctSubscriberSpec := eventingduck.SubscriberSpec{UID: "a_c", SubscriberURI: subscriberURI}
+	emptySubscriberSpec := eventingduck.SubscriberSpec{UID: "", SubscriberURI: subscriberURI}
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	var groupIdsLock sync.Mutex
+	var groupIds []string
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		groupIdsLock.Lock()
+		defer groupIdsLock.Unlock()
+		groupIds = append(groupIds, groupIdArg)
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:          logtesting.TestLogger(t).Desugar(),
+		Topic:           testTopic,
+		SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+		ShutdownTimeout: time.Second, // Await Consume Loops & Error Processing Of Closed ConsumerGroups So They Do Not Outlive The Test
+	}).(*DispatcherImpl)
+
+	// Perform The Test
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{firstSubscriberSpec, conflictingSubscriberSpec, distinctSubscriberSpec, emptySubscriberSpec})
+
+	// Verify The Conflicting & Empty Subscribers Are Reported As Failed & The Others Have Distinct Safe GroupIds
+	assert.Len(t, failedSubscriptions, 2)
+	assert.Contains(t, failedSubscriptions[conflictingSubscriberSpec].Error(), "same sanitized consumer group ID 'kafka.a_b'")
+	assert.Contains(t, failedSubscriptions[emptySubscriberSpec].Error(), "subscriber UID is empty")
+	assert.Len(t, dispatcher.subscribers, 2)
+	groupIdsLock.Lock()
+	assert.ElementsMatch(t, []string{"kafka.a_b", "kafka.a_c"}, groupIds)
+	groupIdsLock.Unlock()
+
+	// Shutdown The Dispatcher to Cleanup Resources
+	dispatcher.Shutdown(context.TODO())
+}
+
+// Test The ConsumerGroupIds() Functionality
+func TestConsumerGroupIds(t *testing.T) {
+	dispatcher := &DispatcherImpl{
+		subscribers: map[types.UID]*SubscriberWrapper{
+			uid456: NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid456}, "kafka."+string(uid456), nil),
+			uid123: NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid123}, "kafka."+string(uid123), nil),
+		},
+	}
+	assert.Equal(t, []string{"kafka." + string(uid123), "kafka." + string(uid456)}, dispatcher.ConsumerGroupIds())
+	assert.Empty(t, (&DispatcherImpl{}).ConsumerGroupIds())
+}
+
+// Test The ParseGroupIdPolicy() Functionality
+func TestParseGroupIdPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultGroupIdPolicy},
+		{name: "UID", policy: constants.GroupIdPolicyUid, want: constants.GroupIdPolicyUid},
+		{name: "Stable", policy: constants.GroupIdPolicyStable, want: constants.GroupIdPolicyStable},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseGroupIdPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ParseGroupIdSanitizationPolicy() Functionality
+func TestParseGroupIdSanitizationPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultGroupIdSanitizationPolicy},
+		{name: "Replace", policy: constants.GroupIdSanitizationPolicyReplace, want: constants.GroupIdSanitizationPolicyReplace},
+		{name: "Reject", policy: constants.GroupIdSanitizationPolicyReject, want: constants.GroupIdSanitizationPolicyReject},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseGroupIdSanitizationPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+//
+// Update The Delivery Guarantee From The Specified KafkaChannel DeliveryGuarantee Annotation
+//
+// The guarantee determines the isolation level and offset commits of the subscribers' ConsumerGroups, so changing it
+// closes the existing ConsumerGroups in order for the next UpdateSubscriptions() to recreate them (resuming from their
+// committed offsets).  Guarantees which never lose events are incompatible with subscribers which proceed past
+// messages which could not be delivered (the "progress" HeadOfLinePolicy), so the HeadOfLinePolicies must be updated
+// first.  An invalid or incompatible annotation is returned as an error, in which case the previous guarantee is retained.
+//
+func (d *DispatcherImpl) UpdateDeliveryGuarantee(annotation string) error {
+
+	// Validate The Delivery Guarantee Against The Sarama Configuration
+	settings, err := delivery.GetSettings(annotation)
+	if err == nil {
+		err = delivery.Validate(d.SaramaConfig, annotation)
+	}
+	if err != nil {
+		d.Logger.Error("Invalid Delivery Guarantee", zap.Error(err))
+		return err
+	}
+
+	// Validate The Delivery Guarantee Against The Subscribers' HeadOfLinePolicies
+	if settings != nil && settings.CommitMode == delivery.CommitModeAfterDelivery {
+		for subscriber, policy := range d.currentHeadOfLinePolicies() {
+			if policy == constants.HeadOfLinePolicyProgress {
+				err = fmt.Errorf("%s delivery is incompatible with the '%s' HeadOfLinePolicy of subscriber %s, which proceeds past messages which could not be delivered", annotation, policy, subscriber)
+				d.Logger.Error("Incompatible Delivery Guarantee", zap.Error(err))
+				return err
+			}
+		}
+	}
+
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+
+	// Restart The ConsumerGroups Of Existing Subscribers With The New Guarantee
+	if annotation != d.deliveryGuarantee {
+		d.Logger.Info("Delivery Guarantee Changed - Restarting ConsumerGroups", zap.String("Old", d.deliveryGuarantee), zap.String("New", annotation))
+		d.deliveryGuarantee = annotation
+		for _, subscriber := range d.subscribers {
+			d.stopConsumerGroup(context.Background(), subscriber) // Failures Are Logged - Recreated Regardless
+			delete(d.subscribers, subscriber.UID)
+		}
+	}
+	return nil
+}
+
+// Get The Commit Mode Of The Current Delivery Guarantee (Empty If None, In Which Case The Sarama Configuration Applies)
+func (d *DispatcherImpl) commitMode() string {
+	settings, err := delivery.GetSettings(d.deliveryGuarantee)
+	if err != nil || settings == nil {
+		return ""
+	}
+	return settings.CommitMode
+}
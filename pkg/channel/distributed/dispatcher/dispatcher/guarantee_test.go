@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// Test That The UpdateDeliveryGuarantee() Functionality Wires The ConsumerGroups Per Each Delivery Guarantee
+func TestUpdateDeliveryGuarantee(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock Tracking The ConsumerGroup Configs & Restore After Test
+	var configsLock sync.Mutex
+	var consumerGroupConfigs []*sarama.Config
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		configsLock.Lock()
+		defer configsLock.Unlock()
+		consumerGroupConfigs = append(consumerGroupConfigs, configArg)
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Test Data
+	saramaConfig := getSaramaConfigFromYaml(t, TestConfigBase)
+	saramaConfig.Version = sarama.V2_0_0_0
+	saramaConfig.Producer.Retry.Max = 3
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = true
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:          zap.NewNop(), // ConsumerGroup Error Processing Of Restarted Subscribers May Log After The Test
+		SaramaConfig:    saramaConfig,
+		ShutdownTimeout: time.Second, // Await Consume Loops Of Restarted ConsumerGroups So They Do Not Outlive The Test
+	}).(*DispatcherImpl)
+	defer dispatcher.Shutdown(context.TODO())
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}}
+
+	// Update The Delivery Guarantee & Subscriptions, Returning The Config Of Any ConsumerGroup (Re)Created
+	updateDeliveryGuarantee := func(annotation string) (*sarama.Config, error) {
+		configsLock.Lock()
+		consumerGroupConfigs = nil
+		configsLock.Unlock()
+		err := dispatcher.UpdateDeliveryGuarantee(annotation)
+		assert.Empty(t, updateSubscriptions(t, dispatcher, subscriberSpecs))
+		configsLock.Lock()
+		defer configsLock.Unlock()
+		if len(consumerGroupConfigs) <= 0 {
+			return nil, err
+		}
+		assert.Len(t, consumerGroupConfigs, 1)
+		return consumerGroupConfigs[0], err
+	}
+
+	// Verify The ConsumerGroup Of A KafkaChannel Without A Delivery Guarantee Uses The Sarama Config As-Is
+	config, err := updateDeliveryGuarantee("")
+	assert.Nil(t, err)
+	assert.Equal(t, saramaConfig, config)
+	assert.Equal(t, "", dispatcher.commitMode())
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name               string
+		guarantee          string
+		wantIsolationLevel sarama.IsolationLevel
+		wantCommitMode     string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "At Most Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, wantIsolationLevel: sarama.ReadUncommitted, wantCommitMode: delivery.CommitModeBeforeDelivery},
+		{name: "At Least Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, wantIsolationLevel: sarama.ReadUncommitted, wantCommitMode: delivery.CommitModeAfterDelivery},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, wantIsolationLevel: sarama.ReadCommitted, wantCommitMode: delivery.CommitModeAfterDelivery},
+	}
+
+	// Verify Changing The Delivery Guarantee Recreates The ConsumerGroup With Its Settings
+	for _, testCase := range testCases {
+		config, err = updateDeliveryGuarantee(testCase.guarantee)
+		assert.Nil(t, err, testCase.name)
+		assert.NotNil(t, config, testCase.name)
+		assert.Equal(t, testCase.wantIsolationLevel, config.Consumer.IsolationLevel, testCase.name)
+		assert.False(t, config.Consumer.Offsets.AutoCommit.Enable, testCase.name)
+		assert.Equal(t, testCase.wantCommitMode, dispatcher.commitMode(), testCase.name)
+		assert.True(t, saramaConfig.Consumer.Offsets.AutoCommit.Enable, testCase.name)
+	}
+
+	// Verify An Unchanged Delivery Guarantee Does Not Recreate The ConsumerGroup
+	config, err = updateDeliveryGuarantee(kafkav1beta1.DeliveryGuaranteeEffectivelyOnce)
+	assert.Nil(t, err)
+	assert.Nil(t, config)
+
+	// Verify An Invalid Delivery Guarantee Is Rejected & The Previous Guarantee Retained
+	config, err = updateDeliveryGuarantee("exactly-once")
+	assert.NotNil(t, err)
+	assert.Nil(t, config)
+	assert.Equal(t, kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, dispatcher.deliveryGuarantee)
+
+	// Verify A Delivery Guarantee Incompatible With A Subscriber's "progress" HeadOfLinePolicy Is Rejected
+	assert.Nil(t, dispatcher.UpdateHeadOfLinePolicies(`{"`+id123+`":"progress"}`))
+	_, err = updateDeliveryGuarantee(kafkav1beta1.DeliveryGuaranteeAtLeastOnce)
+	assert.NotNil(t, err)
+	_, err = updateDeliveryGuarantee(kafkav1beta1.DeliveryGuaranteeAtMostOnce)
+	assert.Nil(t, err)
+	assert.Nil(t, dispatcher.UpdateHeadOfLinePolicies(""))
+
+	// Verify A Delivery Guarantee Incompatible With The Sarama Config Is Rejected
+	saramaConfig.Producer.Retry.Max = 0
+	_, err = updateDeliveryGuarantee(kafkav1beta1.DeliveryGuaranteeEffectivelyOnce)
+	assert.NotNil(t, err)
+	assert.Equal(t, kafkav1beta1.DeliveryGuaranteeAtMostOnce, dispatcher.deliveryGuarantee)
+}
@@ -19,14 +19,24 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/schemaregistry"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -35,22 +45,99 @@ import (
 // 3 Digit Word Boundary HTTP Status Code Regular Expression
 var HttpStatusCodeRegExp = regexp.MustCompile(`(^|\\s)([12345]\\d{2})(\\s|$)`)
 
+// Status Code Of An Unexpected HTTP Response In A Dispatch Error (The First Being The Subscriber's)
+var dispatchStatusCodeRegExp = regexp.MustCompile(`got ([1-5])\d{2}`)
+
+// Error Returned When A Message Which Could Not Be Deserialized Should Block Further Consumption Of Its Partition
+var ErrDeserializationFailure = errors.New("failed to deserialize message into a cloudevent")
+
+// Error Returned When A Message Could Not Be Delivered To The Subscriber Nor Sent To Its DeadLetterSink
+var ErrDeadLetterFailure = errors.New("failed to deliver message to subscriber or its dead letter sink")
+
 // Verify The Handler Implements The Sarama ConsumerGroupHandler
 var _ sarama.ConsumerGroupHandler = &Handler{}
 
 // Define A Sarama ConsumerGroupHandler Implementation
 type Handler struct {
-	Logger            *zap.Logger
-	Subscriber        *eventingduck.SubscriberSpec
-	MessageDispatcher channel.MessageDispatcher
+	Logger                       *zap.Logger
+	Subscriber                   *eventingduck.SubscriberSpec
+	MessageDispatcher            channel.MessageDispatcher
+	DeserializationFailurePolicy string
+	TombstonePolicy              string
+	StripProvenanceHeaders       bool
+	SchemaRegistryFraming        bool             // Strip The Schema Registry Wire Format Header From Message Values Before Deserializing
+	MaxMessageBytes              int64            // Maximum Size Of A Message Which Will Be Processed (Unlimited If Zero)
+	OversizedMessagePolicy       string           // Handling Of Messages Exceeding The MaxMessageBytes
+	ExtensionFilter              *ExtensionFilter // Optional Filter Of The CloudEvent Extensions Dispatched (All Are Dispatched If Nil)
+	StatsReporter                metrics.StatsReporter
+	ManualCommit                 bool                        // Mark Only Successfully Delivered Messages & Commit Explicitly (Auto-Commit Disabled)
+	ManualCommitInterval         time.Duration               // Interval Between Explicit Commits Of Marked Offsets While Consuming (Zero Commits After Every Message)
+	ManualCommitBatchSize        int                         // Number Of Marked Messages Which Triggers An Explicit Commit Before The Interval (Disabled If Zero)
+	CommitBeforeDelivery         bool                        // Mark & Commit Each Message Before Delivering It (Never Redelivered, Per The "at-most-once" Delivery Guarantee)
+	CommitOnRebalance            bool                        // Explicitly Commit The Marked Offsets When The Session's Partitions Are Revoked
+	OverloadPauseThreshold       int                         // Consecutive 429 Subscriber Responses Which Pause Consumption Of A Partition (Disabled If Zero)
+	OverloadPauseCooldown        time.Duration               // Duration For Which A Partition's Consumption Is Paused
+	RetryBudget                  time.Duration               // Maximum Time Spent Retrying Each Message With The "progress" HeadOfLinePolicy
+	RedeliveryInterval           time.Duration               // Initial Back-Off Before Redelivering A Message Which Blocks Its Partition (Default If Zero)
+	headOfLinePolicy             func() string               // Optional Lookup Of The Subscriber's Current HeadOfLinePolicy ("ordering" If Nil)
+	legacyBridge                 func() *LegacyBridge        // Optional Lookup Of The KafkaChannel's Current LegacyBridge (Records Not Wrapped If Nil)
+	sessionMonitor               *sessionMonitor             // Optional Tracking Of ConsumerGroup Session Liveness
+	groupId                      string                      // The ConsumerGroup's ID (Identifying Its Lifecycle Events)
+	groupEvents                  *consumerGroupEventRecorder // Optional Recording Of ConsumerGroup Lifecycle Events
+	deliveryErrors               *deliveryErrorLog           // Optional Sampling Of Repeated Delivery Error Logs
+	onRebalance                  func(groupId string)        // Optional Callback Invoked When The ConsumerGroup (Re)Joins
+	deliveryCtx                  context.Context             // Optional Context Of Deliveries, Cancelled To Abandon Them (Never Cancelled If Nil)
 }
 
 // Create A New Handler
-func NewHandler(logger *zap.Logger, subscriber *eventingduck.SubscriberSpec) *Handler {
+func NewHandler(logger *zap.Logger, subscriber *eventingduck.SubscriberSpec, deserializationFailurePolicy string, tombstonePolicy string, stripProvenanceHeaders bool, statsReporter metrics.StatsReporter) *Handler {
 	return &Handler{
-		Logger:            logger,
-		Subscriber:        subscriber,
-		MessageDispatcher: newMessageDispatcherWrapper(logger),
+		Logger:                       logger,
+		Subscriber:                   subscriber,
+		MessageDispatcher:            newMessageDispatcherWrapper(logger),
+		DeserializationFailurePolicy: deserializationFailurePolicy,
+		TombstonePolicy:              tombstonePolicy,
+		StripProvenanceHeaders:       stripProvenanceHeaders,
+		StatsReporter:                statsReporter,
+	}
+}
+
+// Validate The Specified DeserializationFailurePolicy & Return It (Or The Default If Unspecified)
+func ParseDeserializationFailurePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultDeserializationFailurePolicy, nil
+	case constants.DeserializationFailurePolicySkip, constants.DeserializationFailurePolicyDeadLetter, constants.DeserializationFailurePolicyFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid deserialization failure policy '%s' - must be one of '%s', '%s' or '%s'", policy,
+			constants.DeserializationFailurePolicySkip, constants.DeserializationFailurePolicyDeadLetter, constants.DeserializationFailurePolicyFail)
+	}
+}
+
+// Validate The Specified TombstonePolicy & Return It (Or The Default If Unspecified)
+func ParseTombstonePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultTombstonePolicy, nil
+	case constants.TombstonePolicySkip, constants.TombstonePolicyDispatch:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid tombstone policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.TombstonePolicySkip, constants.TombstonePolicyDispatch)
+	}
+}
+
+// Validate The Specified OversizedMessagePolicy & Return It (Or The Default If Unspecified)
+func ParseOversizedMessagePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultOversizedMessagePolicy, nil
+	case constants.OversizedMessagePolicySkip, constants.OversizedMessagePolicyDeadLetter:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid oversized message policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.OversizedMessagePolicySkip, constants.OversizedMessagePolicyDeadLetter)
 	}
 }
 
@@ -60,13 +147,33 @@ var newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispat
 }
 
 // ConsumerGroupHandler Lifecycle Method (Runs before any ConsumeClaims)
-func (h *Handler) Setup(_ sarama.ConsumerGroupSession) error {
-	return nil // Nothing To Do As Of Yet
+func (h *Handler) Setup(session sarama.ConsumerGroupSession) error {
+	if h.sessionMonitor != nil {
+		h.sessionMonitor.sessionStarted() // The Group Has Been (Re)Joined
+	}
+	if h.onRebalance != nil {
+		h.onRebalance(h.groupId)
+	}
+	if h.groupEvents != nil && session != nil {
+		h.groupEvents.event(h.groupId, constants.ConsumerGroupJoinedEventReason, "ConsumerGroup %s joined as member %s (generation %d)", h.groupId, session.MemberID(), session.GenerationID())
+		h.groupEvents.event(h.groupId, constants.PartitionsAssignedEventReason, "ConsumerGroup %s assigned partitions %s", h.groupId, formatClaims(session.Claims()))
+	}
+	return nil
 }
 
 // ConsumerGroupHandler Lifecycle Method (Runs after all ConsumeClaims stop but before final offset commit)
-func (h *Handler) Cleanup(_ sarama.ConsumerGroupSession) error {
-	return nil // Nothing To Do As Of Yet
+func (h *Handler) Cleanup(session sarama.ConsumerGroupSession) error {
+	if h.groupEvents != nil && session != nil {
+		h.groupEvents.event(h.groupId, constants.PartitionsRevokedEventReason, "ConsumerGroup %s revoked partitions %s", h.groupId, formatClaims(session.Claims()))
+	}
+
+	// Commit The Offsets Of All Messages Marked By The (Now Stopped) ConsumeClaims Before The Partitions Are Released So
+	// That Their New Owners Resume After Them, Rather Than Relying On Sarama's Final Best-Effort Flush Or The Next AutoCommit
+	if h.CommitOnRebalance && session != nil {
+		h.Logger.Debug("Committing Marked Offsets Before Releasing Partitions", zap.String("GroupId", h.groupId))
+		session.Commit()
+	}
+	return nil
 }
 
 // ConsumerGroupHandler Lifecycle Method (Main processing loop, must finish when claim.Messages() channel closes.)
@@ -107,18 +214,172 @@ func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama
 		}
 	}
 
+	// Monitor The Subscriber's Responses For Sustained Overload (If Enabled)
+	var overload *overloadMonitor
+	if h.OverloadPauseThreshold > 0 {
+		overload = newOverloadMonitor(h.Logger, h.OverloadPauseThreshold, h.OverloadPauseCooldown, destinationURL)
+		retryConfig.CheckRetry = overload.wrapCheckRetry(retryConfig.CheckRetry)
+	}
+
+	// Bound The Time Spent Retrying Each Message If The Subscriber Favors Progress Over Ordering (Must Wrap The Others)
+	var budget *retryBudget
+	if h.headOfLinePolicy != nil && h.headOfLinePolicy() == constants.HeadOfLinePolicyProgress {
+		budget = newRetryBudget(h.RetryBudget)
+		retryConfig.CheckRetry = budget.wrapCheckRetry(retryConfig.CheckRetry)
+		retryConfig.Backoff = budget.wrapBackoff(retryConfig.Backoff)
+	}
+
+	// When Auto-Commit Is Disabled Commit Any Marked Offsets On Exit (Session End Or Delivery Failure), And Otherwise In
+	// Batches Whenever The Interval Elapses Or The Batch Size Has Been Marked (Whichever Comes First).  Only Delivered
+	// Messages Are Marked, So A Crash Between Commits Only Replays The Messages Delivered Since The Last Commit.
+	var commitTicks <-chan time.Time
+	uncommittedCount := 0
+	if h.ManualCommit {
+		defer session.Commit()
+		if h.ManualCommitInterval > 0 {
+			commitTicker := time.NewTicker(h.ManualCommitInterval)
+			defer commitTicker.Stop()
+			commitTicks = commitTicker.C
+		}
+	}
+
 	// Pull Any Available Messages From The ConsumerGroupClaim (Until The Channel Closes)
-	for message := range claim.Messages() {
+	messages := claim.Messages()
+	var message *sarama.ConsumerMessage
+	var redelivery *sarama.ConsumerMessage // A Message Blocking The Partition Which Is To Be Redelivered Before Any Others
+	redeliveries := 0
+	for {
+
+		// Stop Pulling Messages For The Cooldown If The Subscriber Is Overloaded (Ending Consumption If The Session Ends)
+		if overload != nil && message != nil && overload.overloaded() {
+			if !overload.pause(session.Context(), message.Topic, message.Partition) {
+				return nil
+			}
+		}
+
+		// Redeliver Any Message Blocking The Partition, Otherwise Wait For The Next Message (Committing Any Marked Offsets
+		// Each Interval In The Meantime)
+		if redelivery != nil {
+			message, redelivery = redelivery, nil
+			redeliveries++
+		} else {
+			select {
+			case <-commitTicks:
+				if uncommittedCount > 0 {
+					session.Commit()
+					uncommittedCount = 0
+				}
+				continue
+			case nextMessage, ok := <-messages:
+				if !ok {
+					return nil // Return Success
+				}
+				message = nextMessage
+				redeliveries = 0
+			}
+		}
+
+		// Commit Each Message Before Delivering It If It Must Never Be Redelivered (e.g. After A Crash Or Re-Balance)
+		if h.CommitBeforeDelivery {
+			session.MarkMessage(message, "")
+			session.Commit()
+		}
 
 		// Consume The Message (Ignore Errors - Will have already been retried and we're moving on so as not to block further Topic processing.)
-		_ = h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &retryConfig)
+		if budget != nil {
+			budget.start()
+		}
+		err := h.consumeMessage(message, destinationURL, replyURL, deadLetterURL, &retryConfig)
+
+		// Unless It Could Not Be Deserialized & Should Block The Partition (Redelivered Without Marking Until The Session Ends)
+		if errors.Is(err, ErrDeserializationFailure) {
+			if !h.awaitRedelivery(session, message, redeliveries, err) {
+				return err
+			}
+			redelivery = message
+			continue
+		}
+
+		// Deliveries Abandoned During Shutdown Were Not Attempted In Full (Return Without Marking So It Is Redelivered)
+		if err != nil && h.deliveryContext().Err() != nil {
+			h.Logger.Warn("Delivery Abandoned - Offset Will Not Be Marked",
+				zap.String("Topic", message.Topic),
+				zap.Int32("Partition", message.Partition),
+				zap.Int64("Offset", message.Offset),
+				zap.Error(err))
+			return err
+		}
+
+		// Subscribers Favoring Progress Proceed Past Messages Which Could Not Be Delivered (Or Dead-Lettered) Within The Budget
+		if err != nil && budget != nil {
+			h.Logger.Warn("Failed To Deliver Message Within Retry Budget - Proceeding Past It",
+				zap.String("Topic", message.Topic),
+				zap.Int32("Partition", message.Partition),
+				zap.Int64("Offset", message.Offset),
+				zap.Duration("RetryBudget", h.RetryBudget),
+				zap.Error(err))
+			err = nil
+		}
+
+		// Messages Which Could Not Be Sent To The Subscriber's DeadLetterSink Either Are Never Marked - Instead The Partition
+		// Is Blocked & The Message Redelivered Until The Session Ends (Subscribers Without One Are Marked Regardless)
+		if errors.Is(err, ErrDeadLetterFailure) && !h.CommitBeforeDelivery {
+			h.Logger.Warn("Failed To Deliver Message To Subscriber Or DeadLetterSink - Offset Will Not Be Marked",
+				zap.String("Topic", message.Topic),
+				zap.Int32("Partition", message.Partition),
+				zap.Int64("Offset", message.Offset),
+				zap.Error(err))
+			if uncommittedCount > 0 {
+				session.Commit()
+				uncommittedCount = 0
+			}
+			if !h.awaitRedelivery(session, message, redeliveries, err) {
+				return err
+			}
+			redelivery = message
+			continue
+		}
+
+		// With Auto-Commit Disabled Undelivered Messages Are Never Marked - Instead The Partition Is Blocked & The Message
+		// Redelivered (Once Those Already Delivered Are Committed) Until The Session Ends, Whereupon Consumption Resumes
+		// From The Last Committed Offset
+		if h.ManualCommit {
+			if err != nil {
+				h.Logger.Warn("Failed To Deliver Message With Auto-Commit Disabled - Offset Will Not Be Marked",
+					zap.String("Topic", message.Topic),
+					zap.Int32("Partition", message.Partition),
+					zap.Int64("Offset", message.Offset),
+					zap.Error(err))
+				if uncommittedCount > 0 {
+					session.Commit()
+					uncommittedCount = 0
+				}
+				if !h.awaitRedelivery(session, message, redeliveries, err) {
+					return err
+				}
+				redelivery = message
+				continue
+			}
+			session.MarkMessage(message, "")
+			uncommittedCount++
+			if h.ManualCommitInterval <= 0 || (h.ManualCommitBatchSize > 0 && uncommittedCount >= h.ManualCommitBatchSize) {
+				session.Commit()
+				uncommittedCount = 0
+			}
+			continue
+		}
 
 		// Mark The Message As Having Been Consumed (Does Not Imply Successful Delivery - Only Full Retry Attempts Made)
 		session.MarkMessage(message, "")
 	}
+}
 
-	// Return Success
-	return nil
+// Get The Context Of The Handler's Deliveries (Background If None Was Specified)
+func (h *Handler) deliveryContext() context.Context {
+	if h.deliveryCtx == nil {
+		return context.Background()
+	}
+	return h.deliveryCtx
 }
 
 // Consume A Single Message
@@ -133,15 +394,357 @@ func (h *Handler) consumeMessage(consumerMessage *sarama.ConsumerMessage, destin
 		zap.Int32("Partition", consumerMessage.Partition),
 		zap.Int64("Offset", consumerMessage.Offset))
 
+	// Topic Warm-Up Marker Records (Produced By The Controller To Each Partition Of A New Topic) Are Never Dispatched
+	if isWarmupMarker(consumerMessage) {
+		h.Logger.Debug("Received A Topic Warm-Up Marker Record - Skipping",
+			zap.String("Topic", consumerMessage.Topic),
+			zap.Int32("Partition", consumerMessage.Partition),
+			zap.Int64("Offset", consumerMessage.Offset))
+		return nil
+	}
+
+	// Report The Raw Size Of The Consumed Message (Regardless Of Whether It Can Be Deserialized)
+	messageSize := kafkautil.ConsumerMessageSize(consumerMessage)
+	if h.StatsReporter != nil {
+		h.StatsReporter.ReportConsumedBytes(consumerMessage.Topic, messageSize)
+	}
+
+	// Messages Exceeding The Maximum Size (If Any) Are Not Processed & Are Handled According To The OversizedMessagePolicy
+	if h.MaxMessageBytes > 0 && messageSize > h.MaxMessageBytes {
+		return h.handleOversizedMessage(consumerMessage, messageSize, deadLetterURL, retryConfig)
+	}
+
+	// Forward Any Provenance Kafka Headers As HTTP Headers (Unless Configured To Strip Them)
+	var additionalHeaders http.Header
+	if !h.StripProvenanceHeaders {
+		additionalHeaders = provenanceHeaders(consumerMessage)
+	}
+
+	// Tombstone Records (Null Value) Carry No CloudEvent & Are Handled According To The TombstonePolicy
+	if isTombstone(consumerMessage) {
+		return h.handleTombstone(consumerMessage, additionalHeaders, destinationURL, replyURL, deadLetterURL, retryConfig)
+	}
+
+	// Strip The Schema Registry Wire Format Header (If Enabled) From A Copy Of The ConsumerMessage (The Original Is Marked)
+	if h.SchemaRegistryFraming {
+		_, payload, err := schemaregistry.Unframe(consumerMessage.Value)
+		if err != nil {
+			return h.handleDeserializationFailure(consumerMessage, err, deadLetterURL, retryConfig)
+		}
+		unframedMessage := *consumerMessage
+		unframedMessage.Value = payload
+		consumerMessage = &unframedMessage
+	}
+
+	// Remove Any CloudEvent Extensions Which Should Not Be Dispatched From A Copy Of The ConsumerMessage (The Original Is Marked)
+	if h.ExtensionFilter != nil {
+		filteredMessage, err := h.ExtensionFilter.filter(consumerMessage)
+		if err != nil {
+			return h.handleDeserializationFailure(consumerMessage, err, deadLetterURL, retryConfig)
+		}
+		consumerMessage = filteredMessage
+	}
+
 	// Convert The Sarama ConsumerMessage Into A CloudEvents Message
-	message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
+	var message binding.Message = kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
 	if message.ReadEncoding() == binding.EncodingUnknown {
-		h.Logger.Warn("Received A Message With Unknown Encoding - Skipping")
-		return errors.New("received a message with unknown encoding - skipping")
+
+		// Wrap Records Which Are Not CloudEvents In A New CloudEvent If The KafkaChannel Is A LegacyBridge
+		var legacyBridge *LegacyBridge
+		if h.legacyBridge != nil {
+			legacyBridge = h.legacyBridge()
+		}
+		if legacyBridge == nil {
+			return h.handleDeserializationFailure(consumerMessage, binding.ErrUnknownEncoding, deadLetterURL, retryConfig)
+		}
+		message = legacyBridge.wrap(consumerMessage)
 	}
 
 	// Dispatch The Message With Configured Retries & Return Any Errors
-	return h.MessageDispatcher.DispatchMessageWithRetries(context.Background(), message, nil, destinationURL, replyURL, deadLetterURL, retryConfig)
+	return h.dispatchMessage(consumerMessage.Topic, message, additionalHeaders, destinationURL, replyURL, deadLetterURL, retryConfig)
+}
+
+//
+// Dispatch The Message To The Subscriber With Configured Retries, Reporting Whether It Succeeded & Returning Any Errors
+//
+// Messages which exhaust their retries are sent to the Subscriber's DeadLetterSink (if any) by the MessageDispatcher,
+// and a failure to send them there as well is wrapped in ErrDeadLetterFailure so that the message is not marked.
+//
+func (h *Handler) dispatchMessage(topicName string, message binding.Message, additionalHeaders http.Header, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+
+	// Observe The Subscriber's Own Final Response (A Message Accepted By The DeadLetterSink Still Dispatches Without Error)
+	var subscriberResponse *subscriberResponseObserver
+	if destinationURL != nil && retryConfig != nil {
+		subscriberResponse = &subscriberResponseObserver{subscriberURL: destinationURL, retryMax: retryConfig.RetryMax}
+		observedRetryConfig := *retryConfig // Copied Per Dispatch So That Concurrently Dispatched Partitions Are Not Conflated
+		observedRetryConfig.CheckRetry = subscriberResponse.wrapCheckRetry(retryConfig.CheckRetry)
+		retryConfig = &observedRetryConfig
+	}
+
+	err := h.MessageDispatcher.DispatchMessageWithRetries(h.deliveryContext(), message, additionalHeaders, destinationURL, replyURL, deadLetterURL, retryConfig)
+	if h.StatsReporter != nil && h.deliveryContext().Err() == nil { // Deliveries Abandoned During Shutdown Are Not Counted
+		statusClass, success := dispatchOutcome(subscriberResponse, err)
+		h.StatsReporter.ReportDispatch(topicName, string(h.Subscriber.UID), statusClass, success)
+	}
+	if err != nil && deadLetterURL != nil {
+		return fmt.Errorf("%w: %v", ErrDeadLetterFailure, err)
+	}
+	return err
+}
+
+// Get The Class (e.g. "5xx") Of The Subscriber's Final HTTP Response To A Dispatch, And Whether The Dispatch Succeeded.  A
+// Message Which The Subscriber Failed But The DeadLetterSink Accepted Is A Failure With The Subscriber's Status Class.
+func dispatchOutcome(subscriberResponse *subscriberResponseObserver, err error) (string, bool) {
+	if subscriberResponse == nil || !subscriberResponse.observed {
+		return dispatchStatusClass(err), err == nil
+	}
+	if subscriberResponse.statusCode < 100 || subscriberResponse.statusCode > 599 {
+		return metrics.DispatchStatusClassUnknown, false
+	}
+	statusClass := fmt.Sprintf("%dxx", subscriberResponse.statusCode/100)
+	return statusClass, err == nil && statusClass == "2xx"
+}
+
+// Get The Class (e.g. "5xx") Of The HTTP Status With Which A Dispatch Completed ("unknown" If There Was No Response)
+func dispatchStatusClass(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+	if match := dispatchStatusCodeRegExp.FindStringSubmatch(err.Error()); match != nil {
+		return match[1] + "xx"
+	}
+	return metrics.DispatchStatusClassUnknown
+}
+
+// Observes The Subscriber's Final Response To A Single Dispatch (Not Shared Between Dispatches)
+type subscriberResponseObserver struct {
+	subscriberURL *url.URL
+	retryMax      int
+	attempts      int
+	statusCode    int  // The Status Code Of The Subscriber's Latest Response (Zero If The Latest Attempt Had No Response)
+	observed      bool // Whether Any Attempt To Send To The Subscriber Was Observed
+	final         bool // Whether The Subscriber's Final Attempt Has Been Observed (Later Calls Are The Reply Or DeadLetterSink)
+}
+
+// Wrap The Specified CheckRetry Function To Observe The Subscriber's Responses
+func (o *subscriberResponseObserver) wrapCheckRetry(checkRetry kncloudevents.CheckRetry) kncloudevents.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		retry, checkErr := false, error(nil)
+		if checkRetry != nil {
+			retry, checkErr = checkRetry(ctx, response, err)
+		}
+		o.observe(response, retry && checkErr == nil)
+		return retry, checkErr
+	}
+}
+
+// Track The Subscriber's Latest Response Until Its Final Attempt (The Subscriber Is Always Sent To Before The Reply Or
+// DeadLetterSink, So Only Attempts Without Any Response Before Then Are Attributed To The Subscriber)
+func (o *subscriberResponseObserver) observe(response *http.Response, retry bool) {
+	if o.final {
+		return
+	}
+	if response != nil && !isResponseFrom(response, o.subscriberURL) {
+		o.final = true
+		return
+	}
+	o.attempts++
+	o.observed = true
+	o.statusCode = 0
+	if response != nil {
+		o.statusCode = response.StatusCode
+	}
+	o.final = !retry || o.attempts > o.retryMax
+}
+
+// Extract The Receiver's Provenance Kafka Headers (If Any) From The Specified ConsumerMessage As HTTP Headers
+func provenanceHeaders(consumerMessage *sarama.ConsumerMessage) http.Header {
+	var headers http.Header
+	for _, recordHeader := range consumerMessage.Headers {
+		if recordHeader == nil {
+			continue
+		}
+		key := string(recordHeader.Key)
+		if key == kafkaconstants.ProvenanceHeaderKeyProducerPod || key == kafkaconstants.ProvenanceHeaderKeyChannel {
+			if headers == nil {
+				headers = http.Header{}
+			}
+			headers.Set(key, string(recordHeader.Value))
+		}
+	}
+	return headers
+}
+
+// Determine Whether The Specified ConsumerMessage Is A Topic Warm-Up Marker Record (Has The Marker Kafka Header)
+func isWarmupMarker(consumerMessage *sarama.ConsumerMessage) bool {
+	for _, recordHeader := range consumerMessage.Headers {
+		if recordHeader != nil && string(recordHeader.Key) == kafkaconstants.WarmupMarkerHeaderKey {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// Handle A Message Which Could Not Be Deserialized Into A CloudEvent According To The DeserializationFailurePolicy
+//
+// Returns an error wrapping ErrDeserializationFailure if consumption of the partition should stop
+// (without marking the message) or nil if the message has been dealt with and may be marked.  A
+// "deadletter" policy for a Subscriber without a DeadLetterSink, or whose DeadLetterSink could not
+// be reached, falls back to the "fail" behavior so that messages are never silently lost.
+//
+func (h *Handler) handleDeserializationFailure(consumerMessage *sarama.ConsumerMessage, parseErr error, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+
+	// Create A Logger With The Message Coordinates & Parse Error
+	logger := h.Logger.With(
+		zap.String("Topic", consumerMessage.Topic),
+		zap.Int32("Partition", consumerMessage.Partition),
+		zap.Int64("Offset", consumerMessage.Offset),
+		zap.String("Policy", h.DeserializationFailurePolicy),
+		zap.NamedError("ParseError", parseErr))
+
+	// Error To Return If The Partition Should Be Blocked
+	blockingErr := fmt.Errorf("%w (topic=%s, partition=%d, offset=%d): %v",
+		ErrDeserializationFailure, consumerMessage.Topic, consumerMessage.Partition, consumerMessage.Offset, parseErr)
+
+	switch h.DeserializationFailurePolicy {
+
+	case constants.DeserializationFailurePolicySkip:
+		logger.Warn("Received A Message Which Could Not Be Deserialized - Skipping")
+		if h.StatsReporter != nil {
+			h.StatsReporter.ReportDeserializationFailure(consumerMessage.Topic, h.DeserializationFailurePolicy)
+		}
+		return nil
+
+	case constants.DeserializationFailurePolicyDeadLetter:
+		if deadLetterURL == nil {
+			logger.Error("Received A Message Which Could Not Be Deserialized But Subscriber Has No DeadLetterSink - Blocking Partition")
+			return blockingErr
+		}
+		err := h.MessageDispatcher.DispatchMessageWithRetries(h.deliveryContext(), newDeserializationFailureMessage(consumerMessage, parseErr), nil, deadLetterURL, nil, nil, retryConfig)
+		if err != nil {
+			logger.Error("Failed To Send Message Which Could Not Be Deserialized To DeadLetterSink - Blocking Partition", zap.Error(err))
+			return blockingErr
+		}
+		logger.Warn("Received A Message Which Could Not Be Deserialized - Sent To DeadLetterSink")
+		return nil
+
+	default:
+		logger.Error("Received A Message Which Could Not Be Deserialized - Blocking Partition")
+		return blockingErr
+	}
+}
+
+// Wrap The Raw Value Of A Message Which Could Not Be Deserialized In A New CloudEvent (Including The Parse Error)
+func newDeserializationFailureMessage(consumerMessage *sarama.ConsumerMessage, parseErr error) binding.Message {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%s-%d-%d", consumerMessage.Topic, consumerMessage.Partition, consumerMessage.Offset))
+	event.SetSource(fmt.Sprintf("/kafka/topics/%s/partitions/%d", consumerMessage.Topic, consumerMessage.Partition))
+	event.SetType(constants.DeserializationFailureEventType)
+	event.SetExtension(constants.DeserializationFailureErrorExtension, parseErr.Error())
+	if !consumerMessage.Timestamp.IsZero() {
+		event.SetTime(consumerMessage.Timestamp)
+	}
+	_ = event.SetData(constants.DeserializationFailureDataContentType, consumerMessage.Value) // []byte Data Is Stored As-Is & Never Fails
+	return binding.ToMessage(&event)
+}
+
+//
+// Handle A Message Exceeding The MaxMessageBytes According To The OversizedMessagePolicy
+//
+// Oversized messages are never dispatched to the Subscriber, protecting it (and the Dispatcher's memory) from
+// pathological producers.  They are either skipped, or sent as-is to the Subscriber's DeadLetterSink in which
+// case any delivery error is returned as usual.  A "deadletter" policy for a Subscriber without a DeadLetterSink,
+// or for a message which is not a CloudEvent, falls back to the "skip" behavior as blocking the partition would
+// never succeed.
+//
+func (h *Handler) handleOversizedMessage(consumerMessage *sarama.ConsumerMessage, messageSize int64, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+
+	// Create A Logger With The Message Coordinates & Size
+	logger := h.Logger.With(
+		zap.String("Topic", consumerMessage.Topic),
+		zap.Int32("Partition", consumerMessage.Partition),
+		zap.Int64("Offset", consumerMessage.Offset),
+		zap.Int64("Size", messageSize),
+		zap.Int64("MaxMessageBytes", h.MaxMessageBytes))
+
+	// Send The Message To The DeadLetterSink If Configured & Possible
+	if h.OversizedMessagePolicy == constants.OversizedMessagePolicyDeadLetter {
+		message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
+		if deadLetterURL == nil {
+			logger.Error("Received An Oversized Message But Subscriber Has No DeadLetterSink - Skipping")
+		} else if message.ReadEncoding() == binding.EncodingUnknown {
+			logger.Error("Received An Oversized Message Which Is Not A CloudEvent - Skipping")
+		} else {
+			logger.Warn("Received An Oversized Message - Sending To DeadLetterSink")
+			if h.StatsReporter != nil {
+				h.StatsReporter.ReportOversizedMessage(consumerMessage.Topic, constants.OversizedMessagePolicyDeadLetter)
+			}
+			return h.MessageDispatcher.DispatchMessageWithRetries(h.deliveryContext(), message, nil, deadLetterURL, nil, nil, retryConfig)
+		}
+	} else {
+		logger.Warn("Received An Oversized Message - Skipping")
+	}
+
+	if h.StatsReporter != nil {
+		h.StatsReporter.ReportOversizedMessage(consumerMessage.Topic, constants.OversizedMessagePolicySkip)
+	}
+	return nil
+}
+
+//
+// Handle A Tombstone Record According To The TombstonePolicy
+//
+// Tombstones (records with a null value) are written to compacted topics to mark their key for deletion,
+// and so never contain a CloudEvent (binary content mode CloudEvents without data also have a null value,
+// but are identified by their "ce_specversion" header and dispatched as usual).  They are either skipped, or dispatched to the Subscriber as a new
+// delete-typed CloudEvent identifying the key, in which case any delivery error is returned as usual.
+//
+func (h *Handler) handleTombstone(consumerMessage *sarama.ConsumerMessage, additionalHeaders http.Header, destinationURL *url.URL, replyURL *url.URL, deadLetterURL *url.URL, retryConfig *kncloudevents.RetryConfig) error {
+
+	// Create A Logger With The Message Coordinates & Key
+	logger := h.Logger.With(
+		zap.String("Topic", consumerMessage.Topic),
+		zap.Int32("Partition", consumerMessage.Partition),
+		zap.Int64("Offset", consumerMessage.Offset),
+		zap.ByteString("Key", consumerMessage.Key),
+		zap.String("Policy", h.TombstonePolicy))
+
+	if h.TombstonePolicy == constants.TombstonePolicyDispatch {
+		logger.Debug("Received A Tombstone Record - Dispatching Delete Event")
+		return h.dispatchMessage(consumerMessage.Topic, newTombstoneMessage(consumerMessage), additionalHeaders, destinationURL, replyURL, deadLetterURL, retryConfig)
+	}
+
+	logger.Debug("Received A Tombstone Record - Skipping")
+	return nil
+}
+
+// Determine Whether The Specified Record Is A Tombstone (Null Value & Not A Data-Less Binary Content Mode CloudEvent)
+func isTombstone(consumerMessage *sarama.ConsumerMessage) bool {
+	if consumerMessage.Value != nil {
+		return false
+	}
+	for _, header := range consumerMessage.Headers {
+		if header != nil && strings.ToLower(string(header.Key)) == cloudEventHeaderSpecs.PrefixedSpecVersionName() {
+			return false
+		}
+	}
+	return true
+}
+
+// Create A New Delete-Typed CloudEvent For The Specified Tombstone Record
+func newTombstoneMessage(consumerMessage *sarama.ConsumerMessage) binding.Message {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%s-%d-%d", consumerMessage.Topic, consumerMessage.Partition, consumerMessage.Offset))
+	event.SetSource(fmt.Sprintf("/kafka/topics/%s/partitions/%d", consumerMessage.Topic, consumerMessage.Partition))
+	event.SetType(constants.TombstoneEventType)
+	if consumerMessage.Key != nil {
+		event.SetExtension(constants.TombstoneKeyExtension, string(consumerMessage.Key))
+	}
+	if !consumerMessage.Timestamp.IsZero() {
+		event.SetTime(consumerMessage.Timestamp)
+	}
+	return binding.ToMessage(&event)
 }
 
 //
@@ -154,19 +757,24 @@ func (h *Handler) checkRetry(_ context.Context, response *http.Response, err err
 
 	// Retry Any Nil HTTP Response
 	if response == nil {
-		h.Logger.Info("Unable To Check Retry State On Nil Response - Retrying")
+		h.logDeliveryError(zapcore.InfoLevel, "Unable To Check Retry State On Nil Response - Retrying")
 		return true, nil
 	}
 
 	// Retry Any Errors
 	if err != nil {
-		h.Logger.Warn("Received Response Error - Retrying", zap.Error(err))
+		h.logDeliveryError(zapcore.WarnLevel, "Received Response Error - Retrying", zap.Error(err))
 		return true, nil
 	}
 
-	// Extract The StatusCode From The Response & Add To Logger
+	// Extract The StatusCode From The Response & Add To Log Fields
 	statusCode := response.StatusCode
-	logger := h.Logger.With(zap.Int("StatusCode", statusCode))
+	statusCodeField := zap.Int("StatusCode", statusCode)
+
+	// Track The Subscriber's Recovery From Any Sampled Delivery Errors
+	if h.deliveryErrors != nil {
+		h.deliveryErrors.observe(response)
+	}
 
 	//
 	// Note - Normally we would NOT want to retry 400 responses, BUT the knative-eventing
@@ -175,16 +783,25 @@ func (h *Handler) checkRetry(_ context.Context, response *http.Response, err err
 	//        been resolved we can remove 400 from the list of codes to retry.
 	//
 	if statusCode >= 500 || statusCode == 400 || statusCode == 404 || statusCode == 429 {
-		logger.Warn("Failed To Send Message To Subscriber Service - Retrying")
+		h.logDeliveryError(zapcore.WarnLevel, "Failed To Send Message To Subscriber Service - Retrying", statusCodeField)
 		return true, nil
 	} else if statusCode >= 300 && statusCode <= 399 {
-		logger.Warn("Failed To Send Message To Subscriber Service - Not Retrying")
+		h.logDeliveryError(zapcore.WarnLevel, "Failed To Send Message To Subscriber Service - Not Retrying", statusCodeField)
 		return false, nil
 	} else if statusCode == -1 {
-		logger.Warn("No StatusCode Detected In Error - Retrying")
+		h.logDeliveryError(zapcore.WarnLevel, "No StatusCode Detected In Error - Retrying", statusCodeField)
 		return true, nil
 	}
 
 	// Do Not Retry 1XX, 2XX, & Most 4XX StatusCode Responses
 	return false, nil
 }
+
+// Log The Specified Delivery Error (Sampled If Repeated Identical Errors Are Configured To Be)
+func (h *Handler) logDeliveryError(level zapcore.Level, message string, fields ...zap.Field) {
+	if h.deliveryErrors != nil {
+		h.deliveryErrors.log(level, message, fields...)
+	} else if checkedEntry := h.Logger.Check(level, message); checkedEntry != nil {
+		checkedEntry.Write(fields...)
+	}
+}
@@ -19,8 +19,10 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,6 +32,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/schemaregistry"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
@@ -56,6 +63,10 @@ const (
 	testMsgEventTypeVersion  = "TestMsgEventTypeVersion"
 	testMsgKnativeHistory    = "TestKnativeHistory"
 	testMsgJsonContentString = "{\"content\": \"Test Message 1\"}"
+	testMalformedMsgContent  = "Not A CloudEvent"
+	testTombstoneKey         = "TestTombstoneKey"
+	testProducerPod          = "TestProducerPod"
+	testChannelKey           = "TestNamespace/TestChannel"
 )
 
 var (
@@ -77,6 +88,13 @@ func TestNewHandler(t *testing.T) {
 func TestHandlerSetup(t *testing.T) {
 	handler := createTestHandler(t, testSubscriberURI, testReplyURI, nil)
 	assert.Nil(t, handler.Setup(nil))
+
+	// Verify The Rebalance Callback Is Invoked With The ConsumerGroup ID (If Specified)
+	var rebalancedGroupIds []string
+	handler.groupId = "TestGroupId"
+	handler.onRebalance = func(groupId string) { rebalancedGroupIds = append(rebalancedGroupIds, groupId) }
+	assert.Nil(t, handler.Setup(nil))
+	assert.Equal(t, []string{"TestGroupId"}, rebalancedGroupIds)
 }
 
 // Test The Handler's Cleanup() Functionality
@@ -85,56 +103,1212 @@ func TestHandlerCleanup(t *testing.T) {
 	assert.Nil(t, handler.Cleanup(nil))
 }
 
-// Test The Handler's ConsumeClaim() Functionality
-func TestHandlerConsumeClaim(t *testing.T) {
+// Test The Handler's ConsumeClaim() Functionality
+func TestHandlerConsumeClaim(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only           bool
+		name           string
+		destinationUri *apis.URL
+		replyUri       *apis.URL
+		deadLetterUri  *apis.URL
+		retry          bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Complete Subscriber Configuration",
+			destinationUri: testSubscriberURI,
+			replyUri:       testReplyURI,
+			deadLetterUri:  testDeadLetterURI,
+			retry:          true,
+		},
+		{
+			name:          "No Subscriber URL",
+			replyUri:      testReplyURI,
+			deadLetterUri: testDeadLetterURI,
+			retry:         true,
+		},
+		{
+			name:           "No Reply URL",
+			destinationUri: testSubscriberURI,
+			deadLetterUri:  testDeadLetterURI,
+			retry:          true,
+		},
+		{
+			name:           "No DeadLetter URL",
+			destinationUri: testSubscriberURI,
+			replyUri:       testReplyURI,
+			retry:          true,
+		},
+		{
+			name:           "No Retry",
+			destinationUri: testSubscriberURI,
+			replyUri:       testReplyURI,
+			deadLetterUri:  testDeadLetterURI,
+			retry:          false,
+		},
+		{
+			name:  "Empty Subscriber Configuration",
+			retry: false,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			performHandlerConsumeClaimTest(t, testCase.destinationUri, testCase.replyUri, testCase.deadLetterUri, testCase.retry)
+		})
+	}
+}
+
+// Test One Permutation Of The Handler's ConsumeClaim() Functionality
+func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, deadLetterUri *apis.URL, retry bool) {
+
+	// Initialize Destination As Specified
+	var destinationUrl *url.URL
+	if destinationUri != nil {
+		destinationUrl = destinationUri.URL()
+	}
+
+	// Initialize Reply As Specified
+	var replyUrl *url.URL
+	if replyUri != nil {
+		replyUrl = replyUri.URL()
+	}
+
+	// Initialize DeadLetter As Specified
+	var deadLetterUrl *url.URL
+	if deadLetterUri != nil {
+		deadLetterUrl = deadLetterUri.URL()
+	}
+
+	// Create The Specified DeliverySpec
+	deliverySpec := createDeliverySpec(deadLetterUri, retry)
+
+	// Create The Expected RetryConfig
+	var retryConfig kncloudevents.RetryConfig
+	if retry {
+		var err error
+		retryConfig, err = kncloudevents.RetryConfigFromDeliverySpec(deliverySpec)
+		assert.NotNil(t, retryConfig)
+		assert.Nil(t, err)
+	}
+
+	// Create Mocks For Testing
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, destinationUrl, replyUrl, deadLetterUrl, &retryConfig, nil)
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Handler To Test
+	handler := createTestHandler(t, destinationUri, replyUri, &deliverySpec)
+
+	// Background Start Consuming Claims
+	go func() {
+		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+		assert.Nil(t, err)
+	}()
+
+	// Perform The Test (Add ConsumerMessages To Claims)
+	consumerMessage := createConsumerMessage(t)
+	mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+	// Wait For Message To Be Marked As Complete
+	markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+
+	// Close The Mock ConsumerGroupClaim Message Channel To Complete/Exit Handler's ConsumeClaim()
+	close(mockConsumerGroupClaim.MessageChan)
+
+	// Verify The Results (CloudEvent Was Dispatched & ConsumerMessage Was Marked)
+	assert.Equal(t, consumerMessage, markedMessage)
+	assert.NotNil(t, mockMessageDispatcher.Message())
+	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+
+	// Verify The Raw Size Of The ConsumerMessage (Key + Value + Header Keys & Values) Was Reported Against The Topic
+	expectedBytes := int64(len(consumerMessage.Key) + len(consumerMessage.Value))
+	for _, header := range consumerMessage.Headers {
+		expectedBytes += int64(len(header.Key) + len(header.Value))
+	}
+	assert.True(t, expectedBytes > 0)
+	assert.Equal(t, expectedBytes, handler.StatsReporter.(*dispatchertesting.MockStatsReporter).ConsumedBytes[consumerMessage.Topic])
+
+	// Verify The Successful Dispatch Was Reported Against The Subscriber
+	assert.Equal(t, 1, handler.StatsReporter.(*dispatchertesting.MockStatsReporter).Dispatches(string(testSubscriberUID), "2xx", true))
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Messages Carrying Provenance Kafka Headers
+func TestHandlerConsumeClaimProvenanceHeaders(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only                   bool
+		name                   string
+		stripProvenanceHeaders bool
+		expectedHeaders        http.Header
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name: "Forward Provenance Headers",
+			expectedHeaders: http.Header{
+				http.CanonicalHeaderKey(kafkaconstants.ProvenanceHeaderKeyProducerPod): []string{testProducerPod},
+				http.CanonicalHeaderKey(kafkaconstants.ProvenanceHeaderKeyChannel):     []string{testChannelKey},
+			},
+		},
+		{
+			name:                   "Strip Provenance Headers",
+			stripProvenanceHeaders: true,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, testCase.expectedHeaders, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With The Specified Provenance Configuration
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.StripProvenanceHeaders = testCase.stripProvenanceHeaders
+
+			// Background Start Consuming Claims
+			go func() {
+				err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+				assert.Nil(t, err)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage With Provenance Headers To Claims)
+			consumerMessage := createConsumerMessage(t)
+			consumerMessage.Headers = append(consumerMessage.Headers,
+				&sarama.RecordHeader{Key: []byte(kafkaconstants.ProvenanceHeaderKeyProducerPod), Value: []byte(testProducerPod)},
+				&sarama.RecordHeader{Key: []byte(kafkaconstants.ProvenanceHeaderKeyChannel), Value: []byte(testChannelKey)})
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Wait For Message To Be Marked As Complete
+			markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+
+			// Close The Mock ConsumerGroupClaim Message Channel To Complete/Exit Handler's ConsumeClaim()
+			close(mockConsumerGroupClaim.MessageChan)
+
+			// Verify The Results (CloudEvent Was Dispatched Unmodified With Expected Headers & ConsumerMessage Was Marked)
+			assert.Equal(t, consumerMessage, markedMessage)
+			assert.NotNil(t, mockMessageDispatcher.Message())
+			verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Schema Registry Wire Format Framing Enabled
+func TestHandlerConsumeClaimSchemaRegistryFraming(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only         bool
+		name         string
+		framed       bool
+		expectMarked bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Framed Message",
+			framed:       true,
+			expectMarked: true,
+		},
+		{
+			name:         "Unframed Message",
+			framed:       false,
+			expectMarked: false,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing (With A Session Which Ends, Ending Redelivery Of Any Message Blocking The Partition)
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession, endSession := createEndingConsumerGroupSession(t)
+			defer endSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With Schema Registry Framing Enabled & The "fail" DeserializationFailurePolicy
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.SchemaRegistryFraming = true
+			handler.DeserializationFailurePolicy = constants.DeserializationFailurePolicyFail
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage, Framed As Specified, To Claims)
+			consumerMessage := createConsumerMessage(t)
+			if testCase.framed {
+				consumerMessage.Value = schemaregistry.Frame(7, consumerMessage.Value)
+			}
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify Framed Messages Are Dispatched Without The Header & Unframed Messages Block The Partition
+			var err error
+			select {
+			case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+				assert.True(t, testCase.expectMarked)
+				assert.Equal(t, consumerMessage, markedMessage)
+				close(mockConsumerGroupClaim.MessageChan)
+				err = <-errChan
+				assert.Nil(t, err)
+				verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+			case err = <-errChan:
+				assert.False(t, testCase.expectMarked)
+				assert.True(t, errors.Is(err, ErrDeserializationFailure))
+				assert.Contains(t, err.Error(), schemaregistry.ErrNotFramed.Error())
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Auto-Commit Disabled (Explicit Offset Marking & Committing)
+func TestHandlerConsumeClaimManualCommit(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only                 bool
+		name                 string
+		commitInterval       time.Duration
+		dispatchErr          error
+		expectMarked         bool
+		expectIntervalCommit bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:                 "Delivered Message Committed Immediately",
+			commitInterval:       0,
+			expectMarked:         true,
+			expectIntervalCommit: true,
+		},
+		{
+			name:           "Delivered Message Committed At Session End",
+			commitInterval: time.Hour,
+			expectMarked:   true,
+		},
+		{
+			name:           "Undelivered Message Not Marked",
+			commitInterval: 0,
+			dispatchErr:    errors.New("test delivery failure"),
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing (With A Session Which Ends, Ending Redelivery Of Any Undelivered Message)
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession, endSession := createEndingConsumerGroupSession(t)
+			defer endSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, testCase.dispatchErr)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With Auto-Commit Disabled
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.ManualCommit = true
+			handler.ManualCommitInterval = testCase.commitInterval
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage To Claims)
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify Delivered Messages Are Marked (And Committed If The Interval Has Elapsed)
+			if testCase.expectMarked {
+				assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+				if testCase.expectIntervalCommit {
+					assert.True(t, <-mockConsumerGroupSession.CommitChan)
+				}
+				close(mockConsumerGroupClaim.MessageChan)
+			}
+
+			// Verify The Final Commit Occurs Without Any (Further) Messages Having Been Marked
+			select {
+			case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+				assert.Fail(t, "unexpected message marked", "offset %d", markedMessage.Offset)
+			case committed := <-mockConsumerGroupSession.CommitChan:
+				assert.True(t, committed)
+			}
+
+			// Verify ConsumeClaim() Returned The Delivery Error (If Any) Once The Session Ended, Having Blocked The Partition
+			assert.Equal(t, testCase.dispatchErr, <-errChan)
+			assert.NotNil(t, mockMessageDispatcher.Message())
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality When Committing Before Delivery (At-Most-Once Delivery Guarantee)
+func TestHandlerConsumeClaimCommitBeforeDelivery(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		dispatchErr error
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Delivered Message", dispatchErr: nil},
+		{name: "Undelivered Message", dispatchErr: errors.New("test delivery failure")},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, testCase.dispatchErr)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test Committing Before Delivery
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.CommitBeforeDelivery = true
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage To Claims)
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify The Message Is Marked & Committed Before It Is Delivered
+			assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+			assert.Nil(t, mockMessageDispatcher.Message())
+			assert.True(t, <-mockConsumerGroupSession.CommitChan)
+
+			// Verify Consumption Proceeds Regardless Of The Delivery Outcome
+			assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+			assert.NotNil(t, mockMessageDispatcher.Message())
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality When Undelivered Messages Cannot Be Sent To The DeadLetterSink Either
+func TestHandlerConsumeClaimDeadLetterFailure(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name                 string
+		deadLetterUri        *apis.URL
+		commitBeforeDelivery bool
+		dispatchErr          error
+		expectMarked         bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:          "Delivered Or Dead Lettered Message Marked",
+			deadLetterUri: testDeadLetterURI,
+			expectMarked:  true,
+		},
+		{
+			name:          "Message Not Dead Lettered Not Marked",
+			deadLetterUri: testDeadLetterURI,
+			dispatchErr:   errors.New("test delivery and dead letter failure"),
+		},
+		{
+			name:         "Undelivered Message Without DeadLetterSink Marked",
+			dispatchErr:  errors.New("test delivery failure"),
+			expectMarked: true,
+		},
+		{
+			name:                 "Message Not Dead Lettered Committed Before Delivery",
+			deadLetterUri:        testDeadLetterURI,
+			commitBeforeDelivery: true,
+			dispatchErr:          errors.New("test delivery and dead letter failure"),
+			expectMarked:         true,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Initialize DeadLetter As Specified
+			var deadLetterUrl *url.URL
+			if testCase.deadLetterUri != nil {
+				deadLetterUrl = testCase.deadLetterUri.URL()
+			}
+
+			// Create Mocks For Testing (With A Session Which Ends, Ending Redelivery Of Any Message Blocking The Partition)
+			retryConfig := kncloudevents.NoRetries()
+			session := newOffsetRecordingSession()
+			sessionCtx, endSession := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer endSession()
+			session.Ctx = sessionCtx
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, deadLetterUrl, &retryConfig, testCase.dispatchErr)
+
+			// Create The Handler To Test With The TestCase's DeadLetterSink
+			deliverySpec := createDeliverySpec(testCase.deadLetterUri, false)
+			handler := createTestHandler(t, testSubscriberURI, nil, &deliverySpec)
+			handler.MessageDispatcher = mockMessageDispatcher
+			handler.CommitBeforeDelivery = testCase.commitBeforeDelivery
+
+			// Background Start Consuming Claims
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage To Claims, Closing The Claim Unless Consumption Is Expected To Stop)
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+			if testCase.expectMarked {
+				assert.Eventually(t, func() bool { return session.marked() == consumerMessage.Offset+1 }, 5*time.Second, time.Millisecond)
+				close(mockConsumerGroupClaim.MessageChan)
+			}
+
+			// Verify Consumption Only Stopped (Without Marking The Message) Once The Session Ended If It Could Not Be Dead Lettered
+			select {
+			case err := <-errChan:
+				if testCase.expectMarked {
+					assert.Nil(t, err)
+				} else {
+					assert.True(t, errors.Is(err, ErrDeadLetterFailure))
+					assert.Equal(t, int64(0), session.marked())
+				}
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "ConsumeClaim did not return")
+			}
+			assert.NotNil(t, mockMessageDispatcher.Message())
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Batched Commits (Auto-Commit Disabled)
+func TestHandlerConsumeClaimBatchedCommit(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only                bool
+		name                string
+		commitInterval      time.Duration
+		commitBatchSize     int
+		messageCount        int
+		failingMessage      int   // 1 Based Index Of A Final Message Whose First Delivery Fails (None If Zero)
+		wantCommitCount     int   // Commits Before The ConsumeClaim Returns (Excluding The Final Commit)
+		wantCommittedOffset int64 // Offset From Which Consumption Would Resume After A Crash
+		wantMarkedOffset    int64 // Offset From Which Consumption Resumes After The Final Commit
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:                "Batch Size Reached",
+			commitInterval:      time.Hour,
+			commitBatchSize:     3,
+			messageCount:        5,
+			wantCommitCount:     1,
+			wantCommittedOffset: testOffset + 3,
+			wantMarkedOffset:    testOffset + 5,
+		},
+		{
+			name:                "Multiple Batches",
+			commitInterval:      time.Hour,
+			commitBatchSize:     2,
+			messageCount:        6,
+			wantCommitCount:     3,
+			wantCommittedOffset: testOffset + 6,
+			wantMarkedOffset:    testOffset + 6,
+		},
+		{
+			name:                "Batch Size Not Reached",
+			commitInterval:      time.Hour,
+			commitBatchSize:     10,
+			messageCount:        5,
+			wantCommitCount:     0,
+			wantCommittedOffset: 0,
+			wantMarkedOffset:    testOffset + 5,
+		},
+		{
+			name:                "Undelivered Message Redelivered After Commit",
+			commitInterval:      time.Hour,
+			commitBatchSize:     2,
+			messageCount:        4,
+			failingMessage:      4,
+			wantCommitCount:     1,
+			wantCommittedOffset: testOffset + 2,
+			wantMarkedOffset:    testOffset + 3,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			session := newOffsetRecordingSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			messageDispatcher := &countingMessageDispatcher{failingDispatch: testCase.failingMessage}
+
+			// Create The Handler To Test With Auto-Commit Disabled & Batched Commits
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.MessageDispatcher = messageDispatcher
+			handler.ManualCommit = true
+			handler.ManualCommitInterval = testCase.commitInterval
+			handler.ManualCommitBatchSize = testCase.commitBatchSize
+			handler.RedeliveryInterval = 10 * time.Millisecond
+
+			// Background Start Consuming Claims
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Send The Deliverable Messages With Consecutive Offsets)
+			deliveredCount := testCase.messageCount
+			if testCase.failingMessage > 0 {
+				deliveredCount = testCase.failingMessage - 1
+			}
+			for index := 0; index < deliveredCount; index++ {
+				consumerMessage := createConsumerMessage(t)
+				consumerMessage.Offset = testOffset + int64(index)
+				mockConsumerGroupClaim.MessageChan <- consumerMessage
+			}
+
+			// Verify The Commits (i.e. The Offset From Which Consumption Would Resume After A Crash At This Point)
+			assert.Eventually(t, func() bool {
+				commitCount, committedOffset := session.committed()
+				return session.marked() == testCase.wantMarkedOffset &&
+					commitCount == testCase.wantCommitCount &&
+					committedOffset == testCase.wantCommittedOffset
+			}, 5*time.Second, time.Millisecond)
+
+			// Verify A Message Whose Delivery Fails Is Only Marked Once Redelivered In Place (After Committing Those Already
+			// Delivered) Rather Than Ending The Session
+			wantMarkedOffset := testCase.wantMarkedOffset
+			wantDispatchCount := testCase.messageCount
+			wantCommitCount := testCase.wantCommitCount + 1 // Including The Final Commit
+			if testCase.failingMessage > 0 {
+				consumerMessage := createConsumerMessage(t)
+				consumerMessage.Offset = testOffset + int64(deliveredCount)
+				mockConsumerGroupClaim.MessageChan <- consumerMessage
+				wantMarkedOffset++
+				wantDispatchCount++
+				wantCommitCount++
+				assert.Eventually(t, func() bool {
+					return session.marked() == wantMarkedOffset && messageDispatcher.count() == wantDispatchCount
+				}, 5*time.Second, time.Millisecond)
+				commitCount, committedOffset := session.committed()
+				assert.Equal(t, testCase.wantCommitCount+1, commitCount)
+				assert.Equal(t, testCase.wantMarkedOffset, committedOffset)
+			}
+
+			// Stop Consuming Via The Session Ending
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+
+			// Verify The Final Commit Includes All Delivered Messages
+			commitCount, committedOffset := session.committed()
+			assert.Equal(t, wantCommitCount, commitCount)
+			assert.Equal(t, wantMarkedOffset, committedOffset)
+			assert.Equal(t, wantMarkedOffset, session.marked())
+			assert.Equal(t, wantDispatchCount, messageDispatcher.count())
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality Commits Marked Offsets Each Interval While Idle
+func TestHandlerConsumeClaimIntervalCommit(t *testing.T) {
+
+	// Create Mocks For Testing
+	session := newOffsetRecordingSession()
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	messageDispatcher := &countingMessageDispatcher{}
+
+	// Create The Handler To Test With Auto-Commit Disabled & A Batch Size Which Is Never Reached
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.MessageDispatcher = messageDispatcher
+	handler.ManualCommit = true
+	handler.ManualCommitInterval = 10 * time.Millisecond
+	handler.ManualCommitBatchSize = 100
+
+	// Background Start Consuming Claims
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+	}()
+
+	// Perform The Test (Send Two Messages & Then Leave The Partition Idle)
+	for index := 0; index < 2; index++ {
+		consumerMessage := createConsumerMessage(t)
+		consumerMessage.Offset = testOffset + int64(index)
+		mockConsumerGroupClaim.MessageChan <- consumerMessage
+	}
+
+	// Verify The Marked Offsets Are Committed Without Further Messages Or The Session Ending
+	assert.Eventually(t, func() bool {
+		_, committedOffset := session.committed()
+		return committedOffset == testOffset+2
+	}, 5*time.Second, time.Millisecond)
+
+	// Verify Idle Intervals Without Newly Marked Offsets Do Not Commit
+	commitCount, _ := session.committed()
+	time.Sleep(50 * time.Millisecond)
+	idleCommitCount, _ := session.committed()
+	assert.Equal(t, commitCount, idleCommitCount)
+
+	// Stop Consuming
+	close(mockConsumerGroupClaim.MessageChan)
+	assert.Nil(t, <-errChan)
+}
+
+//
+// ConsumerGroupSession Recording The Marked & Committed Offsets (As Sarama Would Track Them For The Partition)
+//
+type offsetRecordingSession struct {
+	dispatchertesting.MockConsumerGroupSession
+	lock            sync.Mutex
+	markedOffset    int64 // The Next Offset To Consume As Marked (Not Yet Necessarily Committed)
+	committedOffset int64 // The Next Offset To Consume As Committed (Where Consumption Resumes After A Crash)
+	commitCount     int
+}
+
+func newOffsetRecordingSession() *offsetRecordingSession {
+	return &offsetRecordingSession{}
+}
+
+func (s *offsetRecordingSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.markedOffset = msg.Offset + 1
+}
+
+func (s *offsetRecordingSession) Commit() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.committedOffset = s.markedOffset
+	s.commitCount++
+}
+
+func (s *offsetRecordingSession) marked() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.markedOffset
+}
+
+func (s *offsetRecordingSession) committed() (int, int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.commitCount, s.committedOffset
+}
+
+//
+// MessageDispatcher Counting The Dispatched Messages (Optionally Failing To Deliver One Of Them)
+//
+type countingMessageDispatcher struct {
+	channel.MessageDispatcher
+	lock            sync.Mutex
+	dispatchCount   int
+	failingDispatch int // 1 Based Index Of The Dispatch Which Fails (None If Zero)
+}
+
+func (d *countingMessageDispatcher) DispatchMessageWithRetries(_ context.Context, _ binding.Message, _ http.Header, _ *url.URL, _ *url.URL, _ *url.URL, _ *kncloudevents.RetryConfig) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.dispatchCount++
+	if d.dispatchCount == d.failingDispatch {
+		return errors.New("test delivery failure")
+	}
+	return nil
+}
+
+func (d *countingMessageDispatcher) count() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.dispatchCount
+}
+
+// Test The Handler's ConsumeClaim() Functionality When An In-Flight Delivery Is Abandoned (e.g. At The Shutdown Deadline)
+// Test The Handler's ConsumeClaim() Functionality Without A StatsReporter (Metrics Are Optional)
+func TestHandlerConsumeClaimWithoutStatsReporter(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name            string
+		maxMessageBytes int64
+		policy          string
+		expectDispatch  bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Dispatched Message",
+			expectDispatch: true,
+		},
+		{
+			name:            "Oversized Message Skip Policy",
+			maxMessageBytes: 1,
+			policy:          constants.OversizedMessagePolicySkip,
+		},
+		{
+			name:            "Oversized Message DeadLetter Policy Without DeadLetterSink",
+			maxMessageBytes: 1,
+			policy:          constants.OversizedMessagePolicyDeadLetter,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test Without A StatsReporter
+			deliverySpec := createDeliverySpec(nil, false)
+			handler := createTestHandler(t, testSubscriberURI, nil, &deliverySpec)
+			handler.MaxMessageBytes = testCase.maxMessageBytes
+			handler.OversizedMessagePolicy = testCase.policy
+			handler.StatsReporter = nil
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage To Claims)
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify The Message Is Marked & Consumption Completes Without Error
+			markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+			assert.Equal(t, consumerMessage, markedMessage)
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+
+			// Verify The Message Was Dispatched To The Subscriber (Or Not At All)
+			if testCase.expectDispatch {
+				verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+			} else {
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
+		})
+	}
+}
+
+func TestHandlerConsumeClaimAbandonedDelivery(t *testing.T) {
+
+	// Create Mocks For Testing
+	session := newOffsetRecordingSession()
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	messageDispatcher := newBlockingMessageDispatcher()
+
+	// Create The Handler To Test With A Cancellable Delivery Context
+	deliveryCtx, abandon := context.WithCancel(context.Background())
+	defer abandon()
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.MessageDispatcher = messageDispatcher
+	handler.deliveryCtx = deliveryCtx
+
+	// Background Start Consuming Claims
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+	}()
+
+	// Perform The Test (Abandon The Delivery Once It Is In-Flight)
+	mockConsumerGroupClaim.MessageChan <- createConsumerMessage(t)
+	assert.Eventually(t, func() bool { return messageDispatcher.inProgress() == 1 }, 5*time.Second, time.Millisecond)
+	abandon()
+
+	// Verify Consumption Stopped Without Marking The Abandoned Message (So That It Is Redelivered)
+	select {
+	case err := <-errChan:
+		assert.NotNil(t, err)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ConsumeClaim did not return after the delivery was abandoned")
+	}
+	assert.Equal(t, int64(0), session.marked())
+	assert.Equal(t, 0, handler.StatsReporter.(*dispatchertesting.MockStatsReporter).Dispatches(string(testSubscriberUID), metrics.DispatchStatusClassUnknown, false))
+}
+
+// Test The Handler's ConsumeClaim() Functionality Reports The Outcome Of Each Dispatch To The Subscriber
+func TestHandlerConsumeClaimDispatchMetrics(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name            string
+		deadLetterUri   *apis.URL
+		httpResponses   []*http.Response
+		dispatchErr     error
+		wantStatusClass string
+		wantSuccess     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:            "Success",
+			wantStatusClass: "2xx",
+			wantSuccess:     true,
+		},
+		{
+			name:            "Server Error",
+			dispatchErr:     fmt.Errorf("unable to complete request to %s: unexpected HTTP response, expected 2xx, got 503", testSubscriberURIString),
+			wantStatusClass: "5xx",
+		},
+		{
+			name:            "Client Error With Failed Dead Letter",
+			dispatchErr:     fmt.Errorf("unable to complete request to either %s (unexpected HTTP response, expected 2xx, got 404) or %s (unexpected HTTP response, expected 2xx, got 500)", testSubscriberURIString, testDeadLetterURIString),
+			wantStatusClass: "4xx",
+		},
+		{
+			name:            "No Response",
+			dispatchErr:     errors.New("unable to complete request: connection refused"),
+			wantStatusClass: metrics.DispatchStatusClassUnknown,
+		},
+		{
+			name:            "Observed Success",
+			httpResponses:   []*http.Response{createHTTPResponse(testSubscriberURI, http.StatusOK)},
+			wantStatusClass: "2xx",
+			wantSuccess:     true,
+		},
+		{
+			name:            "Dead Lettered Server Error",
+			deadLetterUri:   testDeadLetterURI,
+			httpResponses:   []*http.Response{createHTTPResponse(testSubscriberURI, http.StatusServiceUnavailable), createHTTPResponse(testDeadLetterURI, http.StatusAccepted)},
+			wantStatusClass: "5xx",
+		},
+		{
+			name:            "Dead Lettered Client Error",
+			deadLetterUri:   testDeadLetterURI,
+			httpResponses:   []*http.Response{createHTTPResponse(testSubscriberURI, http.StatusNotFound), createHTTPResponse(testDeadLetterURI, http.StatusOK)},
+			wantStatusClass: "4xx",
+		},
+		{
+			name:            "Dead Lettered No Response",
+			deadLetterUri:   testDeadLetterURI,
+			httpResponses:   []*http.Response{nil, createHTTPResponse(testDeadLetterURI, http.StatusOK)},
+			wantStatusClass: metrics.DispatchStatusClassUnknown,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			retryConfig := kncloudevents.NoRetries()
+			var deadLetterUrl *url.URL
+			var deliverySpec *eventingduck.DeliverySpec
+			if testCase.deadLetterUri != nil {
+				deadLetterUrl = testCase.deadLetterUri.URL()
+				spec := createDeliverySpec(testCase.deadLetterUri, false)
+				deliverySpec = &spec
+			}
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, deadLetterUrl, &retryConfig, testCase.dispatchErr).WithHTTPResponses(testCase.httpResponses...)
+
+			// Create The Handler To Test
+			handler := createTestHandler(t, testSubscriberURI, nil, deliverySpec)
+			handler.MessageDispatcher = mockMessageDispatcher
+			mockStatsReporter := handler.StatsReporter.(*dispatchertesting.MockStatsReporter)
+
+			// Background Start Consuming Claims
+			go func() {
+				assert.Nil(t, handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim))
+			}()
+
+			// Perform The Test (Waiting For The Message To Be Marked)
+			mockConsumerGroupClaim.MessageChan <- createConsumerMessage(t)
+			<-mockConsumerGroupSession.MarkMessageChan
+			close(mockConsumerGroupClaim.MessageChan)
+
+			// Verify The Dispatch Was Reported Once With The Expected Status Class & Outcome
+			assert.Equal(t, 1, mockStatsReporter.Dispatches(string(testSubscriberUID), testCase.wantStatusClass, testCase.wantSuccess))
+			assert.Equal(t, 0, mockStatsReporter.Dispatches(string(testSubscriberUID), testCase.wantStatusClass, !testCase.wantSuccess))
+		})
+	}
+}
+
+// Test The subscriberResponseObserver Tracks Only The Subscriber's Final Response Across Retries
+func TestSubscriberResponseObserver(t *testing.T) {
+
+	// Create The Observer To Test Wrapping A CheckRetry Which Retries Any Non-2xx Response
+	observer := &subscriberResponseObserver{subscriberURL: testSubscriberURI.URL(), retryMax: 2}
+	checkRetry := observer.wrapCheckRetry(func(_ context.Context, response *http.Response, _ error) (bool, error) {
+		return response == nil || response.StatusCode >= 300, nil
+	})
+
+	// Perform The Test (Subscriber Retried Then Accepted, Followed By A Failed Reply)
+	for _, response := range []*http.Response{
+		nil,
+		createHTTPResponse(testSubscriberURI, http.StatusInternalServerError),
+		createHTTPResponse(testSubscriberURI, http.StatusOK),
+		createHTTPResponse(testReplyURI, http.StatusBadGateway),
+	} {
+		_, _ = checkRetry(context.TODO(), response, nil)
+	}
+
+	// Verify The Subscriber's Final Response Was Observed & The Dispatch Counted As An Error Of The Subscriber's Class
+	assert.True(t, observer.observed)
+	assert.Equal(t, 3, observer.attempts)
+	assert.Equal(t, http.StatusOK, observer.statusCode)
+	statusClass, success := dispatchOutcome(observer, errors.New("failed to forward reply"))
+	assert.Equal(t, "2xx", statusClass)
+	assert.False(t, success)
+}
+
+// Test The Handler's Cleanup() Functionality Commits The Offsets Marked Before A Re-Balance Revoked The Partition
+func TestHandlerCleanupCommitOnRebalance(t *testing.T) {
 
 	// Define The TestCase Type
 	type TestCase struct {
-		only           bool
-		name           string
-		destinationUri *apis.URL
-		replyUri       *apis.URL
-		deadLetterUri  *apis.URL
-		retry          bool
+		name                string
+		commitOnRebalance   bool
+		wantCommitCount     int
+		wantCommittedOffset int64
 	}
 
-	// Define The TestCases
+	// Define The TestCases (Auto-Commit Enabled, With No AutoCommit Interval Elapsing During The Test)
 	testCases := []TestCase{
 		{
-			name:           "Complete Subscriber Configuration",
-			destinationUri: testSubscriberURI,
-			replyUri:       testReplyURI,
-			deadLetterUri:  testDeadLetterURI,
-			retry:          true,
+			name:                "Commit On Rebalance",
+			commitOnRebalance:   true,
+			wantCommitCount:     1,
+			wantCommittedOffset: testOffset + 3,
 		},
 		{
-			name:          "No Subscriber URL",
-			replyUri:      testReplyURI,
-			deadLetterUri: testDeadLetterURI,
-			retry:         true,
+			name:                "No Commit On Rebalance",
+			commitOnRebalance:   false,
+			wantCommitCount:     0,
+			wantCommittedOffset: 0,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			session := newOffsetRecordingSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			messageDispatcher := newBlockingMessageDispatcher()
+
+			// Create The Handler To Test
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.MessageDispatcher = messageDispatcher
+			handler.CommitOnRebalance = testCase.commitOnRebalance
+
+			// Background Start Consuming Claims
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Deliver Two Messages & Then Re-Balance While The Third Delivery Is In-Flight)
+			for index := 0; index < 3; index++ {
+				consumerMessage := createConsumerMessage(t)
+				consumerMessage.Offset = testOffset + int64(index)
+				mockConsumerGroupClaim.MessageChan <- consumerMessage
+				assert.Eventually(t, func() bool { return messageDispatcher.inProgress() == 1 }, 5*time.Second, time.Millisecond)
+				if index < 2 {
+					messageDispatcher.releaseChan <- struct{}{}
+				}
+			}
+			close(mockConsumerGroupClaim.MessageChan)
+			messageDispatcher.releaseAll()
+
+			// Verify Consumption Stops Once The In-Flight Message Has Been Delivered & Marked
+			select {
+			case err := <-errChan:
+				assert.Nil(t, err)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "ConsumeClaim did not return after the partition was revoked")
+			}
+			assert.Equal(t, int64(testOffset+3), session.marked())
+
+			// Verify Sarama's Cleanup() Of The Revoked Partitions Commits All Marked Offsets (If Enabled)
+			assert.Nil(t, handler.Cleanup(session))
+			commitCount, committedOffset := session.committed()
+			assert.Equal(t, testCase.wantCommitCount, commitCount)
+			assert.Equal(t, testCase.wantCommittedOffset, committedOffset)
+		})
+	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Messages Which Cannot Be Deserialized
+func TestHandlerConsumeClaimDeserializationFailure(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only             bool
+		name             string
+		policy           string
+		deadLetterUri    *apis.URL
+		dispatchErr      error
+		expectDispatched bool
+		expectMarked     bool
+		expectMetric     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Skip Policy",
+			policy:       constants.DeserializationFailurePolicySkip,
+			expectMarked: true,
+			expectMetric: true,
 		},
 		{
-			name:           "No Reply URL",
-			destinationUri: testSubscriberURI,
-			deadLetterUri:  testDeadLetterURI,
-			retry:          true,
+			name:             "DeadLetter Policy",
+			policy:           constants.DeserializationFailurePolicyDeadLetter,
+			deadLetterUri:    testDeadLetterURI,
+			expectDispatched: true,
+			expectMarked:     true,
 		},
 		{
-			name:           "No DeadLetter URL",
-			destinationUri: testSubscriberURI,
-			replyUri:       testReplyURI,
-			retry:          true,
+			name:   "DeadLetter Policy Without DeadLetterSink",
+			policy: constants.DeserializationFailurePolicyDeadLetter,
 		},
 		{
-			name:           "No Retry",
-			destinationUri: testSubscriberURI,
-			replyUri:       testReplyURI,
-			deadLetterUri:  testDeadLetterURI,
-			retry:          false,
+			name:             "DeadLetter Policy With DeadLetterSink Failure",
+			policy:           constants.DeserializationFailurePolicyDeadLetter,
+			deadLetterUri:    testDeadLetterURI,
+			dispatchErr:      errors.New("test dispatch error"),
+			expectDispatched: true,
 		},
 		{
-			name:  "Empty Subscriber Configuration",
-			retry: false,
+			name:   "Fail Policy",
+			policy: constants.DeserializationFailurePolicyFail,
 		},
 	}
 
@@ -152,48 +1326,280 @@ func TestHandlerConsumeClaim(t *testing.T) {
 	// Execute The Individual Test Cases
 	for _, testCase := range filteredTestCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			performHandlerConsumeClaimTest(t, testCase.destinationUri, testCase.replyUri, testCase.deadLetterUri, testCase.retry)
+
+			// Initialize DeadLetter As Specified
+			var deadLetterUrl *url.URL
+			if testCase.deadLetterUri != nil {
+				deadLetterUrl = testCase.deadLetterUri.URL()
+			}
+
+			// Create Mocks For Testing (DeadLetter Messages Are Dispatched Directly To The DeadLetterSink & The Session Ends,
+			// Ending Redelivery Of Any Message Blocking The Partition)
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession, endSession := createEndingConsumerGroupSession(t)
+			defer endSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, deadLetterUrl, nil, nil, &retryConfig, testCase.dispatchErr)
+			mockStatsReporter := dispatchertesting.NewMockStatsReporter()
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With The Specified Policy
+			deliverySpec := createDeliverySpec(testCase.deadLetterUri, false)
+			handler := createTestHandler(t, testSubscriberURI, testReplyURI, &deliverySpec)
+			handler.DeserializationFailurePolicy = testCase.policy
+			handler.StatsReporter = mockStatsReporter
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A Malformed ConsumerMessage To Claims)
+			consumerMessage := createMalformedConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Wait For The Message To Be Marked Or For ConsumeClaim() To Stop
+			var err error
+			select {
+			case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+				assert.True(t, testCase.expectMarked)
+				assert.Equal(t, consumerMessage, markedMessage)
+				close(mockConsumerGroupClaim.MessageChan)
+				err = <-errChan
+				assert.Nil(t, err)
+			case err = <-errChan:
+				assert.False(t, testCase.expectMarked)
+				assert.True(t, errors.Is(err, ErrDeserializationFailure))
+				assert.Contains(t, err.Error(), binding.ErrUnknownEncoding.Error())
+			}
+
+			// Verify The Deserialization Failure Metric
+			if testCase.expectMetric {
+				assert.Equal(t, 1, mockStatsReporter.DeserializationFailures[testCase.policy])
+			} else {
+				assert.Empty(t, mockStatsReporter.DeserializationFailures)
+			}
+
+			// Verify The Message Sent To The DeadLetterSink (Wraps Raw Value & Includes Parse Error)
+			if testCase.expectDispatched {
+				dispatchedEvent, err := binding.ToEvent(context.TODO(), mockMessageDispatcher.Message())
+				assert.Nil(t, err)
+				assert.Equal(t, constants.DeserializationFailureEventType, dispatchedEvent.Type())
+				assert.Equal(t, testMalformedMsgContent, string(dispatchedEvent.Data()))
+				assert.Equal(t, binding.ErrUnknownEncoding.Error(), dispatchedEvent.Extensions()[constants.DeserializationFailureErrorExtension])
+			} else {
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
 		})
 	}
 }
 
-// Test One Permutation Of The Handler's ConsumeClaim() Functionality
-func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, deadLetterUri *apis.URL, retry bool) {
+// Test The ParseDeserializationFailurePolicy() Functionality
+func TestParseDeserializationFailurePolicy(t *testing.T) {
 
-	// Initialize Destination As Specified
-	var destinationUrl *url.URL
-	if destinationUri != nil {
-		destinationUrl = destinationUri.URL()
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
 	}
 
-	// Initialize Reply As Specified
-	var replyUrl *url.URL
-	if replyUri != nil {
-		replyUrl = replyUri.URL()
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultDeserializationFailurePolicy},
+		{name: "Skip", policy: constants.DeserializationFailurePolicySkip, want: constants.DeserializationFailurePolicySkip},
+		{name: "DeadLetter", policy: constants.DeserializationFailurePolicyDeadLetter, want: constants.DeserializationFailurePolicyDeadLetter},
+		{name: "Fail", policy: constants.DeserializationFailurePolicyFail, want: constants.DeserializationFailurePolicyFail},
+		{name: "Invalid", policy: "ignore", wantErr: true},
 	}
 
-	// Initialize DeadLetter As Specified
-	var deadLetterUrl *url.URL
-	if deadLetterUri != nil {
-		deadLetterUrl = deadLetterUri.URL()
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseDeserializationFailurePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
 	}
+}
 
-	// Create The Specified DeliverySpec
-	deliverySpec := createDeliverySpec(deadLetterUri, retry)
+// Test The Handler's ConsumeClaim() Functionality With Tombstone Records (Null Value)
+func TestHandlerConsumeClaimTombstone(t *testing.T) {
 
-	// Create The Expected RetryConfig
-	var retryConfig kncloudevents.RetryConfig
-	if retry {
-		var err error
-		retryConfig, err = kncloudevents.RetryConfigFromDeliverySpec(deliverySpec)
-		assert.NotNil(t, retryConfig)
-		assert.Nil(t, err)
+	// Define The TestCase Type
+	type TestCase struct {
+		only             bool
+		name             string
+		policy           string
+		dataless         bool
+		manualCommit     bool
+		dispatchErr      error
+		expectDispatched bool
+		expectMarked     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Skip Policy",
+			policy:       constants.TombstonePolicySkip,
+			expectMarked: true,
+		},
+		{
+			name:             "Dispatch Policy",
+			policy:           constants.TombstonePolicyDispatch,
+			expectDispatched: true,
+			expectMarked:     true,
+		},
+		{
+			name:             "Dispatch Policy With Delivery Failure",
+			policy:           constants.TombstonePolicyDispatch,
+			dispatchErr:      errors.New("test dispatch error"),
+			expectDispatched: true,
+			expectMarked:     true,
+		},
+		{
+			name:             "Dispatch Policy With Delivery Failure & Auto-Commit Disabled",
+			policy:           constants.TombstonePolicyDispatch,
+			manualCommit:     true,
+			dispatchErr:      errors.New("test dispatch error"),
+			expectDispatched: true,
+		},
+		{
+			name:             "Binary CloudEvent Without Data Is Not A Tombstone",
+			policy:           constants.TombstonePolicySkip,
+			dataless:         true,
+			expectDispatched: true,
+			expectMarked:     true,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing (With A Session Which Ends, Ending Redelivery Of Any Undelivered Message)
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession, endSession := createEndingConsumerGroupSession(t)
+			defer endSession()
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, testCase.dispatchErr)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With The Specified Policy
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.TombstonePolicy = testCase.policy
+			handler.ManualCommit = testCase.manualCommit
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A Tombstone, Or Data-Less Binary CloudEvent, ConsumerMessage To Claims)
+			consumerMessage := createTombstoneConsumerMessage()
+			if testCase.dataless {
+				consumerMessage = createConsumerMessage(t)
+				consumerMessage.Value = nil
+			}
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Wait For The Message To Be Marked Or For ConsumeClaim() To Stop (Committing Without Marking)
+			select {
+			case markedMessage := <-mockConsumerGroupSession.MarkMessageChan:
+				assert.True(t, testCase.expectMarked)
+				assert.Equal(t, consumerMessage, markedMessage)
+				close(mockConsumerGroupClaim.MessageChan)
+				assert.Nil(t, <-errChan)
+			case <-mockConsumerGroupSession.CommitChan:
+				assert.False(t, testCase.expectMarked)
+				assert.Equal(t, testCase.dispatchErr, <-errChan)
+			}
+
+			// Verify The Data-Less CloudEvent Or Delete-Typed CloudEvent (Identifies The Key & Has No Data) Dispatched To The Subscriber
+			if testCase.expectDispatched && testCase.dataless {
+				dispatchedEvent, err := binding.ToEvent(context.TODO(), mockMessageDispatcher.Message())
+				assert.Nil(t, err)
+				assert.Equal(t, testMsgType, dispatchedEvent.Type())
+				assert.Equal(t, testMsgId, dispatchedEvent.ID())
+				assert.Nil(t, dispatchedEvent.Data())
+			} else if testCase.expectDispatched {
+				dispatchedEvent, err := binding.ToEvent(context.TODO(), mockMessageDispatcher.Message())
+				assert.Nil(t, err)
+				assert.Equal(t, constants.TombstoneEventType, dispatchedEvent.Type())
+				assert.Equal(t, fmt.Sprintf("%s-%d-%d", testTopic, testPartition, testOffset), dispatchedEvent.ID())
+				assert.Equal(t, testTombstoneKey, dispatchedEvent.Extensions()[constants.TombstoneKeyExtension])
+				assert.Nil(t, dispatchedEvent.Data())
+			} else {
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
+		})
+	}
+}
+
+// Test The ParseTombstonePolicy() Functionality
+func TestParseTombstonePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultTombstonePolicy},
+		{name: "Skip", policy: constants.TombstonePolicySkip, want: constants.TombstonePolicySkip},
+		{name: "Dispatch", policy: constants.TombstonePolicyDispatch, want: constants.TombstonePolicyDispatch},
+		{name: "Invalid", policy: "delete", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseTombstonePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
 	}
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Topic Warm-Up Marker Records (Skipped Without Dispatching)
+func TestHandlerConsumeClaimWarmupMarker(t *testing.T) {
 
 	// Create Mocks For Testing
+	retryConfig := kncloudevents.NoRetries()
 	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
 	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
-	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, destinationUrl, replyUrl, deadLetterUrl, &retryConfig, nil)
+	mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
 
 	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
 	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
@@ -203,28 +1609,209 @@ func performHandlerConsumeClaimTest(t *testing.T, destinationUri, replyUri, dead
 	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
 
 	// Create The Handler To Test
-	handler := createTestHandler(t, destinationUri, replyUri, &deliverySpec)
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
 
 	// Background Start Consuming Claims
+	errChan := make(chan error)
 	go func() {
-		err := handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
-		assert.Nil(t, err)
+		errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
 	}()
 
-	// Perform The Test (Add ConsumerMessages To Claims)
-	consumerMessage := createConsumerMessage(t)
+	// Perform The Test (Add A Warm-Up Marker ConsumerMessage, Which Is Not A CloudEvent, To Claims)
+	consumerMessage := &sarama.ConsumerMessage{
+		Headers:   []*sarama.RecordHeader{{Key: []byte(kafkaconstants.WarmupMarkerHeaderKey), Value: []byte("true")}},
+		Timestamp: time.Now(),
+		Value:     []byte("TestMarkerValue"),
+		Topic:     testTopic,
+		Partition: testPartition,
+		Offset:    testOffset,
+	}
 	mockConsumerGroupClaim.MessageChan <- consumerMessage
 
-	// Wait For Message To Be Marked As Complete
-	markedMessage := <-mockConsumerGroupSession.MarkMessageChan
-
-	// Close The Mock ConsumerGroupClaim Message Channel To Complete/Exit Handler's ConsumeClaim()
+	// Verify The Marker Record Was Marked Without Being Dispatched
+	assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
 	close(mockConsumerGroupClaim.MessageChan)
+	assert.Nil(t, <-errChan)
+	assert.Nil(t, mockMessageDispatcher.Message())
+}
 
-	// Verify The Results (CloudEvent Was Dispatched & ConsumerMessage Was Marked)
-	assert.Equal(t, consumerMessage, markedMessage)
-	assert.NotNil(t, mockMessageDispatcher.Message())
-	verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+// Test The Handler's ConsumeClaim() Functionality With Messages Exceeding The Maximum Message Size
+func TestHandlerConsumeClaimOversizedMessage(t *testing.T) {
+
+	// Test Data (The Size Of The Valid Test ConsumerMessage)
+	messageSize := kafkautil.ConsumerMessageSize(createConsumerMessage(t))
+
+	// Define The TestCase Type
+	type TestCase struct {
+		only               bool
+		name               string
+		maxMessageBytes    int64
+		policy             string
+		deadLetterUri      *apis.URL
+		dispatchErr        error
+		expectSubscriber   bool
+		expectDeadLetter   bool
+		expectMetricPolicy string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:             "Unlimited",
+			maxMessageBytes:  0,
+			policy:           constants.OversizedMessagePolicySkip,
+			expectSubscriber: true,
+		},
+		{
+			name:             "Under Maximum",
+			maxMessageBytes:  messageSize + 1,
+			policy:           constants.OversizedMessagePolicySkip,
+			expectSubscriber: true,
+		},
+		{
+			name:             "At Maximum",
+			maxMessageBytes:  messageSize,
+			policy:           constants.OversizedMessagePolicyDeadLetter,
+			deadLetterUri:    testDeadLetterURI,
+			expectSubscriber: true,
+		},
+		{
+			name:               "Over Maximum Skip Policy",
+			maxMessageBytes:    messageSize - 1,
+			policy:             constants.OversizedMessagePolicySkip,
+			deadLetterUri:      testDeadLetterURI,
+			expectMetricPolicy: constants.OversizedMessagePolicySkip,
+		},
+		{
+			name:               "Over Maximum DeadLetter Policy",
+			maxMessageBytes:    messageSize - 1,
+			policy:             constants.OversizedMessagePolicyDeadLetter,
+			deadLetterUri:      testDeadLetterURI,
+			expectDeadLetter:   true,
+			expectMetricPolicy: constants.OversizedMessagePolicyDeadLetter,
+		},
+		{
+			name:               "Over Maximum DeadLetter Policy With DeadLetterSink Failure",
+			maxMessageBytes:    messageSize - 1,
+			policy:             constants.OversizedMessagePolicyDeadLetter,
+			deadLetterUri:      testDeadLetterURI,
+			dispatchErr:        errors.New("test dispatch error"),
+			expectDeadLetter:   true,
+			expectMetricPolicy: constants.OversizedMessagePolicyDeadLetter,
+		},
+		{
+			name:               "Over Maximum DeadLetter Policy Without DeadLetterSink",
+			maxMessageBytes:    messageSize - 1,
+			policy:             constants.OversizedMessagePolicyDeadLetter,
+			expectMetricPolicy: constants.OversizedMessagePolicySkip,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Initialize DeadLetter As Specified
+			var deadLetterUrl *url.URL
+			if testCase.deadLetterUri != nil {
+				deadLetterUrl = testCase.deadLetterUri.URL()
+			}
+
+			// Create Mocks For Testing (Oversized Messages Are Dispatched Directly To The DeadLetterSink)
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, deadLetterUrl, &retryConfig, testCase.dispatchErr)
+			if testCase.expectDeadLetter {
+				mockMessageDispatcher = dispatchertesting.NewMockMessageDispatcher(t, nil, deadLetterUrl, nil, nil, &retryConfig, testCase.dispatchErr)
+			}
+			mockStatsReporter := dispatchertesting.NewMockStatsReporter()
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test With The Specified Maximum Message Size & Policy
+			deliverySpec := createDeliverySpec(testCase.deadLetterUri, false)
+			handler := createTestHandler(t, testSubscriberURI, nil, &deliverySpec)
+			handler.MaxMessageBytes = testCase.maxMessageBytes
+			handler.OversizedMessagePolicy = testCase.policy
+			handler.StatsReporter = mockStatsReporter
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A ConsumerMessage To Claims)
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify The Message Is Always Marked (Oversized Messages Never Block The Partition)
+			markedMessage := <-mockConsumerGroupSession.MarkMessageChan
+			assert.Equal(t, consumerMessage, markedMessage)
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+
+			// Verify The Message Was Dispatched To The Subscriber / DeadLetterSink (As-Is) Or Not At All
+			if testCase.expectSubscriber || testCase.expectDeadLetter {
+				verifyDispatchedMessage(t, mockMessageDispatcher.Message())
+			} else {
+				assert.Nil(t, mockMessageDispatcher.Message())
+			}
+
+			// Verify The Oversized Message Metric
+			if len(testCase.expectMetricPolicy) > 0 {
+				assert.Equal(t, map[string]int{testCase.expectMetricPolicy: 1}, mockStatsReporter.OversizedMessages)
+			} else {
+				assert.Empty(t, mockStatsReporter.OversizedMessages)
+			}
+		})
+	}
+}
+
+// Test The ParseOversizedMessagePolicy() Functionality
+func TestParseOversizedMessagePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultOversizedMessagePolicy},
+		{name: "Skip", policy: constants.OversizedMessagePolicySkip, want: constants.OversizedMessagePolicySkip},
+		{name: "DeadLetter", policy: constants.OversizedMessagePolicyDeadLetter, want: constants.OversizedMessagePolicyDeadLetter},
+		{name: "Invalid", policy: "fail", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseOversizedMessagePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
 }
 
 // Test The Custom CheckRetry() Implementation
@@ -399,6 +1986,19 @@ func createDeliverySpec(deadLetterUri *apis.URL, retry bool) eventingduck.Delive
 	return deliverySpec
 }
 
+// Utility Function For Creating An HTTP Response With The Specified Status Code To A Request To The Specified URL
+func createHTTPResponse(requestUri *apis.URL, statusCode int) *http.Response {
+	return &http.Response{StatusCode: statusCode, Request: &http.Request{URL: requestUri.URL()}}
+}
+
+// Utility Function For Creating A Mock ConsumerGroupSession Which Ends Shortly (Before Any Redelivery With The Default Back-Off)
+func createEndingConsumerGroupSession(t *testing.T) (dispatchertesting.MockConsumerGroupSession, context.CancelFunc) {
+	session := dispatchertesting.NewMockConsumerGroupSession(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	session.Ctx = ctx
+	return session, cancel
+}
+
 // Utility Function For Creating New Handler
 func createTestHandler(t *testing.T, subscriberURL *apis.URL, replyUrl *apis.URL, delivery *eventingduck.DeliverySpec) *Handler {
 
@@ -413,13 +2013,16 @@ func createTestHandler(t *testing.T, subscriberURL *apis.URL, replyUrl *apis.URL
 	}
 
 	// Perform The Test Create The Test Handler
-	handler := NewHandler(logger, testSubscriber)
+	handler := NewHandler(logger, testSubscriber, constants.DefaultDeserializationFailurePolicy, constants.DefaultTombstonePolicy, false, dispatchertesting.NewMockStatsReporter())
 
 	// Verify The Results
 	assert.NotNil(t, handler)
 	assert.Equal(t, logger, handler.Logger)
 	assert.Equal(t, testSubscriber, handler.Subscriber)
 	assert.NotNil(t, handler.MessageDispatcher)
+	assert.Equal(t, constants.DefaultDeserializationFailurePolicy, handler.DeserializationFailurePolicy)
+	assert.Equal(t, constants.DefaultTombstonePolicy, handler.TombstonePolicy)
+	assert.False(t, handler.StripProvenanceHeaders)
 
 	// Return The Handler
 	return handler
@@ -479,3 +2082,34 @@ func createConsumerMessage(t *testing.T) *sarama.ConsumerMessage {
 	// Return The Test ConsumerMessage
 	return consumerMessage
 }
+
+// Utility Function For Creating ConsumerMessages Which Cannot Be Deserialized Into CloudEvents
+func createMalformedConsumerMessage(t *testing.T) *sarama.ConsumerMessage {
+
+	// Create The ConsumerMessage To Test (No CloudEvent Headers Or Structured Content-Type)
+	consumerMessage := &sarama.ConsumerMessage{
+		Timestamp: time.Now(),
+		Value:     []byte(testMalformedMsgContent),
+		Topic:     testTopic,
+		Partition: testPartition,
+		Offset:    testOffset,
+	}
+
+	// Quick Run Through Of CloudEvents SDK Binding Message Conversion To Ensure Invalidity
+	assert.Equal(t, binding.EncodingUnknown, kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage).ReadEncoding())
+
+	// Return The Test ConsumerMessage
+	return consumerMessage
+}
+
+// Utility Function For Creating Tombstone ConsumerMessages (Key With Null Value, As Written To Compacted Topics)
+func createTombstoneConsumerMessage() *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Timestamp: time.Now(),
+		Key:       []byte(testTombstoneKey),
+		Value:     nil,
+		Topic:     testTopic,
+		Partition: testPartition,
+		Offset:    testOffset,
+	}
+}
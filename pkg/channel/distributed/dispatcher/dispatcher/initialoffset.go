@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+//
+// Parse The KafkaChannel's InitialOffsets Annotation Into Maps Of Subscriber UID Or URI To Sarama Initial Offset / Source
+// ConsumerGroup / Error
+//
+// The annotation is a JSON object whose keys identify subscribers either by their (Subscription) UID or by their
+// subscriber URI (the latter surviving resubscriptions which change the UID), and whose values are one of the
+// constants.InitialOffset* values, or the constants.InitialOffsetGroupPrefix followed by the ID of a source ConsumerGroup
+// whose committed offsets are copied to the subscriber's new ConsumerGroup (e.g. when migrating to a new GroupId).
+// An empty annotation results in empty maps (all subscribers using the default).  Subscribers with an invalid initial
+// offset are returned in the third map, so that only those subscribers are failed, whereas an annotation which is not
+// a JSON object of strings is returned as an error.
+//
+func ParseInitialOffsets(annotation string) (map[string]int64, map[string]string, map[string]error, error) {
+
+	initialOffsets := make(map[string]int64)
+	sourceGroupIds := make(map[string]string)
+	invalidInitialOffsets := make(map[string]error)
+	if len(annotation) <= 0 {
+		return initialOffsets, sourceGroupIds, invalidInitialOffsets, nil
+	}
+
+	// Unmarshal The JSON Annotation
+	policies := make(map[string]string)
+	if err := json.Unmarshal([]byte(annotation), &policies); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid %s annotation: %w", constants.InitialOffsetsAnnotation, err)
+	}
+
+	// Convert Each Subscriber's Policy To The Corresponding Sarama Offset Or Source ConsumerGroup
+	for key, policy := range policies {
+		switch {
+		case policy == constants.InitialOffsetOldest:
+			initialOffsets[key] = sarama.OffsetOldest
+		case policy == constants.InitialOffsetNewest:
+			initialOffsets[key] = sarama.OffsetNewest
+		case strings.HasPrefix(policy, constants.InitialOffsetGroupPrefix) && len(policy) > len(constants.InitialOffsetGroupPrefix):
+			sourceGroupIds[key] = strings.TrimPrefix(policy, constants.InitialOffsetGroupPrefix)
+		default:
+			invalidInitialOffsets[key] = fmt.Errorf("invalid %s annotation: initial offset '%s' of subscriber '%s' must be one of '%s' or '%s' (or a source consumer group as '%s<group-id>')",
+				constants.InitialOffsetsAnnotation, policy, key, constants.InitialOffsetOldest, constants.InitialOffsetNewest, constants.InitialOffsetGroupPrefix)
+		}
+	}
+
+	return initialOffsets, sourceGroupIds, invalidInitialOffsets, nil
+}
+
+//
+// Update The Per-Subscriber Initial Offsets From The Specified KafkaChannel InitialOffsets Annotation
+//
+// The initial offsets are applied by the next UpdateSubscriptions(), which creates the ConsumerGroups of new subscribers
+// with them, and closes & recreates the ConsumerGroup of any existing subscriber whose initial offset has changed (so
+// that a changed policy is not silently ignored).  Note that the initial offset only determines where a ConsumerGroup
+// starts consuming partitions without committed offsets, so a recreated ConsumerGroup otherwise resumes from where it
+// left off (the same applying to the offsets copied from a source ConsumerGroup, which also happens on recreation).  Subscribers with an invalid initial offset are failed by UpdateSubscriptions() (their existing ConsumerGroup,
+// if any, being left as-is), whereas an annotation which cannot be parsed at all is returned as an error, in which
+// case the previous initial offsets are retained.
+//
+func (d *DispatcherImpl) UpdateInitialOffsets(annotation string) error {
+
+	initialOffsets, sourceGroupIds, invalidInitialOffsets, err := ParseInitialOffsets(annotation)
+	if err != nil {
+		d.Logger.Error("Failed To Parse Subscriber Initial Offsets", zap.Error(err))
+		return err
+	}
+
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	d.initialOffsets = initialOffsets
+	d.sourceGroupIds = sourceGroupIds
+	d.invalidInitialOffsets = invalidInitialOffsets
+	return nil
+}
+
+//
+// Get The Initial Offset & Source ConsumerGroup (If Any) Of The Specified Subscriber's ConsumerGroup
+//
+// The initial offset is the Sarama config's unless specified in the annotation, including for subscribers with a source
+// ConsumerGroup, whose partitions without offsets to copy start from it as usual.
+//
+func (d *DispatcherImpl) subscriberInitialOffset(subscriberSpec eventingduck.SubscriberSpec) (int64, string, error) {
+
+	// Subscribers Are Identified By UID In Preference To Their URI
+	keys := []string{string(subscriberSpec.UID)}
+	if subscriberSpec.SubscriberURI != nil {
+		keys = append(keys, subscriberSpec.SubscriberURI.String())
+	}
+	for _, key := range keys {
+		if err, ok := d.invalidInitialOffsets[key]; ok {
+			return 0, "", err
+		}
+		if initialOffset, ok := d.initialOffsets[key]; ok {
+			return initialOffset, "", nil
+		}
+		if sourceGroupId, ok := d.sourceGroupIds[key]; ok {
+			return d.SaramaConfig.Consumer.Offsets.Initial, sourceGroupId, nil
+		}
+	}
+	return d.SaramaConfig.Consumer.Offsets.Initial, "", nil
+}
+
+// Get The Sarama Config For Creating A Subscriber's ConsumerGroup With The Specified Initial Offset (& The Delivery Guarantee)
+func (d *DispatcherImpl) consumerGroupConfig(initialOffset int64) *sarama.Config {
+
+	// Use The Shared Sarama Config Unless A Different Initial Offset Is Specified
+	config := d.SaramaConfig
+	if initialOffset != d.SaramaConfig.Consumer.Offsets.Initial {
+		initialOffsetConfig := *d.SaramaConfig
+		initialOffsetConfig.Consumer.Offsets.Initial = initialOffset
+		config = &initialOffsetConfig
+	}
+
+	// Apply The Consumer Settings Of The KafkaChannel's Delivery Guarantee (If Any - Validated When Updated)
+	guaranteeConfig, err := delivery.ConfigureConsumer(config, d.deliveryGuarantee)
+	if err != nil {
+		d.Logger.Error("Failed To Apply Delivery Guarantee - Using Default Consumer Settings", zap.String("Guarantee", d.deliveryGuarantee), zap.Error(err))
+		return config
+	}
+	return guaranteeConfig
+}
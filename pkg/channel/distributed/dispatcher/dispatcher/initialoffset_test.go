@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseInitialOffsets() Functionality
+func TestParseInitialOffsets(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		annotation  string
+		want        map[string]int64
+		wantSources map[string]string
+		wantInvalid []string
+		wantErr     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", annotation: "", want: map[string]int64{}},
+		{name: "Empty", annotation: "{}", want: map[string]int64{}},
+		{
+			name:       "Oldest & Newest",
+			annotation: `{"` + id123 + `":"oldest","http://subscriber.example.com":"newest"}`,
+			want:       map[string]int64{id123: sarama.OffsetOldest, "http://subscriber.example.com": sarama.OffsetNewest},
+		},
+		{
+			name:        "Source ConsumerGroup",
+			annotation:  `{"` + id123 + `":"group:legacy-group","` + id456 + `":"newest"}`,
+			want:        map[string]int64{id456: sarama.OffsetNewest},
+			wantSources: map[string]string{id123: "legacy-group"},
+		},
+		{name: "Invalid Offset", annotation: `{"` + id123 + `":"latest"}`, want: map[string]int64{}, wantInvalid: []string{id123}},
+		{name: "Empty Source ConsumerGroup", annotation: `{"` + id123 + `":"group:"}`, want: map[string]int64{}, wantInvalid: []string{id123}},
+		{
+			name:        "Valid & Invalid Offsets",
+			annotation:  `{"` + id123 + `":"oldest","` + id456 + `":"earliest","` + id789 + `":""}`,
+			want:        map[string]int64{id123: sarama.OffsetOldest},
+			wantInvalid: []string{id456, id789},
+		},
+		{name: "Invalid JSON", annotation: `oldest`, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			initialOffsets, sourceGroupIds, invalidInitialOffsets, err := ParseInitialOffsets(testCase.annotation)
+			assert.Equal(t, testCase.want, initialOffsets)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantErr {
+				assert.Nil(t, sourceGroupIds)
+				assert.Nil(t, invalidInitialOffsets)
+			} else {
+				if testCase.wantSources == nil {
+					testCase.wantSources = map[string]string{}
+				}
+				assert.Equal(t, testCase.wantSources, sourceGroupIds)
+				assert.Len(t, invalidInitialOffsets, len(testCase.wantInvalid))
+				for _, key := range testCase.wantInvalid {
+					assert.Contains(t, invalidInitialOffsets[key].Error(), "of subscriber '"+key+"' must be one of 'oldest' or 'newest'")
+				}
+			}
+		})
+	}
+}
+
+// Test That The Per-Subscriber Initial Offsets Are Applied When Creating (& Recreating) The Subscribers' ConsumerGroups
+func TestUpdateInitialOffsets(t *testing.T) {
+
+	// Test Data
+	subscriberURI := apis.HTTP("subscriber.example.com")
+	uidSubscriber := eventingduck.SubscriberSpec{UID: uid123}
+	uriSubscriber := eventingduck.SubscriberSpec{UID: uid456, SubscriberURI: subscriberURI}
+	defaultSubscriber := eventingduck.SubscriberSpec{UID: uid789}
+	subscriberSpecs := []eventingduck.SubscriberSpec{uidSubscriber, uriSubscriber, defaultSubscriber}
+
+	// Replace The NewConsumerGroupWrapper With Mock Recording The Initial Offset Of Each GroupId Creation & Restore After Test
+	var initialOffsetsLock sync.Mutex
+	initialOffsets := make(map[string][]int64)
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		initialOffsetsLock.Lock()
+		defer initialOffsetsLock.Unlock()
+		initialOffsets[groupIdArg] = append(initialOffsets[groupIdArg], configArg.Consumer.Offsets.Initial)
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+	verifyInitialOffsets := func(expected map[string][]int64) {
+		initialOffsetsLock.Lock()
+		defer initialOffsetsLock.Unlock()
+		assert.Equal(t, expected, initialOffsets)
+	}
+
+	// Create A New DispatcherImpl To Test (Defaulting To The Newest Offset)
+	saramaConfig := getSaramaConfigFromYaml(t, TestConfigBase)
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		Topic:        testTopic,
+		SaramaConfig: saramaConfig,
+	}).(*DispatcherImpl)
+	defer dispatcher.Shutdown(context.TODO())
+
+	// Verify An Unparseable Annotation Is Rejected
+	assert.NotNil(t, dispatcher.UpdateInitialOffsets(`oldest`))
+
+	// Perform The Test - Specify The Oldest Offset For One Subscriber By UID & Another By URI
+	err := dispatcher.UpdateInitialOffsets(`{"` + id123 + `":"oldest","` + subscriberURI.String() + `":"oldest","` + id789 + `":"newest"}`)
+	assert.Nil(t, err)
+	failedSubscriptions := updateSubscriptions(t, dispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 0)
+
+	// Verify The Initial Offset Of Each ConsumerGroup On Creation
+	verifyInitialOffsets(map[string][]int64{
+		"kafka." + id123: {sarama.OffsetOldest},
+		"kafka." + id456: {sarama.OffsetOldest},
+		"kafka." + id789: {sarama.OffsetNewest},
+	})
+
+	// Verify The Shared Sarama Config Is Unchanged
+	assert.Equal(t, sarama.OffsetNewest, dispatcher.SaramaConfig.Consumer.Offsets.Initial)
+
+	// Verify The ConsumerGroups Are Not Recreated While The Initial Offsets Are Unchanged
+	failedSubscriptions = updateSubscriptions(t, dispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 0)
+	verifyInitialOffsets(map[string][]int64{
+		"kafka." + id123: {sarama.OffsetOldest},
+		"kafka." + id456: {sarama.OffsetOldest},
+		"kafka." + id789: {sarama.OffsetNewest},
+	})
+
+	// Verify Only The ConsumerGroups Whose Initial Offsets Changed (Reverting To The Default) Are Closed & Recreated
+	uidConsumerGroup := dispatcher.subscribers[uid123].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	defaultConsumerGroup := dispatcher.subscribers[uid789].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(""))
+	failedSubscriptions = updateSubscriptions(t, dispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 0)
+	verifyInitialOffsets(map[string][]int64{
+		"kafka." + id123: {sarama.OffsetOldest, sarama.OffsetNewest},
+		"kafka." + id456: {sarama.OffsetOldest, sarama.OffsetNewest},
+		"kafka." + id789: {sarama.OffsetNewest},
+	})
+	assert.True(t, uidConsumerGroup.Closed)
+	assert.False(t, defaultConsumerGroup.Closed)
+	assert.Len(t, dispatcher.subscribers, 3)
+	assert.Equal(t, sarama.OffsetNewest, dispatcher.subscribers[uid123].InitialOffset)
+
+	// Verify Subscribers With An Invalid Initial Offset Are Failed, Retaining Any Existing ConsumerGroup As-Is
+	newSubscriber := eventingduck.SubscriberSpec{UID: "new-subscriber"}
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"latest","new-subscriber":"earliest","`+id789+`":"oldest"}`))
+	failedSubscriptions = updateSubscriptions(t, dispatcher, append(subscriberSpecs, newSubscriber))
+	assert.Len(t, failedSubscriptions, 2)
+	assert.Contains(t, failedSubscriptions[uidSubscriber].Error(), "initial offset 'latest' of subscriber '"+id123+"'")
+	assert.Contains(t, failedSubscriptions[newSubscriber].Error(), "initial offset 'earliest' of subscriber 'new-subscriber'")
+	verifyInitialOffsets(map[string][]int64{
+		"kafka." + id123: {sarama.OffsetOldest, sarama.OffsetNewest},
+		"kafka." + id456: {sarama.OffsetOldest, sarama.OffsetNewest},
+		"kafka." + id789: {sarama.OffsetNewest, sarama.OffsetOldest},
+	})
+	assert.Len(t, dispatcher.subscribers, 3)
+	assert.Contains(t, dispatcher.subscribers, uid123)
+	assert.NotContains(t, dispatcher.subscribers, types.UID("new-subscriber"))
+}
+
+// Test That A Dispatcher Recreated Due To A Sarama Config Change Tolerates Subscribers With An Invalid Initial Offset
+func TestConfigChangedInvalidInitialOffset(t *testing.T) {
+
+	// Test Data
+	invalidSubscriber := eventingduck.SubscriberSpec{UID: uid123}
+	validSubscriber := eventingduck.SubscriberSpec{UID: uid456}
+	subscriberSpecs := []eventingduck.SubscriberSpec{invalidSubscriber, validSubscriber}
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl With Both Subscribers & Then Invalidate The Initial Offset Of One Of Them
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		Topic:        testTopic,
+		SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+	}).(*DispatcherImpl)
+	failedSubscriptions := updateSubscriptions(t, dispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 0)
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"latest"}`))
+	failedSubscriptions = updateSubscriptions(t, dispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 1)
+	assert.Len(t, dispatcher.subscribers, 2)
+
+	// Perform The Test - Change The Sarama Config (Which Must Not Be Fatal)
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	newDispatcher := dispatcher.ConfigChanged(configMap)
+	assert.NotNil(t, newDispatcher)
+	defer newDispatcher.Shutdown(context.TODO())
+
+	// Verify Only The Valid Subscriber Is Subscribed By The New Dispatcher & The Invalid One Remains Failed
+	assert.Len(t, newDispatcher.(*DispatcherImpl).subscribers, 1)
+	assert.Contains(t, newDispatcher.(*DispatcherImpl).subscribers, uid456)
+	failedSubscriptions = updateSubscriptions(t, newDispatcher, subscriberSpecs)
+	assert.Len(t, failedSubscriptions, 1)
+	assert.Contains(t, failedSubscriptions[invalidSubscriber].Error(), "initial offset 'latest' of subscriber '"+id123+"'")
+}
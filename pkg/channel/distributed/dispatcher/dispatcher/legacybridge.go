@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+//
+// Attributes Of The CloudEvents Synthesized From Legacy (Non-CloudEvent) Records Of A Bridging KafkaChannel
+//
+// KafkaChannels whose Topics carry raw records from legacy producers may be marked as a bridge via the
+// constants.LegacyBridgeAnnotation, in which case any record which is not already a CloudEvent is wrapped in a
+// new CloudEvent (with the record's value as its data) rather than being treated as a deserialization failure.
+//
+type LegacyBridge struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+}
+
+//
+// Parse The KafkaChannel's LegacyBridge Annotation
+//
+// The annotation is a JSON object with the (required) "type" and "source" attributes of the synthesized CloudEvents,
+// the latter being a URI-reference.  An empty annotation results in a nil LegacyBridge (records are not wrapped).
+//
+func ParseLegacyBridge(annotation string) (*LegacyBridge, error) {
+
+	if len(annotation) <= 0 {
+		return nil, nil
+	}
+
+	// Unmarshal The JSON Annotation
+	legacyBridge := &LegacyBridge{}
+	if err := json.Unmarshal([]byte(annotation), legacyBridge); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", constants.LegacyBridgeAnnotation, err)
+	}
+
+	// Validate The CloudEvent Attributes
+	if len(legacyBridge.Type) <= 0 {
+		return nil, fmt.Errorf("invalid %s annotation: type must be specified", constants.LegacyBridgeAnnotation)
+	}
+	if len(legacyBridge.Source) <= 0 {
+		return nil, fmt.Errorf("invalid %s annotation: source must be specified", constants.LegacyBridgeAnnotation)
+	}
+	if _, err := url.Parse(legacyBridge.Source); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: source must be a URI-reference: %w", constants.LegacyBridgeAnnotation, err)
+	}
+
+	return legacyBridge, nil
+}
+
+//
+// Update The LegacyBridge From The Specified KafkaChannel LegacyBridge Annotation
+//
+// The LegacyBridge is looked up as each message is consumed, so a change applies to all subscribers immediately.  An
+// invalid annotation is returned as an error, in which case the previous LegacyBridge (if any) is retained.
+//
+func (d *DispatcherImpl) UpdateLegacyBridge(annotation string) error {
+
+	legacyBridge, err := ParseLegacyBridge(annotation)
+	if err != nil {
+		d.Logger.Error("Failed To Parse LegacyBridge", zap.Error(err))
+		return err
+	}
+
+	d.legacyBridgeLock.Lock()
+	defer d.legacyBridgeLock.Unlock()
+	d.legacyBridge = legacyBridge
+	return nil
+}
+
+// Get The Current LegacyBridge (Nil If The KafkaChannel Is Not A Bridge)
+func (d *DispatcherImpl) currentLegacyBridge() *LegacyBridge {
+	d.legacyBridgeLock.RLock()
+	defer d.legacyBridgeLock.RUnlock()
+	return d.legacyBridge
+}
+
+//
+// Wrap The Raw Value Of A Legacy (Non-CloudEvent) Record In A New CloudEvent With The LegacyBridge's Attributes
+//
+// The CloudEvent is identified by the record's coordinates (so redeliveries are recognizable as duplicates), and
+// carries the record's key (if any) in the partitioning extension.  Values which are valid JSON are dispatched as
+// "application/json" data, and all others as-is as "application/octet-stream" data.
+//
+func (b *LegacyBridge) wrap(consumerMessage *sarama.ConsumerMessage) binding.Message {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%s-%d-%d", consumerMessage.Topic, consumerMessage.Partition, consumerMessage.Offset))
+	event.SetSource(b.Source)
+	event.SetType(b.Type)
+	if consumerMessage.Key != nil {
+		event.SetExtension(constants.TombstoneKeyExtension, string(consumerMessage.Key))
+	}
+	if !consumerMessage.Timestamp.IsZero() {
+		event.SetTime(consumerMessage.Timestamp)
+	}
+	dataContentType := constants.LegacyBridgeBytesDataContentType
+	if json.Valid(consumerMessage.Value) {
+		dataContentType = constants.LegacyBridgeJsonDataContentType
+	}
+	_ = event.SetData(dataContentType, consumerMessage.Value) // []byte Data Is Stored As-Is & Never Fails
+	return binding.ToMessage(&event)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseLegacyBridge() Functionality
+func TestParseLegacyBridge(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		annotation string
+		want       *LegacyBridge
+		wantErr    bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", annotation: ""},
+		{
+			name:       "Type & Source",
+			annotation: `{"type":"com.example.legacy","source":"/legacy/orders"}`,
+			want:       &LegacyBridge{Type: "com.example.legacy", Source: "/legacy/orders"},
+		},
+		{name: "Missing Type", annotation: `{"source":"/legacy/orders"}`, wantErr: true},
+		{name: "Missing Source", annotation: `{"type":"com.example.legacy"}`, wantErr: true},
+		{name: "Invalid Source", annotation: `{"type":"com.example.legacy","source":"http://[::1"}`, wantErr: true},
+		{name: "Invalid JSON", annotation: `com.example.legacy`, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			legacyBridge, err := ParseLegacyBridge(testCase.annotation)
+			assert.Equal(t, testCase.want, legacyBridge)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That The LegacyBridge Reflects Subsequent Updates & Retains The Previous Value On Invalid Updates
+func TestUpdateLegacyBridge(t *testing.T) {
+
+	// Test Data
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()}).(*DispatcherImpl)
+	assert.Nil(t, dispatcher.currentLegacyBridge())
+
+	// Perform The Test - Mark The KafkaChannel As A LegacyBridge
+	assert.Nil(t, dispatcher.UpdateLegacyBridge(`{"type":"com.example.legacy","source":"/legacy/orders"}`))
+	assert.Equal(t, &LegacyBridge{Type: "com.example.legacy", Source: "/legacy/orders"}, dispatcher.currentLegacyBridge())
+
+	// Verify An Invalid Annotation Is Rejected & The Previous LegacyBridge Retained
+	assert.NotNil(t, dispatcher.UpdateLegacyBridge(`{"type":"com.example.legacy"}`))
+	assert.Equal(t, &LegacyBridge{Type: "com.example.legacy", Source: "/legacy/orders"}, dispatcher.currentLegacyBridge())
+
+	// Verify Removing The Annotation Disables The LegacyBridge
+	assert.Nil(t, dispatcher.UpdateLegacyBridge(""))
+	assert.Nil(t, dispatcher.currentLegacyBridge())
+}
+
+// Test The Handler's ConsumeClaim() Functionality With Legacy (Non-CloudEvent) Records Of A LegacyBridge KafkaChannel
+func TestHandlerConsumeClaimLegacyBridge(t *testing.T) {
+
+	// Test Data
+	legacyBridge := &LegacyBridge{Type: "com.example.legacy", Source: "/legacy/orders"}
+	timestamp := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name            string
+		key             []byte
+		value           []byte
+		wantContentType string
+		wantKey         string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:            "Raw JSON",
+			key:             []byte("TestLegacyKey"),
+			value:           []byte(`{"orderId":123,"status":"shipped"}`),
+			wantContentType: constants.LegacyBridgeJsonDataContentType,
+			wantKey:         "TestLegacyKey",
+		},
+		{
+			name:            "Raw Bytes",
+			value:           []byte{0x00, 0x01, 0xfe, 0xff},
+			wantContentType: constants.LegacyBridgeBytesDataContentType,
+		},
+		{
+			name:            "Raw Text",
+			value:           []byte("order 123 shipped"),
+			wantContentType: constants.LegacyBridgeBytesDataContentType,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create Mocks For Testing
+			retryConfig := kncloudevents.NoRetries()
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			mockMessageDispatcher := dispatchertesting.NewMockMessageDispatcher(t, nil, testSubscriberURI.URL(), nil, nil, &retryConfig, nil)
+
+			// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+			newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+			newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+				return mockMessageDispatcher
+			}
+			defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+			// Create The Handler To Test For A LegacyBridge KafkaChannel (Failing On Any Deserialization Failure)
+			handler := createTestHandler(t, testSubscriberURI, nil, nil)
+			handler.DeserializationFailurePolicy = constants.DeserializationFailurePolicyFail
+			handler.legacyBridge = func() *LegacyBridge { return legacyBridge }
+
+			// Background Start Consuming Claims
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test (Add A Raw ConsumerMessage Without Any CloudEvent Headers To Claims)
+			consumerMessage := &sarama.ConsumerMessage{
+				Timestamp: timestamp,
+				Key:       testCase.key,
+				Value:     testCase.value,
+				Topic:     testTopic,
+				Partition: testPartition,
+				Offset:    testOffset,
+			}
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+
+			// Verify The Message Was Marked After Being Dispatched
+			assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+
+			// Verify The Synthesized CloudEvent Has The Configured Attributes & The Raw Value As Its Data
+			dispatchedEvent, err := binding.ToEvent(context.TODO(), mockMessageDispatcher.Message())
+			assert.Nil(t, err)
+			assert.Equal(t, legacyBridge.Type, dispatchedEvent.Type())
+			assert.Equal(t, legacyBridge.Source, dispatchedEvent.Source())
+			assert.Equal(t, fmt.Sprintf("%s-%d-%d", testTopic, testPartition, testOffset), dispatchedEvent.ID())
+			assert.Equal(t, timestamp, dispatchedEvent.Time())
+			assert.Equal(t, testCase.wantContentType, dispatchedEvent.DataContentType())
+			assert.Equal(t, testCase.value, dispatchedEvent.Data())
+			if len(testCase.wantKey) > 0 {
+				assert.Equal(t, testCase.wantKey, dispatchedEvent.Extensions()[constants.TombstoneKeyExtension])
+			} else {
+				assert.NotContains(t, dispatchedEvent.Extensions(), constants.TombstoneKeyExtension)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// Function Reference For Creating The Sarama Client Used To Seed ConsumerGroup Offsets (Facilitates Testing)
+var newOffsetSeedClient = sarama.NewClient
+
+//
+// Seed The Specified ConsumerGroup With The Committed Offsets Of The Specified Source ConsumerGroup
+//
+// This is performed just before the subscriber's ConsumerGroup is first created with a given source ConsumerGroup, so
+// that a subscriber migrating to a new GroupId resumes from where the source ConsumerGroup left off instead of replaying
+// or skipping the Topic's backlog per the initial offset.  It is not repeated when the dispatcher is recreated for a
+// configuration change (where a failure would be fatal), as the ConsumerGroup has already been seeded.  Only partitions
+// without a committed offset of their own are seeded, so restarting the dispatcher never rewinds a ConsumerGroup which
+// has since progressed.  The copy is a snapshot, and it is up to the user to stop the source ConsumerGroup beforehand
+// to avoid duplicates.  Nothing is done without a source ConsumerGroup.
+//
+func (d *DispatcherImpl) seedConsumerGroupOffsets(logger *zap.Logger, groupId string, sourceGroupId string) error {
+
+	// Nothing To Do Without A (Distinct) Source ConsumerGroup
+	if len(sourceGroupId) <= 0 || sourceGroupId == groupId {
+		return nil
+	}
+
+	// Copy The Source ConsumerGroup's Offsets
+	offsets, err := copyConsumerGroupOffsets(d.Brokers, d.SaramaConfig, d.Topic, groupId, sourceGroupId)
+	if err != nil {
+		return fmt.Errorf("failed to seed offsets of consumer group '%s' from consumer group '%s': %w", groupId, sourceGroupId, err)
+	}
+	logger.Info("Seeded ConsumerGroup Offsets From Source ConsumerGroup", zap.String("SourceGroupId", sourceGroupId), zap.Any("Offsets", offsets))
+	return nil
+}
+
+// Commit The Source ConsumerGroup's Offsets For Partitions Of The Topic Without An Offset Of The ConsumerGroup, Returning Them By Partition
+func copyConsumerGroupOffsets(brokers []string, config *sarama.Config, topic string, groupId string, sourceGroupId string) (map[int32]int64, error) {
+
+	// Create A Client & ClusterAdmin (Closing The ClusterAdmin Closes The Client)
+	client, err := newOffsetSeedClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	defer func() { _ = admin.Close() }()
+
+	// Get The Committed Offsets Of Both ConsumerGroups For Each Partition
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	sourceOffsets, err := listConsumerGroupOffsets(admin, topic, sourceGroupId, partitions)
+	if err != nil {
+		return nil, err
+	}
+	targetOffsets, err := listConsumerGroupOffsets(admin, topic, groupId, partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine The Offsets To Copy (Partitions Committed By The Source But Not By The ConsumerGroup)
+	offsets := make(map[int32]int64)
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           groupId,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+		RetentionTime:           -1,
+	}
+	for _, partition := range partitions {
+		if targetOffsets[partition] < 0 && sourceOffsets[partition] >= 0 {
+			offsets[partition] = sourceOffsets[partition]
+			request.AddBlock(topic, partition, sourceOffsets[partition], 0, "")
+		}
+	}
+	if len(offsets) <= 0 {
+		return offsets, nil
+	}
+
+	// Commit The Offsets Via The ConsumerGroup's Coordinator
+	coordinator, err := client.Coordinator(groupId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		return nil, err
+	}
+	for partition := range offsets {
+		if kerr, ok := response.Errors[topic][partition]; ok && kerr != sarama.ErrNoError {
+			return nil, fmt.Errorf("failed to commit offset for partition %d: %w", partition, kerr)
+		}
+	}
+	return offsets, nil
+}
+
+// Get The Committed Offset (Or -1 If None) Of The Specified ConsumerGroup For Each Of The Specified Partitions Of The Topic
+func listConsumerGroupOffsets(admin sarama.ClusterAdmin, topic string, groupId string, partitions []int32) (map[int32]int64, error) {
+	response, err := admin.ListConsumerGroupOffsets(groupId, map[string][]int32{topic: partitions})
+	if err != nil {
+		return nil, err
+	}
+	if response.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to fetch offsets of consumer group '%s': %w", groupId, response.Err)
+	}
+	offsets := make(map[int32]int64)
+	for _, partition := range partitions {
+		offsets[partition] = -1
+		if block := response.GetBlock(topic, partition); block != nil && block.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("failed to fetch offset of consumer group '%s' for partition %d: %w", groupId, partition, block.Err)
+		} else if block != nil {
+			offsets[partition] = block.Offset
+		}
+	}
+	return offsets, nil
+}
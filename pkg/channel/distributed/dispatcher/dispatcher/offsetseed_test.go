@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The copyConsumerGroupOffsets() Functionality Against A Mock Broker
+func TestCopyConsumerGroupOffsets(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		groupId       string
+		sourceGroupId string
+		commitError   bool
+		want          map[int32]int64
+		wantErr       bool
+	}
+
+	// Define The TestCases (See newConsumerLagMockBroker() For The Committed Offsets Of group1 & group2)
+	testCases := []TestCase{
+		{name: "New Group From Fully Committed Source", groupId: "group3", sourceGroupId: "group1", want: map[int32]int64{0: 40, 1: 50}},
+		{name: "New Group From Partially Committed Source", groupId: "group3", sourceGroupId: "group2", want: map[int32]int64{0: 90}},
+		{name: "Partially Committed Group", groupId: "group2", sourceGroupId: "group1", want: map[int32]int64{1: 50}},
+		{name: "Fully Committed Group", groupId: "group1", sourceGroupId: "group2", want: map[int32]int64{}},
+		{name: "Unknown Source", groupId: "group3", sourceGroupId: "unknown", want: map[int32]int64{}},
+		{name: "Commit Error", groupId: "group3", sourceGroupId: "group1", commitError: true, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Broker Accepting (Or Rejecting) Offset Commits
+			broker := newOffsetSeedMockBroker(t, testCase.commitError, "group3")
+			defer broker.Close()
+
+			// Perform The Test
+			offsets, err := copyConsumerGroupOffsets([]string{broker.Addr()}, createConsumerLagConfig(), testTopic, testCase.groupId, testCase.sourceGroupId)
+
+			// Verify The Results & That The Group Starts At The Copied Offsets
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.want, offsets)
+			if !testCase.wantErr {
+				assert.Equal(t, testCase.want, committedOffsets(broker, testCase.groupId))
+			}
+		})
+	}
+}
+
+// Test That Subscribers With A Source ConsumerGroup Have Their ConsumerGroup Seeded Before It Is Created
+func TestUpdateSubscriptionsSeedsOffsets(t *testing.T) {
+
+	// Test Data
+	groupId := "kafka." + id123
+	subscriber := eventingduck.SubscriberSpec{UID: uid123}
+
+	// Create A Mock Broker Accepting Offset Commits For The Subscriber's ConsumerGroup
+	broker := newOffsetSeedMockBroker(t, false, groupId)
+	defer broker.Close()
+
+	// Replace The Offset Seed Client Creation With One For The Mock Broker & Restore After Test
+	newOffsetSeedClientPlaceholder := newOffsetSeedClient
+	newOffsetSeedClient = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		return sarama.NewClient([]string{broker.Addr()}, createConsumerLagConfig())
+	}
+	defer func() { newOffsetSeedClient = newOffsetSeedClientPlaceholder }()
+
+	// Replace The NewConsumerGroupWrapper With Mock Recording The Offsets Committed Before Each Creation & Restore After Test
+	var createdLock sync.Mutex
+	var created []map[int32]int64
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		createdLock.Lock()
+		defer createdLock.Unlock()
+		created = append(created, committedOffsets(broker, groupIdArg))
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+	verifyCreated := func(expected []map[int32]int64) {
+		createdLock.Lock()
+		defer createdLock.Unlock()
+		assert.Equal(t, expected, created)
+	}
+
+	// Create A New DispatcherImpl To Test
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		Topic:        testTopic,
+		SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+	}).(*DispatcherImpl)
+	defer dispatcher.Shutdown(context.TODO())
+
+	// Perform The Test - Seed The Subscriber's New ConsumerGroup From group2
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"group:group2"}`))
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 0)
+	verifyCreated([]map[int32]int64{{0: 90}})
+	assert.Equal(t, "group2", dispatcher.subscribers[uid123].SourceGroupId)
+
+	// Verify The ConsumerGroup Is Neither Reseeded Nor Recreated While The Source ConsumerGroup Is Unchanged
+	failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 0)
+	verifyCreated([]map[int32]int64{{0: 90}})
+
+	// Verify A Changed Source ConsumerGroup Recreates & Reseeds The ConsumerGroup (Whose Commits The Mock Broker Does Not Retain)
+	consumerGroup := dispatcher.subscribers[uid123].ConsumerGroup.(*kafkatesting.MockConsumerGroup)
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"group:group1"}`))
+	failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 0)
+	verifyCreated([]map[int32]int64{{0: 90}, {0: 40, 1: 50}})
+	assert.True(t, consumerGroup.Closed)
+
+	// Verify A Failure To Seed The Offsets Fails The Subscriber Without Creating Its ConsumerGroup
+	newOffsetSeedClient = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		return nil, sarama.ErrOutOfBrokers
+	}
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"group:group2"}`))
+	failedSubscriptions = updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 1)
+	assert.Contains(t, failedSubscriptions[subscriber].Error(), "failed to seed offsets of consumer group '"+groupId+"' from consumer group 'group2'")
+	verifyCreated([]map[int32]int64{{0: 90}, {0: 40, 1: 50}})
+	assert.NotContains(t, dispatcher.subscribers, uid123)
+}
+
+// Test The ConfigChanged() Functionality Does Not Reseed (Or Fail On Seeding) ConsumerGroups When Recreating The Dispatcher
+func TestConfigChangedDoesNotReseedOffsets(t *testing.T) {
+
+	// Test Data
+	groupId := "kafka." + id123
+	subscriber := eventingduck.SubscriberSpec{UID: uid123}
+
+	// Create A Mock Broker Accepting Offset Commits For The Subscriber's ConsumerGroup
+	broker := newOffsetSeedMockBroker(t, false, groupId)
+	defer broker.Close()
+
+	// Replace The Offset Seed Client Creation With One For The Mock Broker & Restore After Test
+	seedClientCount := 0
+	newOffsetSeedClientPlaceholder := newOffsetSeedClient
+	newOffsetSeedClient = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		seedClientCount++
+		return sarama.NewClient([]string{broker.Addr()}, createConsumerLagConfig())
+	}
+	defer func() { newOffsetSeedClient = newOffsetSeedClientPlaceholder }()
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() { kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl Whose Subscriber's ConsumerGroup Is Seeded From group2
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:       logtesting.TestLogger(t).Desugar(),
+		Topic:        testTopic,
+		SaramaConfig: getSaramaConfigFromYaml(t, TestConfigBase),
+	}).(*DispatcherImpl)
+	assert.Nil(t, dispatcher.UpdateInitialOffsets(`{"`+id123+`":"group:group2"}`))
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 0)
+	assert.Equal(t, 1, seedClientCount)
+
+	// Perform The Test - Change The Sarama Config While Seeding Would Fail (Which Must Not Be Fatal)
+	newOffsetSeedClient = func(addrs []string, config *sarama.Config) (sarama.Client, error) {
+		seedClientCount++
+		return nil, sarama.ErrOutOfBrokers
+	}
+	configMap := getBaseConfigMap()
+	configMap.Data[commonconfig.SaramaSettingsConfigKey] = TestConfigConsumerChange
+	newDispatcher := dispatcher.ConfigChanged(configMap)
+	assert.NotNil(t, newDispatcher)
+	defer newDispatcher.Shutdown(context.TODO())
+
+	// Verify The Subscriber's ConsumerGroup Was Recreated Without Being Reseeded
+	assert.Equal(t, 1, seedClientCount)
+	assert.Contains(t, newDispatcher.(*DispatcherImpl).subscribers, uid123)
+	assert.Equal(t, "group2", newDispatcher.(*DispatcherImpl).subscribers[uid123].SourceGroupId)
+
+	// Verify A Removed & Re-Added Subscriber Is Seeded Again
+	failedSubscriptions = updateSubscriptions(t, newDispatcher, []eventingduck.SubscriberSpec{})
+	assert.Len(t, failedSubscriptions, 0)
+	failedSubscriptions = updateSubscriptions(t, newDispatcher, []eventingduck.SubscriberSpec{subscriber})
+	assert.Len(t, failedSubscriptions, 1)
+	assert.Equal(t, 2, seedClientCount)
+}
+
+//
+// Create A Mock Broker Like newConsumerLagMockBroker() Which Also Coordinates The Specified ConsumerGroup & Accepts
+// (Or Rejects) Offset Commits.  The committed offsets of group1 & group2 are fixed, so the offsets committed by the
+// tests are verified via the broker's request history instead.
+//
+func newOffsetSeedMockBroker(t *testing.T, commitError bool, groupId string) *sarama.MockBroker {
+	broker := newConsumerLagMockBroker(t)
+	offsetCommitResponse := sarama.NewMockOffsetCommitResponse(t)
+	if commitError {
+		offsetCommitResponse.SetError(groupId, testTopic, 0, sarama.ErrOffsetMetadataTooLarge)
+	}
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()).
+			SetLeader(testTopic, 1, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "group1", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "group2", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "unknown", broker).
+			SetCoordinator(sarama.CoordinatorGroup, groupId, broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("group1", testTopic, 0, 40, "", sarama.ErrNoError).
+			SetOffset("group1", testTopic, 1, 50, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 0, 90, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 1, -1, "", sarama.ErrNoError),
+		"OffsetCommitRequest": offsetCommitResponse,
+	})
+	return broker
+}
+
+// Get The Offsets (By Partition) Of All OffsetCommitRequests Received By The Mock Broker For The Specified ConsumerGroup
+func committedOffsets(broker *sarama.MockBroker, groupId string) map[int32]int64 {
+	offsets := make(map[int32]int64)
+	for _, requestResponse := range broker.History() {
+		if request, ok := requestResponse.Request.(*sarama.OffsetCommitRequest); ok && request.ConsumerGroup == groupId {
+			for _, partition := range []int32{0, 1} {
+				if offset, _, err := request.Offset(testTopic, partition); err == nil {
+					offsets[partition] = offset
+				}
+			}
+		}
+	}
+	return offsets
+}
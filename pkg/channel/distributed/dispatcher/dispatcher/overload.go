@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// Validate The Specified Overload Pause Threshold & Cooldown, Returning The Cooldown (Or The Default If Unspecified)
+func ParseOverloadPause(threshold int, cooldownMillis int64) (time.Duration, error) {
+	if threshold < 0 {
+		return 0, fmt.Errorf("invalid overload pause threshold %d - must be >= 0", threshold)
+	}
+	if cooldownMillis < 0 {
+		return 0, fmt.Errorf("invalid overload pause cooldown %dms - must be >= 0", cooldownMillis)
+	}
+	if cooldownMillis == 0 {
+		cooldownMillis = constants.DefaultOverloadPauseCooldownMillis
+	}
+	return time.Duration(cooldownMillis) * time.Millisecond, nil
+}
+
+//
+// Monitor A Subscriber's Overload (429 Too Many Requests) Responses For A Single Partition & Pause Its Consumption
+//
+// Every response from the subscriber (including each retry) is observed via the RetryConfig's CheckRetry, and once
+// the configured number of consecutive 429 responses has been received the partition's ConsumeClaim() loop stops
+// pulling messages for the cooldown.  Sarama (which does not support pausing partitions directly) then stops
+// fetching the partition once its buffered messages are not being consumed, providing backpressure to the broker
+// rather than continuing to fetch messages only to retry them against the overloaded subscriber.  Any response
+// other than a 429 resets the count, and a threshold of zero disables the monitor.
+//
+type overloadMonitor struct {
+	logger        *zap.Logger
+	threshold     int
+	cooldown      time.Duration
+	subscriberURL *url.URL
+	lock          sync.Mutex
+	consecutive   int // Number Of Consecutive 429 Responses From The Subscriber
+	pauses        int // Number Of Times The Partition Has Been Paused (For Observability / Testing)
+}
+
+// overloadMonitor Constructor
+func newOverloadMonitor(logger *zap.Logger, threshold int, cooldown time.Duration, subscriberURL *url.URL) *overloadMonitor {
+	return &overloadMonitor{logger: logger, threshold: threshold, cooldown: cooldown, subscriberURL: subscriberURL}
+}
+
+// Wrap The Specified CheckRetry So That Each Response Is Observed Before Determining Whether To Retry
+func (m *overloadMonitor) wrapCheckRetry(checkRetry kncloudevents.CheckRetry) kncloudevents.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		m.observe(response)
+		if checkRetry == nil {
+			return false, nil
+		}
+		return checkRetry(ctx, response, err)
+	}
+}
+
+// Track The Consecutive 429 Responses From The Subscriber (Ignoring Those From The Reply & DeadLetterSink)
+func (m *overloadMonitor) observe(response *http.Response) {
+	if m.threshold <= 0 || !isResponseFrom(response, m.subscriberURL) {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if response.StatusCode == http.StatusTooManyRequests {
+		m.consecutive++
+	} else {
+		m.consecutive = 0
+	}
+}
+
+// Determine Whether The Specified Response Is From The Specified Destination (e.g. The Subscriber Rather Than The Reply)
+func isResponseFrom(response *http.Response, destinationURL *url.URL) bool {
+	if response == nil || destinationURL == nil || response.Request == nil || response.Request.URL == nil {
+		return false
+	}
+	requestURL := response.Request.URL
+	return requestURL.Scheme == destinationURL.Scheme && requestURL.Host == destinationURL.Host && requestURL.Path == destinationURL.Path
+}
+
+// Determine Whether The Subscriber Has Responded With The Threshold Number Of Consecutive 429s
+func (m *overloadMonitor) overloaded() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.threshold > 0 && m.consecutive >= m.threshold
+}
+
+// Pause Consumption For The Cooldown, Returning False If The Specified (Session) Context Ended In The Meantime
+func (m *overloadMonitor) pause(ctx context.Context, topic string, partition int32) bool {
+
+	// Reset The Count So That The Partition Is Only Paused Again Upon Further Sustained 429 Responses
+	m.lock.Lock()
+	m.consecutive = 0
+	m.pauses++
+	m.lock.Unlock()
+
+	// Wait For The Cooldown To Elapse (Or The Session To End)
+	logger := m.logger.With(zap.String("Topic", topic), zap.Int32("Partition", partition), zap.Duration("Cooldown", m.cooldown))
+	logger.Warn("Subscriber Overloaded - Pausing Partition Consumption", zap.Int("Threshold", m.threshold))
+	timer := time.NewTimer(m.cooldown)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		logger.Info("Overload Cooldown Elapsed - Resuming Partition Consumption")
+		return true
+	case <-ctx.Done():
+		logger.Info("ConsumerGroup Session Ended While Partition Consumption Paused")
+		return false
+	}
+}
+
+// Get The Number Of Times The Partition Has Been Paused
+func (m *overloadMonitor) pauseCount() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.pauses
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseOverloadPause() Functionality
+func TestParseOverloadPause(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		threshold      int
+		cooldownMillis int64
+		want           time.Duration
+		wantErr        bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", want: constants.DefaultOverloadPauseCooldownMillis * time.Millisecond},
+		{name: "Specified", threshold: 3, cooldownMillis: 250, want: 250 * time.Millisecond},
+		{name: "Negative Threshold", threshold: -1, wantErr: true},
+		{name: "Negative Cooldown", threshold: 3, cooldownMillis: -1, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cooldown, err := ParseOverloadPause(testCase.threshold, testCase.cooldownMillis)
+			assert.Equal(t, testCase.want, cooldown)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The overloadMonitor's Tracking Of Consecutive 429 Responses
+func TestOverloadMonitorObserve(t *testing.T) {
+
+	// Test Data
+	subscriberURL, _ := url.Parse(testSubscriberURIString)
+	deadLetterURL, _ := url.Parse(testDeadLetterURIString)
+	response := func(requestURL *url.URL, statusCode int) *http.Response {
+		return &http.Response{StatusCode: statusCode, Request: &http.Request{URL: requestURL}}
+	}
+
+	// Create The overloadMonitor To Test
+	monitor := newOverloadMonitor(logtesting.TestLogger(t).Desugar(), 2, time.Millisecond, subscriberURL)
+	checkRetry := monitor.wrapCheckRetry(func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		return response.StatusCode == http.StatusTooManyRequests, nil
+	})
+
+	// Verify A Single 429 Is Not Sustained Overload (& The Wrapped CheckRetry Is Still Applied)
+	retry, err := checkRetry(context.Background(), response(subscriberURL, http.StatusTooManyRequests), nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	assert.False(t, monitor.overloaded())
+
+	// Verify Other Responses Reset The Count
+	_, _ = checkRetry(context.Background(), response(subscriberURL, http.StatusInternalServerError), nil)
+	_, _ = checkRetry(context.Background(), response(subscriberURL, http.StatusTooManyRequests), nil)
+	assert.False(t, monitor.overloaded())
+
+	// Verify Responses From Other Destinations (& Nil Responses) Are Ignored
+	_, _ = checkRetry(context.Background(), response(deadLetterURL, http.StatusTooManyRequests), nil)
+	monitor.observe(nil)
+	assert.False(t, monitor.overloaded())
+
+	// Verify Consecutive 429s From The Subscriber Reach The Threshold
+	_, _ = checkRetry(context.Background(), response(subscriberURL, http.StatusTooManyRequests), nil)
+	assert.True(t, monitor.overloaded())
+
+	// Verify Pausing Waits For The Cooldown & Resets The Count
+	assert.True(t, monitor.pause(context.Background(), testTopic, testPartition))
+	assert.False(t, monitor.overloaded())
+	assert.Equal(t, 1, monitor.pauseCount())
+
+	// Verify Pausing Ends Early (Returning False) When The Session Ends
+	monitor.cooldown = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, monitor.pause(ctx, testTopic, testPartition))
+	assert.Equal(t, 2, monitor.pauseCount())
+}
+
+// Test That The Handler's ConsumeClaim() Pauses & Resumes A Partition Upon Sustained 429 Responses
+func TestHandlerConsumeClaimOverloadPause(t *testing.T) {
+
+	// Test Data
+	cooldown := 250 * time.Millisecond
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		threshold int
+		wantPause bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", threshold: 0, wantPause: false},
+		{name: "Below Threshold", threshold: 3, wantPause: false},
+		{name: "Sustained Overload", threshold: 2, wantPause: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Subscriber Which Is Overloaded For The First Two Requests
+			var requests int32
+			subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= 2 {
+					response.WriteHeader(http.StatusTooManyRequests)
+				} else {
+					response.WriteHeader(http.StatusAccepted)
+				}
+			}))
+			defer subscriber.Close()
+			subscriberURI, err := apis.ParseURL(subscriber.URL)
+			assert.Nil(t, err)
+
+			// Create The Handler To Test With The TestCase's Threshold
+			handler := createTestHandler(t, subscriberURI, nil, nil)
+			handler.OverloadPauseThreshold = testCase.threshold
+			handler.OverloadPauseCooldown = cooldown
+
+			// Background Start Consuming Claims
+			mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+			}()
+
+			// Consume Two Messages To Which The Subscriber Responds 429 (Marked As Their Delivery Was Attempted)
+			for i := 0; i < 2; i++ {
+				consumerMessage := createConsumerMessage(t)
+				mockConsumerGroupClaim.MessageChan <- consumerMessage
+				assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+			}
+
+			// Verify The Next Message Is Only Pulled After The Cooldown When Paused
+			startTime := time.Now()
+			consumerMessage := createConsumerMessage(t)
+			mockConsumerGroupClaim.MessageChan <- consumerMessage
+			pulledAfter := time.Since(startTime)
+			if testCase.wantPause {
+				assert.True(t, pulledAfter >= cooldown-50*time.Millisecond, "message pulled after %v", pulledAfter)
+			} else {
+				assert.True(t, pulledAfter < cooldown-50*time.Millisecond, "message pulled after %v", pulledAfter)
+			}
+
+			// Verify Consumption Resumed & The Message Was Delivered
+			assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+			assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+			// Stop Consuming
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+		})
+	}
+}
+
+// Test That The Handler's ConsumeClaim() Returns When The Session Ends While A Partition Is Paused
+func TestHandlerConsumeClaimOverloadPauseSessionEnd(t *testing.T) {
+
+	// Create A Mock Subscriber Which Is Always Overloaded
+	subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer subscriber.Close()
+	subscriberURI, err := apis.ParseURL(subscriber.URL)
+	assert.Nil(t, err)
+
+	// Create The Handler To Test With A Long Cooldown
+	handler := createTestHandler(t, subscriberURI, nil, nil)
+	handler.OverloadPauseThreshold = 1
+	handler.OverloadPauseCooldown = time.Hour
+
+	// Background Start Consuming Claims With A Cancellable Session Context
+	ctx, cancel := context.WithCancel(context.Background())
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	mockConsumerGroupSession.Ctx = ctx
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+	errChan := make(chan error)
+	go func() {
+		errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+	}()
+
+	// Consume A Message To Which The Subscriber Responds 429 (Pausing The Partition)
+	consumerMessage := createConsumerMessage(t)
+	mockConsumerGroupClaim.MessageChan <- consumerMessage
+	assert.Equal(t, consumerMessage, <-mockConsumerGroupSession.MarkMessageChan)
+
+	// Verify Ending The Session Ends The Pause & Consumption
+	cancel()
+	select {
+	case err := <-errChan:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ConsumeClaim did not return when the session ended")
+	}
+}
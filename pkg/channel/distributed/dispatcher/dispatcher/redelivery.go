@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+// Get The Back-Off Before The Specified (Zero Based) Redelivery Of A Message Blocking Its Partition, Doubling The
+// Interval (The Default Consume Retry Interval If Unspecified) With Each Redelivery Up To The Maximum Redelivery Interval
+func redeliveryBackoff(interval time.Duration, redeliveries int) time.Duration {
+	if interval <= 0 {
+		interval = constants.DefaultConsumeRetryIntervalMillis * time.Millisecond
+	}
+	maxInterval := constants.MaxRedeliveryIntervalMillis * time.Millisecond
+	for i := 0; i < redeliveries && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval
+}
+
+//
+// Wait To Redeliver A Message Which Blocks Its Partition, Returning False If The Session Ended Or Deliveries Were Abandoned
+//
+// Returning an error from ConsumeClaim() cancels the entire ConsumerGroup session, upon which every partition of every
+// member is re-balanced and the message is immediately redelivered from the same (unmarked) offset, resulting in a hot
+// re-join loop for as long as the message cannot be handled.  Instead the message is redelivered in place after a back-off
+// so that only its own partition is blocked, and ConsumeClaim() only returns once the session ends of its own accord.
+//
+func (h *Handler) awaitRedelivery(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, redeliveries int, err error) bool {
+
+	// Wait For The Back-Off To Elapse (Or The Session / Deliveries To End)
+	backoff := redeliveryBackoff(h.RedeliveryInterval, redeliveries)
+	h.Logger.Warn("Message Blocking Partition - Redelivering After Back-Off",
+		zap.String("Topic", message.Topic),
+		zap.Int32("Partition", message.Partition),
+		zap.Int64("Offset", message.Offset),
+		zap.Int("Redeliveries", redeliveries),
+		zap.Duration("Backoff", backoff),
+		zap.Error(err))
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-session.Context().Done():
+		h.Logger.Info("ConsumerGroup Session Ended While Message Blocking Partition - Offset Will Not Be Marked", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset))
+		return false
+	case <-h.deliveryContext().Done():
+		h.Logger.Info("Deliveries Abandoned While Message Blocking Partition - Offset Will Not Be Marked", zap.Int32("Partition", message.Partition), zap.Int64("Offset", message.Offset))
+		return false
+	}
+}
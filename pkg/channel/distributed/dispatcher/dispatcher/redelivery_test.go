@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/pkg/apis"
+)
+
+// Test The redeliveryBackoff() Functionality
+func TestRedeliveryBackoff(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		interval     time.Duration
+		redeliveries int
+		want         time.Duration
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Default First", interval: 0, redeliveries: 0, want: constants.DefaultConsumeRetryIntervalMillis * time.Millisecond},
+		{name: "First", interval: 100 * time.Millisecond, redeliveries: 0, want: 100 * time.Millisecond},
+		{name: "Doubled", interval: 100 * time.Millisecond, redeliveries: 3, want: 800 * time.Millisecond},
+		{name: "Capped", interval: 100 * time.Millisecond, redeliveries: 1000, want: constants.MaxRedeliveryIntervalMillis * time.Millisecond},
+		{name: "Large Interval Capped", interval: time.Hour, redeliveries: 0, want: constants.MaxRedeliveryIntervalMillis * time.Millisecond},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, redeliveryBackoff(testCase.interval, testCase.redeliveries))
+		})
+	}
+}
+
+// Test A Message Which Cannot Be Deserialized With The "fail" Policy Is Redelivered In Place With A Back-Off (Not A Tight Loop)
+func TestHandlerConsumeClaimDeserializationFailureRedelivery(t *testing.T) {
+
+	// Create Mocks For Testing (With A Session Lasting Long Enough For A Few Backed-Off Redeliveries)
+	mockConsumerGroupSession := dispatchertesting.NewMockConsumerGroupSession(t)
+	sessionCtx, endSession := context.WithTimeout(context.Background(), 220*time.Millisecond)
+	defer endSession()
+	mockConsumerGroupSession.Ctx = sessionCtx
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+
+	// Create The Handler To Test With The "fail" Policy & A Short Redelivery Interval (Counting Delivery Attempts Via The
+	// LegacyBridge Lookup, Which Is Made Once For Each Attempt To Deserialize The Message)
+	attempts := 0
+	handler := createTestHandler(t, testSubscriberURI, nil, nil)
+	handler.DeserializationFailurePolicy = constants.DeserializationFailurePolicyFail
+	handler.RedeliveryInterval = 20 * time.Millisecond
+	handler.legacyBridge = func() *LegacyBridge {
+		attempts++
+		return nil
+	}
+
+	// Background Start Consuming Claims
+	start := time.Now()
+	errChan := make(chan error)
+	go func() {
+		errChan <- handler.ConsumeClaim(mockConsumerGroupSession, mockConsumerGroupClaim)
+	}()
+
+	// Perform The Test (Add A Malformed ConsumerMessage To Claims)
+	mockConsumerGroupClaim.MessageChan <- createMalformedConsumerMessage(t)
+
+	// Verify ConsumeClaim() Only Returned (Without Marking The Message) Once The Session Ended
+	select {
+	case err := <-errChan:
+		assert.True(t, errors.Is(err, ErrDeserializationFailure))
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(220*time.Millisecond))
+	case <-mockConsumerGroupSession.MarkMessageChan:
+		assert.Fail(t, "message which could not be deserialized was marked")
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ConsumeClaim did not return after the session ended")
+	}
+
+	// Verify The Message Was Redelivered With A Doubling Back-Off (At 0, 20, 60 & 140ms, But Not Again Before 300ms)
+	assert.GreaterOrEqual(t, attempts, 2)
+	assert.LessOrEqual(t, attempts, 4)
+}
+
+// Test A Message Which Can Be Sent To Neither The Subscriber Nor Its DeadLetterSink Is Redelivered In Place With A Back-Off
+// For As Long As The DeadLetterSink Is Down, Without Ending The Session (Not A Tight Re-Join Loop)
+func TestHandlerConsumeClaimDeadLetterFailureRedelivery(t *testing.T) {
+
+	// Create A Mock Subscriber & DeadLetterSink Which Are Both Down (Counting The Deliveries Attempted)
+	var subscriberAttempts, deadLetterAttempts int32
+	subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&subscriberAttempts, 1)
+		response.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer subscriber.Close()
+	deadLetterSink := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&deadLetterAttempts, 1)
+		response.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer deadLetterSink.Close()
+	subscriberURI, err := apis.ParseURL(subscriber.URL)
+	assert.Nil(t, err)
+	deadLetterURI, err := apis.ParseURL(deadLetterSink.URL)
+	assert.Nil(t, err)
+
+	// Create Mocks For Testing (With A Session Lasting Long Enough For A Few Backed-Off Redeliveries)
+	session := newOffsetRecordingSession()
+	sessionCtx, endSession := context.WithTimeout(context.Background(), 220*time.Millisecond)
+	defer endSession()
+	session.Ctx = sessionCtx
+	mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+
+	// Create The Handler To Test With The DeadLetterSink & A Short Redelivery Interval
+	deliverySpec := createDeliverySpec(deadLetterURI, false)
+	handler := createTestHandler(t, subscriberURI, nil, &deliverySpec)
+	handler.RedeliveryInterval = 20 * time.Millisecond
+
+	// Background Start Consuming Claims
+	start := time.Now()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+	}()
+
+	// Perform The Test (Add A ConsumerMessage To Claims)
+	mockConsumerGroupClaim.MessageChan <- createConsumerMessage(t)
+
+	// Verify ConsumeClaim() Only Returned (Without Marking The Message) Once The Session Ended
+	select {
+	case err := <-errChan:
+		assert.True(t, errors.Is(err, ErrDeadLetterFailure))
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(220*time.Millisecond))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "ConsumeClaim did not return after the session ended")
+	}
+	assert.Equal(t, int64(0), session.marked())
+
+	// Verify The Message Was Redelivered With A Doubling Back-Off (At 0, 20, 60 & 140ms, But Not Again Before 300ms)
+	attempts := atomic.LoadInt32(&subscriberAttempts)
+	assert.GreaterOrEqual(t, attempts, int32(2))
+	assert.LessOrEqual(t, attempts, int32(4))
+	assert.Equal(t, attempts, atomic.LoadInt32(&deadLetterAttempts))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/pkg/apis"
+)
+
+// Headers Which The Go HTTP Client Strips When Redirected To Another Domain (Never Restored By The "preserve" Policy)
+var sensitiveRedirectHeaders = map[string]bool{
+	"Authorization":       true,
+	"Www-Authenticate":    true,
+	"Cookie":              true,
+	"Cookie2":             true,
+	"Proxy-Authorization": true,
+	"Proxy-Authenticate":  true,
+}
+
+// Validate The Specified RedirectPolicy & Return It (Or The Default If Unspecified)
+func ParseRedirectPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultRedirectPolicy, nil
+	case constants.RedirectPolicyFollow, constants.RedirectPolicyPreserve, constants.RedirectPolicyFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid redirect policy '%s' - must be one of '%s', '%s' or '%s'", policy,
+			constants.RedirectPolicyFollow, constants.RedirectPolicyPreserve, constants.RedirectPolicyFail)
+	}
+}
+
+// Validate The Specified Maximum Number Of Redirects Followed & Return It (Or The Default If Unspecified)
+func ParseRedirectMax(redirectMax int) (int, error) {
+	if redirectMax < 0 {
+		return 0, fmt.Errorf("invalid redirect max %d - must be >= 0", redirectMax)
+	}
+	if redirectMax == 0 {
+		redirectMax = constants.DefaultRedirectMax
+	}
+	return redirectMax, nil
+}
+
+//
+// Create An HTTP Client CheckRedirect Function Applying The RedirectPolicy To The Responses Of A Single Subscriber
+//
+// With the "follow" policy the Go HTTP client's standard handling is retained (only the maximum number of redirects
+// differing if configured), so that a nil function is returned when the default limit applies.  The "preserve" policy
+// restores the original request's method, body and (non-sensitive) headers on each redirect, since the Go HTTP client
+// re-sends POST requests redirected by 301, 302 & 303 responses as GET requests without the CloudEvent.  The "fail"
+// policy returns the redirect response itself, which the Knative MessageDispatcher treats as a failed delivery (not
+// retried, per the Handler's checkRetry) before sending the event to any DeadLetterSink.  Redirects of requests to
+// other destinations (i.e. the reply & DeadLetterSink) are always followed per the Go HTTP client.
+//
+func newCheckRedirect(logger *zap.Logger, policy string, redirectMax int, subscriberURI *apis.URL) func(*http.Request, []*http.Request) error {
+
+	// Retain The Go HTTP Client's Default Behavior If Nothing Differs From It
+	if (policy == "" || policy == constants.RedirectPolicyFollow) && (redirectMax <= 0 || redirectMax == constants.DefaultRedirectMax) {
+		return nil
+	}
+	if redirectMax <= 0 {
+		redirectMax = constants.DefaultRedirectMax
+	}
+
+	return func(request *http.Request, via []*http.Request) error {
+
+		// Only Subscriber Requests Are Subject To The RedirectPolicy
+		original := via[0]
+		subscriberRequest := isSubscriberURL(subscriberURI, original.URL)
+		if subscriberRequest && policy == constants.RedirectPolicyFail {
+			logger.Warn("Subscriber Responded With Redirect - Treating As Failed Delivery", zap.String("Location", request.URL.String()))
+			return http.ErrUseLastResponse
+		}
+
+		// Stop Following Redirects Once The Maximum Has Been Reached
+		if len(via) > redirectMax {
+			return fmt.Errorf("stopped after %d redirects", redirectMax)
+		}
+
+		// Restore The CloudEvent's Method, Body & Headers Which The Go HTTP Client May Have Dropped
+		if subscriberRequest && policy == constants.RedirectPolicyPreserve {
+			if request.Method != original.Method && original.GetBody != nil {
+				body, err := original.GetBody()
+				if err != nil {
+					return err
+				}
+				request.Body = body
+				request.GetBody = original.GetBody
+				request.ContentLength = original.ContentLength
+			}
+			request.Method = original.Method
+			for key, values := range original.Header {
+				if _, ok := request.Header[key]; !ok && !sensitiveRedirectHeaders[key] {
+					request.Header[key] = values
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// Determine Whether The Specified URL Is That Of The Subscriber (Rather Than The Reply Or DeadLetterSink)
+func isSubscriberURL(subscriberURI *apis.URL, requestURL *url.URL) bool {
+	return subscriberURI != nil && requestURL != nil &&
+		requestURL.Scheme == subscriberURI.Scheme && requestURL.Host == subscriberURI.Host && requestURL.Path == subscriberURI.Path
+}
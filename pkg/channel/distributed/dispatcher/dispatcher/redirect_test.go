@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseRedirectPolicy() Functionality
+func TestParseRedirectPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultRedirectPolicy},
+		{name: "Follow", policy: constants.RedirectPolicyFollow, want: constants.RedirectPolicyFollow},
+		{name: "Preserve", policy: constants.RedirectPolicyPreserve, want: constants.RedirectPolicyPreserve},
+		{name: "Fail", policy: constants.RedirectPolicyFail, want: constants.RedirectPolicyFail},
+		{name: "Invalid", policy: "ignore", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseRedirectPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ParseRedirectMax() Functionality
+func TestParseRedirectMax(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		redirectMax int
+		want        int
+		wantErr     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", redirectMax: 0, want: constants.DefaultRedirectMax},
+		{name: "Specified", redirectMax: 3, want: 3},
+		{name: "Negative", redirectMax: -1, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			redirectMax, err := ParseRedirectMax(testCase.redirectMax)
+			assert.Equal(t, testCase.want, redirectMax)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The Delivery Of Events To A Subscriber Responding With Redirects Under Each RedirectPolicy
+func TestRedirectPolicies(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name             string
+		policy           string
+		redirectMax      int
+		statusCode       int
+		redirects        int // Number Of Consecutive Redirects Before The Subscriber Accepts The Event
+		deadLetter       bool
+		wantErr          bool
+		wantMethod       string // Method Of The Request Reaching The Subscriber's Target (None If Empty)
+		wantBody         bool   // Whether The Request Reaching The Subscriber's Target Contained The CloudEvent
+		wantDeadLettered int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "Default Follows 307 With Event",
+			policy:     "",
+			statusCode: http.StatusTemporaryRedirect,
+			redirects:  1,
+			wantMethod: http.MethodPost,
+			wantBody:   true,
+		},
+		{
+			name:       "Follow 302 Drops Event",
+			policy:     constants.RedirectPolicyFollow,
+			statusCode: http.StatusFound,
+			redirects:  1,
+			wantMethod: http.MethodGet,
+			wantBody:   false,
+		},
+		{
+			name:        "Follow Exceeding Redirect Max",
+			policy:      constants.RedirectPolicyFollow,
+			redirectMax: 2,
+			statusCode:  http.StatusTemporaryRedirect,
+			redirects:   3,
+			wantErr:     true,
+		},
+		{
+			name:       "Preserve 302 Re-Sends Event",
+			policy:     constants.RedirectPolicyPreserve,
+			statusCode: http.StatusFound,
+			redirects:  1,
+			wantMethod: http.MethodPost,
+			wantBody:   true,
+		},
+		{
+			name:       "Preserve 303 Chain Re-Sends Event",
+			policy:     constants.RedirectPolicyPreserve,
+			statusCode: http.StatusSeeOther,
+			redirects:  3,
+			wantMethod: http.MethodPost,
+			wantBody:   true,
+		},
+		{
+			name:       "Fail",
+			policy:     constants.RedirectPolicyFail,
+			statusCode: http.StatusTemporaryRedirect,
+			redirects:  1,
+			wantErr:    true,
+		},
+		{
+			name:             "Fail With DeadLetterSink",
+			policy:           constants.RedirectPolicyFail,
+			statusCode:       http.StatusMovedPermanently,
+			redirects:        1,
+			deadLetter:       true,
+			wantDeadLettered: 1,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Subscriber Redirecting Through The TestCase's Number Of Hops Before Accepting The Event
+			var lock sync.Mutex
+			var targetMethod string
+			var targetBody []byte
+			var targetEventId string
+			subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				hop := len(request.URL.Query()["hop"])
+				if hop < testCase.redirects {
+					query := request.URL.Query()
+					query.Add("hop", "true")
+					response.Header().Set("Location", "/target?"+query.Encode())
+					response.WriteHeader(testCase.statusCode)
+					return
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				targetMethod = request.Method
+				targetBody, _ = ioutil.ReadAll(request.Body)
+				targetEventId = request.Header.Get("ce-id")
+				response.WriteHeader(http.StatusAccepted)
+			}))
+			defer subscriber.Close()
+
+			// Create A Mock DeadLetterSink
+			var deadLettered int
+			deadLetterSink := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+				deadLettered++
+				response.WriteHeader(http.StatusAccepted)
+			}))
+			defer deadLetterSink.Close()
+
+			// Create The MessageDispatcher With The TestCase's RedirectPolicy
+			logger := logtesting.TestLogger(t).Desugar()
+			subscriberURI, err := apis.ParseURL(subscriber.URL + "/subscriber")
+			assert.Nil(t, err)
+			checkRedirect := newCheckRedirect(logger, testCase.policy, testCase.redirectMax, subscriberURI)
+			messageDispatcher := newSubscriberMessageDispatcherWrapper(logger, NewSubscriberTransport(nil), checkRedirect)
+
+			// Perform The Test
+			var deadLetterURL *url.URL
+			if testCase.deadLetter {
+				deadLetterURL, err = url.Parse(deadLetterSink.URL)
+				assert.Nil(t, err)
+			}
+			retryConfig := kncloudevents.NoRetries()
+			err = messageDispatcher.DispatchMessageWithRetries(context.Background(), newRedirectTestMessage(t), nil, subscriberURI.URL(), nil, deadLetterURL, &retryConfig)
+
+			// Verify The Results
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantMethod, targetMethod)
+			if testCase.wantBody {
+				assert.Equal(t, `{"content":"redirected"}`, string(targetBody))
+				assert.Equal(t, "redirect-test-id", targetEventId)
+			} else {
+				assert.Empty(t, targetBody)
+			}
+			assert.Equal(t, testCase.wantDeadLettered, deadLettered)
+		})
+	}
+}
+
+// Test That The Default RedirectPolicy Retains The Go HTTP Client's Redirect Handling
+func TestNewCheckRedirectDefault(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	assert.Nil(t, newCheckRedirect(logger, "", 0, nil))
+	assert.Nil(t, newCheckRedirect(logger, constants.RedirectPolicyFollow, constants.DefaultRedirectMax, nil))
+	assert.NotNil(t, newCheckRedirect(logger, constants.RedirectPolicyFollow, 3, nil))
+	assert.NotNil(t, newCheckRedirect(logger, constants.RedirectPolicyFail, 0, nil))
+}
+
+// Create A Test Message Carrying A CloudEvent With Data (In The Request Body)
+func newRedirectTestMessage(t *testing.T) binding.Message {
+	event := cloudevents.NewEvent()
+	event.SetID("redirect-test-id")
+	event.SetType("test-type")
+	event.SetSource("test-source")
+	assert.Nil(t, event.SetData(cloudevents.ApplicationJSON, map[string]string{"content": "redirected"}))
+	return binding.ToMessage(&event)
+}
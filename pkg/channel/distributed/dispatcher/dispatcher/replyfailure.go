@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/pkg/apis"
+)
+
+// Validate The Specified ReplyFailurePolicy & Return It (Or The Default If Unspecified)
+func ParseReplyFailurePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultReplyFailurePolicy, nil
+	case constants.ReplyFailurePolicyFail, constants.ReplyFailurePolicyDrop, constants.ReplyFailurePolicyDeadLetter:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid reply failure policy '%s' - must be one of '%s', '%s' or '%s'", policy,
+			constants.ReplyFailurePolicyFail, constants.ReplyFailurePolicyDrop, constants.ReplyFailurePolicyDeadLetter)
+	}
+}
+
+// Validate The Specified Reply Retry Budget & Interval, Returning The Interval (Or The Default If Unspecified)
+func ParseReplyRetry(retryMax int, intervalMillis int64) (time.Duration, error) {
+	if retryMax < 0 {
+		return 0, fmt.Errorf("invalid reply retry max %d - must be >= 0", retryMax)
+	}
+	if intervalMillis < 0 {
+		return 0, fmt.Errorf("invalid reply retry interval %dms - must be >= 0", intervalMillis)
+	}
+	if intervalMillis == 0 {
+		intervalMillis = constants.DefaultReplyRetryIntervalMillis
+	}
+	return time.Duration(intervalMillis) * time.Millisecond, nil
+}
+
+// Determine Whether The Specified ReplyFailurePolicy Is Applied By A replyFailureTransport (Rather Than Failing The Delivery)
+func isReplyFailureHandled(policy string) bool {
+	return policy == constants.ReplyFailurePolicyDrop || policy == constants.ReplyFailurePolicyDeadLetter
+}
+
+//
+// HTTP RoundTripper Applying The ReplyFailurePolicy To The Replies Of A Single Subscriber
+//
+// The Knative MessageDispatcher forwards the subscriber's response event to the reply destination with the same
+// retries as the subscriber, and treats a failure to do so as a failed delivery.  A reply destination which has been
+// removed (or is otherwise permanently unreachable) would therefore fail every delivery, and with auto-commit disabled
+// would stop the partition's progress entirely.  Instead, requests to the reply destination are attempted up to the
+// configured retry budget here, after which the reply is either dropped or sent to the subscriber's DeadLetterSink
+// (as per the policy) and replaced with a 202 (Accepted) so that the delivery completes.  A reply which cannot be
+// sent to the DeadLetterSink either is returned as the failure it was, and requests to the subscriber & the
+// DeadLetterSink are passed through unchanged.
+//
+type replyFailureTransport struct {
+	logger        *zap.Logger
+	transport     http.RoundTripper
+	policy        string
+	replyURI      *apis.URL
+	deadLetterURI *apis.URL // Optional DeadLetterSink (Replies Are Dropped Without One)
+	retryMax      int
+	retryInterval time.Duration
+	topic         string
+	statsReporter metrics.StatsReporter
+}
+
+// Verify The replyFailureTransport Implements The http.RoundTripper Interface
+var _ http.RoundTripper = &replyFailureTransport{}
+
+// replyFailureTransport Constructor
+func newReplyFailureTransport(logger *zap.Logger, transport http.RoundTripper, policy string, replyURI *apis.URL, deadLetterURI *apis.URL, retryMax int, retryInterval time.Duration, topic string, statsReporter metrics.StatsReporter) *replyFailureTransport {
+	return &replyFailureTransport{
+		logger:        logger,
+		transport:     transport,
+		policy:        policy,
+		replyURI:      replyURI,
+		deadLetterURI: deadLetterURI,
+		retryMax:      retryMax,
+		retryInterval: retryInterval,
+		topic:         topic,
+		statsReporter: statsReporter,
+	}
+}
+
+// Perform The Specified HTTP Request, Applying The ReplyFailurePolicy To Replies Which Cannot Be Delivered
+func (r *replyFailureTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	// Pass Through Requests To Any Other Destination
+	if !isRequestTo(request, r.replyURI) {
+		return r.transport.RoundTrip(request)
+	}
+
+	// Buffer The Reply's Body So That It May Be Resent
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Attempt To Deliver The Reply Up To The Retry Budget (Giving Up Early If The Request Is Cancelled)
+	var response *http.Response
+	var err error
+	for attempt := 0; attempt <= r.retryMax; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(r.retryInterval)
+			select {
+			case <-timer.C:
+			case <-request.Context().Done():
+				timer.Stop()
+				return response, err
+			}
+			if response != nil {
+				_ = response.Body.Close()
+			}
+		}
+		response, err = r.transport.RoundTrip(cloneRequest(request, nil, body))
+		if err == nil && response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+			return response, nil
+		}
+	}
+
+	// The Reply Could Not Be Delivered - Send It To The DeadLetterSink If Configured To Do So
+	logger := r.logger.With(zap.String("ReplyURI", r.replyURI.String()), zap.Int("Attempts", r.retryMax+1), zap.NamedError("ReplyError", replyError(response, err)))
+	policy := constants.ReplyFailurePolicyDrop
+	if r.policy == constants.ReplyFailurePolicyDeadLetter {
+		if r.deadLetterURI == nil {
+			logger.Warn("Reply Failure Policy Is DeadLetter But Subscriber Has No DeadLetterSink - Dropping Reply")
+		} else {
+			deadLetterResponse, deadLetterErr := r.transport.RoundTrip(cloneRequest(request, r.deadLetterURI, body))
+			if deadLetterErr != nil || deadLetterResponse.StatusCode < http.StatusOK || deadLetterResponse.StatusCode >= http.StatusMultipleChoices {
+				logger.Error("Failed To Send Undeliverable Reply To DeadLetterSink", zap.Error(replyError(deadLetterResponse, deadLetterErr)))
+				if deadLetterResponse != nil {
+					_ = deadLetterResponse.Body.Close()
+				}
+				return response, err
+			}
+			_ = deadLetterResponse.Body.Close()
+			policy = constants.ReplyFailurePolicyDeadLetter
+		}
+	}
+
+	// Complete The Delivery As Though The Reply Had Been Accepted
+	if response != nil {
+		_ = response.Body.Close()
+	}
+	if policy == constants.ReplyFailurePolicyDeadLetter {
+		logger.Warn("Failed To Deliver Reply - Sent To DeadLetterSink")
+	} else {
+		logger.Warn("Failed To Deliver Reply - Dropped")
+	}
+	r.statsReporter.ReportReplyFailure(r.topic, policy)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", http.StatusAccepted, http.StatusText(http.StatusAccepted)),
+		StatusCode: http.StatusAccepted,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    request,
+	}, nil
+}
+
+// Determine Whether The Specified Request Is To The Specified Destination (e.g. The Reply Rather Than The Subscriber)
+func isRequestTo(request *http.Request, destinationURI *apis.URL) bool {
+	return destinationURI != nil && request.URL != nil &&
+		request.URL.Scheme == destinationURI.Scheme && request.URL.Host == destinationURI.Host && request.URL.Path == destinationURI.Path
+}
+
+// Copy The Specified Request With A Fresh Reader Of The Buffered Body (Redirected To The Specified Destination If Any)
+func cloneRequest(request *http.Request, destinationURI *apis.URL, body []byte) *http.Request {
+	clone := request.Clone(request.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	clone.ContentLength = int64(len(body))
+	if destinationURI != nil {
+		clone.URL = destinationURI.URL()
+		clone.Host = ""
+	}
+	return clone
+}
+
+// Describe The Failure Of A Request From Its Error Or Non-2xx Response
+func replyError(response *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected HTTP response, expected 2xx, got %d", response.StatusCode)
+}
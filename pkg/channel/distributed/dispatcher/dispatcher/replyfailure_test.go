@@ -0,0 +1,260 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseReplyFailurePolicy() Functionality
+func TestParseReplyFailurePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultReplyFailurePolicy},
+		{name: "Fail", policy: constants.ReplyFailurePolicyFail, want: constants.ReplyFailurePolicyFail},
+		{name: "Drop", policy: constants.ReplyFailurePolicyDrop, want: constants.ReplyFailurePolicyDrop},
+		{name: "DeadLetter", policy: constants.ReplyFailurePolicyDeadLetter, want: constants.ReplyFailurePolicyDeadLetter},
+		{name: "Invalid", policy: "skip", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseReplyFailurePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ParseReplyRetry() Functionality
+func TestParseReplyRetry(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		retryMax       int
+		intervalMillis int64
+		want           time.Duration
+		wantErr        bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", want: constants.DefaultReplyRetryIntervalMillis * time.Millisecond},
+		{name: "Specified", retryMax: 3, intervalMillis: 250, want: 250 * time.Millisecond},
+		{name: "Negative RetryMax", retryMax: -1, wantErr: true},
+		{name: "Negative Interval", retryMax: 3, intervalMillis: -1, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			interval, err := ParseReplyRetry(testCase.retryMax, testCase.intervalMillis)
+			assert.Equal(t, testCase.want, interval)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That Replies Which Cannot Be Delivered (e.g. To A Removed Reply Destination) Are Handled Per The ReplyFailurePolicy
+func TestReplyFailureTransport(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name               string
+		policy             string
+		replyStatus        int  // Status Code Of The Reply Destination's Responses (Unless Removed)
+		replyRemoved       bool // Whether The Reply Destination Has Been Removed (Refusing Connections)
+		deadLetter         bool
+		deadLetterStatus   int
+		wantErr            bool
+		wantReplyAttempts  int32
+		wantDeadLettered   int32
+		wantDeadLetterType string // The CloudEvent Type Received By The DeadLetterSink (The Reply Or The Original Event)
+		wantReplyFailures  map[string]int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:              "Reply Delivered",
+			policy:            constants.ReplyFailurePolicyDrop,
+			replyStatus:       http.StatusAccepted,
+			wantReplyAttempts: 1,
+		},
+		{
+			name:         "Fail Policy With Removed Reply",
+			policy:       constants.ReplyFailurePolicyFail,
+			replyRemoved: true,
+			wantErr:      true,
+		},
+		{
+			name:               "Fail Policy With Removed Reply & DeadLetterSink",
+			policy:             constants.ReplyFailurePolicyFail,
+			replyRemoved:       true,
+			deadLetter:         true,
+			deadLetterStatus:   http.StatusAccepted,
+			wantDeadLettered:   1,
+			wantDeadLetterType: "test-type",
+		},
+		{
+			name:              "Drop Policy With Removed Reply",
+			policy:            constants.ReplyFailurePolicyDrop,
+			replyRemoved:      true,
+			wantReplyFailures: map[string]int{constants.ReplyFailurePolicyDrop: 1},
+		},
+		{
+			name:              "Drop Policy With Failing Reply",
+			policy:            constants.ReplyFailurePolicyDrop,
+			replyStatus:       http.StatusNotFound,
+			wantReplyAttempts: 3,
+			wantReplyFailures: map[string]int{constants.ReplyFailurePolicyDrop: 1},
+		},
+		{
+			name:               "DeadLetter Policy With Removed Reply",
+			policy:             constants.ReplyFailurePolicyDeadLetter,
+			replyRemoved:       true,
+			deadLetter:         true,
+			deadLetterStatus:   http.StatusAccepted,
+			wantDeadLettered:   1,
+			wantDeadLetterType: "test-reply-type",
+			wantReplyFailures:  map[string]int{constants.ReplyFailurePolicyDeadLetter: 1},
+		},
+		{
+			name:               "DeadLetter Policy With Failing Reply",
+			policy:             constants.ReplyFailurePolicyDeadLetter,
+			replyStatus:        http.StatusNotFound,
+			deadLetter:         true,
+			deadLetterStatus:   http.StatusAccepted,
+			wantReplyAttempts:  3,
+			wantDeadLettered:   1,
+			wantDeadLetterType: "test-reply-type",
+			wantReplyFailures:  map[string]int{constants.ReplyFailurePolicyDeadLetter: 1},
+		},
+		{
+			name:              "DeadLetter Policy With Removed Reply & No DeadLetterSink",
+			policy:            constants.ReplyFailurePolicyDeadLetter,
+			replyRemoved:      true,
+			wantReplyFailures: map[string]int{constants.ReplyFailurePolicyDrop: 1},
+		},
+		{
+			name:               "DeadLetter Policy With Removed Reply & Failing DeadLetterSink",
+			policy:             constants.ReplyFailurePolicyDeadLetter,
+			replyRemoved:       true,
+			deadLetter:         true,
+			deadLetterStatus:   http.StatusInternalServerError,
+			wantErr:            true,
+			wantDeadLettered:   2, // The Reply (By The Transport) & Then The Original Event (By The MessageDispatcher)
+			wantDeadLetterType: "test-type",
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Subscriber Which Always Responds With A Reply Event
+			subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				response.Header().Set("ce-specversion", "1.0")
+				response.Header().Set("ce-id", "test-reply-id")
+				response.Header().Set("ce-type", "test-reply-type")
+				response.Header().Set("ce-source", "test-subscriber")
+				response.WriteHeader(http.StatusOK)
+			}))
+			defer subscriber.Close()
+
+			// Create A Mock Reply Destination (Closed Immediately If It Has Been Removed)
+			var replyAttempts int32
+			reply := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				atomic.AddInt32(&replyAttempts, 1)
+				response.WriteHeader(testCase.replyStatus)
+			}))
+			defer reply.Close()
+			if testCase.replyRemoved {
+				reply.Close()
+			}
+
+			// Create A Mock DeadLetterSink Recording The Type Of The Last Event Received
+			var deadLettered int32
+			var deadLetterType atomic.Value
+			deadLetterSink := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				atomic.AddInt32(&deadLettered, 1)
+				deadLetterType.Store(request.Header.Get("ce-type"))
+				response.WriteHeader(testCase.deadLetterStatus)
+			}))
+			defer deadLetterSink.Close()
+
+			// Create The MessageDispatcher With The ReplyFailure Transport (Unless The Policy Fails The Delivery)
+			logger := logtesting.TestLogger(t).Desugar()
+			subscriberURI, err := apis.ParseURL(subscriber.URL)
+			assert.Nil(t, err)
+			replyURI, err := apis.ParseURL(reply.URL)
+			assert.Nil(t, err)
+			var deadLetterURI *apis.URL
+			var deadLetterURL *url.URL
+			if testCase.deadLetter {
+				deadLetterURI, err = apis.ParseURL(deadLetterSink.URL)
+				assert.Nil(t, err)
+				deadLetterURL = deadLetterURI.URL()
+			}
+			statsReporter := dispatchertesting.NewMockStatsReporter()
+			var transport http.RoundTripper = NewSubscriberTransport(nil)
+			if isReplyFailureHandled(testCase.policy) {
+				transport = newReplyFailureTransport(logger, transport, testCase.policy, replyURI, deadLetterURI, 2, 10*time.Millisecond, testTopic, statsReporter)
+			}
+			messageDispatcher := newSubscriberMessageDispatcherWrapper(logger, transport, nil)
+
+			// Perform The Test
+			retryConfig := kncloudevents.NoRetries()
+			err = messageDispatcher.DispatchMessageWithRetries(context.Background(), newTestMessage(), nil, subscriberURI.URL(), replyURI.URL(), deadLetterURL, &retryConfig)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantReplyAttempts, atomic.LoadInt32(&replyAttempts))
+			assert.Equal(t, testCase.wantDeadLettered, atomic.LoadInt32(&deadLettered))
+			if testCase.wantDeadLettered > 0 {
+				assert.Equal(t, testCase.wantDeadLetterType, deadLetterType.Load())
+			}
+			for _, policy := range []string{constants.ReplyFailurePolicyDrop, constants.ReplyFailurePolicyDeadLetter} {
+				assert.Equal(t, testCase.wantReplyFailures[policy], statsReporter.ReplyFailures(policy))
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// Validate The Specified Retry Budget & Return It (Or The Default If Unspecified)
+func ParseRetryBudget(retryBudgetMillis int64) (time.Duration, error) {
+	if retryBudgetMillis < 0 {
+		return 0, fmt.Errorf("invalid retry budget %dms - must be >= 0", retryBudgetMillis)
+	}
+	if retryBudgetMillis == 0 {
+		retryBudgetMillis = constants.DefaultRetryBudgetMillis
+	}
+	return time.Duration(retryBudgetMillis) * time.Millisecond, nil
+}
+
+//
+// Parse The KafkaChannel's HeadOfLinePolicies Annotation Into A Map Of Subscriber UID Or URI To HeadOfLinePolicy
+//
+// The annotation is a JSON object whose keys identify subscribers either by their (Subscription) UID or by their
+// subscriber URI, and whose values are one of the constants.HeadOfLinePolicy* values.  An empty annotation results
+// in an empty map (all subscribers using the default "ordering" policy).
+//
+func ParseHeadOfLinePolicies(annotation string) (map[string]string, error) {
+
+	headOfLinePolicies := make(map[string]string)
+	if len(annotation) <= 0 {
+		return headOfLinePolicies, nil
+	}
+
+	// Unmarshal The JSON Annotation
+	policies := make(map[string]string)
+	if err := json.Unmarshal([]byte(annotation), &policies); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", constants.HeadOfLinePoliciesAnnotation, err)
+	}
+
+	// Validate Each Subscriber's Policy (Sorted For Deterministic Errors)
+	keys := make([]string, 0, len(policies))
+	for key := range policies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch policies[key] {
+		case constants.HeadOfLinePolicyOrdering, constants.HeadOfLinePolicyProgress:
+			headOfLinePolicies[key] = policies[key]
+		default:
+			return nil, fmt.Errorf("invalid %s annotation: head-of-line policy '%s' of subscriber '%s' must be one of '%s' or '%s'",
+				constants.HeadOfLinePoliciesAnnotation, policies[key], key, constants.HeadOfLinePolicyOrdering, constants.HeadOfLinePolicyProgress)
+		}
+	}
+
+	return headOfLinePolicies, nil
+}
+
+//
+// Update The Per-Subscriber HeadOfLinePolicies From The Specified KafkaChannel HeadOfLinePolicies Annotation
+//
+// Unlike the initial offsets, a subscriber's policy is looked up each time one of its partitions is claimed, so a
+// change applies to existing subscribers from their next ConsumerGroup session (e.g. after a re-balance).  An invalid
+// annotation is returned as an error, in which case the previous policies are retained.
+//
+func (d *DispatcherImpl) UpdateHeadOfLinePolicies(annotation string) error {
+
+	headOfLinePolicies, err := ParseHeadOfLinePolicies(annotation)
+	if err != nil {
+		d.Logger.Error("Failed To Parse Subscriber HeadOfLinePolicies", zap.Error(err))
+		return err
+	}
+
+	d.headOfLineLock.Lock()
+	defer d.headOfLineLock.Unlock()
+	d.headOfLinePolicies = headOfLinePolicies
+	return nil
+}
+
+// Get The Current Per-Subscriber HeadOfLinePolicies (Replaced Rather Than Modified By Updates)
+func (d *DispatcherImpl) currentHeadOfLinePolicies() map[string]string {
+	d.headOfLineLock.RLock()
+	defer d.headOfLineLock.RUnlock()
+	return d.headOfLinePolicies
+}
+
+// Get A Function Returning The Specified Subscriber's Current HeadOfLinePolicy (For Use By Its Handler)
+func (d *DispatcherImpl) headOfLinePolicyFunc(subscriberSpec eventingduck.SubscriberSpec) func() string {
+	return func() string {
+
+		// Subscribers Are Identified By UID In Preference To Their URI
+		headOfLinePolicies := d.currentHeadOfLinePolicies()
+		policy, ok := headOfLinePolicies[string(subscriberSpec.UID)]
+		if !ok && subscriberSpec.SubscriberURI != nil {
+			policy, ok = headOfLinePolicies[subscriberSpec.SubscriberURI.String()]
+		}
+		if !ok {
+			return constants.DefaultHeadOfLinePolicy
+		}
+		return policy
+	}
+}
+
+//
+// Bound The Time Spent Retrying Each Message Of A Single Partition
+//
+// With the "progress" HeadOfLinePolicy a partition's ConsumeClaim() loop starts the budget before delivering each
+// message, and the wrapped RetryConfig stops retrying once it has been exhausted, limiting each backoff to the time
+// remaining.  The message dispatcher then sends the message to the subscriber's DeadLetterSink (if any), after which
+// the message is marked regardless of the outcome so that a single poison message cannot stall the partition.  A
+// DeadLetterSink attempted after the budget has been exhausted is therefore not retried.  The budget is only used by
+// the single goroutine consuming the partition, so it requires no locking.
+//
+type retryBudget struct {
+	budget   time.Duration
+	deadline time.Time
+}
+
+// retryBudget Constructor
+func newRetryBudget(budget time.Duration) *retryBudget {
+	return &retryBudget{budget: budget}
+}
+
+// Start The Budget Of The Next Message
+func (b *retryBudget) start() {
+	b.deadline = time.Now().Add(b.budget)
+}
+
+// Get The Time Remaining In The Current Message's Budget (Zero Once Exhausted)
+func (b *retryBudget) remaining() time.Duration {
+	remaining := time.Until(b.deadline)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Wrap The Specified CheckRetry So That No Further Retries Are Made Once The Budget Is Exhausted
+func (b *retryBudget) wrapCheckRetry(checkRetry kncloudevents.CheckRetry) kncloudevents.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		if checkRetry == nil {
+			return false, nil
+		}
+		retry, retryErr := checkRetry(ctx, response, err)
+		if retry && b.remaining() <= 0 {
+			return false, retryErr
+		}
+		return retry, retryErr
+	}
+}
+
+// Wrap The Specified Backoff So That No Backoff Extends Beyond The Budget
+func (b *retryBudget) wrapBackoff(backoff kncloudevents.Backoff) kncloudevents.Backoff {
+	return func(attemptNum int, response *http.Response) time.Duration {
+		var delay time.Duration
+		if backoff != nil {
+			delay = backoff(attemptNum, response)
+		}
+		if remaining := b.remaining(); delay > remaining {
+			return remaining
+		}
+		return delay
+	}
+}
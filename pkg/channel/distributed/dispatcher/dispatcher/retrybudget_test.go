@@ -0,0 +1,288 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The ParseRetryBudget() Functionality
+func TestParseRetryBudget(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		budgetMillis int64
+		want         time.Duration
+		wantErr      bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", budgetMillis: 0, want: constants.DefaultRetryBudgetMillis * time.Millisecond},
+		{name: "Specified", budgetMillis: 2500, want: 2500 * time.Millisecond},
+		{name: "Negative", budgetMillis: -1, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			budget, err := ParseRetryBudget(testCase.budgetMillis)
+			assert.Equal(t, testCase.want, budget)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ParseHeadOfLinePolicies() Functionality
+func TestParseHeadOfLinePolicies(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		annotation string
+		want       map[string]string
+		wantErr    bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", annotation: "", want: map[string]string{}},
+		{name: "Empty", annotation: "{}", want: map[string]string{}},
+		{
+			name:       "Ordering & Progress",
+			annotation: `{"` + id123 + `":"progress","http://subscriber.example.com":"ordering"}`,
+			want:       map[string]string{id123: constants.HeadOfLinePolicyProgress, "http://subscriber.example.com": constants.HeadOfLinePolicyOrdering},
+		},
+		{name: "Invalid Policy", annotation: `{"` + id123 + `":"skip"}`, wantErr: true},
+		{name: "Invalid JSON", annotation: `progress`, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			headOfLinePolicies, err := ParseHeadOfLinePolicies(testCase.annotation)
+			assert.Equal(t, testCase.want, headOfLinePolicies)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That The Per-Subscriber HeadOfLinePolicies Are Looked Up By UID Or URI & Reflect Subsequent Updates
+func TestUpdateHeadOfLinePolicies(t *testing.T) {
+
+	// Test Data
+	subscriberURI := apis.HTTP("subscriber.example.com")
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()}).(*DispatcherImpl)
+	uidSubscriberPolicy := dispatcher.headOfLinePolicyFunc(eventingduck.SubscriberSpec{UID: uid123})
+	uriSubscriberPolicy := dispatcher.headOfLinePolicyFunc(eventingduck.SubscriberSpec{UID: uid456, SubscriberURI: subscriberURI})
+	defaultSubscriberPolicy := dispatcher.headOfLinePolicyFunc(eventingduck.SubscriberSpec{UID: uid789})
+
+	// Verify All Subscribers Default To Ordering
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, uidSubscriberPolicy())
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, uriSubscriberPolicy())
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, defaultSubscriberPolicy())
+
+	// Perform The Test - Specify Progress For One Subscriber By UID & Another By URI
+	err := dispatcher.UpdateHeadOfLinePolicies(`{"` + id123 + `":"progress","` + subscriberURI.String() + `":"progress"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, constants.HeadOfLinePolicyProgress, uidSubscriberPolicy())
+	assert.Equal(t, constants.HeadOfLinePolicyProgress, uriSubscriberPolicy())
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, defaultSubscriberPolicy())
+
+	// Verify An Invalid Annotation Is Rejected & The Previous Policies Retained
+	assert.NotNil(t, dispatcher.UpdateHeadOfLinePolicies(`{"`+id123+`":"skip"}`))
+	assert.Equal(t, constants.HeadOfLinePolicyProgress, uidSubscriberPolicy())
+
+	// Verify The UID Takes Precedence Over The URI & Removing The Annotation Restores The Default
+	assert.Nil(t, dispatcher.UpdateHeadOfLinePolicies(`{"`+id456+`":"ordering","`+subscriberURI.String()+`":"progress"}`))
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, uriSubscriberPolicy())
+	assert.Nil(t, dispatcher.UpdateHeadOfLinePolicies(""))
+	assert.Equal(t, constants.HeadOfLinePolicyOrdering, uidSubscriberPolicy())
+}
+
+// Test That The Wrapped RetryConfig Functions Stop Retrying & Backing Off Once The Budget Is Exhausted
+func TestRetryBudget(t *testing.T) {
+
+	// Test Data
+	alwaysRetry := func(context.Context, *http.Response, error) (bool, error) { return true, nil }
+	neverRetry := func(context.Context, *http.Response, error) (bool, error) { return false, nil }
+	longBackoff := func(int, *http.Response) time.Duration { return time.Hour }
+	shortBackoff := func(int, *http.Response) time.Duration { return time.Millisecond }
+
+	// Start A Budget With Time Remaining
+	budget := newRetryBudget(time.Minute)
+	budget.start()
+
+	// Verify Retries Are Determined By The Wrapped CheckRetry & Backoffs Are Limited To The Time Remaining
+	retry, err := budget.wrapCheckRetry(alwaysRetry)(context.TODO(), nil, nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	retry, _ = budget.wrapCheckRetry(neverRetry)(context.TODO(), nil, nil)
+	assert.False(t, retry)
+	retry, _ = budget.wrapCheckRetry(nil)(context.TODO(), nil, nil)
+	assert.False(t, retry)
+	assert.Equal(t, time.Millisecond, budget.wrapBackoff(shortBackoff)(1, nil))
+	delay := budget.wrapBackoff(longBackoff)(1, nil)
+	assert.True(t, delay > 0 && delay <= time.Minute)
+	assert.Equal(t, time.Duration(0), budget.wrapBackoff(nil)(1, nil))
+
+	// Exhaust The Budget & Verify No Further Retries Or Backoffs
+	budget.deadline = time.Now().Add(-time.Second)
+	retry, _ = budget.wrapCheckRetry(alwaysRetry)(context.TODO(), nil, nil)
+	assert.False(t, retry)
+	assert.Equal(t, time.Duration(0), budget.wrapBackoff(longBackoff)(1, nil))
+
+	// Verify Starting The Next Message Renews The Budget
+	budget.start()
+	retry, _ = budget.wrapCheckRetry(alwaysRetry)(context.TODO(), nil, nil)
+	assert.True(t, retry)
+}
+
+// Test That The Handler's ConsumeClaim() Proceeds Past A Poison Message Only When Favoring Progress
+func TestHandlerConsumeClaimHeadOfLinePolicy(t *testing.T) {
+
+	// Test Data
+	poisonMsgId := "PoisonMsgId"
+	retryBudget := 200 * time.Millisecond
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name             string
+		policy           string
+		retry            bool
+		deadLetter       bool
+		wantBlocked      bool
+		wantDeadLettered bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Ordering Blocks The Partition", policy: constants.HeadOfLinePolicyOrdering, deadLetter: false, wantBlocked: true},
+		{name: "Progress Dead-Letters The Poison Message", policy: constants.HeadOfLinePolicyProgress, retry: true, deadLetter: true, wantDeadLettered: true},
+		{name: "Progress Skips The Poison Message Without A DeadLetterSink", policy: constants.HeadOfLinePolicyProgress, retry: true, deadLetter: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Subscriber Which Always Fails To Accept The Poison Message
+			var poisonAttempts, delivered int32
+			subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				if request.Header.Get("Ce-Id") == poisonMsgId {
+					atomic.AddInt32(&poisonAttempts, 1)
+					response.WriteHeader(http.StatusInternalServerError)
+				} else {
+					atomic.AddInt32(&delivered, 1)
+					response.WriteHeader(http.StatusAccepted)
+				}
+			}))
+			defer subscriber.Close()
+			subscriberURI, err := apis.ParseURL(subscriber.URL)
+			assert.Nil(t, err)
+
+			// Create A Mock DeadLetterSink Counting The Messages It Receives
+			var deadLettered int32
+			deadLetterSink := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, poisonMsgId, request.Header.Get("Ce-Id"))
+				atomic.AddInt32(&deadLettered, 1)
+				response.WriteHeader(http.StatusAccepted)
+			}))
+			defer deadLetterSink.Close()
+			var deadLetterURI *apis.URL
+			if testCase.deadLetter {
+				deadLetterURI, err = apis.ParseURL(deadLetterSink.URL)
+				assert.Nil(t, err)
+			}
+
+			// Create The Handler To Test With Auto-Commit Disabled (Exponential Retries Would Otherwise Take 30s)
+			deliverySpec := createDeliverySpec(deadLetterURI, testCase.retry)
+			handler := createTestHandler(t, subscriberURI, nil, &deliverySpec)
+			handler.ManualCommit = true
+			handler.RetryBudget = retryBudget
+			handler.headOfLinePolicy = func() string { return testCase.policy }
+
+			// Background Start Consuming Claims (In A Session Which Ends, Ending Redelivery Of A Message Blocking The Partition)
+			session := newOffsetRecordingSession()
+			if testCase.wantBlocked {
+				sessionCtx, endSession := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer endSession()
+				session.Ctx = sessionCtx
+			}
+			mockConsumerGroupClaim := dispatchertesting.NewMockConsumerGroupClaim(t)
+			errChan := make(chan error)
+			go func() {
+				errChan <- handler.ConsumeClaim(session, mockConsumerGroupClaim)
+			}()
+
+			// Perform The Test - Consume The Poison Message
+			startTime := time.Now()
+			mockConsumerGroupClaim.MessageChan <- createPoisonConsumerMessage(t, poisonMsgId)
+
+			// Verify An Ordering Subscriber's Partition Is Blocked Without Marking The Poison Message (Until The Session Ends)
+			if testCase.wantBlocked {
+				assert.NotNil(t, <-errChan)
+				assert.Equal(t, int64(0), session.marked())
+				assert.Equal(t, int32(1), atomic.LoadInt32(&poisonAttempts))
+				return
+			}
+
+			// Otherwise Verify The Partition Proceeds To Deliver The Next Message Once The Budget Is Exhausted
+			nextMessage := createConsumerMessage(t)
+			nextMessage.Offset = int64(testOffset + 1)
+			mockConsumerGroupClaim.MessageChan <- nextMessage
+			close(mockConsumerGroupClaim.MessageChan)
+			assert.Nil(t, <-errChan)
+			elapsed := time.Since(startTime)
+			assert.True(t, elapsed >= retryBudget && elapsed < 5*retryBudget, "proceeded after %v", elapsed)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&poisonAttempts)) // The Initial Attempt & One Retry Within The Budget
+			assert.Equal(t, int32(1), atomic.LoadInt32(&delivered))
+			if testCase.wantDeadLettered {
+				assert.Equal(t, int32(1), atomic.LoadInt32(&deadLettered))
+			} else {
+				assert.Equal(t, int32(0), atomic.LoadInt32(&deadLettered))
+			}
+			_, committedOffset := session.committed()
+			assert.Equal(t, int64(testOffset+2), committedOffset)
+		})
+	}
+}
+
+// Utility Function For Creating A Valid ConsumerMessage With The Specified CloudEvent ID
+func createPoisonConsumerMessage(t *testing.T, id string) *sarama.ConsumerMessage {
+	consumerMessage := createConsumerMessage(t)
+	for _, header := range consumerMessage.Headers {
+		if string(header.Key) == "ce_id" {
+			header.Value = []byte(id)
+		}
+	}
+	return consumerMessage
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+//
+// Determine Whether The Specified ConsumerGroup Error Indicates A Missed Heartbeat / Lost Group Membership
+//
+// Sarama reports the errors of a session's heartbeat loop (coordinator lookups, heartbeat requests and any
+// coordinator eviction) as-is, whereas errors related to the consumption of a specific partition are always
+// wrapped in a ConsumerError.  Any other (unwrapped) error is therefore considered a potential loss of the
+// session, and it is left to the sessionMonitor to verify whether a new session is subsequently established.
+//
+func IsSessionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var consumerError *sarama.ConsumerError
+	return !errors.As(err, &consumerError) && !IsDecompressionError(err)
+}
+
+//
+// Monitor The Liveness Of A Subscriber's ConsumerGroup Session & Force A Rejoin When It Is Not Re-Established
+//
+// A network partitioned Dispatcher may continue to believe it is a member of the ConsumerGroup after the
+// coordinator has evicted it, silently consuming nothing until Consume() eventually returns an error.  When
+// a missed heartbeat is reported, the monitor waits for the configured timeout and, if no new session has
+// been established (Setup) in the meantime, cancels the context of the current Consume() call so that the
+// consumption loop immediately rejoins the ConsumerGroup.  A timeout of zero disables the monitor.
+//
+type sessionMonitor struct {
+	logger     *zap.Logger
+	timeout    time.Duration
+	lock       sync.Mutex
+	cancel     context.CancelFunc // Cancels The Current Consume() Call
+	generation int                // Incremented Upon Each New Session
+	timer      *time.Timer        // Pending Liveness Check (Nil If None)
+	rejoins    int                // Number Of Forced Rejoins (For Observability / Testing)
+}
+
+// sessionMonitor Constructor
+func newSessionMonitor(logger *zap.Logger, timeout time.Duration) *sessionMonitor {
+	return &sessionMonitor{logger: logger, timeout: timeout}
+}
+
+// Create A New Cancellable Context For A Consume() Call (Replacing Any Prior Context)
+func (m *sessionMonitor) consumeContext() context.Context {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.cancel != nil {
+		m.cancel() // Release The Prior (Completed) Consume() Context
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	return ctx
+}
+
+// Track The Establishment Of A New ConsumerGroup Session (Cancelling Any Pending Liveness Check)
+func (m *sessionMonitor) sessionStarted() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.generation++
+	m.stopTimer()
+}
+
+// Schedule A Liveness Check In Response To A Missed Heartbeat (Unless Disabled Or Already Scheduled)
+func (m *sessionMonitor) heartbeatMissed(err error) {
+	if m.timeout <= 0 {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.timer != nil {
+		return
+	}
+	m.logger.Warn("ConsumerGroup Session Missed Heartbeat - Awaiting Rejoin", zap.Duration("Timeout", m.timeout), zap.Error(err))
+	generation := m.generation
+	m.timer = time.AfterFunc(m.timeout, func() {
+		m.checkSession(generation)
+	})
+}
+
+// Force A Rejoin If No New Session Has Been Established Since The Specified Generation
+func (m *sessionMonitor) checkSession(generation int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.timer = nil
+	if m.generation == generation && m.cancel != nil {
+		m.logger.Warn("ConsumerGroup Session Not Re-Established - Forcing Rejoin", zap.Duration("Timeout", m.timeout))
+		m.rejoins++
+		m.cancel()
+	}
+}
+
+// Get The Number Of Forced Rejoins
+func (m *sessionMonitor) rejoinCount() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.rejoins
+}
+
+// Stop Monitoring (Cancelling Any Pending Liveness Check)
+func (m *sessionMonitor) stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stopTimer()
+}
+
+// Stop Any Pending Liveness Check (Caller Must Hold The Lock)
+func (m *sessionMonitor) stopTimer() {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const testSessionLivenessTimeout = 50 * time.Millisecond
+
+// Test The IsSessionError() Functionality
+func TestIsSessionError(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name string
+		err  error
+		want bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Nil", err: nil, want: false},
+		{name: "Coordinator Not Available", err: sarama.ErrConsumerCoordinatorNotAvailable, want: true},
+		{name: "Not Coordinator", err: sarama.ErrNotCoordinatorForConsumer, want: true},
+		{name: "Heartbeat Request Failure", err: sarama.ErrOutOfBrokers, want: true},
+		{name: "Heartbeat Network Failure", err: errors.New("read tcp: i/o timeout"), want: true},
+		{name: "Partition Error", err: &sarama.ConsumerError{Topic: testTopic, Partition: 1, Err: sarama.ErrOffsetOutOfRange}, want: false},
+		{name: "Decompression Error", err: sarama.PacketDecodingError{Info: "invalid compression specified (7)"}, want: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, IsSessionError(testCase.err))
+		})
+	}
+}
+
+// Test The sessionMonitor's Liveness Checking
+func TestSessionMonitor(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		timeout     time.Duration
+		rejoined    bool
+		wantRejoins int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", timeout: 0, wantRejoins: 0},
+		{name: "Session Re-Established", timeout: testSessionLivenessTimeout, rejoined: true, wantRejoins: 0},
+		{name: "Session Not Re-Established", timeout: testSessionLivenessTimeout, wantRejoins: 1},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Monitor With An Established Session
+			monitor := newSessionMonitor(logtesting.TestLogger(t).Desugar(), testCase.timeout)
+			ctx := monitor.consumeContext()
+			monitor.sessionStarted()
+
+			// Perform The Test - Miss A Heartbeat (Repeatedly) & Optionally Establish A New Session
+			monitor.heartbeatMissed(sarama.ErrOutOfBrokers)
+			monitor.heartbeatMissed(sarama.ErrOutOfBrokers)
+			if testCase.rejoined {
+				monitor.sessionStarted()
+			}
+			time.Sleep(3 * testSessionLivenessTimeout)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantRejoins, monitor.rejoinCount())
+			assert.Equal(t, testCase.wantRejoins > 0, ctx.Err() != nil)
+			monitor.stop()
+		})
+	}
+}
+
+// Test The Dispatcher Forcing A Rejoin After The Coordinator Evicts A Subscriber's (Wedged) Session
+func TestSessionLivenessRejoin(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With A Mock Which Simulates A Missed Heartbeat Eviction
+	consumerGroup := newEvictedConsumerGroup()
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return consumerGroup, nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A New DispatcherImpl To Test With A Single Subscriber
+	dispatcher := NewDispatcher(DispatcherConfig{
+		Logger:                 logtesting.TestLogger(t).Desugar(),
+		Topic:                  testTopic,
+		StatsReporter:          dispatchertesting.NewMockStatsReporter(),
+		SaramaConfig:           getSaramaConfigFromYaml(t, TestConfigBase),
+		SessionLivenessTimeout: testSessionLivenessTimeout,
+	}).(*DispatcherImpl)
+	failedSubscriptions := updateSubscriptions(t, dispatcher, []eventingduck.SubscriberSpec{{UID: uid123}})
+	assert.Len(t, failedSubscriptions, 0)
+	assert.Eventually(t, func() bool { return consumerGroup.joinCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Perform The Test - Simulate The Heartbeat Loop Reporting The Loss Of The Coordinator
+	consumerGroup.errorChan <- sarama.ErrNotCoordinatorForConsumer
+
+	// Verify The Wedged Session Was Cancelled & The ConsumerGroup Rejoined
+	assert.Eventually(t, func() bool { return consumerGroup.joinCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, consumerGroup.cancelled())
+
+	// Verify The New Session Is Left Alone
+	time.Sleep(3 * testSessionLivenessTimeout)
+	assert.Equal(t, 2, consumerGroup.joinCount())
+
+	// Shutdown The Dispatcher to Cleanup Resources
+	dispatcher.Shutdown(context.TODO())
+}
+
+//
+// Mock ConsumerGroup Whose Sessions Never End On Their Own
+//
+// This simulates a network partitioned member which the coordinator has evicted but whose session (from
+// Sarama's perspective) remains active, so that only a cancellation of the Consume() context (or Close)
+// results in a new session being joined.
+//
+type evictedConsumerGroup struct {
+	lock       sync.Mutex
+	errorChan  chan error
+	closedChan chan struct{}
+	joins      int
+	cancels    int
+}
+
+// Verify The Mock ConsumerGroup Implements The Interface
+var _ sarama.ConsumerGroup = &evictedConsumerGroup{}
+
+// Mock ConsumerGroup Constructor
+func newEvictedConsumerGroup() *evictedConsumerGroup {
+	return &evictedConsumerGroup{errorChan: make(chan error), closedChan: make(chan struct{})}
+}
+
+func (c *evictedConsumerGroup) Consume(ctx context.Context, _ []string, handler sarama.ConsumerGroupHandler) error {
+	select {
+	case <-c.closedChan:
+		return sarama.ErrClosedConsumerGroup
+	default:
+	}
+	c.lock.Lock()
+	c.joins++
+	c.lock.Unlock()
+	_ = handler.Setup(nil)
+	select {
+	case <-ctx.Done():
+		c.lock.Lock()
+		c.cancels++
+		c.lock.Unlock()
+		return nil
+	case <-c.closedChan:
+		return sarama.ErrClosedConsumerGroup
+	}
+}
+
+func (c *evictedConsumerGroup) Errors() <-chan error {
+	return c.errorChan
+}
+
+func (c *evictedConsumerGroup) Close() error {
+	close(c.closedChan)
+	close(c.errorChan)
+	return nil
+}
+
+func (c *evictedConsumerGroup) joinCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.joins
+}
+
+func (c *evictedConsumerGroup) cancelled() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cancels > 0
+}
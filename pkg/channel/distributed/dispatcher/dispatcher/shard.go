@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
+)
+
+//
+// Update The Number Of Dispatcher Shards From The Specified KafkaChannel DispatcherShards Annotation
+//
+// The KafkaChannel's subscriptions are assigned across that many Dispatcher Deployments, this Dispatcher consuming only
+// the subscriptions assigned to its Shard (see sharding.SubscriptionShard).  The new number of shards is applied by the
+// next UpdateSubscriptions(), which stops the ConsumerGroups of subscriptions assigned elsewhere and starts those newly
+// assigned here.  An invalid annotation is returned as an error, in which case the previous number of shards is retained.
+//
+func (d *DispatcherImpl) UpdateShards(annotation string) error {
+
+	shards, err := sharding.ParseDispatcherShards(annotation)
+	if err != nil {
+		d.Logger.Error("Failed To Parse Dispatcher Shards", zap.Error(err))
+		return err
+	}
+
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	if shards != d.shards {
+		d.Logger.Info("Dispatcher Shards Updated", zap.Int("Shard", d.Shard), zap.Int("Shards", shards))
+	}
+	d.shards = shards
+	return nil
+}
+
+// Determine Whether The Subscription With The Specified UID Is Assigned To This Dispatcher's Shard
+func (d *DispatcherImpl) OwnsSubscription(uid types.UID) bool {
+	d.consumerUpdateLock.Lock()
+	defer d.consumerUpdateLock.Unlock()
+	return d.ownsSubscription(uid)
+}
+
+// Determine Whether The Subscription Is Assigned To This Dispatcher's Shard (The Caller Must Hold The consumerUpdateLock)
+func (d *DispatcherImpl) ownsSubscription(uid types.UID) bool {
+	if d.shards <= sharding.MinDispatcherShards {
+		return true // Unsharded (Or Not Yet Known, e.g. When Restoring The Subscription Snapshot)
+	}
+	return sharding.SubscriptionShard(uid, d.shards) == d.Shard
+}
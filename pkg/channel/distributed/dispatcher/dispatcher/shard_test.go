@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The UpdateShards() Functionality
+func TestUpdateShards(t *testing.T) {
+
+	// Test Data
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar(), Shard: 1}).(*DispatcherImpl)
+	uid := types.UID("3f1c9b0e-5c1a-4a8e-9f3e-1d2c3b4a5f6e")
+
+	// Verify An Unsharded Dispatcher Owns Every Subscription
+	assert.True(t, dispatcher.OwnsSubscription(uid))
+
+	// Perform The Test - Shard The KafkaChannel's Subscriptions
+	assert.Nil(t, dispatcher.UpdateShards("4"))
+	assert.Equal(t, 4, dispatcher.shards)
+	assert.Equal(t, sharding.SubscriptionShard(uid, 4) == 1, dispatcher.OwnsSubscription(uid))
+
+	// Verify An Invalid Annotation Is Rejected & The Previous Number Of Shards Retained
+	assert.NotNil(t, dispatcher.UpdateShards("many"))
+	assert.Equal(t, 4, dispatcher.shards)
+
+	// Verify Removing The Annotation Restores An Unsharded Dispatcher
+	assert.Nil(t, dispatcher.UpdateShards(""))
+	assert.True(t, dispatcher.OwnsSubscription(uid))
+}
+
+// Test That Sharded Dispatchers Each Consume A Distinct Subset Of The Subscriptions With Unchanged ConsumerGroup IDs
+func TestUpdateSubscriptionsSharded(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Test Data
+	subscriberSpecs := make([]eventingduck.SubscriberSpec, 30)
+	for index := range subscriberSpecs {
+		subscriberSpecs[index] = eventingduck.SubscriberSpec{UID: types.UID(fmt.Sprintf("subscription-%d", index))}
+	}
+	newShardDispatcher := func(shard int) *DispatcherImpl {
+		return NewDispatcher(DispatcherConfig{
+			Logger:          logtesting.TestLogger(t).Desugar(),
+			SaramaConfig:    getSaramaConfigFromYaml(t, TestConfigBase),
+			ShutdownTimeout: time.Second, // Await Consume Loops Of Reassigned Subscriptions So They Do Not Outlive The Test
+			Shard:           shard,
+		}).(*DispatcherImpl)
+	}
+
+	// Get The ConsumerGroup IDs Of An Unsharded Dispatcher
+	unsharded := newShardDispatcher(0)
+	assert.Empty(t, updateSubscriptions(t, unsharded, subscriberSpecs))
+	unshardedGroupIds := make(map[types.UID]string)
+	for uid, subscriber := range unsharded.subscribers {
+		unshardedGroupIds[uid] = subscriber.GroupId
+	}
+	assert.Len(t, unshardedGroupIds, len(subscriberSpecs))
+	unsharded.Shutdown(context.TODO())
+
+	// Shard The Subscriptions Across The Specified Dispatchers & Return The Shard Consuming Each Subscription
+	shardSubscriptions := func(dispatchers []*DispatcherImpl) map[types.UID]int {
+		owners := make(map[types.UID]int)
+		for shard, dispatcher := range dispatchers {
+			assert.Nil(t, dispatcher.UpdateShards(fmt.Sprint(len(dispatchers))))
+			assert.Empty(t, updateSubscriptions(t, dispatcher, subscriberSpecs))
+			for uid, subscriber := range dispatcher.subscribers {
+				_, duplicate := owners[uid]
+				assert.False(t, duplicate, "subscription %s consumed by multiple shards", uid)
+				assert.Equal(t, unshardedGroupIds[uid], subscriber.GroupId)
+				assert.True(t, dispatcher.OwnsSubscription(uid))
+				owners[uid] = shard
+			}
+			assert.Len(t, dispatcher.SubscriberSpecs, len(dispatcher.subscribers))
+		}
+		assert.Len(t, owners, len(subscriberSpecs))
+		return owners
+	}
+
+	// Perform The Test - Shard The Subscriptions Across Two Dispatchers
+	dispatchers := []*DispatcherImpl{newShardDispatcher(0), newShardDispatcher(1)}
+	twoShardOwners := shardSubscriptions(dispatchers)
+
+	// Scale Out To Three Dispatchers & Verify Subscriptions Only Moved To The New Shard
+	dispatchers = append(dispatchers, newShardDispatcher(2))
+	threeShardOwners := shardSubscriptions(dispatchers)
+	for uid, shard := range threeShardOwners {
+		if shard != twoShardOwners[uid] {
+			assert.Equal(t, 2, shard, "subscription %s moved between existing shards", uid)
+		}
+	}
+
+	// Scale Back In To Two Dispatchers & Verify The Original Assignment Is Restored
+	dispatchers[2].Shutdown(context.TODO())
+	dispatchers = dispatchers[:2]
+	assert.Equal(t, twoShardOwners, shardSubscriptions(dispatchers))
+
+	// Shutdown The Dispatchers to Cleanup Resources
+	for _, dispatcher := range dispatchers {
+		dispatcher.Shutdown(context.TODO())
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// Default Maximum Age Of A Subscription Snapshot Before It Is Considered Too Stale To Warm Start From
+const DefaultSubscriptionSnapshotMaxAge = 10 * time.Minute
+
+// Snapshot Of The Dispatcher's Active Subscriptions (Persisted For Warm Restarts)
+type SubscriptionSnapshot struct {
+	ChannelKey      string                        `json:"channelKey"`
+	Timestamp       time.Time                     `json:"timestamp"`
+	SubscriberSpecs []eventingduck.SubscriberSpec `json:"subscriberSpecs"`
+}
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var now = time.Now
+
+// Persist The Specified SubscriberSpecs As A SubscriptionSnapshot To The Specified File Path
+func SaveSubscriptionSnapshot(path string, channelKey string, subscriberSpecs []eventingduck.SubscriberSpec) error {
+
+	// Create The SubscriptionSnapshot & Marshal To JSON
+	snapshot := &SubscriptionSnapshot{
+		ChannelKey:      channelKey,
+		Timestamp:       now(),
+		SubscriberSpecs: subscriberSpecs,
+	}
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	// Write To A Temporary File & Rename So That Readers Never Observe A Partially Written Snapshot
+	tempPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	err = ioutil.WriteFile(tempPath, snapshotBytes, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+//
+// Load The SubscriberSpecs From The SubscriptionSnapshot At The Specified File Path
+//
+// A missing snapshot is not an error and simply results in an empty slice being returned.  A snapshot
+// which belongs to a different channel, or which is older than the specified maxAge, is considered
+// stale and is likewise ignored.  Note that a "fresh" snapshot may still contain subscriptions which
+// have since been removed - it is the responsibility of the caller to reconcile against the
+// authoritative list of subscriptions once it becomes available.
+//
+func LoadSubscriptionSnapshot(path string, channelKey string, maxAge time.Duration) ([]eventingduck.SubscriberSpec, error) {
+
+	// Read The Snapshot File (Missing File Simply Means No Snapshot)
+	snapshotBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []eventingduck.SubscriberSpec{}, nil
+		}
+		return nil, err
+	}
+
+	// Unmarshal The Snapshot
+	snapshot := &SubscriptionSnapshot{}
+	err = json.Unmarshal(snapshotBytes, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription snapshot '%s': %v", path, err)
+	}
+
+	// Ignore Snapshots From Other Channels Or Which Are Too Old To Be Trusted
+	if snapshot.ChannelKey != channelKey || now().Sub(snapshot.Timestamp) > maxAge {
+		return []eventingduck.SubscriberSpec{}, nil
+	}
+
+	// Return The Snapshot's SubscriberSpecs
+	if snapshot.SubscriberSpecs == nil {
+		return []eventingduck.SubscriberSpec{}, nil
+	}
+	return snapshot.SubscriberSpecs, nil
+}
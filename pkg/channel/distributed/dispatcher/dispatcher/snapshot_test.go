@@ -0,0 +1,301 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	kafkaconsumer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/consumer"
+	kafkatesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/testing"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	snapshotChannelKey = "TestNamespace/TestChannel"
+)
+
+// Test The SaveSubscriptionSnapshot() & LoadSubscriptionSnapshot() Round Trip Functionality
+func TestSubscriptionSnapshotRoundTrip(t *testing.T) {
+
+	// Test Data
+	path := createSnapshotPath(t)
+	subscriberURI, err := apis.ParseURL("http://subscriber.example.com")
+	assert.Nil(t, err)
+	subscriberSpecs := []eventingduck.SubscriberSpec{
+		{UID: uid123, Generation: 1, SubscriberURI: subscriberURI},
+		{UID: uid456, Generation: 2},
+	}
+
+	// Perform The Test
+	err = SaveSubscriptionSnapshot(path, snapshotChannelKey, subscriberSpecs)
+	assert.Nil(t, err)
+	loadedSubscriberSpecs, err := LoadSubscriptionSnapshot(path, snapshotChannelKey, DefaultSubscriptionSnapshotMaxAge)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, subscriberSpecs, loadedSubscriberSpecs)
+}
+
+// Test The LoadSubscriptionSnapshot() Functionality For Missing, Stale & Invalid Snapshots
+func TestLoadSubscriptionSnapshot(t *testing.T) {
+
+	// Test Data
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123}}
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name       string
+		save       bool
+		contents   string
+		channelKey string
+		age        time.Duration
+		want       []eventingduck.SubscriberSpec
+		wantErr    bool
+	}
+
+	// Define The Test Cases
+	tests := []testCase{
+		{
+			name:       "Missing Snapshot",
+			channelKey: snapshotChannelKey,
+			want:       []eventingduck.SubscriberSpec{},
+		},
+		{
+			name:       "Valid Snapshot",
+			save:       true,
+			channelKey: snapshotChannelKey,
+			want:       subscriberSpecs,
+		},
+		{
+			name:       "Snapshot For Different Channel",
+			save:       true,
+			channelKey: "OtherNamespace/OtherChannel",
+			want:       []eventingduck.SubscriberSpec{},
+		},
+		{
+			name:       "Snapshot Too Old",
+			save:       true,
+			channelKey: snapshotChannelKey,
+			age:        DefaultSubscriptionSnapshotMaxAge + time.Minute,
+			want:       []eventingduck.SubscriberSpec{},
+		},
+		{
+			name:       "Corrupt Snapshot",
+			contents:   "{ not json",
+			channelKey: snapshotChannelKey,
+			wantErr:    true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Create The Snapshot File As Specified
+			path := createSnapshotPath(t)
+			if tt.save {
+				mockNow(t, time.Now().Add(-tt.age))
+				assert.Nil(t, SaveSubscriptionSnapshot(path, snapshotChannelKey, subscriberSpecs))
+				now = time.Now
+			} else if len(tt.contents) > 0 {
+				assert.Nil(t, ioutil.WriteFile(path, []byte(tt.contents), 0644))
+			}
+
+			// Perform The Test
+			got, err := LoadSubscriptionSnapshot(path, tt.channelKey, DefaultSubscriptionSnapshotMaxAge)
+
+			// Verify The Results
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+// Test The Warm Restart Of A Dispatcher From A Snapshot Followed By Reconciliation Against The Authoritative List
+func TestRestoreSubscriptionSnapshot(t *testing.T) {
+
+	// Test Data
+	path := createSnapshotPath(t)
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Create A Dispatcher With Snapshots Enabled & Subscribe (Which Should Persist The Snapshot)
+	dispatcherConfig := DispatcherConfig{
+		Logger:                   logger,
+		ChannelKey:               snapshotChannelKey,
+		SaramaConfig:             getSaramaConfigFromYaml(t, TestConfigBase),
+		SubscriptionSnapshotPath: path,
+	}
+	originalDispatcher := NewDispatcher(dispatcherConfig)
+	failedSubscriptions := updateSubscriptions(t, originalDispatcher, []eventingduck.SubscriberSpec{{UID: uid123}, {UID: uid456}})
+	assert.Empty(t, failedSubscriptions)
+	originalDispatcher.Shutdown(context.TODO())
+
+	// Create A New Dispatcher (Simulating A Restart) & Perform The Warm Restart
+	restartedDispatcher := NewDispatcher(dispatcherConfig).(*DispatcherImpl)
+	failedSubscriptions = restartedDispatcher.RestoreSubscriptionSnapshot()
+
+	// Verify The Snapshot Subscriptions Were Started Prior To Any Reconciliation
+	assert.Empty(t, failedSubscriptions)
+	assert.Len(t, restartedDispatcher.subscribers, 2)
+	assert.NotNil(t, restartedDispatcher.subscribers[uid123])
+	assert.NotNil(t, restartedDispatcher.subscribers[uid456])
+	staleSubscriber := restartedDispatcher.subscribers[uid123]
+
+	// Reconcile Against The Authoritative Subscriptions (uid123 Was Removed While Down, uid789 Was Added)
+	failedSubscriptions = updateSubscriptions(t, restartedDispatcher, []eventingduck.SubscriberSpec{{UID: uid456}, {UID: uid789}})
+
+	// Verify The Stale Subscription Was Closed & The Snapshot Reflects The Authoritative State
+	assert.Empty(t, failedSubscriptions)
+	assert.Len(t, restartedDispatcher.subscribers, 2)
+	assert.Nil(t, restartedDispatcher.subscribers[uid123])
+	assert.NotNil(t, restartedDispatcher.subscribers[uid456])
+	assert.NotNil(t, restartedDispatcher.subscribers[uid789])
+	assert.True(t, staleSubscriber.ConsumerGroup.(*kafkatesting.MockConsumerGroup).Closed)
+	snapshotSubscriberSpecs, err := LoadSubscriptionSnapshot(path, snapshotChannelKey, DefaultSubscriptionSnapshotMaxAge)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []types.UID{uid456, uid789}, subscriberUIDs(snapshotSubscriberSpecs))
+
+	// Shutdown The Dispatcher To Cleanup Resources
+	restartedDispatcher.Shutdown(context.TODO())
+}
+
+// Test The RestoreSubscriptionSnapshot() Functionality Honors The Configured Maximum Snapshot Age
+func TestRestoreSubscriptionSnapshotMaxAge(t *testing.T) {
+
+	// Replace The NewConsumerGroupWrapper With Mock For Testing & Restore After Test
+	newConsumerGroupWrapperPlaceholder := kafkaconsumer.NewConsumerGroupWrapper
+	kafkaconsumer.NewConsumerGroupWrapper = func(brokersArg []string, groupIdArg string, configArg *sarama.Config) (sarama.ConsumerGroup, error) {
+		return kafkatesting.NewMockConsumerGroup(t), nil
+	}
+	defer func() {
+		kafkaconsumer.NewConsumerGroupWrapper = newConsumerGroupWrapperPlaceholder
+	}()
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name        string
+		maxAge      time.Duration
+		age         time.Duration
+		wantRestore bool
+	}
+
+	// Define The Test Cases
+	tests := []testCase{
+		{name: "Default Max Age Fresh", age: DefaultSubscriptionSnapshotMaxAge - time.Minute, wantRestore: true},
+		{name: "Default Max Age Stale", age: DefaultSubscriptionSnapshotMaxAge + time.Minute, wantRestore: false},
+		{name: "Configured Max Age Fresh", maxAge: time.Hour, age: DefaultSubscriptionSnapshotMaxAge + time.Minute, wantRestore: true},
+		{name: "Configured Max Age Stale", maxAge: time.Minute, age: 2 * time.Minute, wantRestore: false},
+	}
+
+	// Run The Test Cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Save A Snapshot Of The Specified Age (Restoring The Time Afterwards)
+			path := createSnapshotPath(t)
+			mockNow(t, time.Now().Add(-tt.age))
+			assert.Nil(t, SaveSubscriptionSnapshot(path, snapshotChannelKey, []eventingduck.SubscriberSpec{{UID: uid123}}))
+			now = time.Now
+
+			// Create A Dispatcher With The Specified Maximum Snapshot Age
+			dispatcher := NewDispatcher(DispatcherConfig{
+				Logger:                     logtesting.TestLogger(t).Desugar(),
+				ChannelKey:                 snapshotChannelKey,
+				SaramaConfig:               getSaramaConfigFromYaml(t, TestConfigBase),
+				SubscriptionSnapshotPath:   path,
+				SubscriptionSnapshotMaxAge: tt.maxAge,
+				ShutdownTimeout:            time.Second, // Await Consume Loops & Error Processing So They Do Not Outlive The Test
+			}).(*DispatcherImpl)
+
+			// Perform The Test
+			failedSubscriptions := dispatcher.RestoreSubscriptionSnapshot()
+
+			// Verify The Snapshot Was Only Restored If It Was Fresh Enough
+			assert.Empty(t, failedSubscriptions)
+			if tt.wantRestore {
+				assert.Len(t, dispatcher.subscribers, 1)
+			} else {
+				assert.Len(t, dispatcher.subscribers, 0)
+			}
+
+			// Shutdown The Dispatcher To Cleanup Resources
+			dispatcher.Shutdown(context.TODO())
+		})
+	}
+}
+
+// Test The RestoreSubscriptionSnapshot() Functionality When Snapshots Are Disabled
+func TestRestoreSubscriptionSnapshotDisabled(t *testing.T) {
+
+	// Create A Dispatcher Without A Snapshot Path
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()}).(*DispatcherImpl)
+
+	// Perform The Test
+	failedSubscriptions := dispatcher.RestoreSubscriptionSnapshot()
+
+	// Verify The Results
+	assert.Nil(t, failedSubscriptions)
+	assert.Len(t, dispatcher.subscribers, 0)
+}
+
+// Utility Function For Creating A Snapshot File Path In A Temporary Directory (Removed After The Test)
+func createSnapshotPath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "subscriptions.json")
+}
+
+// Utility Function For Mocking The Current Time Used When Saving Snapshots
+func mockNow(t *testing.T, mockTime time.Time) {
+	now = func() time.Time { return mockTime }
+	t.Cleanup(func() { now = time.Now })
+}
+
+// Utility Function For Extracting The UIDs From A List Of SubscriberSpecs
+func subscriberUIDs(subscriberSpecs []eventingduck.SubscriberSpec) []types.UID {
+	uids := make([]types.UID, 0, len(subscriberSpecs))
+	for _, subscriberSpec := range subscriberSpecs {
+		uids = append(uids, subscriberSpec.UID)
+	}
+	return uids
+}
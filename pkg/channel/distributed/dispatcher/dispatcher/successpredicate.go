@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/pkg/apis"
+)
+
+// Maximum Size Of A Subscriber's Response Body Which Is Inspected For The SuccessPredicate's JSON Field
+const maxSuccessPredicateBodyBytes = 1024 * 1024
+
+//
+// Predicate Overriding The Status-Code-Only Classification Of Successful (2xx) Subscriber Responses
+//
+// Some subscribers indicate a failure to process an event with a 2xx response whose header or (JSON) body describes
+// the error, which would otherwise be treated as a successful delivery and committed.  When a response carries the
+// configured Header its value must equal the HeaderValue, and when the response body is a JSON object containing the
+// configured (dot separated) JsonField its value must equal the JsonValue, for the delivery to be successful.  String
+// values are compared as-is and other values in their JSON form.  Responses without the Header / JsonField retain
+// the default classification, so that standard subscribers are unaffected.
+//
+type SuccessPredicate struct {
+	Header      string
+	HeaderValue string
+	JsonField   []string
+	JsonValue   string
+}
+
+// Create A SuccessPredicate From The Specified Configuration (Nil If None Is Configured)
+func NewSuccessPredicate(config commonconfig.EKDispatcherSuccessPredicateConfig) (*SuccessPredicate, error) {
+
+	// Validate That The Header & JsonField Are Each Specified With Their Value
+	if (len(config.Header) > 0) != (len(config.HeaderValue) > 0) {
+		return nil, fmt.Errorf("invalid success predicate - the header '%s' and headerValue '%s' must be specified together", config.Header, config.HeaderValue)
+	}
+	if (len(config.JsonField) > 0) != (len(config.JsonValue) > 0) {
+		return nil, fmt.Errorf("invalid success predicate - the jsonField '%s' and jsonValue '%s' must be specified together", config.JsonField, config.JsonValue)
+	}
+
+	// No Predicate If Neither Is Specified
+	if len(config.Header) <= 0 && len(config.JsonField) <= 0 {
+		return nil, nil
+	}
+
+	// Split The JsonField Into Its Path Components
+	var jsonField []string
+	if len(config.JsonField) > 0 {
+		jsonField = strings.Split(config.JsonField, ".")
+		for _, component := range jsonField {
+			if len(component) <= 0 {
+				return nil, fmt.Errorf("invalid success predicate - the jsonField '%s' contains an empty path component", config.JsonField)
+			}
+		}
+	}
+
+	return &SuccessPredicate{
+		Header:      http.CanonicalHeaderKey(config.Header),
+		HeaderValue: config.HeaderValue,
+		JsonField:   jsonField,
+		JsonValue:   config.JsonValue,
+	}, nil
+}
+
+// Determine Whether The Specified (2xx) Response Is Successful, Returning The Reason If Not
+func (p *SuccessPredicate) Evaluate(header http.Header, body []byte) (bool, string) {
+
+	// Check The Header (If Present)
+	if len(p.Header) > 0 {
+		if values, ok := header[p.Header]; ok && (len(values) != 1 || values[0] != p.HeaderValue) {
+			return false, fmt.Sprintf("response header '%s' is '%s' rather than '%s'", p.Header, strings.Join(values, ","), p.HeaderValue)
+		}
+	}
+
+	// Check The JsonField (If Present In A JSON Object Body)
+	if len(p.JsonField) > 0 {
+		if value, ok := jsonFieldValue(body, p.JsonField); ok && value != p.JsonValue {
+			return false, fmt.Sprintf("response field '%s' is '%s' rather than '%s'", strings.Join(p.JsonField, "."), value, p.JsonValue)
+		}
+	}
+
+	return true, ""
+}
+
+// Get The String Representation Of The Field At The Specified Path Within The JSON Object Body (If Present)
+func jsonFieldValue(body []byte, path []string) (string, bool) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(body, &object) != nil {
+		return "", false
+	}
+	for index, component := range path {
+		rawValue, ok := object[component]
+		if !ok {
+			return "", false
+		}
+		if index < len(path)-1 {
+			object = nil
+			if json.Unmarshal(rawValue, &object) != nil || object == nil {
+				return "", false
+			}
+			continue
+		}
+		var stringValue string
+		if json.Unmarshal(rawValue, &stringValue) == nil {
+			return stringValue, true
+		}
+		return string(rawValue), true
+	}
+	return "", false
+}
+
+//
+// HTTP RoundTripper Applying A SuccessPredicate To The Responses Of A Single Subscriber
+//
+// Successful (2xx) responses from the subscriber which fail the predicate are replaced with a 502 (Bad Gateway) so
+// that the Knative MessageDispatcher retries the delivery per the subscription's DeliverySpec, and then sends the
+// event to any DeadLetterSink, exactly as it would for a subscriber responding with an error status.  Requests to
+// other destinations (i.e. the reply & DeadLetterSink) are passed through unchanged.
+//
+type successPredicateTransport struct {
+	logger        *zap.Logger
+	transport     http.RoundTripper
+	predicate     *SuccessPredicate
+	subscriberURI *apis.URL
+}
+
+// Verify The successPredicateTransport Implements The http.RoundTripper Interface
+var _ http.RoundTripper = &successPredicateTransport{}
+
+// successPredicateTransport Constructor
+func newSuccessPredicateTransport(logger *zap.Logger, transport http.RoundTripper, predicate *SuccessPredicate, subscriberURI *apis.URL) *successPredicateTransport {
+	return &successPredicateTransport{logger: logger, transport: transport, predicate: predicate, subscriberURI: subscriberURI}
+}
+
+// Perform The Specified HTTP Request & Apply The SuccessPredicate To Successful Subscriber Responses
+func (s *successPredicateTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	// Perform The Request
+	response, err := s.transport.RoundTrip(request)
+	if err != nil || response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices || !s.isSubscriberRequest(request) {
+		return response, err
+	}
+
+	// Buffer The (Limited) Start Of The Body For Inspection, Leaving The Response Body Intact
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxSuccessPredicateBodyBytes))
+	if err != nil {
+		_ = response.Body.Close()
+		return nil, err
+	}
+	response.Body = &prefixedReadCloser{Reader: io.MultiReader(bytes.NewReader(body), response.Body), Closer: response.Body}
+
+	// Replace Responses Failing The Predicate With A Retryable Failure
+	if ok, reason := s.predicate.Evaluate(response.Header, body); !ok {
+		s.logger.Warn("Subscriber Response Failed SuccessPredicate - Treating As Failed Delivery", zap.Int("StatusCode", response.StatusCode), zap.String("Reason", reason))
+		response.StatusCode = http.StatusBadGateway
+		response.Status = fmt.Sprintf("%d %s", http.StatusBadGateway, http.StatusText(http.StatusBadGateway))
+	}
+	return response, nil
+}
+
+// Determine Whether The Specified Request Is To The Subscriber (Rather Than The Reply Or DeadLetterSink)
+func (s *successPredicateTransport) isSubscriberRequest(request *http.Request) bool {
+	return isSubscriberURL(s.subscriberURI, request.URL)
+}
+
+// ReadCloser Reading The Buffered Start Of A Response Body Followed By The Remainder & Closing The Original
+type prefixedReadCloser struct {
+	io.Reader
+	io.Closer
+}
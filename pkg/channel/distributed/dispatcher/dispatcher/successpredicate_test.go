@@ -0,0 +1,225 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+)
+
+// Test The NewSuccessPredicate() Functionality
+func TestNewSuccessPredicate(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKDispatcherSuccessPredicateConfig
+		want    *SuccessPredicate
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", config: commonconfig.EKDispatcherSuccessPredicateConfig{}, want: nil},
+		{
+			name:   "Header",
+			config: commonconfig.EKDispatcherSuccessPredicateConfig{Header: "x-result", HeaderValue: "ok"},
+			want:   &SuccessPredicate{Header: "X-Result", HeaderValue: "ok"},
+		},
+		{
+			name:   "JsonField",
+			config: commonconfig.EKDispatcherSuccessPredicateConfig{JsonField: "result.status", JsonValue: "ok"},
+			want:   &SuccessPredicate{JsonField: []string{"result", "status"}, JsonValue: "ok"},
+		},
+		{
+			name:   "Header & JsonField",
+			config: commonconfig.EKDispatcherSuccessPredicateConfig{Header: "X-Result", HeaderValue: "ok", JsonField: "status", JsonValue: "ok"},
+			want:   &SuccessPredicate{Header: "X-Result", HeaderValue: "ok", JsonField: []string{"status"}, JsonValue: "ok"},
+		},
+		{name: "Header Without Value", config: commonconfig.EKDispatcherSuccessPredicateConfig{Header: "X-Result"}, wantErr: true},
+		{name: "HeaderValue Without Header", config: commonconfig.EKDispatcherSuccessPredicateConfig{HeaderValue: "ok"}, wantErr: true},
+		{name: "JsonField Without Value", config: commonconfig.EKDispatcherSuccessPredicateConfig{JsonField: "status"}, wantErr: true},
+		{name: "JsonValue Without Field", config: commonconfig.EKDispatcherSuccessPredicateConfig{JsonValue: "ok"}, wantErr: true},
+		{name: "JsonField With Empty Component", config: commonconfig.EKDispatcherSuccessPredicateConfig{JsonField: "result..status", JsonValue: "ok"}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			predicate, err := NewSuccessPredicate(testCase.config)
+			assert.Equal(t, testCase.want, predicate)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The SuccessPredicate's Evaluate() Functionality
+func TestSuccessPredicateEvaluate(t *testing.T) {
+
+	// Test Data
+	headerPredicate := &SuccessPredicate{Header: "X-Result", HeaderValue: "ok"}
+	jsonPredicate := &SuccessPredicate{JsonField: []string{"result", "code"}, JsonValue: "0"}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		predicate *SuccessPredicate
+		header    http.Header
+		body      string
+		want      bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Header Matches", predicate: headerPredicate, header: http.Header{"X-Result": {"ok"}}, want: true},
+		{name: "Header Differs", predicate: headerPredicate, header: http.Header{"X-Result": {"error"}}, want: false},
+		{name: "Header Repeated", predicate: headerPredicate, header: http.Header{"X-Result": {"ok", "error"}}, want: false},
+		{name: "Header Absent", predicate: headerPredicate, header: http.Header{}, want: true},
+		{name: "JsonField Number Matches", predicate: jsonPredicate, body: `{"result":{"code":0}}`, want: true},
+		{name: "JsonField String Matches", predicate: jsonPredicate, body: `{"result":{"code":"0"}}`, want: true},
+		{name: "JsonField Differs", predicate: jsonPredicate, body: `{"result":{"code":500,"message":"database unavailable"}}`, want: false},
+		{name: "JsonField Null", predicate: jsonPredicate, body: `{"result":{"code":null}}`, want: false},
+		{name: "JsonField Absent", predicate: jsonPredicate, body: `{"result":{"message":"processed"}}`, want: true},
+		{name: "JsonField Parent Not Object", predicate: jsonPredicate, body: `{"result":"processed"}`, want: true},
+		{name: "Body Not JSON", predicate: jsonPredicate, body: `processed`, want: true},
+		{name: "Body Empty", predicate: jsonPredicate, body: ``, want: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ok, reason := testCase.predicate.Evaluate(testCase.header, []byte(testCase.body))
+			assert.Equal(t, testCase.want, ok)
+			assert.Equal(t, testCase.want, len(reason) == 0)
+		})
+	}
+}
+
+// Test That Subscriber Responses Failing The SuccessPredicate Are Retried & Sent To The DeadLetterSink
+func TestSuccessPredicateTransport(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name             string
+		predicate        *SuccessPredicate
+		responseBody     string
+		deadLetter       bool
+		wantErr          bool
+		wantAttempts     int32
+		wantDeadLettered int32
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:         "Error Body Without Predicate",
+			predicate:    nil,
+			responseBody: `{"status":"error"}`,
+			wantErr:      false,
+			wantAttempts: 1,
+		},
+		{
+			name:         "Error Body With Predicate",
+			predicate:    &SuccessPredicate{JsonField: []string{"status"}, JsonValue: "ok"},
+			responseBody: `{"status":"error"}`,
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:             "Error Body With Predicate & DeadLetterSink",
+			predicate:        &SuccessPredicate{JsonField: []string{"status"}, JsonValue: "ok"},
+			responseBody:     `{"status":"error"}`,
+			deadLetter:       true,
+			wantErr:          false,
+			wantAttempts:     3,
+			wantDeadLettered: 1,
+		},
+		{
+			name:         "Success Body With Predicate",
+			predicate:    &SuccessPredicate{JsonField: []string{"status"}, JsonValue: "ok"},
+			responseBody: `{"status":"ok"}`,
+			wantErr:      false,
+			wantAttempts: 1,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Subscriber Which Always Responds 200 With The TestCase's Body
+			var attempts int32
+			subscriber := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				response.WriteHeader(http.StatusOK)
+				_, _ = response.Write([]byte(testCase.responseBody))
+			}))
+			defer subscriber.Close()
+
+			// Create A Mock DeadLetterSink (Whose Response Is Never Subject To The Predicate)
+			var deadLettered int32
+			deadLetterSink := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				atomic.AddInt32(&deadLettered, 1)
+				response.WriteHeader(http.StatusOK)
+				_, _ = response.Write([]byte(`{"status":"error"}`))
+			}))
+			defer deadLetterSink.Close()
+
+			// Create The MessageDispatcher With The SuccessPredicate Transport (If Any)
+			logger := logtesting.TestLogger(t).Desugar()
+			subscriberURI, err := apis.ParseURL(subscriber.URL)
+			assert.Nil(t, err)
+			var transport http.RoundTripper = NewSubscriberTransport(nil)
+			if testCase.predicate != nil {
+				transport = newSuccessPredicateTransport(logger, transport, testCase.predicate, subscriberURI)
+			}
+			messageDispatcher := newSubscriberMessageDispatcherWrapper(logger, transport, nil)
+
+			// Perform The Test
+			var deadLetterURL *url.URL
+			if testCase.deadLetter {
+				deadLetterURL, err = url.Parse(deadLetterSink.URL)
+				assert.Nil(t, err)
+			}
+			backoffPolicy := eventingduck.BackoffPolicyLinear
+			retryConfig, err := kncloudevents.RetryConfigFromDeliverySpec(eventingduck.DeliverySpec{
+				Retry:         ptr.Int32(2),
+				BackoffPolicy: &backoffPolicy,
+				BackoffDelay:  ptr.String("PT0.01S"),
+			})
+			assert.Nil(t, err)
+			err = messageDispatcher.DispatchMessageWithRetries(context.Background(), newTestMessage(), nil, subscriberURI.URL(), nil, deadLetterURL, &retryConfig)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantAttempts, atomic.LoadInt32(&attempts))
+			assert.Equal(t, testCase.wantDeadLettered, atomic.LoadInt32(&deadLettered))
+		})
+	}
+}
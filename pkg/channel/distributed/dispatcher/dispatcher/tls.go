@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opencensus.io/plugin/ochttp"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/tracing/propagation/tracecontextb3"
+)
+
+//
+// Per-Subscription HTTP Transport Supporting Reloadable TLS Client Configuration
+//
+// Each subscription is delivered to with its own MessageDispatcher whose HTTP client uses a SubscriberTransport,
+// so that subscribers requiring mutual TLS are presented with their own client certificate.  The TLS configuration
+// can be replaced at any time (e.g. when the referenced Secret is updated), in which case subsequent requests use
+// a new underlying http.Transport and the idle connections of the prior one are closed.
+//
+type SubscriberTransport struct {
+	transportMutex sync.RWMutex
+	transport      *http.Transport
+}
+
+// Verify The SubscriberTransport Implements The http.RoundTripper Interface
+var _ http.RoundTripper = &SubscriberTransport{}
+
+// Create A New SubscriberTransport With The Specified (Optional) TLS Configuration
+func NewSubscriberTransport(tlsConfig *tls.Config) *SubscriberTransport {
+	return &SubscriberTransport{transport: newHttpTransport(tlsConfig)}
+}
+
+// Replace The SubscriberTransport's TLS Configuration (Nil Restores The Default)
+func (s *SubscriberTransport) Update(tlsConfig *tls.Config) {
+	s.transportMutex.Lock()
+	defer s.transportMutex.Unlock()
+	s.transport.CloseIdleConnections()
+	s.transport = newHttpTransport(tlsConfig)
+}
+
+// Perform The Specified HTTP Request Using The Current Underlying http.Transport
+func (s *SubscriberTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	s.transportMutex.RLock()
+	transport := s.transport
+	s.transportMutex.RUnlock()
+	return transport.RoundTrip(request)
+}
+
+// Create A New http.Transport Based On The Default Transport With The Specified (Optional) TLS Configuration
+func newHttpTransport(tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+	return transport
+}
+
+// Wrapper Function To Facilitate Testing With A Mock Knative MessageDispatcher For A Specific SubscriberTransport
+var newSubscriberMessageDispatcherWrapper = func(logger *zap.Logger, transport http.RoundTripper, checkRedirect func(*http.Request, []*http.Request) error) channel.MessageDispatcher {
+	sender := &kncloudevents.HTTPMessageSender{
+		Client: &http.Client{
+			Transport: &ochttp.Transport{
+				Base:        transport,
+				Propagation: tracecontextb3.TraceContextEgress,
+			},
+			CheckRedirect: checkRedirect,
+		},
+	}
+	return channel.NewMessageDispatcherFromSender(logger, sender)
+}
+
+//
+// Create A TLS Client Configuration From The Specified Subscriber TLS Secret
+//
+// The Secret must contain the standard "tls.crt" and "tls.key" entries holding the PEM encoded client certificate
+// and private key, and may optionally contain a "ca.crt" entry holding the PEM encoded certificate authority used
+// to verify the subscriber (the system roots are used otherwise).
+//
+func NewSubscriberTLSConfig(secret *corev1.Secret) (*tls.Config, error) {
+
+	// Load The Client Certificate & Private Key
+	certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in subscriber tls secret '%s/%s': %v", secret.Namespace, secret.Name, err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{certificate}}
+
+	// Load The Optional Certificate Authority
+	if caCert, ok := secret.Data[constants.SubscriberTLSCACertKey]; ok {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("invalid ca certificate in subscriber tls secret '%s/%s'", secret.Namespace, secret.Name)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	// Return The TLS Configuration
+	return tlsConfig, nil
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testClientCommonName1 = "subscriber-1"
+	testClientCommonName2 = "subscriber-2"
+)
+
+// Test The NewSubscriberTLSConfig() Functionality
+func TestNewSubscriberTLSConfig(t *testing.T) {
+
+	// Test Data
+	ca := newTestCertificateAuthority(t)
+	certPEM, keyPEM := ca.issue(t, testClientCommonName1, false)
+
+	// Define The TestCase Struct
+	type testCase struct {
+		name      string
+		data      map[string][]byte
+		wantRoots bool
+		wantErr   bool
+	}
+
+	// Define The Test Cases
+	tests := []testCase{
+		{
+			name: "Client Certificate Only",
+			data: map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+		{
+			name:      "Client Certificate With CA",
+			data:      map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, constants.SubscriberTLSCACertKey: ca.certPEM},
+			wantRoots: true,
+		},
+		{
+			name:    "Missing Private Key",
+			data:    map[string][]byte{corev1.TLSCertKey: certPEM},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid CA",
+			data:    map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, constants.SubscriberTLSCACertKey: []byte("not a certificate")},
+			wantErr: true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Perform The Test
+			tlsConfig, err := NewSubscriberTLSConfig(newTestTLSSecret("test-secret", uid123, tt.data))
+
+			// Verify The Results
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, tlsConfig)
+			} else {
+				assert.Nil(t, err)
+				assert.Len(t, tlsConfig.Certificates, 1)
+				assert.Equal(t, tt.wantRoots, tlsConfig.RootCAs != nil)
+			}
+		})
+	}
+}
+
+// Test That Each Subscription's MessageDispatcher Presents Its Own Client Certificate (And Reloads On Update)
+func TestSubscriberMessageDispatcherClientCertificates(t *testing.T) {
+
+	// Create A Mutual TLS Server Which Reports The Presented Client Certificate
+	ca := newTestCertificateAuthority(t)
+	server, serverURL, peerCommonNames := newTestMutualTLSServer(t, ca)
+	defer server.Close()
+
+	// Create The TLS Client Configuration For Each Subscriber
+	tlsConfig1 := newTestSubscriberTLSConfig(t, ca, testClientCommonName1)
+	tlsConfig2 := newTestSubscriberTLSConfig(t, ca, testClientCommonName2)
+
+	// Create A MessageDispatcher With Its Own SubscriberTransport For Each Subscriber
+	logger := logtesting.TestLogger(t).Desugar()
+	transport1 := NewSubscriberTransport(tlsConfig1)
+	transport2 := NewSubscriberTransport(tlsConfig2)
+	messageDispatcher1 := newSubscriberMessageDispatcherWrapper(logger, transport1, nil)
+	messageDispatcher2 := newSubscriberMessageDispatcherWrapper(logger, transport2, nil)
+
+	// Verify Each Subscriber Presents Its Own Client Certificate
+	assert.Nil(t, messageDispatcher1.DispatchMessage(context.Background(), newTestMessage(), nil, serverURL, nil, nil))
+	assert.Equal(t, testClientCommonName1, <-peerCommonNames)
+	assert.Nil(t, messageDispatcher2.DispatchMessage(context.Background(), newTestMessage(), nil, serverURL, nil, nil))
+	assert.Equal(t, testClientCommonName2, <-peerCommonNames)
+
+	// Reload The First Subscriber's Transport & Verify The New Client Certificate Is Presented
+	transport1.Update(tlsConfig2)
+	assert.Nil(t, messageDispatcher1.DispatchMessage(context.Background(), newTestMessage(), nil, serverURL, nil, nil))
+	assert.Equal(t, testClientCommonName2, <-peerCommonNames)
+
+	// Remove The First Subscriber's TLS Configuration & Verify The Server Rejects It
+	transport1.Update(nil)
+	assert.NotNil(t, messageDispatcher1.DispatchMessage(context.Background(), newTestMessage(), nil, serverURL, nil, nil))
+}
+
+// Test The UpdateSubscriberTLSSecrets() Functionality
+func TestUpdateSubscriberTLSSecrets(t *testing.T) {
+
+	// Test Data
+	ca := newTestCertificateAuthority(t)
+	certPEM1, keyPEM1 := ca.issue(t, testClientCommonName1, false)
+	certPEM2, keyPEM2 := ca.issue(t, testClientCommonName2, false)
+	secret1 := newTestTLSSecret("subscriber-1-tls", uid123, map[string][]byte{corev1.TLSCertKey: certPEM1, corev1.TLSPrivateKeyKey: keyPEM1})
+	secret1.ResourceVersion = "1"
+	secret1Updated := newTestTLSSecret("subscriber-1-tls", uid123, map[string][]byte{corev1.TLSCertKey: certPEM2, corev1.TLSPrivateKeyKey: keyPEM2})
+	secret1Updated.ResourceVersion = "2"
+	secret1Invalid := newTestTLSSecret("subscriber-1-tls", uid123, map[string][]byte{corev1.TLSCertKey: certPEM1})
+	secret1Invalid.ResourceVersion = "3"
+	secret2 := newTestTLSSecret("subscriber-2-tls", uid456, map[string][]byte{corev1.TLSCertKey: certPEM2, corev1.TLSPrivateKeyKey: keyPEM2})
+	secret2Duplicate := newTestTLSSecret("subscriber-2-tls-duplicate", uid456, map[string][]byte{corev1.TLSCertKey: certPEM1, corev1.TLSPrivateKeyKey: keyPEM1})
+
+	// Create A Dispatcher With A Single Active Subscriber (Transport Verified Via Its Client Certificate)
+	dispatcher := NewDispatcher(DispatcherConfig{Logger: logtesting.TestLogger(t).Desugar()}).(*DispatcherImpl)
+	dispatcher.subscribers[uid123] = createSubscriberWrapper(t, uid123)
+	dispatcher.subscribers[uid123].Transport = NewSubscriberTransport(nil)
+
+	// Load The Initial Secret & Verify It Is Applied To The Active Subscriber
+	assert.Empty(t, dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{secret1}))
+	assert.Equal(t, testClientCommonName1, transportCommonName(t, dispatcher.subscribers[uid123].Transport))
+	initialSubscriberTLS := dispatcher.subscriberTLS[uid123]
+
+	// Verify An Unchanged Secret Is Not Reloaded
+	assert.Empty(t, dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{secret1}))
+	assert.True(t, initialSubscriberTLS == dispatcher.subscriberTLS[uid123])
+
+	// Verify An Updated Secret Is Reloaded
+	assert.Empty(t, dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{secret1Updated}))
+	assert.Equal(t, testClientCommonName2, transportCommonName(t, dispatcher.subscribers[uid123].Transport))
+
+	// Verify An Invalid Secret Is Reported As Failed & The Prior Configuration Is Retained
+	failedSubscriberTLS := dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{secret1Invalid})
+	assert.Len(t, failedSubscriberTLS, 1)
+	assert.NotNil(t, failedSubscriberTLS[uid123])
+	assert.Equal(t, testClientCommonName2, transportCommonName(t, dispatcher.subscribers[uid123].Transport))
+
+	// Verify Multiple Secrets For A Single Subscriber Are Reported As Failed
+	failedSubscriberTLS = dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{secret1Updated, secret2, secret2Duplicate})
+	assert.Len(t, failedSubscriberTLS, 1)
+	assert.NotNil(t, failedSubscriberTLS[uid456])
+	assert.NotNil(t, dispatcher.subscriberTLS[uid456])
+
+	// Verify A Removed Secret Restores The Default Configuration
+	assert.Empty(t, dispatcher.UpdateSubscriberTLSSecrets([]*corev1.Secret{}))
+	assert.Nil(t, dispatcher.subscriberTLS[uid123])
+	assert.Empty(t, dispatcher.subscribers[uid123].Transport.transport.TLSClientConfig.Certificates)
+}
+
+//
+// Private Utility Functions
+//
+
+// Test Certificate Authority For Issuing Server & Client Certificates
+type testCertificateAuthority struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+// Create A New Self-Signed Test Certificate Authority
+func newTestCertificateAuthority(t *testing.T) *testCertificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	assert.Nil(t, err)
+	return &testCertificateAuthority{cert: cert, key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})}
+}
+
+// Issue A PEM Encoded Certificate & Private Key With The Specified CommonName For A Client (Or Localhost Server)
+func (ca *testCertificateAuthority) issue(t *testing.T, commonName string, server bool) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// Create A Subscriber TLS Secret With The Specified Data
+func newTestTLSSecret(name string, uid types.UID, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+			Labels:    map[string]string{constants.SubscriberTLSSecretLabel: string(uid)},
+		},
+		Data: data,
+	}
+}
+
+// Create A Subscriber TLS Client Configuration (Trusting The Specified CA) With The Specified CommonName
+func newTestSubscriberTLSConfig(t *testing.T, ca *testCertificateAuthority, commonName string) *tls.Config {
+	certPEM, keyPEM := ca.issue(t, commonName, false)
+	tlsConfig, err := NewSubscriberTLSConfig(newTestTLSSecret(commonName+"-tls", uid123, map[string][]byte{
+		corev1.TLSCertKey:                certPEM,
+		corev1.TLSPrivateKeyKey:          keyPEM,
+		constants.SubscriberTLSCACertKey: ca.certPEM,
+	}))
+	assert.Nil(t, err)
+	return tlsConfig
+}
+
+// Create A Test Server Requiring Client Certificates Issued By The Specified CA (Peer CommonNames Sent To Returned Channel)
+func newTestMutualTLSServer(t *testing.T, ca *testCertificateAuthority) (*httptest.Server, *url.URL, chan string) {
+	peerCommonNames := make(chan string, 10)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		peerCommonNames <- request.TLS.PeerCertificates[0].Subject.CommonName
+		writer.WriteHeader(http.StatusAccepted)
+	}))
+	serverCertPEM, serverKeyPEM := ca.issue(t, "localhost", true)
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	assert.Nil(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}, ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	serverURL, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	return server, serverURL, peerCommonNames
+}
+
+// Create A Simple CloudEvent Message To Dispatch
+func newTestMessage() binding.Message {
+	event := cloudevents.NewEvent()
+	event.SetID("test-id")
+	event.SetType("test-type")
+	event.SetSource("test-source")
+	return binding.ToMessage(&event)
+}
+
+// Determine The CommonName Of The Client Certificate Configured In The Specified SubscriberTransport
+func transportCommonName(t *testing.T, transport *SubscriberTransport) string {
+	transport.transportMutex.RLock()
+	defer transport.transportMutex.RUnlock()
+	assert.NotNil(t, transport.transport.TLSClientConfig)
+	cert, err := x509.ParseCertificate(transport.transport.TLSClientConfig.Certificates[0].Certificate[0])
+	assert.Nil(t, err)
+	return cert.Subject.CommonName
+}
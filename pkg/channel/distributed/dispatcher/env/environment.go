@@ -38,8 +38,24 @@ type Environment struct {
 	ServiceName  string // Required
 
 	// Kafka Authorization
-	KafkaUsername string // Optional
-	KafkaPassword string // Optional
+	KafkaUsername      string // Optional
+	KafkaPassword      string // Optional
+	KafkaSaslMechanism string // Optional
+
+	// Warm Restart Configuration
+	SubscriptionSnapshotPath string // Optional
+
+	// Kafka Client Rack Configuration
+	NodeName string // Optional
+
+	// Shutdown Configuration
+	TerminationGracePeriodSeconds int64 // Optional
+
+	// Sharding Configuration
+	Shard int // Optional
+
+	// Startup Configuration
+	ConfigMapWaitTimeoutMillis int64 // Optional
 }
 
 // Get The Environment
@@ -69,6 +85,13 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	// Verify The Metrics & Health Ports Are Distinct (Before Attempting To Bind Either Of Them)
+	err = env.VerifyDistinctPorts(map[string]int{"MetricsPort": environment.MetricsPort, "HealthPort": environment.HealthPort})
+	if err != nil {
+		logger.Error("Invalid Port Configuration", zap.Error(err))
+		return nil, err
+	}
+
 	// Get The Required K8S KafkaBrokers Config Value
 	environment.KafkaBrokers, err = env.GetRequiredConfigValue(logger, env.KafkaBrokerEnvVarKey)
 	if err != nil {
@@ -99,6 +122,34 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 	// Get The Optional KafkaPassword Config Value
 	environment.KafkaPassword = env.GetOptionalConfigValue(logger, env.KafkaPasswordEnvVarKey, "")
 
+	// Get The Optional KafkaSaslMechanism Config Value
+	environment.KafkaSaslMechanism = env.GetOptionalConfigValue(logger, env.KafkaSaslMechanismEnvVarKey, "")
+
+	// Get The Optional SubscriptionSnapshotPath Config Value
+	environment.SubscriptionSnapshotPath = env.GetOptionalConfigValue(logger, env.SubscriptionSnapshotPathEnvVarKey, "")
+
+	// Get The Optional NodeName Config Value
+	environment.NodeName = env.GetOptionalConfigValue(logger, env.NodeNameEnvVarKey, "")
+
+	// Get The Optional TerminationGracePeriodSeconds Config Value & Convert To Int64 (Zero If Unspecified)
+	environment.TerminationGracePeriodSeconds, err = env.GetOptionalConfigInt64(logger, env.TerminationGracePeriodEnvVarKey, "0", "TerminationGracePeriodSeconds")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get The Optional Shard Config Value & Convert To Int (Zero If Unspecified, i.e. Not Sharded)
+	shard, err := env.GetOptionalConfigInt64(logger, env.DispatcherShardEnvVarKey, "0", "Shard")
+	if err != nil {
+		return nil, err
+	}
+	environment.Shard = int(shard)
+
+	// Get The Optional ConfigMapWaitTimeoutMillis Config Value & Convert To Int64 (Zero If Unspecified, i.e. No Waiting)
+	environment.ConfigMapWaitTimeoutMillis, err = env.GetOptionalConfigInt64(logger, env.ConfigMapWaitTimeoutEnvVarKey, "0", "ConfigMapWaitTimeoutMillis")
+	if err != nil {
+		return nil, err
+	}
+
 	// Clone The Environment & Mask The Password For Safe Logging
 	safeEnvironment := *environment
 	if len(safeEnvironment.KafkaPassword) > 0 {
@@ -39,6 +39,12 @@ const (
 	serviceName   = "TestServiceName"
 	kafkaUsername = "TestKafkaUsername"
 	kafkaPassword = "TestKafkaPassword"
+	saslMechanism = "SCRAM-SHA-512"
+	snapshotPath  = "/tmp/TestSnapshotPath"
+	nodeName      = "TestNodeName"
+	gracePeriod   = "45"
+	shard         = "2"
+	configMapWait = "30000"
 )
 
 // Define The TestCase Struct
@@ -53,6 +59,12 @@ type TestCase struct {
 	serviceName   string
 	kafkaUsername string
 	kafkaPassword string
+	saslMechanism string
+	snapshotPath  string
+	nodeName      string
+	gracePeriod   string
+	shard         string
+	configMapWait string
 	expectedError error
 }
 
@@ -67,6 +79,41 @@ func TestGetEnvironment(t *testing.T) {
 	testCase := getValidTestCase("Valid Complete Config")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config Without SASL Mechanism")
+	testCase.saslMechanism = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config Without NodeName")
+	testCase.nodeName = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config Without TerminationGracePeriodSeconds")
+	testCase.gracePeriod = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - TerminationGracePeriodSeconds")
+	testCase.gracePeriod = "NAN"
+	testCase.expectedError = fmt.Errorf("invalid (non int64) value '%s' for environment variable '%s'", testCase.gracePeriod, commonenv.TerminationGracePeriodEnvVarKey)
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config Without Shard")
+	testCase.shard = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Shard")
+	testCase.shard = "NAN"
+	testCase.expectedError = fmt.Errorf("invalid (non int64) value '%s' for environment variable '%s'", testCase.shard, commonenv.DispatcherShardEnvVarKey)
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config Without ConfigMapWaitTimeoutMillis")
+	testCase.configMapWait = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - ConfigMapWaitTimeoutMillis")
+	testCase.configMapWait = "NAN"
+	testCase.expectedError = fmt.Errorf("invalid (non int64) value '%s' for environment variable '%s'", testCase.configMapWait, commonenv.ConfigMapWaitTimeoutEnvVarKey)
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - MetricsDomain")
 	testCase.metricsDomain = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(commonenv.MetricsDomainEnvVarKey)
@@ -92,6 +139,11 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getInvalidIntEnvironmentVariableError(testCase.healthPort, commonenv.HealthPortEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Invalid Config - HealthPort Conflicts With MetricsPort")
+	testCase.healthPort = metricsPort
+	testCase.expectedError = fmt.Errorf("port conflict: HealthPort and MetricsPort are both configured as port %s", metricsPort)
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - KafkaBrokers")
 	testCase.kafkaBrokers = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(commonenv.KafkaBrokerEnvVarKey)
@@ -126,6 +178,12 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenv(t, commonenv.ServiceNameEnvVarKey, testCase.serviceName)
 		assertSetenv(t, commonenv.KafkaUsernameEnvVarKey, testCase.kafkaUsername)
 		assertSetenv(t, commonenv.KafkaPasswordEnvVarKey, testCase.kafkaPassword)
+		assertSetenvNonempty(t, commonenv.KafkaSaslMechanismEnvVarKey, testCase.saslMechanism)
+		assertSetenv(t, commonenv.SubscriptionSnapshotPathEnvVarKey, testCase.snapshotPath)
+		assertSetenv(t, commonenv.NodeNameEnvVarKey, testCase.nodeName)
+		assertSetenvNonempty(t, commonenv.TerminationGracePeriodEnvVarKey, testCase.gracePeriod)
+		assertSetenvNonempty(t, commonenv.DispatcherShardEnvVarKey, testCase.shard)
+		assertSetenvNonempty(t, commonenv.ConfigMapWaitTimeoutEnvVarKey, testCase.configMapWait)
 
 		// Perform The Test
 		environment, err := GetEnvironment(logger)
@@ -143,6 +201,24 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.serviceName, environment.ServiceName)
 			assert.Equal(t, testCase.kafkaUsername, environment.KafkaUsername)
 			assert.Equal(t, testCase.kafkaPassword, environment.KafkaPassword)
+			assert.Equal(t, testCase.saslMechanism, environment.KafkaSaslMechanism)
+			assert.Equal(t, testCase.snapshotPath, environment.SubscriptionSnapshotPath)
+			assert.Equal(t, testCase.nodeName, environment.NodeName)
+			if len(testCase.gracePeriod) > 0 {
+				assert.Equal(t, testCase.gracePeriod, strconv.FormatInt(environment.TerminationGracePeriodSeconds, 10))
+			} else {
+				assert.Equal(t, int64(0), environment.TerminationGracePeriodSeconds)
+			}
+			if len(testCase.shard) > 0 {
+				assert.Equal(t, testCase.shard, strconv.Itoa(environment.Shard))
+			} else {
+				assert.Equal(t, 0, environment.Shard)
+			}
+			if len(testCase.configMapWait) > 0 {
+				assert.Equal(t, testCase.configMapWait, strconv.FormatInt(environment.ConfigMapWaitTimeoutMillis, 10))
+			} else {
+				assert.Equal(t, int64(0), environment.ConfigMapWaitTimeoutMillis)
+			}
 
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
@@ -175,6 +251,12 @@ func getValidTestCase(name string) TestCase {
 		serviceName:   serviceName,
 		kafkaUsername: kafkaUsername,
 		kafkaPassword: kafkaPassword,
+		saslMechanism: saslMechanism,
+		snapshotPath:  snapshotPath,
+		nodeName:      nodeName,
+		gracePeriod:   gracePeriod,
+		shard:         shard,
+		configMapWait: configMapWait,
 		expectedError: nil,
 	}
 }
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+)
+
+// Wrapper Around Sarama NewClient() To Facilitate Mocking In Unit Tests
+var newClientWrapper = sarama.NewClient
+
+//
+// AuthChecker Periodically Verifies That The Kafka Brokers Still Accept The Current SASL Credentials
+//
+// Credentials can be rotated or revoked out-of-band, in which case the long-lived ConsumerGroups would
+// only discover the problem on their next reconnect.  The AuthChecker performs a lightweight metadata
+// request with a fresh client on each interval and clears the Server's AuthenticationValid flag (and
+// reports a metric) when the brokers reject the credentials.  Connectivity failures are logged but do
+// NOT affect the flag, so that authentication problems remain distinguishable from network problems.
+//
+type AuthChecker struct {
+	logger        *zap.Logger
+	brokers       []string
+	config        *sarama.Config
+	interval      time.Duration
+	healthServer  *Server
+	statsReporter metrics.StatsReporter
+	stopChan      chan struct{}
+	stoppedChan   chan struct{}
+}
+
+// Create A New AuthChecker With The Specified Configuration
+func NewAuthChecker(logger *zap.Logger, brokers []string, config *sarama.Config, interval time.Duration, healthServer *Server, statsReporter metrics.StatsReporter) *AuthChecker {
+
+	// Use A Shallow Copy Of The Sarama Config Without Metadata Retries (Each Check Is A Single Attempt)
+	checkConfig := *config
+	checkConfig.Metadata.Retry.Max = 0
+
+	return &AuthChecker{
+		logger:        logger,
+		brokers:       brokers,
+		config:        &checkConfig,
+		interval:      interval,
+		healthServer:  healthServer,
+		statsReporter: statsReporter,
+		stopChan:      make(chan struct{}),
+		stoppedChan:   make(chan struct{}),
+	}
+}
+
+// Start Performing Authentication Checks Every Interval In A Separate Go Routine
+func (a *AuthChecker) Start() {
+	a.logger.Info("Starting Kafka Authentication Checks", zap.Duration("Interval", a.interval))
+	go func() {
+		defer close(a.stoppedChan)
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.Check()
+			case <-a.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop Performing Authentication Checks (Blocks Until The Go Routine Has Exited)
+func (a *AuthChecker) Stop() {
+	close(a.stopChan)
+	<-a.stoppedChan
+	a.logger.Info("Stopped Kafka Authentication Checks")
+}
+
+// Perform A Single Authentication Check, Updating The Server's Flag & Returning Any Error Encountered
+func (a *AuthChecker) Check() error {
+
+	// Creating A Client Authenticates Against The Brokers & Performs A Metadata Request
+	client, err := newClientWrapper(a.brokers, a.config)
+	if err == nil {
+		if closeErr := client.Close(); closeErr != nil {
+			a.logger.Warn("Failed To Close Kafka Authentication Check Client", zap.Error(closeErr))
+		}
+		if !a.healthServer.AuthenticationValid() {
+			a.logger.Info("Kafka Authentication Check Succeeded - Credentials Are Valid Again")
+		}
+		a.healthServer.SetAuthenticationValid(true)
+		return nil
+	}
+
+	// Only Authentication Failures Affect The Readiness - Connectivity Problems Are Left To The ConsumerGroups
+	if isAuthenticationError(err) {
+		a.logger.Error("Kafka Authentication Check Failed - Credentials Rejected By Brokers", zap.Error(err))
+		a.healthServer.SetAuthenticationValid(false)
+		a.statsReporter.ReportAuthenticationFailure()
+	} else {
+		a.logger.Warn("Kafka Authentication Check Could Not Reach Brokers - Ignoring", zap.Error(err))
+	}
+	return err
+}
+
+// Determine Whether The Specified Error Indicates That The Brokers Rejected The SASL Credentials
+func isAuthenticationError(err error) bool {
+	return errors.Is(err, sarama.ErrSASLAuthenticationFailed) ||
+		errors.Is(err, sarama.ErrIllegalSASLState) ||
+		errors.Is(err, sarama.ErrUnsupportedSASLMechanism)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The AuthChecker Against A Mock Broker Which Starts Rejecting The SASL Credentials
+func TestAuthCheckerCheck(t *testing.T) {
+
+	// Create A Mock Broker Which Initially Accepts The SASL Credentials
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(createMockBrokerHandlers(t, broker, sarama.ErrNoError))
+
+	// Create The AuthChecker To Test
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	healthServer.SetDispatcherReady(true)
+	statsReporter := dispatchertesting.NewMockStatsReporter()
+	authChecker := NewAuthChecker(logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, createSaslConfig(), time.Minute, healthServer, statsReporter)
+
+	// Verify Valid Credentials Leave The Server Ready
+	assert.Nil(t, authChecker.Check())
+	assert.True(t, healthServer.AuthenticationValid())
+	assert.True(t, healthServer.Ready())
+	assert.Equal(t, 0, statsReporter.AuthenticationFailures)
+
+	// Revoke The Credentials & Verify The Server Is No Longer Ready And The Failure Was Reported
+	broker.SetHandlerByMap(createMockBrokerHandlers(t, broker, sarama.ErrSASLAuthenticationFailed))
+	err := authChecker.Check()
+	assert.True(t, errors.Is(err, sarama.ErrSASLAuthenticationFailed))
+	assert.False(t, healthServer.AuthenticationValid())
+	assert.False(t, healthServer.Ready())
+	assert.Equal(t, 1, statsReporter.AuthenticationFailures)
+
+	// Restore The Credentials & Verify The Server Recovers
+	broker.SetHandlerByMap(createMockBrokerHandlers(t, broker, sarama.ErrNoError))
+	assert.Nil(t, authChecker.Check())
+	assert.True(t, healthServer.AuthenticationValid())
+	assert.True(t, healthServer.Ready())
+	assert.Equal(t, 1, statsReporter.AuthenticationFailures)
+}
+
+// Test The AuthChecker Ignores Connectivity Failures When Determining Authentication Validity
+func TestAuthCheckerCheckConnectivityFailure(t *testing.T) {
+
+	// Create A Mock Broker & Close It So That It Cannot Be Reached
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+
+	// Create The AuthChecker To Test
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	healthServer.SetDispatcherReady(true)
+	statsReporter := dispatchertesting.NewMockStatsReporter()
+	authChecker := NewAuthChecker(logtesting.TestLogger(t).Desugar(), []string{brokerAddr}, createSaslConfig(), time.Minute, healthServer, statsReporter)
+
+	// Perform The Test
+	err := authChecker.Check()
+
+	// Verify The Results
+	assert.True(t, errors.Is(err, sarama.ErrOutOfBrokers))
+	assert.True(t, healthServer.AuthenticationValid())
+	assert.True(t, healthServer.Ready())
+	assert.Equal(t, 0, statsReporter.AuthenticationFailures)
+}
+
+// Test The AuthChecker's Start() & Stop() Functionality Performs Periodic Checks
+func TestAuthCheckerStartStop(t *testing.T) {
+
+	// Replace The NewClientWrapper With A Mock Which Rejects The Credentials & Restore After Test
+	newClientWrapperPlaceholder := newClientWrapper
+	checkCount := make(chan struct{}, 10)
+	newClientWrapper = func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		checkCount <- struct{}{}
+		return nil, sarama.ErrSASLAuthenticationFailed
+	}
+	defer func() {
+		newClientWrapper = newClientWrapperPlaceholder
+	}()
+
+	// Create The AuthChecker To Test
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	statsReporter := dispatchertesting.NewMockStatsReporter()
+	authChecker := NewAuthChecker(logtesting.TestLogger(t).Desugar(), []string{"TestBroker"}, sarama.NewConfig(), 10*time.Millisecond, healthServer, statsReporter)
+
+	// Start The AuthChecker & Wait For The First Check
+	authChecker.Start()
+	select {
+	case <-checkCount:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed Out Waiting For Authentication Check")
+	}
+	authChecker.Stop()
+
+	// Verify The Results
+	assert.False(t, healthServer.AuthenticationValid())
+	assert.True(t, statsReporter.AuthenticationFailures >= 1)
+}
+
+// Test The isAuthenticationError() Functionality
+func TestIsAuthenticationError(t *testing.T) {
+	assert.True(t, isAuthenticationError(sarama.ErrSASLAuthenticationFailed))
+	assert.True(t, isAuthenticationError(sarama.ErrIllegalSASLState))
+	assert.True(t, isAuthenticationError(sarama.ErrUnsupportedSASLMechanism))
+	assert.False(t, isAuthenticationError(sarama.ErrOutOfBrokers))
+	assert.False(t, isAuthenticationError(errors.New("test error")))
+}
+
+//
+// Private Utility Functions
+//
+
+// Create A Sarama Config With SASL PLAIN Authentication Enabled (Single Attempt, Short Timeouts)
+func createSaslConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Net.DialTimeout = time.Second
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	config.Net.SASL.Version = sarama.SASLHandshakeV1
+	config.Net.SASL.User = "TestUsername"
+	config.Net.SASL.Password = "TestPassword"
+	return config
+}
+
+// Create The Mock Broker Handlers With The SASL Authentication Responding With The Specified Error
+func createMockBrokerHandlers(t *testing.T, broker *sarama.MockBroker, authError sarama.KError) map[string]sarama.MockResponse {
+	return map[string]sarama.MockResponse{
+		"SaslHandshakeRequest":    sarama.NewMockSaslHandshakeResponse(t).SetEnabledMechanisms([]string{sarama.SASLTypePlaintext}),
+		"SaslAuthenticateRequest": sarama.NewMockSaslAuthenticateResponse(t).SetError(authError),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()),
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+)
+
+//
+// Verify That The Kafka Brokers Can Be Reached At Startup, Applying The BrokerUnavailablePolicy If Not
+//
+// Without this check a Dispatcher whose brokers are all unreachable starts up "ready" and then loops on
+// ConsumerGroup creation errors.  With the "crash" policy an error is returned so that the caller can exit
+// non-zero and leave Kubernetes to restart the Dispatcher with its usual back-off.  With the "degraded"
+// policy the Dispatcher stays up (and so remains observable) but reports not ready, while a background
+// go routine keeps retrying every interval until the brokers are reached or the Context is done.
+//
+func CheckBrokers(ctx context.Context, logger *zap.Logger, brokers []string, config *sarama.Config, policy string, interval time.Duration, healthServer *Server) error {
+
+	// Use A Shallow Copy Of The Sarama Config Without Metadata Retries (Each Check Is A Single Attempt)
+	checkConfig := *config
+	checkConfig.Metadata.Retry.Max = 0
+
+	// Nothing To Do If The Brokers Can Be Reached
+	err := brokersReachable(brokers, &checkConfig)
+	if err == nil {
+		return nil
+	}
+
+	// Crash If So Configured
+	if policy == constants.BrokerUnavailablePolicyCrash {
+		return fmt.Errorf("unable to reach any of the kafka brokers %v: %w", brokers, err)
+	}
+
+	// Otherwise Report Not Ready & Keep Retrying In The Background
+	logger.Error("Unable To Reach Kafka Brokers - Dispatcher Will Report Not Ready Until They Are Available",
+		zap.Strings("Brokers", brokers), zap.Duration("Interval", interval), zap.Error(err))
+	healthServer.SetBrokersAvailable(false)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			err := brokersReachable(brokers, &checkConfig)
+			if err == nil {
+				logger.Info("Kafka Brokers Are Now Available")
+				healthServer.SetBrokersAvailable(true)
+				return
+			}
+			logger.Debug("Kafka Brokers Still Unavailable", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Validate The Specified BrokerUnavailablePolicy & Return It (Or The Default If Unspecified)
+func ParseBrokerUnavailablePolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultBrokerUnavailablePolicy, nil
+	case constants.BrokerUnavailablePolicyCrash, constants.BrokerUnavailablePolicyDegraded:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid broker unavailable policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.BrokerUnavailablePolicyCrash, constants.BrokerUnavailablePolicyDegraded)
+	}
+}
+
+// Determine Whether Any Of The Specified Brokers Can Be Reached (Creating A Client Performs A Metadata Request)
+func brokersReachable(brokers []string, config *sarama.Config) error {
+	client, err := newClientWrapper(brokers, config)
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
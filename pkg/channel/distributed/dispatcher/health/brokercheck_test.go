@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test CheckBrokers() Leaves The Dispatcher Ready When The Brokers Can Be Reached (Regardless Of Policy)
+func TestCheckBrokersAvailable(t *testing.T) {
+	for _, policy := range []string{constants.BrokerUnavailablePolicyCrash, constants.BrokerUnavailablePolicyDegraded} {
+		t.Run(policy, func(t *testing.T) {
+
+			// Create A Mock Broker Which Responds To Metadata Requests
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+			broker.SetHandlerByMap(createMockTopicBrokerHandlers(t, broker, false))
+
+			// Perform The Test
+			healthServer := NewDispatcherHealthServer(testHttpPort)
+			err := CheckBrokers(context.Background(), logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, createTopicWaitConfig(), policy, 10*time.Millisecond, healthServer)
+
+			// Verify The Results
+			assert.Nil(t, err)
+			assert.True(t, healthServer.BrokersAvailable())
+		})
+	}
+}
+
+// Test CheckBrokers() Returns An Error With The "crash" Policy When The Brokers Cannot Be Reached
+func TestCheckBrokersUnavailableCrash(t *testing.T) {
+
+	// Create A Mock Broker & Close It So That It Cannot Be Reached
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+
+	// Perform The Test
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	err := CheckBrokers(context.Background(), logtesting.TestLogger(t).Desugar(), []string{brokerAddr}, createTopicWaitConfig(), constants.BrokerUnavailablePolicyCrash, 10*time.Millisecond, healthServer)
+
+	// Verify The Results
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, sarama.ErrOutOfBrokers))
+	assert.Contains(t, err.Error(), brokerAddr)
+}
+
+// Test CheckBrokers() Reports Not Ready With The "degraded" Policy Until The Brokers Can Be Reached
+func TestCheckBrokersUnavailableDegraded(t *testing.T) {
+
+	// Create A Mock Broker Which Initially Fails To Respond To Metadata Requests
+	unavailable := int32(1)
+	newClientWrapperPlaceholder := newClientWrapper
+	newClientWrapper = func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		if atomic.LoadInt32(&unavailable) == 1 {
+			return nil, sarama.ErrOutOfBrokers
+		}
+		return newClientWrapperPlaceholder(addrs, conf)
+	}
+	defer func() {
+		newClientWrapper = newClientWrapperPlaceholder
+	}()
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(createMockTopicBrokerHandlers(t, broker, false))
+
+	// Perform The Test
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	healthServer.SetDispatcherReady(true)
+	err := CheckBrokers(ctx, logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, createTopicWaitConfig(), constants.BrokerUnavailablePolicyDegraded, 10*time.Millisecond, healthServer)
+
+	// Verify The Dispatcher Stays Up But Reports Not Ready While The Brokers Are Unavailable
+	assert.Nil(t, err)
+	assert.False(t, healthServer.BrokersAvailable())
+	assert.False(t, healthServer.Ready())
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, healthServer.BrokersAvailable())
+
+	// Verify The Dispatcher Reports Ready Once The Brokers Become Available
+	atomic.StoreInt32(&unavailable, 0)
+	assert.Eventually(t, healthServer.BrokersAvailable, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, healthServer.Ready())
+}
+
+// Test CheckBrokers() Stops Retrying With The "degraded" Policy When The Context Is Done
+func TestCheckBrokersUnavailableDegradedCancelled(t *testing.T) {
+
+	// Create A Mock Broker & Close It So That It Cannot Be Reached
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+
+	// Count The Attempts To Reach The Brokers
+	attempts := make(chan struct{}, 100)
+	newClientWrapperPlaceholder := newClientWrapper
+	newClientWrapper = func(addrs []string, conf *sarama.Config) (sarama.Client, error) {
+		attempts <- struct{}{}
+		return newClientWrapperPlaceholder(addrs, conf)
+	}
+	defer func() {
+		newClientWrapper = newClientWrapperPlaceholder
+	}()
+
+	// Perform The Test
+	ctx, cancel := context.WithCancel(context.Background())
+	healthServer := NewDispatcherHealthServer(testHttpPort)
+	err := CheckBrokers(ctx, logtesting.TestLogger(t).Desugar(), []string{brokerAddr}, createTopicWaitConfig(), constants.BrokerUnavailablePolicyDegraded, 10*time.Millisecond, healthServer)
+	assert.Nil(t, err)
+
+	// Verify The Brokers Are Retried Until The Context Is Cancelled
+	assert.Eventually(t, func() bool { return len(attempts) >= 3 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	attemptCount := len(attempts)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, attemptCount, len(attempts))
+	assert.False(t, healthServer.BrokersAvailable())
+}
+
+// Test The ParseBrokerUnavailablePolicy() Functionality
+func TestParseBrokerUnavailablePolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultBrokerUnavailablePolicy},
+		{name: "Crash", policy: constants.BrokerUnavailablePolicyCrash, want: constants.BrokerUnavailablePolicyCrash},
+		{name: "Degraded", policy: constants.BrokerUnavailablePolicyDegraded, want: constants.BrokerUnavailablePolicyDegraded},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseBrokerUnavailablePolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
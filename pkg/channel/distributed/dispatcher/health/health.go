@@ -29,14 +29,20 @@ type Server struct {
 
 	// Additional Synchronization Mutexes
 	dispatcherMutex sync.Mutex // Synchronizes access to the dispatcherReady flag
+	authMutex       sync.Mutex // Synchronizes access to the authenticationValid flag
+	topicMutex      sync.Mutex // Synchronizes access to the topicAvailable flag
+	brokersMutex    sync.Mutex // Synchronizes access to the brokersAvailable flag
 
 	// Additional Internal Flags
-	dispatcherReady bool // A flag that the producer sets when it is ready
+	dispatcherReady     bool // A flag that the producer sets when it is ready
+	authenticationValid bool // A flag that the AuthChecker clears when the Kafka credentials are rejected
+	topicAvailable      bool // A flag that is cleared when the Kafka Topic did not exist within the startup wait timeout
+	brokersAvailable    bool // A flag that is cleared when none of the Kafka brokers could be reached at startup
 }
 
 // Creates A New Server With Specified Configuration
 func NewDispatcherHealthServer(httpPort string) *Server {
-	dispatcherHealth := &Server{}
+	dispatcherHealth := &Server{authenticationValid: true, topicAvailable: true, brokersAvailable: true}
 	dispatcherHealth.Server = *health.NewHealthServer(httpPort, dispatcherHealth)
 
 	// Return The Server
@@ -50,6 +56,27 @@ func (chs *Server) SetDispatcherReady(isReady bool) {
 	chs.dispatcherMutex.Unlock()
 }
 
+// Synchronized Function To Set Authentication Valid Flag
+func (chs *Server) SetAuthenticationValid(isValid bool) {
+	chs.authMutex.Lock()
+	chs.authenticationValid = isValid
+	chs.authMutex.Unlock()
+}
+
+// Synchronized Function To Set Topic Available Flag
+func (chs *Server) SetTopicAvailable(isAvailable bool) {
+	chs.topicMutex.Lock()
+	chs.topicAvailable = isAvailable
+	chs.topicMutex.Unlock()
+}
+
+// Synchronized Function To Set Brokers Available Flag
+func (chs *Server) SetBrokersAvailable(isAvailable bool) {
+	chs.brokersMutex.Lock()
+	chs.brokersAvailable = isAvailable
+	chs.brokersMutex.Unlock()
+}
+
 // Set All Liveness And Readiness Flags To False
 func (chs *Server) Shutdown() {
 	chs.Server.Shutdown()
@@ -61,11 +88,32 @@ func (chs *Server) DispatcherReady() bool {
 	return chs.dispatcherReady
 }
 
+// Synchronized Access Function For AuthenticationValid Flag
+func (chs *Server) AuthenticationValid() bool {
+	chs.authMutex.Lock()
+	defer chs.authMutex.Unlock()
+	return chs.authenticationValid
+}
+
+// Synchronized Access Function For TopicAvailable Flag
+func (chs *Server) TopicAvailable() bool {
+	chs.topicMutex.Lock()
+	defer chs.topicMutex.Unlock()
+	return chs.topicAvailable
+}
+
+// Synchronized Access Function For BrokersAvailable Flag
+func (chs *Server) BrokersAvailable() bool {
+	chs.brokersMutex.Lock()
+	defer chs.brokersMutex.Unlock()
+	return chs.brokersAvailable
+}
+
 // Functions That Implement The HealthInterface
 
 // Response Function For Readiness Requests (/healthy)
 func (chs *Server) Ready() bool {
-	return chs.dispatcherReady
+	return chs.dispatcherReady && chs.AuthenticationValid() && chs.TopicAvailable() && chs.BrokersAvailable()
 }
 
 // Response Function For Liveness Requests (/healthz)
@@ -40,6 +40,9 @@ func TestNewDispatcherHealthServer(t *testing.T) {
 	assert.NotNil(t, health)
 	assert.Equal(t, false, health.Alive())
 	assert.Equal(t, false, health.dispatcherReady)
+	assert.Equal(t, true, health.AuthenticationValid())
+	assert.Equal(t, true, health.TopicAvailable())
+	assert.Equal(t, true, health.BrokersAvailable())
 }
 
 // Test Flag Set And Reset Functions
@@ -53,6 +56,18 @@ func TestReadinessFlagWrites(t *testing.T) {
 	assert.Equal(t, false, chs.DispatcherReady())
 	chs.SetDispatcherReady(true)
 	assert.Equal(t, true, chs.DispatcherReady())
+	chs.SetAuthenticationValid(false)
+	assert.Equal(t, false, chs.AuthenticationValid())
+	chs.SetAuthenticationValid(true)
+	assert.Equal(t, true, chs.AuthenticationValid())
+	chs.SetTopicAvailable(false)
+	assert.Equal(t, false, chs.TopicAvailable())
+	chs.SetTopicAvailable(true)
+	assert.Equal(t, true, chs.TopicAvailable())
+	chs.SetBrokersAvailable(false)
+	assert.Equal(t, false, chs.BrokersAvailable())
+	chs.SetBrokersAvailable(true)
+	assert.Equal(t, true, chs.BrokersAvailable())
 }
 
 // Test The Dispatcher Health Server Via The HTTP Handlers
@@ -68,6 +83,24 @@ func TestDispatcherHealthHandler(t *testing.T) {
 	chs.SetDispatcherReady(true)
 	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
 
+	// Verify that an authentication failure clears the readiness status until the credentials are valid again
+	chs.SetAuthenticationValid(false)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusInternalServerError)
+	chs.SetAuthenticationValid(true)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
+
+	// Verify that a missing topic clears the readiness status until the topic is available
+	chs.SetTopicAvailable(false)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusInternalServerError)
+	chs.SetTopicAvailable(true)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
+
+	// Verify that unreachable brokers clear the readiness status until the brokers are available
+	chs.SetBrokersAvailable(false)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusInternalServerError)
+	chs.SetBrokersAvailable(true)
+	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
+
 	// Verify that the shutdown process sets all statuses to not live / not ready
 	chs.SetDispatcherReady(true)
 	getEventToHandler(t, chs.HandleReadiness, readinessPath, http.StatusOK)
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// The JSON Body Of Responses From The Scaling Endpoint
+type ScalingSignal struct {
+	Topic          string           `json:"topic"`
+	Lag            int64            `json:"lag"`            // The Largest Lag Of Any Subscriber's ConsumerGroup
+	TargetLag      int64            `json:"targetLag"`      // The Configured Lag Which A Single Dispatcher Replica Should Sustain
+	Scale          float64          `json:"scale"`          // The Ratio Of The Lag To The Target (Values Above 1 Indicate Scaling Up)
+	ConsumerGroups map[string]int64 `json:"consumerGroups"` // The Lag Of Each Subscriber's ConsumerGroup
+}
+
+//
+// LagScaler Reports A Scaling Signal Derived From The Consumer Lag Of The Dispatcher's ConsumerGroups
+//
+// Each request to the scaling endpoint compares the high-water mark of every partition of the Topic with
+// the offsets committed by each subscriber's ConsumerGroup, and reports the total lag of the slowest group
+// alongside the configured target so that an autoscaler (e.g. the KEDA metrics-api scaler or an HPA external
+// metric) can scale the Dispatcher without querying Kafka itself.  Partitions without a committed offset only
+// count as lag if the ConsumerGroups start consuming from the oldest offset.  The committed offsets are fetched
+// via the (optional) shared ConsumerGroupOffsetCache.
+//
+type LagScaler struct {
+	logger      *zap.Logger
+	brokers     []string
+	config      *sarama.Config
+	topic       string
+	targetLag   int64
+	groupIds    func() []string           // Returns The IDs Of The Current Subscribers' ConsumerGroups
+	offsetCache *ConsumerGroupOffsetCache // Optional Cache Of The ConsumerGroups' Committed Offsets (Always Fetched If Nil)
+}
+
+// Create A New LagScaler For The Specified Topic & Target Lag (Which Must Be Positive)
+func NewLagScaler(logger *zap.Logger, brokers []string, config *sarama.Config, topic string, targetLag int64, groupIds func() []string, offsetCache *ConsumerGroupOffsetCache) (*LagScaler, error) {
+
+	// Validate The Target Lag
+	if targetLag <= 0 {
+		return nil, fmt.Errorf("invalid scaling target lag %d - must be positive", targetLag)
+	}
+
+	// Use A Shallow Copy Of The Sarama Config Without Metadata Retries (Each Request Is A Single Attempt)
+	scalerConfig := *config
+	scalerConfig.Metadata.Retry.Max = 0
+
+	return &LagScaler{
+		logger:      logger.With(zap.String("Topic", topic)),
+		brokers:     brokers,
+		config:      &scalerConfig,
+		topic:       topic,
+		targetLag:   targetLag,
+		groupIds:    groupIds,
+		offsetCache: offsetCache,
+	}, nil
+}
+
+// HTTP Request Handler For Scaling Requests (Responds 503 If The Lag Could Not Be Determined)
+func (l *LagScaler) HandleScaling(responseWriter http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	signal, err := l.Signal()
+	if err != nil {
+		l.logger.Warn("Failed To Determine Consumer Lag For Scaling Signal", zap.Error(err))
+		responseWriter.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(responseWriter).Encode(signal)
+}
+
+// Determine The Current Scaling Signal
+func (l *LagScaler) Signal() (ScalingSignal, error) {
+	groupLags, err := l.consumerGroupLags(l.groupIds())
+	if err != nil {
+		return ScalingSignal{}, err
+	}
+	return newScalingSignal(l.topic, groupLags, l.targetLag), nil
+}
+
+// Create The ScalingSignal For The Specified ConsumerGroup Lags & Target Lag
+func newScalingSignal(topic string, groupLags map[string]int64, targetLag int64) ScalingSignal {
+	var lag int64
+	for _, groupLag := range groupLags {
+		if groupLag > lag {
+			lag = groupLag
+		}
+	}
+	return ScalingSignal{
+		Topic:          topic,
+		Lag:            lag,
+		TargetLag:      targetLag,
+		Scale:          float64(lag) / float64(targetLag),
+		ConsumerGroups: groupLags,
+	}
+}
+
+// Get The Total Lag Across All Partitions Of The Topic For Each Of The Specified ConsumerGroups
+func (l *LagScaler) consumerGroupLags(groupIds []string) (map[string]int64, error) {
+
+	groupLags := make(map[string]int64, len(groupIds))
+	if len(groupIds) <= 0 {
+		return groupLags, nil
+	}
+
+	// Create A Client & ClusterAdmin (Closing The ClusterAdmin Closes The Client)
+	client, err := newClientWrapper(l.brokers, l.config)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	defer func() { _ = admin.Close() }()
+
+	// Get The High-Water Mark (And If Uncommitted Partitions Count As Lag, The Oldest Offset) Of Each Partition
+	partitions, err := client.Partitions(l.topic)
+	if err != nil {
+		return nil, err
+	}
+	highWaterMarks := make(map[int32]int64, len(partitions))
+	oldestOffsets := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		if highWaterMarks[partition], err = client.GetOffset(l.topic, partition, sarama.OffsetNewest); err != nil {
+			return nil, err
+		}
+		if l.config.Consumer.Offsets.Initial == sarama.OffsetOldest {
+			if oldestOffsets[partition], err = client.GetOffset(l.topic, partition, sarama.OffsetOldest); err != nil {
+				return nil, err
+			}
+		} else {
+			oldestOffsets[partition] = highWaterMarks[partition]
+		}
+	}
+
+	// Sum The Lag Of Each Partition For Each ConsumerGroup
+	for _, groupId := range groupIds {
+		response, err := l.offsetCache.ListConsumerGroupOffsets(admin, groupId, l.topic, partitions)
+		if err != nil {
+			return nil, err
+		}
+		if response.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("failed to fetch offsets of consumer group '%s': %w", groupId, response.Err)
+		}
+		var groupLag int64
+		for _, partition := range partitions {
+			offset := oldestOffsets[partition]
+			if block := response.GetBlock(l.topic, partition); block != nil {
+				if block.Err != sarama.ErrNoError {
+					return nil, fmt.Errorf("failed to fetch offset of consumer group '%s' for partition %d: %w", groupId, partition, block.Err)
+				}
+				if block.Offset >= 0 {
+					offset = block.Offset
+				}
+			}
+			if highWaterMarks[partition] > offset {
+				groupLag += highWaterMarks[partition] - offset
+			}
+		}
+		groupLags[groupId] = groupLag
+	}
+	return groupLags, nil
+}
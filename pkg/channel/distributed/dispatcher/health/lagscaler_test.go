@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewLagScaler() Validation Of The Target Lag
+func TestNewLagScaler(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	groupIds := func() []string { return nil }
+	for _, targetLag := range []int64{0, -1} {
+		lagScaler, err := NewLagScaler(logger, []string{"localhost:9092"}, sarama.NewConfig(), testTopic, targetLag, groupIds, nil)
+		assert.Nil(t, lagScaler)
+		assert.NotNil(t, err)
+	}
+	config := sarama.NewConfig()
+	lagScaler, err := NewLagScaler(logger, []string{"localhost:9092"}, config, testTopic, 1000, groupIds, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), lagScaler.targetLag)
+	assert.Equal(t, 0, lagScaler.config.Metadata.Retry.Max)
+	assert.NotEqual(t, 0, config.Metadata.Retry.Max) // The Specified Config Is Not Modified
+}
+
+// Test The newScalingSignal() Functionality For Various ConsumerGroup Lags
+func TestNewScalingSignal(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		groupLags map[string]int64
+		wantLag   int64
+		wantScale float64
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No ConsumerGroups", groupLags: map[string]int64{}, wantLag: 0, wantScale: 0},
+		{name: "No Lag", groupLags: map[string]int64{"group1": 0}, wantLag: 0, wantScale: 0},
+		{name: "Below Target", groupLags: map[string]int64{"group1": 50}, wantLag: 50, wantScale: 0.5},
+		{name: "At Target", groupLags: map[string]int64{"group1": 100}, wantLag: 100, wantScale: 1},
+		{name: "Slowest ConsumerGroup Above Target", groupLags: map[string]int64{"group1": 100, "group2": 250}, wantLag: 250, wantScale: 2.5},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			signal := newScalingSignal(testTopic, testCase.groupLags, 100)
+			assert.Equal(t, ScalingSignal{Topic: testTopic, Lag: testCase.wantLag, TargetLag: 100, Scale: testCase.wantScale, ConsumerGroups: testCase.groupLags}, signal)
+		})
+	}
+}
+
+// Test The HandleScaling() Functionality Against A Mock Broker
+func TestLagScalerHandleScaling(t *testing.T) {
+
+	// Create A Mock Broker With Two Partitions & Two ConsumerGroups
+	//   - Partition 0 Has Offsets 10 - 100, Partition 1 Has Offsets 20 - 50
+	//   - group1 Has Committed 40 & 50 (Lag 60 + 0)
+	//   - group2 Has Committed 90 On Partition 0 Only (Lag 10 + Either 0 Or 30 Depending On The Initial Offset)
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()).
+			SetLeader(testTopic, 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(testTopic, 0, sarama.OffsetNewest, 100).
+			SetOffset(testTopic, 0, sarama.OffsetOldest, 10).
+			SetOffset(testTopic, 1, sarama.OffsetNewest, 50).
+			SetOffset(testTopic, 1, sarama.OffsetOldest, 20),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "group1", broker).
+			SetCoordinator(sarama.CoordinatorGroup, "group2", broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("group1", testTopic, 0, 40, "", sarama.ErrNoError).
+			SetOffset("group1", testTopic, 1, 50, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 0, 90, "", sarama.ErrNoError).
+			SetOffset("group2", testTopic, 1, -1, "", sarama.ErrNoError),
+	})
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		initialOffset int64
+		targetLag     int64
+		want          ScalingSignal
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:          "Initial Offset Newest",
+			initialOffset: sarama.OffsetNewest,
+			targetLag:     40,
+			want:          ScalingSignal{Topic: testTopic, Lag: 60, TargetLag: 40, Scale: 1.5, ConsumerGroups: map[string]int64{"group1": 60, "group2": 10}},
+		},
+		{
+			name:          "Initial Offset Oldest",
+			initialOffset: sarama.OffsetOldest,
+			targetLag:     120,
+			want:          ScalingSignal{Topic: testTopic, Lag: 60, TargetLag: 120, Scale: 0.5, ConsumerGroups: map[string]int64{"group1": 60, "group2": 40}},
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createTopicWaitConfig()
+			config.Consumer.Offsets.Initial = testCase.initialOffset
+			lagScaler, err := NewLagScaler(logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, config, testTopic, testCase.targetLag, func() []string { return []string{"group1", "group2"} }, nil)
+			assert.Nil(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			lagScaler.HandleScaling(responseRecorder, httptest.NewRequest(http.MethodGet, "/scaling", nil))
+
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, "application/json", responseRecorder.Header().Get("Content-Type"))
+			signal := ScalingSignal{}
+			assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &signal))
+			assert.Equal(t, testCase.want, signal)
+		})
+	}
+}
+
+// Test The HandleScaling() Functionality When The Lag Cannot Be Determined Or Is Not Required
+func TestLagScalerHandleScalingUnavailable(t *testing.T) {
+
+	// Create A Mock Broker & Close It So That It Cannot Be Reached
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+	logger := logtesting.TestLogger(t).Desugar()
+	groupIds := []string{"group1"}
+	lagScaler, err := NewLagScaler(logger, []string{brokerAddr}, createTopicWaitConfig(), testTopic, 100, func() []string { return groupIds }, nil)
+	assert.Nil(t, err)
+
+	// Unsupported Methods Are Rejected
+	responseRecorder := httptest.NewRecorder()
+	lagScaler.HandleScaling(responseRecorder, httptest.NewRequest(http.MethodPost, "/scaling", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, responseRecorder.Code)
+
+	// Unreachable Brokers Result In A 503
+	responseRecorder = httptest.NewRecorder()
+	lagScaler.HandleScaling(responseRecorder, httptest.NewRequest(http.MethodGet, "/scaling", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+	// No ConsumerGroups Requires No Brokers
+	groupIds = nil
+	responseRecorder = httptest.NewRecorder()
+	lagScaler.HandleScaling(responseRecorder, httptest.NewRequest(http.MethodGet, "/scaling", nil))
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	signal := ScalingSignal{}
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &signal))
+	assert.Equal(t, ScalingSignal{Topic: testTopic, TargetLag: 100, ConsumerGroups: map[string]int64{}}, signal)
+}
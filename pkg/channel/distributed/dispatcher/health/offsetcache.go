@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Time Source (Replaceable For Testing)
+var now = time.Now
+
+//
+// ConsumerGroupOffsetCache Caches The Committed Offsets Fetched From The ConsumerGroup Coordinators
+//
+// Features which repeatedly fetch the committed offsets of the Dispatcher's ConsumerGroups (e.g. the lag scaling
+// signal, which autoscalers may poll every few seconds) share a cache so that the coordinators are not queried
+// redundantly.  Each ConsumerGroup's offsets are re-fetched once the TTL has elapsed, or as soon as the group
+// re-balances (when its offsets may have been committed by another member).  Only successful fetches are cached,
+// and caching is disabled entirely if the TTL is zero.  A nil cache is valid and always fetches.
+//
+type ConsumerGroupOffsetCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	entries map[string]consumerGroupOffsetCacheEntry // Keyed By ConsumerGroup ID
+}
+
+// The Cached Offsets Of A Single ConsumerGroup
+type consumerGroupOffsetCacheEntry struct {
+	topic      string
+	partitions []int32
+	response   *sarama.OffsetFetchResponse
+	expiry     time.Time
+}
+
+// ConsumerGroupOffsetCache Constructor
+func NewConsumerGroupOffsetCache(ttl time.Duration) *ConsumerGroupOffsetCache {
+	return &ConsumerGroupOffsetCache{ttl: ttl, entries: make(map[string]consumerGroupOffsetCacheEntry)}
+}
+
+// Get The Committed Offsets Of The Specified ConsumerGroup For The Partitions Of The Topic (Cached For The TTL)
+func (c *ConsumerGroupOffsetCache) ListConsumerGroupOffsets(admin sarama.ClusterAdmin, groupId string, topic string, partitions []int32) (*sarama.OffsetFetchResponse, error) {
+
+	// Fetch Directly If Caching Is Disabled
+	if c == nil || c.ttl <= 0 {
+		return admin.ListConsumerGroupOffsets(groupId, map[string][]int32{topic: partitions})
+	}
+
+	// Use The Cached Offsets If They Are Unexpired & For The Same Partitions
+	c.lock.Lock()
+	entry, ok := c.entries[groupId]
+	c.lock.Unlock()
+	if ok && now().Before(entry.expiry) && entry.topic == topic && equalPartitions(entry.partitions, partitions) {
+		return entry.response, nil
+	}
+
+	// Otherwise Fetch The Offsets & Cache Them If Successful
+	response, err := admin.ListConsumerGroupOffsets(groupId, map[string][]int32{topic: partitions})
+	if err != nil || !offsetFetchSucceeded(response) {
+		return response, err
+	}
+	c.lock.Lock()
+	c.entries[groupId] = consumerGroupOffsetCacheEntry{topic: topic, partitions: partitions, response: response, expiry: now().Add(c.ttl)}
+	c.lock.Unlock()
+	return response, nil
+}
+
+// Invalidate The Cached Offsets Of The Specified ConsumerGroup (e.g. When It Re-Balances)
+func (c *ConsumerGroupOffsetCache) Invalidate(groupId string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.entries, groupId)
+}
+
+// Determine Whether The Specified OffsetFetchResponse Has No Errors (Either Overall Or For Any Partition)
+func offsetFetchSucceeded(response *sarama.OffsetFetchResponse) bool {
+	if response == nil || response.Err != sarama.ErrNoError {
+		return false
+	}
+	for _, blocks := range response.Blocks {
+		for _, block := range blocks {
+			if block.Err != sarama.ErrNoError {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Determine Whether The Specified Partition Lists Are Identical
+func equalPartitions(partitions1 []int32, partitions2 []int32) bool {
+	if len(partitions1) != len(partitions2) {
+		return false
+	}
+	for index := range partitions1 {
+		if partitions1[index] != partitions2[index] {
+			return false
+		}
+	}
+	return true
+}
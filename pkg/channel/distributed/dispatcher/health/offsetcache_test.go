@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test The ConsumerGroupOffsetCache's Caching, Expiry & Invalidation Of Committed Offsets
+func TestConsumerGroupOffsetCache(t *testing.T) {
+
+	// Replace The Time Source With A Controllable One & Restore After Test
+	currentTime := time.Unix(1e9, 0)
+	now = func() time.Time { return currentTime }
+	t.Cleanup(func() { now = time.Now })
+
+	// Test Data
+	const ttl = 10 * time.Second
+	partitions := []int32{0, 1}
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name          string
+		cache         *ConsumerGroupOffsetCache
+		response      *sarama.OffsetFetchResponse
+		err           error
+		actions       func(cache *ConsumerGroupOffsetCache) // Performed Between The First & Second Fetch
+		secondTopic   string
+		secondGroupId string
+		wantFetches   int // Number Of Fetches From The Coordinator For Both Calls
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:        "Cached Within TTL",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			actions:     func(_ *ConsumerGroupOffsetCache) { currentTime = currentTime.Add(ttl - time.Millisecond) },
+			wantFetches: 1,
+		},
+		{
+			name:        "Refreshed After TTL",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			actions:     func(_ *ConsumerGroupOffsetCache) { currentTime = currentTime.Add(ttl) },
+			wantFetches: 2,
+		},
+		{
+			name:        "Refreshed After Rebalance",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			actions:     func(cache *ConsumerGroupOffsetCache) { cache.Invalidate("group1") },
+			wantFetches: 2,
+		},
+		{
+			name:        "Other ConsumerGroup Rebalanced",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			actions:     func(cache *ConsumerGroupOffsetCache) { cache.Invalidate("group2") },
+			wantFetches: 1,
+		},
+		{
+			name:          "Other ConsumerGroup Not Cached",
+			cache:         NewConsumerGroupOffsetCache(ttl),
+			response:      newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			secondGroupId: "group2",
+			wantFetches:   2,
+		},
+		{
+			name:        "Other Topic Not Cached",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			secondTopic: "otherTopic",
+			wantFetches: 2,
+		},
+		{
+			name:        "Fetch Error Not Cached",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			err:         errors.New("test fetch error"),
+			wantFetches: 2,
+		},
+		{
+			name:        "Response Error Not Cached",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNotCoordinatorForConsumer, sarama.ErrNoError),
+			wantFetches: 2,
+		},
+		{
+			name:        "Partition Error Not Cached",
+			cache:       NewConsumerGroupOffsetCache(ttl),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrUnknownTopicOrPartition),
+			wantFetches: 2,
+		},
+		{
+			name:        "Caching Disabled",
+			cache:       NewConsumerGroupOffsetCache(0),
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			wantFetches: 2,
+		},
+		{
+			name:        "Nil Cache",
+			response:    newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError),
+			actions:     func(cache *ConsumerGroupOffsetCache) { cache.Invalidate("group1") },
+			wantFetches: 2,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			admin := &countingClusterAdmin{response: testCase.response, err: testCase.err}
+
+			// Perform The First Fetch
+			response, err := testCase.cache.ListConsumerGroupOffsets(admin, "group1", testTopic, partitions)
+			assert.Equal(t, testCase.response, response)
+			assert.Equal(t, testCase.err, err)
+
+			// Perform Any Intermediate Actions & The Second Fetch
+			if testCase.actions != nil {
+				testCase.actions(testCase.cache)
+			}
+			secondTopic := testTopic
+			if len(testCase.secondTopic) > 0 {
+				secondTopic = testCase.secondTopic
+			}
+			secondGroupId := "group1"
+			if len(testCase.secondGroupId) > 0 {
+				secondGroupId = testCase.secondGroupId
+			}
+			response, err = testCase.cache.ListConsumerGroupOffsets(admin, secondGroupId, secondTopic, partitions)
+			assert.Equal(t, testCase.response, response)
+			assert.Equal(t, testCase.err, err)
+
+			// Verify The Number Of Fetches From The Coordinator
+			assert.Equal(t, testCase.wantFetches, admin.fetches)
+		})
+	}
+}
+
+// Test The ConsumerGroupOffsetCache Re-Fetches When The Topic's Partitions Change
+func TestConsumerGroupOffsetCachePartitionsChanged(t *testing.T) {
+	cache := NewConsumerGroupOffsetCache(time.Minute)
+	admin := &countingClusterAdmin{response: newTestOffsetFetchResponse(sarama.ErrNoError, sarama.ErrNoError)}
+	for _, partitions := range [][]int32{{0, 1}, {0, 1}, {0, 1, 2}, {0, 1, 2}} {
+		_, err := cache.ListConsumerGroupOffsets(admin, "group1", testTopic, partitions)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, admin.fetches)
+}
+
+// Create An OffsetFetchResponse For Two Partitions Of The Test Topic With The Specified Errors
+func newTestOffsetFetchResponse(err sarama.KError, partitionErr sarama.KError) *sarama.OffsetFetchResponse {
+	response := &sarama.OffsetFetchResponse{Err: err}
+	response.AddBlock(testTopic, 0, &sarama.OffsetFetchResponseBlock{Offset: 40, Err: sarama.ErrNoError})
+	response.AddBlock(testTopic, 1, &sarama.OffsetFetchResponseBlock{Offset: 50, Err: partitionErr})
+	return response
+}
+
+//
+// ClusterAdmin Counting The ConsumerGroup Offset Fetches (Which Return The Specified Response & Error)
+//
+type countingClusterAdmin struct {
+	sarama.ClusterAdmin
+	response *sarama.OffsetFetchResponse
+	err      error
+	fetches  int
+}
+
+func (a *countingClusterAdmin) ListConsumerGroupOffsets(_ string, _ map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	a.fetches++
+	return a.response, a.err
+}
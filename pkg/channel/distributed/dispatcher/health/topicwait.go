@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+//
+// Wait For The Specified Kafka Topic To Exist, Polling Every Interval Until The Context Is Done
+//
+// When a KafkaChannel is first created the Dispatcher may start before the controller has created the
+// Topic, in which case the ConsumerGroups log UnknownTopicOrPartition errors until it appears.  Each
+// poll uses a fresh client whose (full) metadata request lists the existing Topics without asking the
+// brokers about the specific Topic, so that brokers with auto.create.topics.enable do not create it.
+// Returns nil once the Topic exists, or an error describing the last poll if the Context is done first.
+//
+func WaitForTopic(ctx context.Context, logger *zap.Logger, brokers []string, config *sarama.Config, topic string, interval time.Duration) error {
+
+	// Use A Shallow Copy Of The Sarama Config Without Metadata Retries (Each Poll Is A Single Attempt)
+	pollConfig := *config
+	pollConfig.Metadata.Retry.Max = 0
+	pollConfig.Metadata.Full = true
+
+	logger = logger.With(zap.String("Topic", topic))
+	logger.Info("Waiting For Kafka Topic To Exist", zap.Duration("Interval", interval))
+	for {
+
+		// Check Whether The Topic Exists & Return If So
+		exists, err := topicExists(brokers, &pollConfig, topic)
+		if exists {
+			logger.Info("Kafka Topic Exists")
+			return nil
+		}
+		if err == nil {
+			err = sarama.ErrUnknownTopicOrPartition
+		}
+		logger.Debug("Kafka Topic Not Yet Available", zap.Error(err))
+
+		// Wait For The Next Poll (Or Give Up)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("kafka topic '%s' not available (%v): %w", topic, err, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Determine Whether The Specified Topic Is Included In The Brokers' Metadata
+func topicExists(brokers []string, config *sarama.Config, topic string) (bool, error) {
+
+	// Creating A Client Performs The Initial (Full) Metadata Request
+	client, err := newClientWrapper(brokers, config)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = client.Close() }()
+
+	// Search The Topics Known To The Client
+	topics, err := client.Topics()
+	if err != nil {
+		return false, err
+	}
+	for _, existingTopic := range topics {
+		if existingTopic == topic {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const testTopic = "TestTopic"
+
+// Test WaitForTopic() Returns Once The Topic Is Created Within The Timeout
+func TestWaitForTopicAppearsInTime(t *testing.T) {
+
+	// Create A Mock Broker Which Initially Does Not Have The Topic
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(createMockTopicBrokerHandlers(t, broker, false))
+
+	// Create The Topic After A Few Polls
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		broker.SetHandlerByMap(createMockTopicBrokerHandlers(t, broker, true))
+	}()
+
+	// Perform The Test
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	startTime := time.Now()
+	err := WaitForTopic(ctx, logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, createTopicWaitConfig(), testTopic, 10*time.Millisecond)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.True(t, time.Since(startTime) >= 100*time.Millisecond)
+}
+
+// Test WaitForTopic() Returns A Descriptive Error When The Topic Is Never Created
+func TestWaitForTopicNeverAppears(t *testing.T) {
+
+	// Create A Mock Broker Which Never Has The Topic
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(createMockTopicBrokerHandlers(t, broker, false))
+
+	// Perform The Test
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := WaitForTopic(ctx, logtesting.TestLogger(t).Desugar(), []string{broker.Addr()}, createTopicWaitConfig(), testTopic, 10*time.Millisecond)
+
+	// Verify The Results
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), testTopic)
+	assert.Contains(t, err.Error(), sarama.ErrUnknownTopicOrPartition.Error())
+}
+
+// Test WaitForTopic() Keeps Polling While The Brokers Cannot Be Reached
+func TestWaitForTopicBrokersUnavailable(t *testing.T) {
+
+	// Create A Mock Broker & Close It So That It Cannot Be Reached
+	broker := sarama.NewMockBroker(t, 1)
+	brokerAddr := broker.Addr()
+	broker.Close()
+
+	// Perform The Test
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := WaitForTopic(ctx, logtesting.TestLogger(t).Desugar(), []string{brokerAddr}, createTopicWaitConfig(), testTopic, 10*time.Millisecond)
+
+	// Verify The Results
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), sarama.ErrOutOfBrokers.Error())
+}
+
+//
+// Private Utility Functions
+//
+
+// Create A Sarama Config For Waiting On Topics Against The Mock Broker
+func createTopicWaitConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	config.Net.DialTimeout = time.Second
+	return config
+}
+
+// Create The Mock Broker Handlers With The Metadata Optionally Including The Test Topic
+func createMockTopicBrokerHandlers(t *testing.T, broker *sarama.MockBroker, topicExists bool) map[string]sarama.MockResponse {
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetBroker(broker.Addr(), broker.BrokerID()).
+		SetController(broker.BrokerID())
+	if topicExists {
+		metadataResponse = metadataResponse.SetLeader(testTopic, 0, broker.BrokerID())
+	}
+	return map[string]sarama.MockResponse{"MetadataRequest": metadataResponse}
+}
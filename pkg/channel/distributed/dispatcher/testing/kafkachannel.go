@@ -22,6 +22,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/sharding"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 )
@@ -99,3 +101,92 @@ func WithSubscriberReady(uid types.UID) KafkaChannelOption {
 		})
 	}
 }
+
+// WithSubscriberFailed marks the subscriber with the given UID as not ready with the specified message.
+func WithSubscriberFailed(uid types.UID, message string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Status.SubscribableStatus.Subscribers == nil {
+			kafkachannel.Status.SubscribableStatus.Subscribers = []eventingduck.SubscriberStatus{}
+		}
+		kafkachannel.Status.SubscribableStatus.Subscribers = append(kafkachannel.Status.SubscribableStatus.Subscribers, eventingduck.SubscriberStatus{
+			Ready:   corev1.ConditionFalse,
+			UID:     uid,
+			Message: message,
+		})
+	}
+}
+
+// WithMaxDeliveryConcurrency sets the maximum delivery concurrency in the KafkaChannel's spec.
+func WithMaxDeliveryConcurrency(maxDeliveryConcurrency int32) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		kafkachannel.Spec.MaxDeliveryConcurrency = maxDeliveryConcurrency
+	}
+}
+
+// WithEffectiveMaxDeliveryConcurrency sets the effective maximum delivery concurrency in the KafkaChannel's status.
+func WithEffectiveMaxDeliveryConcurrency(maxDeliveryConcurrency int32) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		kafkachannel.Status.MaxDeliveryConcurrency = maxDeliveryConcurrency
+	}
+}
+
+// WithInitialOffsets sets the annotation specifying the initial offsets of the KafkaChannel's subscribers.
+func WithInitialOffsets(annotation string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Annotations == nil {
+			kafkachannel.Annotations = make(map[string]string)
+		}
+		kafkachannel.Annotations[constants.InitialOffsetsAnnotation] = annotation
+	}
+}
+
+// WithHeadOfLinePolicies sets the annotation specifying the head-of-line policies of the KafkaChannel's subscribers.
+func WithHeadOfLinePolicies(annotation string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Annotations == nil {
+			kafkachannel.Annotations = make(map[string]string)
+		}
+		kafkachannel.Annotations[constants.HeadOfLinePoliciesAnnotation] = annotation
+	}
+}
+
+// WithLegacyBridge sets the annotation marking the KafkaChannel as a bridge for legacy (non-CloudEvent) records.
+func WithLegacyBridge(annotation string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Annotations == nil {
+			kafkachannel.Annotations = make(map[string]string)
+		}
+		kafkachannel.Annotations[constants.LegacyBridgeAnnotation] = annotation
+	}
+}
+
+// WithDeliveryGuarantee sets the annotation specifying the end-to-end delivery guarantee of the KafkaChannel's events.
+func WithDeliveryGuarantee(annotation string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Annotations == nil {
+			kafkachannel.Annotations = make(map[string]string)
+		}
+		kafkachannel.Annotations[v1beta1.DeliveryGuaranteeAnnotationKey] = annotation
+	}
+}
+
+// WithDispatcherShards sets the annotation specifying the number of Dispatcher shards across which subscriptions are assigned.
+func WithDispatcherShards(annotation string) KafkaChannelOption {
+	return func(kafkachannel *v1beta1.KafkaChannel) {
+		if kafkachannel.Annotations == nil {
+			kafkachannel.Annotations = make(map[string]string)
+		}
+		kafkachannel.Annotations[sharding.DispatcherShardsAnnotation] = annotation
+	}
+}
+
+// NewSubscriberTLSSecret creates a Secret labelled as containing the TLS client certificate for the specified subscriber.
+func NewSubscriberTLSSecret(name string, namespace string, uid types.UID) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{constants.SubscriberTLSSecretLabel: string(uid)},
+		},
+	}
+}
@@ -18,13 +18,17 @@ package testing
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/Shopify/sarama"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
@@ -46,6 +50,7 @@ type MockMessageDispatcher struct {
 	expectedRetryConfig    *kncloudevents.RetryConfig
 	message                cloudevents.Message
 	response               error
+	httpResponses          []*http.Response
 }
 
 // Mock MessageDispatcher Constructor
@@ -69,6 +74,12 @@ func NewMockMessageDispatcher(t *testing.T,
 	}
 }
 
+// Specify The HTTP Responses (nil For No Response) To Be Checked With The RetryConfig's CheckRetry, In Order, On Dispatch
+func (m *MockMessageDispatcher) WithHTTPResponses(httpResponses ...*http.Response) *MockMessageDispatcher {
+	m.httpResponses = httpResponses
+	return m
+}
+
 func (m *MockMessageDispatcher) DispatchMessage(ctx context.Context, message cloudevents.Message, additionalHeaders http.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL) error {
 	panic("implement me")
 }
@@ -87,6 +98,17 @@ func (m *MockMessageDispatcher) DispatchMessageWithRetries(ctx context.Context,
 	// Track The Received Message
 	m.message = message
 
+	// Check The Desired HTTP Responses As The Real MessageDispatcher Would
+	for _, httpResponse := range m.httpResponses {
+		if retryConfig.CheckRetry != nil {
+			var err error
+			if httpResponse == nil {
+				err = fmt.Errorf("connection refused")
+			}
+			_, _ = retryConfig.CheckRetry(ctx, httpResponse, err)
+		}
+	}
+
 	// Return The Desired Error Response
 	return m.response
 }
@@ -104,25 +126,30 @@ var _ sarama.ConsumerGroupSession = &MockConsumerGroupSession{}
 
 // Define The Mock ConsumerGroupSession
 type MockConsumerGroupSession struct {
-	t               *testing.T
-	MarkMessageChan chan *sarama.ConsumerMessage
+	t                 *testing.T
+	MarkMessageChan   chan *sarama.ConsumerMessage
+	CommitChan        chan bool
+	ClaimedPartitions map[string][]int32 // Returned By Claims()
+	Member            string             // Returned By MemberID()
+	Generation        int32              // Returned By GenerationID()
+	Ctx               context.Context    // Returned By Context() (Background If Nil)
 }
 
 // Mock ConsumerGroupSession Constructor
 func NewMockConsumerGroupSession(t *testing.T) MockConsumerGroupSession {
-	return MockConsumerGroupSession{t: t, MarkMessageChan: make(chan *sarama.ConsumerMessage)}
+	return MockConsumerGroupSession{t: t, MarkMessageChan: make(chan *sarama.ConsumerMessage), CommitChan: make(chan bool)}
 }
 
 func (m MockConsumerGroupSession) Claims() map[string][]int32 {
-	panic("implement me")
+	return m.ClaimedPartitions
 }
 
 func (m MockConsumerGroupSession) MemberID() string {
-	panic("implement me")
+	return m.Member
 }
 
 func (m MockConsumerGroupSession) GenerationID() int32 {
-	panic("implement me")
+	return m.Generation
 }
 
 func (m MockConsumerGroupSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
@@ -139,11 +166,14 @@ func (m MockConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metad
 }
 
 func (m MockConsumerGroupSession) Context() context.Context {
-	panic("implement me")
+	if m.Ctx == nil {
+		return context.Background()
+	}
+	return m.Ctx
 }
 
 func (m MockConsumerGroupSession) Commit() {
-	panic("implement me")
+	m.CommitChan <- true
 }
 
 //
@@ -183,3 +213,152 @@ func (m MockConsumerGroupClaim) HighWaterMarkOffset() int64 {
 func (m MockConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 	return m.MessageChan
 }
+
+//
+// Mock StatsReporter Implementation
+//
+
+// Verify The Mock StatsReporter Implements The Interface
+var _ metrics.StatsReporter = &MockStatsReporter{}
+
+// Define The Mock StatsReporter
+type MockStatsReporter struct {
+	DeserializationFailures map[string]int   // Count Of Reported Deserialization Failures Keyed By Policy
+	AuthenticationFailures  int              // Count Of Reported Authentication Failures
+	ConsumedBytes           map[string]int64 // Total Reported Consumed Bytes Keyed By Topic
+	DecompressionErrors     map[string]int   // Count Of Reported Decompression Errors Keyed By Policy
+	OversizedMessages       map[string]int   // Count Of Reported Oversized Messages Keyed By Policy
+	consumeRetriesLock      sync.Mutex       // Guards ConsumeRetries (Reported Asynchronously From The Consume Loops)
+	consumeRetries          map[string]int   // Count Of Reported Consume Retries Keyed By Reason
+	replyFailuresLock       sync.Mutex       // Guards ReplyFailures (Reported From The HTTP Transports Of Concurrent Deliveries)
+	replyFailures           map[string]int   // Count Of Reported Reply Failures Keyed By Policy
+	groupErrorLogDrops      int64            // Count Of Reported ConsumerGroup Error Log Drops (Reported Asynchronously By The Error Processing)
+	consumerLagsLock        sync.Mutex       // Guards ConsumerLags (Reported Asynchronously By The Consumer Lag Polling)
+	consumerLags            map[string]int64 // Last Reported Consumer Lag Keyed By ConsumerGroup ID
+	dispatchesLock          sync.Mutex       // Guards Dispatches (Reported From The Handlers Of Concurrent ConsumerGroups)
+	dispatches              map[string]int   // Count Of Reported Dispatches Keyed By Subscriber UID, Status Class & Outcome
+}
+
+// Mock StatsReporter Constructor
+func NewMockStatsReporter() *MockStatsReporter {
+	return &MockStatsReporter{DeserializationFailures: make(map[string]int), ConsumedBytes: make(map[string]int64), DecompressionErrors: make(map[string]int), OversizedMessages: make(map[string]int), consumeRetries: make(map[string]int), replyFailures: make(map[string]int), consumerLags: make(map[string]int64), dispatches: make(map[string]int)}
+}
+
+func (m *MockStatsReporter) Report(_ map[string]map[string]interface{}) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportDeserializationFailure(_ string, policyName string) {
+	m.DeserializationFailures[policyName]++
+}
+
+func (m *MockStatsReporter) ReportAuthenticationFailure() {
+	m.AuthenticationFailures++
+}
+
+func (m *MockStatsReporter) ReportProducedBytes(_ string, _ int64) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportConsumedBytes(topicName string, bytes int64) {
+	m.ConsumedBytes[topicName] += bytes
+}
+
+func (m *MockStatsReporter) ReportDecompressionError(_ string, policyName string) {
+	m.DecompressionErrors[policyName]++
+}
+
+func (m *MockStatsReporter) ReportStaleEventRejection(_ string) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportOversizedMessage(_ string, policyName string) {
+	m.OversizedMessages[policyName]++
+}
+
+func (m *MockStatsReporter) ReportHeartbeat(_ string, _ bool) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportConsumeRetry(_ string, reasonName string) {
+	m.consumeRetriesLock.Lock()
+	defer m.consumeRetriesLock.Unlock()
+	m.consumeRetries[reasonName]++
+}
+
+func (m *MockStatsReporter) ReportSpoolDepth(_ int64) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportReplyFailure(_ string, policyName string) {
+	m.replyFailuresLock.Lock()
+	defer m.replyFailuresLock.Unlock()
+	m.replyFailures[policyName]++
+}
+
+func (m *MockStatsReporter) ReportAuditDrop(_ string, _ string) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportProduceLatencySloExceeded(_ string) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportGroupErrorLogDrop(_ string) {
+	atomic.AddInt64(&m.groupErrorLogDrops, 1)
+}
+
+func (m *MockStatsReporter) ReportRateLimitRejection(_ string, _ string) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportConsumerLag(_ string, groupId string, lag int64) {
+	m.consumerLagsLock.Lock()
+	defer m.consumerLagsLock.Unlock()
+	m.consumerLags[groupId] = lag
+}
+
+func (m *MockStatsReporter) ReportDispatch(_ string, subscriberUID string, statusClassName string, success bool) {
+	m.dispatchesLock.Lock()
+	defer m.dispatchesLock.Unlock()
+	m.dispatches[dispatchKey(subscriberUID, statusClassName, success)]++
+}
+
+// Get The Count Of Reported Consume Retries For The Specified Reason
+func (m *MockStatsReporter) ConsumeRetries(reasonName string) int {
+	m.consumeRetriesLock.Lock()
+	defer m.consumeRetriesLock.Unlock()
+	return m.consumeRetries[reasonName]
+}
+
+// Get The Count Of Reported Reply Failures For The Specified Policy
+func (m *MockStatsReporter) ReplyFailures(policyName string) int {
+	m.replyFailuresLock.Lock()
+	defer m.replyFailuresLock.Unlock()
+	return m.replyFailures[policyName]
+}
+
+// Get The Count Of Reported ConsumerGroup Error Log Drops
+func (m *MockStatsReporter) GroupErrorLogDrops() int64 {
+	return atomic.LoadInt64(&m.groupErrorLogDrops)
+}
+
+// Get The Last Reported Consumer Lag For The Specified ConsumerGroup (And Whether Any Was Reported)
+func (m *MockStatsReporter) ConsumerLag(groupId string) (int64, bool) {
+	m.consumerLagsLock.Lock()
+	defer m.consumerLagsLock.Unlock()
+	lag, ok := m.consumerLags[groupId]
+	return lag, ok
+}
+
+// Get The Count Of Reported Dispatches For The Specified Subscriber UID, Status Class & Outcome
+func (m *MockStatsReporter) Dispatches(subscriberUID string, statusClassName string, success bool) int {
+	m.dispatchesLock.Lock()
+	defer m.dispatchesLock.Unlock()
+	return m.dispatches[dispatchKey(subscriberUID, statusClassName, success)]
+}
+
+// Get The Key Of The Reported Dispatches For The Specified Subscriber UID, Status Class & Outcome
+func dispatchKey(subscriberUID string, statusClassName string, success bool) string {
+	return fmt.Sprintf("%s/%s/%t", subscriberUID, statusClassName, success)
+}
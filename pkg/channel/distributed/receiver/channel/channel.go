@@ -23,6 +23,8 @@ import (
 	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sclientcmd "k8s.io/client-go/tools/clientcmd"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkainformers "knative.dev/eventing-kafka/pkg/client/informers/externalversions"
@@ -122,6 +124,33 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	return nil
 }
 
+// Get The Compression Codec Override Annotated On The Specified KafkaChannel (Empty If None Or Unknown Channel)
+func CompressionCodec(channelReference eventingChannel.ChannelReference) string {
+	return channelAnnotation(channelReference, constants.CompressionAnnotation)
+}
+
+// Get The Delivery Guarantee Annotated On The Specified KafkaChannel (Empty If None Or Unknown Channel)
+func DeliveryGuarantee(channelReference eventingChannel.ChannelReference) string {
+	return channelAnnotation(channelReference, kafkav1beta1.DeliveryGuaranteeAnnotationKey)
+}
+
+// Get The Producer Idempotence Override Annotated On The Specified KafkaChannel (Empty If None Or Unknown Channel)
+func Idempotence(channelReference eventingChannel.ChannelReference) string {
+	return channelAnnotation(channelReference, constants.IdempotenceAnnotation)
+}
+
+// Get The Specified Annotation Of The Specified KafkaChannel (Empty If None Or Unknown Channel)
+func channelAnnotation(channelReference eventingChannel.ChannelReference, annotation string) string {
+	if kafkaChannelLister == nil {
+		return ""
+	}
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		return ""
+	}
+	return kafkaChannel.Annotations[annotation]
+}
+
 // Close The Channel Lister (Stop Processing)
 func Close() {
 	if stopChan != nil {
@@ -22,10 +22,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -107,3 +111,120 @@ func TestClose(t *testing.T) {
 	// Verify stopChan Was Closed
 	assert.False(t, ok)
 }
+
+// Test The CompressionCodec() Functionality
+func TestCompressionCodec(t *testing.T) {
+
+	// Test Data
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	annotatedChannel := receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue)
+	annotatedChannel.Annotations = map[string]string{constants.CompressionAnnotation: "zstd"}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		channel *kafkav1beta1.KafkaChannel
+		want    string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unknown Channel", channel: nil, want: ""},
+		{name: "Channel Without Annotation", channel: receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue), want: ""},
+		{name: "Channel With Annotation", channel: annotatedChannel, want: "zstd"},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Mock The Package Level KafkaChannel Lister With The TestCase's KafkaChannel
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if testCase.channel != nil {
+				assert.Nil(t, indexer.Add(testCase.channel))
+			}
+			kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+			// Perform The Test & Verify The Results
+			assert.Equal(t, testCase.want, CompressionCodec(channelReference))
+		})
+	}
+}
+
+// Test The DeliveryGuarantee() Functionality
+func TestDeliveryGuarantee(t *testing.T) {
+
+	// Test Data
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	annotatedChannel := receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue)
+	annotatedChannel.Annotations = map[string]string{kafkav1beta1.DeliveryGuaranteeAnnotationKey: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		channel *kafkav1beta1.KafkaChannel
+		want    string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unknown Channel", channel: nil, want: ""},
+		{name: "Channel Without Annotation", channel: receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue), want: ""},
+		{name: "Channel With Annotation", channel: annotatedChannel, want: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Mock The Package Level KafkaChannel Lister With The TestCase's KafkaChannel
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if testCase.channel != nil {
+				assert.Nil(t, indexer.Add(testCase.channel))
+			}
+			kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+			// Perform The Test & Verify The Results
+			assert.Equal(t, testCase.want, DeliveryGuarantee(channelReference))
+		})
+	}
+}
+
+// Test The Idempotence() Functionality
+func TestIdempotence(t *testing.T) {
+
+	// Test Data
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	annotatedChannel := receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue)
+	annotatedChannel.Annotations = map[string]string{constants.IdempotenceAnnotation: "false"}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		channel *kafkav1beta1.KafkaChannel
+		want    string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unknown Channel", channel: nil, want: ""},
+		{name: "Channel Without Annotation", channel: receivertesting.CreateKafkaChannel(receivertesting.ChannelName, receivertesting.ChannelNamespace, corev1.ConditionTrue), want: ""},
+		{name: "Channel With Annotation", channel: annotatedChannel, want: "false"},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Mock The Package Level KafkaChannel Lister With The TestCase's KafkaChannel
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if testCase.channel != nil {
+				assert.Nil(t, indexer.Add(testCase.channel))
+			}
+			kafkaChannelLister = kafkalisters.NewKafkaChannelLister(indexer)
+
+			// Perform The Test & Verify The Results
+			assert.Equal(t, testCase.want, Idempotence(channelReference))
+		})
+	}
+}
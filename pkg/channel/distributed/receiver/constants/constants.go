@@ -24,8 +24,62 @@ const (
 
 	MetricsInterval = 5 * time.Second
 
+	HttpPort = 8080 // Must Match The Port Used By The Knative Eventing MessageReceiver
+
 	ExtensionKeyPartitionKey = "partitionkey"
 
+	// Policies For Handling The CloudEvent "partitionkey" Extension Of Received Events
+	PartitionKeyPolicyHonor   = "honor"  // Use The Extension (If Present) As The Kafka Record Key
+	PartitionKeyPolicyIgnore  = "ignore" // Produce Records Without A Key (The Extension Is Still Carried As A Header)
+	DefaultPartitionKeyPolicy = PartitionKeyPolicyHonor
+
+	// Policies For Producing Received Events Without Data (Which Would Otherwise Be Produced With A Null Value)
+	EmptyDataPolicyEmpty   = "empty"  // Produce A Zero-Length Value
+	EmptyDataPolicyNull    = "null"   // Produce A Null Value (A Tombstone On Compacted Topics)
+	EmptyDataPolicyReject  = "reject" // Refuse To Produce The Event
+	DefaultEmptyDataPolicy = EmptyDataPolicyEmpty
+
+	// Policies For Handling Events Produced To A KafkaChannel Whose Kafka Topic Does Not Exist (e.g. Auto-Create Disabled)
+	UnknownTopicPolicyNotFound    = "notfound"    // Respond With A 404 (Not Found) Describing The Missing Topic
+	UnknownTopicPolicyUnavailable = "unavailable" // Respond With A 503 (Service Unavailable) So That Senders Retry Later
+	UnknownTopicPolicyRetry       = "retry"       // Retry Producing For A Short While (The Topic May Be Being Created) Before Responding 503
+	UnknownTopicPolicyFail        = "fail"        // Fail Fast With A 500 (Internal Server Error) As For Any Other Produce Failure
+	DefaultUnknownTopicPolicy     = UnknownTopicPolicyNotFound
+
+	// Duration & Interval Of Retries With The "retry" UnknownTopicPolicy (If Not Configured)
+	DefaultUnknownTopicRetryTimeoutMillis  = 5000
+	DefaultUnknownTopicRetryIntervalMillis = 500
+
+	// Maximum Total Size Of The Messages Spooled To Local Disk When Producing Fails (If Not Configured)
+	DefaultSpoolMaxBytes = 100 * 1024 * 1024
+
+	// Interval Between Attempts To Replay The Spooled Messages To Kafka (If Not Configured)
+	DefaultSpoolReplayIntervalMillis = 5000
+
+	// Policies For Ordering The Kafka Messages Produced For Concurrently Received Events
+	ProduceOrderingThroughput = "throughput" // Pipeline Requests Freely (A Retried Message May Land After Later Messages With The Same Key)
+	ProduceOrderingPartition  = "partition"  // Limit Sarama To A Single In-Flight Request Per Broker (Net.MaxOpenRequests=1)
+	ProduceOrderingKey        = "key"        // Serialize Messages With The Same Topic & Key (Each Waits For The Previous One's Delivery Report)
+	DefaultProduceOrdering    = ProduceOrderingThroughput
+
+	// Maximum Number Of Produced Records Queued For Delivery To The Audit Sink Before Further Records Are Dropped (If Not Configured)
+	DefaultAuditQueueSize = 1000
+
+	// Timeout Of Each Delivery Of A Produced Record To The Audit Sink (If Not Configured)
+	DefaultAuditTimeoutMillis = 5000
+
+	// HTTP Response Header Reporting The Time Taken To Produce The Event To Kafka (In Milliseconds)
+	ProduceLatencyHeader = "Ek-Produce-Latency-Ms"
+
+	// KafkaChannel Annotation Overriding The Sarama Producer.Compression Codec (e.g. "gzip") For Its Produced Messages
+	CompressionAnnotation = "eventing-kafka.knative.dev/compression"
+
+	// KafkaChannel Annotation Enabling Or Disabling ("true" / "false") The Sarama Producer.Idempotent Setting For Its Produced Messages
+	IdempotenceAnnotation = "eventing-kafka.knative.dev/idempotence"
+
+	// CloudEvent Type Of The Heartbeat Events (If Not Configured)
+	DefaultHeartbeatEventType = "dev.knative.kafka.heartbeat"
+
 	KafkaHeaderKeyContentType = "content-type"
 
 	CeKafkaHeaderKeySpecVersion  = "ce_specversion"
@@ -19,6 +19,7 @@ package env
 import (
 	"go.uber.org/zap"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 )
 
 // The Environment Struct
@@ -35,9 +36,17 @@ type Environment struct {
 	KafkaBrokers string // Required
 	ServiceName  string // Required
 
+	// Pod Configuration
+	PodName string // Optional
+
 	// Kafka Authorization
-	KafkaUsername string // Optional
-	KafkaPassword string // Optional
+	KafkaUsername      string // Optional
+	KafkaPassword      string // Optional
+	KafkaSaslMechanism string // Optional
+
+	// Schema Registry Authorization
+	SchemaRegistryUsername string // Optional
+	SchemaRegistryPassword string // Optional
 }
 
 // Get The Environment
@@ -67,6 +76,13 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	// Verify The HTTP, Metrics & Health Ports Are Distinct (Before Attempting To Bind Any Of Them)
+	err = env.VerifyDistinctPorts(map[string]int{"HttpPort": constants.HttpPort, "MetricsPort": environment.MetricsPort, "HealthPort": environment.HealthPort})
+	if err != nil {
+		logger.Error("Invalid Port Configuration", zap.Error(err))
+		return nil, err
+	}
+
 	// Get The Required K8S KafkaBrokers Config Value
 	environment.KafkaBrokers, err = env.GetRequiredConfigValue(logger, env.KafkaBrokerEnvVarKey)
 	if err != nil {
@@ -79,17 +95,32 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 		return nil, err
 	}
 
+	// Get The Optional PodName Config Value (Used To Tag Produced Messages With Their Provenance)
+	environment.PodName = env.GetOptionalConfigValue(logger, env.PodNameEnvVarKey, "")
+
 	// Get The Optional KafkaUsername Config Value
 	environment.KafkaUsername = env.GetOptionalConfigValue(logger, env.KafkaUsernameEnvVarKey, "")
 
 	// Get The Optional KafkaPassword Config Value
 	environment.KafkaPassword = env.GetOptionalConfigValue(logger, env.KafkaPasswordEnvVarKey, "")
 
-	// Clone The Environment & Mask The Password For Safe Logging
+	// Get The Optional KafkaSaslMechanism Config Value
+	environment.KafkaSaslMechanism = env.GetOptionalConfigValue(logger, env.KafkaSaslMechanismEnvVarKey, "")
+
+	// Get The Optional SchemaRegistryUsername Config Value
+	environment.SchemaRegistryUsername = env.GetOptionalConfigValue(logger, env.SchemaRegistryUsernameEnvVarKey, "")
+
+	// Get The Optional SchemaRegistryPassword Config Value
+	environment.SchemaRegistryPassword = env.GetOptionalConfigValue(logger, env.SchemaRegistryPasswordEnvVarKey, "")
+
+	// Clone The Environment & Mask The Passwords For Safe Logging
 	safeEnvironment := *environment
 	if len(safeEnvironment.KafkaPassword) > 0 {
 		safeEnvironment.KafkaPassword = "*************"
 	}
+	if len(safeEnvironment.SchemaRegistryPassword) > 0 {
+		safeEnvironment.SchemaRegistryPassword = "*************"
+	}
 
 	// Log The Receiver Configuration Loaded From Environment Variables
 	logger.Info("Environment Variables", zap.Any("Environment", safeEnvironment))
@@ -35,8 +35,13 @@ const (
 	healthPort    = "1234"
 	kafkaBrokers  = "TestKafkaBrokers"
 	serviceName   = "TestServiceName"
+	podName       = "TestPodName"
 	kafkaUsername = "TestKafkaUsername"
 	kafkaPassword = "TestKafkaPassword"
+	saslMechanism = "SCRAM-SHA-512"
+
+	schemaRegistryUsername = "TestSchemaRegistryUsername"
+	schemaRegistryPassword = "TestSchemaRegistryPassword"
 )
 
 // Define The TestCase Struct
@@ -47,8 +52,12 @@ type TestCase struct {
 	healthPort    string
 	kafkaBrokers  string
 	serviceName   string
+	podName       string
 	kafkaUsername string
 	kafkaPassword string
+	saslMechanism string
+	registryUser  string
+	registryPass  string
 	expectedError error
 }
 
@@ -63,6 +72,19 @@ func TestGetEnvironment(t *testing.T) {
 	testCase := getValidTestCase("Valid Complete Config")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - No PodName")
+	testCase.podName = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - No SASL Mechanism")
+	testCase.saslMechanism = ""
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Valid Config - No Schema Registry Auth")
+	testCase.registryUser = ""
+	testCase.registryPass = ""
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - MetricsDomain")
 	testCase.metricsDomain = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(env.MetricsDomainEnvVarKey)
@@ -88,6 +110,21 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedError = getInvalidIntegerEnvironmentVariableError(testCase.healthPort, env.HealthPortEnvVarKey)
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Invalid Config - HealthPort Conflicts With MetricsPort")
+	testCase.healthPort = metricsPort
+	testCase.expectedError = fmt.Errorf("port conflict: HealthPort and MetricsPort are both configured as port %s", metricsPort)
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - MetricsPort Conflicts With HttpPort")
+	testCase.metricsPort = "8080"
+	testCase.expectedError = fmt.Errorf("port conflict: HttpPort and MetricsPort are both configured as port 8080")
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - HealthPort Conflicts With HttpPort")
+	testCase.healthPort = "8080"
+	testCase.expectedError = fmt.Errorf("port conflict: HealthPort and HttpPort are both configured as port 8080")
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Missing Required Config - KafkaBrokers")
 	testCase.kafkaBrokers = ""
 	testCase.expectedError = getMissingRequiredEnvironmentVariableError(env.KafkaBrokerEnvVarKey)
@@ -108,8 +145,12 @@ func TestGetEnvironment(t *testing.T) {
 		assertSetenvNonempty(t, env.HealthPortEnvVarKey, testCase.healthPort)
 		assertSetenv(t, env.KafkaBrokerEnvVarKey, testCase.kafkaBrokers)
 		assertSetenv(t, env.ServiceNameEnvVarKey, testCase.serviceName)
+		assertSetenvNonempty(t, env.PodNameEnvVarKey, testCase.podName)
 		assertSetenv(t, env.KafkaUsernameEnvVarKey, testCase.kafkaUsername)
 		assertSetenv(t, env.KafkaPasswordEnvVarKey, testCase.kafkaPassword)
+		assertSetenvNonempty(t, env.KafkaSaslMechanismEnvVarKey, testCase.saslMechanism)
+		assertSetenvNonempty(t, env.SchemaRegistryUsernameEnvVarKey, testCase.registryUser)
+		assertSetenvNonempty(t, env.SchemaRegistryPasswordEnvVarKey, testCase.registryPass)
 
 		// Perform The Test
 		environment, err := GetEnvironment(logger)
@@ -123,8 +164,12 @@ func TestGetEnvironment(t *testing.T) {
 			assert.Equal(t, testCase.healthPort, strconv.Itoa(environment.HealthPort))
 			assert.Equal(t, testCase.kafkaBrokers, environment.KafkaBrokers)
 			assert.Equal(t, testCase.serviceName, environment.ServiceName)
+			assert.Equal(t, testCase.podName, environment.PodName)
 			assert.Equal(t, testCase.kafkaUsername, environment.KafkaUsername)
 			assert.Equal(t, testCase.kafkaPassword, environment.KafkaPassword)
+			assert.Equal(t, testCase.saslMechanism, environment.KafkaSaslMechanism)
+			assert.Equal(t, testCase.registryUser, environment.SchemaRegistryUsername)
+			assert.Equal(t, testCase.registryPass, environment.SchemaRegistryPassword)
 
 		} else {
 			assert.Equal(t, testCase.expectedError, err)
@@ -153,8 +198,12 @@ func getValidTestCase(name string) TestCase {
 		healthPort:    healthPort,
 		kafkaBrokers:  kafkaBrokers,
 		serviceName:   serviceName,
+		podName:       podName,
 		kafkaUsername: kafkaUsername,
 		kafkaPassword: kafkaPassword,
+		saslMechanism: saslMechanism,
+		registryUser:  schemaRegistryUsername,
+		registryPass:  schemaRegistryPassword,
 		expectedError: nil,
 	}
 }
@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/types"
+	"go.uber.org/zap"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+)
+
+// CloudEvent "time" Attribute As Represented In Binary & Structured Content Mode HTTP Requests
+const (
+	binaryTimeHeader          = "Ce-Time"
+	structuredContentTypeJson = "application/cloudevents+json"
+)
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var now = time.Now
+
+//
+// HTTP Handler Which Rejects CloudEvents Older Than A Maximum Age Before Delegating To The Next Handler
+//
+// This protects the Kafka Topics from events whose "time" attribute is implausibly old, whether due to producer
+// clock skew or the replay of previously captured requests.  Such events are rejected with a 400 (Bad Request)
+// describing the reason, and are counted in the stale event rejection metric.  Events without a "time" attribute
+// (which is optional in the CloudEvents specification), batched events, and requests which cannot be parsed are
+// passed through unchecked for the next handler to process as usual.
+//
+type Handler struct {
+	logger        *zap.Logger
+	maxAge        time.Duration
+	statsReporter metrics.StatsReporter
+	next          http.Handler
+}
+
+// Verify The Handler Implements The http.Handler Interface
+var _ http.Handler = &Handler{}
+
+// Handler Constructor
+func NewHandler(logger *zap.Logger, maxAge time.Duration, statsReporter metrics.StatsReporter, next http.Handler) *Handler {
+	return &Handler{logger: logger, maxAge: maxAge, statsReporter: statsReporter, next: next}
+}
+
+// Reject Stale CloudEvents Or Otherwise Delegate To The Next Handler
+func (h *Handler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+
+	// Extract The CloudEvent's Time (If Any)
+	eventTime, ok := h.eventTime(request)
+	if ok {
+
+		// Reject The Event If It Is Older Than The Maximum Age
+		age := now().Sub(eventTime)
+		if age > h.maxAge {
+			reason := fmt.Sprintf("event time '%s' is older than the maximum event age of %s", eventTime.Format(time.RFC3339Nano), h.maxAge)
+			h.logger.Warn("Rejecting Stale CloudEvent", zap.String("Host", request.Host), zap.Duration("Age", age), zap.Duration("MaxAge", h.maxAge))
+			h.statsReporter.ReportStaleEventRejection(h.topicName(request.Host))
+			http.Error(response, reason, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Delegate All Other Requests To The Next Handler
+	h.next.ServeHTTP(response, request)
+}
+
+// Get The CloudEvent "time" Attribute Of The Specified Binary Or Structured (JSON) Content Mode Request
+func (h *Handler) eventTime(request *http.Request) (time.Time, bool) {
+
+	// Binary Content Mode Carries The Time In A Header
+	timeString := request.Header.Get(binaryTimeHeader)
+
+	// Structured Content Mode Carries The Time In The Body (Which Is Buffered For The Next Handler)
+	if len(timeString) <= 0 && strings.HasPrefix(request.Header.Get("Content-Type"), structuredContentTypeJson) && request.Body != nil {
+		body, err := ioutil.ReadAll(request.Body)
+		_ = request.Body.Close()
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return time.Time{}, false
+		}
+		structuredEvent := struct {
+			Time string `json:"time"`
+		}{}
+		if json.Unmarshal(body, &structuredEvent) != nil {
+			return time.Time{}, false
+		}
+		timeString = structuredEvent.Time
+	}
+
+	// Events Without A Time Attribute Bypass The Check
+	if len(timeString) <= 0 {
+		return time.Time{}, false
+	}
+
+	// Invalid Times Are Left For The Next Handler To Reject
+	timestamp, err := types.ParseTimestamp(timeString)
+	if err != nil || timestamp == nil {
+		h.logger.Debug("Unable To Parse CloudEvent Time - Skipping Event Age Check", zap.String("Time", timeString), zap.Error(err))
+		return time.Time{}, false
+	}
+	return timestamp.Time, true
+}
+
+// Get The Kafka Topic Name For The KafkaChannel Identified By The Request Host (Empty If Unknown)
+func (h *Handler) topicName(host string) string {
+	channelReference, err := eventingchannel.ParseChannel(host)
+	if err != nil {
+		return ""
+	}
+	channelReference.Name = kafkautil.TrimKafkaChannelServiceNameSuffix(channelReference.Name)
+	return util.TopicName(channelReference)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testHost   = receivertesting.ChannelName + "-kn-channel." + receivertesting.ChannelNamespace + ".svc.cluster.local"
+	testMaxAge = 5 * time.Minute
+)
+
+// Test The NewHandler() Functionality
+func TestNewHandler(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	statsReporter := receivertesting.NewMockStatsReporter()
+	next := http.NotFoundHandler()
+	handler := NewHandler(logger, testMaxAge, statsReporter, next)
+	assert.NotNil(t, handler)
+	assert.Equal(t, logger, handler.logger)
+	assert.Equal(t, testMaxAge, handler.maxAge)
+	assert.Equal(t, statsReporter, handler.statsReporter)
+	assert.NotNil(t, handler.next)
+}
+
+// Test The Handler's ServeHTTP() Functionality
+func TestServeHTTP(t *testing.T) {
+
+	// Mock The Current Time
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Test Data
+	freshTime := mockNow.Add(-time.Minute).Format(time.RFC3339Nano)
+	boundaryTime := mockNow.Add(-testMaxAge).Format(time.RFC3339Nano)
+	staleTime := mockNow.Add(-testMaxAge - time.Nanosecond).Format(time.RFC3339Nano)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name         string
+		only         bool
+		headers      map[string]string
+		body         string
+		wantStatus   int
+		wantNext     bool
+		wantRejected bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "Binary Fresh Event",
+			headers:    map[string]string{"Ce-Time": freshTime},
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:       "Binary Event At Max Age",
+			headers:    map[string]string{"Ce-Time": boundaryTime},
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:         "Binary Event Just Over Max Age",
+			headers:      map[string]string{"Ce-Time": staleTime},
+			wantStatus:   http.StatusBadRequest,
+			wantRejected: true,
+		},
+		{
+			name:       "Binary Event Without Time",
+			headers:    map[string]string{"Ce-Id": "123"},
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:       "Binary Event With Invalid Time",
+			headers:    map[string]string{"Ce-Time": "yesterday"},
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:       "Structured Fresh Event",
+			headers:    map[string]string{"Content-Type": "application/cloudevents+json; charset=UTF-8"},
+			body:       `{"specversion":"1.0","id":"123","time":"` + freshTime + `"}`,
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:       "Structured Event At Max Age",
+			headers:    map[string]string{"Content-Type": "application/cloudevents+json"},
+			body:       `{"specversion":"1.0","id":"123","time":"` + boundaryTime + `"}`,
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:         "Structured Event Just Over Max Age",
+			headers:      map[string]string{"Content-Type": "application/cloudevents+json"},
+			body:         `{"specversion":"1.0","id":"123","time":"` + staleTime + `"}`,
+			wantStatus:   http.StatusBadRequest,
+			wantRejected: true,
+		},
+		{
+			name:       "Structured Event Without Time",
+			headers:    map[string]string{"Content-Type": "application/cloudevents+json"},
+			body:       `{"specversion":"1.0","id":"123"}`,
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+		{
+			name:       "Structured Event With Invalid Body",
+			headers:    map[string]string{"Content-Type": "application/cloudevents+json"},
+			body:       `not json`,
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+		},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Filtered TestCases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Next Handler Which Records The Request Body It Received
+			nextCalled := false
+			nextBody := ""
+			next := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				nextCalled = true
+				body, err := ioutil.ReadAll(request.Body)
+				assert.Nil(t, err)
+				nextBody = string(body)
+				response.WriteHeader(http.StatusAccepted)
+			})
+
+			// Create The Handler To Test
+			statsReporter := receivertesting.NewMockStatsReporter()
+			handler := NewHandler(logtesting.TestLogger(t).Desugar(), testMaxAge, statsReporter, next)
+
+			// Create The Request
+			request := httptest.NewRequest(http.MethodPost, "http://"+testHost+"/", strings.NewReader(testCase.body))
+			for key, value := range testCase.headers {
+				request.Header.Set(key, value)
+			}
+			response := httptest.NewRecorder()
+
+			// Perform The Test
+			handler.ServeHTTP(response, request)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantStatus, response.Code)
+			assert.Equal(t, testCase.wantNext, nextCalled)
+			if testCase.wantNext {
+				assert.Equal(t, testCase.body, nextBody)
+			}
+			if testCase.wantRejected {
+				assert.Contains(t, response.Body.String(), "older than the maximum event age of 5m0s")
+				assert.Equal(t, map[string]int{receivertesting.TopicName: 1}, statsReporter.StaleEventRejections)
+			} else {
+				assert.Len(t, statsReporter.StaleEventRejections, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package heartbeat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+)
+
+// Function Which Produces The Specified Binding Message To The Referenced KafkaChannel's Topic
+type ProduceFunc func(ctx context.Context, channelReference eventingchannel.ChannelReference, message binding.Message) error
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var now = time.Now
+
+//
+// Periodic Producer Of Synthetic Heartbeat CloudEvents For End-To-End Pipeline Monitoring
+//
+// A heartbeat event is produced to the target KafkaChannel every interval, so that downstream monitors
+// subscribed to the channel can verify the whole path (Receiver -> Kafka -> Dispatcher -> Subscriber) is
+// flowing even when there is no real traffic.  Each event carries an incrementing sequence number, allowing
+// monitors to detect missed heartbeats, and every attempt is counted in the heartbeat metric by result.
+//
+type Heartbeat struct {
+	logger           *zap.Logger
+	channelReference eventingchannel.ChannelReference
+	interval         time.Duration
+	eventType        string
+	source           string
+	produce          ProduceFunc
+	statsReporter    metrics.StatsReporter
+	sequence         int64
+}
+
+// Heartbeat Constructor (Target Is The "namespace/name" Of The KafkaChannel To Produce Heartbeat Events To)
+func NewHeartbeat(logger *zap.Logger, target string, interval time.Duration, eventType string, source string, produce ProduceFunc, statsReporter metrics.StatsReporter) (*Heartbeat, error) {
+
+	// Validate The Interval
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid heartbeat interval '%s' - must be positive", interval)
+	}
+
+	// Parse The Target KafkaChannel
+	namespace, name, err := cache.SplitMetaNamespaceKey(target)
+	if err != nil || len(namespace) <= 0 || len(name) <= 0 {
+		return nil, fmt.Errorf("invalid heartbeat target '%s' - must be the 'namespace/name' of a KafkaChannel", target)
+	}
+	channelReference := eventingchannel.ChannelReference{Namespace: namespace, Name: name}
+
+	// Create & Return The Heartbeat
+	return &Heartbeat{
+		logger:           logger.With(zap.String("Channel", channelReference.String()), zap.String("Topic", util.TopicName(channelReference))),
+		channelReference: channelReference,
+		interval:         interval,
+		eventType:        eventType,
+		source:           source,
+		produce:          produce,
+		statsReporter:    statsReporter,
+	}, nil
+}
+
+// Produce A Heartbeat Event Every Interval Until The Context Is Done (Blocking)
+func (h *Heartbeat) Start(ctx context.Context) {
+
+	h.logger.Info("Starting Heartbeat", zap.Duration("Interval", h.interval), zap.String("Type", h.eventType))
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info("Stopping Heartbeat")
+			return
+		case <-ticker.C:
+			_ = h.beat(ctx)
+		}
+	}
+}
+
+// Produce A Single Heartbeat Event & Report The Result
+func (h *Heartbeat) beat(ctx context.Context) error {
+
+	// Create The Heartbeat CloudEvent
+	h.sequence++
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetType(h.eventType)
+	event.SetSource(h.source)
+	event.SetTime(now())
+	err := event.SetData(cloudevents.ApplicationJSON, map[string]int64{"sequence": h.sequence})
+	if err != nil {
+		h.logger.Error("Failed To Set Heartbeat Event Data", zap.Error(err))
+		h.statsReporter.ReportHeartbeat(util.TopicName(h.channelReference), false)
+		return err
+	}
+
+	// Produce The Heartbeat Event To The Target KafkaChannel
+	err = h.produce(ctx, h.channelReference, binding.ToMessage(&event))
+	if err != nil {
+		h.logger.Warn("Failed To Produce Heartbeat Event", zap.Int64("Sequence", h.sequence), zap.Error(err))
+	} else {
+		h.logger.Debug("Produced Heartbeat Event", zap.Int64("Sequence", h.sequence))
+	}
+	h.statsReporter.ReportHeartbeat(util.TopicName(h.channelReference), err == nil)
+	return err
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testTarget    = receivertesting.ChannelNamespace + "/" + receivertesting.ChannelName
+	testInterval  = 10 * time.Millisecond
+	testEventType = "dev.knative.kafka.heartbeat"
+	testSource    = "eventing-kafka-channel-receiver/test-pod"
+)
+
+// Test The NewHeartbeat() Functionality
+func TestNewHeartbeat(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name     string
+		only     bool
+		target   string
+		interval time.Duration
+		wantErr  bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Valid", target: testTarget, interval: testInterval},
+		{name: "Zero Interval", target: testTarget, interval: 0, wantErr: true},
+		{name: "Negative Interval", target: testTarget, interval: -time.Second, wantErr: true},
+		{name: "Empty Target", target: "", interval: testInterval, wantErr: true},
+		{name: "Target Without Namespace", target: receivertesting.ChannelName, interval: testInterval, wantErr: true},
+		{name: "Target With Empty Name", target: receivertesting.ChannelNamespace + "/", interval: testInterval, wantErr: true},
+		{name: "Target With Too Many Segments", target: "a/b/c", interval: testInterval, wantErr: true},
+	}
+
+	// Filter To Those With "only" Flag (If Any Specified)
+	filteredTestCases := make([]TestCase, 0)
+	for _, testCase := range testCases {
+		if testCase.only {
+			filteredTestCases = append(filteredTestCases, testCase)
+		}
+	}
+	if len(filteredTestCases) == 0 {
+		filteredTestCases = testCases
+	}
+
+	// Execute The Filtered TestCases
+	for _, testCase := range filteredTestCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statsReporter := receivertesting.NewMockStatsReporter()
+			heartbeat, err := NewHeartbeat(logtesting.TestLogger(t).Desugar(), testCase.target, testCase.interval, testEventType, testSource, nil, statsReporter)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			if testCase.wantErr {
+				assert.Nil(t, heartbeat)
+			} else {
+				assert.NotNil(t, heartbeat)
+				assert.Equal(t, eventingchannel.ChannelReference{Namespace: receivertesting.ChannelNamespace, Name: receivertesting.ChannelName}, heartbeat.channelReference)
+				assert.Equal(t, testCase.interval, heartbeat.interval)
+				assert.Equal(t, testEventType, heartbeat.eventType)
+				assert.Equal(t, testSource, heartbeat.source)
+				assert.Equal(t, statsReporter, heartbeat.statsReporter)
+			}
+		})
+	}
+}
+
+// Test The Heartbeat Produces Events On Schedule Until The Context Is Done
+func TestHeartbeatStart(t *testing.T) {
+
+	// Mock The Current Time
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Create A Mock ProduceFunc Which Records The Produced Events
+	events := make(chan *cloudevents.Event, 100)
+	channelReferences := make(chan eventingchannel.ChannelReference, 100)
+	produce := func(ctx context.Context, channelReference eventingchannel.ChannelReference, message binding.Message) error {
+		event, err := binding.ToEvent(ctx, message)
+		assert.Nil(t, err)
+		events <- event
+		channelReferences <- channelReference
+		return nil
+	}
+
+	// Create The Heartbeat To Test
+	statsReporter := receivertesting.NewMockStatsReporter()
+	heartbeat, err := NewHeartbeat(logtesting.TestLogger(t).Desugar(), testTarget, testInterval, testEventType, testSource, produce, statsReporter)
+	assert.Nil(t, err)
+
+	// Start The Heartbeat In The Background
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		heartbeat.Start(ctx)
+		close(stopped)
+	}()
+
+	// Verify The First Few Heartbeat Events Are Produced To The Target With Incrementing Sequence Numbers
+	for sequence := 1; sequence <= 3; sequence++ {
+		select {
+		case event := <-events:
+			assert.Equal(t, testEventType, event.Type())
+			assert.Equal(t, testSource, event.Source())
+			assert.Equal(t, mockNow, event.Time())
+			assert.NotEmpty(t, event.ID())
+			data := map[string]int{}
+			assert.Nil(t, event.DataAs(&data))
+			assert.Equal(t, sequence, data["sequence"])
+			assert.Equal(t, eventingchannel.ChannelReference{Namespace: receivertesting.ChannelNamespace, Name: receivertesting.ChannelName}, <-channelReferences)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed Out Waiting For Heartbeat Event %d", sequence)
+		}
+	}
+
+	// Verify The Heartbeat Stops When The Context Is Done
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Heartbeat Did Not Stop When The Context Was Done")
+	}
+
+	// Verify Each Successful Heartbeat Was Reported
+	assert.True(t, statsReporter.Heartbeats[true] >= 3)
+	assert.Equal(t, 0, statsReporter.Heartbeats[false])
+}
+
+// Test The Heartbeat Reports Events Which Could Not Be Produced
+func TestHeartbeatProduceFailure(t *testing.T) {
+
+	// Create A Mock ProduceFunc Which Always Fails
+	produceErr := errors.New("test produce error")
+	produce := func(_ context.Context, _ eventingchannel.ChannelReference, _ binding.Message) error {
+		return produceErr
+	}
+
+	// Create The Heartbeat To Test
+	statsReporter := receivertesting.NewMockStatsReporter()
+	heartbeat, err := NewHeartbeat(logtesting.TestLogger(t).Desugar(), testTarget, testInterval, testEventType, testSource, produce, statsReporter)
+	assert.Nil(t, err)
+
+	// Perform The Test
+	err = heartbeat.beat(context.Background())
+	assert.Equal(t, produceErr, err)
+	err = heartbeat.beat(context.Background())
+	assert.Equal(t, produceErr, err)
+
+	// Verify The Failures Were Reported (With The Sequence Still Incremented For Gap Detection)
+	assert.Equal(t, map[bool]int{false: 2}, statsReporter.Heartbeats)
+	assert.Equal(t, int64(2), heartbeat.sequence)
+}
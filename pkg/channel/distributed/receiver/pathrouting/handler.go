@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathrouting
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	"knative.dev/pkg/network"
+)
+
+//
+// HTTP Handler Which Routes Requests To A KafkaChannel Identified By The Request Path Before Delegating To The Next Handler
+//
+// When the Receiver is exposed under a path prefix by an external (path-based) router, requests arrive with a path of
+// the form "<prefix>/<namespace>/<name>" and a Host header identifying the router rather than the KafkaChannel.  Such
+// requests have the prefix stripped, the KafkaChannel's namespace & name extracted from the remaining path, and are
+// rewritten to the root path with the Host of the KafkaChannel's Service, so that the next handler (which resolves the
+// KafkaChannel from the Host header) routes them as usual.  Requests to the root path are passed through unchanged to
+// retain Host based routing, and requests whose path is under the prefix but does not identify a KafkaChannel are
+// rejected with a 404 (Not Found) describing the expected format.
+//
+type Handler struct {
+	logger *zap.Logger
+	prefix string
+	next   http.Handler
+}
+
+// Verify The Handler Implements The http.Handler Interface
+var _ http.Handler = &Handler{}
+
+// Handler Constructor (The Prefix Is Normalized To Have A Leading, But No Trailing, Slash)
+func NewHandler(logger *zap.Logger, prefix string, next http.Handler) *Handler {
+	return &Handler{logger: logger, prefix: NormalizePathPrefix(prefix), next: next}
+}
+
+// Route Requests Whose Path Identifies A KafkaChannel Or Otherwise Delegate To The Next Handler
+func (h *Handler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+
+	// Requests To The Root Path (Host Based Routing) & Outside The Prefix Are Left For The Next Handler
+	path := request.URL.Path
+	if path == "/" || !(path == h.prefix || strings.HasPrefix(path, h.prefix+"/")) {
+		h.next.ServeHTTP(response, request)
+		return
+	}
+
+	// Extract The KafkaChannel From The Remaining Path
+	channelReference, err := ParseChannelPath(h.prefix, path)
+	if err != nil {
+		h.logger.Info("Rejecting Request With Invalid KafkaChannel Path", zap.String("Path", path), zap.Error(err))
+		http.Error(response, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	// Rewrite The Request As Though It Were Addressed To The KafkaChannel's Service
+	request.Host = fmt.Sprintf("%s-%s.%s.svc.%s", channelReference.Name, kafkaconstants.KafkaChannelServiceNameSuffix, channelReference.Namespace, network.GetClusterDomainName())
+	request.URL.Path = "/"
+	request.URL.RawPath = ""
+	h.next.ServeHTTP(response, request)
+}
+
+// Normalize The Specified Path Prefix To Have A Leading, But No Trailing, Slash (The Root Prefix Being Empty)
+func NormalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if len(prefix) <= 0 {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// Extract The KafkaChannel Namespace & Name From A "<prefix>/<namespace>/<name>" Path (A Trailing Slash Is Permitted)
+func ParseChannelPath(prefix string, path string) (eventingchannel.ChannelReference, error) {
+
+	// Strip The Prefix & Any Trailing Slash
+	prefix = NormalizePathPrefix(prefix)
+	if !strings.HasPrefix(path, prefix+"/") {
+		return eventingchannel.ChannelReference{}, fmt.Errorf("invalid channel path '%s' - expected '%s/<namespace>/<name>'", path, prefix)
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(path, prefix+"/"), "/"), "/")
+
+	// Verify The Remaining Path Consists Of Exactly A Valid Namespace & Name
+	if len(segments) != 2 {
+		return eventingchannel.ChannelReference{}, fmt.Errorf("invalid channel path '%s' - expected '%s/<namespace>/<name>'", path, prefix)
+	}
+	for _, segment := range segments {
+		if errs := validation.IsDNS1123Label(segment); len(errs) > 0 {
+			return eventingchannel.ChannelReference{}, fmt.Errorf("invalid channel path '%s' - '%s' is not a valid namespace or name: %s", path, segment, strings.Join(errs, ", "))
+		}
+	}
+
+	return eventingchannel.ChannelReference{Namespace: segments[0], Name: segments[1]}, nil
+}
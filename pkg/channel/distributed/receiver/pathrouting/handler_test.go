@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathrouting
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testRouterHost  = "events.example.com"
+	testChannelHost = "channel-kn-channel.namespace.svc.cluster.local"
+)
+
+// Test The NewHandler() Functionality
+func TestNewHandler(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	handler := NewHandler(logger, "kafka/", http.NotFoundHandler())
+	assert.NotNil(t, handler)
+	assert.Equal(t, logger, handler.logger)
+	assert.Equal(t, "/kafka", handler.prefix)
+	assert.NotNil(t, handler.next)
+}
+
+// Test The NormalizePathPrefix() Functionality
+func TestNormalizePathPrefix(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name   string
+		prefix string
+		want   string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Empty", prefix: "", want: ""},
+		{name: "Root", prefix: "/", want: ""},
+		{name: "Normalized", prefix: "/kafka", want: "/kafka"},
+		{name: "Without Leading Slash", prefix: "kafka", want: "/kafka"},
+		{name: "With Trailing Slash", prefix: "/kafka/", want: "/kafka"},
+		{name: "Multiple Segments", prefix: "/events/kafka/", want: "/events/kafka"},
+		{name: "Whitespace", prefix: " /kafka ", want: "/kafka"},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, NormalizePathPrefix(testCase.prefix))
+		})
+	}
+}
+
+// Test The ParseChannelPath() Functionality
+func TestParseChannelPath(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		prefix  string
+		path    string
+		want    eventingchannel.ChannelReference
+		wantErr bool
+	}
+
+	// Define The TestCases
+	channelReference := eventingchannel.ChannelReference{Namespace: "namespace", Name: "channel"}
+	testCases := []TestCase{
+		{name: "Root Prefix", prefix: "", path: "/namespace/channel", want: channelReference},
+		{name: "Slash Prefix", prefix: "/", path: "/namespace/channel", want: channelReference},
+		{name: "Single Segment Prefix", prefix: "/kafka", path: "/kafka/namespace/channel", want: channelReference},
+		{name: "Multiple Segment Prefix", prefix: "/events/kafka", path: "/events/kafka/namespace/channel", want: channelReference},
+		{name: "Unnormalized Prefix", prefix: "kafka/", path: "/kafka/namespace/channel", want: channelReference},
+		{name: "Trailing Slash", prefix: "/kafka", path: "/kafka/namespace/channel/", want: channelReference},
+		{name: "Prefix Only", prefix: "/kafka", path: "/kafka", wantErr: true},
+		{name: "Prefix With Trailing Slash Only", prefix: "/kafka", path: "/kafka/", wantErr: true},
+		{name: "Namespace Only", prefix: "/kafka", path: "/kafka/namespace", wantErr: true},
+		{name: "Extra Segment", prefix: "/kafka", path: "/kafka/namespace/channel/extra", wantErr: true},
+		{name: "Empty Namespace", prefix: "/kafka", path: "/kafka//channel", wantErr: true},
+		{name: "Empty Name", prefix: "/kafka", path: "/kafka/namespace//", wantErr: true},
+		{name: "Invalid Namespace", prefix: "/kafka", path: "/kafka/Namespace/channel", wantErr: true},
+		{name: "Invalid Name", prefix: "/kafka", path: "/kafka/namespace/channel.name", wantErr: true},
+		{name: "Different Prefix", prefix: "/kafka", path: "/other/namespace/channel", wantErr: true},
+		{name: "Partial Prefix Segment", prefix: "/kafka", path: "/kafkachannels/namespace/channel", wantErr: true},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			channelReference, err := ParseChannelPath(testCase.prefix, testCase.path)
+			assert.Equal(t, testCase.want, channelReference)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The Handler's ServeHTTP() Functionality
+func TestServeHTTP(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name       string
+		prefix     string
+		host       string
+		path       string
+		wantStatus int
+		wantNext   bool
+		wantHost   string
+		wantPath   string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "Prefixed Channel Path",
+			prefix:     "/kafka",
+			host:       testRouterHost,
+			path:       "/kafka/namespace/channel",
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+			wantHost:   testChannelHost,
+			wantPath:   "/",
+		},
+		{
+			name:       "Root Prefixed Channel Path",
+			prefix:     "/",
+			host:       testRouterHost,
+			path:       "/namespace/channel",
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+			wantHost:   testChannelHost,
+			wantPath:   "/",
+		},
+		{
+			name:       "Escaped Channel Path",
+			prefix:     "/kafka",
+			host:       testRouterHost,
+			path:       "/kafka/namespace/%63hannel",
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+			wantHost:   testChannelHost,
+			wantPath:   "/",
+		},
+		{
+			name:       "Host Based Request",
+			prefix:     "/kafka",
+			host:       testChannelHost,
+			path:       "/",
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+			wantHost:   testChannelHost,
+			wantPath:   "/",
+		},
+		{
+			name:       "Path Outside Prefix",
+			prefix:     "/kafka",
+			host:       testRouterHost,
+			path:       "/other/namespace/channel",
+			wantStatus: http.StatusAccepted,
+			wantNext:   true,
+			wantHost:   testRouterHost,
+			wantPath:   "/other/namespace/channel",
+		},
+		{
+			name:       "Malformed Channel Path",
+			prefix:     "/kafka",
+			host:       testRouterHost,
+			path:       "/kafka/namespace",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid Channel Name",
+			prefix:     "/kafka",
+			host:       testRouterHost,
+			path:       "/kafka/namespace/Channel",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	// Execute The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Next Handler Which Records The Request Host & Path It Received
+			nextCalled := false
+			nextHost := ""
+			nextPath := ""
+			next := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				nextCalled = true
+				nextHost = request.Host
+				nextPath = request.URL.Path
+				response.WriteHeader(http.StatusAccepted)
+			})
+
+			// Create The Handler To Test
+			handler := NewHandler(logtesting.TestLogger(t).Desugar(), testCase.prefix, next)
+
+			// Create The Request
+			request := httptest.NewRequest(http.MethodPost, "http://"+testCase.host+testCase.path, nil)
+			response := httptest.NewRecorder()
+
+			// Perform The Test
+			handler.ServeHTTP(response, request)
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantStatus, response.Code)
+			assert.Equal(t, testCase.wantNext, nextCalled)
+			if testCase.wantNext {
+				assert.Equal(t, testCase.wantHost, nextHost)
+				assert.Equal(t, testCase.wantPath, nextPath)
+			} else {
+				assert.Contains(t, response.Body.String(), "invalid channel path")
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// Configuration For Delivering The Broker Metadata Of Each Produced Record To An External Audit Sink
+type AuditConfig struct {
+	Url       string        // The URL Of The Audit Sink To Which Each Produced Record Is POSTed (Disabled If Empty)
+	QueueSize int           // Maximum Number Of Records Awaiting Delivery Before Further Records Are Dropped
+	Timeout   time.Duration // Timeout Of Each Delivery To The Audit Sink
+}
+
+// Create The AuditConfig For The Specified Receiver Configuration (Validating & Defaulting Unspecified Values)
+func NewAuditConfig(config commonconfig.EKReceiverAuditConfig) (AuditConfig, error) {
+
+	// No URL - Auditing Disabled
+	if len(config.Url) <= 0 {
+		return AuditConfig{}, nil
+	}
+
+	// Validate The URL
+	sinkUrl, err := url.Parse(config.Url)
+	if err != nil || (sinkUrl.Scheme != "http" && sinkUrl.Scheme != "https") || len(sinkUrl.Host) <= 0 {
+		return AuditConfig{}, fmt.Errorf("invalid audit url '%s' - must be an absolute http or https url", config.Url)
+	}
+
+	// Validate The Queue Size (Defaulting If Unspecified)
+	queueSize := config.QueueSize
+	if queueSize < 0 {
+		return AuditConfig{}, fmt.Errorf("invalid audit queue size %d - must not be negative", queueSize)
+	} else if queueSize == 0 {
+		queueSize = constants.DefaultAuditQueueSize
+	}
+
+	// Default The Timeout If Unspecified
+	timeout := time.Duration(config.TimeoutMillis) * time.Millisecond
+	if timeout <= 0 {
+		timeout = constants.DefaultAuditTimeoutMillis * time.Millisecond
+	}
+
+	return AuditConfig{Url: config.Url, QueueSize: queueSize, Timeout: timeout}, nil
+}
+
+// The JSON Body POSTed To The Audit Sink For Each Produced Record
+type AuditRecord struct {
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var auditNow = time.Now
+
+//
+// Asynchronous Delivery Of Produced Record Metadata To An External Audit Sink
+//
+// Each successfully produced record is queued (without blocking the produce) and a background process POSTs
+// them to the audit sink one at a time, in the order they were produced.  Records which would overflow the
+// bounded queue, or which the audit sink does not accept, are dropped and counted in the audit drop metric
+// so that gaps in the audit trail are visible.  The timestamp is the one reported by the broker, which is
+// the LogAppendTime if the topic is so configured, and otherwise the CreateTime assigned by the producer.
+//
+type auditor struct {
+	logger        *zap.Logger
+	config        AuditConfig
+	client        *http.Client
+	statsReporter metrics.StatsReporter
+	records       chan AuditRecord
+	stopChan      chan struct{}
+	stoppedChan   chan struct{}
+}
+
+// Create An Auditor For The Specified Configuration
+func newAuditor(logger *zap.Logger, config AuditConfig, statsReporter metrics.StatsReporter) *auditor {
+	return &auditor{
+		logger:        logger.With(zap.String("AuditUrl", config.Url)),
+		config:        config,
+		client:        &http.Client{Timeout: config.Timeout},
+		statsReporter: statsReporter,
+		records:       make(chan AuditRecord, config.QueueSize),
+		stopChan:      make(chan struct{}),
+		stoppedChan:   make(chan struct{}),
+	}
+}
+
+// Queue The Metadata Of The Specified Produced Message For Delivery (Dropping It If The Queue Is Full)
+func (a *auditor) record(producerMessage *sarama.ProducerMessage, partition int32, offset int64) {
+	timestamp := producerMessage.Timestamp
+	if timestamp.IsZero() {
+		timestamp = auditNow()
+	}
+	record := AuditRecord{Topic: producerMessage.Topic, Partition: partition, Offset: offset, Timestamp: timestamp}
+	select {
+	case a.records <- record:
+	default:
+		a.logger.Warn("Audit Queue Full - Dropping Record", zap.Any("Record", record))
+		a.statsReporter.ReportAuditDrop(record.Topic, metrics.AuditDropReasonQueueFull)
+	}
+}
+
+// Start Delivering The Queued Records To The Audit Sink Until Stopped
+func (a *auditor) start() {
+	go func() {
+		defer close(a.stoppedChan)
+		for {
+			select {
+			case <-a.stopChan:
+				a.drain()
+				return
+			case record := <-a.records:
+				a.deliver(record)
+			}
+		}
+	}()
+}
+
+// Stop Delivering Records (Waiting For Those Already Queued To Be Delivered)
+func (a *auditor) stop() {
+	close(a.stopChan)
+	<-a.stoppedChan
+}
+
+// Deliver Any Records Remaining In The Queue, Dropping The Rest Once A Delivery Fails (So Stopping Is Not Held Up By An Unavailable Sink)
+func (a *auditor) drain() {
+	available := true
+	for {
+		select {
+		case record := <-a.records:
+			if available {
+				available = a.deliver(record)
+			} else {
+				a.statsReporter.ReportAuditDrop(record.Topic, metrics.AuditDropReasonDeliveryFailure)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// POST The Specified Record To The Audit Sink & Return Whether It Was Accepted (Counting It As Dropped If Not)
+func (a *auditor) deliver(record AuditRecord) bool {
+	err := a.post(record)
+	if err != nil {
+		a.logger.Warn("Failed To Deliver Record To Audit Sink - Dropping Record", zap.Any("Record", record), zap.Error(err))
+		a.statsReporter.ReportAuditDrop(record.Topic, metrics.AuditDropReasonDeliveryFailure)
+		return false
+	}
+	return true
+}
+
+// POST The Specified Record To The Audit Sink As JSON
+func (a *auditor) post(record AuditRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, a.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := a.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("audit sink responded with status code %d", response.StatusCode)
+	}
+	return nil
+}
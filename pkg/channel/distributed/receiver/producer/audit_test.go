@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewAuditConfig() Functionality
+func TestNewAuditConfig(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKReceiverAuditConfig
+		want    AuditConfig
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", config: commonconfig.EKReceiverAuditConfig{QueueSize: 10}, want: AuditConfig{}},
+		{
+			name:   "Defaulted",
+			config: commonconfig.EKReceiverAuditConfig{Url: "http://audit.example.com/records"},
+			want:   AuditConfig{Url: "http://audit.example.com/records", QueueSize: constants.DefaultAuditQueueSize, Timeout: constants.DefaultAuditTimeoutMillis * time.Millisecond},
+		},
+		{
+			name:   "Configured",
+			config: commonconfig.EKReceiverAuditConfig{Url: "https://audit.example.com", QueueSize: 50, TimeoutMillis: 250},
+			want:   AuditConfig{Url: "https://audit.example.com", QueueSize: 50, Timeout: 250 * time.Millisecond},
+		},
+		{name: "Relative Url", config: commonconfig.EKReceiverAuditConfig{Url: "/records"}, wantErr: true},
+		{name: "Unsupported Scheme", config: commonconfig.EKReceiverAuditConfig{Url: "ftp://audit.example.com"}, wantErr: true},
+		{name: "Invalid Url", config: commonconfig.EKReceiverAuditConfig{Url: "http://audit example.com/%zz"}, wantErr: true},
+		{name: "Negative QueueSize", config: commonconfig.EKReceiverAuditConfig{Url: "http://audit.example.com", QueueSize: -1}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			auditConfig, err := NewAuditConfig(testCase.config)
+			assert.Equal(t, testCase.want, auditConfig)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality Delivers The Produced Record Metadata To The Audit Sink
+func TestProduceKafkaMessageAudit(t *testing.T) {
+
+	// Stub The Current Time
+	timestamp := time.Date(2020, 11, 17, 10, 30, 0, 0, time.UTC)
+	auditNowPlaceholder := auditNow
+	auditNow = func() time.Time { return timestamp }
+	defer func() { auditNow = auditNowPlaceholder }()
+
+	// Create An Audit Sink Which Records The Delivered Records
+	records := make(chan AuditRecord, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		record := AuditRecord{}
+		assert.Nil(t, json.NewDecoder(request.Body).Decode(&record))
+		records <- record
+		writer.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// Create A Producer Which Audits To The Sink
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	statsReporter := receivertesting.NewMockStatsReporter()
+	producer.auditor = newAuditor(logtesting.TestLogger(t).Desugar(), AuditConfig{Url: server.URL, QueueSize: 10, Timeout: time.Second}, statsReporter)
+	producer.auditor.start()
+
+	// Produce Two Messages
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	for i := 0; i < 2; i++ {
+		err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+		assert.Nil(t, err)
+		mockSyncProducer.GetMessage()
+	}
+
+	// Verify The Records Were Delivered In Order With The Produced Metadata
+	producer.auditor.stop()
+	assert.Equal(t, AuditRecord{Topic: receivertesting.TopicName, Partition: 1, Offset: 1, Timestamp: timestamp}, <-records)
+	assert.Equal(t, AuditRecord{Topic: receivertesting.TopicName, Partition: 1, Offset: 2, Timestamp: timestamp}, <-records)
+	assert.Equal(t, 0, statsReporter.AuditDrops(metrics.AuditDropReasonQueueFull))
+	assert.Equal(t, 0, statsReporter.AuditDrops(metrics.AuditDropReasonDeliveryFailure))
+}
+
+// Test The ProduceKafkaMessage() Functionality Is Not Blocked By A Slow Audit Sink (Dropping Records Which Overflow The Queue)
+func TestProduceKafkaMessageAuditSlowSink(t *testing.T) {
+
+	// Create An Audit Sink Which Blocks Until Released
+	received := make(chan struct{}, 3)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received <- struct{}{}
+		<-release
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create A Producer Which Audits To The Sink With A Single Record Queue
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	statsReporter := receivertesting.NewMockStatsReporter()
+	producer.auditor = newAuditor(logtesting.TestLogger(t).Desugar(), AuditConfig{Url: server.URL, QueueSize: 1, Timeout: 10 * time.Second}, statsReporter)
+	producer.auditor.start()
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	produce := func() time.Duration {
+		start := time.Now()
+		err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+		assert.Nil(t, err)
+		mockSyncProducer.GetMessage()
+		return time.Since(start)
+	}
+
+	// Produce A Message & Wait For Its Record To Block In The Audit Sink
+	assert.Less(t, int64(produce()), int64(time.Second))
+	<-received
+
+	// Produce A Message Which Is Queued & Another Which Overflows The Queue - Neither Should Wait For The Sink
+	assert.Less(t, int64(produce()), int64(time.Second))
+	assert.Less(t, int64(produce()), int64(time.Second))
+	assert.Equal(t, 1, statsReporter.AuditDrops(metrics.AuditDropReasonQueueFull))
+
+	// Release The Sink & Verify The Queued Record Is Still Delivered
+	close(release)
+	producer.auditor.stop()
+	assert.Len(t, received, 1)
+	assert.Equal(t, 0, statsReporter.AuditDrops(metrics.AuditDropReasonDeliveryFailure))
+}
+
+// Test The Auditor Counts Records Which The Audit Sink Does Not Accept As Dropped
+func TestAuditorDeliveryFailure(t *testing.T) {
+
+	// Create An Audit Sink Which Rejects All Records
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// Create An Auditor For The Sink & Queue Some Records Before Starting It
+	statsReporter := receivertesting.NewMockStatsReporter()
+	auditor := newAuditor(logtesting.TestLogger(t).Desugar(), AuditConfig{Url: server.URL, QueueSize: 3, Timeout: time.Second}, statsReporter)
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducer(t, mockSyncProducer)
+	producer.auditor = auditor
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	for i := 0; i < 3; i++ {
+		err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+		assert.Nil(t, err)
+		mockSyncProducer.GetMessage()
+	}
+
+	// Start & Stop The Auditor - Every Record Should Be Counted As Dropped Without Waiting On The Failing Sink
+	auditor.start()
+	auditor.stop()
+	assert.Equal(t, 3, statsReporter.AuditDrops(metrics.AuditDropReasonDeliveryFailure))
+	assert.Equal(t, 0, statsReporter.AuditDrops(metrics.AuditDropReasonQueueFull))
+}
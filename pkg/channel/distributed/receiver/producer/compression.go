@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// Context Key For The Compression Codec Override Of The KafkaChannel To Which An Event Is Being Produced
+type compressionCodecKey struct{}
+
+// Return A Copy Of The Specified Context Carrying The (Possibly Empty) Compression Codec Override For ProduceKafkaMessage()
+func WithCompressionCodec(ctx context.Context, codec string) context.Context {
+	return context.WithValue(ctx, compressionCodecKey{}, codec)
+}
+
+// Get The Compression Codec Override (If Any) From The Specified Context
+func compressionCodecFromContext(ctx context.Context) string {
+	codec, _ := ctx.Value(compressionCodecKey{}).(string)
+	return codec
+}
+
+// Parse The Specified Compression Codec Name & Verify It Is Supported By The Specified Kafka Version (As Sarama Does)
+func ParseCompressionCodec(codec string, version sarama.KafkaVersion) (sarama.CompressionCodec, error) {
+	var compressionCodec sarama.CompressionCodec
+	switch strings.ToLower(codec) {
+	case "none":
+		compressionCodec = sarama.CompressionNone
+	case "gzip":
+		compressionCodec = sarama.CompressionGZIP
+	case "snappy":
+		compressionCodec = sarama.CompressionSnappy
+	case "lz4":
+		compressionCodec = sarama.CompressionLZ4
+	case "zstd":
+		compressionCodec = sarama.CompressionZSTD
+	default:
+		return sarama.CompressionNone, fmt.Errorf("invalid compression codec '%s' - must be one of 'none', 'gzip', 'snappy', 'lz4' or 'zstd'", codec)
+	}
+	if compressionCodec == sarama.CompressionLZ4 && !version.IsAtLeast(sarama.V0_10_0_0) {
+		return sarama.CompressionNone, fmt.Errorf("lz4 compression requires Kafka version >= %s (configured version is %s)", sarama.V0_10_0_0, version)
+	}
+	if compressionCodec == sarama.CompressionZSTD && !version.IsAtLeast(sarama.V2_1_0_0) {
+		return sarama.CompressionNone, fmt.Errorf("zstd compression requires Kafka version >= %s (configured version is %s)", sarama.V2_1_0_0, version)
+	}
+	return compressionCodec, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+)
+
+// Test The ParseCompressionCodec() Functionality
+func TestParseCompressionCodec(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		codec   string
+		version sarama.KafkaVersion
+		want    sarama.CompressionCodec
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "None", codec: "none", version: sarama.V2_0_0_0, want: sarama.CompressionNone},
+		{name: "Gzip", codec: "gzip", version: sarama.V2_0_0_0, want: sarama.CompressionGZIP},
+		{name: "Snappy", codec: "snappy", version: sarama.V2_0_0_0, want: sarama.CompressionSnappy},
+		{name: "LZ4", codec: "lz4", version: sarama.V2_0_0_0, want: sarama.CompressionLZ4},
+		{name: "ZSTD", codec: "zstd", version: sarama.V2_1_0_0, want: sarama.CompressionZSTD},
+		{name: "Mixed Case", codec: "GZip", version: sarama.V2_0_0_0, want: sarama.CompressionGZIP},
+		{name: "LZ4 Unsupported By Version", codec: "lz4", version: sarama.V0_9_0_0, wantErr: true},
+		{name: "ZSTD Unsupported By Version", codec: "zstd", version: sarama.V2_0_0_0, wantErr: true},
+		{name: "Invalid", codec: "brotli", version: sarama.V2_0_0_0, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			codec, err := ParseCompressionCodec(testCase.codec, testCase.version)
+			assert.Equal(t, testCase.want, codec)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality With KafkaChannel Compression Codec Overrides
+func TestProduceKafkaMessageCompression(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		codec        string
+		wantOverride bool
+		wantCodec    sarama.CompressionCodec
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Override", codec: "", wantOverride: false},
+		{name: "Override", codec: "gzip", wantOverride: true, wantCodec: sarama.CompressionGZIP},
+		{name: "Override Matching Global Codec", codec: "snappy", wantOverride: false},
+		{name: "Override Unsupported By Version", codec: "zstd", wantOverride: false},
+		{name: "Invalid Override", codec: "brotli", wantOverride: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Test Producer With A Global Snappy Codec & A Kafka Version Without ZSTD Support
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.configuration.Version = sarama.V2_0_0_0
+			producer.configuration.Producer.Compression = sarama.CompressionSnappy
+			producer.configuration.Producer.CompressionLevel = 6
+
+			// Stub The Kafka Producer Creation Wrapper To Track SyncProducers Created For Overrides
+			var overrideConfigs []*sarama.Config
+			overrideSyncProducer := receivertesting.NewMockSyncProducer()
+			createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+			createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+				overrideConfigs = append(overrideConfigs, config)
+				assert.Equal(t, []string{receivertesting.KafkaBrokers}, brokers)
+				return overrideSyncProducer, gometrics.NewRegistry(), nil
+			}
+			defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+
+			// Produce Two Messages With The TestCase's Codec Override
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+			ctx := WithCompressionCodec(context.Background(), testCase.codec)
+			for i := 0; i < 2; i++ {
+				err := producer.ProduceKafkaMessage(ctx, channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				assert.Nil(t, err)
+
+				// Verify The Message Was Produced By The Expected SyncProducer
+				var producerMessage sarama.ProducerMessage
+				if testCase.wantOverride {
+					producerMessage = overrideSyncProducer.GetMessage()
+				} else {
+					producerMessage = mockSyncProducer.GetMessage()
+				}
+				assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
+			}
+
+			// Verify A Single Override SyncProducer Was Created (If Expected) With Only The Codec & Level Changed
+			if testCase.wantOverride {
+				assert.Len(t, overrideConfigs, 1)
+				assert.Equal(t, testCase.wantCodec, overrideConfigs[0].Producer.Compression)
+				assert.Equal(t, sarama.CompressionLevelDefault, overrideConfigs[0].Producer.CompressionLevel)
+				assert.Equal(t, producer.configuration.ClientID, overrideConfigs[0].ClientID)
+				assert.Equal(t, sarama.CompressionSnappy, producer.configuration.Producer.Compression)
+			} else {
+				assert.Len(t, overrideConfigs, 0)
+			}
+
+			// Verify Closing The Producer Closes Any Override SyncProducer
+			producer.Close()
+			assert.True(t, mockSyncProducer.Closed())
+			assert.Equal(t, testCase.wantOverride, overrideSyncProducer.Closed())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// Error Returned When Producing An Event Without Data With The "reject" EmptyDataPolicy
+var ErrEmptyData = errors.New("event has no data - rejected by the receiver's empty data policy")
+
+// Validate The Specified EmptyDataPolicy & Return It (Or The Default If Unspecified)
+func ParseEmptyDataPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultEmptyDataPolicy, nil
+	case constants.EmptyDataPolicyEmpty, constants.EmptyDataPolicyNull, constants.EmptyDataPolicyReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid empty data policy '%s' - must be one of '%s', '%s' or '%s'", policy,
+			constants.EmptyDataPolicyEmpty, constants.EmptyDataPolicyNull, constants.EmptyDataPolicyReject)
+	}
+}
+
+//
+// Apply The EmptyDataPolicy To The Specified ProducerMessage (If It Has No Value)
+//
+// A CloudEvent without data is valid, but when written in binary content mode it leaves the Kafka message without
+// a value, which Kafka (and consumers of compacted topics in particular) treat as a tombstone deleting the previous
+// records with the same key.  The default policy produces a zero-length value instead, the "null" policy retains
+// the null value (tombstone), and the "reject" policy refuses to produce the event.  Structured content mode events
+// always have a value (the JSON envelope) so are unaffected.
+//
+func applyEmptyDataPolicy(producerMessage *sarama.ProducerMessage, policy string) error {
+	if producerMessage.Value != nil {
+		return nil
+	}
+	switch policy {
+	case constants.EmptyDataPolicyNull:
+		return nil
+	case constants.EmptyDataPolicyReject:
+		return ErrEmptyData
+	default:
+		producerMessage.Value = sarama.ByteEncoder{}
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/stretchr/testify/assert"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/schemaregistry"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+)
+
+// Test The ParseEmptyDataPolicy() Functionality
+func TestParseEmptyDataPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultEmptyDataPolicy},
+		{name: "Empty", policy: constants.EmptyDataPolicyEmpty, want: constants.EmptyDataPolicyEmpty},
+		{name: "Null", policy: constants.EmptyDataPolicyNull, want: constants.EmptyDataPolicyNull},
+		{name: "Reject", policy: constants.EmptyDataPolicyReject, want: constants.EmptyDataPolicyReject},
+		{name: "Invalid", policy: "tombstone", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParseEmptyDataPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality With An Event Without Data For Each EmptyDataPolicy
+func TestProduceKafkaMessageEmptyData(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		policy         string
+		schemaRegistry bool
+		wantErr        error
+		wantNull       bool
+		wantValue      []byte
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Empty", policy: constants.EmptyDataPolicyEmpty, wantValue: []byte{}},
+		{name: "Null", policy: constants.EmptyDataPolicyNull, wantNull: true},
+		{name: "Reject", policy: constants.EmptyDataPolicyReject, wantErr: ErrEmptyData},
+		{name: "Empty With Schema Registry", policy: constants.EmptyDataPolicyEmpty, schemaRegistry: true, wantValue: schemaregistry.Frame(1, nil)},
+		{name: "Null With Schema Registry", policy: constants.EmptyDataPolicyNull, schemaRegistry: true, wantNull: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With The EmptyDataPolicy (And Optional Schema Registry Framing)
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.options.EmptyDataPolicy = testCase.policy
+			if testCase.schemaRegistry {
+				producer.options.SchemaRegistryConfig = SchemaRegistryConfig{Client: &mockSchemaRegistryClient{schemaId: 1}}
+			}
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+			// Create An Event Without Data
+			event := receivertesting.CreateCloudEvent(cloudevents.VersionV1)
+			event.DataEncoded = nil
+
+			// Perform The Test
+			err := producer.ProduceKafkaMessage(context.Background(), channelReference, binding.ToMessage(event))
+
+			// Verify The Event Was Rejected (Subsequent Events With Data Are Still Produced) Or Produced As Expected
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				err = producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				assert.Nil(t, err)
+				assert.Equal(t, receivertesting.EventDataJson, encodeValue(t, mockSyncProducer.GetMessage()))
+				return
+			}
+			assert.Nil(t, err)
+			producerMessage := mockSyncProducer.GetMessage()
+			if testCase.wantNull {
+				assert.Nil(t, producerMessage.Value)
+			} else {
+				assert.NotNil(t, producerMessage.Value)
+				assert.Equal(t, testCase.wantValue, encodeValue(t, producerMessage))
+			}
+			receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyId, receivertesting.EventId)
+		})
+	}
+}
+
+// Utility Function For Encoding The Value Of The Specified ProducerMessage
+func encodeValue(t *testing.T, producerMessage sarama.ProducerMessage) []byte {
+	value, err := producerMessage.Value.Encode()
+	assert.Nil(t, err)
+	return value
+}
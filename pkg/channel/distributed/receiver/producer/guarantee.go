@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+)
+
+// Context Key For The Delivery Guarantee Of The KafkaChannel To Which An Event Is Being Produced
+type deliveryGuaranteeKey struct{}
+
+// Return A Copy Of The Specified Context Carrying The (Possibly Empty) Delivery Guarantee For ProduceKafkaMessage()
+func WithDeliveryGuarantee(ctx context.Context, guarantee string) context.Context {
+	return context.WithValue(ctx, deliveryGuaranteeKey{}, guarantee)
+}
+
+// Get The Delivery Guarantee (If Any) From The Specified Context
+func deliveryGuaranteeFromContext(ctx context.Context) string {
+	guarantee, _ := ctx.Value(deliveryGuaranteeKey{}).(string)
+	return guarantee
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+)
+
+// Test The ProduceKafkaMessage() Functionality With KafkaChannel Delivery Guarantees
+func TestProduceKafkaMessageDeliveryGuarantee(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name             string
+		guarantee        string
+		codec            string
+		retryMax         int
+		wantOverride     bool
+		wantIdempotent   bool
+		wantRequiredAcks sarama.RequiredAcks
+		wantRetryMax     int
+		wantCodec        sarama.CompressionCodec
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Guarantee", guarantee: "", retryMax: 3, wantOverride: false},
+		{name: "At Most Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, retryMax: 3, wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForLocal, wantRetryMax: 0},
+		{name: "At Least Once", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, retryMax: 3, wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForAll, wantRetryMax: 3},
+		{name: "Effectively Once", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, retryMax: 3, wantOverride: true, wantIdempotent: true, wantRequiredAcks: sarama.WaitForAll, wantRetryMax: 3},
+		{name: "Effectively Once With Compression", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, codec: "gzip", retryMax: 3, wantOverride: true, wantIdempotent: true, wantRequiredAcks: sarama.WaitForAll, wantRetryMax: 3, wantCodec: sarama.CompressionGZIP},
+		{name: "Incompatible With Disabled Retries", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, retryMax: 0, wantOverride: false},
+		{name: "Invalid Guarantee", guarantee: "exactly-once", retryMax: 3, wantOverride: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Test Producer With Producer Settings Opposed To Those Of The Delivery Guarantees
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.configuration.Version = sarama.V2_0_0_0
+			producer.configuration.Producer.Idempotent = false
+			producer.configuration.Producer.RequiredAcks = sarama.NoResponse
+			producer.configuration.Producer.Retry.Max = testCase.retryMax
+			producer.configuration.Producer.Compression = sarama.CompressionNone
+
+			// Stub The Kafka Producer Creation Wrapper To Track SyncProducers Created For Overrides
+			var overrideConfigs []*sarama.Config
+			overrideSyncProducer := receivertesting.NewMockSyncProducer()
+			createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+			createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+				overrideConfigs = append(overrideConfigs, config)
+				return overrideSyncProducer, gometrics.NewRegistry(), nil
+			}
+			defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+
+			// Produce Two Messages With The TestCase's Delivery Guarantee (And Codec) Override
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+			ctx := WithDeliveryGuarantee(WithCompressionCodec(context.Background(), testCase.codec), testCase.guarantee)
+			for i := 0; i < 2; i++ {
+				err := producer.ProduceKafkaMessage(ctx, channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				assert.Nil(t, err)
+
+				// Verify The Message Was Produced By The Expected SyncProducer
+				var producerMessage sarama.ProducerMessage
+				if testCase.wantOverride {
+					producerMessage = overrideSyncProducer.GetMessage()
+				} else {
+					producerMessage = mockSyncProducer.GetMessage()
+				}
+				assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
+			}
+
+			// Verify A Single Override SyncProducer Was Created (If Expected) With The Guarantee's Settings
+			if testCase.wantOverride {
+				assert.Len(t, overrideConfigs, 1)
+				assert.Equal(t, testCase.wantIdempotent, overrideConfigs[0].Producer.Idempotent)
+				assert.Equal(t, testCase.wantRequiredAcks, overrideConfigs[0].Producer.RequiredAcks)
+				assert.Equal(t, testCase.wantRetryMax, overrideConfigs[0].Producer.Retry.Max)
+				assert.Equal(t, testCase.wantCodec, overrideConfigs[0].Producer.Compression)
+				assert.Equal(t, sarama.NoResponse, producer.configuration.Producer.RequiredAcks)
+			} else {
+				assert.Len(t, overrideConfigs, 0)
+			}
+
+			// Verify Closing The Producer Closes Any Override SyncProducer
+			producer.Close()
+			assert.True(t, mockSyncProducer.Closed())
+			assert.Equal(t, testCase.wantOverride, overrideSyncProducer.Closed())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// Context Key For The Producer Idempotence Override Of The KafkaChannel To Which An Event Is Being Produced
+type idempotenceKey struct{}
+
+// Return A Copy Of The Specified Context Carrying The (Possibly Empty) Producer Idempotence Override For ProduceKafkaMessage()
+func WithIdempotence(ctx context.Context, idempotence string) context.Context {
+	return context.WithValue(ctx, idempotenceKey{}, idempotence)
+}
+
+// Get The Producer Idempotence Override (If Any) From The Specified Context
+func idempotenceFromContext(ctx context.Context) string {
+	idempotence, _ := ctx.Value(idempotenceKey{}).(string)
+	return idempotence
+}
+
+// Parse The Specified Producer Idempotence Override ("true" Or "false")
+func ParseIdempotence(idempotence string) (bool, error) {
+	switch idempotence {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid idempotence '%s' - must be 'true' or 'false'", idempotence)
+	}
+}
+
+//
+// Get A Copy Of The Specified Sarama Configuration With The Specified Producer Idempotence Applied
+//
+// Idempotent production requires a single open request per broker (serializing the producer), acks from all in-sync
+// replicas and producer retries.  Disabling it for loss-tolerant KafkaChannels allows their requests to be pipelined,
+// so the open requests are relaxed to the Sarama default (unless the "partition" produce ordering limits them) and the
+// acks to the leader's alone (unless the KafkaChannel's delivery guarantee requires all in-sync replicas).  The
+// override is rejected if it contradicts the (successfully applied) settings of the KafkaChannel's delivery guarantee,
+// which take precedence.  Only the override's own SyncProducer is affected, never that of other KafkaChannels.
+//
+func configureIdempotence(config *sarama.Config, idempotence string, guaranteeSettings *delivery.Settings, ordering string) (*sarama.Config, error) {
+
+	idempotent, err := ParseIdempotence(idempotence)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotenceConfig := *config
+	idempotenceConfig.Producer.Idempotent = idempotent
+	if idempotent {
+		if guaranteeSettings != nil && !guaranteeSettings.ProducerRetries {
+			return nil, fmt.Errorf("idempotence cannot be enabled for a delivery guarantee without producer retries")
+		}
+		if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, fmt.Errorf("idempotence requires Kafka version >= %s (configured version is %s)", sarama.V0_11_0_0, config.Version)
+		}
+		if config.Producer.Retry.Max < 1 {
+			return nil, fmt.Errorf("idempotence requires producer retries, but Producer.Retry.Max is %d", config.Producer.Retry.Max)
+		}
+		idempotenceConfig.Producer.RequiredAcks = sarama.WaitForAll
+		idempotenceConfig.Net.MaxOpenRequests = 1
+	} else {
+		if guaranteeSettings != nil && guaranteeSettings.Idempotent {
+			return nil, fmt.Errorf("idempotence cannot be disabled for a delivery guarantee requiring it")
+		}
+		if guaranteeSettings == nil && idempotenceConfig.Producer.RequiredAcks == sarama.WaitForAll {
+			idempotenceConfig.Producer.RequiredAcks = sarama.WaitForLocal
+		}
+		if ordering != constants.ProduceOrderingPartition && idempotenceConfig.Net.MaxOpenRequests == 1 {
+			idempotenceConfig.Net.MaxOpenRequests = sarama.NewConfig().Net.MaxOpenRequests
+		}
+	}
+	return &idempotenceConfig, nil
+}
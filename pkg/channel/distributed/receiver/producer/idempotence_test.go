@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+)
+
+// Test The ParseIdempotence() Functionality
+func TestParseIdempotence(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		idempotence string
+		want        bool
+		wantErr     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Enabled", idempotence: "true", want: true},
+		{name: "Disabled", idempotence: "false", want: false},
+		{name: "Empty", idempotence: "", wantErr: true},
+		{name: "Invalid", idempotence: "yes", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			idempotent, err := ParseIdempotence(testCase.idempotence)
+			assert.Equal(t, testCase.want, idempotent)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality With KafkaChannel Idempotence Overrides
+func TestProduceKafkaMessageIdempotence(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name                string
+		idempotent          bool // The Global Sarama Configuration's Idempotence
+		idempotence         string
+		guarantee           string
+		ordering            string
+		version             sarama.KafkaVersion
+		wantOverride        bool
+		wantIdempotent      bool
+		wantRequiredAcks    sarama.RequiredAcks
+		wantMaxOpenRequests int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "No Idempotence", idempotent: true, idempotence: "", wantOverride: false},
+		{name: "Same As Global Idempotence", idempotent: true, idempotence: "true", wantOverride: false},
+		{name: "Disabled", idempotent: true, idempotence: "false", wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForLocal, wantMaxOpenRequests: 5},
+		{name: "Disabled With Partition Ordering", idempotent: true, idempotence: "false", ordering: constants.ProduceOrderingPartition, wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForLocal, wantMaxOpenRequests: 1},
+		{name: "Disabled With At Least Once", idempotent: true, idempotence: "false", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForAll, wantMaxOpenRequests: 5},
+		{name: "Disabled With Effectively Once", idempotent: false, idempotence: "false", guarantee: kafkav1beta1.DeliveryGuaranteeEffectivelyOnce, wantOverride: true, wantIdempotent: true, wantRequiredAcks: sarama.WaitForAll, wantMaxOpenRequests: 1},
+		{name: "Enabled", idempotent: false, idempotence: "true", wantOverride: true, wantIdempotent: true, wantRequiredAcks: sarama.WaitForAll, wantMaxOpenRequests: 1},
+		{name: "Enabled With At Most Once", idempotent: false, idempotence: "true", guarantee: kafkav1beta1.DeliveryGuaranteeAtMostOnce, wantOverride: true, wantIdempotent: false, wantRequiredAcks: sarama.WaitForLocal, wantMaxOpenRequests: 5},
+		{name: "Enabled Unsupported By Version", idempotent: false, idempotence: "true", version: sarama.V0_10_2_0, wantOverride: false},
+		{name: "Invalid", idempotent: true, idempotence: "yes", wantOverride: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Test Producer With The TestCase's Global Idempotence (And The Settings It Requires)
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.options.ProduceOrdering = testCase.ordering
+			producer.configuration.Version = sarama.V2_0_0_0
+			if testCase.version != (sarama.KafkaVersion{}) {
+				producer.configuration.Version = testCase.version
+			}
+			producer.configuration.Producer.Idempotent = testCase.idempotent
+			producer.configuration.Producer.RequiredAcks = sarama.WaitForAll
+			producer.configuration.Producer.Retry.Max = 3
+			producer.configuration.Net.MaxOpenRequests = 5
+			if testCase.idempotent || testCase.ordering == constants.ProduceOrderingPartition {
+				producer.configuration.Net.MaxOpenRequests = 1
+			}
+
+			// Stub The Kafka Producer Creation Wrapper To Track SyncProducers Created For Overrides
+			var overrideConfigs []*sarama.Config
+			overrideSyncProducer := receivertesting.NewMockSyncProducer()
+			createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+			createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+				overrideConfigs = append(overrideConfigs, config)
+				return overrideSyncProducer, gometrics.NewRegistry(), nil
+			}
+			defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+
+			// Produce Two Messages With The TestCase's Idempotence (And Delivery Guarantee) Override
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+			ctx := WithIdempotence(WithDeliveryGuarantee(context.Background(), testCase.guarantee), testCase.idempotence)
+			for i := 0; i < 2; i++ {
+				err := producer.ProduceKafkaMessage(ctx, channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				assert.Nil(t, err)
+
+				// Verify The Message Was Produced By The Expected SyncProducer
+				var producerMessage sarama.ProducerMessage
+				if testCase.wantOverride {
+					producerMessage = overrideSyncProducer.GetMessage()
+				} else {
+					producerMessage = mockSyncProducer.GetMessage()
+				}
+				assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
+			}
+
+			// Verify A Single Override SyncProducer Was Created (If Expected) Without Affecting The Global Configuration
+			if testCase.wantOverride {
+				assert.Len(t, overrideConfigs, 1)
+				assert.Equal(t, testCase.wantIdempotent, overrideConfigs[0].Producer.Idempotent)
+				assert.Equal(t, testCase.wantRequiredAcks, overrideConfigs[0].Producer.RequiredAcks)
+				assert.Equal(t, testCase.wantMaxOpenRequests, overrideConfigs[0].Net.MaxOpenRequests)
+			} else {
+				assert.Len(t, overrideConfigs, 0)
+			}
+			assert.Equal(t, testCase.idempotent, producer.configuration.Producer.Idempotent)
+			assert.Equal(t, sarama.WaitForAll, producer.configuration.Producer.RequiredAcks)
+
+			// Verify Closing The Producer Closes Any Override SyncProducer
+			producer.Close()
+			assert.True(t, mockSyncProducer.Closed())
+			assert.Equal(t, testCase.wantOverride, overrideSyncProducer.Closed())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// Configuration For Reporting The Time Taken To Produce Events To Kafka
+type LatencyConfig struct {
+	Header bool          // Whether To Include The Produce Latency In The Response (See LatencyHandler)
+	Slo    time.Duration // Produce Latency Above Which The SLO Is Exceeded (Disabled If Zero)
+}
+
+// Create The LatencyConfig For The Specified Configuration (Validating The SLO)
+func NewLatencyConfig(config commonconfig.EKReceiverLatencyConfig) (LatencyConfig, error) {
+	if config.SloMillis < 0 {
+		return LatencyConfig{}, fmt.Errorf("invalid produce latency SLO %dms - must be >= 0", config.SloMillis)
+	}
+	return LatencyConfig{Header: config.Header, Slo: time.Duration(config.SloMillis) * time.Millisecond}, nil
+}
+
+//
+// Report The Time Taken To Produce An Event Which Was Successfully Produced
+//
+// Produce latency exceeding the SLO (indicating slow brokers) is logged and counted in the
+// produce_latency_slo_exceeded_count metric without failing the request, and the latency is recorded
+// against the request's context so that a LatencyHandler can include it in the response.
+//
+func (p *Producer) reportLatency(ctx context.Context, logger *zap.Logger, topicName string, latency time.Duration) {
+	if p.options.LatencyConfig.Slo > 0 && latency > p.options.LatencyConfig.Slo {
+		logger.Warn("Produce Latency Exceeded SLO", zap.Duration("Latency", latency), zap.Duration("Slo", p.options.LatencyConfig.Slo))
+		p.options.StatsReporter.ReportProduceLatencySloExceeded(topicName)
+	}
+	if holder, ok := ctx.Value(produceLatencyKey{}).(*time.Duration); ok {
+		*holder = latency
+	}
+}
+
+// Context Key For The Produce Latency Of The Event Handled By A Request
+type produceLatencyKey struct{}
+
+//
+// HTTP Handler Which Includes The Produce Latency Of The Event In The Response
+//
+// As with the UnknownTopicHandler, the Knative Eventing MessageReceiver provides no means of adding response
+// headers, so the Producer records the latency against the request's context, and this handler adds it as the
+// constants.ProduceLatencyHeader (in milliseconds) to responses for events which were successfully produced.
+//
+type LatencyHandler struct {
+	next http.Handler
+}
+
+// Verify The LatencyHandler Implements The http.Handler Interface
+var _ http.Handler = &LatencyHandler{}
+
+// LatencyHandler Constructor
+func NewLatencyHandler(next http.Handler) *LatencyHandler {
+	return &LatencyHandler{next: next}
+}
+
+// Delegate To The Next Handler, Adding The Produce Latency (If Recorded) To Its Response
+func (h *LatencyHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	latency := time.Duration(-1)
+	ctx := context.WithValue(request.Context(), produceLatencyKey{}, &latency)
+	h.next.ServeHTTP(&latencyResponseWriter{ResponseWriter: response, latency: &latency}, request.WithContext(ctx))
+}
+
+// ResponseWriter Which Adds The Recorded Produce Latency (If Any) Header Before The Status Code Is Written
+type latencyResponseWriter struct {
+	http.ResponseWriter
+	latency     *time.Duration
+	wroteHeader bool
+}
+
+// Add The Produce Latency Header (If Recorded) & Write The Status Code
+func (w *latencyResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader && *w.latency >= 0 {
+		w.Header().Set(constants.ProduceLatencyHeader, strconv.FormatInt(w.latency.Milliseconds(), 10))
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write The Body (Implicitly Writing An OK Status Code First As Per The http.ResponseWriter)
+func (w *latencyResponseWriter) Write(body []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(body)
+}
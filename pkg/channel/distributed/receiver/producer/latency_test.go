@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+)
+
+// Test The NewLatencyConfig() Functionality
+func TestNewLatencyConfig(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKReceiverLatencyConfig
+		want    LatencyConfig
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", config: commonconfig.EKReceiverLatencyConfig{}, want: LatencyConfig{}},
+		{
+			name:   "Header & SLO",
+			config: commonconfig.EKReceiverLatencyConfig{Header: true, SloMillis: 250},
+			want:   LatencyConfig{Header: true, Slo: 250 * time.Millisecond},
+		},
+		{name: "Negative SLO", config: commonconfig.EKReceiverLatencyConfig{SloMillis: -1}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			latencyConfig, err := NewLatencyConfig(testCase.config)
+			assert.Equal(t, testCase.want, latencyConfig)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test That The Produce Latency Is Included In The Response & Reported When It Exceeds The SLO
+func TestProduceKafkaMessageLatency(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name              string
+		slo               time.Duration
+		delay             time.Duration
+		sendErr           error
+		wantSloExceeded   int
+		wantHeader        bool
+		wantMinimumMillis int64
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:       "Within SLO",
+			slo:        time.Minute,
+			wantHeader: true,
+		},
+		{
+			name:              "Exceeds SLO",
+			slo:               10 * time.Millisecond,
+			delay:             50 * time.Millisecond,
+			wantSloExceeded:   1,
+			wantHeader:        true,
+			wantMinimumMillis: 50,
+		},
+		{
+			name:              "SLO Disabled",
+			delay:             50 * time.Millisecond,
+			wantHeader:        true,
+			wantMinimumMillis: 50,
+		},
+		{
+			name:    "Produce Failure",
+			slo:     10 * time.Millisecond,
+			delay:   50 * time.Millisecond,
+			sendErr: errors.New("test produce error"),
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With A (Mock) Kafka SyncProducer Taking The Specified Time To Produce
+			syncProducer := &slowSyncProducer{delay: testCase.delay, err: testCase.sendErr}
+			producer := createTestProducer(t, syncProducer)
+			producer.options.LatencyConfig = LatencyConfig{Header: true, Slo: testCase.slo}
+			statsReporter := receivertesting.NewMockStatsReporter()
+			producer.options.StatsReporter = statsReporter
+
+			// Create The LatencyHandler Wrapping A Handler Which Produces & Responds As The Knative MessageReceiver Would
+			next := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+				err := producer.ProduceKafkaMessage(request.Context(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				if err != nil {
+					response.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				response.WriteHeader(http.StatusAccepted)
+			})
+			handler := NewLatencyHandler(next)
+
+			// Perform The Test
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "http://"+receivertesting.ChannelName+"/", nil))
+
+			// Verify The Request Only Failed If Producing Failed
+			if testCase.sendErr != nil {
+				assert.Equal(t, http.StatusInternalServerError, response.Code)
+			} else {
+				assert.Equal(t, http.StatusAccepted, response.Code)
+			}
+
+			// Verify The Produce Latency Header (If Expected)
+			latencyHeader := response.Header().Get(constants.ProduceLatencyHeader)
+			if testCase.wantHeader {
+				latencyMillis, err := strconv.ParseInt(latencyHeader, 10, 64)
+				assert.Nil(t, err)
+				assert.GreaterOrEqual(t, latencyMillis, testCase.wantMinimumMillis)
+			} else {
+				assert.Empty(t, latencyHeader)
+			}
+
+			// Verify The Produce Latency SLO Metric
+			assert.Equal(t, testCase.wantSloExceeded, statsReporter.LatencySloExceeded[receivertesting.TopicName])
+		})
+	}
+}
+
+// Test That The LatencyHandler Writes An Implicit OK Status (Without A Latency) When No Event Was Produced
+func TestLatencyHandlerImplicitStatus(t *testing.T) {
+	handler := NewLatencyHandler(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		_, _ = response.Write([]byte("ok"))
+	}))
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "http://"+receivertesting.ChannelName+"/", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "ok", response.Body.String())
+	assert.Empty(t, response.Header().Get(constants.ProduceLatencyHeader))
+}
+
+//
+// Kafka SyncProducer Which Takes The Specified Time To Produce Each Message
+//
+type slowSyncProducer struct {
+	sarama.SyncProducer
+	delay time.Duration
+	err   error
+}
+
+func (p *slowSyncProducer) SendMessage(_ *sarama.ProducerMessage) (int32, int64, error) {
+	time.Sleep(p.delay)
+	if p.err != nil {
+		return -1, -1, p.err
+	}
+	return 1, 1, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+//
+// Validate The Specified Produce Ordering & Return It (Or The Default If Unspecified)
+//
+// The SyncProducer is a thin wrapper around Sarama's AsyncProducer, so concurrently received events are pipelined
+// to the brokers with up to Net.MaxOpenRequests requests in flight.  When one of those requests is retried, its
+// messages can be written after those of a later request, re-ordering events with the same key.  Ordering is only
+// meaningful for events which are produced directly, so it cannot be combined with the local disk spool (which
+// replays its messages later, interleaved with newly received events).
+//
+func ParseProduceOrdering(ordering string, spoolConfig SpoolConfig) (string, error) {
+	switch ordering {
+	case "":
+		return constants.DefaultProduceOrdering, nil
+	case constants.ProduceOrderingThroughput:
+		return ordering, nil
+	case constants.ProduceOrderingPartition, constants.ProduceOrderingKey:
+		if len(spoolConfig.Directory) > 0 {
+			return "", fmt.Errorf("produce ordering '%s' cannot be combined with the receiver spool - spooled messages are replayed out of order", ordering)
+		}
+		return ordering, nil
+	default:
+		return "", fmt.Errorf("invalid produce ordering '%s' - must be one of '%s', '%s' or '%s'", ordering,
+			constants.ProduceOrderingThroughput, constants.ProduceOrderingPartition, constants.ProduceOrderingKey)
+	}
+}
+
+// Constrain The Sarama Config As Required By The Specified Produce Ordering (Only One Request In Flight Per Broker)
+func applyProduceOrdering(logger *zap.Logger, config *sarama.Config, ordering string) {
+	if ordering == constants.ProduceOrderingPartition && config.Net.MaxOpenRequests != 1 {
+		logger.Info("Limiting Sarama Net.MaxOpenRequests To 1 For Ordered Production", zap.Int("MaxOpenRequests", config.Net.MaxOpenRequests))
+		config.Net.MaxOpenRequests = 1
+	}
+}
+
+// Lock Per Topic & Message Key, Tracking The Number Of Messages Holding Or Waiting On It
+type keyLock struct {
+	sync.Mutex
+	references int
+}
+
+// Serializes The Production Of Messages With The Same Topic & Key (Messages Without A Key Are Not Serialized)
+type keySerializer struct {
+	lock  sync.Mutex
+	locks map[string]*keyLock
+}
+
+// Create A New keySerializer
+func newKeySerializer() *keySerializer {
+	return &keySerializer{locks: make(map[string]*keyLock)}
+}
+
+// Block Until No Other Message With The Same Topic & Key Is Being Produced & Return The Function Which Releases It
+func (s *keySerializer) acquire(producerMessage *sarama.ProducerMessage) (func(), error) {
+
+	// Messages Without A Key Are Partitioned Arbitrarily & So Have No Order To Preserve
+	if producerMessage.Key == nil {
+		return func() {}, nil
+	}
+	key, err := producerMessage.Key.Encode()
+	if err != nil {
+		return nil, err
+	}
+	lockKey := producerMessage.Topic + "/" + string(key)
+
+	// Reference The Lock For The Topic & Key (Creating It If Necessary)
+	s.lock.Lock()
+	lock, ok := s.locks[lockKey]
+	if !ok {
+		lock = &keyLock{}
+		s.locks[lockKey] = lock
+	}
+	lock.references++
+	s.lock.Unlock()
+
+	// Wait For Any Previous Message With The Same Topic & Key, Releasing (& Removing If Unreferenced) When Done
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		s.lock.Lock()
+		lock.references--
+		if lock.references <= 0 {
+			delete(s.locks, lockKey)
+		}
+		s.lock.Unlock()
+	}, nil
+}
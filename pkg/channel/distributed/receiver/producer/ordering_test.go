@@ -0,0 +1,276 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+// Test The ParseProduceOrdering() Functionality
+func TestParseProduceOrdering(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		ordering     string
+		spoolConfig  SpoolConfig
+		wantOrdering string
+		wantErr      bool
+	}
+
+	// Define The TestCases
+	spoolConfig := SpoolConfig{Directory: "/spool"}
+	testCases := []TestCase{
+		{name: "Default", wantOrdering: constants.DefaultProduceOrdering},
+		{name: "Throughput", ordering: constants.ProduceOrderingThroughput, wantOrdering: constants.ProduceOrderingThroughput},
+		{name: "Throughput With Spool", ordering: constants.ProduceOrderingThroughput, spoolConfig: spoolConfig, wantOrdering: constants.ProduceOrderingThroughput},
+		{name: "Partition", ordering: constants.ProduceOrderingPartition, wantOrdering: constants.ProduceOrderingPartition},
+		{name: "Partition With Spool", ordering: constants.ProduceOrderingPartition, spoolConfig: spoolConfig, wantErr: true},
+		{name: "Key", ordering: constants.ProduceOrderingKey, wantOrdering: constants.ProduceOrderingKey},
+		{name: "Key With Spool", ordering: constants.ProduceOrderingKey, spoolConfig: spoolConfig, wantErr: true},
+		{name: "Invalid", ordering: "strict", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ordering, err := ParseProduceOrdering(testCase.ordering, testCase.spoolConfig)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantOrdering, ordering)
+		})
+	}
+}
+
+// Test The applyProduceOrdering() Functionality
+func TestApplyProduceOrdering(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name                string
+		ordering            string
+		maxOpenRequests     int
+		wantMaxOpenRequests int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Throughput", ordering: constants.ProduceOrderingThroughput, maxOpenRequests: 5, wantMaxOpenRequests: 5},
+		{name: "Partition", ordering: constants.ProduceOrderingPartition, maxOpenRequests: 5, wantMaxOpenRequests: 1},
+		{name: "Partition Already Constrained", ordering: constants.ProduceOrderingPartition, maxOpenRequests: 1, wantMaxOpenRequests: 1},
+		{name: "Key", ordering: constants.ProduceOrderingKey, maxOpenRequests: 5, wantMaxOpenRequests: 5},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := sarama.NewConfig()
+			config.Net.MaxOpenRequests = testCase.maxOpenRequests
+			applyProduceOrdering(logtesting.TestLogger(t).Desugar(), config, testCase.ordering)
+			assert.Equal(t, testCase.wantMaxOpenRequests, config.Net.MaxOpenRequests)
+			assert.Nil(t, config.Validate())
+		})
+	}
+}
+
+// Test The Per-Key Ordering Of Concurrently Produced Messages When The First Of Them Is Retried
+func TestProduceKafkaMessageOrdering(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		ordering  string
+		secondKey string
+		wantIds   []string
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Throughput Same Key Re-Ordered", ordering: constants.ProduceOrderingThroughput, secondKey: "key-1", wantIds: []string{"2", "1"}},
+		{name: "Key Same Key Ordered", ordering: constants.ProduceOrderingKey, secondKey: "key-1", wantIds: []string{"1", "2"}},
+		{name: "Key Different Keys Not Serialized", ordering: constants.ProduceOrderingKey, secondKey: "key-2", wantIds: []string{"2", "1"}},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With The Specified Produce Ordering Whose First Message Is Retried
+			syncProducer := newRetryingSyncProducer()
+			producer := createTestProducer(t, syncProducer)
+			if testCase.ordering == constants.ProduceOrderingKey {
+				producer.keySerializer = newKeySerializer()
+			}
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+			// Produce The First Message & (Once It Is In Flight) The Second Message Concurrently
+			waitGroup := sync.WaitGroup{}
+			waitGroup.Add(2)
+			go func() {
+				defer waitGroup.Done()
+				assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, createKeyedBindingMessage("1", "key-1")))
+			}()
+			<-syncProducer.inFlight
+			go func() {
+				defer waitGroup.Done()
+				assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, createKeyedBindingMessage("2", testCase.secondKey)))
+			}()
+			waitGroup.Wait()
+
+			// Verify The Order In Which The Messages Were Written
+			assert.Equal(t, testCase.wantIds, syncProducer.writtenIds(t))
+			if producer.keySerializer != nil {
+				assert.Empty(t, producer.keySerializer.locks)
+			}
+		})
+	}
+}
+
+// Test That The Produce Ordering Constrains The Producer (Including When Its Configuration Changes)
+func TestNewProducerOrdering(t *testing.T) {
+
+	// Stub The Kafka Producer Creation Wrapper With Test Version Returning A Mock SyncProducer
+	createSyncProducerWrapperPlaceholder := createSyncProducerWrapper
+	createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
+		return receivertesting.NewMockSyncProducer(), gometrics.NewRegistry(), nil
+	}
+	defer func() { createSyncProducerWrapper = createSyncProducerWrapperPlaceholder }()
+	assert.Nil(t, os.Setenv(system.NamespaceEnvKey, commonconstants.KnativeEventingNamespace))
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name                string
+		ordering            string
+		wantMaxOpenRequests int
+		wantKeySerializer   bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Throughput", ordering: constants.ProduceOrderingThroughput, wantMaxOpenRequests: 5},
+		{name: "Partition", ordering: constants.ProduceOrderingPartition, wantMaxOpenRequests: 1},
+		{name: "Key", ordering: constants.ProduceOrderingKey, wantMaxOpenRequests: 5, wantKeySerializer: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With The Specified Produce Ordering
+			logger := logtesting.TestLogger(t).Desugar()
+			config := sarama.NewConfig()
+			producer, err := NewProducer(logger, config, ProducerOptions{
+				Brokers:            []string{receivertesting.KafkaBrokers},
+				PartitionKeyPolicy: constants.DefaultPartitionKeyPolicy,
+				EmptyDataPolicy:    constants.DefaultEmptyDataPolicy,
+				ProduceOrdering:    testCase.ordering,
+				UnknownTopicConfig: UnknownTopicConfig{Policy: constants.DefaultUnknownTopicPolicy},
+				StatsReporter:      receivertesting.NewMockStatsReporter(),
+				HealthServer:       channelhealth.NewChannelHealthServer("12345"),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.wantMaxOpenRequests, config.Net.MaxOpenRequests)
+			assert.Equal(t, testCase.wantKeySerializer, producer.keySerializer != nil)
+
+			// Verify The Reconfigured Producer Is Constrained & Not Needlessly Recreated For The Same ConfigMap
+			reconfiguredProducer := producer.ConfigChanged(getBaseConfigMap())
+			assert.NotNil(t, reconfiguredProducer)
+			assert.Equal(t, testCase.wantMaxOpenRequests, reconfiguredProducer.configuration.Net.MaxOpenRequests)
+			assert.Equal(t, testCase.wantKeySerializer, reconfiguredProducer.keySerializer != nil)
+			assert.Nil(t, reconfiguredProducer.ConfigChanged(getBaseConfigMap()))
+			reconfiguredProducer.Close()
+		})
+	}
+}
+
+// Create A Binding Message With The Specified Id & Partition Key
+func createKeyedBindingMessage(id string, partitionKey string) binding.Message {
+	cloudEvent := receivertesting.CreateCloudEvent(cloudevents.VersionV1)
+	cloudEvent.SetID(id)
+	cloudEvent.SetExtension(constants.ExtensionKeyPartitionKey, partitionKey)
+	return binding.ToMessage(cloudEvent)
+}
+
+//
+// Kafka SyncProducer Simulating A Retry Of The First Message Sent
+//
+// The first message is only written once another message has been written (or after a short timeout), as when
+// Sarama retries a request while a later request to the same partition succeeds.
+//
+type retryingSyncProducer struct {
+	sarama.SyncProducer
+	lock     sync.Mutex
+	sent     bool
+	inFlight chan struct{}
+	written  chan struct{}
+	messages []*sarama.ProducerMessage
+}
+
+func newRetryingSyncProducer() *retryingSyncProducer {
+	return &retryingSyncProducer{inFlight: make(chan struct{}), written: make(chan struct{}, 1)}
+}
+
+func (p *retryingSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	p.lock.Lock()
+	retry := !p.sent
+	p.sent = true
+	p.lock.Unlock()
+	if retry {
+		close(p.inFlight)
+		select {
+		case <-p.written:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.messages = append(p.messages, message)
+	select {
+	case p.written <- struct{}{}:
+	default:
+	}
+	return 1, int64(len(p.messages)), nil
+}
+
+func (p *retryingSyncProducer) Close() error {
+	return nil
+}
+
+func (p *retryingSyncProducer) writtenIds(t *testing.T) []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	ids := make([]string, 0, len(p.messages))
+	for _, message := range p.messages {
+		ids = append(ids, string(receivertesting.GetProducerMessageHeader(t, message.Headers, constants.CeKafkaHeaderKeyId).Value))
+	}
+	return ids
+}
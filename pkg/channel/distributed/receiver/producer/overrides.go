@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/delivery"
+)
+
+// The KafkaChannel Overrides Of The Sarama Producer Configuration Carried By The Context Of ProduceKafkaMessage()
+type producerOverride struct {
+	codec       string // Compression Codec (See WithCompressionCodec())
+	guarantee   string // Delivery Guarantee (See WithDeliveryGuarantee())
+	idempotence string // Producer Idempotence (See WithIdempotence())
+}
+
+//
+// Get The SyncProducer To Use For The Specified KafkaChannel Overrides
+//
+// Sarama applies a single configuration to all the messages of a producer, so KafkaChannels overriding the (global)
+// compression codec, producer settings or idempotence of the Sarama configuration are produced by an additional
+// SyncProducer per combination of overrides, created on first use from a copy of the configuration (sharing its
+// metrics registry).
+// Overrides which are invalid, or unsupported by the configured Kafka version, are logged once and ignored.
+//
+func (p *Producer) syncProducer(logger *zap.Logger, override producerOverride) (sarama.SyncProducer, error) {
+
+	// No Overrides (Or Configuration To Base Them On) - Use The Default SyncProducer
+	if override == (producerOverride{}) || p.configuration == nil {
+		return p.kafkaProducer, nil
+	}
+
+	p.overrideLock.Lock()
+	defer p.overrideLock.Unlock()
+
+	// Use Any SyncProducer Previously Determined For The Overrides
+	if syncProducer, ok := p.overrideProducers[override]; ok {
+		return syncProducer, nil
+	}
+
+	// Apply The Valid Overrides To A Copy Of The Configuration
+	config := *p.configuration
+	if len(override.codec) > 0 {
+		compressionCodec, err := ParseCompressionCodec(override.codec, config.Version)
+		if err != nil {
+			logger.Warn("Invalid KafkaChannel Compression Codec - Using Default Compression", zap.String("Codec", override.codec), zap.Error(err))
+		} else if compressionCodec != config.Producer.Compression {
+			config.Producer.Compression = compressionCodec
+			config.Producer.CompressionLevel = sarama.CompressionLevelDefault // The Codec's Default Level
+		}
+	}
+	var guaranteeSettings *delivery.Settings
+	if len(override.guarantee) > 0 {
+		guaranteeConfig, err := delivery.ConfigureProducer(&config, override.guarantee)
+		if err != nil {
+			logger.Warn("Invalid KafkaChannel Delivery Guarantee - Using Default Producer Settings", zap.String("Guarantee", override.guarantee), zap.Error(err))
+		} else {
+			config = *guaranteeConfig
+			guaranteeSettings, _ = delivery.GetSettings(override.guarantee)
+		}
+	}
+	if len(override.idempotence) > 0 {
+		idempotenceConfig, err := configureIdempotence(&config, override.idempotence, guaranteeSettings, p.options.ProduceOrdering)
+		if err != nil {
+			logger.Warn("Invalid KafkaChannel Idempotence - Using Default Producer Idempotence", zap.String("Idempotence", override.idempotence), zap.Error(err))
+		} else {
+			config = *idempotenceConfig
+		}
+	}
+
+	// Use The Default SyncProducer If The Overrides Match The Global Configuration (Or Were Invalid)
+	if !producerConfigChanged(p.configuration, &config) {
+		p.overrideProducers[override] = p.kafkaProducer
+		return p.kafkaProducer, nil
+	}
+
+	// Create A New SyncProducer With The Overridden Configuration
+	syncProducer, _, err := createSyncProducerWrapper(&config, p.options.Brokers)
+	if err != nil {
+		logger.Error("Failed To Create Kafka SyncProducer For KafkaChannel Overrides", zap.String("Codec", override.codec), zap.String("Guarantee", override.guarantee), zap.String("Idempotence", override.idempotence), zap.Error(err))
+		return nil, err
+	}
+	logger.Info("Created Kafka SyncProducer For KafkaChannel Overrides", zap.String("Codec", override.codec), zap.String("Guarantee", override.guarantee), zap.String("Idempotence", override.idempotence))
+	p.overrideProducers[override] = syncProducer
+	return syncProducer, nil
+}
+
+// Determine Whether Any Of The Settings Which May Be Overridden By A KafkaChannel Differ Between The Configurations
+func producerConfigChanged(config, overriddenConfig *sarama.Config) bool {
+	return config.Producer.Compression != overriddenConfig.Producer.Compression ||
+		config.Producer.CompressionLevel != overriddenConfig.Producer.CompressionLevel ||
+		config.Producer.Idempotent != overriddenConfig.Producer.Idempotent ||
+		config.Producer.RequiredAcks != overriddenConfig.Producer.RequiredAcks ||
+		config.Producer.Retry.Max != overriddenConfig.Producer.Retry.Max ||
+		config.Net.MaxOpenRequests != overriddenConfig.Net.MaxOpenRequests
+}
+
+// Close The SyncProducers Created For KafkaChannel Overrides
+func (p *Producer) closeOverrideProducers() {
+	p.overrideLock.Lock()
+	defer p.overrideLock.Unlock()
+	for override, syncProducer := range p.overrideProducers {
+		if syncProducer != p.kafkaProducer {
+			err := syncProducer.Close()
+			if err != nil {
+				p.logger.Error("Failed To Close Kafka SyncProducer For KafkaChannel Overrides", zap.String("Codec", override.codec), zap.String("Guarantee", override.guarantee), zap.String("Idempotence", override.idempotence), zap.Error(err))
+			}
+		}
+	}
+	p.overrideProducers = make(map[producerOverride]sarama.SyncProducer)
+}
@@ -19,6 +19,9 @@ package producer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -27,8 +30,13 @@ import (
 	gometrics "github.com/rcrowley/go-metrics"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
 	kafkaproducer "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	kafkasarama "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/schemaregistry"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
@@ -40,26 +48,57 @@ import (
 type Producer struct {
 	logger             *zap.Logger
 	kafkaProducer      sarama.SyncProducer
-	healthServer       *health.Server
-	statsReporter      metrics.StatsReporter
 	metricsRegistry    gometrics.Registry
 	metricsStopChan    chan struct{}
 	metricsStoppedChan chan struct{}
 	configuration      *sarama.Config
-	brokers            []string
+	options            ProducerOptions
+	keySerializer      *keySerializer                           // Serializes Messages With The Same Topic & Key For The "key" Produce Ordering (Otherwise Nil)
+	spool              *spool                                   // Optional Local Disk Spool Of Messages Which Could Not Be Produced (Nil If Disabled)
+	auditor            *auditor                                 // Optional Delivery Of Produced Record Metadata To An External Audit Sink (Nil If Disabled)
+	overrideProducers  map[producerOverride]sarama.SyncProducer // SyncProducers By KafkaChannel Compression Codec, Delivery Guarantee & Idempotence Overrides
+	overrideLock       sync.Mutex
+}
+
+// Options For Creating A Producer (Everything Other Than The Sarama Config, Which Is Replaced By ConfigChanged())
+type ProducerOptions struct {
+	Brokers              []string              // The Kafka Brokers To Produce To
+	ProvenanceConfig     ProvenanceConfig      // Tagging Of Produced Messages With The Receiver Pod & KafkaChannel
+	PartitionKeyPolicy   string                // Use Of The "partitionkey" Extension As The Record Key (One Of The constants.PartitionKeyPolicy* Values)
+	EmptyDataPolicy      string                // Handling Of Events Without Data (One Of The constants.EmptyDataPolicy* Values)
+	ProduceOrdering      string                // Ordering Of Produced Messages (One Of The constants.ProduceOrdering* Values)
+	SchemaRegistryConfig SchemaRegistryConfig  // Framing Of Produced Message Values In The Schema Registry Wire Format
+	UnknownTopicConfig   UnknownTopicConfig    // Handling Of Messages Produced To Topics Which Do Not (Yet) Exist
+	SpoolConfig          SpoolConfig           // Local Disk Spool Of Messages Which Could Not Be Produced
+	AuditConfig          AuditConfig           // Delivery Of Produced Record Metadata To An External Audit Sink
+	LatencyConfig        LatencyConfig         // Reporting Of The Produce Latency
+	StatsReporter        metrics.StatsReporter // Reporter Of The Producer's Metrics
+	HealthServer         *health.Server        // Health Server Whose Producer Readiness Is Maintained
+}
+
+// Provenance Configuration For Tagging Produced Kafka Messages With The Receiver Pod & KafkaChannel
+type ProvenanceConfig struct {
+	Enabled bool   // Whether To Add The Provenance Kafka Headers To Produced Messages
+	PodName string // The Name Of The Receiver Pod Producing The Messages
+}
+
+// Schema Registry Configuration For Framing Produced Kafka Message Values In The Schema Registry Wire Format
+type SchemaRegistryConfig struct {
+	Client     schemaregistry.ClientInterface // The Schema Registry Client (Nil Disables Framing)
+	SchemaType string                         // The Type Of The Schema Registered For Each Topic (e.g. "JSON")
+	Schema     string                         // The Schema Registered For Each Topic
 }
 
 // Initialize The Producer
-func NewProducer(logger *zap.Logger,
-	config *sarama.Config,
-	brokers []string,
-	statsReporter metrics.StatsReporter,
-	healthServer *health.Server) (*Producer, error) {
+func NewProducer(logger *zap.Logger, config *sarama.Config, options ProducerOptions) (*Producer, error) {
+
+	// Constrain The Sarama Config As Required By The Produce Ordering
+	applyProduceOrdering(logger, config, options.ProduceOrdering)
 
 	// Create The Kafka Producer Using The Specified Kafka Authentication
-	kafkaProducer, metricsRegistry, err := createSyncProducerWrapper(config, brokers)
+	kafkaProducer, metricsRegistry, err := createSyncProducerWrapper(config, options.Brokers)
 	if err != nil {
-		logger.Error("Failed To Create Kafka SyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", brokers))
+		logger.Error("Failed To Create Kafka SyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", options.Brokers))
 		return nil, err
 	} else {
 		logger.Info("Successfully Created Kafka SyncProducer")
@@ -69,32 +108,106 @@ func NewProducer(logger *zap.Logger,
 	producer := &Producer{
 		logger:             logger,
 		kafkaProducer:      kafkaProducer,
-		healthServer:       healthServer,
-		statsReporter:      statsReporter,
 		metricsRegistry:    metricsRegistry,
 		metricsStopChan:    make(chan struct{}),
 		metricsStoppedChan: make(chan struct{}),
 		configuration:      config,
-		brokers:            brokers,
+		options:            options,
+		overrideProducers:  make(map[producerOverride]sarama.SyncProducer),
+	}
+
+	// Serialize Messages With The Same Topic & Key (If Required By The Produce Ordering)
+	if options.ProduceOrdering == constants.ProduceOrderingKey {
+		producer.keySerializer = newKeySerializer()
+	}
+
+	// Start Delivering Produced Record Metadata To The Audit Sink (If Enabled)
+	if len(options.AuditConfig.Url) > 0 {
+		producer.auditor = newAuditor(logger, options.AuditConfig, options.StatsReporter)
+		producer.auditor.start()
+	}
+
+	// Create The Local Disk Spool & Start Replaying Any Spooled Messages (If Enabled)
+	if len(options.SpoolConfig.Directory) > 0 {
+		producer.spool, err = newSpool(logger, options.SpoolConfig, options.StatsReporter)
+		if err != nil {
+			logger.Error("Failed To Create Spool - Exiting", zap.Error(err), zap.String("Directory", options.SpoolConfig.Directory))
+			if producer.auditor != nil {
+				producer.auditor.stop()
+			}
+			_ = kafkaProducer.Close()
+			return nil, err
+		}
+		producer.spool.auditor = producer.auditor
+		producer.spool.start(func(override producerOverride) (sarama.SyncProducer, error) {
+			return producer.syncProducer(logger, override)
+		})
 	}
 
 	// Start Observing Metrics
 	producer.ObserveMetrics(constants.MetricsInterval)
 
 	// Mark The Producer As Ready
-	healthServer.SetProducerReady(true)
+	options.HealthServer.SetProducerReady(true)
 
 	// Return The New Producer
 	logger.Info("Successfully Started Kafka Producer")
 	return producer, nil
 }
 
+// Validate The Specified PartitionKeyPolicy & Return It (Or The Default If Unspecified)
+func ParsePartitionKeyPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return constants.DefaultPartitionKeyPolicy, nil
+	case constants.PartitionKeyPolicyHonor, constants.PartitionKeyPolicyIgnore:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid partition key policy '%s' - must be one of '%s' or '%s'", policy,
+			constants.PartitionKeyPolicyHonor, constants.PartitionKeyPolicyIgnore)
+	}
+}
+
+// Create The SchemaRegistryConfig For The Specified Configuration (Framing Is Disabled If No Registry URL Is Specified)
+func NewSchemaRegistryConfig(config commonconfig.EKKafkaSchemaRegistryConfig, username string, password string) (SchemaRegistryConfig, error) {
+
+	// No Registry URL - Framing Disabled
+	if len(config.Url) <= 0 {
+		return SchemaRegistryConfig{}, nil
+	}
+
+	// Validate The Schema Type (Defaulting If Unspecified)
+	schemaType := strings.ToUpper(config.SchemaType)
+	switch schemaType {
+	case "":
+		schemaType = schemaregistry.DefaultSchemaType
+	case schemaregistry.SchemaTypeJson, schemaregistry.SchemaTypeAvro, schemaregistry.SchemaTypeProto:
+	default:
+		return SchemaRegistryConfig{}, fmt.Errorf("invalid schema registry schema type '%s' - must be one of '%s', '%s' or '%s'", config.SchemaType,
+			schemaregistry.SchemaTypeJson, schemaregistry.SchemaTypeAvro, schemaregistry.SchemaTypeProto)
+	}
+
+	// Default The Schema If Unspecified
+	schema := config.Schema
+	if len(schema) <= 0 {
+		schema = schemaregistry.DefaultSchema
+	}
+
+	// Return The SchemaRegistryConfig With A New Client
+	return SchemaRegistryConfig{
+		Client:     schemaregistry.NewClient(config.Url, username, password),
+		SchemaType: schemaType,
+		Schema:     schema,
+	}, nil
+}
+
 // Wrapper Around Common Kafka SyncProducer Creation To Facilitate Unit Testing
 var createSyncProducerWrapper = func(config *sarama.Config, brokers []string) (sarama.SyncProducer, gometrics.Registry, error) {
 	return kafkaproducer.CreateSyncProducer(brokers, config)
 }
 
 // Produce A KafkaMessage From The Specified CloudEvent To The Specified Topic And Wait For The Delivery Report
+// (Compressed & Delivered Per Any Overrides Carried By The Context - See WithCompressionCodec(), WithDeliveryGuarantee() & WithIdempotence())
 func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, message binding.Message, transformers ...binding.Transformer) error {
 
 	// Validate The Kafka Producer (Must Be Pre-Initialized)
@@ -110,6 +223,12 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 	// Initialize The Sarama ProducerMessage With The Specified Topic Name
 	producerMessage := &sarama.ProducerMessage{Topic: topicName}
 
+	// The SaramaKafka Protocol Uses The "partitionkey" Extension (If Present) As The Record Key Unless Told Otherwise,
+	// Which Keeps Events Keyed By An Upstream Producer / Channel On The Same Partition Throughout A Chain Of Channels
+	if p.options.PartitionKeyPolicy == constants.PartitionKeyPolicyIgnore {
+		ctx = kafkasaramaprotocol.WithSkipKeyMapping(ctx)
+	}
+
 	// Use The SaramaKafka Protocol To Convert The Binding Message To A ProducerMessage
 	err := kafkasaramaprotocol.WriteProducerMessage(ctx, message, producerMessage, transformers...)
 	if err != nil {
@@ -117,18 +236,90 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 		return err
 	}
 
+	// Produce Events Without Data As A Zero-Length Value, A Null Value (Tombstone), Or Not At All (Per The Policy)
+	err = applyEmptyDataPolicy(producerMessage, p.options.EmptyDataPolicy)
+	if err != nil {
+		logger.Warn("Rejecting Event Without Data", zap.String("Policy", p.options.EmptyDataPolicy))
+		return err
+	}
+
+	// Frame The Kafka Message Value In The Schema Registry Wire Format (If Enabled & Not A Tombstone)
+	if p.options.SchemaRegistryConfig.Client != nil && producerMessage.Value != nil {
+		err = p.frameValue(ctx, producerMessage)
+		if err != nil {
+			logger.Error("Failed To Frame Kafka Message Value In Schema Registry Wire Format", zap.Error(err))
+			return err
+		}
+	}
+
+	// Tag The Kafka Message With Its Provenance (Kafka Headers Only - The CloudEvent Is Not Modified)
+	if p.options.ProvenanceConfig.Enabled {
+		producerMessage.Headers = append(producerMessage.Headers,
+			sarama.RecordHeader{Key: []byte(kafkaconstants.ProvenanceHeaderKeyProducerPod), Value: []byte(p.options.ProvenanceConfig.PodName)},
+			sarama.RecordHeader{Key: []byte(kafkaconstants.ProvenanceHeaderKeyChannel), Value: []byte(channelReference.String())})
+	}
+
+	// Get The SyncProducer For The KafkaChannel's Compression Codec, Delivery Guarantee & Idempotence Overrides (If Any)
+	override := producerOverride{codec: compressionCodecFromContext(ctx), guarantee: deliveryGuaranteeFromContext(ctx), idempotence: idempotenceFromContext(ctx)}
+	kafkaProducer, err := p.syncProducer(logger, override)
+	if err != nil {
+		return err
+	}
+
+	// Wait For Any Previous Message With The Same Topic & Key To Be Delivered (If Required By The Produce Ordering)
+	if p.keySerializer != nil {
+		release, err := p.keySerializer.acquire(producerMessage)
+		if err != nil {
+			logger.Error("Failed To Encode Kafka Message Key", zap.Error(err))
+			return err
+		}
+		defer release()
+	}
+
 	// Produce The Kafka Message To The Kafka Topic
 	logger.Debug("Producing Kafka Message", zap.Any("Headers", producerMessage.Headers), zap.Any("Message", producerMessage.Value))
-	partition, offset, err := p.kafkaProducer.SendMessage(producerMessage)
+	produceStart := time.Now()
+	partition, offset, err := p.sendMessage(ctx, logger, kafkaProducer, producerMessage)
 	if err != nil {
+		if p.spool != nil && IsSpoolableError(err) && override.guarantee != kafkav1beta1.DeliveryGuaranteeAtMostOnce {
+			spoolErr := p.spool.add(producerMessage, override)
+			if spoolErr == nil {
+				logger.Warn("Failed To Send Message To Kafka - Spooled For Replay", zap.Error(err))
+				return nil
+			}
+			logger.Error("Failed To Spool Message", zap.Error(spoolErr))
+		}
 		logger.Error("Failed To Send Message To Kafka", zap.Error(err))
+		recordUnknownTopicError(ctx, err)
 		return err
 	} else {
 		logger.Debug("Successfully Sent Message To Kafka", zap.Int32("Partition", partition), zap.Int64("Offset", offset))
+		p.options.StatsReporter.ReportProducedBytes(topicName, kafkautil.ProducerMessageSize(producerMessage))
+		p.reportLatency(ctx, logger, topicName, time.Since(produceStart))
+		if p.auditor != nil {
+			p.auditor.record(producerMessage, partition, offset)
+		}
 		return nil
 	}
 }
 
+// Prefix The ProducerMessage Value With The Schema Registry Wire Format Header For The Topic's Registered Schema
+func (p *Producer) frameValue(ctx context.Context, producerMessage *sarama.ProducerMessage) error {
+	schemaId, err := p.options.SchemaRegistryConfig.Client.RegisterSchema(ctx, schemaregistry.ValueSubject(producerMessage.Topic), p.options.SchemaRegistryConfig.SchemaType, p.options.SchemaRegistryConfig.Schema)
+	if err != nil {
+		return err
+	}
+	var value []byte
+	if producerMessage.Value != nil {
+		value, err = producerMessage.Value.Encode()
+		if err != nil {
+			return err
+		}
+	}
+	producerMessage.Value = sarama.ByteEncoder(schemaregistry.Frame(schemaId, value))
+	return nil
+}
+
 // Async Process For Observing Kafka Metrics
 func (p *Producer) ObserveMetrics(interval time.Duration) {
 
@@ -150,7 +341,7 @@ func (p *Producer) ObserveMetrics(interval time.Duration) {
 				kafkaMetrics := p.metricsRegistry.GetAll()
 
 				// Forward Metrics To Prometheus For Observation
-				p.statsReporter.Report(kafkaMetrics)
+				p.options.StatsReporter.Report(kafkaMetrics)
 			}
 		}
 	}()
@@ -160,12 +351,25 @@ func (p *Producer) ObserveMetrics(interval time.Duration) {
 func (p *Producer) Close() {
 
 	// Mark The Producer As No Longer Ready
-	p.healthServer.SetProducerReady(false)
+	p.options.HealthServer.SetProducerReady(false)
 
 	// Stop Observing Metrics
 	close(p.metricsStopChan)
 	<-p.metricsStoppedChan
 
+	// Stop Replaying Any Spooled Messages (They Remain Spooled For The Next Producer)
+	if p.spool != nil {
+		p.spool.stop()
+	}
+
+	// Stop Delivering To The Audit Sink (After Any Records Already Queued Have Been Delivered)
+	if p.auditor != nil {
+		p.auditor.stop()
+	}
+
+	// Close Any Compression Codec & Delivery Guarantee Override Producers
+	p.closeOverrideProducers()
+
 	// Close The Kafka Producer & Log Results
 	err := p.kafkaProducer.Close()
 	if err != nil {
@@ -201,6 +405,13 @@ func (p *Producer) ConfigChanged(configMap *v1.ConfigMap) *Producer {
 		// Some of the current config settings may not be overridden by the configmap (username, password, etc.)
 		kafkasarama.UpdateSaramaConfig(newConfig, p.configuration.ClientID, p.configuration.Net.SASL.User, p.configuration.Net.SASL.Password)
 
+		// The SASL mechanism is determined at startup (possibly from the Kafka Secret) so it is also retained
+		newConfig.Net.SASL.Mechanism = p.configuration.Net.SASL.Mechanism
+		newConfig.Net.SASL.SCRAMClientGeneratorFunc = p.configuration.Net.SASL.SCRAMClientGeneratorFunc
+
+		// The current config has been constrained by the produce ordering, so the new one must be as well
+		applyProduceOrdering(p.logger, newConfig, p.options.ProduceOrdering)
+
 		// Ignore the "Admin" and "Consumer" sections when comparing, as changes to those do not require restarting the Producer
 		if kafkasarama.ConfigEqual(newConfig, p.configuration, newConfig.Admin, newConfig.Consumer) {
 			p.logger.Info("No Producer Changes Detected In New Configuration - Ignoring")
@@ -211,7 +422,7 @@ func (p *Producer) ConfigChanged(configMap *v1.ConfigMap) *Producer {
 	// Create A New Producer With The New Configuration (Reusing All Other Existing Config)
 	p.logger.Info("Producer Changes Detected In New Configuration - Closing & Recreating Producer")
 	p.Close()
-	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.statsReporter, p.healthServer)
+	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.options)
 	if err != nil {
 		p.logger.Fatal("Failed To Create Kafka Producer With New Configuration", zap.Error(err))
 		return nil
@@ -19,10 +19,15 @@ package producer
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/Shopify/sarama"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/ghodss/yaml"
 	gometrics "github.com/rcrowley/go-metrics"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +35,8 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/constants"
+	kafkaconstants "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/schemaregistry"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
@@ -121,7 +128,7 @@ func TestNewProducer(t *testing.T) {
 	producer := createTestProducer(t, mockSyncProducer)
 
 	// Verify The Results
-	assert.True(t, producer.healthServer.ProducerReady())
+	assert.True(t, producer.options.HealthServer.ProducerReady())
 }
 
 // Test The ProduceKafkaMessage() Functionality For Event With PartitionKey
@@ -155,6 +162,265 @@ func TestProduceKafkaMessage(t *testing.T) {
 	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeySubject, receivertesting.EventSubject)
 	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyDataSchema, receivertesting.EventDataSchema)
 	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey, receivertesting.PartitionKey)
+	assert.Nil(t, receivertesting.GetProducerMessageHeader(t, producerMessage.Headers, kafkaconstants.ProvenanceHeaderKeyProducerPod))
+	assert.Nil(t, receivertesting.GetProducerMessageHeader(t, producerMessage.Headers, kafkaconstants.ProvenanceHeaderKeyChannel))
+}
+
+// Test The ProduceKafkaMessage() Functionality With Provenance Headers Enabled
+func TestProduceKafkaMessageProvenance(t *testing.T) {
+
+	// Create Test Data
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	producer := createTestProducerWithProvenance(t, mockSyncProducer, ProvenanceConfig{Enabled: true, PodName: receivertesting.PodName})
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	bindingMessage := receivertesting.CreateBindingMessage(cloudevents.VersionV1)
+
+	// Perform The Test & Verify Results
+	err := producer.ProduceKafkaMessage(context.Background(), channelReference, bindingMessage)
+	assert.Nil(t, err)
+
+	// Verify Message Was Produced With The Provenance Headers (CloudEvent Headers Unaffected)
+	producerMessage := mockSyncProducer.GetMessage()
+	assert.NotNil(t, producerMessage)
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, kafkaconstants.ProvenanceHeaderKeyProducerPod, receivertesting.PodName)
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, kafkaconstants.ProvenanceHeaderKeyChannel, receivertesting.ChannelKey)
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyId, receivertesting.EventId)
+	receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey, receivertesting.PartitionKey)
+}
+
+// Test The ParsePartitionKeyPolicy() Functionality
+func TestParsePartitionKeyPolicy(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unspecified", policy: "", want: constants.DefaultPartitionKeyPolicy},
+		{name: "Honor", policy: constants.PartitionKeyPolicyHonor, want: constants.PartitionKeyPolicyHonor},
+		{name: "Ignore", policy: constants.PartitionKeyPolicyIgnore, want: constants.PartitionKeyPolicyIgnore},
+		{name: "Invalid", policy: "random", wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := ParsePartitionKeyPolicy(testCase.policy)
+			assert.Equal(t, testCase.want, policy)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The NewSchemaRegistryConfig() Functionality
+func TestNewSchemaRegistryConfig(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name           string
+		config         commonconfig.EKKafkaSchemaRegistryConfig
+		wantClient     bool
+		wantSchemaType string
+		wantSchema     string
+		wantErr        bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", config: commonconfig.EKKafkaSchemaRegistryConfig{}, wantClient: false},
+		{name: "Disabled With Schema", config: commonconfig.EKKafkaSchemaRegistryConfig{SchemaType: "AVRO", Schema: `"string"`}, wantClient: false},
+		{name: "Defaults", config: commonconfig.EKKafkaSchemaRegistryConfig{Url: "http://registry"}, wantClient: true, wantSchemaType: schemaregistry.SchemaTypeJson, wantSchema: schemaregistry.DefaultSchema},
+		{name: "Lowercase Avro", config: commonconfig.EKKafkaSchemaRegistryConfig{Url: "http://registry", SchemaType: "avro", Schema: `"string"`}, wantClient: true, wantSchemaType: schemaregistry.SchemaTypeAvro, wantSchema: `"string"`},
+		{name: "Invalid SchemaType", config: commonconfig.EKKafkaSchemaRegistryConfig{Url: "http://registry", SchemaType: "xml"}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config, err := NewSchemaRegistryConfig(testCase.config, "username", "password")
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantClient, config.Client != nil)
+			assert.Equal(t, testCase.wantSchemaType, config.SchemaType)
+			assert.Equal(t, testCase.wantSchema, config.Schema)
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality For Events Whose "partitionkey" Was Set By An Upstream Component
+func TestProduceKafkaMessagePartitionKey(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name         string
+		policy       string
+		partitionKey string
+		wantKey      bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Honor Incoming PartitionKey", policy: constants.PartitionKeyPolicyHonor, partitionKey: receivertesting.PartitionKey, wantKey: true},
+		{name: "Honor Without PartitionKey", policy: constants.PartitionKeyPolicyHonor, wantKey: false},
+		{name: "Ignore Incoming PartitionKey", policy: constants.PartitionKeyPolicyIgnore, partitionKey: receivertesting.PartitionKey, wantKey: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With The Specified PartitionKeyPolicy
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.options.PartitionKeyPolicy = testCase.policy
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+			// Create A Binary Content Mode HTTP Request As Sent By An Upstream Knative Component (e.g. Another Dispatcher)
+			request, err := http.NewRequest(http.MethodPost, "http://"+receivertesting.ChannelName, ioutil.NopCloser(strings.NewReader(string(receivertesting.EventDataJson))))
+			assert.Nil(t, err)
+			request.Header.Set("Content-Type", receivertesting.EventDataContentType)
+			request.Header.Set("Ce-Specversion", cloudevents.VersionV1)
+			request.Header.Set("Ce-Id", receivertesting.EventId)
+			request.Header.Set("Ce-Type", receivertesting.EventType)
+			request.Header.Set("Ce-Source", receivertesting.EventSource)
+			if len(testCase.partitionKey) > 0 {
+				request.Header.Set("Ce-Partitionkey", testCase.partitionKey)
+			}
+
+			// Perform The Test
+			err = producer.ProduceKafkaMessage(context.Background(), channelReference, cehttp.NewMessageFromHttpRequest(request))
+			assert.Nil(t, err)
+
+			// Verify The Record Key & That The Extension Is Always Carried Forward As A Header
+			producerMessage := mockSyncProducer.GetMessage()
+			if testCase.wantKey {
+				assert.NotNil(t, producerMessage.Key)
+				key, err := producerMessage.Key.Encode()
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.partitionKey, string(key))
+			} else {
+				assert.Nil(t, producerMessage.Key)
+			}
+			if len(testCase.partitionKey) > 0 {
+				receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey, testCase.partitionKey)
+			} else {
+				assert.Nil(t, receivertesting.GetProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyPartitionKey))
+			}
+		})
+	}
+}
+
+// Test The ProduceKafkaMessage() Functionality Reports The Produced Bytes
+func TestProduceKafkaMessageProducedBytes(t *testing.T) {
+
+	// Create Test Data
+	mockSyncProducer := receivertesting.NewMockSyncProducer()
+	mockStatsReporter := receivertesting.NewMockStatsReporter()
+	producer := createTestProducer(t, mockSyncProducer)
+	producer.options.StatsReporter = mockStatsReporter
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+	// Produce Two Messages
+	assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1)))
+	firstMessage := mockSyncProducer.GetMessage()
+	assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1)))
+	secondMessage := mockSyncProducer.GetMessage()
+
+	// Calculate The Expected Size Of Each Serialized Message (Key + Value + Header Keys & Values)
+	expectedBytes := int64(0)
+	for _, producerMessage := range []sarama.ProducerMessage{firstMessage, secondMessage} {
+		expectedBytes += int64(len(receivertesting.PartitionKey) + len(receivertesting.EventDataJson))
+		for _, header := range producerMessage.Headers {
+			expectedBytes += int64(len(header.Key) + len(header.Value))
+		}
+	}
+
+	// Verify The Produced Bytes Were Reported Against The Topic
+	assert.Equal(t, expectedBytes, mockStatsReporter.ProducedBytes[receivertesting.TopicName])
+}
+
+// Test The ProduceKafkaMessage() Functionality With Schema Registry Framing Enabled
+func TestProduceKafkaMessageSchemaRegistry(t *testing.T) {
+
+	// Test Data
+	schemaId := int32(42)
+	registryErr := errors.New("test registry error")
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name        string
+		registryErr error
+		wantErr     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Registered Schema", registryErr: nil, wantErr: false},
+		{name: "Registry Failure", registryErr: registryErr, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With A Mock Schema Registry Client
+			mockSyncProducer := receivertesting.NewMockSyncProducer()
+			mockRegistryClient := &mockSchemaRegistryClient{schemaId: schemaId, err: testCase.registryErr}
+			producer := createTestProducer(t, mockSyncProducer)
+			producer.options.SchemaRegistryConfig = SchemaRegistryConfig{
+				Client:     mockRegistryClient,
+				SchemaType: schemaregistry.DefaultSchemaType,
+				Schema:     schemaregistry.DefaultSchema,
+			}
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+
+			// Perform The Test
+			err := producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+
+			// Verify The Schema Was Registered Against The Topic's Value Subject
+			assert.Equal(t, schemaregistry.ValueSubject(receivertesting.TopicName), mockRegistryClient.subject)
+			assert.Equal(t, schemaregistry.DefaultSchemaType, mockRegistryClient.schemaType)
+			assert.Equal(t, schemaregistry.DefaultSchema, mockRegistryClient.schema)
+
+			// Verify The Produced Value Is Framed With The Schema ID (Or Nothing Was Produced On Failure)
+			if testCase.wantErr {
+				assert.Equal(t, registryErr, err)
+			} else {
+				assert.Nil(t, err)
+				producerMessage := mockSyncProducer.GetMessage()
+				value, err := producerMessage.Value.Encode()
+				assert.Nil(t, err)
+				unframedSchemaId, payload, err := schemaregistry.Unframe(value)
+				assert.Nil(t, err)
+				assert.Equal(t, schemaId, unframedSchemaId)
+				assert.Equal(t, receivertesting.EventDataJson, payload)
+				receivertesting.ValidateProducerMessageHeader(t, producerMessage.Headers, constants.CeKafkaHeaderKeyId, receivertesting.EventId)
+			}
+		})
+	}
+}
+
+// Mock Schema Registry Client Which Records The Last Registration & Returns The Configured Results
+type mockSchemaRegistryClient struct {
+	schemaId   int32
+	err        error
+	subject    string
+	schemaType string
+	schema     string
+}
+
+func (c *mockSchemaRegistryClient) RegisterSchema(_ context.Context, subject string, schemaType string, schema string) (int32, error) {
+	c.subject = subject
+	c.schemaType = schemaType
+	c.schema = schema
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.schemaId, nil
 }
 
 func getBaseConfigMap() *corev1.ConfigMap {
@@ -220,6 +486,9 @@ func runConfigChangedTest(t *testing.T, originalProducer *Producer, base *corev1
 
 	// Verify that a new producer was created or not, as expected
 	assert.Equal(t, expectedNewProducer, newProducer != nil)
+	if newProducer != nil {
+		assert.Equal(t, originalProducer.options, newProducer.options) // The ProducerOptions Are Retained
+	}
 
 	// Return either the new or original producer for use by the rest of the TestConfigChanged test
 	if expectedNewProducer {
@@ -241,7 +510,7 @@ func TestClose(t *testing.T) {
 	producer.Close()
 
 	// Verify The Results
-	assert.False(t, producer.healthServer.ProducerReady())
+	assert.False(t, producer.options.HealthServer.ProducerReady())
 	assert.True(t, mockSyncProducer.Closed())
 }
 
@@ -255,6 +524,11 @@ func getSaramaConfigFromYaml(t *testing.T, saramaYaml string) *sarama.Config {
 
 // Create A Producer With Specified KafkaProducer For Testing
 func createTestProducer(t *testing.T, kafkaSyncProducer sarama.SyncProducer) *Producer {
+	return createTestProducerWithProvenance(t, kafkaSyncProducer, ProvenanceConfig{})
+}
+
+// Create A Producer With Specified KafkaProducer & ProvenanceConfig For Testing
+func createTestProducerWithProvenance(t *testing.T, kafkaSyncProducer sarama.SyncProducer, provenanceConfig ProvenanceConfig) *Producer {
 
 	testConfig := getSaramaConfigFromYaml(t, TestSaramaConfigYaml)
 
@@ -276,11 +550,23 @@ func createTestProducer(t *testing.T, kafkaSyncProducer sarama.SyncProducer) *Pr
 	statsReporter := metrics.NewStatsReporter(logger)
 
 	// Create The Producer
-	producer, err := NewProducer(logger, testConfig, []string{receivertesting.KafkaBrokers}, statsReporter, healthServer)
+	producer, err := NewProducer(logger, testConfig, ProducerOptions{
+		Brokers:            []string{receivertesting.KafkaBrokers},
+		ProvenanceConfig:   provenanceConfig,
+		PartitionKeyPolicy: constants.DefaultPartitionKeyPolicy,
+		EmptyDataPolicy:    constants.DefaultEmptyDataPolicy,
+		ProduceOrdering:    constants.DefaultProduceOrdering,
+		UnknownTopicConfig: UnknownTopicConfig{Policy: constants.DefaultUnknownTopicPolicy},
+		StatsReporter:      statsReporter,
+		HealthServer:       healthServer,
+	})
 	assert.Nil(t, err)
+	assert.Equal(t, provenanceConfig, producer.options.ProvenanceConfig)
+	assert.Equal(t, constants.DefaultPartitionKeyPolicy, producer.options.PartitionKeyPolicy)
+	assert.Nil(t, producer.options.SchemaRegistryConfig.Client)
 	assert.Equal(t, kafkaSyncProducer, producer.kafkaProducer)
-	assert.Equal(t, healthServer, producer.healthServer)
-	assert.Equal(t, statsReporter, producer.statsReporter)
+	assert.Equal(t, healthServer, producer.options.HealthServer)
+	assert.Equal(t, statsReporter, producer.options.StatsReporter)
 
 	// Return The Producer
 	return producer
@@ -0,0 +1,378 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// File Name Suffixes Of Spooled Messages (Written To A Temporary File & Renamed So That Partial Writes Are Never Replayed)
+const (
+	spoolFileSuffix     = ".msg"
+	spoolTempFileSuffix = ".tmp"
+)
+
+// Error Returned When A Message Cannot Be Spooled Without Exceeding The Spool's Maximum Size
+var errSpoolFull = errors.New("spool is full")
+
+// Configuration For Spooling Events To Local Disk When Producing Them To Kafka Fails
+type SpoolConfig struct {
+	Directory      string        // Directory On A Persistent Volume In Which Messages Are Spooled (Disabled If Empty)
+	MaxBytes       int64         // Maximum Total Size Of The Spooled Messages
+	ReplayInterval time.Duration // Interval Between Attempts To Replay The Spooled Messages
+}
+
+// Create The SpoolConfig For The Specified Receiver Configuration (Validating & Defaulting Unspecified Values)
+func NewSpoolConfig(config commonconfig.EKReceiverSpoolConfig) (SpoolConfig, error) {
+
+	// No Directory - Spooling Disabled
+	if len(config.Directory) <= 0 {
+		return SpoolConfig{}, nil
+	}
+
+	// Validate The Maximum Size (Defaulting If Unspecified)
+	maxBytes := config.MaxBytes
+	if maxBytes < 0 {
+		return SpoolConfig{}, fmt.Errorf("invalid spool max bytes %d - must not be negative", maxBytes)
+	} else if maxBytes == 0 {
+		maxBytes = constants.DefaultSpoolMaxBytes
+	}
+
+	// Default The Replay Interval If Unspecified
+	replayInterval := time.Duration(config.ReplayIntervalMillis) * time.Millisecond
+	if replayInterval <= 0 {
+		replayInterval = constants.DefaultSpoolReplayIntervalMillis * time.Millisecond
+	}
+
+	return SpoolConfig{Directory: config.Directory, MaxBytes: maxBytes, ReplayInterval: replayInterval}, nil
+}
+
+//
+// Kafka Errors Indicating The Brokers Could Not Be Reached (Or Could Not Accept The Message For Now)
+//
+// Messages failing with these errors (after exhausting Sarama's own Producer.Retry attempts) are expected to succeed
+// once the brokers are reachable again, and so are spooled.  Any other failure (e.g. an oversized message or an
+// unknown topic) would fail again on replay, and is returned to the sender as usual.
+//
+var spoolableErrors = []error{
+	sarama.ErrOutOfBrokers,
+	sarama.ErrNotConnected,
+	sarama.ErrBrokerNotAvailable,
+	sarama.ErrLeaderNotAvailable,
+	sarama.ErrNotLeaderForPartition,
+	sarama.ErrRequestTimedOut,
+	sarama.ErrNetworkException,
+	sarama.ErrNotEnoughReplicas,
+	sarama.ErrNotEnoughReplicasAfterAppend,
+}
+
+// Determine Whether The Specified Produce Error Indicates The Kafka Brokers Are (Temporarily) Unavailable
+func IsSpoolableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, spoolableError := range spoolableErrors {
+		if errors.Is(err, spoolableError) {
+			return true
+		}
+	}
+	var netError net.Error
+	return errors.As(err, &netError)
+}
+
+// The Serialized Form Of A Spooled ProducerMessage (After All Framing / Headers Have Been Applied)
+type spooledMessage struct {
+	Topic       string          `json:"topic"`
+	Key         []byte          `json:"key,omitempty"`
+	Value       []byte          `json:"value"` // Null & Zero-Length Values Are Distinct (Tombstone vs Empty Data)
+	Headers     []spooledHeader `json:"headers,omitempty"`
+	Codec       string          `json:"codec,omitempty"`       // KafkaChannel Compression Codec Override (If Any)
+	Guarantee   string          `json:"guarantee,omitempty"`   // KafkaChannel Delivery Guarantee Override (If Any)
+	Idempotence string          `json:"idempotence,omitempty"` // KafkaChannel Producer Idempotence Override (If Any)
+}
+
+type spooledHeader struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Function Returning The SyncProducer For The Specified KafkaChannel Overrides With Which To Replay Spooled Messages
+type replayProducerFunc func(override producerOverride) (sarama.SyncProducer, error)
+
+//
+// Local Disk Spool Of Messages Which Could Not Be Produced To Kafka
+//
+// Each message is written to its own file in the spool directory, named so that the files sort in the order they
+// were spooled, and a background process periodically replays them (oldest first) until one fails, at which point
+// replay is retried after the next interval.  The spool survives restarts of the Receiver (provided the directory
+// is on a persistent volume), and its depth is reported as a metric.
+//
+type spool struct {
+	logger        *zap.Logger
+	config        SpoolConfig
+	statsReporter metrics.StatsReporter
+	auditor       *auditor   // Optional Auditor Of Replayed Messages (Nil If Auditing Is Disabled)
+	lock          sync.Mutex // Guards The Depth, Size & Sequence
+	replayLock    sync.Mutex // Serializes Replay (Without Blocking The Spooling Of New Messages)
+	depth         int64
+	bytes         int64
+	sequence      uint64
+	stopChan      chan struct{}
+	stoppedChan   chan struct{}
+}
+
+// Create A Spool In The Configured Directory (Creating It If Necessary) & Account For Any Previously Spooled Messages
+func newSpool(logger *zap.Logger, config SpoolConfig, statsReporter metrics.StatsReporter) (*spool, error) {
+
+	// Ensure The Spool Directory Exists
+	err := os.MkdirAll(config.Directory, 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create The Spool
+	s := &spool{
+		logger:        logger.With(zap.String("SpoolDirectory", config.Directory)),
+		config:        config,
+		statsReporter: statsReporter,
+		stopChan:      make(chan struct{}),
+		stoppedChan:   make(chan struct{}),
+	}
+
+	// Account For Messages Spooled Before A Restart
+	fileInfos, err := ioutil.ReadDir(config.Directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() && strings.HasSuffix(fileInfo.Name(), spoolFileSuffix) {
+			s.depth++
+			s.bytes += fileInfo.Size()
+		}
+	}
+	if s.depth > 0 {
+		s.logger.Info("Found Previously Spooled Messages", zap.Int64("Depth", s.depth), zap.Int64("Bytes", s.bytes))
+	}
+	s.statsReporter.ReportSpoolDepth(s.depth)
+	return s, nil
+}
+
+// Spool The Specified ProducerMessage & Its KafkaChannel Overrides (Returning errSpoolFull If It Would Exceed The Maximum Size)
+func (s *spool) add(producerMessage *sarama.ProducerMessage, override producerOverride) error {
+
+	// Serialize The Message
+	message := spooledMessage{Topic: producerMessage.Topic, Codec: override.codec, Guarantee: override.guarantee, Idempotence: override.idempotence}
+	var err error
+	if producerMessage.Key != nil {
+		if message.Key, err = producerMessage.Key.Encode(); err != nil {
+			return err
+		}
+	}
+	if producerMessage.Value != nil {
+		if message.Value, err = producerMessage.Value.Encode(); err != nil {
+			return err
+		}
+	}
+	for _, header := range producerMessage.Headers {
+		message.Headers = append(message.Headers, spooledHeader{Key: header.Key, Value: header.Value})
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Enforce The Maximum Size
+	if s.bytes+int64(len(data)) > s.config.MaxBytes {
+		return errSpoolFull
+	}
+
+	// Write The Message To A Temporary File & Rename It Into Place
+	s.sequence++
+	fileName := filepath.Join(s.config.Directory, fmt.Sprintf("%020d-%010d", time.Now().UnixNano(), s.sequence))
+	err = ioutil.WriteFile(fileName+spoolTempFileSuffix, data, 0640)
+	if err == nil {
+		err = os.Rename(fileName+spoolTempFileSuffix, fileName+spoolFileSuffix)
+	}
+	if err != nil {
+		_ = os.Remove(fileName + spoolTempFileSuffix)
+		return err
+	}
+
+	// Update The Depth
+	s.depth++
+	s.bytes += int64(len(data))
+	s.statsReporter.ReportSpoolDepth(s.depth)
+	return nil
+}
+
+// Start Periodically Replaying The Spooled Messages Via The Specified Kafka Producers Until Stopped
+func (s *spool) start(replayProducer replayProducerFunc) {
+	go func() {
+		defer close(s.stoppedChan)
+		ticker := time.NewTicker(s.config.ReplayInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stopChan:
+				return
+			case <-ticker.C:
+				s.replay(replayProducer)
+			}
+		}
+	}()
+}
+
+// Stop Replaying The Spooled Messages (Waiting For Any Replay In Progress To Finish)
+func (s *spool) stop() {
+	close(s.stopChan)
+	<-s.stoppedChan
+}
+
+//
+// Replay The Spooled Messages In Order, Stopping At The First Which Fails Because The Brokers Are Still Unavailable
+//
+// Each message is replayed by the SyncProducer for the KafkaChannel overrides it was spooled with (e.g. its compression
+// codec), so that spooling does not change how the message is produced.
+//
+func (s *spool) replay(replayProducer replayProducerFunc) {
+
+	s.replayLock.Lock()
+	defer s.replayLock.Unlock()
+
+	// Nothing To Do If The Spool Is Empty
+	if s.getDepth() <= 0 {
+		return
+	}
+
+	// Get The Spooled Message Files In Order
+	fileInfos, err := ioutil.ReadDir(s.config.Directory)
+	if err != nil {
+		s.logger.Error("Failed To Read Spool Directory", zap.Error(err))
+		return
+	}
+	fileNames := make([]string, 0, len(fileInfos))
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() && strings.HasSuffix(fileInfo.Name(), spoolFileSuffix) {
+			fileNames = append(fileNames, fileInfo.Name())
+		}
+	}
+	sort.Strings(fileNames)
+
+	// Replay Each Message, Removing It Once Produced (Or If It Can Never Be Produced)
+	replayed := 0
+	for _, fileName := range fileNames {
+		filePath := filepath.Join(s.config.Directory, fileName)
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			s.logger.Error("Failed To Read Spooled Message", zap.String("File", fileName), zap.Error(err))
+			return
+		}
+		message := &spooledMessage{}
+		if err = json.Unmarshal(data, message); err != nil {
+			s.logger.Error("Discarding Corrupt Spooled Message", zap.String("File", fileName), zap.Error(err))
+		} else {
+			producerMessage := message.producerMessage()
+			var kafkaProducer sarama.SyncProducer
+			kafkaProducer, err = replayProducer(message.override())
+			if err != nil {
+				s.logger.Warn("Failed To Get Kafka Producer For Spooled Message - Deferring Spool Replay", zap.Int64("Depth", s.getDepth()), zap.Error(err))
+				break
+			}
+			var partition int32
+			var offset int64
+			partition, offset, err = kafkaProducer.SendMessage(producerMessage)
+			if IsSpoolableError(err) {
+				s.logger.Debug("Kafka Still Unavailable - Deferring Spool Replay", zap.Int64("Depth", s.getDepth()), zap.Error(err))
+				break
+			} else if err != nil {
+				s.logger.Error("Discarding Spooled Message Which Cannot Be Produced", zap.String("Topic", message.Topic), zap.Error(err))
+			} else {
+				s.statsReporter.ReportProducedBytes(message.Topic, kafkautil.ProducerMessageSize(producerMessage))
+				if s.auditor != nil {
+					s.auditor.record(producerMessage, partition, offset)
+				}
+				replayed++
+			}
+		}
+		if err = os.Remove(filePath); err != nil {
+			s.logger.Error("Failed To Remove Spooled Message", zap.String("File", fileName), zap.Error(err))
+			return
+		}
+		s.removed(int64(len(data)))
+	}
+
+	// Log The Progress
+	if replayed > 0 {
+		s.logger.Info("Replayed Spooled Messages", zap.Int("Replayed", replayed), zap.Int64("Depth", s.getDepth()))
+	}
+}
+
+// Account For The Removal Of A Spooled Message Of The Specified Size
+func (s *spool) removed(bytes int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.depth--
+	s.bytes -= bytes
+	s.statsReporter.ReportSpoolDepth(s.depth)
+}
+
+// Get The Number Of Spooled Messages
+func (s *spool) getDepth() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.depth
+}
+
+// Get The KafkaChannel Overrides With Which The Message Was Spooled
+func (m *spooledMessage) override() producerOverride {
+	return producerOverride{codec: m.Codec, guarantee: m.Guarantee, idempotence: m.Idempotence}
+}
+
+// Convert The Spooled Message Back Into A ProducerMessage
+func (m *spooledMessage) producerMessage() *sarama.ProducerMessage {
+	producerMessage := &sarama.ProducerMessage{Topic: m.Topic}
+	if m.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(m.Key)
+	}
+	if m.Value != nil {
+		producerMessage.Value = sarama.ByteEncoder(m.Value)
+	}
+	for _, header := range m.Headers {
+		producerMessage.Headers = append(producerMessage.Headers, sarama.RecordHeader{Key: header.Key, Value: header.Value})
+	}
+	return producerMessage
+}
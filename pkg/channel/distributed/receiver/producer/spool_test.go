@@ -0,0 +1,367 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewSpoolConfig() Functionality
+func TestNewSpoolConfig(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKReceiverSpoolConfig
+		want    SpoolConfig
+		wantErr bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Disabled", config: commonconfig.EKReceiverSpoolConfig{MaxBytes: 100}, want: SpoolConfig{}},
+		{
+			name:   "Defaulted",
+			config: commonconfig.EKReceiverSpoolConfig{Directory: "/spool"},
+			want:   SpoolConfig{Directory: "/spool", MaxBytes: constants.DefaultSpoolMaxBytes, ReplayInterval: constants.DefaultSpoolReplayIntervalMillis * time.Millisecond},
+		},
+		{
+			name:   "Configured",
+			config: commonconfig.EKReceiverSpoolConfig{Directory: "/spool", MaxBytes: 1024, ReplayIntervalMillis: 250},
+			want:   SpoolConfig{Directory: "/spool", MaxBytes: 1024, ReplayInterval: 250 * time.Millisecond},
+		},
+		{name: "Negative MaxBytes", config: commonconfig.EKReceiverSpoolConfig{Directory: "/spool", MaxBytes: -1}, wantErr: true},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			spoolConfig, err := NewSpoolConfig(testCase.config)
+			assert.Equal(t, testCase.want, spoolConfig)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The IsSpoolableError() Functionality
+func TestIsSpoolableError(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name string
+		err  error
+		want bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Nil", err: nil, want: false},
+		{name: "Out Of Brokers", err: sarama.ErrOutOfBrokers, want: true},
+		{name: "Not Enough Replicas", err: sarama.ErrNotEnoughReplicas, want: true},
+		{name: "Request Timed Out", err: sarama.ErrRequestTimedOut, want: true},
+		{name: "Wrapped Leader Not Available", err: fmt.Errorf("produce failed: %w", sarama.ErrLeaderNotAvailable), want: true},
+		{name: "Network Error", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, want: true},
+		{name: "Message Too Large", err: sarama.ErrMessageSizeTooLarge, want: false},
+		{name: "Unknown Topic", err: sarama.ErrUnknownTopicOrPartition, want: false},
+		{name: "Other Error", err: errors.New("other"), want: false},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, IsSpoolableError(testCase.err))
+		})
+	}
+}
+
+// Test That Messages Which Could Not Be Produced Are Spooled (Within The Spool's Maximum Size)
+func TestProduceKafkaMessageSpool(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name      string
+		sendErr   error
+		maxBytes  int64
+		wantErr   bool
+		wantDepth int64
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Brokers Unavailable", sendErr: sarama.ErrOutOfBrokers, maxBytes: constants.DefaultSpoolMaxBytes, wantDepth: 1},
+		{name: "Brokers Unavailable Spool Full", sendErr: sarama.ErrOutOfBrokers, maxBytes: 10, wantErr: true, wantDepth: 0},
+		{name: "Message Too Large", sendErr: sarama.ErrMessageSizeTooLarge, maxBytes: constants.DefaultSpoolMaxBytes, wantErr: true, wantDepth: 0},
+		{name: "Produced", maxBytes: constants.DefaultSpoolMaxBytes, wantDepth: 0},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer With A (Not Started) Spool
+			directory := createSpoolDirectory(t)
+			defer func() { _ = os.RemoveAll(directory) }()
+			syncProducer := &spoolSyncProducer{err: testCase.sendErr}
+			producer := createTestProducer(t, syncProducer)
+			statsReporter := receivertesting.NewMockStatsReporter()
+			spool, err := newSpool(logtesting.TestLogger(t).Desugar(), SpoolConfig{Directory: directory, MaxBytes: testCase.maxBytes, ReplayInterval: time.Hour}, statsReporter)
+			assert.Nil(t, err)
+			producer.spool = spool
+
+			// Perform The Test
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+			err = producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.wantDepth, spool.getDepth())
+			assert.Equal(t, testCase.wantDepth, statsReporter.SpoolDepth())
+			assert.Len(t, spoolFiles(t, directory), int(testCase.wantDepth))
+		})
+	}
+}
+
+// Test That Spooled Messages Are Replayed In Order Once The Brokers Recover (And Survive A Restart Until Then)
+func TestSpoolReplay(t *testing.T) {
+
+	// Create A Spool Whose Messages Cannot Yet Be Produced
+	directory := createSpoolDirectory(t)
+	defer func() { _ = os.RemoveAll(directory) }()
+	logger := logtesting.TestLogger(t).Desugar()
+	config := SpoolConfig{Directory: directory, MaxBytes: constants.DefaultSpoolMaxBytes, ReplayInterval: time.Hour}
+	statsReporter := receivertesting.NewMockStatsReporter()
+	spool, err := newSpool(logger, config, statsReporter)
+	assert.Nil(t, err)
+
+	// Spool Some Messages
+	messages := []*sarama.ProducerMessage{
+		{Topic: receivertesting.TopicName, Key: sarama.StringEncoder("key1"), Value: sarama.StringEncoder("value1"), Headers: []sarama.RecordHeader{{Key: []byte("header"), Value: []byte("1")}}},
+		{Topic: receivertesting.TopicName, Value: sarama.StringEncoder("value2")},
+		{Topic: receivertesting.TopicName, Key: sarama.StringEncoder("key3"), Value: sarama.StringEncoder("value3")},
+	}
+	for _, message := range messages {
+		assert.Nil(t, spool.add(message, producerOverride{}))
+	}
+	assert.Equal(t, int64(3), statsReporter.SpoolDepth())
+
+	// Verify Nothing Is Replayed While The Brokers Remain Unavailable
+	syncProducer := &spoolSyncProducer{err: sarama.ErrOutOfBrokers}
+	spool.replay(syncProducer.replayProducer)
+	assert.Equal(t, int64(3), spool.getDepth())
+	assert.Len(t, syncProducer.sentMessages(), 0)
+
+	// Verify The Spooled Messages Are Accounted For After A Restart
+	statsReporter = receivertesting.NewMockStatsReporter()
+	spool, err = newSpool(logger, config, statsReporter)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), spool.getDepth())
+	assert.Equal(t, int64(3), statsReporter.SpoolDepth())
+
+	// Perform The Test - Replay Once The Brokers Have Recovered
+	syncProducer.recover()
+	spool.replay(syncProducer.replayProducer)
+
+	// Verify The Messages Were Produced In Order & Removed From The Spool
+	assert.Equal(t, int64(0), spool.getDepth())
+	assert.Equal(t, int64(0), statsReporter.SpoolDepth())
+	assert.Len(t, spoolFiles(t, directory), 0)
+	sentMessages := syncProducer.sentMessages()
+	assert.Len(t, sentMessages, len(messages))
+	for index, message := range messages {
+		assert.Equal(t, message.Topic, sentMessages[index].Topic)
+		assert.Equal(t, encode(t, message.Key), encode(t, sentMessages[index].Key))
+		assert.Equal(t, encode(t, message.Value), encode(t, sentMessages[index].Value))
+		assert.Equal(t, message.Headers, sentMessages[index].Headers)
+	}
+	assert.Equal(t, int64(33), statsReporter.ProducedBytes[receivertesting.TopicName])
+}
+
+// Test That Spooled Messages With Null & Zero-Length Values Are Replayed As Such (A Tombstone vs An Event Without Data)
+func TestSpoolReplayEmptyValues(t *testing.T) {
+
+	// Create A Spool
+	directory := createSpoolDirectory(t)
+	defer func() { _ = os.RemoveAll(directory) }()
+	config := SpoolConfig{Directory: directory, MaxBytes: constants.DefaultSpoolMaxBytes, ReplayInterval: time.Hour}
+	spool, err := newSpool(logtesting.TestLogger(t).Desugar(), config, receivertesting.NewMockStatsReporter())
+	assert.Nil(t, err)
+
+	// Spool A Message With A Null Value & One With A Zero-Length Value
+	assert.Nil(t, spool.add(&sarama.ProducerMessage{Topic: receivertesting.TopicName, Key: sarama.StringEncoder("null")}, producerOverride{}))
+	assert.Nil(t, spool.add(&sarama.ProducerMessage{Topic: receivertesting.TopicName, Key: sarama.StringEncoder("empty"), Value: sarama.ByteEncoder{}}, producerOverride{}))
+
+	// Perform The Test
+	syncProducer := &spoolSyncProducer{}
+	spool.replay(syncProducer.replayProducer)
+
+	// Verify The Null Value Remains Null & The Zero-Length Value Remains Non-Null
+	sentMessages := syncProducer.sentMessages()
+	assert.Len(t, sentMessages, 2)
+	assert.Nil(t, sentMessages[0].Value)
+	assert.NotNil(t, sentMessages[1].Value)
+	assert.Equal(t, 0, sentMessages[1].Value.Length())
+}
+
+// Test That Spooled Messages Are Replayed By The SyncProducer For The KafkaChannel Overrides They Were Spooled With
+func TestSpoolReplayOverrides(t *testing.T) {
+
+	// Create A Spool
+	directory := createSpoolDirectory(t)
+	defer func() { _ = os.RemoveAll(directory) }()
+	logger := logtesting.TestLogger(t).Desugar()
+	config := SpoolConfig{Directory: directory, MaxBytes: constants.DefaultSpoolMaxBytes, ReplayInterval: time.Hour}
+	spool, err := newSpool(logger, config, receivertesting.NewMockStatsReporter())
+	assert.Nil(t, err)
+
+	// Spool A Message With Overrides & One Without
+	override := producerOverride{codec: "gzip", guarantee: kafkav1beta1.DeliveryGuaranteeAtLeastOnce, idempotence: "false"}
+	assert.Nil(t, spool.add(&sarama.ProducerMessage{Topic: receivertesting.TopicName, Value: sarama.StringEncoder("overridden")}, override))
+	assert.Nil(t, spool.add(&sarama.ProducerMessage{Topic: receivertesting.TopicName, Value: sarama.StringEncoder("default")}, producerOverride{}))
+
+	// Verify Nothing Is Replayed (Or Discarded) While The Producer For The Overrides Cannot Be Created
+	defaultProducer := &spoolSyncProducer{}
+	overrideProducer := &spoolSyncProducer{}
+	var overrideErr error = sarama.ErrOutOfBrokers
+	replayProducer := func(replayOverride producerOverride) (sarama.SyncProducer, error) {
+		if replayOverride == override {
+			return overrideProducer, overrideErr
+		}
+		return defaultProducer, nil
+	}
+	spool.replay(replayProducer)
+	assert.Equal(t, int64(2), spool.getDepth())
+	assert.Len(t, defaultProducer.sentMessages(), 0)
+
+	// Perform The Test - Replay After A Restart Once The Producer For The Overrides Can Be Created
+	spool, err = newSpool(logger, config, receivertesting.NewMockStatsReporter())
+	assert.Nil(t, err)
+	overrideErr = nil
+	spool.replay(replayProducer)
+
+	// Verify Each Message Was Replayed By The SyncProducer For Its Overrides
+	assert.Equal(t, int64(0), spool.getDepth())
+	assert.Len(t, overrideProducer.sentMessages(), 1)
+	assert.Equal(t, []byte("overridden"), encode(t, overrideProducer.sentMessages()[0].Value))
+	assert.Len(t, defaultProducer.sentMessages(), 1)
+	assert.Equal(t, []byte("default"), encode(t, defaultProducer.sentMessages()[0].Value))
+}
+
+// Test That The Spool Is Replayed In The Background Until Stopped
+func TestSpoolStart(t *testing.T) {
+
+	// Create A Spool With A Short Replay Interval
+	directory := createSpoolDirectory(t)
+	defer func() { _ = os.RemoveAll(directory) }()
+	statsReporter := receivertesting.NewMockStatsReporter()
+	spool, err := newSpool(logtesting.TestLogger(t).Desugar(), SpoolConfig{Directory: directory, MaxBytes: constants.DefaultSpoolMaxBytes, ReplayInterval: 10 * time.Millisecond}, statsReporter)
+	assert.Nil(t, err)
+	assert.Nil(t, spool.add(&sarama.ProducerMessage{Topic: receivertesting.TopicName, Value: sarama.StringEncoder("value")}, producerOverride{}))
+
+	// Perform The Test - Start Replaying While The Brokers Are Unavailable & Then Recover Them
+	syncProducer := &spoolSyncProducer{err: sarama.ErrOutOfBrokers}
+	spool.start(syncProducer.replayProducer)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(1), spool.getDepth())
+	syncProducer.recover()
+
+	// Verify The Message Is Replayed & The Replay Stops
+	assert.Eventually(t, func() bool { return spool.getDepth() == 0 }, 5*time.Second, 10*time.Millisecond)
+	spool.stop()
+	assert.Len(t, syncProducer.sentMessages(), 1)
+}
+
+// Create A Temporary Spool Directory For Testing
+func createSpoolDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "spool")
+	assert.Nil(t, err)
+	return directory
+}
+
+// Get The Names Of The Spooled Message Files In The Specified Directory
+func spoolFiles(t *testing.T, directory string) []string {
+	fileNames, err := filepath.Glob(filepath.Join(directory, "*"+spoolFileSuffix))
+	assert.Nil(t, err)
+	return fileNames
+}
+
+// Encode The Specified (Possibly Nil) Sarama Encoder For Comparison
+func encode(t *testing.T, encoder sarama.Encoder) []byte {
+	if encoder == nil {
+		return nil
+	}
+	data, err := encoder.Encode()
+	assert.Nil(t, err)
+	return data
+}
+
+//
+// Kafka SyncProducer Which Fails With The Specified Error (Until Recovered) & Records The Messages Sent Otherwise
+//
+type spoolSyncProducer struct {
+	sarama.SyncProducer
+	lock     sync.Mutex
+	err      error
+	messages []*sarama.ProducerMessage
+}
+
+func (p *spoolSyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return -1, -1, p.err
+	}
+	p.messages = append(p.messages, message)
+	return 1, int64(len(p.messages)), nil
+}
+
+func (p *spoolSyncProducer) replayProducer(_ producerOverride) (sarama.SyncProducer, error) {
+	return p, nil
+}
+
+func (p *spoolSyncProducer) Close() error {
+	return nil
+}
+
+func (p *spoolSyncProducer) recover() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.err = nil
+}
+
+func (p *spoolSyncProducer) sentMessages() []*sarama.ProducerMessage {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.messages
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+)
+
+// Configuration For Handling Events Produced To A KafkaChannel Whose Kafka Topic Does Not Exist
+type UnknownTopicConfig struct {
+	Policy        string        // One Of The constants.UnknownTopicPolicy* Values
+	RetryTimeout  time.Duration // Maximum Time To Retry Producing With The "retry" Policy
+	RetryInterval time.Duration // Interval Between Attempts With The "retry" Policy
+}
+
+// Create The UnknownTopicConfig For The Specified Receiver Configuration (Validating The Policy & Defaulting Unspecified Values)
+func NewUnknownTopicConfig(config commonconfig.EKReceiverConfig) (UnknownTopicConfig, error) {
+
+	// Validate The Policy (Defaulting If Unspecified)
+	policy := config.UnknownTopicPolicy
+	switch policy {
+	case "":
+		policy = constants.DefaultUnknownTopicPolicy
+	case constants.UnknownTopicPolicyNotFound, constants.UnknownTopicPolicyUnavailable, constants.UnknownTopicPolicyRetry, constants.UnknownTopicPolicyFail:
+	default:
+		return UnknownTopicConfig{}, fmt.Errorf("invalid unknown topic policy '%s' - must be one of '%s', '%s', '%s' or '%s'", policy,
+			constants.UnknownTopicPolicyNotFound, constants.UnknownTopicPolicyUnavailable, constants.UnknownTopicPolicyRetry, constants.UnknownTopicPolicyFail)
+	}
+
+	// Default The Retry Timeout & Interval If Unspecified
+	retryTimeout := time.Duration(config.UnknownTopicRetryTimeoutMillis) * time.Millisecond
+	if retryTimeout <= 0 {
+		retryTimeout = constants.DefaultUnknownTopicRetryTimeoutMillis * time.Millisecond
+	}
+	retryInterval := time.Duration(config.UnknownTopicRetryIntervalMillis) * time.Millisecond
+	if retryInterval <= 0 {
+		retryInterval = constants.DefaultUnknownTopicRetryIntervalMillis * time.Millisecond
+	}
+
+	return UnknownTopicConfig{Policy: policy, RetryTimeout: retryTimeout, RetryInterval: retryInterval}, nil
+}
+
+// Error Returned When Producing To A Kafka Topic Which Does Not Exist (Including The HTTP Status To Respond With)
+type UnknownTopicError struct {
+	Topic      string
+	StatusCode int
+	Err        error
+}
+
+// Describe The Missing Topic (Suggesting A Retry When The Topic Might Still Be Created)
+func (e *UnknownTopicError) Error() string {
+	if e.StatusCode == http.StatusServiceUnavailable {
+		return fmt.Sprintf("kafka topic '%s' does not exist (it may not have been created yet) - please retry later", e.Topic)
+	}
+	return fmt.Sprintf("kafka topic '%s' does not exist", e.Topic)
+}
+
+// Unwrap The Underlying Sarama Error
+func (e *UnknownTopicError) Unwrap() error {
+	return e.Err
+}
+
+//
+// Send The Specified ProducerMessage, Handling A Non-Existent Topic According To The UnknownTopicPolicy
+//
+// Without auto-creation of topics the brokers reject messages for a KafkaChannel whose topic does not (yet) exist
+// with an UnknownTopicOrPartition error.  Rather than the generic 500 (Internal Server Error) response, the error is
+// (unless the policy is "fail") converted into an UnknownTopicError describing the missing topic and the status to
+// respond with, after first retrying for a short while with the "retry" policy in case the topic is being created.
+//
+func (p *Producer) sendMessage(ctx context.Context, logger *zap.Logger, kafkaProducer sarama.SyncProducer, producerMessage *sarama.ProducerMessage) (int32, int64, error) {
+
+	// Send The Message & Return The Result Of Anything Other Than An Unknown Topic
+	partition, offset, err := kafkaProducer.SendMessage(producerMessage)
+	if !errors.Is(err, sarama.ErrUnknownTopicOrPartition) || p.options.UnknownTopicConfig.Policy == constants.UnknownTopicPolicyFail {
+		return partition, offset, err
+	}
+
+	// Retry Until The Topic Exists, The Retry Timeout Elapses, Or The Request Is Cancelled
+	statusCode := http.StatusNotFound
+	if p.options.UnknownTopicConfig.Policy == constants.UnknownTopicPolicyRetry {
+		statusCode = http.StatusServiceUnavailable
+		timeout := time.NewTimer(p.options.UnknownTopicConfig.RetryTimeout)
+		defer timeout.Stop()
+		ticker := time.NewTicker(p.options.UnknownTopicConfig.RetryInterval)
+		defer ticker.Stop()
+		for errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+			select {
+			case <-ctx.Done():
+				return partition, offset, &UnknownTopicError{Topic: producerMessage.Topic, StatusCode: statusCode, Err: err}
+			case <-timeout.C:
+				return partition, offset, &UnknownTopicError{Topic: producerMessage.Topic, StatusCode: statusCode, Err: err}
+			case <-ticker.C:
+				logger.Debug("Retrying Message For Unknown Kafka Topic")
+				partition, offset, err = kafkaProducer.SendMessage(producerMessage)
+			}
+		}
+		return partition, offset, err
+	} else if p.options.UnknownTopicConfig.Policy == constants.UnknownTopicPolicyUnavailable {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	return partition, offset, &UnknownTopicError{Topic: producerMessage.Topic, StatusCode: statusCode, Err: err}
+}
+
+// Context Key For The UnknownTopicError (If Any) Encountered While Handling A Request
+type unknownTopicErrorKey struct{}
+
+// Record The Specified Error Against The Request Context (If It Is An UnknownTopicError & The Context Is From An UnknownTopicHandler)
+func recordUnknownTopicError(ctx context.Context, err error) {
+	var unknownTopicError *UnknownTopicError
+	if holder, ok := ctx.Value(unknownTopicErrorKey{}).(**UnknownTopicError); ok && errors.As(err, &unknownTopicError) {
+		*holder = unknownTopicError
+	}
+}
+
+//
+// HTTP Handler Which Responds To Events For Non-Existent Kafka Topics As Described By The UnknownTopicError
+//
+// The Knative Eventing MessageReceiver only distinguishes unknown channels (404 with no body) from all other errors
+// (500 with no body), so the Producer records any UnknownTopicError against the request's context, and this
+// handler replaces the MessageReceiver's error response with the UnknownTopicError's status and description.
+//
+type UnknownTopicHandler struct {
+	logger *zap.Logger
+	next   http.Handler
+}
+
+// Verify The UnknownTopicHandler Implements The http.Handler Interface
+var _ http.Handler = &UnknownTopicHandler{}
+
+// UnknownTopicHandler Constructor
+func NewUnknownTopicHandler(logger *zap.Logger, next http.Handler) *UnknownTopicHandler {
+	return &UnknownTopicHandler{logger: logger, next: next}
+}
+
+// Delegate To The Next Handler, Replacing Its Error Response If An UnknownTopicError Was Recorded
+func (h *UnknownTopicHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	var unknownTopicError *UnknownTopicError
+	ctx := context.WithValue(request.Context(), unknownTopicErrorKey{}, &unknownTopicError)
+	h.next.ServeHTTP(&unknownTopicResponseWriter{ResponseWriter: response, logger: h.logger, unknownTopicError: &unknownTopicError}, request.WithContext(ctx))
+}
+
+// ResponseWriter Which Replaces Error Responses With The Recorded UnknownTopicError (If Any)
+type unknownTopicResponseWriter struct {
+	http.ResponseWriter
+	logger            *zap.Logger
+	unknownTopicError **UnknownTopicError
+	replaced          bool
+}
+
+// Write The Status Code, Or The UnknownTopicError's Status & Description Instead Of An Error Status
+func (w *unknownTopicResponseWriter) WriteHeader(statusCode int) {
+	unknownTopicError := *w.unknownTopicError
+	if unknownTopicError != nil && statusCode >= http.StatusBadRequest {
+		w.logger.Warn("Rejecting Event For Unknown Kafka Topic", zap.String("Topic", unknownTopicError.Topic), zap.Int("StatusCode", unknownTopicError.StatusCode))
+		w.replaced = true
+		http.Error(w.ResponseWriter, unknownTopicError.Error(), unknownTopicError.StatusCode)
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write The Body (Discarded If The Response Was Replaced)
+func (w *unknownTopicResponseWriter) Write(body []byte) (int, error) {
+	if w.replaced {
+		return len(body), nil
+	}
+	return w.ResponseWriter.Write(body)
+}
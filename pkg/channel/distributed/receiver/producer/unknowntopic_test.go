@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test The NewUnknownTopicConfig() Functionality
+func TestNewUnknownTopicConfig(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name    string
+		config  commonconfig.EKReceiverConfig
+		want    UnknownTopicConfig
+		wantErr bool
+	}
+
+	// Test Data
+	defaultRetryTimeout := constants.DefaultUnknownTopicRetryTimeoutMillis * time.Millisecond
+	defaultRetryInterval := constants.DefaultUnknownTopicRetryIntervalMillis * time.Millisecond
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name:   "Unspecified",
+			config: commonconfig.EKReceiverConfig{},
+			want:   UnknownTopicConfig{Policy: constants.DefaultUnknownTopicPolicy, RetryTimeout: defaultRetryTimeout, RetryInterval: defaultRetryInterval},
+		},
+		{
+			name:   "Not Found",
+			config: commonconfig.EKReceiverConfig{UnknownTopicPolicy: constants.UnknownTopicPolicyNotFound},
+			want:   UnknownTopicConfig{Policy: constants.UnknownTopicPolicyNotFound, RetryTimeout: defaultRetryTimeout, RetryInterval: defaultRetryInterval},
+		},
+		{
+			name:   "Unavailable",
+			config: commonconfig.EKReceiverConfig{UnknownTopicPolicy: constants.UnknownTopicPolicyUnavailable},
+			want:   UnknownTopicConfig{Policy: constants.UnknownTopicPolicyUnavailable, RetryTimeout: defaultRetryTimeout, RetryInterval: defaultRetryInterval},
+		},
+		{
+			name:   "Retry With Custom Timeout & Interval",
+			config: commonconfig.EKReceiverConfig{UnknownTopicPolicy: constants.UnknownTopicPolicyRetry, UnknownTopicRetryTimeoutMillis: 2000, UnknownTopicRetryIntervalMillis: 100},
+			want:   UnknownTopicConfig{Policy: constants.UnknownTopicPolicyRetry, RetryTimeout: 2 * time.Second, RetryInterval: 100 * time.Millisecond},
+		},
+		{
+			name:   "Fail",
+			config: commonconfig.EKReceiverConfig{UnknownTopicPolicy: constants.UnknownTopicPolicyFail},
+			want:   UnknownTopicConfig{Policy: constants.UnknownTopicPolicyFail, RetryTimeout: defaultRetryTimeout, RetryInterval: defaultRetryInterval},
+		},
+		{
+			name:    "Invalid",
+			config:  commonconfig.EKReceiverConfig{UnknownTopicPolicy: "random"},
+			wantErr: true,
+		},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			unknownTopicConfig, err := NewUnknownTopicConfig(testCase.config)
+			assert.Equal(t, testCase.want, unknownTopicConfig)
+			assert.Equal(t, testCase.wantErr, err != nil)
+		})
+	}
+}
+
+// Test The Response To Events Produced To A Non-Existent Kafka Topic For Each UnknownTopicPolicy
+func TestUnknownTopicHandler(t *testing.T) {
+
+	// Define The TestCase Type
+	type TestCase struct {
+		name       string
+		policy     string
+		failures   int // Number Of Sends Which Fail With An Unknown Topic Before The Topic Exists
+		wantStatus int
+		wantBody   string
+		wantSends  int
+	}
+
+	// Test Data
+	notFoundBody := "kafka topic '" + receivertesting.TopicName + "' does not exist\n"
+	unavailableBody := "kafka topic '" + receivertesting.TopicName + "' does not exist (it may not have been created yet) - please retry later\n"
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Topic Exists", policy: constants.UnknownTopicPolicyNotFound, failures: 0, wantStatus: http.StatusAccepted, wantSends: 1},
+		{name: "Not Found", policy: constants.UnknownTopicPolicyNotFound, failures: 100, wantStatus: http.StatusNotFound, wantBody: notFoundBody, wantSends: 1},
+		{name: "Unavailable", policy: constants.UnknownTopicPolicyUnavailable, failures: 100, wantStatus: http.StatusServiceUnavailable, wantBody: unavailableBody, wantSends: 1},
+		{name: "Retry Until Topic Created", policy: constants.UnknownTopicPolicyRetry, failures: 2, wantStatus: http.StatusAccepted, wantSends: 3},
+		{name: "Retry Until Timeout", policy: constants.UnknownTopicPolicyRetry, failures: 100, wantStatus: http.StatusServiceUnavailable, wantBody: unavailableBody},
+		{name: "Fail", policy: constants.UnknownTopicPolicyFail, failures: 100, wantStatus: http.StatusInternalServerError, wantSends: 1},
+	}
+
+	// Execute The Individual Test Cases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Producer Whose Kafka Producer Reports The Topic As Unknown For The Specified Number Of Sends
+			syncProducer := &unknownTopicSyncProducer{failures: testCase.failures}
+			producer := createTestProducer(t, syncProducer)
+			producer.options.UnknownTopicConfig = UnknownTopicConfig{Policy: testCase.policy, RetryTimeout: 200 * time.Millisecond, RetryInterval: 10 * time.Millisecond}
+
+			// Create The UnknownTopicHandler Wrapping A Handler Which Responds As The Knative MessageReceiver Would
+			channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+			next := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				err := producer.ProduceKafkaMessage(request.Context(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+				if err != nil {
+					response.WriteHeader(http.StatusInternalServerError)
+				} else {
+					response.WriteHeader(http.StatusAccepted)
+				}
+			})
+			handler := NewUnknownTopicHandler(logtesting.TestLogger(t).Desugar(), next)
+
+			// Perform The Test
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "http://"+receivertesting.ChannelName+"/", nil))
+
+			// Verify The Results
+			assert.Equal(t, testCase.wantStatus, response.Code)
+			assert.Equal(t, testCase.wantBody, response.Body.String())
+			if testCase.wantSends > 0 {
+				assert.Equal(t, testCase.wantSends, syncProducer.sendCount())
+			} else {
+				assert.Greater(t, syncProducer.sendCount(), 2) // Retried Repeatedly Until The Timeout
+			}
+		})
+	}
+}
+
+// Test That Retrying An Unknown Topic Stops When The Request Is Cancelled
+func TestProduceKafkaMessageUnknownTopicCancelled(t *testing.T) {
+
+	// Create A Producer Retrying For Longer Than The Test Should Take
+	syncProducer := &unknownTopicSyncProducer{failures: 1000}
+	producer := createTestProducer(t, syncProducer)
+	producer.options.UnknownTopicConfig = UnknownTopicConfig{Policy: constants.UnknownTopicPolicyRetry, RetryTimeout: time.Minute, RetryInterval: 10 * time.Millisecond}
+
+	// Perform The Test With A Context Cancelled Shortly After Starting
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	err := producer.ProduceKafkaMessage(ctx, channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1))
+
+	// Verify The UnknownTopicError Was Returned Without Waiting For The Retry Timeout
+	unknownTopicError, ok := err.(*UnknownTopicError)
+	assert.True(t, ok)
+	assert.Equal(t, receivertesting.TopicName, unknownTopicError.Topic)
+	assert.Equal(t, http.StatusServiceUnavailable, unknownTopicError.StatusCode)
+	assert.Equal(t, sarama.ErrUnknownTopicOrPartition, unknownTopicError.Unwrap())
+}
+
+//
+// Kafka SyncProducer Which Reports The Topic As Unknown For A Number Of Sends Before Succeeding
+//
+type unknownTopicSyncProducer struct {
+	sarama.SyncProducer
+	lock     sync.Mutex
+	failures int
+	sends    int
+}
+
+func (p *unknownTopicSyncProducer) SendMessage(_ *sarama.ProducerMessage) (int32, int64, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.sends++
+	if p.sends <= p.failures {
+		return -1, -1, sarama.ErrUnknownTopicOrPartition
+	}
+	return 1, int64(p.sends), nil
+}
+
+func (p *unknownTopicSyncProducer) sendCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.sends
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	kafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
+	eventingchannel "knative.dev/eventing/pkg/channel"
+)
+
+// Number Of Tracked KafkaChannels Above Which Idle Rate Limiters Are Discarded
+const pruneThreshold = 1000
+
+// Function Reference Variable To Facilitate Mocking The Current Time In Unit Tests
+var now = time.Now
+
+// The Per-Channel Rate Limits (Zero Meaning Unlimited)
+type Limits struct {
+	RequestsPerSecond float64
+	BytesPerSecond    int64
+}
+
+// Validate The Specified RateLimit Configuration & Return The Limits
+func NewLimits(config commonconfig.EKReceiverRateLimitConfig) (Limits, error) {
+	if config.RequestsPerSecond < 0 {
+		return Limits{}, fmt.Errorf("invalid rate limit requestsPerSecond %v - must not be negative", config.RequestsPerSecond)
+	}
+	if config.BytesPerSecond < 0 {
+		return Limits{}, fmt.Errorf("invalid rate limit bytesPerSecond %d - must not be negative", config.BytesPerSecond)
+	}
+	return Limits{RequestsPerSecond: config.RequestsPerSecond, BytesPerSecond: config.BytesPerSecond}, nil
+}
+
+// Determine Whether Any Limit Is Configured
+func (l Limits) Enabled() bool {
+	return l.RequestsPerSecond > 0 || l.BytesPerSecond > 0
+}
+
+//
+// HTTP Handler Which Enforces Per-KafkaChannel Rate Limits Before Delegating To The Next Handler
+//
+// Each KafkaChannel (identified by the request Host) has a token bucket for requests and another for bytes, each
+// refilled at the configured rate per second and holding up to one second's worth of tokens (so short bursts are
+// absorbed).  Requests exceeding either limit are rejected with a 429 (Too Many Requests) and a Retry-After header
+// indicating when the bucket will have refilled sufficiently, and are counted in the rate limit rejection metric.
+// A single request larger than one second's worth of bytes is admitted once the bytes bucket is full (and empties
+// it), rather than being rejected forever.  The limits may be changed at any time via SetLimits(), which resets the
+// buckets of every KafkaChannel.  Requests for which no KafkaChannel can be determined are passed through unchecked
+// for the next handler to reject as usual.
+//
+type Handler struct {
+	logger        *zap.Logger
+	statsReporter metrics.StatsReporter
+	next          http.Handler
+	lock          sync.Mutex
+	limits        Limits
+	channels      map[string]*channelLimiter
+}
+
+// Verify The Handler Implements The http.Handler Interface
+var _ http.Handler = &Handler{}
+
+// The Request & Byte Token Buckets Of A Single KafkaChannel
+type channelLimiter struct {
+	requests *rate.Limiter
+	bytes    *rate.Limiter
+	lastUsed time.Time
+}
+
+// Handler Constructor
+func NewHandler(logger *zap.Logger, limits Limits, statsReporter metrics.StatsReporter, next http.Handler) *Handler {
+	return &Handler{logger: logger, statsReporter: statsReporter, next: next, limits: limits, channels: make(map[string]*channelLimiter)}
+}
+
+// Replace The Rate Limits (Resetting The Token Buckets Of All KafkaChannels) If They Have Changed
+func (h *Handler) SetLimits(limits Limits) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if limits == h.limits {
+		return
+	}
+	h.logger.Info("Rate Limits Changed", zap.Float64("RequestsPerSecond", limits.RequestsPerSecond), zap.Int64("BytesPerSecond", limits.BytesPerSecond))
+	h.limits = limits
+	h.channels = make(map[string]*channelLimiter)
+}
+
+// Reject Requests Exceeding The KafkaChannel's Rate Limits Or Otherwise Delegate To The Next Handler
+func (h *Handler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+
+	// Reserve The Request (& Its Bytes) From The KafkaChannel's Token Buckets
+	topicName, delay, reason := h.reserve(request)
+
+	// Reject The Request If Either Bucket Has Insufficient Tokens
+	if delay > 0 {
+		retryAfter := int(math.Ceil(delay.Seconds()))
+		h.logger.Warn("Rejecting Event Exceeding Rate Limit", zap.String("Topic", topicName), zap.String("Reason", reason), zap.Duration("Delay", delay))
+		h.statsReporter.ReportRateLimitRejection(topicName, reason)
+		response.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		http.Error(response, fmt.Sprintf("rate limit of %s per second exceeded - retry after %d seconds", h.limitDescription(reason), retryAfter), http.StatusTooManyRequests)
+		return
+	}
+
+	// Delegate All Other Requests To The Next Handler
+	h.next.ServeHTTP(response, request)
+}
+
+// Reserve The Request From Its KafkaChannel's Buckets, Returning The Delay Until It Would Be Allowed (Zero If Allowed Now)
+func (h *Handler) reserve(request *http.Request) (string, time.Duration, string) {
+
+	// Requests Are Only Limited If Limits Are Configured And The KafkaChannel Can Be Determined
+	h.lock.Lock()
+	limits := h.limits
+	h.lock.Unlock()
+	if !limits.Enabled() {
+		return "", 0, ""
+	}
+	topicName := h.topicName(request.Host)
+	if len(topicName) <= 0 {
+		return "", 0, ""
+	}
+
+	// Determine The Request Size (Prior To Locking, As The Body May Need To Be Read)
+	size := 0
+	if limits.BytesPerSecond > 0 {
+		size = requestSize(request)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// Get (Or Create) The KafkaChannel's Token Buckets
+	timestamp := now()
+	limiter := h.channels[topicName]
+	if limiter == nil {
+		if len(h.channels) >= pruneThreshold {
+			h.prune(timestamp)
+		}
+		limiter = &channelLimiter{requests: newLimiter(h.limits.RequestsPerSecond), bytes: newLimiter(float64(h.limits.BytesPerSecond))}
+		h.channels[topicName] = limiter
+	}
+	limiter.lastUsed = timestamp
+
+	// Reserve A Request Token
+	requestReservation := limiter.requests.ReserveN(timestamp, 1)
+	if delay := requestReservation.DelayFrom(timestamp); delay > 0 {
+		requestReservation.CancelAt(timestamp)
+		return topicName, delay, metrics.RateLimitReasonRequests
+	}
+
+	// Reserve The Request's Bytes (Capped At The Bucket Size So That Large Requests Are Eventually Admitted)
+	if size > 0 {
+		if limiter.bytes.Limit() != rate.Inf && size > limiter.bytes.Burst() {
+			size = limiter.bytes.Burst()
+		}
+		bytesReservation := limiter.bytes.ReserveN(timestamp, size)
+		if delay := bytesReservation.DelayFrom(timestamp); delay > 0 {
+			bytesReservation.CancelAt(timestamp)
+			requestReservation.CancelAt(timestamp)
+			return topicName, delay, metrics.RateLimitReasonBytes
+		}
+	}
+
+	// The Request Is Allowed
+	return topicName, 0, ""
+}
+
+// Discard The Token Buckets Of KafkaChannels Idle Long Enough For Them To Have Refilled (Equivalent To New Buckets)
+func (h *Handler) prune(timestamp time.Time) {
+	for topicName, limiter := range h.channels {
+		idle := timestamp.Sub(limiter.lastUsed)
+		if refilled(limiter.requests, idle) && refilled(limiter.bytes, idle) {
+			delete(h.channels, topicName)
+		}
+	}
+}
+
+// Describe The Limit Corresponding To The Specified Rejection Reason
+func (h *Handler) limitDescription(reason string) string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if reason == metrics.RateLimitReasonBytes {
+		return fmt.Sprintf("%d bytes", h.limits.BytesPerSecond)
+	}
+	return fmt.Sprintf("%v requests", h.limits.RequestsPerSecond)
+}
+
+// Get The Kafka Topic Name For The KafkaChannel Identified By The Request Host (Empty If Unknown)
+func (h *Handler) topicName(host string) string {
+	channelReference, err := eventingchannel.ParseChannel(host)
+	if err != nil {
+		return ""
+	}
+	channelReference.Name = kafkautil.TrimKafkaChannelServiceNameSuffix(channelReference.Name)
+	return util.TopicName(channelReference)
+}
+
+// Create A Token Bucket Refilled At The Specified Rate Per Second & Holding One Second's Worth (Unlimited If Zero)
+func newLimiter(perSecond float64) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), int(math.Max(1, math.Ceil(perSecond))))
+}
+
+// Determine Whether The Specified Token Bucket Has Completely Refilled After Being Idle For The Specified Duration
+func refilled(limiter *rate.Limiter, idle time.Duration) bool {
+	return limiter.Limit() == rate.Inf || idle.Seconds()*float64(limiter.Limit()) >= float64(limiter.Burst())
+}
+
+// Get The Size Of The Specified Request's Body (Buffering Bodies Of Unknown Length For The Next Handler)
+func requestSize(request *http.Request) int {
+	if request.ContentLength >= 0 || request.Body == nil {
+		return int(request.ContentLength)
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	_ = request.Body.Close()
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0
+	}
+	return len(body)
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	commonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
+	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+// Test Data
+const (
+	testHost      = receivertesting.ChannelName + "-kn-channel." + receivertesting.ChannelNamespace + ".svc.cluster.local"
+	testOtherHost = "other-channel-kn-channel." + receivertesting.ChannelNamespace + ".svc.cluster.local"
+)
+
+// Test The NewLimits() Functionality
+func TestNewLimits(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name        string
+		config      commonconfig.EKReceiverRateLimitConfig
+		want        Limits
+		wantEnabled bool
+		wantErr     bool
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{name: "Unconfigured"},
+		{name: "Requests", config: commonconfig.EKReceiverRateLimitConfig{RequestsPerSecond: 0.5}, want: Limits{RequestsPerSecond: 0.5}, wantEnabled: true},
+		{name: "Bytes", config: commonconfig.EKReceiverRateLimitConfig{BytesPerSecond: 1024}, want: Limits{BytesPerSecond: 1024}, wantEnabled: true},
+		{name: "Both", config: commonconfig.EKReceiverRateLimitConfig{RequestsPerSecond: 100, BytesPerSecond: 1024}, want: Limits{RequestsPerSecond: 100, BytesPerSecond: 1024}, wantEnabled: true},
+		{name: "Negative Requests", config: commonconfig.EKReceiverRateLimitConfig{RequestsPerSecond: -1}, wantErr: true},
+		{name: "Negative Bytes", config: commonconfig.EKReceiverRateLimitConfig{BytesPerSecond: -1}, wantErr: true},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			limits, err := NewLimits(testCase.config)
+			assert.Equal(t, testCase.wantErr, err != nil)
+			assert.Equal(t, testCase.want, limits)
+			assert.Equal(t, testCase.wantEnabled, limits.Enabled())
+		})
+	}
+}
+
+// Test The NewHandler() Functionality
+func TestNewHandler(t *testing.T) {
+	logger := logtesting.TestLogger(t).Desugar()
+	limits := Limits{RequestsPerSecond: 10}
+	statsReporter := receivertesting.NewMockStatsReporter()
+	next := http.NotFoundHandler()
+	handler := NewHandler(logger, limits, statsReporter, next)
+	assert.NotNil(t, handler)
+	assert.Equal(t, logger, handler.logger)
+	assert.Equal(t, limits, handler.limits)
+	assert.Equal(t, statsReporter, handler.statsReporter)
+	assert.NotNil(t, handler.next)
+	assert.NotNil(t, handler.channels)
+}
+
+// A Single Request Sent To The Handler During A Test
+type testRequest struct {
+	host           string
+	body           string
+	chunked        bool          // Send The Body Without A Content-Length
+	offset         time.Duration // Time Since The Start Of The Test
+	wantStatus     int
+	wantRetryAfter string
+}
+
+// Test The Handler's ServeHTTP() Functionality
+func TestServeHTTP(t *testing.T) {
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name           string
+		limits         Limits
+		requests       []testRequest
+		wantRejections map[string]int
+	}
+
+	// Define The TestCases
+	testCases := []TestCase{
+		{
+			name: "Unlimited",
+			requests: []testRequest{
+				{host: testHost, body: strings.Repeat("x", 1000), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 1000), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 1000), wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{},
+		},
+		{
+			name:   "Requests Within Limit",
+			limits: Limits{RequestsPerSecond: 2},
+			requests: []testRequest{
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, offset: 500 * time.Millisecond, wantStatus: http.StatusAccepted},
+				{host: testHost, offset: time.Second, wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{},
+		},
+		{
+			name:   "Requests Exceeding Limit",
+			limits: Limits{RequestsPerSecond: 2},
+			requests: []testRequest{
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testHost, offset: 400 * time.Millisecond, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testHost, offset: 500 * time.Millisecond, wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonRequests: 2},
+		},
+		{
+			name:   "Fractional Requests Limit",
+			limits: Limits{RequestsPerSecond: 0.2},
+			requests: []testRequest{
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, offset: time.Second, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "4"},
+				{host: testHost, offset: 5 * time.Second, wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonRequests: 1},
+		},
+		{
+			name:   "Channels Limited Independently",
+			limits: Limits{RequestsPerSecond: 1},
+			requests: []testRequest{
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testOtherHost, wantStatus: http.StatusAccepted},
+				{host: testHost, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testOtherHost, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonRequests: 2},
+		},
+		{
+			name:   "Unknown Channel Not Limited",
+			limits: Limits{RequestsPerSecond: 1},
+			requests: []testRequest{
+				{host: "localhost", wantStatus: http.StatusAccepted},
+				{host: "localhost", wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{},
+		},
+		{
+			name:   "Bytes Exceeding Limit",
+			limits: Limits{BytesPerSecond: 100},
+			requests: []testRequest{
+				{host: testHost, body: strings.Repeat("x", 60), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 60), wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testHost, body: strings.Repeat("x", 40), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 60), offset: 600 * time.Millisecond, wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonBytes: 1},
+		},
+		{
+			name:   "Chunked Bytes Exceeding Limit",
+			limits: Limits{BytesPerSecond: 100},
+			requests: []testRequest{
+				{host: testHost, body: strings.Repeat("x", 60), chunked: true, wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 60), chunked: true, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonBytes: 1},
+		},
+		{
+			name:   "Request Larger Than Bytes Limit",
+			limits: Limits{BytesPerSecond: 100},
+			requests: []testRequest{
+				{host: testHost, body: strings.Repeat("x", 500), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 500), offset: 500 * time.Millisecond, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testHost, body: strings.Repeat("x", 500), offset: time.Second, wantStatus: http.StatusAccepted},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonBytes: 1},
+		},
+		{
+			name:   "Bytes Rejection Does Not Consume Request Tokens",
+			limits: Limits{RequestsPerSecond: 2, BytesPerSecond: 100},
+			requests: []testRequest{
+				{host: testHost, body: strings.Repeat("x", 100), wantStatus: http.StatusAccepted},
+				{host: testHost, body: strings.Repeat("x", 100), wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+				{host: testHost, wantStatus: http.StatusAccepted},
+				{host: testHost, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+			},
+			wantRejections: map[string]int{metrics.RateLimitReasonRequests: 1, metrics.RateLimitReasonBytes: 1},
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Mock The Current Time
+			start := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+			mockNow := start
+			now = func() time.Time { return mockNow }
+			t.Cleanup(func() { now = time.Now })
+
+			// Create The Handler To Test
+			statsReporter := receivertesting.NewMockStatsReporter()
+			handler := NewHandler(logtesting.TestLogger(t).Desugar(), testCase.limits, statsReporter, newTestNextHandler(t))
+
+			// Perform The Test & Verify Each Response
+			for index, testRequest := range testCase.requests {
+				mockNow = start.Add(testRequest.offset)
+				response := serveTestRequest(handler, testRequest)
+				assert.Equal(t, testRequest.wantStatus, response.Code, "request %d", index)
+				assert.Equal(t, testRequest.wantRetryAfter, response.Header().Get("Retry-After"), "request %d", index)
+				if testRequest.wantStatus == http.StatusTooManyRequests {
+					assert.Contains(t, response.Body.String(), "rate limit of")
+				} else {
+					assert.Equal(t, testRequest.body, response.Body.String(), "request %d", index)
+				}
+			}
+
+			// Verify The Reported Rejections
+			assert.Equal(t, testCase.wantRejections, statsReporter.RateLimitRejections)
+		})
+	}
+}
+
+// Test Changing The Limits Of A Running Handler (As On A ConfigMap Reload)
+func TestSetLimits(t *testing.T) {
+
+	// Mock The Current Time
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Create An Initially Unlimited Handler
+	statsReporter := receivertesting.NewMockStatsReporter()
+	handler := NewHandler(logtesting.TestLogger(t).Desugar(), Limits{}, statsReporter, newTestNextHandler(t))
+	request := testRequest{host: testHost}
+
+	// Verify Requests Are Not Limited
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+
+	// Enable A Limit & Verify It Is Enforced
+	handler.SetLimits(Limits{RequestsPerSecond: 1})
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveTestRequest(handler, request).Code)
+
+	// Verify Setting The Same Limit Does Not Reset The Token Buckets
+	handler.SetLimits(Limits{RequestsPerSecond: 1})
+	assert.Equal(t, http.StatusTooManyRequests, serveTestRequest(handler, request).Code)
+
+	// Raise The Limit & Verify The Token Buckets Are Reset To The New Limit
+	handler.SetLimits(Limits{RequestsPerSecond: 2})
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveTestRequest(handler, request).Code)
+
+	// Disable The Limit & Verify Requests Are No Longer Limited
+	handler.SetLimits(Limits{})
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, http.StatusAccepted, serveTestRequest(handler, request).Code)
+	assert.Equal(t, map[string]int{metrics.RateLimitReasonRequests: 3}, statsReporter.RateLimitRejections)
+}
+
+// Test That Only The Token Buckets Of Idle KafkaChannels Are Discarded
+func TestPrune(t *testing.T) {
+
+	// Mock The Current Time
+	mockNow := time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return mockNow }
+	t.Cleanup(func() { now = time.Now })
+
+	// Create A Handler & Use The Token Buckets Of Two KafkaChannels At Different Times
+	handler := NewHandler(logtesting.TestLogger(t).Desugar(), Limits{RequestsPerSecond: 0.5, BytesPerSecond: 1000}, receivertesting.NewMockStatsReporter(), newTestNextHandler(t))
+	serveTestRequest(handler, testRequest{host: testHost, body: "idle"})
+	mockNow = mockNow.Add(time.Second)
+	serveTestRequest(handler, testRequest{host: testOtherHost, body: "busy"})
+	assert.Len(t, handler.channels, 2)
+
+	// Verify Neither Is Discarded Before Its Request Bucket Has Refilled (Two Seconds At 0.5 Requests Per Second)
+	handler.prune(mockNow.Add(time.Second - time.Millisecond))
+	assert.Len(t, handler.channels, 2)
+
+	// Verify Only The Idle KafkaChannel Is Discarded Once Its Buckets Have Refilled
+	handler.prune(mockNow.Add(time.Second))
+	assert.Len(t, handler.channels, 1)
+	assert.NotContains(t, handler.channels, receivertesting.TopicName)
+}
+
+// Create A Next Handler Which Accepts Every Request, Echoing Its Body
+func newTestNextHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		body, err := ioutil.ReadAll(request.Body)
+		assert.Nil(t, err)
+		response.WriteHeader(http.StatusAccepted)
+		_, _ = response.Write(body)
+	})
+}
+
+// Send The Specified Test Request To The Handler & Return The Recorded Response
+func serveTestRequest(handler *Handler, testRequest testRequest) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "http://"+testRequest.host+"/", strings.NewReader(testRequest.body))
+	if testRequest.chunked {
+		request.ContentLength = -1
+	}
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	return response
+}
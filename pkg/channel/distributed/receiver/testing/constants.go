@@ -26,6 +26,9 @@ const (
 
 	ChannelName      = "TestChannelName"
 	ChannelNamespace = "TestChannelNamespace"
+	ChannelKey       = "TestChannelNamespace/TestChannelName" // Match ChannelReference String() Implementation For Channel Name/Namespace Above
+
+	PodName = "TestPodName"
 
 	TopicName = "TestChannelNamespace.TestChannelName" // Match util.go TopicName() Implementation For Channel Name/Namespace Above
 
@@ -18,12 +18,15 @@ package testing
 
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 
 	"github.com/Shopify/sarama"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/metrics"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 )
 
@@ -140,3 +143,110 @@ func (m MockKafkaChannelNamespaceLister) Get(name string) (*kafkav1beta1.KafkaCh
 		return nil, k8serrors.NewNotFound(kafkav1beta1.Resource("KafkaChannel"), name)
 	}
 }
+
+//
+// Mock StatsReporter
+//
+
+var _ metrics.StatsReporter = &MockStatsReporter{}
+
+type MockStatsReporter struct {
+	ProducedBytes        map[string]int64 // Total Reported Produced Bytes Keyed By Topic
+	StaleEventRejections map[string]int   // Count Of Reported Stale Event Rejections Keyed By Topic
+	Heartbeats           map[bool]int     // Count Of Reported Heartbeats Keyed By Success
+	spoolDepth           int64            // Last Reported Spool Depth (Reported Asynchronously By The Spool Replay)
+	auditDrops           map[string]int   // Count Of Reported Audit Drops Keyed By Reason (Reported Asynchronously By The Auditor)
+	auditDropsLock       sync.Mutex
+	LatencySloExceeded   map[string]int // Count Of Reported Produce Latency SLO Violations Keyed By Topic
+	RateLimitRejections  map[string]int // Count Of Reported Rate Limit Rejections Keyed By Reason
+}
+
+func NewMockStatsReporter() *MockStatsReporter {
+	return &MockStatsReporter{ProducedBytes: make(map[string]int64), StaleEventRejections: make(map[string]int), Heartbeats: make(map[bool]int), auditDrops: make(map[string]int), LatencySloExceeded: make(map[string]int), RateLimitRejections: make(map[string]int)}
+}
+
+func (m *MockStatsReporter) Report(_ map[string]map[string]interface{}) {
+	// Sarama Metrics Not Currently Verified - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportDeserializationFailure(_ string, _ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportAuthenticationFailure() {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportProducedBytes(topicName string, bytes int64) {
+	m.ProducedBytes[topicName] += bytes
+}
+
+func (m *MockStatsReporter) ReportConsumedBytes(_ string, _ int64) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportDecompressionError(_ string, _ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportStaleEventRejection(topicName string) {
+	m.StaleEventRejections[topicName]++
+}
+
+func (m *MockStatsReporter) ReportOversizedMessage(_ string, _ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportHeartbeat(_ string, success bool) {
+	m.Heartbeats[success]++
+}
+
+func (m *MockStatsReporter) ReportConsumeRetry(_ string, _ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportSpoolDepth(depth int64) {
+	atomic.StoreInt64(&m.spoolDepth, depth)
+}
+
+func (m *MockStatsReporter) ReportReplyFailure(_ string, _ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportAuditDrop(_ string, reasonName string) {
+	m.auditDropsLock.Lock()
+	defer m.auditDropsLock.Unlock()
+	m.auditDrops[reasonName]++
+}
+
+func (m *MockStatsReporter) ReportProduceLatencySloExceeded(topicName string) {
+	m.LatencySloExceeded[topicName]++
+}
+
+func (m *MockStatsReporter) ReportGroupErrorLogDrop(_ string) {
+	// Not Used By The Receiver - No Need To Mock
+}
+
+func (m *MockStatsReporter) ReportRateLimitRejection(_ string, reasonName string) {
+	m.RateLimitRejections[reasonName]++
+}
+
+func (m *MockStatsReporter) ReportConsumerLag(_ string, _ string, _ int64) {
+	panic("implement me")
+}
+
+func (m *MockStatsReporter) ReportDispatch(_ string, _ string, _ string, _ bool) {
+	panic("implement me")
+}
+
+// Get The Last Reported Spool Depth
+func (m *MockStatsReporter) SpoolDepth() int64 {
+	return atomic.LoadInt64(&m.spoolDepth)
+}
+
+// Get The Count Of Reported Audit Drops For The Specified Reason
+func (m *MockStatsReporter) AuditDrops(reasonName string) int {
+	m.auditDropsLock.Lock()
+	defer m.auditDropsLock.Unlock()
+	return m.auditDrops[reasonName]
+}